@@ -15,7 +15,6 @@ import (
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	domainPlugin "github.com/felixgeelhaar/roady/pkg/domain/plugin"
 	infraPlugin "github.com/felixgeelhaar/roady/pkg/plugin"
-	"github.com/hashicorp/go-plugin"
 )
 
 var asanaBaseURL = "https://app.asana.com/api/1.0"
@@ -345,10 +344,5 @@ func shortenGID(gid string) string {
 }
 
 func main() {
-	plugin.Serve(&plugin.ServeConfig{
-		HandshakeConfig: infraPlugin.HandshakeConfig,
-		Plugins: map[string]plugin.Plugin{
-			"syncer": &domainPlugin.SyncerPlugin{Impl: &AsanaSyncer{}},
-		},
-	})
+	infraPlugin.Serve(&AsanaSyncer{})
 }
@@ -12,7 +12,6 @@ import (
 	domainPlugin "github.com/felixgeelhaar/roady/pkg/domain/plugin"
 	infraPlugin "github.com/felixgeelhaar/roady/pkg/plugin"
 	"github.com/google/go-github/v69/github"
-	goplugin "github.com/hashicorp/go-plugin"
 	"golang.org/x/oauth2"
 )
 
@@ -283,10 +282,5 @@ func (s *GitHubSyncer) Push(taskID string, status planning.TaskStatus) error {
 
 func main() {
 	// Plugin serving
-	goplugin.Serve(&goplugin.ServeConfig{
-		HandshakeConfig: infraPlugin.HandshakeConfig,
-		Plugins: map[string]goplugin.Plugin{
-			"syncer": &domainPlugin.SyncerPlugin{Impl: &GitHubSyncer{}},
-		},
-	})
+	infraPlugin.Serve(&GitHubSyncer{})
 }
@@ -13,7 +13,6 @@ import (
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	domainPlugin "github.com/felixgeelhaar/roady/pkg/domain/plugin"
 	infraPlugin "github.com/felixgeelhaar/roady/pkg/plugin"
-	goplugin "github.com/hashicorp/go-plugin"
 )
 
 type JiraSyncer struct {
@@ -261,10 +260,5 @@ func (s *JiraSyncer) Push(taskID string, status planning.TaskStatus) error {
 }
 
 func main() {
-	goplugin.Serve(&goplugin.ServeConfig{
-		HandshakeConfig: infraPlugin.HandshakeConfig,
-		Plugins: map[string]goplugin.Plugin{
-			"syncer": &domainPlugin.SyncerPlugin{Impl: &JiraSyncer{}},
-		},
-	})
+	infraPlugin.Serve(&JiraSyncer{})
 }
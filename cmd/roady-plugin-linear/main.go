@@ -13,7 +13,6 @@ import (
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	domainPlugin "github.com/felixgeelhaar/roady/pkg/domain/plugin"
 	infraPlugin "github.com/felixgeelhaar/roady/pkg/plugin"
-	goplugin "github.com/hashicorp/go-plugin"
 )
 
 type LinearSyncer struct {
@@ -379,10 +378,5 @@ func (s *LinearSyncer) updateIssueState(issueID, stateID string) error {
 }
 
 func main() {
-	goplugin.Serve(&goplugin.ServeConfig{
-		HandshakeConfig: infraPlugin.HandshakeConfig,
-		Plugins: map[string]goplugin.Plugin{
-			"syncer": &domainPlugin.SyncerPlugin{Impl: &LinearSyncer{}},
-		},
-	})
+	infraPlugin.Serve(&LinearSyncer{})
 }
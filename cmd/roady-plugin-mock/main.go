@@ -1,13 +1,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"slices"
+	"time"
 
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	domainPlugin "github.com/felixgeelhaar/roady/pkg/domain/plugin"
 	infraPlugin "github.com/felixgeelhaar/roady/pkg/plugin"
-	goplugin "github.com/hashicorp/go-plugin"
 )
 
 type MockSyncer struct{}
@@ -47,11 +49,46 @@ func (m *MockSyncer) Push(taskID string, status planning.TaskStatus) error {
 	log.Printf("Mock push: task %s -> status %s", taskID, status)
 	return nil
 }
+
+// Subscribe simulates external completion of every in-progress task, then
+// waits for the subscription to be cancelled.
+func (m *MockSyncer) Subscribe(ctx context.Context, plan *planning.Plan, state *planning.ExecutionState, eventTypes []string, handle func(domainPlugin.ExternalEvent) error) error {
+	if plan != nil && state != nil && (len(eventTypes) == 0 || slices.Contains(eventTypes, domainPlugin.EventStatusChanged)) {
+		for _, t := range plan.Tasks {
+			if res, ok := state.TaskStates[t.ID]; !ok || res.Status != planning.StatusInProgress {
+				continue
+			}
+			err := handle(domainPlugin.ExternalEvent{
+				ID:        "mock-" + t.ID,
+				Type:      domainPlugin.EventStatusChanged,
+				TaskID:    t.ID,
+				Status:    planning.StatusDone,
+				Provider:  "mock",
+				Timestamp: time.Now(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	<-ctx.Done()
+	return nil
+}
+
+// PushChanges logs each change and links every created task to a mock
+// issue.
+func (m *MockSyncer) PushChanges(changes []domainPlugin.TaskChange) (*domainPlugin.SyncResult, error) {
+	links := make(map[string]planning.ExternalRef)
+	for _, c := range changes {
+		log.Printf("Mock change: %s task %s %v", c.Kind, c.TaskID, c.Fields())
+		if c.Kind == domainPlugin.ChangeCreate {
+			id := "MOCK-" + c.TaskID
+			links[c.TaskID] = planning.ExternalRef{ID: id, Identifier: id, LastSyncedAt: time.Now()}
+		}
+	}
+	return &domainPlugin.SyncResult{LinkUpdates: links}, nil
+}
+
 func main() {
-	goplugin.Serve(&goplugin.ServeConfig{
-		HandshakeConfig: infraPlugin.HandshakeConfig,
-		Plugins: map[string]goplugin.Plugin{
-			"syncer": &domainPlugin.SyncerPlugin{Impl: &MockSyncer{}},
-		},
-	})
+	infraPlugin.Serve(&MockSyncer{})
 }
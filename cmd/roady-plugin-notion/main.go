@@ -15,7 +15,6 @@ import (
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	domainPlugin "github.com/felixgeelhaar/roady/pkg/domain/plugin"
 	infraPlugin "github.com/felixgeelhaar/roady/pkg/plugin"
-	"github.com/hashicorp/go-plugin"
 )
 
 const notionAPIVersion = "2022-06-28"
@@ -415,10 +414,5 @@ func shortenID(id string) string {
 }
 
 func main() {
-	plugin.Serve(&plugin.ServeConfig{
-		HandshakeConfig: infraPlugin.HandshakeConfig,
-		Plugins: map[string]plugin.Plugin{
-			"syncer": &domainPlugin.SyncerPlugin{Impl: &NotionSyncer{}},
-		},
-	})
+	infraPlugin.Serve(&NotionSyncer{})
 }
@@ -15,7 +15,6 @@ import (
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	domainPlugin "github.com/felixgeelhaar/roady/pkg/domain/plugin"
 	infraPlugin "github.com/felixgeelhaar/roady/pkg/plugin"
-	"github.com/hashicorp/go-plugin"
 )
 
 var trelloBaseURL = "https://api.trello.com/1"
@@ -440,10 +439,5 @@ func (s *TrelloSyncer) mapRoadyToTrelloList(status planning.TaskStatus) string {
 }
 
 func main() {
-	plugin.Serve(&plugin.ServeConfig{
-		HandshakeConfig: infraPlugin.HandshakeConfig,
-		Plugins: map[string]plugin.Plugin{
-			"syncer": &domainPlugin.SyncerPlugin{Impl: &TrelloSyncer{}},
-		},
-	})
+	infraPlugin.Serve(&TrelloSyncer{})
 }
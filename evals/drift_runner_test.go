@@ -40,6 +40,14 @@ func TestDriftCorpus(t *testing.T) {
 		{
 			name:  "intent_drift_when_spec_diverges_from_lock",
 			setup: setupIntentDrift,
+			expected: []expectedIssue{
+				{Type: drift.DriftTypeSpec, Category: drift.CategoryMissing},
+				{Type: drift.DriftTypeSpec, Category: drift.CategoryMissing},
+			},
+		},
+		{
+			name:  "intent_drift_when_requirement_edited_after_lock",
+			setup: setupIntentEdit,
 			expected: []expectedIssue{
 				{Type: drift.DriftTypeSpec, Category: drift.CategoryMismatch},
 			},
@@ -145,6 +153,20 @@ func setupIntentDrift(t *testing.T) *storage.FilesystemRepository {
 	return repo
 }
 
+func setupIntentEdit(t *testing.T) *storage.FilesystemRepository {
+	t.Helper()
+	repo := setupHealthyProject(t)
+
+	// Edit an existing requirement's description without re-locking; the
+	// plan still covers it, so only a single intent mismatch is expected.
+	updated, _ := repo.LoadSpec()
+	updated.Features[0].Requirements[0].Description = "Rewritten after lock"
+	if err := repo.SaveSpec(updated); err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
+
 func setupPlanDrift(t *testing.T) *storage.FilesystemRepository {
 	t.Helper()
 	repo := setupHealthyProject(t)
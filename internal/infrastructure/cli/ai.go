@@ -34,7 +34,10 @@ var aiConfigureCmd = &cobra.Command{
 		if cErr != nil {
 			return fmt.Errorf("resolve project path: %w", cErr)
 		}
-		repo := storage.NewFilesystemRepository(cwd)
+		repo, err := storage.OpenRepository(cwd, "")
+		if err != nil {
+			return err
+		}
 		if !repo.IsInitialized() {
 			return fmt.Errorf("roady is not initialized in this directory")
 		}
@@ -1,11 +1,18 @@
 package cli
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"time"
 
 	"github.com/felixgeelhaar/roady/internal/infrastructure/wiring"
 	"github.com/felixgeelhaar/roady/pkg/application"
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
+	"github.com/felixgeelhaar/roady/pkg/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -14,9 +21,21 @@ var auditCmd = &cobra.Command{
 	Short: "Audit and verify project history",
 }
 
+var (
+	auditVerifyRequireSigned bool
+	auditVerifyCheckpoints   []string
+)
+
 var auditVerifyCmd = &cobra.Command{
 	Use:   "verify",
 	Short: "Verify the integrity of the project audit trail",
+	Long: `Verify the hash chain of the audit trail, the signatures of signed events
+against the public keys in team.yaml, and the signed checkpoints in
+.roady/audit-checkpoints.jsonl.
+
+Pass checkpoints exported with 'roady audit checkpoint' and kept elsewhere
+with --checkpoint: a log whose history was rewritten no longer matches them,
+even if every hash was recomputed.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cwd, err := getProjectRoot()
 		if err != nil {
@@ -31,6 +50,43 @@ var auditVerifyCmd = &cobra.Command{
 			return fmt.Errorf("verification failed: %w", err)
 		}
 
+		teamCfg, err := workspace.Repo.LoadTeam()
+		if err != nil {
+			return MapError(fmt.Errorf("load team: %w", err))
+		}
+		keys, err := teamCfg.PublicKeys()
+		if err != nil {
+			return fmt.Errorf("team.yaml: %w", err)
+		}
+		report, err := service.VerifySignatures(keys, auditVerifyRequireSigned)
+		if err != nil {
+			return fmt.Errorf("verification failed: %w", err)
+		}
+		violations = append(violations, report.Violations...)
+
+		checkpoints, err := storage.NewFileAuditCheckpointLog(workspace.Repo.ProjectBase()).LoadCheckpoints()
+		if err != nil {
+			return fmt.Errorf("load checkpoints: %w", err)
+		}
+		for _, path := range auditVerifyCheckpoints {
+			exported, err := readCheckpointFile(path)
+			if err != nil {
+				return err
+			}
+			checkpoints = append(checkpoints, exported...)
+		}
+		checkpointViolations, err := service.VerifyCheckpoints(checkpoints, keys)
+		if err != nil {
+			return fmt.Errorf("verification failed: %w", err)
+		}
+		violations = append(violations, checkpointViolations...)
+
+		if report.Signed > 0 || len(keys) > 0 {
+			fmt.Printf("Signatures: %d signed, %d unsigned events.\n", report.Signed, report.Unsigned)
+		}
+		if len(checkpoints) > 0 {
+			fmt.Printf("Checkpoints: %d checked.\n", len(checkpoints))
+		}
 		if len(violations) == 0 {
 			fmt.Println("Audit trail is intact and verified.")
 			return nil
@@ -45,7 +101,352 @@ var auditVerifyCmd = &cobra.Command{
 	},
 }
 
+var (
+	auditCompactKeep   int
+	auditCompactBefore string
+	auditCompactJSON   bool
+)
+
+var auditCompactCmd = &cobra.Command{
+	Use:   "compact",
+	Short: "Archive old events into signed, gzip'd segments",
+	Long: `Move old events out of .roady/events.jsonl into a gzip'd segment under
+.roady/archive/. Each segment is recorded in archive/manifest.json with an
+anchor signed by ROADY_SIGNER's key, so 'roady audit verify' still checks
+the whole hash chain, archived segments included, against the public keys
+in team.yaml.
+
+By default all but the newest --keep events are archived; with --before,
+the events older than that date are. The newest event is always kept.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cwd, err := getProjectRoot()
+		if err != nil {
+			return fmt.Errorf("resolve project path: %w", err)
+		}
+		opts := storage.CompactOptions{Keep: auditCompactKeep}
+		if auditCompactBefore != "" {
+			if opts.Before, err = parseCompactBefore(auditCompactBefore); err != nil {
+				return err
+			}
+		}
+
+		if opts.Signer, err = wiring.LoadSigner(); err != nil {
+			return err
+		}
+		if opts.Signer == nil {
+			return fmt.Errorf("no signer configured: set %s (see 'roady audit keygen')", wiring.SignerEnv)
+		}
+
+		workspace := wiring.NewWorkspace(cwd)
+		result, err := workspace.Repo.CompactEvents(opts)
+		if err != nil {
+			return MapError(fmt.Errorf("compact audit trail: %w", err))
+		}
+
+		if auditCompactJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(result)
+		}
+		if result.Archived == 0 {
+			fmt.Printf("Nothing to compact: %d events in the active log.\n", result.Remaining)
+			return nil
+		}
+		fmt.Printf("Archived %d events to %s (%d kept, %d segments).\n",
+			result.Archived, result.Segment, result.Remaining, result.Segments)
+		return nil
+	},
+}
+
+var (
+	auditKeygenOut   string
+	auditKeygenForce bool
+)
+
+var auditKeygenCmd = &cobra.Command{
+	Use:   "keygen <member>",
+	Short: "Create a signing key for a team member or agent",
+	Long: `Generate an ed25519 key pair for a team member or agent. The private key is
+written to ~/.roady/keys/<member>.key (or --out) and the public key is
+registered in team.yaml, which should be committed.
+
+Set ROADY_SIGNER=<member> (and ROADY_SIGNING_KEY when using --out) to sign
+every event roady records from then on.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		services, err := loadServicesForCurrentDir()
+		if err != nil {
+			return err
+		}
+		cfg, err := services.Team.ListMembers()
+		if err != nil {
+			return MapError(fmt.Errorf("load team: %w", err))
+		}
+		if cfg.FindMember(name) == nil {
+			return fmt.Errorf("%s is not a team member; add them with 'roady team add %s <role>' first", name, name)
+		}
+
+		path := auditKeygenOut
+		if path == "" {
+			if path, err = wiring.SigningKeyPath(name); err != nil {
+				return err
+			}
+		}
+		pub, err := wiring.GenerateSigningKey(path, auditKeygenForce)
+		if err != nil {
+			return fmt.Errorf("generate key: %w", err)
+		}
+		if err := services.Team.SetPublicKey(name, pub); err != nil {
+			return MapError(fmt.Errorf("register key: %w", err))
+		}
+
+		fmt.Printf("Private key written to %s\n", path)
+		fmt.Printf("Public key registered for %s: %s\n", name, team.EncodePublicKey(pub))
+		fmt.Printf("Sign events with: export %s=%s\n", wiring.SignerEnv, name)
+		return nil
+	},
+}
+
+var auditCheckpointOut string
+
+var auditCheckpointCmd = &cobra.Command{
+	Use:   "checkpoint",
+	Short: "Record and export a signed checkpoint of the audit trail",
+	Long: `Sign the length and last hash of the audit trail with the key of
+ROADY_SIGNER, record it in .roady/audit-checkpoints.jsonl and print it (or
+write it to --out). Keep exported checkpoints out-of-band and compare them
+later with 'roady audit verify --checkpoint <file>'.
+
+While a signer is configured, roady also records a checkpoint every 24h.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cwd, err := getProjectRoot()
+		if err != nil {
+			return fmt.Errorf("resolve project path: %w", err)
+		}
+		signer, err := wiring.LoadSigner()
+		if err != nil {
+			return err
+		}
+		if signer == nil {
+			return fmt.Errorf("no signer configured: set %s (see 'roady audit keygen')", wiring.SignerEnv)
+		}
+
+		workspace := wiring.NewWorkspace(cwd)
+		service := application.NewAuditService(workspace.Repo)
+		checkpoint, err := service.Checkpoint(signer, storage.NewFileAuditCheckpointLog(workspace.Repo.ProjectBase()))
+		if err != nil {
+			return MapError(fmt.Errorf("checkpoint: %w", err))
+		}
+
+		data, err := json.MarshalIndent(checkpoint, "", "  ")
+		if err != nil {
+			return err
+		}
+		if auditCheckpointOut == "" {
+			fmt.Println(string(data))
+			return nil
+		}
+		if err := os.WriteFile(auditCheckpointOut, append(data, '\n'), 0600); err != nil {
+			return fmt.Errorf("write checkpoint: %w", err)
+		}
+		fmt.Printf("Checkpoint of %d events written to %s\n", checkpoint.Events, auditCheckpointOut)
+		return nil
+	},
+}
+
+// readCheckpointFile reads one exported checkpoint or a JSON array of them.
+func readCheckpointFile(path string) ([]events.AuditCheckpoint, error) {
+	// #nosec G304 -- the checkpoint file is chosen by the user
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read checkpoint: %w", err)
+	}
+	var checkpoints []events.AuditCheckpoint
+	if err := json.Unmarshal(data, &checkpoints); err == nil {
+		return checkpoints, nil
+	}
+	var checkpoint events.AuditCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("parse checkpoint %s: %w", path, err)
+	}
+	return []events.AuditCheckpoint{checkpoint}, nil
+}
+
+// parseCompactBefore accepts a date (2006-01-02) or an RFC 3339 timestamp.
+func parseCompactBefore(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --before %q: use YYYY-MM-DD or RFC 3339", value)
+	}
+	return t, nil
+}
+
+var (
+	auditQueryTypes         []string
+	auditQueryActor         string
+	auditQueryAggregate     string
+	auditQueryAggregateType string
+	auditQuerySince         string
+	auditQueryUntil         string
+	auditQueryMeta          []string
+	auditQueryLimit         int
+	auditQueryOffset        int
+	auditQueryJSON          bool
+	auditQueryCSV           bool
+)
+
+var auditQueryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "Search the event store",
+	Long: `List the events that match every given filter, oldest first. Archived
+events are included.
+
+--type and --meta values are glob patterns: 'task.*' matches every task
+event. --actor matches actors starting with its value ('ai' matches ai-agent
+and ai:claude), or is a glob when it contains *, ? or [. --since and --until take a window (36h, 7d, 2w), a date
+(YYYY-MM-DD) or an RFC 3339 timestamp.`,
+	Example: `  # Task events recorded by AI agents in the last week
+  roady audit query --type 'task.*' --actor ai --since 7d
+
+  # Everything that happened to one task, as CSV
+  roady audit query --aggregate task-x --csv
+
+  # Events whose metadata matches
+  roady audit query --meta status=blocked --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if auditQueryJSON && auditQueryCSV {
+			return fmt.Errorf("--json and --csv are mutually exclusive")
+		}
+		q, err := buildAuditQuery(time.Now())
+		if err != nil {
+			return err
+		}
+		services, err := loadServicesForCurrentDir()
+		if err != nil {
+			return err
+		}
+		evts, err := services.Audit.QueryEvents(q)
+		if err != nil {
+			return MapError(fmt.Errorf("query events: %w", err))
+		}
+
+		switch {
+		case auditQueryJSON:
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(evts)
+		case auditQueryCSV:
+			return writeEventsCSV(os.Stdout, evts)
+		}
+		if len(evts) == 0 {
+			fmt.Println("No matching events.")
+			return nil
+		}
+		for _, e := range evts {
+			fmt.Printf("[%s] %-15s | %-20s", e.Timestamp.Format(time.RFC822), e.Actor, eventTypeOf(e))
+			if e.AggregateID_ != "" {
+				fmt.Printf(" %s", e.AggregateID_)
+			}
+			if len(e.Metadata) > 0 {
+				fmt.Printf(" (%v)", e.Metadata)
+			}
+			fmt.Println()
+		}
+		return nil
+	},
+}
+
+// buildAuditQuery turns the query flags into an EventQuery.
+func buildAuditQuery(now time.Time) (events.EventQuery, error) {
+	q := events.EventQuery{
+		AggregateType: auditQueryAggregateType,
+		AggregateID:   auditQueryAggregate,
+		EventTypes:    auditQueryTypes,
+		Actor:         auditQueryActor,
+		Limit:         auditQueryLimit,
+		Offset:        auditQueryOffset,
+	}
+	var err error
+	if q.Metadata, err = events.ParseMetadataFilters(auditQueryMeta); err != nil {
+		return q, err
+	}
+	for _, bound := range []struct {
+		flag, value string
+		dst         **time.Time
+	}{{"--since", auditQuerySince, &q.Since}, {"--until", auditQueryUntil, &q.Until}} {
+		if bound.value == "" {
+			continue
+		}
+		t, err := events.ParseQueryTime(bound.value, now)
+		if err != nil {
+			return q, fmt.Errorf("%s: %w", bound.flag, err)
+		}
+		*bound.dst = &t
+	}
+	return q, q.Validate()
+}
+
+// writeEventsCSV writes one row per event; metadata is a JSON object.
+func writeEventsCSV(out io.Writer, evts []*events.BaseEvent) error {
+	w := csv.NewWriter(out)
+	_ = w.Write([]string{"id", "timestamp", "type", "actor", "aggregate_type", "aggregate_id", "metadata"})
+	for _, e := range evts {
+		metadata := ""
+		if len(e.Metadata) > 0 {
+			data, err := json.Marshal(e.Metadata)
+			if err != nil {
+				return fmt.Errorf("encode metadata of event %s: %w", e.ID, err)
+			}
+			metadata = string(data)
+		}
+		_ = w.Write([]string{
+			e.ID, e.Timestamp.Format(time.RFC3339Nano), eventTypeOf(e), e.Actor,
+			e.AggregateType_, e.AggregateID_, metadata,
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// eventTypeOf returns the event type, or the action of a legacy event.
+func eventTypeOf(e *events.BaseEvent) string {
+	if e.Type != "" {
+		return e.Type
+	}
+	return e.Action
+}
+
 func init() {
+	auditCompactCmd.Flags().IntVar(&auditCompactKeep, "keep", 1000, "Number of recent events to keep in events.jsonl")
+	auditCompactCmd.Flags().StringVar(&auditCompactBefore, "before", "", "Archive events older than this date (YYYY-MM-DD or RFC 3339)")
+	auditCompactCmd.Flags().BoolVar(&auditCompactJSON, "json", false, "Output in JSON format")
+	auditQueryCmd.Flags().StringSliceVar(&auditQueryTypes, "type", nil, "Event type glob, e.g. 'task.*' (repeatable)")
+	auditQueryCmd.Flags().StringVar(&auditQueryActor, "actor", "", "Actor glob, e.g. 'ai*'")
+	auditQueryCmd.Flags().StringVar(&auditQueryAggregate, "aggregate", "", "Aggregate ID, e.g. a task ID")
+	auditQueryCmd.Flags().StringVar(&auditQueryAggregateType, "aggregate-type", "", "Aggregate type (task, plan, sync, billing)")
+	auditQueryCmd.Flags().StringVar(&auditQuerySince, "since", "", "Only events at or after this time (7d, 36h, YYYY-MM-DD or RFC 3339)")
+	auditQueryCmd.Flags().StringVar(&auditQueryUntil, "until", "", "Only events at or before this time (7d, 36h, YYYY-MM-DD or RFC 3339)")
+	auditQueryCmd.Flags().StringArrayVar(&auditQueryMeta, "meta", nil, "Metadata filter key=glob (repeatable)")
+	auditQueryCmd.Flags().IntVar(&auditQueryLimit, "limit", 0, "Maximum number of events (0 = all)")
+	auditQueryCmd.Flags().IntVar(&auditQueryOffset, "offset", 0, "Number of matching events to skip")
+	auditQueryCmd.Flags().BoolVar(&auditQueryJSON, "json", false, "Output in JSON format")
+	auditQueryCmd.Flags().BoolVar(&auditQueryCSV, "csv", false, "Output in CSV format")
+	auditVerifyCmd.Flags().BoolVar(&auditVerifyRequireSigned, "require-signatures", false, "Report unsigned events as violations")
+	auditVerifyCmd.Flags().StringArrayVar(&auditVerifyCheckpoints, "checkpoint", nil, "Exported checkpoint file to compare with the log (repeatable)")
+	auditKeygenCmd.Flags().StringVar(&auditKeygenOut, "out", "", "Private key path (default ~/.roady/keys/<member>.key)")
+	auditKeygenCmd.Flags().BoolVar(&auditKeygenForce, "force", false, "Replace an existing private key")
+	auditCheckpointCmd.Flags().StringVar(&auditCheckpointOut, "out", "", "Write the checkpoint to this file instead of stdout")
 	auditCmd.AddCommand(auditVerifyCmd)
+	auditCmd.AddCommand(auditKeygenCmd)
+	auditCmd.AddCommand(auditCheckpointCmd)
+	auditCmd.AddCommand(auditCompactCmd)
+	auditCmd.AddCommand(auditQueryCmd)
 	RootCmd.AddCommand(auditCmd)
 }
@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/roady/internal/infrastructure/wiring"
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
+	"github.com/felixgeelhaar/roady/pkg/storage"
+)
+
+func TestAuditCompactCmd(t *testing.T) {
+	_, cleanup := withTempDir(t)
+	defer cleanup()
+
+	store, _ := storage.NewFileEventStore(".roady")
+	for range 5 {
+		if err := store.Append(&events.BaseEvent{Type: events.EventTypeTaskStarted, Metadata: map[string]interface{}{"task_id": "t1"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	auditCompactKeep, auditCompactBefore, auditCompactJSON = 2, "", false
+	defer func() { auditCompactKeep = 1000 }()
+
+	out := captureStdout(t, func() {
+		if err := auditCompactCmd.RunE(auditCompactCmd, nil); err != nil {
+			t.Fatalf("audit compact: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Archived 3 events to events-000001.jsonl.gz (2 kept, 1 segments)") {
+		t.Errorf("unexpected output: %q", out)
+	}
+	if violations, err := store.VerifyIntegrity(); err != nil || len(violations) != 0 {
+		t.Errorf("VerifyIntegrity = %v, %v", violations, err)
+	}
+
+	out = captureStdout(t, func() {
+		if err := auditCompactCmd.RunE(auditCompactCmd, nil); err != nil {
+			t.Fatalf("audit compact: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Nothing to compact") {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
+
+func TestParseCompactBefore(t *testing.T) {
+	if got, err := parseCompactBefore("2026-03-01"); err != nil || got.Day() != 1 || got.Month() != time.March {
+		t.Errorf("parseCompactBefore(date) = %v, %v", got, err)
+	}
+	if got, err := parseCompactBefore("2026-03-01T10:00:00Z"); err != nil || got.Hour() != 10 {
+		t.Errorf("parseCompactBefore(RFC 3339) = %v, %v", got, err)
+	}
+	if _, err := parseCompactBefore("last week"); err == nil {
+		t.Error("expected an invalid date to be rejected")
+	}
+}
+
+func TestAuditQueryCmd(t *testing.T) {
+	_, cleanup := withTempDir(t)
+	defer cleanup()
+
+	store, _ := storage.NewFileEventStore(".roady")
+	now := time.Now()
+	for _, e := range []*events.BaseEvent{
+		{Type: events.EventTypeTaskStarted, Actor: "ai:claude", AggregateType_: events.AggregateTypeTask, AggregateID_: "task-x", Timestamp: now.Add(-time.Hour)},
+		{Type: events.EventTypeTaskCompleted, Actor: "alice", AggregateType_: events.AggregateTypeTask, AggregateID_: "task-x", Timestamp: now.Add(-time.Hour)},
+		{Type: events.EventTypeTaskStarted, Actor: "ai:claude", AggregateType_: events.AggregateTypeTask, AggregateID_: "task-y", Timestamp: now.AddDate(0, 0, -10)},
+		{Type: events.EventTypePlanApproved, Actor: "ai:claude", Timestamp: now.Add(-time.Hour), Metadata: map[string]interface{}{"plan_id": "p1"}},
+	} {
+		if err := store.Append(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	auditQueryTypes, auditQueryActor, auditQuerySince, auditQueryCSV = []string{"task.*"}, "ai*", "7d", true
+	defer func() {
+		auditQueryTypes, auditQueryActor, auditQuerySince, auditQueryCSV = nil, "", "", false
+	}()
+
+	out := captureStdout(t, func() {
+		if err := auditQueryCmd.RunE(auditQueryCmd, nil); err != nil {
+			t.Fatalf("audit query: %v", err)
+		}
+	})
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "id,timestamp,type") || !strings.Contains(lines[1], "task-x") {
+		t.Errorf("unexpected CSV output: %q", out)
+	}
+
+	auditQueryTypes, auditQueryActor, auditQuerySince, auditQueryCSV = nil, "", "", false
+	auditQueryMeta = []string{"plan_id=p*"}
+	defer func() { auditQueryMeta = nil }()
+	out = captureStdout(t, func() {
+		if err := auditQueryCmd.RunE(auditQueryCmd, nil); err != nil {
+			t.Fatalf("audit query: %v", err)
+		}
+	})
+	if !strings.Contains(out, events.EventTypePlanApproved) || strings.Contains(out, events.EventTypeTaskStarted) {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
+
+func TestBuildAuditQuery_RejectsBadFlags(t *testing.T) {
+	defer func() { auditQuerySince, auditQueryMeta, auditQueryTypes = "", nil, nil }()
+
+	auditQuerySince = "yesterday"
+	if _, err := buildAuditQuery(time.Now()); err == nil || !strings.Contains(err.Error(), "--since") {
+		t.Errorf("expected an invalid --since to be rejected, got %v", err)
+	}
+	auditQuerySince, auditQueryMeta = "", []string{"status"}
+	if _, err := buildAuditQuery(time.Now()); err == nil {
+		t.Error("expected a metadata filter without '=' to be rejected")
+	}
+	auditQueryMeta, auditQueryTypes = nil, []string{"task.["}
+	if _, err := buildAuditQuery(time.Now()); err == nil {
+		t.Error("expected a malformed type glob to be rejected")
+	}
+}
+
+func TestAuditSigningCmds(t *testing.T) {
+	dir, cleanup := withTempDir(t)
+	defer cleanup()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(wiring.SignerEnv, "")
+
+	repo := storage.NewFilesystemRepository(dir)
+	if err := repo.SaveTeam(&team.TeamConfig{Members: []team.Member{{Name: "alice", Role: team.RoleAdmin}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := auditKeygenCmd.RunE(auditKeygenCmd, []string{"bob"}); err == nil || !strings.Contains(err.Error(), "roady team add bob") {
+		t.Errorf("expected keygen for a non-member to fail, got %v", err)
+	}
+	out := captureStdout(t, func() {
+		if err := auditKeygenCmd.RunE(auditKeygenCmd, []string{"alice"}); err != nil {
+			t.Fatalf("audit keygen: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Public key registered for alice: ed25519:") {
+		t.Errorf("unexpected output: %q", out)
+	}
+
+	// Events recorded with ROADY_SIGNER set are signed.
+	t.Setenv(wiring.SignerEnv, "alice")
+	services, err := loadServicesForCurrentDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := services.Audit.Log("task.started", "cli", map[string]interface{}{"task_id": "t1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	exported := filepath.Join(t.TempDir(), "checkpoint.json")
+	auditCheckpointOut = exported
+	defer func() { auditCheckpointOut = "" }()
+	if err := auditCheckpointCmd.RunE(auditCheckpointCmd, nil); err != nil {
+		t.Fatalf("audit checkpoint: %v", err)
+	}
+
+	auditVerifyRequireSigned, auditVerifyCheckpoints = false, []string{exported}
+	defer func() { auditVerifyCheckpoints = nil }()
+	out = captureStdout(t, func() {
+		if err := auditVerifyCmd.RunE(auditVerifyCmd, nil); err != nil {
+			t.Fatalf("audit verify: %v", err)
+		}
+	})
+	for _, want := range []string{"Signatures: 1 signed, 1 unsigned events.", "Checkpoints: 3 checked.", "Audit trail is intact"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output: %q", want, out)
+		}
+	}
+}
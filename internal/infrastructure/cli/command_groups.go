@@ -51,6 +51,7 @@ var commandGroupAssignments = map[string]string{
 	"config":     groupAdmin,
 	"policy":     groupAdmin,
 	"rate":       groupAdmin,
+	"storage":    groupAdmin,
 }
 
 // assignCommandGroups registers Cobra command groups on RootCmd and assigns
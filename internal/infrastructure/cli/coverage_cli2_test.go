@@ -12,6 +12,7 @@ import (
 	"github.com/felixgeelhaar/roady/pkg/domain/dependency"
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	"github.com/felixgeelhaar/roady/pkg/domain/spec"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
 	"github.com/felixgeelhaar/roady/pkg/infrastructure/webhook"
 	"github.com/felixgeelhaar/roady/pkg/storage"
 )
@@ -954,6 +955,51 @@ func TestCov2_ProcessEvent_StatusChangePrint(t *testing.T) {
 	}
 }
 
+func TestProcessEvent_TeamMemberActor(t *testing.T) {
+	_, cleanup := withTempDir(t)
+	defer cleanup()
+
+	repo := storage.NewFilesystemRepository(".")
+	if err := repo.Initialize(); err != nil {
+		t.Fatalf("init repo: %v", err)
+	}
+	_ = repo.SavePlan(&planning.Plan{ID: "p1"})
+	_ = repo.SavePolicy(&domain.PolicyConfig{})
+	state := planning.NewExecutionState("p1")
+	state.TaskStates["t1"] = planning.TaskResult{Status: planning.StatusPending}
+	_ = repo.SaveState(state)
+
+	services, err := loadServicesForCurrentDir()
+	if err != nil {
+		t.Fatalf("loadServices: %v", err)
+	}
+	if err := services.Team.AddMember("ci-bot", team.RoleMember); err != nil {
+		t.Fatal(err)
+	}
+
+	// The serve command refuses an actor who is not on the team.
+	old := webhookActor
+	defer func() { webhookActor = old }()
+	webhookActor = "webhook"
+	if err := webhookServeCmd.RunE(webhookServeCmd, nil); err == nil || !strings.Contains(err.Error(), "not on the team") {
+		t.Fatalf("serve with an unknown actor = %v, want refusal", err)
+	}
+
+	cfg, _ := services.Team.ListMembers()
+	ctx := team.WithCaller(context.Background(), *cfg.FindMember("ci-bot"))
+	event := &webhook.Event{Provider: "github", TaskID: "t1", ExternalID: "42", Status: planning.StatusInProgress, Timestamp: time.Now()}
+	captureStdout(t, func() {
+		if err := newWebhookProcessor(services).ProcessEvent(ctx, event); err != nil {
+			t.Fatalf("ProcessEvent as a member: %v", err)
+		}
+	})
+
+	events, _ := repo.LoadEvents()
+	if len(events) == 0 || events[len(events)-1].Actor != "ci-bot" {
+		t.Errorf("expected the transition to be audited as ci-bot, got %+v", events)
+	}
+}
+
 // ============================================================================
 // watch.go - Auto-sync mode (lines 89-105)
 // ============================================================================
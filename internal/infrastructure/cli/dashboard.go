@@ -14,10 +14,13 @@ import (
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/felixgeelhaar/roady/internal/infrastructure/watch"
 	"github.com/felixgeelhaar/roady/internal/infrastructure/wiring"
 	"github.com/felixgeelhaar/roady/pkg/application"
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
 	"github.com/felixgeelhaar/roady/pkg/infrastructure/dashboard"
+	"github.com/felixgeelhaar/roady/pkg/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -44,6 +47,14 @@ Use 'roady dashboard serve' for the web-based dashboard.`,
 var (
 	dashboardPort      int
 	dashboardAuthToken string
+
+	dashboardOIDCIssuer       string
+	dashboardOIDCClientID     string
+	dashboardOIDCClientSecret string
+	dashboardOIDCRedirectURL  string
+	dashboardOIDCDomains      []string
+	dashboardOIDCGroups       []string
+	dashboardOIDCGroupsClaim  string
 )
 
 // resolveDashboardToken picks the auth token from the --auth-token flag,
@@ -55,6 +66,79 @@ func resolveDashboardToken() string {
 	return os.Getenv("ROADY_DASHBOARD_TOKEN")
 }
 
+// enableDashboardAuth gates the dashboard with the shared token
+// (--auth-token flag or ROADY_DASHBOARD_TOKEN env) and, once any member
+// token has been issued with `roady team token issue`, with member tokens.
+func enableDashboardAuth(server *dashboard.Server, services *wiring.AppServices) error {
+	if tok := resolveDashboardToken(); tok != "" {
+		server.EnableAuthToken(tok)
+	}
+	hasTokens, err := services.Team.HasTokens()
+	if err != nil {
+		return fmt.Errorf("load member tokens: %w", err)
+	}
+	if hasTokens {
+		server.EnableMemberAuth(services.Team)
+	}
+	return nil
+}
+
+// enableDashboardOIDC turns on OpenID Connect login when --oidc-issuer is
+// set. The client secret falls back to the ROADY_OIDC_CLIENT_SECRET env var.
+func enableDashboardOIDC(ctx context.Context, server *dashboard.Server) error {
+	if dashboardOIDCIssuer == "" {
+		return nil
+	}
+	domains, err := parseRoleMappings(dashboardOIDCDomains)
+	if err != nil {
+		return fmt.Errorf("--oidc-domain: %w", err)
+	}
+	groups, err := parseRoleMappings(dashboardOIDCGroups)
+	if err != nil {
+		return fmt.Errorf("--oidc-group: %w", err)
+	}
+	secret := dashboardOIDCClientSecret
+	if secret == "" {
+		secret = os.Getenv("ROADY_OIDC_CLIENT_SECRET")
+	}
+	redirect := dashboardOIDCRedirectURL
+	if redirect == "" {
+		redirect = fmt.Sprintf("http://localhost:%d/auth/callback", dashboardPort)
+	}
+
+	provider, err := dashboard.NewOIDCProvider(ctx, dashboard.OIDCConfig{
+		Issuer:       dashboardOIDCIssuer,
+		ClientID:     dashboardOIDCClientID,
+		ClientSecret: secret,
+		RedirectURL:  redirect,
+		Domains:      domains,
+		Groups:       groups,
+		GroupsClaim:  dashboardOIDCGroupsClaim,
+	})
+	if err != nil {
+		return err
+	}
+	server.EnableOIDC(provider)
+	return nil
+}
+
+// parseRoleMappings parses name=role entries such as example.com=member.
+func parseRoleMappings(entries []string) (map[string]team.Role, error) {
+	mappings := make(map[string]team.Role, len(entries))
+	for _, e := range entries {
+		name, role, ok := strings.Cut(e, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%q is not name=role", e)
+		}
+		r := team.Role(role)
+		if !r.IsValid() {
+			return nil, fmt.Errorf("invalid role %q in %q (valid: %v)", role, e, team.ValidRoles())
+		}
+		mappings[strings.ToLower(name)] = r
+	}
+	return mappings, nil
+}
+
 var dashboardServeCmd = &cobra.Command{
 	Use:   "serve",
 	Short: "Start the web dashboard server",
@@ -70,7 +154,12 @@ Access the dashboard in your browser at the displayed URL.`,
   roady dashboard serve
 
   # Start on custom port
-  roady dashboard serve --port 8080`,
+  roady dashboard serve --port 8080
+
+  # Sign users in with an OpenID Connect provider
+  roady dashboard serve --oidc-issuer https://accounts.example.com \
+    --oidc-client-id roady --oidc-redirect-url https://roady.example.com/auth/callback \
+    --oidc-domain example.com=member --oidc-group roady-admins=admin`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		services, err := loadServicesForCurrentDir()
 		if err != nil {
@@ -89,15 +178,37 @@ Access the dashboard in your browser at the displayed URL.`,
 		// every project (root + sub-projects under .roady/projects/<name>/).
 		root := services.Workspace.Repo.Root()
 		server.EnableOrgKanban(application.NewOrgService(root), nil)
-		// Wire task-action buttons (Start / Complete / Block / Unblock / Reopen)
-		// on the per-project Kanban board.
+		// Wire task-action buttons (Start / Complete / Block / Unblock / Reopen /
+		// Assign) on the per-project Kanban board.
 		server.EnableTaskActions(services.Task)
+		// Wire task creation and editing, and team members as owners.
+		server.EnablePlanEditing(services.Task)
+		server.EnableTeamRoster(services.Team)
 		// Wire cross-project action routing so /org/kanban DnD targets the
 		// right sub-project's TaskService.
-		server.EnableOrgTaskActions(newOrgTaskActionsResolver(root))
-		// Optional auth token gate (--auth-token flag or ROADY_DASHBOARD_TOKEN env).
-		if tok := resolveDashboardToken(); tok != "" {
-			server.EnableAuthToken(tok)
+		orgResolver := newOrgTaskActionsResolver(root)
+		server.EnableOrgTaskActions(orgResolver)
+		server.EnableOrgPlanEditing(orgResolver)
+		// Wire the drift, debt, forecast, billing and timeline pages, for
+		// this project and, via ?project_path=&project=, any discovered one.
+		server.EnableInsights(dashboardInsights(services))
+		server.EnableOrgInsights(orgResolver)
+		// Wire GET /api/events over the event store.
+		server.EnableEventQuery(services.Audit)
+		// Wire /api/schedule and critical-path highlighting on the Kanban board.
+		server.EnableSchedule(provider)
+		// Stream events written by any process (MCP agents, git sync, other
+		// CLI invocations) to /events.
+		ctx, cancel := context.WithCancel(cmd.Context())
+		defer cancel()
+		if err := startDashboardEventFeed(ctx, server, services); err != nil {
+			fmt.Printf("Live updates limited to dashboard actions: %v\n", err)
+		}
+		if err := enableDashboardAuth(server, services); err != nil {
+			return err
+		}
+		if err := enableDashboardOIDC(ctx, server); err != nil {
+			return err
 		}
 
 		// Handle graceful shutdown
@@ -145,15 +256,34 @@ var dashboardOpenCmd = &cobra.Command{
 		// every project (root + sub-projects under .roady/projects/<name>/).
 		root := services.Workspace.Repo.Root()
 		server.EnableOrgKanban(application.NewOrgService(root), nil)
-		// Wire task-action buttons (Start / Complete / Block / Unblock / Reopen)
-		// on the per-project Kanban board.
+		// Wire task-action buttons (Start / Complete / Block / Unblock / Reopen /
+		// Assign) on the per-project Kanban board.
 		server.EnableTaskActions(services.Task)
+		// Wire task creation and editing, and team members as owners.
+		server.EnablePlanEditing(services.Task)
+		server.EnableTeamRoster(services.Team)
 		// Wire cross-project action routing so /org/kanban DnD targets the
 		// right sub-project's TaskService.
-		server.EnableOrgTaskActions(newOrgTaskActionsResolver(root))
-		// Optional auth token gate (--auth-token flag or ROADY_DASHBOARD_TOKEN env).
-		if tok := resolveDashboardToken(); tok != "" {
-			server.EnableAuthToken(tok)
+		orgResolver := newOrgTaskActionsResolver(root)
+		server.EnableOrgTaskActions(orgResolver)
+		server.EnableOrgPlanEditing(orgResolver)
+		// Wire the drift, debt, forecast, billing and timeline pages, for
+		// this project and, via ?project_path=&project=, any discovered one.
+		server.EnableInsights(dashboardInsights(services))
+		server.EnableOrgInsights(orgResolver)
+		// Wire GET /api/events over the event store.
+		server.EnableEventQuery(services.Audit)
+		// Wire /api/schedule and critical-path highlighting on the Kanban board.
+		server.EnableSchedule(provider)
+		// Stream events written by any process (MCP agents, git sync, other
+		// CLI invocations) to /events.
+		ctx, cancel := context.WithCancel(cmd.Context())
+		defer cancel()
+		if err := startDashboardEventFeed(ctx, server, services); err != nil {
+			fmt.Printf("Live updates limited to dashboard actions: %v\n", err)
+		}
+		if err := enableDashboardAuth(server, services); err != nil {
+			return err
 		}
 
 		// Handle graceful shutdown
@@ -205,6 +335,38 @@ func init() {
 	const tokHelp = "Shared bearer token required on every request (env: ROADY_DASHBOARD_TOKEN). Empty = public."
 	dashboardServeCmd.Flags().StringVar(&dashboardAuthToken, "auth-token", "", tokHelp)
 	dashboardOpenCmd.Flags().StringVar(&dashboardAuthToken, "auth-token", "", tokHelp)
+
+	dashboardServeCmd.Flags().StringVar(&dashboardOIDCIssuer, "oidc-issuer", "", "OpenID Connect issuer URL; enables login")
+	dashboardServeCmd.Flags().StringVar(&dashboardOIDCClientID, "oidc-client-id", "", "OpenID Connect client ID")
+	dashboardServeCmd.Flags().StringVar(&dashboardOIDCClientSecret, "oidc-client-secret", "", "OpenID Connect client secret (env: ROADY_OIDC_CLIENT_SECRET)")
+	dashboardServeCmd.Flags().StringVar(&dashboardOIDCRedirectURL, "oidc-redirect-url", "", "Callback URL registered with the provider (default http://localhost:<port>/auth/callback)")
+	dashboardServeCmd.Flags().StringArrayVar(&dashboardOIDCDomains, "oidc-domain", nil, "Allowed email domain and its role, e.g. example.com=member (repeatable)")
+	dashboardServeCmd.Flags().StringArrayVar(&dashboardOIDCGroups, "oidc-group", nil, "Allowed group and its role, e.g. roady-admins=admin (repeatable)")
+	dashboardServeCmd.Flags().StringVar(&dashboardOIDCGroupsClaim, "oidc-groups-claim", "groups", "ID token claim listing the user's groups")
+}
+
+// startDashboardEventFeed tails the project's event store into the
+// dashboard's /events stream. The store is polled whenever the watcher sees
+// it written under the project's .roady directory.
+func startDashboardEventFeed(ctx context.Context, server *dashboard.Server, services *wiring.AppServices) error {
+	store, err := services.Workspace.Repo.EventStore()
+	if err != nil {
+		return fmt.Errorf("open event store: %w", err)
+	}
+	server.EnableEventFeed(store)
+
+	watcher, err := watch.NewFSWatcher(250*time.Millisecond, func(watch.ChangeEvent) {
+		_ = server.PollEvents()
+	})
+	if err != nil {
+		return err
+	}
+	watcher.SetFilter(watch.NewPatternFilter([]string{storage.EventsFile, storage.SQLiteFile + "*"}, nil))
+	if err := watcher.WatchRecursive(services.Workspace.Repo.ProjectBase()); err != nil {
+		return fmt.Errorf("watch project: %w", err)
+	}
+	go func() { _ = watcher.Run(ctx) }()
+	return nil
 }
 
 // dashboardDataProvider implements dashboard.DataProvider
@@ -220,6 +382,12 @@ func (p *dashboardDataProvider) GetState() (*planning.ExecutionState, error) {
 	return p.services.Plan.GetState()
 }
 
+// GetSchedule implements dashboard.ScheduleProvider with the team size and
+// WIP limit of the project.
+func (p *dashboardDataProvider) GetSchedule() (*planning.Schedule, error) {
+	return p.services.Schedule.GetSchedule(application.ScheduleRequest{})
+}
+
 func openBrowser(url string) error {
 	// Validate URL to prevent command injection
 	if !isValidBrowserURL(url) {
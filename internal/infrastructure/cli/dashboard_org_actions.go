@@ -7,7 +7,8 @@ import (
 	"github.com/felixgeelhaar/roady/pkg/infrastructure/dashboard"
 )
 
-// orgTaskActionsResolver implements dashboard.OrgTaskActions by building
+// orgTaskActionsResolver implements dashboard.OrgTaskActions,
+// dashboard.OrgPlanEditor and dashboard.InsightsResolver by building
 // AppServices for the requested (projectPath, project) pair on demand. The
 // underlying wiring.BuildAppServicesForProject is cached internally.
 type orgTaskActionsResolver struct {
@@ -18,7 +19,7 @@ func newOrgTaskActionsResolver(defaultPath string) *orgTaskActionsResolver {
 	return &orgTaskActionsResolver{defaultPath: defaultPath}
 }
 
-func (r *orgTaskActionsResolver) ResolveTaskActions(projectPath, project string) (dashboard.TaskActions, error) {
+func (r *orgTaskActionsResolver) services(projectPath, project string) (*wiring.AppServices, error) {
 	root := projectPath
 	if root == "" {
 		root = r.defaultPath
@@ -27,5 +28,41 @@ func (r *orgTaskActionsResolver) ResolveTaskActions(projectPath, project string)
 	if err != nil && svc == nil {
 		return nil, fmt.Errorf("build services for %s / %s: %w", root, project, err)
 	}
+	return svc, nil
+}
+
+func (r *orgTaskActionsResolver) ResolveTaskActions(projectPath, project string) (dashboard.TaskActions, error) {
+	svc, err := r.services(projectPath, project)
+	if err != nil {
+		return nil, err
+	}
 	return svc.Task, nil
 }
+
+func (r *orgTaskActionsResolver) ResolvePlanEditor(projectPath, project string) (dashboard.PlanEditor, error) {
+	svc, err := r.services(projectPath, project)
+	if err != nil {
+		return nil, err
+	}
+	return svc.Task, nil
+}
+
+func (r *orgTaskActionsResolver) ResolveInsights(projectPath, project string) (dashboard.Insights, error) {
+	svc, err := r.services(projectPath, project)
+	if err != nil {
+		return dashboard.Insights{}, err
+	}
+	return dashboardInsights(svc), nil
+}
+
+// dashboardInsights wires a project's services into the dashboard's report
+// pages.
+func dashboardInsights(svc *wiring.AppServices) dashboard.Insights {
+	return dashboard.Insights{
+		Drift:    svc.Drift,
+		Debt:     svc.Debt,
+		Forecast: svc.Forecast,
+		Billing:  svc.Billing,
+		Events:   svc.Audit,
+	}
+}
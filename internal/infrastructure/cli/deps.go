@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/felixgeelhaar/roady/pkg/domain/dependency"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	"github.com/spf13/cobra"
 )
 
@@ -177,7 +178,7 @@ var depsScanCmd = &cobra.Command{
 
 var depsGraphCmd = &cobra.Command{
 	Use:   "graph",
-	Short: "Show dependency graph summary",
+	Short: "Show dependency graph summary, including @project:task-id task dependencies",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		outputFormat, _ := cmd.Flags().GetString("output")
 		checkCycles, _ := cmd.Flags().GetBool("check-cycles")
@@ -211,6 +212,21 @@ var depsGraphCmd = &cobra.Command{
 			fmt.Println()
 		}
 
+		if len(summary.TaskDependencies) > 0 {
+			fmt.Printf("Cross-project task dependencies (%d):\n", len(summary.TaskDependencies))
+			for _, dep := range summary.TaskDependencies {
+				mark := "waiting"
+				if dep.Met() {
+					mark = "met"
+				}
+				fmt.Printf("  %s -> %s [%s, %s]\n", planning.ExternalTaskRef(dep.Project, dep.TaskID), dep.DependsOn, dep.Status, mark)
+			}
+			fmt.Println()
+		}
+		if len(summary.TaskCycle) > 0 {
+			fmt.Printf("Warning: task dependency cycle: %s\n\n", strings.Join(summary.TaskCycle, " -> "))
+		}
+
 		if checkCycles {
 			hasCycle, err := services.Dependency.CheckForCycles()
 			if err != nil {
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/felixgeelhaar/roady/internal/infrastructure/wiring"
+	"github.com/felixgeelhaar/roady/pkg/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -66,7 +67,15 @@ var doctorCmd = &cobra.Command{
 		})
 
 		check("Audit Trail", func() error {
-			path, err := repo.ResolvePath("events.jsonl")
+			if repo.Backend() != storage.BackendFiles {
+				store, err := repo.EventStore()
+				if err != nil {
+					return err
+				}
+				_, err = store.Count()
+				return err
+			}
+			path, err := repo.ResolvePath(storage.EventsFile)
 			if err != nil {
 				return err
 			}
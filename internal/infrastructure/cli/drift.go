@@ -4,7 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
+	reportpkg "github.com/felixgeelhaar/roady/internal/infrastructure/report"
+	"github.com/felixgeelhaar/roady/pkg/domain/policy"
 	"github.com/spf13/cobra"
 )
 
@@ -48,7 +51,11 @@ var driftDetectCmd = &cobra.Command{
 	Use:   "detect",
 	Short: "Check for discrepancies between the current Spec and Plan",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		outputFormat, _ := cmd.Flags().GetString("output")
+		outputFormat, failOn, err := findingsFlags(cmd)
+		if err != nil {
+			return err
+		}
+		ruleIDs, _ := cmd.Flags().GetStringSlice("rule")
 
 		services, err := loadServicesForCurrentDir()
 		if err != nil {
@@ -59,14 +66,33 @@ var driftDetectCmd = &cobra.Command{
 		if err != nil {
 			return MapError(fmt.Errorf("failed to detect drift: %w", err))
 		}
+		report = report.FilterByRule(ruleIDs...)
 
-		if outputFormat == "json" {
+		var failErr error
+		if reportpkg.DriftFails(report, failOn) {
+			failErr = fmt.Errorf("drift detected")
+		}
+
+		switch outputFormat {
+		case formatJSON:
 			data, _ := json.MarshalIndent(report, "", "  ")
 			fmt.Println(string(data))
-			if len(report.Issues) > 0 {
-				return fmt.Errorf("drift detected")
+			return failErr
+		case formatSARIF:
+			data, err := reportpkg.DriftSARIF(report, reportOptions(services))
+			if err != nil {
+				return fmt.Errorf("failed to render SARIF: %w", err)
 			}
-			return nil
+			fmt.Println(string(data))
+			return failErr
+		case formatJUnit:
+			productSpec, _ := services.Spec.GetSpec()
+			data, err := reportpkg.DriftJUnit(report, productSpec)
+			if err != nil {
+				return fmt.Errorf("failed to render JUnit: %w", err)
+			}
+			fmt.Println(string(data))
+			return failErr
 		}
 
 		if len(report.Issues) == 0 {
@@ -77,12 +103,44 @@ var driftDetectCmd = &cobra.Command{
 		fmt.Printf("Detected %d drift issues:\n", len(report.Issues))
 		for _, issue := range report.Issues {
 			fmt.Printf("- [%s] (%s/%s) %s\n", issue.Severity, issue.Type, issue.Category, issue.Message)
+			if len(issue.Commits) > 0 {
+				fmt.Printf("  Commits: %s\n", strings.Join(issue.Commits, ", "))
+			}
 			if issue.Hint != "" {
 				fmt.Printf("  Hint: %s\n", issue.Hint)
 			}
 		}
 
-		return fmt.Errorf("drift detected")
+		return failErr
+	},
+}
+
+var driftRulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "List drift rules and whether policy.yaml enables them",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		services, err := loadServicesForCurrentDir()
+		if err != nil {
+			return err
+		}
+
+		var cfg policy.DriftConfig
+		if pol, err := services.Workspace.Repo.LoadPolicy(); err == nil && pol != nil {
+			cfg = pol.Drift
+		}
+
+		for _, rule := range services.Drift.Rules() {
+			rc := cfg.Rules[rule.ID()]
+			status := "enabled"
+			if !rc.IsEnabled() {
+				status = "disabled"
+			}
+			if rc.Severity != "" {
+				status += ", severity=" + rc.Severity
+			}
+			fmt.Printf("%-10s %-30s %s\n", rule.ID(), "("+status+")", rule.Description())
+		}
+		return nil
 	},
 }
 
@@ -105,7 +163,9 @@ var driftAcceptCmd = &cobra.Command{
 }
 
 func init() {
-	driftDetectCmd.Flags().StringP("output", "o", "text", "Output format (text, json)")
+	addFindingsFlags(driftDetectCmd)
+	driftDetectCmd.Flags().StringSlice("rule", nil, "Only report issues raised by these drift rule IDs (repeatable)")
+	driftCmd.AddCommand(driftRulesCmd)
 	driftCmd.AddCommand(driftDetectCmd)
 	driftCmd.AddCommand(driftExplainCmd)
 	driftCmd.AddCommand(driftAcceptCmd)
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/felixgeelhaar/roady/internal/infrastructure/report"
+	"github.com/felixgeelhaar/roady/internal/infrastructure/wiring"
+	"github.com/felixgeelhaar/roady/pkg/domain/drift"
+	"github.com/felixgeelhaar/roady/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+// Output formats shared by drift detect and policy check.
+const (
+	formatText  = "text"
+	formatJSON  = "json"
+	formatSARIF = "sarif"
+	formatJUnit = "junit"
+)
+
+// addFindingsFlags registers --output and --fail-on on a findings command.
+func addFindingsFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("output", "o", formatText, "Output format (text, json, sarif, junit)")
+	cmd.Flags().String("fail-on", "low", "Exit non-zero when a finding is at least this severe (none, low, medium, high, critical)")
+}
+
+// findingsFlags reads and validates the flags registered by addFindingsFlags.
+func findingsFlags(cmd *cobra.Command) (string, drift.Severity, error) {
+	format, _ := cmd.Flags().GetString("output")
+	switch format {
+	case formatText, formatJSON, formatSARIF, formatJUnit:
+	default:
+		return "", "", fmt.Errorf("unknown output format %q (expected text, json, sarif or junit)", format)
+	}
+	failOn, _ := cmd.Flags().GetString("fail-on")
+	threshold, err := report.ParseFailOn(failOn)
+	if err != nil {
+		return "", "", err
+	}
+	return format, threshold, nil
+}
+
+// reportOptions locates findings without a source citation at the project's
+// spec.yaml, relative to the directory roady runs in so SARIF consumers
+// resolve it against the repository checkout.
+func reportOptions(services *wiring.AppServices) report.Options {
+	opts := report.Options{ToolVersion: Version, DefaultURI: filepath.Join(storage.RoadyDir, storage.SpecFile)}
+	path, err := services.Workspace.Repo.ResolvePath(storage.SpecFile)
+	if err != nil {
+		return opts
+	}
+	if rel, err := filepath.Rel(services.Workspace.Repo.Root(), path); err == nil {
+		opts.DefaultURI = filepath.ToSlash(rel)
+	}
+	return opts
+}
@@ -64,6 +64,40 @@ func TestPolicyCheckCmd_Violations(t *testing.T) {
 	}
 }
 
+func TestPolicyCheckCmd_JUnit(t *testing.T) {
+	_, cleanup := withTempDir(t)
+	defer cleanup()
+
+	repo := storage.NewFilesystemRepository(".")
+	_ = repo.Initialize()
+	_ = repo.SavePolicy(&domain.PolicyConfig{MaxWIP: 1})
+	_ = repo.SavePlan(&planning.Plan{Tasks: []planning.Task{{ID: "t1"}, {ID: "t2"}}})
+	_ = repo.SaveState(&planning.ExecutionState{
+		TaskStates: map[string]planning.TaskResult{
+			"t1": {Status: planning.StatusInProgress},
+			"t2": {Status: planning.StatusInProgress},
+		},
+	})
+
+	_ = policyCheckCmd.Flags().Set("output", "junit")
+	_ = policyCheckCmd.Flags().Set("fail-on", "none")
+	defer func() {
+		_ = policyCheckCmd.Flags().Set("output", "text")
+		_ = policyCheckCmd.Flags().Set("fail-on", "low")
+	}()
+
+	output := captureStdout(t, func() {
+		if err := policyCheckCmd.RunE(policyCheckCmd, []string{}); err != nil {
+			t.Fatalf("expected --fail-on none to pass, got %v", err)
+		}
+	})
+	for _, want := range []string{`<testcase name="max-wip"`, `<testcase name="dependency-check"`, `<failure`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %s in JUnit output, got:\n%s", want, output)
+		}
+	}
+}
+
 func TestPolicyCheckCmd_NoViolations(t *testing.T) {
 	_, cleanup := withTempDir(t)
 	defer cleanup()
@@ -83,6 +117,33 @@ func TestPolicyCheckCmd_NoViolations(t *testing.T) {
 	}
 }
 
+func TestSpecImportCmd_Gherkin(t *testing.T) {
+	root, cleanup := withTempDir(t)
+	defer cleanup()
+
+	repo := storage.NewFilesystemRepository(".")
+	_ = repo.Initialize()
+
+	path := filepath.Join(root, "login.feature")
+	_ = os.WriteFile(path, []byte("Feature: Login\n  Scenario: Valid password\n    Then I am signed in\n"), 0600)
+
+	output := captureStdout(t, func() {
+		if err := specImportCmd.RunE(specImportCmd, []string{path}); err != nil {
+			t.Fatalf("spec import failed: %v", err)
+		}
+	})
+	if !strings.Contains(output, "with 1 features") {
+		t.Fatalf("expected one imported feature, got:\n%s", output)
+	}
+	s, err := repo.LoadSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req := s.Features[0].Requirements[0]; req.ID != "valid-password" || len(req.AcceptanceCriteria) != 1 {
+		t.Fatalf("unexpected requirement: %+v", req)
+	}
+}
+
 func TestSpecCommands(t *testing.T) {
 	root, cleanup := withTempDir(t)
 	defer cleanup()
@@ -215,6 +276,91 @@ func TestTaskCommand_CompleteWithEvidence(t *testing.T) {
 	}
 }
 
+func TestTaskCommand_VerifyWithCriteria(t *testing.T) {
+	_, cleanup := withTempDir(t)
+	defer cleanup()
+
+	repo := storage.NewFilesystemRepository(".")
+	_ = repo.Initialize()
+	_ = repo.SaveSpec(&spec.ProductSpec{
+		ID:       "spec-1",
+		Title:    "Project",
+		Features: []spec.Feature{{ID: "f1", Title: "Feature"}},
+	})
+	_ = repo.SavePlan(&planning.Plan{
+		ID:             "p1",
+		ApprovalStatus: planning.ApprovalApproved,
+		Tasks: []planning.Task{
+			{ID: "task-1", FeatureID: "f1", Title: "Task", AcceptanceCriteria: []string{"Errors are shown", "Receipts are emailed"}},
+		},
+	})
+	state := planning.NewExecutionState("p1")
+	state.TaskStates["task-1"] = planning.TaskResult{Status: planning.StatusDone}
+	_ = repo.SaveState(state)
+
+	cmd := createTaskCommand("verify", "Verify", "verify")
+	cmd.SetArgs([]string{"task-1", "--criterion", "1=test:TestErrors"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "Receipts are emailed") {
+		t.Fatalf("expected verify to require evidence for criterion 2, got %v", err)
+	}
+
+	cmd = createTaskCommand("verify", "Verify", "verify")
+	cmd.SetArgs([]string{"task-1", "-c", "1=test:TestErrors", "-c", "2=3f2a9c1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("task verify failed: %v", err)
+	}
+	loaded, _ := repo.LoadState()
+	if got := loaded.TaskStates["task-1"]; got.Status != planning.StatusVerified || len(got.CriteriaEvidence) != 2 {
+		t.Fatalf("unexpected state: %+v", got)
+	}
+}
+
+func TestTaskCommand_VerifyRun(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	_, cleanup := withTempDir(t)
+	defer cleanup()
+
+	repo := storage.NewFilesystemRepository(".")
+	_ = repo.Initialize()
+	_ = repo.SaveSpec(&spec.ProductSpec{
+		ID:       "spec-1",
+		Title:    "Project",
+		Features: []spec.Feature{{ID: "f1", Title: "Feature"}},
+	})
+	_ = repo.SavePlan(&planning.Plan{
+		ID:             "p1",
+		ApprovalStatus: planning.ApprovalApproved,
+		Tasks: []planning.Task{
+			{ID: "task-1", FeatureID: "f1", Title: "Task", Verify: &planning.Verification{Command: "test -f ok.txt"}},
+		},
+	})
+	state := planning.NewExecutionState("p1")
+	state.TaskStates["task-1"] = planning.TaskResult{Status: planning.StatusDone}
+	_ = repo.SaveState(state)
+
+	cmd := createTaskCommand("verify", "Verify", "verify")
+	cmd.SetArgs([]string{"task-1", "--run"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "exited with 1") {
+		t.Fatalf("expected the failing command to refuse verification, got %v", err)
+	}
+
+	if err := os.WriteFile("ok.txt", []byte("ok"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cmd = createTaskCommand("verify", "Verify", "verify")
+	cmd.SetArgs([]string{"task-1", "--run", "--timeout", "30s"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("task verify --run failed: %v", err)
+	}
+	loaded, _ := repo.LoadState()
+	got := loaded.TaskStates["task-1"]
+	if got.Status != planning.StatusVerified || got.VerificationRun == nil || !got.VerificationRun.Passed() || len(got.Evidence) != 1 {
+		t.Fatalf("unexpected state: %+v", got)
+	}
+}
+
 func TestSyncCmd_UpdatesStatuses(t *testing.T) {
 	repoRoot := findRepoRoot(t)
 	root, cleanup := withTempDir(t)
@@ -737,6 +883,52 @@ func TestDriftDetectCmd_JSON(t *testing.T) {
 	}
 }
 
+func TestDriftDetectCmd_SARIFFailOn(t *testing.T) {
+	_, cleanup := withTempDir(t)
+	defer cleanup()
+
+	repo := storage.NewFilesystemRepository(".")
+	_ = repo.Initialize()
+	_ = repo.SaveSpec(&spec.ProductSpec{
+		ID:    "spec-1",
+		Title: "Project",
+		Features: []spec.Feature{
+			{ID: "f1", Title: "Feature", Requirements: []spec.Requirement{
+				{ID: "r1", Title: "Req", Source: spec.Source{Doc: "docs/req.md", Line: 7}},
+			}},
+		},
+	})
+	_ = repo.SavePlan(&planning.Plan{ID: "p1"})
+	_ = repo.SaveState(planning.NewExecutionState("p1"))
+
+	_ = driftDetectCmd.Flags().Set("output", "sarif")
+	defer func() {
+		_ = driftDetectCmd.Flags().Set("output", "text")
+		_ = driftDetectCmd.Flags().Set("fail-on", "low")
+	}()
+
+	// The missing task is high severity: --fail-on critical passes.
+	_ = driftDetectCmd.Flags().Set("fail-on", "critical")
+	var runErr error
+	output := captureStdout(t, func() {
+		runErr = driftDetectCmd.RunE(driftDetectCmd, []string{})
+	})
+	if runErr != nil {
+		t.Fatalf("expected --fail-on critical to pass, got %v", runErr)
+	}
+	if !strings.Contains(output, `"version": "2.1.0"`) || !strings.Contains(output, `"uri": "docs/req.md"`) {
+		t.Errorf("expected SARIF located at docs/req.md, got:\n%s", output)
+	}
+
+	_ = driftDetectCmd.Flags().Set("fail-on", "high")
+	_ = captureStdout(t, func() {
+		runErr = driftDetectCmd.RunE(driftDetectCmd, []string{})
+	})
+	if runErr == nil {
+		t.Fatal("expected --fail-on high to fail")
+	}
+}
+
 func TestSpecExplainCmd_AI(t *testing.T) {
 	_, cleanup := withTempDir(t)
 	defer cleanup()
@@ -2,8 +2,14 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
+	"os"
+	"strings"
+	"text/tabwriter"
 
+	"github.com/felixgeelhaar/roady/pkg/application"
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	"github.com/spf13/cobra"
 )
@@ -202,6 +208,240 @@ var planSmartDecomposeCmd = &cobra.Command{
 	},
 }
 
+var planHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List approved plan revisions",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		outputFormat, _ := cmd.Flags().GetString("output")
+		services, err := loadServicesForCurrentDir()
+		if err != nil {
+			return err
+		}
+
+		revisions, err := services.Plan.PlanHistory()
+		if err != nil {
+			return MapError(fmt.Errorf("failed to load plan history: %w", err))
+		}
+
+		if outputFormat == "json" {
+			data, _ := json.MarshalIndent(revisions, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if len(revisions) == 0 {
+			fmt.Println("No approved plan revisions yet. Run 'roady plan approve' to record one.")
+			return nil
+		}
+
+		current := ""
+		if plan, _ := services.Plan.GetPlan(); plan != nil {
+			current = plan.RevisionHash()
+		}
+		fmt.Printf("Plan history (%d):\n", len(revisions))
+		for i := len(revisions) - 1; i >= 0; i-- {
+			r := revisions[i]
+			marker := " "
+			if r.Hash == current {
+				marker = "*"
+			}
+			note := ""
+			if r.Restored {
+				note = " (restored)"
+			}
+			fmt.Printf("%s %s  %s  %-12s %3d tasks%s\n", marker, r.ShortHash(), r.ApprovedAt.Format("2006-01-02 15:04"), r.ApprovedBy, r.TaskCount, note)
+		}
+		return nil
+	},
+}
+
+var planDiffCmd = &cobra.Command{
+	Use:   "diff <rev1> [rev2]",
+	Short: "Show task and dependency changes between two plan revisions",
+	Long: `Compare two plan revisions and list added and removed tasks, changed task
+fields and added or removed dependency edges. A revision is a hash (or a
+unique prefix of at least 4 characters) from 'roady plan history', or
+'current' for plan.json. rev2 defaults to 'current'.
+
+Examples:
+  roady plan diff 3f2a9c1d             # revision against the current plan
+  roady plan diff 3f2a9c1d 9b07e4aa -o json`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		outputFormat, _ := cmd.Flags().GetString("output")
+		to := application.PlanRevisionCurrent
+		if len(args) == 2 {
+			to = args[1]
+		}
+
+		services, err := loadServicesForCurrentDir()
+		if err != nil {
+			return err
+		}
+
+		diff, err := services.Plan.DiffPlanRevisions(args[0], to)
+		if err != nil {
+			return MapError(fmt.Errorf("failed to diff plans: %w", err))
+		}
+
+		if outputFormat == "json" {
+			data, _ := json.MarshalIndent(diff, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if diff.IsEmpty() {
+			fmt.Printf("No plan changes between %s and %s.\n", args[0], to)
+			return nil
+		}
+
+		fmt.Printf("Plan changes %s -> %s:\n", planning.ShortRevision(diff.BaseHash), planning.ShortRevision(diff.CurrentHash))
+		for _, c := range diff.Tasks {
+			switch c.Kind {
+			case planning.TaskAdded:
+				fmt.Printf("  + task %s: %s\n", c.TaskID, c.Title)
+			case planning.TaskRemoved:
+				fmt.Printf("  - task %s: %s\n", c.TaskID, c.Title)
+			default:
+				fmt.Printf("  ~ task %s %s: %q -> %q\n", c.TaskID, c.Field, c.Old, c.New)
+			}
+		}
+		for _, e := range diff.Dependencies {
+			sign := "+"
+			if e.Kind == planning.TaskRemoved {
+				sign = "-"
+			}
+			fmt.Printf("  %s depends %s -> %s\n", sign, e.From, e.To)
+		}
+		return nil
+	},
+}
+
+var planRollbackCmd = &cobra.Command{
+	Use:   "rollback <rev>",
+	Short: "Restore an earlier plan revision for re-approval",
+	Long: `Restore a revision from 'roady plan history' as the current plan. The
+restored plan is pending: approve it with 'roady plan approve' before
+tasks can be started again. Task execution state is kept.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		services, err := loadServicesForCurrentDir()
+		if err != nil {
+			return err
+		}
+
+		actor := os.Getenv("USER")
+		if actor == "" {
+			actor = "cli"
+		}
+		plan, err := services.Plan.RollbackPlan(cmd.Context(), args[0], actor)
+		if err != nil {
+			return MapError(fmt.Errorf("failed to roll back plan: %w", err))
+		}
+
+		fmt.Printf("Plan rolled back to %s (%d tasks). Status: %s\n", planning.ShortRevision(plan.RevisionHash()), len(plan.Tasks), plan.ApprovalStatus)
+		fmt.Println("Run 'roady plan approve' to make it the active plan.")
+		return nil
+	},
+}
+
+var planScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Show the critical path, slack and a simulated schedule",
+	Long: `Compute the earliest and latest start, slack and critical path of every
+task from its estimate and the current execution state, then simulate the
+remaining work with at most --team-size tasks (or the policy's max_wip, if
+smaller) in progress at once. Tasks without an estimate count as 1d.
+
+Times are work hours (8h days, weekdays only) from now.
+
+Examples:
+  roady plan schedule                  # table
+  roady plan schedule -o gantt         # text Gantt chart, critical tasks as #
+  roady plan schedule -o mermaid       # Mermaid gantt diagram
+  roady plan schedule --team-size 2 -o json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		outputFormat, _ := cmd.Flags().GetString("output")
+		teamSize, _ := cmd.Flags().GetInt("team-size")
+		maxWIP, _ := cmd.Flags().GetInt("wip")
+
+		services, err := loadServicesForCurrentDir()
+		if err != nil {
+			return err
+		}
+
+		sched, err := services.Schedule.GetSchedule(application.ScheduleRequest{TeamSize: teamSize, MaxWIP: maxWIP})
+		if err != nil {
+			return MapError(fmt.Errorf("failed to schedule plan: %w", err))
+		}
+		if sched == nil {
+			return MapError(fmt.Errorf("no plan found; run 'roady plan generate' first"))
+		}
+
+		switch outputFormat {
+		case "json":
+			data, _ := json.MarshalIndent(sched, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		case "mermaid":
+			fmt.Print(sched.Mermaid())
+			return nil
+		case "gantt":
+			fmt.Print(sched.Gantt(60))
+			return nil
+		}
+
+		if sched.TotalHours == 0 {
+			fmt.Println("All tasks are complete.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "TASK\tSTATUS\tREMAINING\tEARLIEST\tLATEST\tSLACK\tSTART\tFINISH\t")
+		for _, t := range sched.Tasks {
+			if t.Remaining == 0 {
+				continue
+			}
+			id := t.ID
+			if t.Critical {
+				id = "* " + id
+			}
+			remaining := formatHours(t.Remaining)
+			if !t.Estimated {
+				remaining += "?"
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n", id, t.Status, remaining,
+				formatHours(t.EarliestStart), formatHours(t.LatestStart), formatHours(t.Slack),
+				sched.Date(t.Start).Format("01-02 15:04"), sched.Date(t.Finish).Format("01-02 15:04"))
+		}
+		_ = w.Flush()
+
+		fmt.Printf("\nCritical path: %s (%s)\n", strings.Join(sched.CriticalPath, " -> "), formatHours(sched.CriticalPathHours))
+		capacity := "unlimited"
+		if sched.Capacity > 0 {
+			capacity = fmt.Sprintf("%d in parallel", sched.Capacity)
+		}
+		fmt.Printf("Finishes: %s (%s, %s)\n", sched.FinishesAt().Format("2006-01-02 15:04"), formatHours(sched.TotalHours), capacity)
+		if len(sched.Unestimated) > 0 {
+			fmt.Printf("Unestimated (counted as 1d, marked ?): %s\n", strings.Join(sched.Unestimated, ", "))
+		}
+		return nil
+	},
+}
+
+// formatHours formats work hours as days and hours, e.g. "1d4h".
+func formatHours(h float64) string {
+	d := int(h) / planning.HoursPerDay
+	rest := math.Round((h-float64(d*planning.HoursPerDay))*10) / 10
+	switch {
+	case d > 0 && rest > 0:
+		return fmt.Sprintf("%dd%gh", d, rest)
+	case d > 0:
+		return fmt.Sprintf("%dd", d)
+	}
+	return fmt.Sprintf("%gh", rest)
+}
+
 func init() {
 
 	planGenerateCmd.Flags().BoolVar(&useAI, "ai", false, "Use AI to decompose the spec into tasks")
@@ -218,6 +458,19 @@ func init() {
 
 	planCmd.AddCommand(planSmartDecomposeCmd)
 
+	planHistoryCmd.Flags().StringP("output", "o", "text", "Output format (text, json)")
+	planCmd.AddCommand(planHistoryCmd)
+
+	planDiffCmd.Flags().StringP("output", "o", "text", "Output format (text, json)")
+	planCmd.AddCommand(planDiffCmd)
+
+	planCmd.AddCommand(planRollbackCmd)
+
+	planScheduleCmd.Flags().StringP("output", "o", "table", "Output format (table, gantt, mermaid, json)")
+	planScheduleCmd.Flags().Int("team-size", 0, "People working in parallel (default: team members who can transition tasks)")
+	planScheduleCmd.Flags().Int("wip", 0, "WIP limit (default: the policy's max_wip)")
+	planCmd.AddCommand(planScheduleCmd)
+
 	RootCmd.AddCommand(planCmd)
 
 }
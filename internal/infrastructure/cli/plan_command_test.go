@@ -141,3 +141,95 @@ func TestPlanCommandsAuditTrail(t *testing.T) {
 		}
 	}
 }
+
+func TestPlanHistoryDiffRollbackCommands(t *testing.T) {
+	_, cleanup := withTempDir(t)
+	defer cleanup()
+
+	repo := storage.NewFilesystemRepository(".")
+	if err := repo.Initialize(); err != nil {
+		t.Fatalf("init repo: %v", err)
+	}
+	v1 := &planning.Plan{ID: "p1", ApprovalStatus: planning.ApprovalPending, Tasks: []planning.Task{{ID: "t1", Title: "Login"}}}
+	_ = repo.SavePlan(v1)
+	if err := planApproveCmd.RunE(planApproveCmd, []string{}); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	_ = repo.SavePlan(&planning.Plan{ID: "p1", ApprovalStatus: planning.ApprovalPending, Tasks: []planning.Task{
+		{ID: "t1", Title: "Login", DependsOn: []string{"t2"}},
+		{ID: "t2", Title: "Sessions"},
+	}})
+
+	output := captureStdout(t, func() {
+		if err := planHistoryCmd.RunE(planHistoryCmd, []string{}); err != nil {
+			t.Fatalf("history: %v", err)
+		}
+	})
+	if !strings.Contains(output, planning.ShortRevision(v1.RevisionHash())) {
+		t.Fatalf("expected the approved revision in history, got %q", output)
+	}
+
+	output = captureStdout(t, func() {
+		if err := planDiffCmd.RunE(planDiffCmd, []string{v1.RevisionHash()[:8]}); err != nil {
+			t.Fatalf("diff: %v", err)
+		}
+	})
+	if !strings.Contains(output, "+ task t2: Sessions") || !strings.Contains(output, "+ depends t1 -> t2") {
+		t.Fatalf("unexpected diff output: %q", output)
+	}
+
+	output = captureStdout(t, func() {
+		if err := planRollbackCmd.RunE(planRollbackCmd, []string{v1.RevisionHash()[:8]}); err != nil {
+			t.Fatalf("rollback: %v", err)
+		}
+	})
+	if !strings.Contains(output, "roady plan approve") {
+		t.Fatalf("unexpected rollback output: %q", output)
+	}
+	if loaded, _ := repo.LoadPlan(); len(loaded.Tasks) != 1 || loaded.ApprovalStatus != planning.ApprovalPending {
+		t.Fatalf("expected v1 restored as pending, got %+v", loaded)
+	}
+}
+
+func TestPlanScheduleCommand(t *testing.T) {
+	_, cleanup := withTempDir(t)
+	defer cleanup()
+
+	repo := storage.NewFilesystemRepository(".")
+	if err := repo.Initialize(); err != nil {
+		t.Fatalf("init repo: %v", err)
+	}
+	_ = repo.SavePlan(&planning.Plan{ID: "p1", Tasks: []planning.Task{
+		{ID: "t1", Title: "Login", Estimate: "1d"},
+		{ID: "t2", Title: "Sessions", Estimate: "4h", DependsOn: []string{"t1"}},
+		{ID: "t3", Title: "Docs", Estimate: "2h"},
+	}})
+
+	output := captureStdout(t, func() {
+		if err := planScheduleCmd.RunE(planScheduleCmd, []string{}); err != nil {
+			t.Fatalf("schedule: %v", err)
+		}
+	})
+	if !strings.Contains(output, "* t1") || !strings.Contains(output, "Critical path: t1 -> t2 (1d4h)") {
+		t.Fatalf("unexpected schedule output: %q", output)
+	}
+
+	_ = planScheduleCmd.Flags().Set("output", "mermaid")
+	defer func() { _ = planScheduleCmd.Flags().Set("output", "table") }()
+	output = captureStdout(t, func() {
+		if err := planScheduleCmd.RunE(planScheduleCmd, []string{}); err != nil {
+			t.Fatalf("schedule: %v", err)
+		}
+	})
+	if !strings.HasPrefix(output, "gantt\n") || !strings.Contains(output, "Sessions :crit, t2, ") {
+		t.Fatalf("unexpected mermaid output: %q", output)
+	}
+}
+
+func TestFormatHours(t *testing.T) {
+	for h, want := range map[float64]string{0: "0h", 2.5: "2.5h", 8: "1d", 12: "1d4h", 1.0 / 3: "0.3h"} {
+		if got := formatHours(h); got != want {
+			t.Errorf("formatHours(%v) = %q, want %q", h, got, want)
+		}
+	}
+}
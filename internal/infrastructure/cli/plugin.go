@@ -62,6 +62,26 @@ var pluginRegisterCmd = &cobra.Command{
 	},
 }
 
+var pluginConflictPolicyCmd = &cobra.Command{
+	Use:   "conflict-policy <name> <roady-wins|remote-wins|last-writer-wins>",
+	Short: "Set how two-way field sync resolves conflicting edits",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		services, err := loadServicesForCurrentDir()
+		if err != nil {
+			return err
+		}
+
+		svc := application.NewPluginService(services.Workspace.Repo)
+		if err := svc.SetConflictPolicy(args[0], args[1]); err != nil {
+			return err
+		}
+
+		fmt.Printf("Plugin %q conflict policy: %s\n", args[0], args[1])
+		return nil
+	},
+}
+
 var pluginUnregisterCmd = &cobra.Command{
 	Use:   "unregister <name>",
 	Short: "Unregister a syncer plugin",
@@ -154,6 +174,7 @@ func init() {
 	pluginCmd.AddCommand(pluginListCmd)
 	pluginCmd.AddCommand(pluginRegisterCmd)
 	pluginCmd.AddCommand(pluginUnregisterCmd)
+	pluginCmd.AddCommand(pluginConflictPolicyCmd)
 	pluginCmd.AddCommand(pluginValidateCmd)
 	pluginCmd.AddCommand(pluginStatusCmd)
 	RootCmd.AddCommand(pluginCmd)
@@ -1,10 +1,10 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 
-	"github.com/felixgeelhaar/roady/internal/infrastructure/wiring"
-	"github.com/felixgeelhaar/roady/pkg/application"
+	"github.com/felixgeelhaar/roady/internal/infrastructure/report"
 	"github.com/felixgeelhaar/roady/pkg/domain/policy"
 	"github.com/spf13/cobra"
 )
@@ -18,18 +18,57 @@ var policyCheckCmd = &cobra.Command{
 	Use:   "check",
 	Short: "Check if the current plan complies with policies",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cwd, err := getProjectRoot()
+		outputFormat, failOn, err := findingsFlags(cmd)
 		if err != nil {
-			return fmt.Errorf("resolve project path: %w", err)
+			return err
 		}
-		repo := wiring.NewWorkspace(cwd).Repo
-		service := application.NewPolicyService(repo)
+
+		services, err := loadServicesForCurrentDir()
+		if err != nil {
+			return err
+		}
+		service := services.Policy
 
 		violations, err := service.CheckCompliance()
 		if err != nil {
 			return fmt.Errorf("failed to check policy: %w", err)
 		}
 
+		var failErr error
+		if report.PolicyFails(violations, failOn) {
+			failErr = fmt.Errorf("policy violations found")
+		}
+
+		switch outputFormat {
+		case formatJSON:
+			data, _ := json.MarshalIndent(violations, "", "  ")
+			fmt.Println(string(data))
+			return failErr
+		case formatSARIF:
+			plan, _ := services.Plan.GetPlan()
+			data, err := report.PolicySARIF(violations, plan, reportOptions(services))
+			if err != nil {
+				return fmt.Errorf("failed to render SARIF: %w", err)
+			}
+			fmt.Println(string(data))
+			return failErr
+		case formatJUnit:
+			rules, err := service.ActiveRules()
+			if err != nil {
+				return fmt.Errorf("failed to load policy rules: %w", err)
+			}
+			ruleIDs := make([]string, 0, len(rules))
+			for _, r := range rules {
+				ruleIDs = append(ruleIDs, r.ID())
+			}
+			data, err := report.PolicyJUnit(ruleIDs, violations)
+			if err != nil {
+				return fmt.Errorf("failed to render JUnit: %w", err)
+			}
+			fmt.Println(string(data))
+			return failErr
+		}
+
 		if len(violations) == 0 {
 			fmt.Println("No policy violations found.")
 			return nil
@@ -46,11 +85,12 @@ var policyCheckCmd = &cobra.Command{
 			fmt.Printf("%s %s: %s\n", color, v.RuleID, v.Message)
 		}
 
-		return fmt.Errorf("policy violations found")
+		return failErr
 	},
 }
 
 func init() {
+	addFindingsFlags(policyCheckCmd)
 	policyCmd.AddCommand(policyCheckCmd)
 	RootCmd.AddCommand(policyCmd)
 }
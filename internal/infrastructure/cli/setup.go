@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/felixgeelhaar/roady/pkg/application"
 	"github.com/spf13/cobra"
 )
 
@@ -22,13 +23,15 @@ Supported targets:
   openai         - Setup for OpenAI Codex (via MCP)
   gemini         - Setup for Google Gemini (via MCP bridge)
   global         - Install commands globally and setup MCP
+  git-merge      - Merge .roady/ plan, state and events semantically in git
 
 Examples:
   roady setup claude-code
   roady setup opencode
   roady setup openai
   roady setup claude-desktop
-  roady setup global`,
+  roady setup global
+  roady setup git-merge`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		target := "claude-code"
 		if len(args) > 0 {
@@ -48,8 +51,10 @@ Examples:
 			return setupGemini()
 		case "global":
 			return setupGlobal()
+		case "git-merge":
+			return setupGitMerge(cmd)
 		default:
-			return fmt.Errorf("unknown target: %s (supported: claude-code, claude-desktop, opencode, openai, gemini, global)", target)
+			return fmt.Errorf("unknown target: %s (supported: claude-code, claude-desktop, opencode, openai, gemini, global, git-merge)", target)
 		}
 	},
 }
@@ -179,6 +184,27 @@ func setupGlobal() error {
 	return nil
 }
 
+func setupGitMerge(cmd *cobra.Command) error {
+	fmt.Println("🚀 Setting up the Roady git merge driver...")
+
+	root, err := getProjectRoot()
+	if err != nil {
+		return fmt.Errorf("resolve project path: %w", err)
+	}
+	svc := application.NewWorkspaceSyncService(root, nil)
+	if err := svc.InstallMergeDriver(cmd.Context()); err != nil {
+		return err
+	}
+	fmt.Println("  ✓ Registered merge driver in .git/config")
+	fmt.Println("  ✓ Routed .roady/ plan, state and events files in .gitattributes")
+
+	fmt.Println("\n✅ Git merge setup complete!")
+	fmt.Println("\nNext steps:")
+	fmt.Println("  1. Commit .gitattributes so collaborators share it")
+	fmt.Println("  2. Each collaborator runs 'roady setup git-merge' once to register the driver")
+	return nil
+}
+
 func setupOpenCode() error {
 	fmt.Println("🚀 Setting up Roady for OpenCode...")
 
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/felixgeelhaar/roady/internal/infrastructure/wiring"
 	"github.com/felixgeelhaar/roady/pkg/application"
+	"github.com/felixgeelhaar/roady/pkg/domain/spec"
 	"github.com/spf13/cobra"
 )
 
@@ -50,7 +52,7 @@ var specExplainCmd = &cobra.Command{
 
 var specImportCmd = &cobra.Command{
 	Use:   "import [file]",
-	Short: "Import a spec from a markdown file",
+	Short: "Import a spec from a markdown, Gherkin (.feature), OpenAPI or ADR file",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cwd, err := getProjectRoot()
@@ -61,12 +63,12 @@ var specImportCmd = &cobra.Command{
 		service := application.NewSpecService(repo)
 		filePath := args[0]
 
-		spec, err := service.ImportFromMarkdown(filePath)
+		spec, err := service.ImportFile(filePath)
 		if err != nil {
 			return MapError(fmt.Errorf("failed to import spec: %w", err))
 		}
 
-		fmt.Printf("Successfully imported spec '%s' with %d features.\n", spec.Title, len(spec.Features))
+		fmt.Printf("Successfully imported spec '%s' with %d features and %d constraints.\n", spec.Title, len(spec.Features), len(spec.Constraints))
 		return nil
 	},
 }
@@ -100,11 +102,64 @@ var specValidateCmd = &cobra.Command{
 	},
 }
 
+var specDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show per-requirement changes between the spec and the lock or a git ref",
+	Long: `Compare the current spec with a baseline and list added, removed, renamed
+and modified features, requirements and constraints.
+
+Examples:
+  roady spec diff                   # against spec.lock.json
+  roady spec diff --against main    # against spec.yaml committed on main
+  roady spec diff -o json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		against, _ := cmd.Flags().GetString("against")
+		outputFormat, _ := cmd.Flags().GetString("output")
+
+		services, err := loadServicesForCurrentDir()
+		if err != nil {
+			return err
+		}
+
+		diff, err := services.Spec.Diff(cmd.Context(), against)
+		if err != nil {
+			return MapError(fmt.Errorf("failed to diff spec: %w", err))
+		}
+
+		if outputFormat == "json" {
+			data, _ := json.MarshalIndent(diff, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if diff.IsEmpty() {
+			fmt.Printf("No spec changes against %s.\n", against)
+			return nil
+		}
+
+		fmt.Printf("Spec changes against %s (%d):\n", against, len(diff.Changes))
+		for _, c := range diff.Changes {
+			switch c.Kind {
+			case spec.ChangeAdded:
+				fmt.Printf("  + %s %s: %s\n", c.Element, c.ID, c.New)
+			case spec.ChangeRemoved:
+				fmt.Printf("  - %s %s: %s\n", c.Element, c.ID, c.Old)
+			default:
+				fmt.Printf("  ~ %s %s %s: %q -> %q\n", c.Element, c.ID, c.Field, c.Old, c.New)
+			}
+			if c.Source.Doc != "" {
+				fmt.Printf("      at %s:%d\n", c.Source.Doc, c.Source.Line)
+			}
+		}
+		return nil
+	},
+}
+
 var reconcileSpec bool
 
 var specAnalyzeCmd = &cobra.Command{
 	Use:   "analyze [dir]",
-	Short: "Analyze a directory for markdown files and infer a product specification",
+	Short: "Analyze a directory of markdown, Gherkin, OpenAPI and ADR files and infer a product specification",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		dir := "."
 		if len(args) > 0 {
@@ -140,7 +195,7 @@ var specAnalyzeCmd = &cobra.Command{
 			}
 		}
 
-		fmt.Printf("Successfully analyzed directory and generated spec '%s' with %d features.\n", spec.Title, len(spec.Features))
+		fmt.Printf("Successfully analyzed directory and generated spec '%s' with %d features and %d constraints.\n", spec.Title, len(spec.Features), len(spec.Constraints))
 		return nil
 	},
 }
@@ -301,5 +356,8 @@ func init() {
 	specCmd.AddCommand(specReviewCmd)
 	specCmd.AddCommand(specAnalyzeCmd)
 	specCmd.AddCommand(specParseCmd)
+	specDiffCmd.Flags().String("against", application.DiffAgainstLock, "Baseline to compare with: 'lock' or a git ref")
+	specDiffCmd.Flags().StringP("output", "o", "text", "Output format (text, json)")
+	specCmd.AddCommand(specDiffCmd)
 	RootCmd.AddCommand(specCmd)
 }
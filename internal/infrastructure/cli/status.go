@@ -72,6 +72,8 @@ type taskJSONOutput struct {
 	Priority string `json:"priority"`
 	Origin   string `json:"origin,omitempty"`
 	Unlocked bool   `json:"unlocked,omitempty"`
+
+	Criteria *planning.CriteriaCoverage `json:"criteria,omitempty"`
 }
 
 type driftJSONOutput struct {
@@ -163,14 +165,19 @@ func outputStatusJSON(productSpec *spec.ProductSpec, plan *planning.Plan, state
 		filteredTasks := filterTasks(plan.Tasks, state)
 		for _, t := range filteredTasks {
 			status := getTaskStatus(t.ID, state)
-			planOutput.Items = append(planOutput.Items, taskJSONOutput{
+			item := taskJSONOutput{
 				ID:       t.ID,
 				Title:    t.Title,
 				Status:   string(status),
 				Priority: string(t.Priority),
 				Origin:   string(t.NormalisedOrigin()),
 				Unlocked: isTaskUnlocked(t, state, plan),
-			})
+			}
+			if len(t.AcceptanceCriteria) > 0 {
+				coverage := taskCoverage(t, state)
+				item.Criteria = &coverage
+			}
+			planOutput.Items = append(planOutput.Items, item)
 		}
 
 		output.Plan = planOutput
@@ -246,6 +253,9 @@ func outputStatusText(cmd *cobra.Command, productSpec *spec.ProductSpec, plan *p
 				marker += fmt.Sprintf(" from %s", t.Source.Doc)
 			}
 		}
+		if len(t.AcceptanceCriteria) > 0 {
+			marker += fmt.Sprintf(" [criteria %s]", taskCoverage(t, state))
+		}
 		fmt.Printf("%s [%-11s] %-40s (Priority: %s)%s\n", prefix, status, t.Title, t.Priority, marker)
 	}
 
@@ -385,6 +395,16 @@ func getStatusPrefix(status planning.TaskStatus) string {
 	}
 }
 
+// taskCoverage reports how many of the task's acceptance criteria have
+// evidence recorded in state.
+func taskCoverage(task planning.Task, state *planning.ExecutionState) planning.CriteriaCoverage {
+	var result planning.TaskResult
+	if state != nil {
+		result = state.TaskStates[task.ID]
+	}
+	return task.Coverage(result)
+}
+
 // isTaskUnlocked checks if a task is unlocked (for JSON output)
 func isTaskUnlocked(task planning.Task, state *planning.ExecutionState, plan *planning.Plan) bool {
 	status := getTaskStatus(task.ID, state)
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/felixgeelhaar/roady/pkg/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -41,7 +42,15 @@ func emptyStateHintForRoot(root string) (emptyStateStep, bool) {
 		}, true
 	}
 
-	if !pathExists(filepath.Join(roadyDir, "spec.yaml")) {
+	hasSpec := pathExists(filepath.Join(roadyDir, "spec.yaml"))
+	hasPlan := pathExists(filepath.Join(roadyDir, "plan.json"))
+	if repo, err := storage.OpenRepository(root, ""); err == nil && repo.Backend() != storage.BackendFiles {
+		_, specErr := repo.LoadSpec()
+		plan, _ := repo.LoadPlan()
+		hasSpec, hasPlan = specErr == nil, plan != nil
+	}
+
+	if !hasSpec {
 		return emptyStateStep{
 			Stage:   "no-spec",
 			Reason:  "No spec.yaml yet — Roady doesn't know what you're building.",
@@ -50,7 +59,7 @@ func emptyStateHintForRoot(root string) (emptyStateStep, bool) {
 		}, true
 	}
 
-	if !pathExists(filepath.Join(roadyDir, "plan.json")) {
+	if !hasPlan {
 		return emptyStateStep{
 			Stage:   "no-plan",
 			Reason:  "Spec exists but no plan has been generated.",
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/felixgeelhaar/roady/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+var storageCmd = &cobra.Command{
+	Use:   "storage",
+	Short: "Manage the project's storage backend",
+}
+
+var (
+	storageMigrateTo  string
+	storageJSONOutput bool
+)
+
+var storageMigrateCmd = &cobra.Command{
+	Use:   "migrate --to sqlite|files",
+	Short: "Move the project's data to another storage backend",
+	Long: `Move the spec, plan, state, policy, usage, billing data, plan history and
+event log to the files backend (one file per artifact, events.jsonl) or the
+sqlite backend (.roady/roady.db). The copy is read back and compared before
+storage.yaml is switched and the old data removed, so migrating back and
+forth is lossless and the audit hash chain stays verifiable.
+
+Stop other roady processes on the project (MCP server, dashboard, watch)
+before migrating.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := getProjectRoot()
+		if err != nil {
+			return err
+		}
+
+		result, err := storage.Migrate(root, currentSubProject(), storageMigrateTo)
+		if err != nil {
+			return MapError(fmt.Errorf("migrate storage: %w", err))
+		}
+
+		if storageJSONOutput {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(result)
+		}
+
+		fmt.Printf("Migrated from %s to %s: %d artifacts, %d events, %d plan revisions\n",
+			result.From, result.To, result.Artifacts, result.Events, result.Revisions)
+		return nil
+	},
+}
+
+func init() {
+	storageMigrateCmd.Flags().StringVar(&storageMigrateTo, "to", "", "Target backend: sqlite or files")
+	_ = storageMigrateCmd.MarkFlagRequired("to")
+	storageMigrateCmd.Flags().BoolVar(&storageJSONOutput, "json", false, "Output in JSON format")
+	storageCmd.AddCommand(storageMigrateCmd)
+	RootCmd.AddCommand(storageCmd)
+}
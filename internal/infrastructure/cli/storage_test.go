@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/spec"
+	"github.com/felixgeelhaar/roady/pkg/storage"
+)
+
+func TestStorageMigrateCmd(t *testing.T) {
+	dir, cleanup := withTempDir(t)
+	defer cleanup()
+
+	repo := storage.NewFilesystemRepository(".")
+	if err := repo.SaveSpec(&spec.ProductSpec{ID: "s1", Title: "Shop"}); err != nil {
+		t.Fatal(err)
+	}
+
+	storageMigrateTo = storage.BackendSQLite
+	storageJSONOutput = false
+	defer func() { storageMigrateTo = "" }()
+
+	out := captureStdout(t, func() {
+		if err := storageMigrateCmd.RunE(storageMigrateCmd, nil); err != nil {
+			t.Fatalf("migrate to sqlite: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Migrated from files to sqlite: 1 artifacts") {
+		t.Errorf("unexpected output: %q", out)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".roady", storage.SQLiteFile)); err != nil {
+		t.Errorf("expected database: %v", err)
+	}
+
+	if err := storageMigrateCmd.RunE(storageMigrateCmd, nil); err == nil {
+		t.Error("expected migrating to the current backend to fail")
+	}
+
+	storageMigrateTo = storage.BackendFiles
+	_ = captureStdout(t, func() {
+		if err := storageMigrateCmd.RunE(storageMigrateCmd, nil); err != nil {
+			t.Fatalf("migrate to files: %v", err)
+		}
+	})
+	if s, err := repo.LoadSpec(); err != nil || s.Title != "Shop" {
+		t.Errorf("LoadSpec after round trip = %+v, %v", s, err)
+	}
+}
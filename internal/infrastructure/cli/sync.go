@@ -1,14 +1,20 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
 	"sort"
+	"syscall"
 
+	"github.com/felixgeelhaar/roady/pkg/application"
 	"github.com/spf13/cobra"
 )
 
 var (
 	syncPluginName string
+	syncWatch      bool
 )
 
 var syncCmd = &cobra.Command{
@@ -24,6 +30,10 @@ You can use this command in two ways:
 2. Using a plugin binary path directly (uses environment variables):
    roady sync ./roady-plugin-jira
 
+With --watch, Roady subscribes to the plugin's change feed and applies
+external status changes and links as they happen, until interrupted. This
+needs a plugin that speaks version 2 of the plugin protocol.
+
 Configure plugins in .roady/plugins.yaml:
   plugins:
     my-jira:
@@ -40,6 +50,10 @@ Configure plugins in .roady/plugins.yaml:
 			return err
 		}
 
+		if syncWatch {
+			return runSyncWatch(cmd.Context(), services.Sync, args)
+		}
+
 		var results []string
 
 		if syncPluginName != "" {
@@ -77,6 +91,34 @@ Configure plugins in .roady/plugins.yaml:
 	},
 }
 
+// runSyncWatch applies a plugin's change feed until SIGINT or SIGTERM.
+func runSyncWatch(parent context.Context, svc *application.SyncService, args []string) error {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sig)
+	go func() {
+		select {
+		case <-sig:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	report := func(res string) { fmt.Printf("- %s\n", res) }
+	switch {
+	case syncPluginName != "":
+		fmt.Printf("Watching %s for external changes (Ctrl+C to stop)...\n", syncPluginName)
+		return svc.WatchWithNamedPlugin(ctx, syncPluginName, report)
+	case len(args) == 1:
+		fmt.Printf("Watching %s for external changes (Ctrl+C to stop)...\n", args[0])
+		return svc.WatchWithPluginConfig(ctx, args[0], map[string]string{}, report)
+	default:
+		return fmt.Errorf("either --name flag or plugin-path argument is required")
+	}
+}
+
 var syncListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List configured plugins",
@@ -154,6 +196,7 @@ func isSensitiveKey(key string) bool {
 
 func init() {
 	syncCmd.Flags().StringVarP(&syncPluginName, "name", "n", "", "Use named plugin configuration from plugins.yaml")
+	syncCmd.Flags().BoolVar(&syncWatch, "watch", false, "Apply external changes as the plugin streams them, until interrupted")
 	syncCmd.AddCommand(syncListCmd)
 	syncCmd.AddCommand(syncShowCmd)
 	RootCmd.AddCommand(syncCmd)
@@ -1,14 +1,18 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/felixgeelhaar/roady/internal/infrastructure/wiring"
 	"github.com/felixgeelhaar/roady/pkg/application"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	"github.com/felixgeelhaar/roady/pkg/domain/project"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
 	"github.com/spf13/cobra"
 )
 
@@ -20,6 +24,9 @@ var taskCmd = &cobra.Command{
 func createTaskCommand(use, short, event string) *cobra.Command {
 	var evidence string
 	var rateID string
+	var criteria []string
+	var run bool
+	var runTimeout time.Duration
 	cmd := &cobra.Command{
 		Use:   use,
 		Short: short,
@@ -36,17 +43,26 @@ func createTaskCommand(use, short, event string) *cobra.Command {
 			service := application.NewTaskService(repo, audit, policy)
 			taskID := args[0]
 
-			actor := os.Getenv("USER")
-			if actor == "" {
-				actor = "unknown-human"
-			}
+			actor := localActor()
 
-			if event == "start" {
+			switch event {
+			case "start":
 				err := service.StartTask(cmd.Context(), taskID, actor, rateID)
 				if err != nil {
 					return MapError(fmt.Errorf("failed to start task: %w", err))
 				}
-			} else {
+			case "verify":
+				proofs, err := service.ParseCriteriaEvidence(taskID, criteria)
+				if err != nil {
+					return MapError(fmt.Errorf("failed to verify task: %w", err))
+				}
+				if run {
+					return runTaskVerification(cmd, taskID, actor, runTimeout, proofs)
+				}
+				if err := service.VerifyTask(cmd.Context(), taskID, actor, proofs...); err != nil {
+					return MapError(fmt.Errorf("failed to verify task: %w", err))
+				}
+			default:
 				err := service.TransitionTask(taskID, event, actor, evidence)
 				if err != nil {
 					return MapError(fmt.Errorf("failed to transition task: %w", err))
@@ -60,9 +76,39 @@ func createTaskCommand(use, short, event string) *cobra.Command {
 	if event == "start" {
 		cmd.Flags().StringVarP(&rateID, "rate", "r", "", "Rate ID to use for billing")
 	}
+	if event == "verify" {
+		cmd.Flags().StringArrayVarP(&criteria, "criterion", "c", nil, "Evidence for acceptance criterion N as N=[commit:|test:|url:|text:]value (repeatable)")
+		cmd.Flags().BoolVar(&run, "run", false, "Run the task's declared verification command and verify only if it passes")
+		cmd.Flags().DurationVar(&runTimeout, "timeout", 0, "Override the verification command's timeout (with --run)")
+	}
 	return cmd
 }
 
+// runTaskVerification runs a task's declared verification through the
+// event-sourced services so the run lands in the event store.
+func runTaskVerification(cmd *cobra.Command, taskID, actor string, timeout time.Duration, proofs []planning.CriterionEvidence) error {
+	services, err := loadServicesForCurrentDir()
+	if err != nil {
+		return err
+	}
+	result, err := services.Task.VerifyTaskWithRun(cmd.Context(), taskID, actor, timeout, proofs...)
+	if result.Command != "" {
+		fmt.Printf("Ran `%s` in %s (exit %d, output sha256 %s)\n", result.Command, result.Duration().Round(time.Millisecond), result.ExitCode, shortDigest(result.Digest))
+	}
+	if err != nil {
+		return MapError(fmt.Errorf("failed to verify task: %w", err))
+	}
+	fmt.Printf("Task %s transition 'verify' successful.\n", taskID)
+	return nil
+}
+
+func shortDigest(digest string) string {
+	if len(digest) > 12 {
+		return digest[:12]
+	}
+	return digest
+}
+
 var taskQueryJSON bool
 
 var taskReadyCmd = &cobra.Command{
@@ -140,6 +186,14 @@ func outputTaskSummaries(title string, tasks []project.TaskSummary, jsonOut bool
 	return nil
 }
 
+// localActor names the local user in the audit trail.
+func localActor() string {
+	if actor := os.Getenv("USER"); actor != "" {
+		return actor
+	}
+	return "unknown-human"
+}
+
 var taskAssignCmd = &cobra.Command{
 	Use:   "assign <task-id> <assignee>",
 	Short: "Assign a task to a person or agent",
@@ -155,7 +209,11 @@ var taskAssignCmd = &cobra.Command{
 		policy := application.NewPolicyService(repo)
 		service := application.NewTaskService(repo, audit, policy)
 
-		err := service.AssignTask(cmd.Context(), args[0], args[1])
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		err := service.AssignTask(team.WithActor(ctx, localActor()), args[0], args[1])
 		if err != nil {
 			return MapError(fmt.Errorf("failed to assign task: %w", err))
 		}
@@ -164,6 +222,32 @@ var taskAssignCmd = &cobra.Command{
 	},
 }
 
+var taskCommentCmd = &cobra.Command{
+	Use:   "comment <task-id> <text>",
+	Short: "Comment on a task",
+	Long:  "Comment on a task. Comments are pushed to external systems by plugins with two-way field sync.",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cwd, cErr := getProjectRoot()
+		if cErr != nil {
+			return fmt.Errorf("resolve project path: %w", cErr)
+		}
+		workspace := wiring.NewWorkspace(cwd)
+		repo := workspace.Repo
+		service := application.NewTaskService(repo, workspace.Audit, application.NewPolicyService(repo))
+
+		author := os.Getenv("USER")
+		if author == "" {
+			author = "unknown-human"
+		}
+		if _, err := service.CommentTask(args[0], planning.TaskComment{Author: author, Body: args[1]}); err != nil {
+			return MapError(fmt.Errorf("failed to comment on task: %w", err))
+		}
+		fmt.Printf("Comment added to task %s\n", args[0])
+		return nil
+	},
+}
+
 var taskStartRate string
 
 var taskLogCmd = &cobra.Command{
@@ -195,6 +279,7 @@ var taskLogCmd = &cobra.Command{
 
 func init() {
 	taskCmd.AddCommand(taskAssignCmd)
+	taskCmd.AddCommand(taskCommentCmd)
 	taskCmd.AddCommand(createTaskCommand("start", "Start a task", "start"))
 	taskCmd.AddCommand(createTaskCommand("block", "Block a task", "block"))
 	taskCmd.AddCommand(createTaskCommand("unblock", "Unblock a task", "unblock"))
@@ -88,7 +88,100 @@ var teamRemoveCmd = &cobra.Command{
 	},
 }
 
+var teamTokenLabel string
+
+var teamTokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage team members' API tokens",
+	Long: `Member API tokens authenticate callers of the dashboard, the MCP HTTP and
+WebSocket transports and the webhook server as a team member. Their role
+decides what they may change, and audit events record them as the actor.
+
+Only a hash of each token is kept in .roady/credentials.yaml.`,
+}
+
+var teamTokenIssueCmd = &cobra.Command{
+	Use:   "issue <name>",
+	Short: "Issue an API token for a team member",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		services, err := loadServicesForCurrentDir()
+		if err != nil {
+			return err
+		}
+
+		token, err := services.Team.IssueToken(args[0], teamTokenLabel)
+		if err != nil {
+			return MapError(fmt.Errorf("issue token: %w", err))
+		}
+
+		fmt.Printf("Token for %s (shown once, store it securely):\n%s\n", args[0], token)
+		return nil
+	},
+}
+
+var teamTokenRevokeCmd = &cobra.Command{
+	Use:   "revoke <name>",
+	Short: "Revoke a team member's API tokens (all of them unless --label is set)",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		services, err := loadServicesForCurrentDir()
+		if err != nil {
+			return err
+		}
+
+		n, err := services.Team.RevokeTokens(args[0], teamTokenLabel)
+		if err != nil {
+			return MapError(fmt.Errorf("revoke token: %w", err))
+		}
+
+		fmt.Printf("Revoked %d token(s) of %s\n", n, args[0])
+		return nil
+	},
+}
+
+var teamTokenListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List issued API tokens",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		services, err := loadServicesForCurrentDir()
+		if err != nil {
+			return err
+		}
+
+		tokens, err := services.Team.ListTokens()
+		if err != nil {
+			return MapError(fmt.Errorf("list tokens: %w", err))
+		}
+
+		if teamJSONOutput {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(tokens)
+		}
+
+		if len(tokens) == 0 {
+			fmt.Println("No API tokens issued.")
+			return nil
+		}
+
+		fmt.Printf("API Tokens (%d)\n", len(tokens))
+		for _, t := range tokens {
+			fmt.Printf("  %-20s %-16s %s\n", t.Member, t.Label, t.CreatedAt.Format("2006-01-02"))
+		}
+		return nil
+	},
+}
+
 func init() {
+	teamTokenIssueCmd.Flags().StringVar(&teamTokenLabel, "label", "", "Name of the token, e.g. the device or agent using it")
+	teamTokenRevokeCmd.Flags().StringVar(&teamTokenLabel, "label", "", "Revoke only the token with this label")
+	teamTokenListCmd.Flags().BoolVar(&teamJSONOutput, "json", false, "Output in JSON format")
+	teamTokenCmd.AddCommand(teamTokenIssueCmd)
+	teamTokenCmd.AddCommand(teamTokenRevokeCmd)
+	teamTokenCmd.AddCommand(teamTokenListCmd)
+	teamCmd.AddCommand(teamTokenCmd)
+
 	teamListCmd.Flags().BoolVar(&teamJSONOutput, "json", false, "Output in JSON format")
 	teamCmd.AddCommand(teamListCmd)
 	teamCmd.AddCommand(teamAddCmd)
@@ -10,6 +10,7 @@ import (
 
 	"github.com/felixgeelhaar/roady/internal/infrastructure/wiring"
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
 	"github.com/felixgeelhaar/roady/pkg/infrastructure/webhook"
 	"github.com/spf13/cobra"
 )
@@ -33,6 +34,7 @@ var (
 	webhookGitHubSecret string
 	webhookJiraSecret   string
 	webhookLinearSecret string
+	webhookActor        string
 )
 
 var webhookServeCmd = &cobra.Command{
@@ -50,7 +52,13 @@ Configure your external tools to send webhooks to these endpoints.
 Secrets can be provided to validate webhook signatures:
   --github-secret: GitHub webhook secret
   --jira-secret: Jira webhook secret (query param or Bearer token)
-  --linear-secret: Linear webhook signing secret`,
+  --linear-secret: Linear webhook signing secret
+
+Status changes are recorded in the audit log as --actor. Once the project
+has a team, the actor must be a member whose role allows task transitions;
+the server refuses to start when they are not on the team.
+Once member API tokens have been issued, GET /events requires one as a
+Bearer token.`,
 	Example: `  # Start server on port 8080
   roady webhook serve --port 8080
 
@@ -92,6 +100,27 @@ Secrets can be provided to validate webhook signatures:
 		server.RegisterHandler(webhook.NewJiraHandler())
 		server.RegisterHandler(webhook.NewLinearHandler())
 
+		cfg, err := services.Team.ListMembers()
+		if err != nil {
+			return fmt.Errorf("load team: %w", err)
+		}
+		if cfg != nil && len(cfg.Members) > 0 {
+			m := cfg.FindMember(webhookActor)
+			if m == nil {
+				return fmt.Errorf("--actor %s is not on the team; add them with 'roady team add %s member'", webhookActor, webhookActor)
+			}
+			server.SetActorMember(*m)
+		} else {
+			server.SetActor(webhookActor)
+		}
+		hasTokens, err := services.Team.HasTokens()
+		if err != nil {
+			return fmt.Errorf("load member tokens: %w", err)
+		}
+		if hasTokens {
+			server.EnableMemberAuth(services.Team)
+		}
+
 		// Set secrets
 		if githubSecret != "" {
 			server.SetSecret("github", githubSecret)
@@ -140,6 +169,7 @@ func init() {
 	webhookServeCmd.Flags().StringVar(&webhookGitHubSecret, "github-secret", "", "GitHub webhook secret")
 	webhookServeCmd.Flags().StringVar(&webhookJiraSecret, "jira-secret", "", "Jira webhook secret")
 	webhookServeCmd.Flags().StringVar(&webhookLinearSecret, "linear-secret", "", "Linear webhook secret")
+	webhookServeCmd.Flags().StringVar(&webhookActor, "actor", "webhook", "Team member that webhook status changes act as")
 }
 
 // webhookProcessor processes incoming webhook events.
@@ -157,6 +187,8 @@ func (p *webhookProcessor) ProcessEvent(ctx context.Context, event *webhook.Even
 		return nil
 	}
 
+	actor := team.ActorFrom(ctx, "webhook")
+
 	// Load current state
 	state, err := p.services.Plan.GetState()
 	if err != nil {
@@ -180,6 +212,9 @@ func (p *webhookProcessor) ProcessEvent(ctx context.Context, event *webhook.Even
 	// Update status if changed
 	statusChanged := false
 	if event.Status != "" && event.Status != taskResult.Status {
+		if err := team.AuthorizeCaller(ctx, team.PermissionTransitionTasks); err != nil {
+			return err
+		}
 		taskResult.Status = event.Status
 		statusChanged = true
 	}
@@ -202,6 +237,15 @@ func (p *webhookProcessor) ProcessEvent(ctx context.Context, event *webhook.Even
 	}
 
 	if statusChanged {
+		if err := p.services.Audit.Log("task.transition", actor, map[string]interface{}{
+			"task_id":     event.TaskID,
+			"event":       "webhook",
+			"status":      string(event.Status),
+			"provider":    event.Provider,
+			"external_id": event.ExternalID,
+		}); err != nil {
+			return fmt.Errorf("write audit log: %w", err)
+		}
 		fmt.Printf("Updated task %s status to %s (from %s webhook)\n", event.TaskID, event.Status, event.Provider)
 	}
 
@@ -57,7 +57,6 @@ var workspacePullCmd = &cobra.Command{
 		}
 		workspace := wiring.NewWorkspace(cwd)
 		svc := application.NewWorkspaceSyncService(cwd, workspace.Audit)
-
 		result, err := svc.Pull(cmd.Context())
 		if err != nil {
 			return MapError(fmt.Errorf("workspace pull: %w", err))
@@ -70,11 +69,45 @@ var workspacePullCmd = &cobra.Command{
 		}
 
 		fmt.Println(result.Message)
+		if len(result.Merged) > 0 {
+			fmt.Println("Merged files:")
+			for _, f := range result.Merged {
+				fmt.Printf("  %s\n", f)
+			}
+		}
 		if result.Conflict {
 			fmt.Println("Conflicting files:")
 			for _, f := range result.Files {
 				fmt.Printf("  %s\n", f)
 			}
+			for _, c := range result.Conflicts {
+				fmt.Printf("  %s\n", c)
+			}
+		}
+		return nil
+	},
+}
+
+var workspaceMergeDriverCmd = &cobra.Command{
+	Use:    "merge-driver <base> <ours> <theirs> <path>",
+	Short:  "Git merge driver for .roady/ artifacts (see 'roady setup git-merge')",
+	Hidden: true,
+	Args:   cobra.ExactArgs(4),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc := application.NewWorkspaceSyncService(".", nil)
+		result, err := svc.MergeDriver(args[0], args[1], args[2], args[3])
+		if err != nil {
+			return fmt.Errorf("merge %s: %w", args[3], err)
+		}
+		for _, note := range result.Notes {
+			fmt.Fprintf(os.Stderr, "roady: %s: %s\n", args[3], note)
+		}
+		if len(result.Conflicts) > 0 {
+			for _, c := range result.Conflicts {
+				fmt.Fprintf(os.Stderr, "roady: conflict in %s\n", c)
+			}
+			// A non-zero exit tells git the file is still conflicted.
+			os.Exit(1)
 		}
 		return nil
 	},
@@ -85,5 +118,6 @@ func init() {
 	workspacePullCmd.Flags().BoolVar(&workspaceJSONOutput, "json", false, "Output in JSON format")
 	workspaceCmd.AddCommand(workspacePushCmd)
 	workspaceCmd.AddCommand(workspacePullCmd)
+	workspaceCmd.AddCommand(workspaceMergeDriverCmd)
 	RootCmd.AddCommand(workspaceCmd)
 }
@@ -64,5 +64,5 @@ func SaveAIConfig(root string, cfg *AIConfig) error {
 		return fmt.Errorf("failed to marshal AI config: %w", err)
 	}
 
-	return os.WriteFile(path, data, 0600)
+	return storage.WriteFileAtomic(path, data, 0600)
 }
@@ -0,0 +1,140 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/felixgeelhaar/roady/pkg/application"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
+)
+
+// TokenEnv names the member API token the stdio transport acts with.
+const TokenEnv = "ROADY_MCP_TOKEN"
+
+func actorOr(actor, fallback string) string {
+	if actor == "" {
+		return fallback
+	}
+	return actor
+}
+
+// accessErr reports a role denial as is and any other failure as hint.
+func accessErr(err error, hint string) error {
+	if errors.Is(err, team.ErrForbidden) {
+		return mcpErr(err.Error())
+	}
+	return mcpErr(hint)
+}
+
+// requires wraps the handler of a tool that changes project state so the
+// caller's role must hold p. Sessions without an authenticated member are
+// the local user's, like the CLI, and are not subject to roles.
+func requires[A, R any](p team.Permission, h func(context.Context, A) (R, error)) func(context.Context, A) (R, error) {
+	return func(ctx context.Context, args A) (R, error) {
+		if err := team.AuthorizeCaller(ctx, p); err != nil {
+			var zero R
+			return zero, mcpErr(err.Error())
+		}
+		return h(ctx, args)
+	}
+}
+
+type memberAuthenticator interface {
+	HasTokens() (bool, error)
+	Authenticate(token string) (*team.Member, error)
+}
+
+// memberAuthMiddleware guards the HTTP and WebSocket transports. While no
+// member token has been issued requests are the local user's; once one has,
+// every request must present a token, checked per request so tokens issued
+// while the server runs take effect at once. The Bearer token (or ?token=
+// for WebSocket clients that cannot set headers) is resolved to a team
+// member, who is served as the caller so tool handlers and services check
+// that member's role.
+func memberAuthMiddleware(auth memberAuthenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hasTokens, err := auth.HasTokens()
+			if err != nil {
+				http.Error(w, "load member tokens", http.StatusInternalServerError)
+				return
+			}
+			if !hasTokens {
+				next.ServeHTTP(w, r)
+				return
+			}
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok {
+				token = r.URL.Query().Get("token")
+			}
+			var m *team.Member
+			if token != "" {
+				m, _ = auth.Authenticate(token)
+			}
+			if m == nil {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="roady-mcp"`)
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			stripCredentials(r)
+			next.ServeHTTP(w, r.WithContext(team.WithCaller(r.Context(), *m)))
+		})
+	}
+}
+
+// authorizeScope lets a member authenticated against the server's team
+// reach another project only when that project's team lists them with at
+// least the same permissions, so the role checked by requires and the
+// services holds there too.
+func authorizeScope(m team.Member, teamSvc *application.TeamService) error {
+	var there *team.Member
+	if teamSvc != nil {
+		cfg, err := teamSvc.ListMembers()
+		if err != nil {
+			return fmt.Errorf("load team: %w", err)
+		}
+		if cfg != nil {
+			there = cfg.FindMember(m.Name)
+		}
+	}
+	if there == nil {
+		return fmt.Errorf("%w: %s is not on the team of the requested project", team.ErrForbidden, m.Name)
+	}
+	for _, p := range []team.Permission{team.PermissionTransitionTasks, team.PermissionEditPlan, team.PermissionManageTeam} {
+		if m.Role.Allows(p) && !there.Role.Allows(p) {
+			return fmt.Errorf("%w: %s has role %s in the requested project", team.ErrForbidden, m.Name, there.Role)
+		}
+	}
+	return nil
+}
+
+// stripCredentials removes the token so it does not reach the MCP server.
+func stripCredentials(r *http.Request) {
+	r.Header.Del("Authorization")
+	if q := r.URL.Query(); q.Has("token") {
+		q.Del("token")
+		r.URL.RawQuery = q.Encode()
+	}
+}
+
+// stdioCaller attaches the member whose token ROADY_MCP_TOKEN holds, so an
+// MCP client can run the stdio server as a member with their role. Without
+// the variable the session is the local user's.
+func (s *Server) stdioCaller(ctx context.Context) (context.Context, error) {
+	token := os.Getenv(TokenEnv)
+	if token == "" {
+		return ctx, nil
+	}
+	if s.teamSvc == nil {
+		return nil, fmt.Errorf("%s is set but the project has no team", TokenEnv)
+	}
+	m, err := s.teamSvc.Authenticate(token)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", TokenEnv, err)
+	}
+	return team.WithCaller(ctx, *m), nil
+}
@@ -0,0 +1,125 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
+)
+
+type fakeMemberAuth map[string]team.Member
+
+func (f fakeMemberAuth) HasTokens() (bool, error) { return len(f) > 0, nil }
+
+func (f fakeMemberAuth) Authenticate(token string) (*team.Member, error) {
+	m, ok := f[token]
+	if !ok {
+		return nil, team.ErrInvalidToken
+	}
+	return &m, nil
+}
+
+var (
+	authViewer = team.Member{Name: "vi", Role: team.RoleViewer}
+	authMember = team.Member{Name: "mo", Role: team.RoleMember}
+)
+
+func TestMemberAuthMiddleware(t *testing.T) {
+	var seen string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m, _ := team.CallerFrom(r.Context())
+		seen = m.Name + "|" + r.Header.Get("Authorization") + "|" + r.URL.RawQuery
+	})
+	tokens := fakeMemberAuth{}
+	srv := httptest.NewServer(memberAuthMiddleware(tokens)(next))
+	defer srv.Close()
+
+	get := func(path, token string) int {
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+path, strings.NewReader("{}"))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// Until a token is issued requests are the local user's.
+	if code := get("/mcp", ""); code != http.StatusOK || seen != "||" {
+		t.Errorf("no tokens issued: got %d (handler saw %q), want 200 without a caller", code, seen)
+	}
+
+	// Tokens issued while the server runs take effect at once.
+	tokens["rdy_vi"], tokens["rdy_mo"] = authViewer, authMember
+	seen = ""
+	if code := get("/mcp", ""); code != http.StatusUnauthorized || seen != "" {
+		t.Errorf("no token: got %d (handler saw %q), want 401", code, seen)
+	}
+	if code := get("/mcp", "rdy_bogus"); code != http.StatusUnauthorized || seen != "" {
+		t.Errorf("bad token: got %d (handler saw %q), want 401", code, seen)
+	}
+	if code := get("/mcp", "rdy_mo"); code != http.StatusOK || seen != "mo||" {
+		t.Errorf("member token: got %d, handler saw %q, want caller mo without credentials", code, seen)
+	}
+	if code := get("/mcp?token=rdy_vi&x=1", ""); code != http.StatusOK || seen != "vi||x=1" {
+		t.Errorf("query token: got %d, handler saw %q, want caller vi without the token", code, seen)
+	}
+}
+
+func TestRequires(t *testing.T) {
+	called := 0
+	h := requires(team.PermissionEditPlan, func(ctx context.Context, _ struct{}) (string, error) {
+		called++
+		return team.ActorFrom(ctx, "local"), nil
+	})
+
+	viewer := team.WithCaller(context.Background(), authViewer)
+	if _, err := h(team.WithActor(viewer, "ai-agent"), struct{}{}); err == nil || !strings.Contains(err.Error(), "vi has role viewer") {
+		t.Errorf("viewer call = %v, want a role refusal", err)
+	}
+	if called != 0 {
+		t.Fatal("refused call reached the handler")
+	}
+
+	if got, err := h(team.WithCaller(context.Background(), authMember), struct{}{}); err != nil || got != "mo" {
+		t.Errorf("member call = %q, %v; want mo", got, err)
+	}
+	// Without an authenticated member the session is the local user's.
+	if got, err := h(context.Background(), struct{}{}); err != nil || got != "local" {
+		t.Errorf("local call = %q, %v; want local", got, err)
+	}
+}
+
+func TestStdioCaller(t *testing.T) {
+	s := &Server{}
+	t.Setenv(TokenEnv, "")
+	ctx, err := s.stdioCaller(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := team.CallerFrom(ctx); ok {
+		t.Error("stdio without a token should have no caller")
+	}
+
+	t.Setenv(TokenEnv, "rdy_whatever")
+	if _, err := s.stdioCaller(context.Background()); err == nil {
+		t.Error("expected a token without a team to be refused")
+	}
+}
+
+func TestAccessErr(t *testing.T) {
+	forbidden := team.Member{Name: "vi", Role: team.RoleViewer}.Authorize(team.PermissionEditPlan)
+	if err := accessErr(forbidden, "hint"); !strings.Contains(err.Error(), "cannot edit the plan") {
+		t.Errorf("accessErr(forbidden) = %v", err)
+	}
+	if err := accessErr(errors.New("boom"), "hint"); err.Error() != "hint" {
+		t.Errorf("accessErr(other) = %v, want hint", err)
+	}
+}
@@ -37,19 +37,23 @@ func (s *Server) handleOrgDetectDrift(ctx context.Context, args GetSpecArgs) (an
 
 // Plugin handlers
 
-func (s *Server) pluginSvcForPath(projectPath, project string) *application.PluginService {
-	if (projectPath == "" || projectPath == s.root) && project == "" {
-		return s.pluginSvc
+func (s *Server) pluginSvcForPath(ctx context.Context, projectPath, project string) (*application.PluginService, error) {
+	if s.isDefaultScope(projectPath, project) {
+		return s.pluginSvc, nil
 	}
-	svc, err := s.servicesForPath(projectPath, project)
+	svc, err := s.servicesForPath(ctx, projectPath, project)
 	if err != nil {
-		return s.pluginSvc
+		return nil, err
 	}
-	return svc.Plugin
+	return svc.Plugin, nil
 }
 
 func (s *Server) handlePluginList(ctx context.Context, args GetSpecArgs) (any, error) {
-	plugins, err := s.pluginSvcForPath(args.ProjectPath, args.Project).ListPlugins()
+	psvc, err := s.pluginSvcForPath(ctx, args.ProjectPath, args.Project)
+	if err != nil {
+		return nil, accessErr(err, "Failed to load project at the given path.")
+	}
+	plugins, err := psvc.ListPlugins()
 	if err != nil {
 		return nil, mcpErr("Failed to list plugins.")
 	}
@@ -63,7 +67,11 @@ type PluginValidateArgs struct {
 }
 
 func (s *Server) handlePluginValidate(ctx context.Context, args PluginValidateArgs) (any, error) {
-	result, err := s.pluginSvcForPath(args.ProjectPath, args.Project).ValidatePlugin(args.Name)
+	psvc, err := s.pluginSvcForPath(ctx, args.ProjectPath, args.Project)
+	if err != nil {
+		return nil, accessErr(err, "Failed to load project at the given path.")
+	}
+	result, err := psvc.ValidatePlugin(args.Name)
 	if err != nil {
 		return nil, mcpErr("Failed to validate plugin.")
 	}
@@ -77,7 +85,10 @@ type PluginStatusArgs struct {
 }
 
 func (s *Server) handlePluginStatus(ctx context.Context, args PluginStatusArgs) (any, error) {
-	psvc := s.pluginSvcForPath(args.ProjectPath, args.Project)
+	psvc, err := s.pluginSvcForPath(ctx, args.ProjectPath, args.Project)
+	if err != nil {
+		return nil, accessErr(err, "Failed to load project at the given path.")
+	}
 	if args.Name != "" {
 		result, err := psvc.CheckHealth(args.Name)
 		if err != nil {
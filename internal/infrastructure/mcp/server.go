@@ -12,6 +12,7 @@ import (
 	"github.com/felixgeelhaar/roady/internal/infrastructure/wiring"
 	"github.com/felixgeelhaar/roady/pkg/application"
 	"github.com/felixgeelhaar/roady/pkg/domain/billing"
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	"github.com/felixgeelhaar/roady/pkg/domain/team"
 	"go.klarlabs.de/mcp"
@@ -103,7 +104,27 @@ func capSlice(s []string) []string {
 	return s
 }
 
-// servicesForPath returns services for the requested project scope.
+// servicesForPath returns services for the requested project scope; see
+// servicesForScope. A member authenticated against this server's team must
+// also be on the team of any other project they reach; see authorizeScope.
+func (s *Server) servicesForPath(ctx context.Context, pathOverride, project string) (*wiring.AppServices, error) {
+	svc, err := s.servicesForScope(pathOverride, project)
+	if err != nil {
+		return nil, err
+	}
+	if m, ok := team.CallerFrom(ctx); ok && !s.isDefaultScope(pathOverride, project) {
+		if err := authorizeScope(m, svc.Team); err != nil {
+			return nil, err
+		}
+	}
+	return svc, nil
+}
+
+func (s *Server) isDefaultScope(pathOverride, project string) bool {
+	return (pathOverride == "" || pathOverride == s.root) && project == ""
+}
+
+// servicesForScope returns services for the requested project scope.
 // When pathOverride is empty (or matches the server root) AND project is empty,
 // the server's default services are returned. Otherwise a fresh AppServices set
 // is built and cached per (path, project) key — sub-projects under the same
@@ -111,9 +132,8 @@ func capSlice(s []string) []string {
 //
 // Cross-project services are cached to avoid rebuilding the full stack
 // (event replay, AI config loading, etc.) on every request.
-func (s *Server) servicesForPath(pathOverride, project string) (*wiring.AppServices, error) {
-	usingDefault := (pathOverride == "" || pathOverride == s.root) && project == ""
-	if usingDefault {
+func (s *Server) servicesForScope(pathOverride, project string) (*wiring.AppServices, error) {
+	if s.isDefaultScope(pathOverride, project) {
 		if s.services != nil {
 			return s.services, nil
 		}
@@ -229,6 +249,7 @@ type InitArgs struct {
 
 type UpdatePlanArgs struct {
 	Tasks       []planning.Task `json:"tasks" jsonschema:"description=The list of tasks to define the plan"`
+	Actor       string          `json:"actor,omitempty" jsonschema:"description=The team member acting (defaults to ai; token-authenticated callers are recorded as their member)"`
 	ProjectPath string          `json:"project_path,omitempty" jsonschema:"description=Path to the roady project directory (default: server root)"`
 	Project     string          `json:"project,omitempty" jsonschema:"description=Sub-project name under .roady/projects/<name>/ (default: root project)"`
 }
@@ -251,16 +272,66 @@ type GetStateArgs struct {
 }
 
 type GeneratePlanArgs struct {
+	Actor       string `json:"actor,omitempty" jsonschema:"description=The team member acting (defaults to cli; token-authenticated callers are recorded as their member)"`
 	ProjectPath string `json:"project_path,omitempty" jsonschema:"description=Path to the roady project directory (default: server root)"`
 	Project     string `json:"project,omitempty" jsonschema:"description=Sub-project name under .roady/projects/<name>/ (default: root project)"`
 }
 
 type DetectDriftArgs struct {
+	Rules       []string `json:"rules,omitempty" jsonschema:"description=Only report issues raised by these drift rule IDs (e.g. intent, plan, code, policy)"`
+	ProjectPath string   `json:"project_path,omitempty" jsonschema:"description=Path to the roady project directory (default: server root)"`
+	Project     string   `json:"project,omitempty" jsonschema:"description=Sub-project name under .roady/projects/<name>/ (default: root project)"`
+}
+
+type SpecDiffArgs struct {
+	Against     string `json:"against,omitempty" jsonschema:"description=Baseline to compare with: 'lock' (default) or a git ref"`
+	ProjectPath string `json:"project_path,omitempty" jsonschema:"description=Path to the roady project directory (default: server root)"`
+	Project     string `json:"project,omitempty" jsonschema:"description=Sub-project name under .roady/projects/<name>/ (default: root project)"`
+}
+
+type PlanHistoryArgs struct {
+	ProjectPath string `json:"project_path,omitempty" jsonschema:"description=Path to the roady project directory (default: server root)"`
+	Project     string `json:"project,omitempty" jsonschema:"description=Sub-project name under .roady/projects/<name>/ (default: root project)"`
+}
+
+type QueryEventsArgs struct {
+	Types         []string          `json:"types,omitempty" jsonschema:"description=Event type globs, e.g. task.* (any of them matches)"`
+	Actor         string            `json:"actor,omitempty" jsonschema:"description=Actor prefix, e.g. ai for ai-agent, or a glob"`
+	Aggregate     string            `json:"aggregate,omitempty" jsonschema:"description=Aggregate ID, e.g. a task ID"`
+	AggregateType string            `json:"aggregate_type,omitempty" jsonschema:"description=Aggregate type: task, plan, sync or billing"`
+	Since         string            `json:"since,omitempty" jsonschema:"description=Only events at or after this time: a window (36h, 7d, 2w), YYYY-MM-DD or RFC 3339"`
+	Until         string            `json:"until,omitempty" jsonschema:"description=Only events at or before this time: a window (36h, 7d, 2w), YYYY-MM-DD or RFC 3339"`
+	Metadata      map[string]string `json:"metadata,omitempty" jsonschema:"description=Metadata filters: key to value glob"`
+	Limit         int               `json:"limit,omitempty" jsonschema:"description=Maximum number of events (default 100)"`
+	Offset        int               `json:"offset,omitempty" jsonschema:"description=Number of matching events to skip"`
+	ProjectPath   string            `json:"project_path,omitempty" jsonschema:"description=Path to the roady project directory (default: server root)"`
+	Project       string            `json:"project,omitempty" jsonschema:"description=Sub-project name under .roady/projects/<name>/ (default: root project)"`
+}
+
+type PlanDiffArgs struct {
+	From        string `json:"from" jsonschema:"required,description=Base revision: a hash or unique prefix from roady_plan_history, or 'current'"`
+	To          string `json:"to,omitempty" jsonschema:"description=Revision to compare with (default: 'current')"`
+	ProjectPath string `json:"project_path,omitempty" jsonschema:"description=Path to the roady project directory (default: server root)"`
+	Project     string `json:"project,omitempty" jsonschema:"description=Sub-project name under .roady/projects/<name>/ (default: root project)"`
+}
+
+type PlanRollbackArgs struct {
+	Revision    string `json:"revision" jsonschema:"required,description=Revision hash or unique prefix from roady_plan_history"`
+	Actor       string `json:"actor,omitempty" jsonschema:"description=The team member acting (defaults to ai-agent; token-authenticated callers are recorded as their member)"`
+	ProjectPath string `json:"project_path,omitempty" jsonschema:"description=Path to the roady project directory (default: server root)"`
+	Project     string `json:"project,omitempty" jsonschema:"description=Sub-project name under .roady/projects/<name>/ (default: root project)"`
+}
+
+type PlanScheduleArgs struct {
+	TeamSize    int    `json:"team_size,omitempty" jsonschema:"description=People working in parallel (default: team members who can transition tasks)"`
+	MaxWIP      int    `json:"max_wip,omitempty" jsonschema:"description=WIP limit (default: the policy's max_wip)"`
+	Format      string `json:"format,omitempty" jsonschema:"description=Output format: json (default), gantt or mermaid"`
 	ProjectPath string `json:"project_path,omitempty" jsonschema:"description=Path to the roady project directory (default: server root)"`
 	Project     string `json:"project,omitempty" jsonschema:"description=Sub-project name under .roady/projects/<name>/ (default: root project)"`
 }
 
 type ApprovePlanArgs struct {
+	Actor       string `json:"actor,omitempty" jsonschema:"description=The team member acting (defaults to cli; token-authenticated callers are recorded as their member)"`
 	ProjectPath string `json:"project_path,omitempty" jsonschema:"description=Path to the roady project directory (default: server root)"`
 	Project     string `json:"project,omitempty" jsonschema:"description=Sub-project name under .roady/projects/<name>/ (default: root project)"`
 }
@@ -374,7 +445,7 @@ func (s *Server) registerTools() {
 	s.mcpServer.Tool("roady_init").
 		Description("Initialize a new roady project in the current directory").
 		UIResource("ui://roady/init").
-		Handler(s.handleInit)
+		Handler(requires(team.PermissionEditPlan, s.handleInit))
 
 	// Tool: roady_get_spec
 	s.mcpServer.Tool("roady_get_spec").
@@ -382,6 +453,12 @@ func (s *Server) registerTools() {
 		UIResource("ui://roady/spec").
 		Handler(s.handleGetSpec)
 
+	// Tool: roady_spec_diff
+	s.mcpServer.Tool("roady_spec_diff").
+		Description("List added, removed, renamed and modified spec elements against the lock or a git ref").
+		UIResource("ui://roady/spec").
+		Handler(s.handleSpecDiff)
+
 	// Tool: roady_get_plan
 	s.mcpServer.Tool("roady_get_plan").
 		Description("Retrieve the current execution plan").
@@ -398,13 +475,13 @@ func (s *Server) registerTools() {
 	s.mcpServer.Tool("roady_generate_plan").
 		Description("Generate a basic plan from the spec using 1:1 heuristic (resets custom tasks unless they match features)").
 		UIResource("ui://roady/plan").
-		Handler(s.handleGeneratePlan)
+		Handler(requires(team.PermissionEditPlan, s.handleGeneratePlan))
 
 	// Tool: roady_update_plan (Smart Injection)
 	s.mcpServer.Tool("roady_update_plan").
 		Description("Update the plan with a specific list of tasks (Smart Injection). Use this to propose complex architectures.").
 		UIResource("ui://roady/plan").
-		Handler(s.handleUpdatePlan)
+		Handler(requires(team.PermissionEditPlan, s.handleUpdatePlan))
 
 	// Tool: roady_detect_drift
 	s.mcpServer.Tool("roady_detect_drift").
@@ -416,7 +493,7 @@ func (s *Server) registerTools() {
 	s.mcpServer.Tool("roady_accept_drift").
 		Description("Accept the current drift by locking the spec snapshot").
 		UIResource("ui://roady/drift").
-		Handler(s.handleAcceptDrift)
+		Handler(requires(team.PermissionEditPlan, s.handleAcceptDrift))
 
 	// Tool: roady_status
 	s.mcpServer.Tool("roady_status").
@@ -432,9 +509,9 @@ func (s *Server) registerTools() {
 
 	// Tool: roady_transition_task
 	s.mcpServer.Tool("roady_transition_task").
-		Description("Transition a task to a new state (e.g., start, complete, block, stop)").
+		Description("Transition a task to a new state (e.g., start, complete, block, stop, verify). Verifying a task with acceptance criteria requires criteria_evidence for each criterion").
 		UIResource("ui://roady/state").
-		Handler(s.handleTransitionTask)
+		Handler(requires(team.PermissionTransitionTasks, s.handleTransitionTask))
 
 	// Tool: roady_explain_spec
 	s.mcpServer.Tool("roady_explain_spec").
@@ -446,7 +523,37 @@ func (s *Server) registerTools() {
 	s.mcpServer.Tool("roady_approve_plan").
 		Description("Approve the current plan for execution").
 		UIResource("ui://roady/plan").
-		Handler(s.handleApprovePlan)
+		Handler(requires(team.PermissionEditPlan, s.handleApprovePlan))
+
+	// Tool: roady_plan_history
+	s.mcpServer.Tool("roady_plan_history").
+		Description("List approved plan revisions, oldest first").
+		UIResource("ui://roady/plan").
+		Handler(s.handlePlanHistory)
+
+	// Tool: roady_query_events
+	s.mcpServer.Tool("roady_query_events").
+		Description("Search the event store by type glob, actor, aggregate, time window and metadata, oldest first").
+		UIResource("ui://roady/status").
+		Handler(s.handleQueryEvents)
+
+	// Tool: roady_plan_diff
+	s.mcpServer.Tool("roady_plan_diff").
+		Description("List added and removed tasks, changed task fields and dependency edges between two plan revisions").
+		UIResource("ui://roady/plan").
+		Handler(s.handlePlanDiff)
+
+	// Tool: roady_plan_rollback
+	s.mcpServer.Tool("roady_plan_rollback").
+		Description("Restore an earlier plan revision as the current plan; it must be approved again with roady_approve_plan").
+		UIResource("ui://roady/plan").
+		Handler(requires(team.PermissionEditPlan, s.handlePlanRollback))
+
+	// Tool: roady_plan_schedule
+	s.mcpServer.Tool("roady_plan_schedule").
+		Description("Compute the critical path, earliest and latest start and slack per task, and simulate the remaining work within the team size and WIP limit").
+		UIResource("ui://roady/plan").
+		Handler(s.handlePlanSchedule)
 
 	// Tool: roady_get_usage
 	s.mcpServer.Tool("roady_get_usage").
@@ -464,7 +571,7 @@ func (s *Server) registerTools() {
 	s.mcpServer.Tool("roady_add_feature").
 		Description("Add a new feature to the product specification and sync to docs/backlog.md").
 		UIResource("ui://roady/spec").
-		Handler(s.handleAddFeature)
+		Handler(requires(team.PermissionEditPlan, s.handleAddFeature))
 
 	// Tool: roady_forecast (Horizon 5)
 	s.mcpServer.Tool("roady_forecast").
@@ -482,13 +589,13 @@ func (s *Server) registerTools() {
 	s.mcpServer.Tool("roady_git_sync").
 		Description("Synchronize task statuses by scanning git commit messages for markers").
 		UIResource("ui://roady/git-sync").
-		Handler(s.handleGitSync)
+		Handler(requires(team.PermissionTransitionTasks, s.handleGitSync))
 
 	// Tool: roady_sync (External Plugins)
 	s.mcpServer.Tool("roady_sync").
 		Description("Sync the plan with an external system via a plugin binary").
 		UIResource("ui://roady/sync").
-		Handler(s.handleSync)
+		Handler(requires(team.PermissionTransitionTasks, s.handleSync))
 
 	// Tool: roady_deps_list (Horizon 5)
 	s.mcpServer.Tool("roady_deps_list").
@@ -596,7 +703,7 @@ func (s *Server) registerTools() {
 	s.mcpServer.Tool("roady_assign_task").
 		Description("Assign a task to a person or agent without changing its status").
 		UIResource("ui://roady/state").
-		Handler(s.handleAssignTask)
+		Handler(requires(team.PermissionTransitionTasks, s.handleAssignTask))
 
 	// Tool: roady_get_snapshot (v0.6.0 - Coordinator)
 	s.mcpServer.Tool("roady_get_snapshot").
@@ -642,25 +749,25 @@ func (s *Server) registerTools() {
 	s.mcpServer.Tool("roady_workspace_push").
 		Description("Commit and push .roady/ workspace state to git remote").
 		UIResource("ui://roady/workspace").
-		Handler(s.handleWorkspacePush)
+		Handler(requires(team.PermissionEditPlan, s.handleWorkspacePush))
 
 	// Tool: roady_workspace_pull (v0.8.0)
 	s.mcpServer.Tool("roady_workspace_pull").
 		Description("Pull remote .roady/ workspace changes and merge with conflict detection").
 		UIResource("ui://roady/workspace").
-		Handler(s.handleWorkspacePull)
+		Handler(requires(team.PermissionEditPlan, s.handleWorkspacePull))
 
 	// Tool: roady_plan_decompose (v0.10.0 - canonical name)
 	s.mcpServer.Tool("roady_plan_decompose").
 		Description("AI-powered context-aware task decomposition using codebase structure analysis. Canonical name; supersedes roady_smart_decompose.").
 		UIResource("ui://roady/plan").
-		Handler(s.handleSmartDecompose)
+		Handler(requires(team.PermissionEditPlan, s.handleSmartDecompose))
 
 	// Tool: roady_smart_decompose (deprecated; use roady_plan_decompose)
 	s.mcpServer.Tool("roady_smart_decompose").
 		Description("DEPRECATED: use roady_plan_decompose. AI-powered context-aware task decomposition.").
 		UIResource("ui://roady/plan").
-		Handler(s.handleSmartDecompose)
+		Handler(requires(team.PermissionEditPlan, s.handleSmartDecompose))
 
 	// Tool: roady_team_list (v0.8.0)
 	s.mcpServer.Tool("roady_team_list").
@@ -672,13 +779,13 @@ func (s *Server) registerTools() {
 	s.mcpServer.Tool("roady_team_add").
 		Description("Add or update a team member with a role (admin, member, viewer)").
 		UIResource("ui://roady/team").
-		Handler(s.handleTeamAdd)
+		Handler(requires(team.PermissionManageTeam, s.handleTeamAdd))
 
 	// Tool: roady_team_remove (v0.8.0)
 	s.mcpServer.Tool("roady_team_remove").
 		Description("Remove a team member").
 		UIResource("ui://roady/team").
-		Handler(s.handleTeamRemove)
+		Handler(requires(team.PermissionManageTeam, s.handleTeamRemove))
 
 	// Billing tools
 	// Tool: roady_rate_list
@@ -691,13 +798,13 @@ func (s *Server) registerTools() {
 	s.mcpServer.Tool("roady_rate_add").
 		Description("Add a new billing rate").
 		UIResource("ui://roady/billing").
-		Handler(s.handleRateAdd)
+		Handler(requires(team.PermissionEditPlan, s.handleRateAdd))
 
 	// Tool: roady_task_log_time
 	s.mcpServer.Tool("roady_task_log_time").
 		Description("Log time to a task for billing").
 		UIResource("ui://roady/billing").
-		Handler(s.handleTaskLogTime)
+		Handler(requires(team.PermissionTransitionTasks, s.handleTaskLogTime))
 
 	// Tool: roady_cost_report
 	s.mcpServer.Tool("roady_cost_report").
@@ -715,25 +822,25 @@ func (s *Server) registerTools() {
 	s.mcpServer.Tool("roady_rate_remove").
 		Description("Remove a billing rate").
 		UIResource("ui://roady/billing").
-		Handler(s.handleRateRemove)
+		Handler(requires(team.PermissionEditPlan, s.handleRateRemove))
 
 	// Tool: roady_rate_set_default
 	s.mcpServer.Tool("roady_rate_set_default").
 		Description("Set the default billing rate").
 		UIResource("ui://roady/billing").
-		Handler(s.handleRateSetDefault)
+		Handler(requires(team.PermissionEditPlan, s.handleRateSetDefault))
 
 	// Tool: roady_rate_tax
 	s.mcpServer.Tool("roady_rate_tax").
 		Description("Configure tax settings for billing").
 		UIResource("ui://roady/billing").
-		Handler(s.handleRateTax)
+		Handler(requires(team.PermissionEditPlan, s.handleRateTax))
 }
 
 func (s *Server) handleForecast(ctx context.Context, args ForecastArgs) (any, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return nil, mcpErr("Failed to load project at the given path.")
+		return nil, accessErr(err, "Failed to load project at the given path.")
 	}
 	forecast, err := svc.Forecast.GetForecast()
 	if err != nil {
@@ -820,9 +927,9 @@ func (s *Server) handleOrgStatus(ctx context.Context, args GetSpecArgs) (any, er
 }
 
 func (s *Server) handleGitSync(ctx context.Context, args GitSyncArgs) (any, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return nil, mcpErr("Failed to load project at the given path.")
+		return nil, accessErr(err, "Failed to load project at the given path.")
 	}
 	results, err := svc.Git.SyncMarkers(10)
 	if err != nil {
@@ -832,9 +939,9 @@ func (s *Server) handleGitSync(ctx context.Context, args GitSyncArgs) (any, erro
 }
 
 func (s *Server) handleSync(ctx context.Context, args SyncArgs) (any, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return nil, mcpErr("Failed to load project at the given path.")
+		return nil, accessErr(err, "Failed to load project at the given path.")
 	}
 	results, err := svc.Sync.SyncWithPlugin(args.PluginPath)
 	if err != nil {
@@ -844,9 +951,9 @@ func (s *Server) handleSync(ctx context.Context, args SyncArgs) (any, error) {
 }
 
 func (s *Server) handleExplainDrift(ctx context.Context, args ExplainDriftArgs) (string, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return "", mcpErr("Failed to load project at the given path.")
+		return "", accessErr(err, "Failed to load project at the given path.")
 	}
 	if err := requireAI(svc); err != nil {
 		return "", err
@@ -864,9 +971,9 @@ func (s *Server) handleExplainDrift(ctx context.Context, args ExplainDriftArgs)
 }
 
 func (s *Server) handleAcceptDrift(ctx context.Context, args AcceptDriftArgs) (string, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return "", mcpErr("Failed to load project at the given path.")
+		return "", accessErr(err, "Failed to load project at the given path.")
 	}
 	if err := svc.Drift.AcceptDrift(); err != nil {
 		return "", mcpErr("Failed to accept drift. Ensure a spec exists.")
@@ -882,9 +989,9 @@ type AddFeatureArgs struct {
 }
 
 func (s *Server) handleAddFeature(ctx context.Context, args AddFeatureArgs) (string, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return "", mcpErr("Failed to load project at the given path.")
+		return "", accessErr(err, "Failed to load project at the given path.")
 	}
 	spec, err := svc.Spec.AddFeature(args.Title, args.Description)
 	if err != nil {
@@ -894,9 +1001,9 @@ func (s *Server) handleAddFeature(ctx context.Context, args AddFeatureArgs) (str
 }
 
 func (s *Server) handleGetUsage(ctx context.Context, args GetUsageArgs) (any, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return nil, mcpErr("Failed to load project at the given path.")
+		return nil, accessErr(err, "Failed to load project at the given path.")
 	}
 	usage, err := svc.Plan.GetUsage()
 	if err != nil {
@@ -906,21 +1013,125 @@ func (s *Server) handleGetUsage(ctx context.Context, args GetUsageArgs) (any, er
 }
 
 func (s *Server) handleApprovePlan(ctx context.Context, args ApprovePlanArgs) (string, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return "", mcpErr("Failed to load project at the given path.")
+		return "", accessErr(err, "Failed to load project at the given path.")
 	}
-	err = svc.Plan.ApprovePlan()
+	err = svc.Plan.ApprovePlanWithActor(ctx, actorOr(args.Actor, "cli"))
 	if err != nil {
-		return "", mcpErr("Failed to approve plan. Ensure a plan has been generated.")
+		return "", accessErr(err, "Failed to approve plan. Ensure a plan has been generated.")
 	}
 	return "Plan approved successfully", nil
 }
 
+func (s *Server) handlePlanHistory(ctx context.Context, args PlanHistoryArgs) (any, error) {
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
+	if err != nil {
+		return nil, accessErr(err, "Failed to load project at the given path.")
+	}
+	revisions, err := svc.Plan.PlanHistory()
+	if err != nil {
+		return nil, mcpErr(fmt.Sprintf("Failed to load plan history: %v", err))
+	}
+	return revisions, nil
+}
+
+// defaultEventQueryLimit caps roady_query_events when no limit is given.
+const defaultEventQueryLimit = 100
+
+func (s *Server) handleQueryEvents(ctx context.Context, args QueryEventsArgs) (any, error) {
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
+	if err != nil {
+		return nil, accessErr(err, "Failed to load project at the given path.")
+	}
+	q := events.EventQuery{
+		AggregateType: args.AggregateType,
+		AggregateID:   args.Aggregate,
+		EventTypes:    args.Types,
+		Actor:         args.Actor,
+		Metadata:      args.Metadata,
+		Limit:         args.Limit,
+		Offset:        args.Offset,
+	}
+	if q.Limit == 0 {
+		q.Limit = defaultEventQueryLimit
+	}
+	now := time.Now()
+	for _, bound := range []struct {
+		name, value string
+		dst         **time.Time
+	}{{"since", args.Since, &q.Since}, {"until", args.Until, &q.Until}} {
+		if bound.value == "" {
+			continue
+		}
+		t, err := events.ParseQueryTime(bound.value, now)
+		if err != nil {
+			return nil, mcpErr(fmt.Sprintf("Invalid %s: %v", bound.name, err))
+		}
+		*bound.dst = &t
+	}
+	evts, err := svc.Audit.QueryEvents(q)
+	if err != nil {
+		return nil, mcpErr(fmt.Sprintf("Failed to query events: %v", err))
+	}
+	return evts, nil
+}
+
+func (s *Server) handlePlanDiff(ctx context.Context, args PlanDiffArgs) (any, error) {
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
+	if err != nil {
+		return nil, accessErr(err, "Failed to load project at the given path.")
+	}
+	to := args.To
+	if to == "" {
+		to = application.PlanRevisionCurrent
+	}
+	diff, err := svc.Plan.DiffPlanRevisions(args.From, to)
+	if err != nil {
+		return nil, mcpErr(fmt.Sprintf("Failed to diff plans: %v", err))
+	}
+	return diff, nil
+}
+
+func (s *Server) handlePlanRollback(ctx context.Context, args PlanRollbackArgs) (string, error) {
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
+	if err != nil {
+		return "", accessErr(err, "Failed to load project at the given path.")
+	}
+	plan, err := svc.Plan.RollbackPlan(ctx, args.Revision, actorOr(args.Actor, "ai-agent"))
+	if err != nil {
+		return "", mcpErr(fmt.Sprintf("Failed to roll back plan: %v", err))
+	}
+	return fmt.Sprintf("Plan rolled back to %s (%d tasks). Approve it with roady_approve_plan to resume work.", planning.ShortRevision(plan.RevisionHash()), len(plan.Tasks)), nil
+}
+
+func (s *Server) handlePlanSchedule(ctx context.Context, args PlanScheduleArgs) (any, error) {
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
+	if err != nil {
+		return nil, accessErr(err, "Failed to load project at the given path.")
+	}
+	sched, err := svc.Schedule.GetSchedule(application.ScheduleRequest{TeamSize: args.TeamSize, MaxWIP: args.MaxWIP})
+	if err != nil {
+		return nil, mcpErr(fmt.Sprintf("Failed to schedule plan: %v", err))
+	}
+	if sched == nil {
+		return "No plan found. Generate a plan first.", nil
+	}
+	switch args.Format {
+	case "", "json":
+		return sched, nil
+	case "gantt":
+		return sched.Gantt(60), nil
+	case "mermaid":
+		return sched.Mermaid(), nil
+	}
+	return nil, mcpErr(fmt.Sprintf("Unknown format %q; use json, gantt or mermaid.", args.Format))
+}
+
 func (s *Server) handleExplainSpec(ctx context.Context, args ExplainSpecArgs) (string, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return "", mcpErr("Failed to load project at the given path.")
+		return "", accessErr(err, "Failed to load project at the given path.")
 	}
 	if err := requireAI(svc); err != nil {
 		return "", err
@@ -943,9 +1154,9 @@ func (s *Server) handleQuery(ctx context.Context, args QueryArgs) (string, error
 	if args.Question == "" {
 		return "", mcpErr("A question is required.")
 	}
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return "", mcpErr("Failed to load project at the given path.")
+		return "", accessErr(err, "Failed to load project at the given path.")
 	}
 	if err := requireAI(svc); err != nil {
 		return "", err
@@ -959,9 +1170,9 @@ func (s *Server) handleQuery(ctx context.Context, args QueryArgs) (string, error
 }
 
 func (s *Server) handleSuggestPriorities(ctx context.Context, args SuggestPrioritiesArgs) (any, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return nil, mcpErr("Failed to load project at the given path.")
+		return nil, accessErr(err, "Failed to load project at the given path.")
 	}
 	if err := requireAI(svc); err != nil {
 		return nil, err
@@ -975,9 +1186,9 @@ func (s *Server) handleSuggestPriorities(ctx context.Context, args SuggestPriori
 }
 
 func (s *Server) handleReviewSpec(ctx context.Context, args ReviewSpecArgs) (any, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return nil, mcpErr("Failed to load project at the given path.")
+		return nil, accessErr(err, "Failed to load project at the given path.")
 	}
 	if err := requireAI(svc); err != nil {
 		return nil, err
@@ -991,17 +1202,20 @@ func (s *Server) handleReviewSpec(ctx context.Context, args ReviewSpecArgs) (any
 }
 
 type TransitionTaskArgs struct {
-	TaskID      string `json:"task_id" jsonschema:"description=The ID of the task to transition"`
-	Event       string `json:"event" jsonschema:"description=The transition event (start, complete, block, stop, unblock, reopen)"`
-	Evidence    string `json:"evidence,omitempty" jsonschema:"description=Optional evidence for the transition (e.g. commit hash)"`
-	Actor       string `json:"actor,omitempty" jsonschema:"description=The actor performing the transition (defaults to ai-agent)"`
-	ProjectPath string `json:"project_path,omitempty" jsonschema:"description=Path to the roady project directory (default: server root)"`
-	Project     string `json:"project,omitempty" jsonschema:"description=Sub-project name under .roady/projects/<name>/ (default: root project)"`
+	TaskID   string `json:"task_id" jsonschema:"description=The ID of the task to transition"`
+	Event    string `json:"event" jsonschema:"description=The transition event (start, complete, block, stop, unblock, reopen)"`
+	Evidence string `json:"evidence,omitempty" jsonschema:"description=Optional evidence for the transition (e.g. commit hash)"`
+	Actor    string `json:"actor,omitempty" jsonschema:"description=The actor performing the transition (defaults to ai-agent; token-authenticated callers are recorded as their member)"`
+	// CriteriaEvidence is required by verify for tasks with acceptance criteria.
+	CriteriaEvidence []string `json:"criteria_evidence,omitempty" jsonschema:"description=For verify: evidence per acceptance criterion as N=value where value is commit:<hash>, test:<name>, url:<link> or free text"`
+	ProjectPath      string   `json:"project_path,omitempty" jsonschema:"description=Path to the roady project directory (default: server root)"`
+	Project          string   `json:"project,omitempty" jsonschema:"description=Sub-project name under .roady/projects/<name>/ (default: root project)"`
 }
 
 type AssignTaskArgs struct {
 	TaskID      string `json:"task_id" jsonschema:"description=The ID of the task to assign"`
 	Assignee    string `json:"assignee" jsonschema:"description=The person or agent to assign the task to"`
+	Actor       string `json:"actor,omitempty" jsonschema:"description=The team member acting (defaults to the assignee; token-authenticated callers are recorded as their member)"`
 	ProjectPath string `json:"project_path,omitempty" jsonschema:"description=Path to the roady project directory (default: server root)"`
 	Project     string `json:"project,omitempty" jsonschema:"description=Sub-project name under .roady/projects/<name>/ (default: root project)"`
 }
@@ -1060,11 +1274,11 @@ type StatusArgs struct {
 }
 
 func (s *Server) handleAssignTask(ctx context.Context, args AssignTaskArgs) (string, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return "", mcpErr("Failed to load project at the given path.")
+		return "", accessErr(err, "Failed to load project at the given path.")
 	}
-	err = svc.Task.AssignTask(ctx, args.TaskID, args.Assignee)
+	err = svc.Task.AssignTask(team.WithActor(ctx, actorOr(args.Actor, "ai-agent")), args.TaskID, args.Assignee)
 	if err != nil {
 		return "", mcpErr(fmt.Sprintf("Failed to assign task '%s' to '%s': %v", args.TaskID, args.Assignee, err))
 	}
@@ -1072,15 +1286,21 @@ func (s *Server) handleAssignTask(ctx context.Context, args AssignTaskArgs) (str
 }
 
 func (s *Server) handleTransitionTask(ctx context.Context, args TransitionTaskArgs) (string, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return "", mcpErr("Failed to load project at the given path.")
+		return "", accessErr(err, "Failed to load project at the given path.")
 	}
-	actor := args.Actor
-	if actor == "" {
-		actor = "ai-agent"
+	actor := team.ActorFrom(ctx, actorOr(args.Actor, "ai-agent"))
+	switch args.Event {
+	case "verify":
+		var evidence []planning.CriterionEvidence
+		evidence, err = svc.Task.ParseCriteriaEvidence(args.TaskID, args.CriteriaEvidence)
+		if err == nil {
+			err = svc.Task.VerifyTask(ctx, args.TaskID, actor, evidence...)
+		}
+	default:
+		err = svc.Task.TransitionTaskContext(ctx, args.TaskID, args.Event, actor, args.Evidence)
 	}
-	err = svc.Task.TransitionTask(args.TaskID, args.Event, actor, args.Evidence)
 	if err != nil {
 		return "", mcpErr(fmt.Sprintf("Failed to transition task '%s' with event '%s': %v", args.TaskID, args.Event, err))
 	}
@@ -1088,9 +1308,9 @@ func (s *Server) handleTransitionTask(ctx context.Context, args TransitionTaskAr
 }
 
 func (s *Server) handleInit(ctx context.Context, args InitArgs) (string, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return "", mcpErr("Failed to load project at the given path.")
+		return "", accessErr(err, "Failed to load project at the given path.")
 	}
 	err = svc.Init.InitializeProject(args.Name)
 	if err != nil {
@@ -1100,9 +1320,9 @@ func (s *Server) handleInit(ctx context.Context, args InitArgs) (string, error)
 }
 
 func (s *Server) handleGetSpec(ctx context.Context, args GetSpecArgs) (any, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return nil, mcpErr("Failed to load project at the given path.")
+		return nil, accessErr(err, "Failed to load project at the given path.")
 	}
 	spec, err := svc.Spec.GetSpec()
 	if err != nil {
@@ -1111,10 +1331,22 @@ func (s *Server) handleGetSpec(ctx context.Context, args GetSpecArgs) (any, erro
 	return spec, nil
 }
 
+func (s *Server) handleSpecDiff(ctx context.Context, args SpecDiffArgs) (any, error) {
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
+	if err != nil {
+		return nil, accessErr(err, "Failed to load project at the given path.")
+	}
+	diff, err := svc.Spec.Diff(ctx, args.Against)
+	if err != nil {
+		return nil, mcpErr(fmt.Sprintf("Failed to diff spec: %v", err))
+	}
+	return diff, nil
+}
+
 func (s *Server) handleGetPlan(ctx context.Context, args GetPlanArgs) (any, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return nil, mcpErr("Failed to load project at the given path.")
+		return nil, accessErr(err, "Failed to load project at the given path.")
 	}
 	plan, err := svc.Plan.GetPlan()
 	if err != nil {
@@ -1124,9 +1356,9 @@ func (s *Server) handleGetPlan(ctx context.Context, args GetPlanArgs) (any, erro
 }
 
 func (s *Server) handleGetState(ctx context.Context, args GetStateArgs) (any, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return nil, mcpErr("Failed to load project at the given path.")
+		return nil, accessErr(err, "Failed to load project at the given path.")
 	}
 	state, err := svc.Plan.GetState()
 	if err != nil {
@@ -1136,45 +1368,45 @@ func (s *Server) handleGetState(ctx context.Context, args GetStateArgs) (any, er
 }
 
 func (s *Server) handleGeneratePlan(ctx context.Context, args GeneratePlanArgs) (string, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return "", mcpErr("Failed to load project at the given path.")
+		return "", accessErr(err, "Failed to load project at the given path.")
 	}
-	plan, err := svc.Plan.GeneratePlan(ctx)
+	plan, err := svc.Plan.GeneratePlan(team.WithActor(ctx, args.Actor))
 	if err != nil {
-		return "", mcpErr("Failed to generate plan. Ensure a spec exists with at least one feature.")
+		return "", accessErr(err, "Failed to generate plan. Ensure a spec exists with at least one feature.")
 	}
 	return fmt.Sprintf("Plan generated with %d tasks. Plan ID: %s", len(plan.Tasks), plan.ID), nil
 }
 
 func (s *Server) handleUpdatePlan(ctx context.Context, args UpdatePlanArgs) (string, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return "", mcpErr("Failed to load project at the given path.")
+		return "", accessErr(err, "Failed to load project at the given path.")
 	}
-	plan, err := svc.Plan.UpdatePlan(args.Tasks)
+	plan, err := svc.Plan.UpdatePlanWithActor(ctx, args.Tasks, actorOr(args.Actor, "ai"))
 	if err != nil {
-		return "", mcpErr("Failed to update plan. Ensure the task list is valid and a spec exists.")
+		return "", accessErr(err, "Failed to update plan. Ensure the task list is valid and a spec exists.")
 	}
 	return fmt.Sprintf("Plan updated with %d tasks. Plan ID: %s", len(plan.Tasks), plan.ID), nil
 }
 
 func (s *Server) handleDetectDrift(ctx context.Context, args DetectDriftArgs) (any, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return nil, mcpErr("Failed to load project at the given path.")
+		return nil, accessErr(err, "Failed to load project at the given path.")
 	}
 	report, err := svc.Drift.DetectDrift(ctx)
 	if err != nil {
 		return nil, mcpErr("Failed to detect drift. Ensure both spec and plan exist.")
 	}
-	return report, nil
+	return report.FilterByRule(args.Rules...), nil
 }
 
 func (s *Server) handleStatus(ctx context.Context, args StatusArgs) (any, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return nil, mcpErr("Failed to load project at the given path.")
+		return nil, accessErr(err, "Failed to load project at the given path.")
 	}
 	plan, err := svc.Plan.GetPlan()
 	if err != nil {
@@ -1363,9 +1595,9 @@ func containsPriority(priorities []planning.TaskPriority, p planning.TaskPriorit
 }
 
 func (s *Server) handleCheckPolicy(ctx context.Context, args CheckPolicyArgs) (any, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return nil, mcpErr("Failed to load project at the given path.")
+		return nil, accessErr(err, "Failed to load project at the given path.")
 	}
 	vioations, err := svc.Policy.CheckCompliance()
 	if err != nil {
@@ -1404,29 +1636,59 @@ func (s *Server) StartWebSocket(addr string) error {
 	return s.ServeWebSocket(context.Background(), addr)
 }
 
+// ServeStdio serves the stdio transport. With ROADY_MCP_TOKEN set it acts
+// as that member; see stdioCaller.
 func (s *Server) ServeStdio(ctx context.Context) error {
+	ctx, err := s.stdioCaller(ctx)
+	if err != nil {
+		return err
+	}
 	return mcp.ServeStdio(ctx, s.mcpServer, s.serveMiddleware())
 }
 
+// ServeHTTP serves the HTTP transport. Once member API tokens have been
+// issued, callers must present one; see memberAuthMiddleware.
 func (s *Server) ServeHTTP(ctx context.Context, addr string) error {
 	return mcp.ServeHTTPWithMiddleware(ctx, s.mcpServer, addr,
-		[]mcp.HTTPOption{mcp.WithDefaultCORS()},
+		s.httpOptions(mcp.WithDefaultCORS()),
 		s.serveMiddleware(),
 	)
 }
 
+// ServeWebSocket serves the WebSocket transport. Once member API tokens have
+// been issued, callers must present one; see memberAuthMiddleware.
 func (s *Server) ServeWebSocket(ctx context.Context, addr string) error {
 	return mcp.ServeWebSocketWithMiddleware(ctx, s.mcpServer, addr,
-		nil,
+		s.httpOptions(),
 		s.serveMiddleware(),
 	)
 }
 
+// httpOptions adds member authentication to opts.
+func (s *Server) httpOptions(opts ...mcp.HTTPOption) []mcp.HTTPOption {
+	if s.teamSvc != nil {
+		opts = append(opts, mcp.WithHTTPMiddleware(memberAuthMiddleware(s.teamSvc)))
+	}
+	return opts
+}
+
 func (s *Server) StartGRPC(addr string) error {
 	return s.ServeGRPC(context.Background(), addr)
 }
 
+// ServeGRPC serves the gRPC transport. It cannot check member API tokens,
+// so it refuses to start for a project with a team rather than serve its
+// members' tools unauthenticated.
 func (s *Server) ServeGRPC(ctx context.Context, addr string) error {
+	if s.teamSvc != nil {
+		cfg, err := s.teamSvc.ListMembers()
+		if err != nil {
+			return fmt.Errorf("load team: %w", err)
+		}
+		if cfg != nil && len(cfg.Members) > 0 {
+			return fmt.Errorf("the project has a team and the grpc transport cannot authenticate its members; use the http or ws transport")
+		}
+	}
 	return mcp.ServeGRPCWithMiddleware(ctx, s.mcpServer, addr,
 		nil,
 		s.serveMiddleware(),
@@ -1521,9 +1783,9 @@ func (s *Server) handleDebtTrend(ctx context.Context, args DebtTrendArgs) (any,
 // Coordinator-based snapshot and task query handlers (v0.6.0)
 
 func (s *Server) handleGetSnapshot(ctx context.Context, args GetSnapshotArgs) (any, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return nil, mcpErr("Failed to load project at the given path.")
+		return nil, accessErr(err, "Failed to load project at the given path.")
 	}
 	snapshot, err := svc.Plan.GetProjectSnapshot(ctx)
 	if err != nil {
@@ -1563,9 +1825,9 @@ func (s *Server) handleGetSnapshot(ctx context.Context, args GetSnapshotArgs) (a
 // read from .roady/ai.yaml and overridden by env vars at provider load time;
 // here we only need provider/model identifiers, not a live provider.
 func (s *Server) handleCostEstimate(ctx context.Context, args CostEstimateArgs) (any, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return nil, mcpErr("Failed to load project at the given path.")
+		return nil, accessErr(err, "Failed to load project at the given path.")
 	}
 
 	root := s.root
@@ -1612,9 +1874,9 @@ type domainProviderLike interface {
 // The legacy per-status handlers below delegate to it so the response shape
 // stays identical and a single code path serves both old and new tool names.
 func (s *Server) handleTasks(ctx context.Context, args TasksArgs) (any, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return nil, mcpErr("Failed to load project at the given path.")
+		return nil, accessErr(err, "Failed to load project at the given path.")
 	}
 
 	status := args.Status
@@ -1682,9 +1944,9 @@ func (s *Server) handleWorkspacePush(ctx context.Context, args WorkspacePushArgs
 	root := s.root
 	auditSvc := s.auditSvc
 	if args.ProjectPath != "" && args.ProjectPath != s.root {
-		overrideSvc, err := s.servicesForPath(args.ProjectPath, args.Project)
+		overrideSvc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 		if err != nil {
-			return nil, mcpErr("Failed to load project at the given path.")
+			return nil, accessErr(err, "Failed to load project at the given path.")
 		}
 		root = args.ProjectPath
 		auditSvc = overrideSvc.Audit
@@ -1701,9 +1963,9 @@ func (s *Server) handleWorkspacePull(ctx context.Context, args WorkspacePullArgs
 	root := s.root
 	auditSvc := s.auditSvc
 	if args.ProjectPath != "" && args.ProjectPath != s.root {
-		overrideSvc, err := s.servicesForPath(args.ProjectPath, args.Project)
+		overrideSvc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 		if err != nil {
-			return nil, mcpErr("Failed to load project at the given path.")
+			return nil, accessErr(err, "Failed to load project at the given path.")
 		}
 		root = args.ProjectPath
 		auditSvc = overrideSvc.Audit
@@ -1719,9 +1981,9 @@ func (s *Server) handleWorkspacePull(ctx context.Context, args WorkspacePullArgs
 // --- Smart Decompose Handler ---
 
 func (s *Server) handleSmartDecompose(ctx context.Context, args SmartDecomposeArgs) (any, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return nil, mcpErr("Failed to load project at the given path.")
+		return nil, accessErr(err, "Failed to load project at the given path.")
 	}
 	if err := requireAI(svc); err != nil {
 		return nil, err
@@ -1743,20 +2005,22 @@ func (s *Server) handleSmartDecompose(ctx context.Context, args SmartDecomposeAr
 type TeamAddArgs struct {
 	Name        string `json:"name" jsonschema:"description=The name of the team member"`
 	Role        string `json:"role" jsonschema:"description=The role: admin, member, or viewer"`
+	Actor       string `json:"actor,omitempty" jsonschema:"description=The team member acting (defaults to ai-agent; token-authenticated callers are recorded as their member)"`
 	ProjectPath string `json:"project_path,omitempty" jsonschema:"description=Path to the roady project directory (default: server root)"`
 	Project     string `json:"project,omitempty" jsonschema:"description=Sub-project name under .roady/projects/<name>/ (default: root project)"`
 }
 
 type TeamRemoveArgs struct {
 	Name        string `json:"name" jsonschema:"description=The name of the team member to remove"`
+	Actor       string `json:"actor,omitempty" jsonschema:"description=The team member acting (defaults to ai-agent; token-authenticated callers are recorded as their member)"`
 	ProjectPath string `json:"project_path,omitempty" jsonschema:"description=Path to the roady project directory (default: server root)"`
 	Project     string `json:"project,omitempty" jsonschema:"description=Sub-project name under .roady/projects/<name>/ (default: root project)"`
 }
 
 func (s *Server) handleTeamList(ctx context.Context, args GetSpecArgs) (any, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return nil, mcpErr("Failed to load project at the given path.")
+		return nil, accessErr(err, "Failed to load project at the given path.")
 	}
 	cfg, err := svc.Team.ListMembers()
 	if err != nil {
@@ -1772,11 +2036,11 @@ func (s *Server) handleTeamAdd(ctx context.Context, args TeamAddArgs) (string, e
 	if args.Role == "" {
 		return "", mcpErr("role is required")
 	}
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return "", mcpErr("Failed to load project at the given path.")
+		return "", accessErr(err, "Failed to load project at the given path.")
 	}
-	if err := svc.Team.AddMember(args.Name, teamRole(args.Role)); err != nil {
+	if err := svc.Team.AddMemberWithActor(ctx, args.Name, teamRole(args.Role), actorOr(args.Actor, "ai-agent")); err != nil {
 		return "", mcpErr(fmt.Sprintf("failed to add member: %s", err))
 	}
 	return fmt.Sprintf("Member %s added with role %s", args.Name, args.Role), nil
@@ -1786,11 +2050,11 @@ func (s *Server) handleTeamRemove(ctx context.Context, args TeamRemoveArgs) (str
 	if args.Name == "" {
 		return "", mcpErr("name is required")
 	}
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return "", mcpErr("Failed to load project at the given path.")
+		return "", accessErr(err, "Failed to load project at the given path.")
 	}
-	if err := svc.Team.RemoveMember(args.Name); err != nil {
+	if err := svc.Team.RemoveMemberWithActor(ctx, args.Name, actorOr(args.Actor, "ai-agent")); err != nil {
 		return "", mcpErr(fmt.Sprintf("failed to remove member: %s", err))
 	}
 	return fmt.Sprintf("Member %s removed", args.Name), nil
@@ -1802,9 +2066,9 @@ type RateListArgs struct {
 }
 
 func (s *Server) handleRateList(ctx context.Context, args RateListArgs) (any, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return nil, mcpErr("Failed to load project at the given path.")
+		return nil, accessErr(err, "Failed to load project at the given path.")
 	}
 	config, err := svc.Billing.ListRates()
 	if err != nil {
@@ -1826,9 +2090,9 @@ func (s *Server) handleRateAdd(ctx context.Context, args RateAddArgs) (string, e
 	if args.ID == "" || args.Name == "" {
 		return "", mcpErr("id and name are required")
 	}
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return "", mcpErr("Failed to load project at the given path.")
+		return "", accessErr(err, "Failed to load project at the given path.")
 	}
 	rate := billing.Rate{
 		ID:         args.ID,
@@ -1855,9 +2119,9 @@ func (s *Server) handleTaskLogTime(ctx context.Context, args TaskLogTimeArgs) (s
 	if args.TaskID == "" || args.Minutes <= 0 {
 		return "", mcpErr("task_id and minutes are required")
 	}
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return "", mcpErr("Failed to load project at the given path.")
+		return "", accessErr(err, "Failed to load project at the given path.")
 	}
 	if err := svc.Billing.LogTime(args.TaskID, args.RateID, args.Minutes, args.Description); err != nil {
 		return "", mcpErr(fmt.Sprintf("failed to log time: %s", err))
@@ -1874,9 +2138,9 @@ type CostReportArgs struct {
 }
 
 func (s *Server) handleCostReport(ctx context.Context, args CostReportArgs) (any, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return nil, mcpErr("Failed to load project at the given path.")
+		return nil, accessErr(err, "Failed to load project at the given path.")
 	}
 	opts := application.CostReportOpts{
 		TaskID: args.TaskID,
@@ -1899,9 +2163,9 @@ type CostBudgetArgs struct {
 }
 
 func (s *Server) handleCostBudget(ctx context.Context, args CostBudgetArgs) (any, error) {
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return nil, mcpErr("Failed to load project at the given path.")
+		return nil, accessErr(err, "Failed to load project at the given path.")
 	}
 	status, err := svc.Billing.GetBudgetStatus()
 	if err != nil {
@@ -1923,9 +2187,9 @@ func (s *Server) handleRateRemove(ctx context.Context, args RateRemoveArgs) (str
 	if args.ID == "" {
 		return "", mcpErr("rate id is required")
 	}
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return "", mcpErr("Failed to load project at the given path.")
+		return "", accessErr(err, "Failed to load project at the given path.")
 	}
 	if err := svc.Billing.RemoveRate(args.ID); err != nil {
 		return "", mcpErr(fmt.Sprintf("failed to remove rate: %s", err))
@@ -1943,9 +2207,9 @@ func (s *Server) handleRateSetDefault(ctx context.Context, args RateSetDefaultAr
 	if args.ID == "" {
 		return "", mcpErr("rate id is required")
 	}
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return "", mcpErr("Failed to load project at the given path.")
+		return "", accessErr(err, "Failed to load project at the given path.")
 	}
 	if err := svc.Billing.SetDefaultRate(args.ID); err != nil {
 		return "", mcpErr(fmt.Sprintf("failed to set default rate: %s", err))
@@ -1968,9 +2232,9 @@ func (s *Server) handleRateTax(ctx context.Context, args RateTaxArgs) (string, e
 	if args.Percent < 0 || args.Percent > 100 {
 		return "", mcpErr("tax percent must be between 0 and 100")
 	}
-	svc, err := s.servicesForPath(args.ProjectPath, args.Project)
+	svc, err := s.servicesForPath(ctx, args.ProjectPath, args.Project)
 	if err != nil {
-		return "", mcpErr("Failed to load project at the given path.")
+		return "", accessErr(err, "Failed to load project at the given path.")
 	}
 	if err := svc.Billing.SetTax(args.Name, args.Percent, args.Included); err != nil {
 		return "", mcpErr(fmt.Sprintf("failed to set tax: %s", err))
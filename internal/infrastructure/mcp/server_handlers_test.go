@@ -2,10 +2,12 @@ package mcp
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/felixgeelhaar/roady/pkg/domain"
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	"github.com/felixgeelhaar/roady/pkg/domain/policy"
 	"github.com/felixgeelhaar/roady/pkg/domain/spec"
@@ -87,6 +89,156 @@ func TestServer_HandleTransitionalTools(t *testing.T) {
 	if _, err := server.handleAcceptDrift(ctx, AcceptDriftArgs{}); err != nil {
 		t.Fatalf("handleAcceptDrift failed: %v", err)
 	}
+
+	result, err := server.handleSpecDiff(ctx, SpecDiffArgs{})
+	if err != nil {
+		t.Fatalf("handleSpecDiff failed: %v", err)
+	}
+	if d, ok := result.(interface{ IsEmpty() bool }); !ok || !d.IsEmpty() {
+		t.Fatalf("expected empty spec diff after accepting drift, got %+v", result)
+	}
+}
+
+func TestServer_HandlePlanHistoryTools(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := storage.NewFilesystemRepository(tempDir)
+	if err := repo.Initialize(); err != nil {
+		t.Fatalf("initialize repo: %v", err)
+	}
+	v1 := &planning.Plan{ID: "plan-1", ApprovalStatus: planning.ApprovalPending, Tasks: []planning.Task{{ID: "t1", Title: "Task 1"}}}
+	if err := repo.SavePlan(v1); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+
+	server, err := NewServer(tempDir)
+	if err != nil {
+		t.Fatalf("create server: %v", err)
+	}
+	ctx := context.Background()
+
+	if _, err := server.handleApprovePlan(ctx, ApprovePlanArgs{}); err != nil {
+		t.Fatalf("handleApprovePlan failed: %v", err)
+	}
+	v2 := &planning.Plan{ID: "plan-1", ApprovalStatus: planning.ApprovalPending, Tasks: []planning.Task{{ID: "t1", Title: "Task 1"}, {ID: "t2", Title: "Task 2"}}}
+	if err := repo.SavePlan(v2); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+
+	result, err := server.handlePlanHistory(ctx, PlanHistoryArgs{})
+	if err != nil {
+		t.Fatalf("handlePlanHistory failed: %v", err)
+	}
+	history, ok := result.([]planning.PlanRevision)
+	if !ok || len(history) != 1 {
+		t.Fatalf("expected one revision, got %+v", result)
+	}
+
+	result, err = server.handlePlanDiff(ctx, PlanDiffArgs{From: history[0].Hash[:8]})
+	if err != nil {
+		t.Fatalf("handlePlanDiff failed: %v", err)
+	}
+	if d, ok := result.(*planning.PlanDiff); !ok || len(d.Tasks) != 1 || d.Tasks[0].TaskID != "t2" {
+		t.Fatalf("expected t2 to be added, got %+v", result)
+	}
+
+	if _, err := server.handlePlanRollback(ctx, PlanRollbackArgs{Revision: "zzzz"}); err == nil {
+		t.Error("expected an unknown revision to fail")
+	}
+	msg, err := server.handlePlanRollback(ctx, PlanRollbackArgs{Revision: history[0].Hash})
+	if err != nil || !strings.Contains(msg, "roady_approve_plan") {
+		t.Fatalf("handlePlanRollback: %q, %v", msg, err)
+	}
+	if loaded, _ := repo.LoadPlan(); len(loaded.Tasks) != 1 || loaded.ApprovalStatus != planning.ApprovalPending {
+		t.Errorf("expected v1 restored as pending, got %+v", loaded)
+	}
+}
+
+func TestServer_HandlePlanSchedule(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := storage.NewFilesystemRepository(tempDir)
+	if err := repo.Initialize(); err != nil {
+		t.Fatalf("initialize repo: %v", err)
+	}
+	plan := &planning.Plan{ID: "plan-1", Tasks: []planning.Task{
+		{ID: "t1", Title: "Task 1", Estimate: "1d"},
+		{ID: "t2", Title: "Task 2", Estimate: "2h", DependsOn: []string{"t1"}},
+		{ID: "t3", Title: "Task 3", Estimate: "4h"},
+	}}
+	if err := repo.SavePlan(plan); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+
+	server, err := NewServer(tempDir)
+	if err != nil {
+		t.Fatalf("create server: %v", err)
+	}
+	ctx := context.Background()
+
+	result, err := server.handlePlanSchedule(ctx, PlanScheduleArgs{TeamSize: 1})
+	if err != nil {
+		t.Fatalf("handlePlanSchedule failed: %v", err)
+	}
+	sched, ok := result.(*planning.Schedule)
+	if !ok || sched.Capacity != 1 || sched.TotalHours != 14 || !slices.Equal(sched.CriticalPath, []string{"t1", "t2"}) {
+		t.Fatalf("unexpected schedule %+v", result)
+	}
+
+	result, err = server.handlePlanSchedule(ctx, PlanScheduleArgs{Format: "mermaid"})
+	if err != nil {
+		t.Fatalf("handlePlanSchedule failed: %v", err)
+	}
+	if text, _ := result.(string); !strings.Contains(text, "Task 1 :crit, t1, ") {
+		t.Errorf("unexpected mermaid output %q", text)
+	}
+	if _, err := server.handlePlanSchedule(ctx, PlanScheduleArgs{Format: "svg"}); err == nil {
+		t.Error("expected an unknown format to fail")
+	}
+}
+
+func TestServer_HandleTransitionTask_VerifyCriteria(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := storage.NewFilesystemRepository(tempDir)
+	if err := repo.Initialize(); err != nil {
+		t.Fatalf("initialize repo: %v", err)
+	}
+	if err := repo.SavePlan(&planning.Plan{
+		ID:             "plan-1",
+		ApprovalStatus: planning.ApprovalApproved,
+		Tasks:          []planning.Task{{ID: "task-1", Title: "Task 1", AcceptanceCriteria: []string{"Receipts are emailed"}}},
+	}); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+	state := planning.NewExecutionState("plan-1")
+	state.TaskStates["task-1"] = planning.TaskResult{Status: planning.StatusDone}
+	if err := repo.SaveState(state); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+
+	server, err := NewServer(tempDir)
+	if err != nil {
+		t.Fatalf("create server: %v", err)
+	}
+	ctx := context.Background()
+
+	_, err = server.handleTransitionTask(ctx, TransitionTaskArgs{TaskID: "task-1", Event: "verify"})
+	if err == nil || !strings.Contains(err.Error(), "Receipts are emailed") {
+		t.Fatalf("expected verify without evidence to fail, got %v", err)
+	}
+
+	if _, err := server.handleTransitionTask(ctx, TransitionTaskArgs{
+		TaskID:           "task-1",
+		Event:            "verify",
+		CriteriaEvidence: []string{"1=test:TestReceipt"},
+	}); err != nil {
+		t.Fatalf("handleTransitionTask verify failed: %v", err)
+	}
+	loaded, err := repo.LoadState()
+	if err != nil {
+		t.Fatalf("load state: %v", err)
+	}
+	if got := loaded.TaskStates["task-1"]; got.Status != planning.StatusVerified || got.CriteriaEvidence[0].RecordedBy != "ai-agent" {
+		t.Fatalf("unexpected state: %+v", got)
+	}
 }
 
 func TestServerHandleStatusCounts(t *testing.T) {
@@ -670,3 +822,42 @@ func TestServerHandleDebtTrend(t *testing.T) {
 		t.Fatal("expected non-nil result with custom days")
 	}
 }
+
+func TestServer_HandleQueryEvents(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := storage.NewFilesystemRepository(tempDir)
+	if err := repo.Initialize(); err != nil {
+		t.Fatalf("initialize repo: %v", err)
+	}
+	server, err := NewServer(tempDir)
+	if err != nil {
+		t.Fatalf("create server: %v", err)
+	}
+	for _, e := range []struct{ action, actor, taskID string }{
+		{events.EventTypeTaskStarted, "ai:claude", "t1"},
+		{events.EventTypeTaskCompleted, "alice", "t1"},
+		{events.EventTypeTaskStarted, "ai:claude", "t2"},
+	} {
+		if err := server.auditSvc.Log(e.action, e.actor, map[string]interface{}{"task_id": e.taskID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx := context.Background()
+
+	result, err := server.handleQueryEvents(ctx, QueryEventsArgs{Types: []string{"task.*"}, Actor: "ai*", Since: "1h"})
+	if err != nil {
+		t.Fatalf("handleQueryEvents failed: %v", err)
+	}
+	if evts, ok := result.([]*events.BaseEvent); !ok || len(evts) != 2 {
+		t.Fatalf("expected two AI task events, got %+v", result)
+	}
+
+	result, _ = server.handleQueryEvents(ctx, QueryEventsArgs{Metadata: map[string]string{"task_id": "t1"}, Offset: 1})
+	if evts, _ := result.([]*events.BaseEvent); len(evts) != 1 || evts[0].Type != events.EventTypeTaskCompleted {
+		t.Errorf("expected the second t1 event, got %+v", result)
+	}
+
+	if _, err := server.handleQueryEvents(ctx, QueryEventsArgs{Since: "last week"}); err == nil {
+		t.Error("expected an invalid since to fail")
+	}
+}
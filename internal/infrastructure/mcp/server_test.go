@@ -2,6 +2,7 @@ package mcp
 
 import (
 	"context"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -10,9 +11,11 @@ import (
 	"time"
 
 	"github.com/felixgeelhaar/roady/internal/infrastructure/config"
+	"github.com/felixgeelhaar/roady/pkg/application"
 	"github.com/felixgeelhaar/roady/pkg/domain"
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	"github.com/felixgeelhaar/roady/pkg/domain/spec"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
 	"github.com/felixgeelhaar/roady/pkg/storage"
 )
 
@@ -291,7 +294,7 @@ func TestServicesForPath_DefaultRoot(t *testing.T) {
 	}
 
 	// Empty override returns cached services
-	svc, err := server.servicesForPath("", "")
+	svc, err := server.servicesForPath(context.Background(), "", "")
 	if err != nil {
 		t.Fatalf("servicesForPath empty: %v", err)
 	}
@@ -300,7 +303,7 @@ func TestServicesForPath_DefaultRoot(t *testing.T) {
 	}
 
 	// Same root returns cached services
-	svc, err = server.servicesForPath(root, "")
+	svc, err = server.servicesForPath(context.Background(), root, "")
 	if err != nil {
 		t.Fatalf("servicesForPath same root: %v", err)
 	}
@@ -340,7 +343,7 @@ func TestServicesForPath_Override(t *testing.T) {
 	}
 
 	// Override path builds fresh services
-	svc, err := server.servicesForPath(rootB, "")
+	svc, err := server.servicesForPath(context.Background(), rootB, "")
 	if err != nil {
 		t.Fatalf("servicesForPath override: %v", err)
 	}
@@ -349,6 +352,45 @@ func TestServicesForPath_Override(t *testing.T) {
 	}
 }
 
+func TestServicesForPath_CallerNeedsTargetTeam(t *testing.T) {
+	rootA, rootB := t.TempDir(), t.TempDir()
+	for _, root := range []string{rootA, rootB} {
+		repo := storage.NewFilesystemRepository(root)
+		if err := repo.Initialize(); err != nil {
+			t.Fatalf("initialize repo: %v", err)
+		}
+		if err := initMockAIConfig(root); err != nil {
+			t.Fatalf("init mock AI config: %v", err)
+		}
+	}
+	repoB := storage.NewFilesystemRepository(rootB)
+	teamB := application.NewTeamService(repoB, application.NewAuditService(repoB))
+	if err := teamB.AddMember("mo", team.RoleMember); err != nil {
+		t.Fatal(err)
+	}
+
+	server, err := NewServer(rootA)
+	if err != nil {
+		t.Fatalf("create server: %v", err)
+	}
+	as := func(name string, role team.Role) context.Context {
+		return team.WithCaller(context.Background(), team.Member{Name: name, Role: role})
+	}
+
+	if _, err := server.servicesForPath(as("mo", team.RoleMember), rootB, ""); err != nil {
+		t.Errorf("member on both teams: %v", err)
+	}
+	if _, err := server.servicesForPath(as("mo", team.RoleAdmin), rootB, ""); !errors.Is(err, team.ErrForbidden) {
+		t.Errorf("admin here, member there: err = %v, want forbidden", err)
+	}
+	if _, err := server.servicesForPath(as("zed", team.RoleViewer), rootB, ""); !errors.Is(err, team.ErrForbidden) {
+		t.Errorf("not on the target team: err = %v, want forbidden", err)
+	}
+	if _, err := server.servicesForPath(as("zed", team.RoleViewer), "", ""); err != nil {
+		t.Errorf("own project: %v", err)
+	}
+}
+
 func TestServicesForPath_CacheHitAndEviction(t *testing.T) {
 	// Set up the "home" project.
 	rootA := t.TempDir()
@@ -386,11 +428,11 @@ func TestServicesForPath_CacheHitAndEviction(t *testing.T) {
 	}
 
 	// First call builds, second call should return the cached instance.
-	svc1, err := srv.servicesForPath(dirs[0], "")
+	svc1, err := srv.servicesForPath(context.Background(), dirs[0], "")
 	if err != nil {
 		t.Fatalf("first call: %v", err)
 	}
-	svc2, err := srv.servicesForPath(dirs[0], "")
+	svc2, err := srv.servicesForPath(context.Background(), dirs[0], "")
 	if err != nil {
 		t.Fatalf("cached call: %v", err)
 	}
@@ -400,13 +442,13 @@ func TestServicesForPath_CacheHitAndEviction(t *testing.T) {
 
 	// Fill the cache beyond the cap.
 	for _, d := range dirs[1:] {
-		if _, err := srv.servicesForPath(d, ""); err != nil {
+		if _, err := srv.servicesForPath(context.Background(), d, ""); err != nil {
 			t.Fatalf("fill cache: %v", err)
 		}
 	}
 
 	// The first entry should have been evicted.
-	svc3, err := srv.servicesForPath(dirs[0], "")
+	svc3, err := srv.servicesForPath(context.Background(), dirs[0], "")
 	if err != nil {
 		t.Fatalf("post-eviction call: %v", err)
 	}
@@ -0,0 +1,61 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/drift"
+	"github.com/felixgeelhaar/roady/pkg/domain/policy"
+)
+
+// FailOnNone disables the exit-code gate.
+const FailOnNone = "none"
+
+// ParseFailOn validates a --fail-on value. It returns the minimum severity
+// that fails the run, or "" for "none".
+func ParseFailOn(s string) (drift.Severity, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == FailOnNone {
+		return "", nil
+	}
+	sev, err := drift.ParseSeverity(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid --fail-on %q (expected none, low, medium, high or critical)", s)
+	}
+	return sev, nil
+}
+
+// ViolationSeverity grades a policy violation on the drift severity scale,
+// matching DriftDetector.DetectPolicyDrift: errors are high, warnings medium.
+func ViolationSeverity(v policy.Violation) drift.Severity {
+	if v.Level == policy.ViolationWarning {
+		return drift.SeverityMedium
+	}
+	return drift.SeverityHigh
+}
+
+// DriftFails reports whether any issue reaches the threshold.
+func DriftFails(r *drift.Report, threshold drift.Severity) bool {
+	if r == nil || threshold == "" {
+		return false
+	}
+	for _, issue := range r.Issues {
+		if issue.Severity.Rank() >= threshold.Rank() {
+			return true
+		}
+	}
+	return false
+}
+
+// PolicyFails reports whether any violation reaches the threshold.
+func PolicyFails(violations []policy.Violation, threshold drift.Severity) bool {
+	if threshold == "" {
+		return false
+	}
+	for _, v := range violations {
+		if ViolationSeverity(v).Rank() >= threshold.Rank() {
+			return true
+		}
+	}
+	return false
+}
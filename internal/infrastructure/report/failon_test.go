@@ -0,0 +1,49 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/felixgeelhaar/roady/internal/infrastructure/report"
+	"github.com/felixgeelhaar/roady/pkg/domain/drift"
+	"github.com/felixgeelhaar/roady/pkg/domain/policy"
+)
+
+func TestParseFailOn(t *testing.T) {
+	if sev, err := report.ParseFailOn("none"); err != nil || sev != "" {
+		t.Errorf("none: got %q, %v", sev, err)
+	}
+	if sev, err := report.ParseFailOn("HIGH"); err != nil || sev != drift.SeverityHigh {
+		t.Errorf("HIGH: got %q, %v", sev, err)
+	}
+	if _, err := report.ParseFailOn("severe"); err == nil {
+		t.Error("expected error for unknown threshold")
+	}
+}
+
+func TestDriftFails(t *testing.T) {
+	r := &drift.Report{Issues: []drift.Issue{{Severity: drift.SeverityMedium}}}
+	cases := map[drift.Severity]bool{
+		"":                   false,
+		drift.SeverityLow:    true,
+		drift.SeverityMedium: true,
+		drift.SeverityHigh:   false,
+	}
+	for threshold, want := range cases {
+		if got := report.DriftFails(r, threshold); got != want {
+			t.Errorf("threshold %q: got %v, want %v", threshold, got, want)
+		}
+	}
+}
+
+func TestPolicyFails(t *testing.T) {
+	warn := []policy.Violation{{Level: policy.ViolationWarning}}
+	if !report.PolicyFails(warn, drift.SeverityMedium) {
+		t.Error("expected warning to fail at medium")
+	}
+	if report.PolicyFails(warn, drift.SeverityHigh) {
+		t.Error("expected warning to pass at high")
+	}
+	if !report.PolicyFails([]policy.Violation{{Level: policy.ViolationError}}, drift.SeverityHigh) {
+		t.Error("expected error to fail at high")
+	}
+}
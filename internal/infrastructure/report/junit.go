@@ -0,0 +1,170 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/drift"
+	"github.com/felixgeelhaar/roady/pkg/domain/policy"
+	"github.com/felixgeelhaar/roady/pkg/domain/spec"
+)
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string         `xml:"name,attr"`
+	Classname string         `xml:"classname,attr"`
+	Failures  []junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// DriftJUnit renders a drift report as JUnit XML with one testcase per spec
+// requirement. A requirement fails when an issue points at it or at its
+// task-<id> task. Issues that cannot be attributed to a requirement (orphan
+// tasks, policy violations, feature-level changes) each get their own
+// failing testcase in a separate suite.
+func DriftJUnit(r *drift.Report, s *spec.ProductSpec) ([]byte, error) {
+	var issues []drift.Issue
+	if r != nil {
+		issues = r.Issues
+	}
+	claimed := make([]bool, len(issues))
+
+	reqSuite := junitSuite{Name: "requirements"}
+	if s != nil {
+		for _, f := range s.Features {
+			for _, req := range f.Requirements {
+				tc := junitCase{Name: fmt.Sprintf("%s: %s", req.ID, req.Title), Classname: f.ID}
+				for i, issue := range issues {
+					if issueTargets(issue, req.ID) {
+						claimed[i] = true
+						tc.Failures = append(tc.Failures, driftFailure(issue))
+					}
+				}
+				reqSuite.add(tc)
+			}
+		}
+	}
+
+	otherSuite := junitSuite{Name: "unattributed"}
+	for i, issue := range issues {
+		if claimed[i] {
+			continue
+		}
+		classname := issue.RuleID
+		if classname == "" {
+			classname = string(issue.Type)
+		}
+		otherSuite.add(junitCase{Name: issue.ID, Classname: classname, Failures: []junitFailure{driftFailure(issue)}})
+	}
+
+	return marshalJUnit("roady drift", reqSuite, otherSuite)
+}
+
+// PolicyJUnit renders a compliance check as JUnit XML with one testcase per
+// active rule; each violation of the rule is a failure.
+func PolicyJUnit(ruleIDs []string, violations []policy.Violation) ([]byte, error) {
+	suite := junitSuite{Name: "policy"}
+	ids := make([]string, 0, len(ruleIDs))
+	seen := make(map[string]bool, len(ruleIDs))
+	for _, id := range ruleIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	// Violations from rules no longer active still need a testcase.
+	for _, v := range violations {
+		if !seen[v.RuleID] {
+			seen[v.RuleID] = true
+			ids = append(ids, v.RuleID)
+		}
+	}
+
+	for _, id := range ids {
+		tc := junitCase{Name: id, Classname: "policy"}
+		for _, v := range violations {
+			if v.RuleID != id {
+				continue
+			}
+			tc.Failures = append(tc.Failures, junitFailure{
+				Message: v.Message,
+				Type:    string(v.Level),
+				Body:    strings.TrimSpace(fmt.Sprintf("%s %s", v.TaskID, v.Message)),
+			})
+		}
+		suite.add(tc)
+	}
+
+	return marshalJUnit("roady policy", suite)
+}
+
+func (s *junitSuite) add(tc junitCase) {
+	s.Cases = append(s.Cases, tc)
+	s.Tests++
+	if len(tc.Failures) > 0 {
+		s.Failures++
+	}
+}
+
+func marshalJUnit(name string, suites ...junitSuite) ([]byte, error) {
+	root := junitSuites{Name: name}
+	for _, s := range suites {
+		if s.Tests == 0 {
+			continue
+		}
+		root.Suites = append(root.Suites, s)
+		root.Tests += s.Tests
+		root.Failures += s.Failures
+	}
+	data, err := xml.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// issueTargets reports whether an issue concerns the given requirement.
+func issueTargets(issue drift.Issue, reqID string) bool {
+	taskID := "task-" + reqID
+	return issue.ComponentID == reqID || issue.ComponentID == taskID || slices.Contains(issue.TaskIDs, taskID)
+}
+
+func driftFailure(issue drift.Issue) junitFailure {
+	body := issue.Message
+	if issue.Path != "" {
+		loc := issue.Path
+		if issue.Line > 0 {
+			loc = fmt.Sprintf("%s:%d", loc, issue.Line)
+		}
+		body = fmt.Sprintf("%s\nat %s", body, loc)
+	}
+	if issue.Hint != "" {
+		body = fmt.Sprintf("%s\nHint: %s", body, issue.Hint)
+	}
+	return junitFailure{
+		Message: issue.Message,
+		Type:    fmt.Sprintf("%s/%s/%s", issue.Type, issue.Category, issue.Severity),
+		Body:    body,
+	}
+}
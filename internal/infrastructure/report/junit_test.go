@@ -0,0 +1,83 @@
+package report_test
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/felixgeelhaar/roady/internal/infrastructure/report"
+	"github.com/felixgeelhaar/roady/pkg/domain/drift"
+	"github.com/felixgeelhaar/roady/pkg/domain/policy"
+	"github.com/felixgeelhaar/roady/pkg/domain/spec"
+)
+
+type junitDoc struct {
+	Tests    int `xml:"tests,attr"`
+	Failures int `xml:"failures,attr"`
+	Suites   []struct {
+		Name  string `xml:"name,attr"`
+		Cases []struct {
+			Name     string `xml:"name,attr"`
+			Failures []struct {
+				Type string `xml:"type,attr"`
+			} `xml:"failure"`
+		} `xml:"testcase"`
+	} `xml:"testsuite"`
+}
+
+func decodeJUnit(t *testing.T, data []byte) junitDoc {
+	t.Helper()
+	var doc junitDoc
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid JUnit XML: %v\n%s", err, data)
+	}
+	return doc
+}
+
+func TestDriftJUnit(t *testing.T) {
+	s := &spec.ProductSpec{Features: []spec.Feature{
+		{ID: "f1", Requirements: []spec.Requirement{{ID: "r1", Title: "Login"}, {ID: "r2", Title: "Logout"}}},
+	}}
+	r := &drift.Report{Issues: []drift.Issue{
+		{ID: "missing-task-r1", Type: drift.DriftTypePlan, Category: drift.CategoryMissing, Severity: drift.SeverityHigh, ComponentID: "r1"},
+		{ID: "orphan-task-t9", Type: drift.DriftTypePlan, Category: drift.CategoryOrphan, Severity: drift.SeverityMedium, ComponentID: "t9"},
+	}}
+
+	data, err := report.DriftJUnit(r, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := decodeJUnit(t, data)
+
+	if doc.Tests != 3 || doc.Failures != 2 {
+		t.Fatalf("expected 3 tests with 2 failures, got %d/%d", doc.Tests, doc.Failures)
+	}
+	reqs := doc.Suites[0]
+	if reqs.Name != "requirements" || len(reqs.Cases) != 2 {
+		t.Fatalf("unexpected requirements suite: %+v", reqs)
+	}
+	if len(reqs.Cases[0].Failures) != 1 || reqs.Cases[0].Failures[0].Type != "plan/MISSING/high" {
+		t.Errorf("expected r1 to fail with plan/MISSING/high, got %+v", reqs.Cases[0])
+	}
+	if len(reqs.Cases[1].Failures) != 0 {
+		t.Errorf("expected r2 to pass, got %+v", reqs.Cases[1])
+	}
+	if doc.Suites[1].Name != "unattributed" || doc.Suites[1].Cases[0].Name != "orphan-task-t9" {
+		t.Errorf("expected orphan issue in unattributed suite, got %+v", doc.Suites[1])
+	}
+}
+
+func TestPolicyJUnit(t *testing.T) {
+	data, err := report.PolicyJUnit([]string{"max-wip", "dependency-check"}, []policy.Violation{
+		{RuleID: "max-wip", Level: policy.ViolationError, Message: "too much"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := decodeJUnit(t, data)
+	if doc.Tests != 2 || doc.Failures != 1 {
+		t.Fatalf("expected 2 tests with 1 failure, got %d/%d", doc.Tests, doc.Failures)
+	}
+	if doc.Suites[0].Cases[0].Failures[0].Type != "error" {
+		t.Errorf("expected error failure, got %+v", doc.Suites[0].Cases[0])
+	}
+}
@@ -0,0 +1,224 @@
+// Package report renders drift and policy findings in CI interchange
+// formats: SARIF 2.1.0 for code scanning and JUnit XML for test report UIs.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/drift"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/policy"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolName     = "roady"
+	toolURI      = "https://github.com/felixgeelhaar/roady"
+)
+
+// Options controls how findings are rendered.
+type Options struct {
+	// ToolVersion is reported as the SARIF driver version.
+	ToolVersion string
+	// DefaultURI is the artifact location used for findings that carry no
+	// source path of their own, typically the project's spec.yaml.
+	DefaultURI string
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID     string          `json:"ruleId"`
+	RuleIndex  int             `json:"ruleIndex"`
+	Level      string          `json:"level"`
+	Message    sarifMessage    `json:"message"`
+	Locations  []sarifLocation `json:"locations"`
+	Properties map[string]any  `json:"properties,omitempty"`
+	// PartialFingerprints keeps results stable across runs so code scanning
+	// can track a finding instead of re-opening it.
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifact `json:"artifactLocation"`
+	Region           *sarifRegion  `json:"region,omitempty"`
+}
+
+type sarifArtifact struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// DriftSARIF renders a drift report as a SARIF 2.1.0 log. Each issue becomes
+// a result located at its source document and line, falling back to
+// opts.DefaultURI when the issue has no path.
+func DriftSARIF(r *drift.Report, opts Options) ([]byte, error) {
+	b := newSARIFBuilder(opts)
+	if r != nil {
+		for _, issue := range r.Issues {
+			ruleID := issue.RuleID
+			if ruleID == "" {
+				ruleID = string(issue.Type)
+			}
+			props := map[string]any{
+				"type":     issue.Type,
+				"category": issue.Category,
+				"severity": issue.Severity,
+			}
+			if issue.ComponentID != "" {
+				props["component_id"] = issue.ComponentID
+			}
+			if len(issue.TaskIDs) > 0 {
+				props["task_ids"] = issue.TaskIDs
+			}
+			if len(issue.Commits) > 0 {
+				props["commits"] = issue.Commits
+			}
+			msg := issue.Message
+			if issue.Hint != "" {
+				msg = fmt.Sprintf("%s %s", msg, issue.Hint)
+			}
+			b.add(ruleID, fmt.Sprintf("Roady %s drift", ruleID), sarifLevel(issue.Severity), msg, issue.Path, issue.Line, issue.ID, props)
+		}
+	}
+	return b.marshal()
+}
+
+// PolicySARIF renders policy violations as a SARIF 2.1.0 log. Violations
+// tied to a task are located at the spec citation the task was derived
+// from, when the plan records one.
+func PolicySARIF(violations []policy.Violation, plan *planning.Plan, opts Options) ([]byte, error) {
+	sources := taskSources(plan)
+	b := newSARIFBuilder(opts)
+	for _, v := range violations {
+		src := sources[v.TaskID]
+		id := v.RuleID
+		if v.TaskID != "" {
+			id = fmt.Sprintf("%s/%s", v.RuleID, v.TaskID)
+		}
+		props := map[string]any{"level": v.Level}
+		if v.TaskID != "" {
+			props["task_id"] = v.TaskID
+		}
+		b.add(v.RuleID, fmt.Sprintf("Roady policy rule %s", v.RuleID), sarifLevel(ViolationSeverity(v)), v.Message, src.Doc, src.Line, id, props)
+	}
+	return b.marshal()
+}
+
+type sarifBuilder struct {
+	opts      Options
+	run       sarifRun
+	ruleIndex map[string]int
+}
+
+func newSARIFBuilder(opts Options) *sarifBuilder {
+	return &sarifBuilder{
+		opts: opts,
+		run: sarifRun{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           toolName,
+				Version:        opts.ToolVersion,
+				InformationURI: toolURI,
+				Rules:          make([]sarifRule, 0),
+			}},
+			Results: make([]sarifResult, 0),
+		},
+		ruleIndex: make(map[string]int),
+	}
+}
+
+func (b *sarifBuilder) add(ruleID, ruleDesc, level, msg, path string, line int, fingerprint string, props map[string]any) {
+	idx, ok := b.ruleIndex[ruleID]
+	if !ok {
+		idx = len(b.run.Tool.Driver.Rules)
+		b.ruleIndex[ruleID] = idx
+		b.run.Tool.Driver.Rules = append(b.run.Tool.Driver.Rules, sarifRule{ID: ruleID, ShortDescription: sarifMessage{Text: ruleDesc}})
+	}
+
+	if path == "" {
+		path, line = b.opts.DefaultURI, 0
+	}
+	loc := sarifPhysicalLocation{ArtifactLocation: sarifArtifact{URI: path}}
+	if line > 0 {
+		loc.Region = &sarifRegion{StartLine: line}
+	}
+
+	b.run.Results = append(b.run.Results, sarifResult{
+		RuleID:              ruleID,
+		RuleIndex:           idx,
+		Level:               level,
+		Message:             sarifMessage{Text: msg},
+		Locations:           []sarifLocation{{PhysicalLocation: loc}},
+		Properties:          props,
+		PartialFingerprints: map[string]string{"roadyIssueId/v1": fingerprint},
+	})
+}
+
+func (b *sarifBuilder) marshal() ([]byte, error) {
+	return json.MarshalIndent(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{b.run},
+	}, "", "  ")
+}
+
+// sarifLevel maps a drift severity to a SARIF result level.
+func sarifLevel(s drift.Severity) string {
+	switch s {
+	case drift.SeverityCritical, drift.SeverityHigh:
+		return "error"
+	case drift.SeverityMedium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+func taskSources(plan *planning.Plan) map[string]planning.TaskSource {
+	sources := make(map[string]planning.TaskSource)
+	if plan == nil {
+		return sources
+	}
+	for _, t := range plan.Tasks {
+		sources[t.ID] = t.Source
+	}
+	return sources
+}
@@ -0,0 +1,110 @@
+package report_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/felixgeelhaar/roady/internal/infrastructure/report"
+	"github.com/felixgeelhaar/roady/pkg/domain/drift"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/policy"
+)
+
+type sarifDoc struct {
+	Version string `json:"version"`
+	Runs    []struct {
+		Tool struct {
+			Driver struct {
+				Rules []struct {
+					ID string `json:"id"`
+				} `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []struct {
+			RuleID    string `json:"ruleId"`
+			RuleIndex int    `json:"ruleIndex"`
+			Level     string `json:"level"`
+			Locations []struct {
+				PhysicalLocation struct {
+					ArtifactLocation struct {
+						URI string `json:"uri"`
+					} `json:"artifactLocation"`
+					Region *struct {
+						StartLine int `json:"startLine"`
+					} `json:"region"`
+				} `json:"physicalLocation"`
+			} `json:"locations"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+func decodeSARIF(t *testing.T, data []byte) sarifDoc {
+	t.Helper()
+	var doc sarifDoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid SARIF JSON: %v", err)
+	}
+	if doc.Version != "2.1.0" || len(doc.Runs) != 1 {
+		t.Fatalf("unexpected SARIF envelope: %+v", doc)
+	}
+	return doc
+}
+
+func TestDriftSARIF(t *testing.T) {
+	r := &drift.Report{Issues: []drift.Issue{
+		{ID: "missing-task-r1", RuleID: "plan", Type: drift.DriftTypePlan, Category: drift.CategoryMissing, Severity: drift.SeverityHigh, Message: "missing", Path: "docs/auth.md", Line: 14},
+		{ID: "orphan-task-t9", RuleID: "plan", Type: drift.DriftTypePlan, Category: drift.CategoryOrphan, Severity: drift.SeverityMedium, Message: "orphan"},
+		{ID: "intent-added-feature-f2", RuleID: "intent", Type: drift.DriftTypeSpec, Severity: drift.SeverityLow, Message: "added"},
+	}}
+
+	data, err := report.DriftSARIF(r, report.Options{ToolVersion: "1.2.3", DefaultURI: ".roady/spec.yaml"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := decodeSARIF(t, data)
+	run := doc.Runs[0]
+
+	if len(run.Tool.Driver.Rules) != 2 || run.Tool.Driver.Rules[0].ID != "plan" || run.Tool.Driver.Rules[1].ID != "intent" {
+		t.Errorf("expected rules [plan intent], got %+v", run.Tool.Driver.Rules)
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(run.Results))
+	}
+
+	first := run.Results[0]
+	loc := first.Locations[0].PhysicalLocation
+	if first.Level != "error" || loc.ArtifactLocation.URI != "docs/auth.md" || loc.Region == nil || loc.Region.StartLine != 14 {
+		t.Errorf("unexpected first result: %+v", first)
+	}
+
+	second := run.Results[1]
+	loc = second.Locations[0].PhysicalLocation
+	if second.Level != "warning" || loc.ArtifactLocation.URI != ".roady/spec.yaml" || loc.Region != nil {
+		t.Errorf("expected default location without region, got %+v", second)
+	}
+
+	if third := run.Results[2]; third.Level != "note" || third.RuleIndex != 1 {
+		t.Errorf("unexpected third result: %+v", third)
+	}
+}
+
+func TestPolicySARIF_LocatesTaskSource(t *testing.T) {
+	plan := &planning.Plan{Tasks: []planning.Task{{ID: "t1", Source: planning.TaskSource{Doc: "docs/spec.md", Line: 3}}}}
+	violations := []policy.Violation{
+		{RuleID: "needs-owner", Level: policy.ViolationError, Message: "no owner", TaskID: "t1"},
+		{RuleID: "max-wip", Level: policy.ViolationWarning, Message: "too much"},
+	}
+
+	data, err := report.PolicySARIF(violations, plan, report.Options{DefaultURI: ".roady/spec.yaml"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := decodeSARIF(t, data).Runs[0].Results
+
+	if got := results[0].Locations[0].PhysicalLocation; got.ArtifactLocation.URI != "docs/spec.md" || got.Region.StartLine != 3 {
+		t.Errorf("expected task source location, got %+v", got)
+	}
+	if results[0].Level != "error" || results[1].Level != "warning" {
+		t.Errorf("unexpected levels: %s, %s", results[0].Level, results[1].Level)
+	}
+}
@@ -0,0 +1,52 @@
+package wiring
+
+import (
+	"context"
+
+	"github.com/felixgeelhaar/roady/pkg/application"
+	"github.com/felixgeelhaar/roady/pkg/domain"
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+	"github.com/felixgeelhaar/roady/pkg/storage"
+)
+
+// NewWorkspaceProjects returns the project directory of a workspace root.
+// Tasks unlocked in another project are recorded in that project's audit
+// trail, signed by signer when set, and delivered to its webhooks.
+func NewWorkspaceProjects(root string, signer *events.Signer) *application.WorkspaceProjects {
+	projects := application.NewWorkspaceProjects(root)
+	projects.SetAuditOpener(func(project string) (domain.AuditLogger, error) {
+		return openProjectAudit(root, project, signer)
+	})
+	return projects
+}
+
+// openProjectAudit opens the event-sourced audit trail of a project.
+func openProjectAudit(root, project string, signer *events.Signer) (domain.AuditLogger, error) {
+	workspace, err := NewWorkspaceForProject(root, project)
+	if err != nil {
+		return nil, err
+	}
+	eventStore, err := workspace.Repo.EventStore()
+	if err != nil {
+		return nil, err
+	}
+	publisher := storage.NewInMemoryEventPublisher()
+	if workspace.Notifier != nil {
+		notifier := workspace.Notifier
+		publisher.Subscribe(func(e *events.BaseEvent) error {
+			notifier.Notify(context.Background(), e)
+			return nil
+		})
+	}
+	auditSvc, err := application.NewEventSourcedAuditServiceWithCheckpoints(eventStore, publisher, workspace.Repo.ProjectionStore())
+	if err != nil {
+		return nil, err
+	}
+	if signer != nil {
+		checkpointLog := storage.NewFileAuditCheckpointLog(workspace.Repo.ProjectBase())
+		if err := auditSvc.EnableSigning(signer, checkpointLog, AuditCheckpointInterval); err != nil {
+			return nil, err
+		}
+	}
+	return auditSvc, nil
+}
@@ -26,6 +26,7 @@ type AppServices struct {
 	Audit      *application.EventSourcedAuditService // Event-sourced audit with dispatcher and projections
 	Usage      *application.UsageService             // Usage tracking service (separate from audit)
 	Forecast   *application.ForecastService
+	Schedule   *application.ScheduleService
 	Dependency *application.DependencyService
 	Debt       *application.DebtService // Debt analysis service (Horizon 5)
 	Plugin     *application.PluginService
@@ -79,14 +80,15 @@ func BuildAppServicesWithProviderForProject(root, project string, resolver func(
 func buildServicesWithProvider(workspace *Workspace, provider domainai.Provider, loadErr error) (*AppServices, error) {
 	// Create event store and publisher for event-sourced audit.
 	// Events live next to the project's other files (so sub-projects have isolated event streams).
-	eventStore, err := storage.NewFileEventStore(workspace.Repo.ProjectBase())
+	eventStore, err := workspace.Repo.EventStore()
 	if err != nil {
 		return nil, fmt.Errorf("create event store: %w", err)
 	}
 	publisher := storage.NewInMemoryEventPublisher()
 
 	// Create event-sourced audit service with dispatcher and projections
-	auditSvc, err := application.NewEventSourcedAuditService(eventStore, publisher)
+	checkpoints := workspace.Repo.ProjectionStore()
+	auditSvc, err := application.NewEventSourcedAuditServiceWithCheckpoints(eventStore, publisher, checkpoints)
 	if err != nil {
 		return nil, fmt.Errorf("create event-sourced audit: %w", err)
 	}
@@ -98,26 +100,42 @@ func buildServicesWithProvider(workspace *Workspace, provider domainai.Provider,
 	dispatcher.Register(events.NewTaskTransitionHandler(nil).Registration())
 	auditSvc.SetDispatcher(dispatcher)
 
+	// Sign events and record periodic checkpoints when ROADY_SIGNER is set.
+	signer, err := LoadSigner()
+	if err != nil {
+		return nil, err
+	}
+	if signer != nil {
+		checkpointLog := storage.NewFileAuditCheckpointLog(workspace.Repo.ProjectBase())
+		if err := auditSvc.EnableSigning(signer, checkpointLog, AuditCheckpointInterval); err != nil {
+			return nil, err
+		}
+	}
+
+	// The workspace's other projects, for @project:task-id dependencies
+	projects := NewWorkspaceProjects(workspace.Repo.Root(), signer)
+	projectName := workspace.Repo.SubProject()
+
 	// Create services in dependency order
+	teamSvc := application.NewTeamService(workspace.Repo, auditSvc)
 	policySvc := application.NewPolicyService(workspace.Repo)
+	policySvc.SetProjectDirectory(projects)
 	planSvc := application.NewPlanService(workspace.Repo, auditSvc)
+	planSvc.SetProjectDirectory(projectName, projects)
+	planSvc.SetTeam(teamSvc)
 	taskSvc := application.NewTaskService(workspace.Repo, auditSvc, policySvc)
-	driftSvc := application.NewDriftService(workspace.Repo, auditSvc, storage.NewCodebaseInspector(), policySvc)
+	taskSvc.SetProjectDirectory(projectName, projects)
+	taskSvc.SetTeam(teamSvc)
+	taskSvc.SetVerificationRunner(storage.NewCommandRunnerAt(workspace.Repo.Root()))
+	driftSvc := application.NewDriftService(workspace.Repo, auditSvc, storage.NewCodebaseInspectorAt(workspace.Repo.Root()), policySvc)
 	aiSvc := application.NewAIPlanningService(workspace.Repo, provider, auditSvc, planSvc)
 	debtSvc := application.NewDebtService(driftSvc, auditSvc)
+	_ = debtSvc.LoadHistory(eventStore, checkpoints)
 
-	// Create velocity projection for forecasting and hydrate from stored events
+	// Create velocity projection for forecasting and catch it up with stored
+	// events from its last checkpoint
 	velocityProjection := events.NewExtendedVelocityProjection(7, 14, 30)
-	if storedEvents, err := workspace.Repo.LoadEvents(); err == nil {
-		for _, ev := range storedEvents {
-			baseEvent := &events.BaseEvent{
-				Type:      ev.Action,
-				Timestamp: ev.Timestamp,
-				Metadata:  ev.Metadata,
-			}
-			_ = velocityProjection.Apply(baseEvent)
-		}
-	}
+	_ = events.CatchUp(velocityProjection, eventStore, checkpoints)
 
 	// Subscribe velocity projection to live events via publisher
 	publisher.Subscribe(func(e *events.BaseEvent) error {
@@ -139,6 +157,7 @@ func buildServicesWithProvider(workspace *Workspace, provider domainai.Provider,
 	// the workspace root (not the project base), so sub-projects share the same
 	// dependency search root as the repo they live in.
 	depSvc := application.NewDependencyService(workspace.Repo, workspace.Repo.Root())
+	depSvc.SetProjectDirectory(projects)
 
 	services := &AppServices{
 		Workspace:  workspace,
@@ -155,10 +174,11 @@ func buildServicesWithProvider(workspace *Workspace, provider domainai.Provider,
 		Audit:      auditSvc,
 		Usage:      workspace.Usage,
 		Forecast:   forecastSvc,
+		Schedule:   application.NewScheduleService(workspace.Repo),
 		Dependency: depSvc,
 		Debt:       debtSvc,
 		Plugin:     application.NewPluginService(workspace.Repo),
-		Team:       application.NewTeamService(workspace.Repo, auditSvc),
+		Team:       teamSvc,
 		Publisher:  publisher,
 		Provider:   provider,
 	}
@@ -0,0 +1,109 @@
+package wiring
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+)
+
+// Environment variables that select the identity signing audit events.
+// ROADY_SIGNER names a team member or agent; its private key is read from
+// ROADY_SIGNING_KEY, or ~/.roady/keys/<signer>.key by default.
+const (
+	SignerEnv     = "ROADY_SIGNER"
+	SigningKeyEnv = "ROADY_SIGNING_KEY"
+)
+
+// AuditCheckpointInterval is how often a signed audit checkpoint is recorded
+// while a signer is configured.
+const AuditCheckpointInterval = 24 * time.Hour
+
+// signerNamePattern matches identities usable as key file names.
+var signerNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.@-]*$`)
+
+// SigningKeyPath returns the default private key path for an identity.
+func SigningKeyPath(id string) (string, error) {
+	if !signerNamePattern.MatchString(id) {
+		return "", fmt.Errorf("invalid signer name %q", id)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".roady", "keys", id+".key"), nil
+}
+
+// LoadSigner returns the signer selected by ROADY_SIGNER, or nil when
+// signing is not configured.
+func LoadSigner() (*events.Signer, error) {
+	id := os.Getenv(SignerEnv)
+	if id == "" {
+		return nil, nil
+	}
+	path := os.Getenv(SigningKeyEnv)
+	if path == "" {
+		var err error
+		if path, err = SigningKeyPath(id); err != nil {
+			return nil, err
+		}
+	}
+	key, err := ReadSigningKey(path)
+	if err != nil {
+		return nil, fmt.Errorf("signing key for %s: %w", id, err)
+	}
+	return events.NewSigner(id, key)
+}
+
+// GenerateSigningKey writes a new ed25519 private key to path as PKCS #8 PEM
+// and returns its public key. An existing key is only replaced when
+// overwrite is set.
+func GenerateSigningKey(path string, overwrite bool) (ed25519.PublicKey, error) {
+	if _, err := os.Stat(path); err == nil && !overwrite {
+		return nil, fmt.Errorf("%s already exists", path)
+	}
+	pub, key, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		return nil, fmt.Errorf("generate key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("encode key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("create key directory: %w", err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return nil, fmt.Errorf("write key: %w", err)
+	}
+	return pub, nil
+}
+
+// ReadSigningKey reads an ed25519 private key written by GenerateSigningKey.
+func ReadSigningKey(path string) (ed25519.PrivateKey, error) {
+	// #nosec G304 -- the key path is chosen by the user
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, fmt.Errorf("%s is not a PEM private key", path)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an ed25519 key", path)
+	}
+	return key, nil
+}
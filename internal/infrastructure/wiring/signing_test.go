@@ -0,0 +1,60 @@
+package wiring
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	domainai "github.com/felixgeelhaar/roady/pkg/domain/ai"
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+)
+
+func TestBuildAppServicesSignsEvents(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tempDir, ".roady"), 0700); err != nil {
+		t.Fatalf("mkdir roady: %v", err)
+	}
+	keyPath := filepath.Join(t.TempDir(), "ci-bot.key")
+	pub, err := GenerateSigningKey(keyPath, false)
+	if err != nil {
+		t.Fatalf("GenerateSigningKey: %v", err)
+	}
+	if _, err := GenerateSigningKey(keyPath, false); err == nil {
+		t.Error("expected an existing key to be kept")
+	}
+	t.Setenv(SignerEnv, "ci-bot")
+	t.Setenv(SigningKeyEnv, keyPath)
+
+	services, err := BuildAppServicesWithProvider(tempDir, func(string) (domainai.Provider, error) { return nil, nil })
+	if err != nil {
+		t.Fatalf("BuildAppServicesWithProvider: %v", err)
+	}
+	if err := services.Audit.Log("task.started", "ai", map[string]interface{}{"task_id": "t1"}); err != nil {
+		t.Fatal(err)
+	}
+	evts, _ := services.Audit.LoadEvents()
+	if len(evts) != 1 || evts[0].Signer != "ci-bot" {
+		t.Fatalf("expected a signed event, got %+v", evts)
+	}
+	if err := (events.KeyRing{"ci-bot": pub}).VerifyHash(evts[0].Signer, evts[0].Hash, evts[0].Signature); err != nil {
+		t.Errorf("signature does not verify: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, ".roady", "audit-checkpoints.jsonl")); err != nil {
+		t.Errorf("expected a first checkpoint: %v", err)
+	}
+
+	t.Setenv(SigningKeyEnv, filepath.Join(t.TempDir(), "missing.key"))
+	if _, err := BuildAppServicesWithProvider(tempDir, func(string) (domainai.Provider, error) { return nil, nil }); err == nil {
+		t.Error("expected a missing signing key to fail")
+	}
+}
+
+func TestSigningKeyPath(t *testing.T) {
+	if _, err := SigningKeyPath("../escape"); err == nil {
+		t.Error("expected a path-like signer name to be rejected")
+	}
+	path, err := SigningKeyPath("alice")
+	if err != nil || filepath.Base(path) != "alice.key" {
+		t.Errorf("SigningKeyPath = %q, %v", path, err)
+	}
+}
@@ -10,7 +10,7 @@ import (
 
 // Workspace bundles core infrastructure dependencies.
 type Workspace struct {
-	Repo     *storage.FilesystemRepository
+	Repo     storage.Repository
 	Audit    *application.AuditService
 	Usage    *application.UsageService
 	Notifier *webhook.Notifier
@@ -18,20 +18,30 @@ type Workspace struct {
 
 // NewWorkspace constructs a workspace scoped to the root project at <root>/.roady/.
 // For a sub-project (<root>/.roady/projects/<name>/) use NewWorkspaceForProject.
+// If the project's storage.yaml is invalid, the workspace falls back to the
+// files backend; use NewWorkspaceForProject to see the error.
 func NewWorkspace(root string) *Workspace {
-	ws, _ := NewWorkspaceForProject(root, "")
+	ws, err := NewWorkspaceForProject(root, "")
+	if err != nil {
+		ws = newWorkspace(storage.NewFilesystemRepository(root))
+	}
 	return ws
 }
 
 // NewWorkspaceForProject constructs a workspace scoped to a named sub-project
 // at <root>/.roady/projects/<project>/. When project is empty, behaves like
-// NewWorkspace. Returns an error if the project name is invalid.
+// NewWorkspace. The storage backend is the one selected in the project's
+// storage.yaml. Returns an error if the project name or backend is invalid.
 func NewWorkspaceForProject(root, project string) (*Workspace, error) {
-	repo, err := storage.NewFilesystemRepositoryForProject(root, project)
+	repo, err := storage.OpenRepository(root, project)
 	if err != nil {
 		return nil, err
 	}
 
+	return newWorkspace(repo), nil
+}
+
+func newWorkspace(repo storage.Repository) *Workspace {
 	// Load webhook config and create notifier if configured.
 	// Webhooks live next to the project's other files (under projects/<name>/ for sub-projects).
 	var notifier *webhook.Notifier
@@ -46,5 +56,5 @@ func NewWorkspaceForProject(root, project string) (*Workspace, error) {
 		Audit:    application.NewAuditService(repo),
 		Usage:    application.NewUsageService(repo),
 		Notifier: notifier,
-	}, nil
+	}
 }
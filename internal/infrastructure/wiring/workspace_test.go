@@ -1,9 +1,12 @@
 package wiring
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/felixgeelhaar/roady/pkg/domain/events"
+	"github.com/felixgeelhaar/roady/pkg/storage"
 )
 
 func TestNewWorkspaceProvidesRepoAndAudit(t *testing.T) {
@@ -49,3 +52,25 @@ func TestNewWorkspaceWithWebhook(t *testing.T) {
 		t.Fatal("expected notifier to be created when webhook config exists")
 	}
 }
+
+func TestNewWorkspaceUsesConfiguredBackend(t *testing.T) {
+	tempDir := t.TempDir()
+	files := storage.NewFilesystemRepository(tempDir)
+	if err := files.Initialize(); err != nil {
+		t.Fatalf("failed to initialize repo: %v", err)
+	}
+	if err := files.SaveStorageConfig(&storage.StorageConfig{Backend: storage.BackendSQLite}); err != nil {
+		t.Fatalf("save storage config: %v", err)
+	}
+
+	ws := NewWorkspace(tempDir)
+	if ws.Repo.Backend() != storage.BackendSQLite {
+		t.Fatalf("expected sqlite backend, got %s", ws.Repo.Backend())
+	}
+	if err := ws.Audit.Log("test.workspace", "tester", nil); err != nil {
+		t.Fatalf("audit log failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, ".roady", storage.EventsFile)); !os.IsNotExist(err) {
+		t.Error("expected audit events in the database, not events.jsonl")
+	}
+}
@@ -44,6 +44,12 @@ func (s *AuditService) GetTimeline() ([]domain.Event, error) {
 	return s.repo.LoadEvents()
 }
 
+// archiveVerifier is implemented by repositories and event stores that
+// archive old events into signed segments.
+type archiveVerifier interface {
+	VerifyEventArchive() ([]string, error)
+}
+
 func (s *AuditService) VerifyIntegrity() ([]string, error) {
 	events, err := s.repo.LoadEvents()
 	if err != nil {
@@ -51,6 +57,11 @@ func (s *AuditService) VerifyIntegrity() ([]string, error) {
 	}
 
 	var violations []string
+	if av, ok := s.repo.(archiveVerifier); ok {
+		if violations, err = av.VerifyEventArchive(); err != nil {
+			return nil, err
+		}
+	}
 	lastHash := ""
 
 	for i, e := range events {
@@ -0,0 +1,145 @@
+package application
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain"
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+)
+
+// eventSigner is implemented by event stores that sign the events they
+// append.
+type eventSigner interface {
+	SetSigner(signer *events.Signer)
+}
+
+// SignatureReport summarises the event signatures in the audit log.
+type SignatureReport struct {
+	Signed     int      `json:"signed"`
+	Unsigned   int      `json:"unsigned"`
+	Violations []string `json:"violations,omitempty"`
+}
+
+// VerifySignatures checks every signed event against its signer's public
+// key. With requireSigned, unsigned events are reported as violations too.
+func (s *AuditService) VerifySignatures(keys events.KeyRing, requireSigned bool) (*SignatureReport, error) {
+	evts, err := s.repo.LoadEvents()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SignatureReport{}
+	for i, e := range evts {
+		if e.Signature == "" {
+			report.Unsigned++
+			if requireSigned {
+				report.Violations = append(report.Violations, fmt.Sprintf("Event %d (%s): not signed.", i, e.ID))
+			}
+			continue
+		}
+		report.Signed++
+		if err := keys.VerifyHash(e.Signer, e.SignedHash(), e.Signature); err != nil {
+			report.Violations = append(report.Violations, fmt.Sprintf("Event %d (%s): %v.", i, e.ID, err))
+		}
+	}
+	return report, nil
+}
+
+// Checkpoint signs a checkpoint of the current log and records it in log.
+func (s *AuditService) Checkpoint(signer *events.Signer, log events.AuditCheckpointLog) (*events.AuditCheckpoint, error) {
+	evts, err := s.repo.LoadEvents()
+	if err != nil {
+		return nil, err
+	}
+	if len(evts) == 0 {
+		return nil, fmt.Errorf("the audit log is empty")
+	}
+	last := evts[len(evts)-1]
+	c := signer.Checkpoint(len(evts), last.ID, last.Hash, time.Now())
+	if err := log.AppendCheckpoint(c); err != nil {
+		return nil, fmt.Errorf("record checkpoint: %w", err)
+	}
+	return &c, nil
+}
+
+// VerifyCheckpoints checks each checkpoint's signature and that the log still
+// holds the checkpointed event at the checkpointed position.
+func (s *AuditService) VerifyCheckpoints(checkpoints []events.AuditCheckpoint, keys events.KeyRing) ([]string, error) {
+	evts, err := s.repo.LoadEvents()
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []string
+	for _, c := range checkpoints {
+		name := fmt.Sprintf("Checkpoint of %d events at %s", c.Events, c.CreatedAt.Format(time.RFC3339))
+		if err := keys.VerifyCheckpoint(c); err != nil {
+			violations = append(violations, fmt.Sprintf("%s: %v.", name, err))
+			continue
+		}
+		if violation := checkpointViolation(c, evts); violation != "" {
+			violations = append(violations, name+": "+violation)
+		}
+	}
+	return violations, nil
+}
+
+// checkpointViolation compares a checkpoint with the log.
+func checkpointViolation(c events.AuditCheckpoint, evts []domain.Event) string {
+	if c.Events < 1 || c.Events > len(evts) {
+		return fmt.Sprintf("the log has only %d events. Events were removed.", len(evts))
+	}
+	e := evts[c.Events-1]
+	if e.ID != c.EventID || e.Hash != c.Hash {
+		return fmt.Sprintf("event %d is %s with hash %.12s, checkpointed as %s with hash %.12s. History was rewritten.",
+			c.Events-1, e.ID, e.Hash, c.EventID, c.Hash)
+	}
+	return ""
+}
+
+// EnableSigning signs every event the service appends and records a signed
+// checkpoint in log when the last one is older than every. A zero every
+// disables periodic checkpoints.
+func (s *EventSourcedAuditService) EnableSigning(signer *events.Signer, log events.AuditCheckpointLog, every time.Duration) error {
+	store, ok := s.store.(eventSigner)
+	if !ok {
+		return fmt.Errorf("the event store cannot sign events")
+	}
+	store.SetSigner(signer)
+
+	s.signingMu.Lock()
+	defer s.signingMu.Unlock()
+	s.signer = signer
+	s.auditCheckpoints = log
+	s.checkpointEvery = every
+	s.lastCheckpoint = time.Time{}
+	if log != nil {
+		if checkpoints, err := log.LoadCheckpoints(); err == nil && len(checkpoints) > 0 {
+			s.lastCheckpoint = checkpoints[len(checkpoints)-1].CreatedAt
+		}
+	}
+	return nil
+}
+
+// checkpointIfDue records a periodic checkpoint. Checkpoints are
+// best-effort: a failure never fails the event that triggered it.
+func (s *EventSourcedAuditService) checkpointIfDue() {
+	s.signingMu.Lock()
+	defer s.signingMu.Unlock()
+	if s.signer == nil || s.auditCheckpoints == nil || s.checkpointEvery <= 0 {
+		return
+	}
+	now := time.Now()
+	if now.Sub(s.lastCheckpoint) < s.checkpointEvery {
+		return
+	}
+	evts, err := s.store.LoadAll()
+	if err != nil || len(evts) == 0 {
+		return
+	}
+	last := evts[len(evts)-1]
+	if s.auditCheckpoints.AppendCheckpoint(s.signer.Checkpoint(len(evts), last.ID, last.Hash, now)) == nil {
+		s.lastCheckpoint = now
+	}
+}
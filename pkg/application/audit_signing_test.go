@@ -0,0 +1,155 @@
+package application_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/application"
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+	"github.com/felixgeelhaar/roady/pkg/storage"
+)
+
+func newTestSigner(t *testing.T, id string) *events.Signer {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := events.NewSigner(id, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+func TestAuditSigning(t *testing.T) {
+	repo := storage.NewFilesystemRepository(t.TempDir())
+	if err := repo.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	store, _ := repo.EventStore()
+	svc, err := application.NewEventSourcedAuditService(store, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = svc.Log("task.started", "cli", map[string]interface{}{"task_id": "t1"})
+
+	alice := newTestSigner(t, "alice")
+	log := storage.NewFileAuditCheckpointLog(repo.ProjectBase())
+	if err := svc.EnableSigning(alice, log, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	for _, status := range []string{"task.completed", "task.verified"} {
+		if err := svc.Log(status, "alice", map[string]interface{}{"task_id": "t1"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The first signed event is due for a checkpoint, the second is not.
+	checkpoints, err := log.LoadCheckpoints()
+	if err != nil || len(checkpoints) != 1 || checkpoints[0].Events != 2 {
+		t.Fatalf("expected one checkpoint of 2 events, got %+v, %v", checkpoints, err)
+	}
+
+	keys := events.KeyRing{"alice": alice.PublicKey()}
+	audit := application.NewAuditService(repo)
+	report, err := audit.VerifySignatures(keys, false)
+	if err != nil || report.Signed != 2 || report.Unsigned != 1 || len(report.Violations) != 0 {
+		t.Fatalf("VerifySignatures = %+v, %v", report, err)
+	}
+	if report, _ := audit.VerifySignatures(keys, true); len(report.Violations) != 1 {
+		t.Errorf("expected the unsigned event to be reported, got %v", report.Violations)
+	}
+	if report, _ := audit.VerifySignatures(events.KeyRing{}, false); len(report.Violations) != 2 {
+		t.Errorf("expected signatures without a registered key to be reported, got %v", report.Violations)
+	}
+
+	exported, err := audit.Checkpoint(alice, log)
+	if err != nil || exported.Events != 3 {
+		t.Fatalf("Checkpoint = %+v, %v", exported, err)
+	}
+	checkpoints, _ = log.LoadCheckpoints()
+	if violations, err := audit.VerifyCheckpoints(checkpoints, keys); err != nil || len(violations) != 0 {
+		t.Fatalf("VerifyCheckpoints = %v, %v", violations, err)
+	}
+
+	// Rewrite the history and recompute every hash: the chain verifies, but
+	// the signatures and the checkpoints do not.
+	rewriteEventLog(t, repo.ProjectBase(), func(e *events.BaseEvent) {
+		if e.Type == "task.completed" {
+			e.Actor = "mallory"
+		}
+	})
+	if violations, _ := audit.VerifyIntegrity(); len(violations) != 0 {
+		t.Fatalf("expected the rewritten chain to verify, got %v", violations)
+	}
+	if report, _ := audit.VerifySignatures(keys, false); len(report.Violations) != 2 {
+		t.Errorf("expected both signatures to fail, got %v", report.Violations)
+	}
+	violations, _ := audit.VerifyCheckpoints([]events.AuditCheckpoint{*exported}, keys)
+	if len(violations) != 1 || !strings.Contains(violations[0], "History was rewritten") {
+		t.Errorf("expected the exported checkpoint to catch the rewrite, got %v", violations)
+	}
+
+	forged := *exported
+	forged.Events = 2
+	if violations, _ := audit.VerifyCheckpoints([]events.AuditCheckpoint{forged}, keys); len(violations) != 1 || !strings.Contains(violations[0], "signature") {
+		t.Errorf("expected an edited checkpoint to fail its signature, got %v", violations)
+	}
+}
+
+// rewriteEventLog edits each event and recomputes the hash chain.
+func rewriteEventLog(t *testing.T, base string, edit func(e *events.BaseEvent)) {
+	t.Helper()
+	path := filepath.Join(base, storage.EventsFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	prev := ""
+	for _, line := range bytes.Split(bytes.TrimSpace(data), []byte("\n")) {
+		var e events.BaseEvent
+		if err := json.Unmarshal(line, &e); err != nil {
+			t.Fatal(err)
+		}
+		edit(&e)
+		e.PrevHash = prev
+		e.Hash = e.CalculateHash()
+		prev = e.Hash
+		encoded, _ := json.Marshal(&e)
+		out.Write(append(encoded, '\n'))
+	}
+	if err := os.WriteFile(path, out.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEnableSigning_RequiresSigningStore(t *testing.T) {
+	svc, err := application.NewEventSourcedAuditService(&unsignedStore{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.EnableSigning(newTestSigner(t, "bot"), nil, 0); err == nil {
+		t.Error("expected a store that cannot sign to be rejected")
+	}
+}
+
+// unsignedStore is an empty EventStore without SetSigner.
+type unsignedStore struct{}
+
+func (unsignedStore) Append(*events.BaseEvent) error                              { return nil }
+func (unsignedStore) LoadAll() ([]*events.BaseEvent, error)                       { return nil, nil }
+func (unsignedStore) LoadByAggregate(string, string) ([]*events.BaseEvent, error) { return nil, nil }
+func (unsignedStore) LoadByType(string) ([]*events.BaseEvent, error)              { return nil, nil }
+func (unsignedStore) LoadSince(time.Time) ([]*events.BaseEvent, error)            { return nil, nil }
+func (unsignedStore) LoadRange(time.Time, time.Time) ([]*events.BaseEvent, error) { return nil, nil }
+func (unsignedStore) LoadAfter(string) ([]*events.BaseEvent, error)               { return nil, nil }
+func (unsignedStore) GetLastEvent() (*events.BaseEvent, error)                    { return nil, nil }
+func (unsignedStore) Count() (int, error)                                         { return 0, nil }
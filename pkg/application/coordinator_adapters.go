@@ -6,6 +6,7 @@ import (
 	"github.com/felixgeelhaar/roady/pkg/domain"
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	"github.com/felixgeelhaar/roady/pkg/domain/project"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
 )
 
 // PlanRepositoryAdapter adapts WorkspaceRepository to project.PlanRepository.
@@ -75,7 +76,7 @@ func (p *AuditEventPublisher) PublishTaskStarted(ctx context.Context, taskID, ow
 
 // PublishTaskCompleted implements project.EventPublisher.
 func (p *AuditEventPublisher) PublishTaskCompleted(ctx context.Context, taskID, evidence string) error {
-	return p.audit.Log("task.completed", "system", map[string]interface{}{
+	return p.audit.Log("task.completed", team.ActorFrom(ctx, "system"), map[string]interface{}{
 		"task_id":  taskID,
 		"evidence": evidence,
 	})
@@ -83,7 +84,7 @@ func (p *AuditEventPublisher) PublishTaskCompleted(ctx context.Context, taskID,
 
 // PublishTaskBlocked implements project.EventPublisher.
 func (p *AuditEventPublisher) PublishTaskBlocked(ctx context.Context, taskID, reason string) error {
-	return p.audit.Log("task.blocked", "system", map[string]interface{}{
+	return p.audit.Log("task.blocked", team.ActorFrom(ctx, "system"), map[string]interface{}{
 		"task_id": taskID,
 		"reason":  reason,
 	})
@@ -91,7 +92,7 @@ func (p *AuditEventPublisher) PublishTaskBlocked(ctx context.Context, taskID, re
 
 // PublishTaskUnblocked implements project.EventPublisher.
 func (p *AuditEventPublisher) PublishTaskUnblocked(ctx context.Context, taskID string) error {
-	return p.audit.Log("task.unblocked", "system", map[string]interface{}{
+	return p.audit.Log("task.unblocked", team.ActorFrom(ctx, "system"), map[string]interface{}{
 		"task_id": taskID,
 	})
 }
@@ -0,0 +1,109 @@
+package application_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"testing"
+
+	"github.com/felixgeelhaar/roady/pkg/application"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/storage"
+)
+
+const (
+	stressDirEnv    = "ROADY_STRESS_DIR"
+	stressWorkerEnv = "ROADY_STRESS_WORKER"
+	stressWorkers   = 8
+	stressTasks     = 6 // per worker
+)
+
+// TestCoordinator_MultiProcessTransitions starts and completes tasks from
+// several processes sharing one .roady/ directory. Without the project lock
+// and conflict retries, concurrent saves lose each other's transitions; with
+// retries bounded only by the context, every transition must land.
+func TestCoordinator_MultiProcessTransitions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("spawns processes")
+	}
+	dir := t.TempDir()
+	repo := storage.NewFilesystemRepository(dir)
+	if err := repo.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	plan := &planning.Plan{ID: "stress", ApprovalStatus: planning.ApprovalApproved}
+	state := planning.NewExecutionState("stress")
+	for w := range stressWorkers {
+		for i := range stressTasks {
+			id := stressTaskID(w, i)
+			plan.Tasks = append(plan.Tasks, planning.Task{ID: id, Title: id})
+			state.TaskStates[id] = planning.TaskResult{Status: planning.StatusPending}
+		}
+	}
+	if err := repo.SavePlan(plan); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SaveState(state); err != nil {
+		t.Fatal(err)
+	}
+
+	workers := make([]*exec.Cmd, 0, stressWorkers)
+	for w := range stressWorkers {
+		// #nosec G204 -- re-executes this test binary
+		cmd := exec.Command(os.Args[0], "-test.run=^TestCoordinator_StressWorker$", "-test.count=1")
+		cmd.Env = append(os.Environ(), stressDirEnv+"="+dir, stressWorkerEnv+"="+strconv.Itoa(w))
+		if err := cmd.Start(); err != nil {
+			t.Fatalf("start worker %d: %v", w, err)
+		}
+		workers = append(workers, cmd)
+	}
+	for w, cmd := range workers {
+		if err := cmd.Wait(); err != nil {
+			t.Errorf("worker %d: %v", w, err)
+		}
+	}
+
+	final, err := repo.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, result := range final.TaskStates {
+		if result.Status != planning.StatusDone {
+			t.Errorf("task %s ended %s; a transition was lost", id, result.Status)
+		}
+	}
+	// The initial save plus a start and a complete per task.
+	if want := 1 + 2*stressWorkers*stressTasks; final.Version != want {
+		t.Errorf("state version = %d, want %d", final.Version, want)
+	}
+}
+
+// TestCoordinator_StressWorker is the worker process for
+// TestCoordinator_MultiProcessTransitions.
+func TestCoordinator_StressWorker(t *testing.T) {
+	dir := os.Getenv(stressDirEnv)
+	if dir == "" {
+		t.Skip("worker process for TestCoordinator_MultiProcessTransitions")
+	}
+	w, err := strconv.Atoi(os.Getenv(stressWorkerEnv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	coord := application.NewProjectCoordinator(storage.NewFilesystemRepository(dir), nil)
+	ctx := context.Background()
+	for i := range stressTasks {
+		id := stressTaskID(w, i)
+		if err := coord.StartTask(ctx, id, fmt.Sprintf("worker-%d", w), ""); err != nil {
+			t.Fatalf("start %s: %v", id, err)
+		}
+		if _, err := coord.CompleteTask(ctx, id, ""); err != nil {
+			t.Fatalf("complete %s: %v", id, err)
+		}
+	}
+}
+
+func stressTaskID(worker, i int) string {
+	return fmt.Sprintf("task-w%d-%d", worker, i)
+}
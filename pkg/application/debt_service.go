@@ -26,6 +26,12 @@ func NewDebtService(driftSvc *DriftService, auditSvc domain.AuditLogger) *DebtSe
 	}
 }
 
+// LoadHistory catches the drift history up with the event store, resuming
+// from its snapshot in checkpoints if there is one.
+func (s *DebtService) LoadHistory(store events.EventStore, checkpoints events.ProjectionStore) error {
+	return events.CatchUp(s.driftHistoryProj, store, checkpoints)
+}
+
 // GetDebtReport generates a comprehensive debt report based on current drift.
 func (s *DebtService) GetDebtReport(ctx context.Context) (*debt.DebtReport, error) {
 	// Get current drift
@@ -1,11 +1,15 @@
 package application
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/felixgeelhaar/roady/pkg/domain/analytics"
 	"github.com/felixgeelhaar/roady/pkg/domain/dependency"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/project"
 )
 
 // DependencyRepository defines the storage interface for dependency data.
@@ -30,6 +34,7 @@ type DependencyService struct {
 	repo     DependencyRepository
 	resolver *dependency.Resolver
 	rootPath string
+	projects project.ProjectDirectory // for cross-project task dependencies; may be nil
 }
 
 // NewDependencyService creates a new dependency service.
@@ -41,6 +46,12 @@ func NewDependencyService(repo DependencyRepository, rootPath string) *Dependenc
 	}
 }
 
+// SetProjectDirectory includes the "@project:task-id" task dependencies
+// between the workspace's projects in summaries and cycle checks.
+func (s *DependencyService) SetProjectDirectory(dir project.ProjectDirectory) {
+	s.projects = dir
+}
+
 // GetDependencyGraph returns the current dependency graph.
 func (s *DependencyService) GetDependencyGraph() (*dependency.DependencyGraph, error) {
 	graph, err := s.repo.LoadDependencyGraph()
@@ -217,16 +228,94 @@ func (s *DependencyService) GetDependencySummary() (*dependency.DependencySummar
 	}
 
 	summary := graph.GetSummary()
+	taskGraph, taskDeps, err := s.GetTaskDependencies(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	summary.TaskDependencies = taskDeps
+	summary.TaskCycle = taskGraph.Cycle()
 	return &summary, nil
 }
 
-// CheckForCycles checks if the dependency graph has cycles.
+// GetTaskDependencies returns the task graph of every project in the
+// workspace and the dependencies in it that cross projects, with the status
+// of the task depended on.
+func (s *DependencyService) GetTaskDependencies(ctx context.Context) (dependency.TaskGraph, []dependency.TaskDependency, error) {
+	graph := dependency.TaskGraph{}
+	if s.projects == nil {
+		return graph, nil, nil
+	}
+	names, err := s.projects.Projects(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list projects: %w", err)
+	}
+
+	var deps []dependency.TaskDependency
+	states := make(map[string]*planning.ExecutionState)
+	status := func(ref planning.TaskRef) planning.TaskStatus {
+		state, ok := states[ref.Project]
+		if !ok {
+			state, _ = s.projects.LoadState(ctx, ref.Project)
+			states[ref.Project] = state
+		}
+		if _, exists := graph[ref.String()]; !exists || state == nil {
+			return dependency.StatusMissing
+		}
+		return state.GetTaskStatus(ref.TaskID)
+	}
+
+	plans := make(map[string]*planning.Plan, len(names))
+	for _, name := range names {
+		plan, err := s.projects.LoadPlan(ctx, name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("load plan of project %q: %w", name, err)
+		}
+		plans[name] = plan
+		graph.AddPlan(name, plan)
+	}
+	for _, name := range names {
+		if plans[name] == nil {
+			continue
+		}
+		for _, task := range plans[name].Tasks {
+			for _, dep := range task.DependsOn {
+				ref := planning.ParseTaskRef(dep)
+				if !ref.External || ref.Project == name {
+					continue
+				}
+				deps = append(deps, dependency.TaskDependency{
+					Project:   name,
+					TaskID:    task.ID,
+					DependsOn: dep,
+					Status:    status(ref),
+				})
+			}
+		}
+	}
+	sort.SliceStable(deps, func(i, j int) bool {
+		if deps[i].Project != deps[j].Project {
+			return deps[i].Project < deps[j].Project
+		}
+		return deps[i].TaskID < deps[j].TaskID
+	})
+	return graph, deps, nil
+}
+
+// CheckForCycles checks if the dependency graph, or the task dependencies
+// across projects, have cycles.
 func (s *DependencyService) CheckForCycles() (bool, error) {
 	graph, err := s.GetDependencyGraph()
 	if err != nil {
 		return false, err
 	}
-	return graph.HasCycle(), nil
+	if graph.HasCycle() {
+		return true, nil
+	}
+	taskGraph, _, err := s.GetTaskDependencies(context.Background())
+	if err != nil {
+		return false, err
+	}
+	return taskGraph.Cycle() != nil, nil
 }
 
 // GetDependencyOrder returns repos in dependency order (dependencies first).
@@ -7,6 +7,7 @@ import (
 
 	"github.com/felixgeelhaar/roady/pkg/domain"
 	"github.com/felixgeelhaar/roady/pkg/domain/drift"
+	"github.com/felixgeelhaar/roady/pkg/domain/policy"
 )
 
 type DriftService struct {
@@ -58,31 +59,41 @@ func (s *DriftService) DetectDrift(ctx context.Context) (*drift.Report, error) {
 		Issues:    make([]drift.Issue, 0),
 	}
 
-	// 0. Intent Drift (Spec vs Lock)
 	lock, _ := s.repo.LoadSpecLock()
-	if intentIssues := s.detector.DetectIntentDrift(spec, lock); len(intentIssues) > 0 {
-		report.Issues = append(report.Issues, intentIssues...)
-	}
-
-	// 1. Plan vs Spec
-	if planIssues := s.detector.DetectPlanDrift(spec, plan); len(planIssues) > 0 {
-		report.Issues = append(report.Issues, planIssues...)
-	}
+	violations, _ := s.policy.CheckCompliance()
 
-	// 2. Code vs State (Implementation Drift)
-	if codeIssues := s.detector.DetectCodeDrift(plan, state, s.inspector); len(codeIssues) > 0 {
-		report.Issues = append(report.Issues, codeIssues...)
+	var cfg policy.DriftConfig
+	if pol, err := s.repo.LoadPolicy(); err == nil && pol != nil {
+		cfg = pol.Drift
 	}
 
-	// 3. Policy vs State (Policy Drift)
-	violations, _ := s.policy.CheckCompliance()
-	if policyIssues := s.detector.DetectPolicyDrift(violations); len(policyIssues) > 0 {
-		report.Issues = append(report.Issues, policyIssues...)
+	issues, err := s.detector.Detect(drift.Input{
+		Spec:       spec,
+		Lock:       lock,
+		Plan:       plan,
+		State:      state,
+		Inspector:  s.inspector,
+		Violations: violations,
+	}, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid drift configuration: %w", err)
 	}
+	report.Issues = append(report.Issues, issues...)
 
 	return report, nil
 }
 
+// RegisterRule adds a project-specific drift rule that runs after the built-in
+// rules and can be configured under drift.rules in policy.yaml.
+func (s *DriftService) RegisterRule(rule drift.DriftRule) error {
+	return s.detector.Register(rule)
+}
+
+// Rules lists the drift rules the service runs.
+func (s *DriftService) Rules() []drift.DriftRule {
+	return s.detector.Registry().Rules()
+}
+
 // AcceptDrift locks the current spec snapshot and records the acceptance event.
 func (s *DriftService) AcceptDrift() error {
 	spec, err := s.repo.LoadSpec()
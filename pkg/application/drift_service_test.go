@@ -8,8 +8,10 @@ import (
 	"testing"
 
 	"github.com/felixgeelhaar/roady/pkg/application"
+	"github.com/felixgeelhaar/roady/pkg/domain"
 	"github.com/felixgeelhaar/roady/pkg/domain/drift"
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/policy"
 	"github.com/felixgeelhaar/roady/pkg/domain/spec"
 	"github.com/felixgeelhaar/roady/pkg/storage"
 )
@@ -167,3 +169,49 @@ func TestDriftService_AcceptDrift(t *testing.T) {
 		t.Fatalf("unexpected metadata: %+v", last.Metadata)
 	}
 }
+
+func TestDriftService_Detect_PolicyDriftConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := storage.NewFilesystemRepository(tempDir)
+	_ = repo.Initialize()
+	service := application.NewDriftService(repo, application.NewAuditService(repo), storage.NewCodebaseInspector(), application.NewPolicyService(repo))
+
+	if err := repo.SaveSpec(&spec.ProductSpec{Features: []spec.Feature{{
+		ID: "f1", Title: "F1",
+		Requirements: []spec.Requirement{{ID: "r1", Title: "R1"}},
+	}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SavePlan(&planning.Plan{Tasks: []planning.Task{}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := service.RegisterRule(drift.NewRule("always", "Always reports", func(drift.Input) []drift.Issue {
+		return []drift.Issue{{ID: "always", Severity: drift.SeverityLow}}
+	})); err != nil {
+		t.Fatal(err)
+	}
+
+	disabled := false
+	if err := repo.SavePolicy(&domain.PolicyConfig{MaxWIP: 3, Drift: policy.DriftConfig{Rules: map[string]policy.DriftRuleConfig{
+		drift.RulePlan: {Enabled: &disabled},
+		"always":       {Severity: "critical"},
+	}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := service.DetectDrift(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Issues) != 1 || report.Issues[0].RuleID != "always" || report.Issues[0].Severity != drift.SeverityCritical {
+		t.Fatalf("unexpected issues: %+v", report.Issues)
+	}
+
+	if err := repo.SavePolicy(&domain.PolicyConfig{Drift: policy.DriftConfig{Rules: map[string]policy.DriftRuleConfig{"unknown": {}}}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.DetectDrift(context.Background()); err == nil {
+		t.Fatal("expected error for unknown drift rule in policy.yaml")
+	}
+}
@@ -3,6 +3,7 @@ package application
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/felixgeelhaar/roady/pkg/domain"
@@ -13,12 +14,24 @@ import (
 // EventSourcedAuditService implements AuditLogger using the event store.
 // It bridges the existing audit interface with the new event sourcing system.
 type EventSourcedAuditService struct {
-	store      events.EventStore
-	publisher  events.EventPublisher
-	dispatcher *events.EventDispatcher
-	taskProj   *events.TaskStateProjection
-	velProj    *events.VelocityProjection
-	auditProj  *events.AuditTimelineProjection
+	store       events.EventStore
+	checkpoints events.ProjectionStore
+	publisher   events.EventPublisher
+	dispatcher  *events.EventDispatcher
+	taskProj    *events.TaskStateProjection
+	velProj     *events.VelocityProjection
+
+	// The timeline holds every event, so it is only built when first read.
+	auditMu     sync.Mutex
+	auditLoaded bool
+	auditProj   *events.AuditTimelineProjection
+
+	// Optional event signing and periodic checkpoints. See EnableSigning.
+	signingMu        sync.Mutex
+	signer           *events.Signer
+	auditCheckpoints events.AuditCheckpointLog
+	checkpointEvery  time.Duration
+	lastCheckpoint   time.Time
 }
 
 // Compile-time check that EventSourcedAuditService implements AuditLogger.
@@ -26,15 +39,23 @@ var _ domain.AuditLogger = (*EventSourcedAuditService)(nil)
 
 // NewEventSourcedAuditService creates a new event-sourced audit service.
 func NewEventSourcedAuditService(store events.EventStore, publisher events.EventPublisher) (*EventSourcedAuditService, error) {
+	return NewEventSourcedAuditServiceWithCheckpoints(store, publisher, nil)
+}
+
+// NewEventSourcedAuditServiceWithCheckpoints creates an event-sourced audit
+// service whose projections resume from the snapshots in checkpoints and
+// only apply the events recorded since.
+func NewEventSourcedAuditServiceWithCheckpoints(store events.EventStore, publisher events.EventPublisher, checkpoints events.ProjectionStore) (*EventSourcedAuditService, error) {
 	svc := &EventSourcedAuditService{
-		store:     store,
-		publisher: publisher,
-		taskProj:  events.NewTaskStateProjection(),
-		velProj:   events.NewVelocityProjection(7),
-		auditProj: events.NewAuditTimelineProjection(),
+		store:       store,
+		checkpoints: checkpoints,
+		publisher:   publisher,
+		taskProj:    events.NewTaskStateProjection(),
+		velProj:     events.NewVelocityProjection(7),
+		auditProj:   events.NewAuditTimelineProjection(),
 	}
 
-	// Rebuild projections from existing events
+	// Catch projections up with existing events
 	if err := svc.rebuildProjections(); err != nil {
 		return nil, err
 	}
@@ -44,7 +65,11 @@ func NewEventSourcedAuditService(store events.EventStore, publisher events.Event
 		publisher.Subscribe(func(e *events.BaseEvent) error {
 			_ = svc.taskProj.Apply(e)
 			_ = svc.velProj.Apply(e)
-			_ = svc.auditProj.Apply(e)
+			svc.auditMu.Lock()
+			if svc.auditLoaded {
+				_ = svc.auditProj.Apply(e)
+			}
+			svc.auditMu.Unlock()
 			return nil
 		})
 	}
@@ -53,22 +78,22 @@ func NewEventSourcedAuditService(store events.EventStore, publisher events.Event
 }
 
 func (s *EventSourcedAuditService) rebuildProjections() error {
-	evts, err := s.store.LoadAll()
-	if err != nil {
+	if err := events.CatchUp(s.taskProj, s.store, s.checkpoints); err != nil {
 		return err
 	}
+	return events.CatchUp(s.velProj, s.store, s.checkpoints)
+}
 
-	if err := s.taskProj.Rebuild(evts); err != nil {
-		return err
-	}
-	if err := s.velProj.Rebuild(evts); err != nil {
-		return err
-	}
-	if err := s.auditProj.Rebuild(evts); err != nil {
-		return err
+// timeline returns the audit timeline projection, building it on first use.
+func (s *EventSourcedAuditService) timeline() *events.AuditTimelineProjection {
+	s.auditMu.Lock()
+	defer s.auditMu.Unlock()
+	if !s.auditLoaded {
+		if evts, err := s.store.LoadAll(); err == nil && s.auditProj.Rebuild(evts) == nil {
+			s.auditLoaded = true
+		}
 	}
-
-	return nil
+	return s.auditProj
 }
 
 // Log implements domain.AuditLogger.
@@ -107,17 +132,18 @@ func (s *EventSourcedAuditService) Log(action string, actor string, metadata map
 		}()
 	}
 
+	s.checkpointIfDue()
 	return nil
 }
 
 // GetTimeline returns the audit timeline from the projection.
 func (s *EventSourcedAuditService) GetTimeline() []events.TimelineEntry {
-	return s.auditProj.GetTimeline()
+	return s.timeline().GetTimeline()
 }
 
 // GetRecentTimeline returns the most recent n timeline entries.
 func (s *EventSourcedAuditService) GetRecentTimeline(n int) []events.TimelineEntry {
-	return s.auditProj.GetRecentEntries(n)
+	return s.timeline().GetRecentEntries(n)
 }
 
 // GetTaskState returns the current state of a task from the projection.
@@ -148,6 +174,11 @@ func (s *EventSourcedAuditService) VerifyIntegrity() ([]string, error) {
 	}
 
 	var violations []string
+	if av, ok := s.store.(archiveVerifier); ok {
+		if violations, err = av.VerifyEventArchive(); err != nil {
+			return nil, err
+		}
+	}
 	lastHash := ""
 
 	for i, e := range evts {
@@ -176,6 +207,11 @@ func (s *EventSourcedAuditService) LoadEventsSince(since time.Time) ([]*events.B
 	return s.store.LoadSince(since)
 }
 
+// QueryEvents returns the events that match the query.
+func (s *EventSourcedAuditService) QueryEvents(q events.EventQuery) ([]*events.BaseEvent, error) {
+	return events.Query(s.store, q)
+}
+
 // SetDispatcher sets the event dispatcher for this service.
 func (s *EventSourcedAuditService) SetDispatcher(dispatcher *events.EventDispatcher) {
 	s.dispatcher = dispatcher
@@ -243,6 +243,34 @@ func TestEventSourcedAuditService_RebuildFromExisting(t *testing.T) {
 	}
 }
 
+func TestEventSourcedAuditService_ResumesFromCheckpoints(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, _ := storage.NewFileEventStore(tmpDir)
+	checkpoints := storage.NewFileProjectionStore(tmpDir)
+
+	_ = store.Append(&events.BaseEvent{Type: events.EventTypeTaskStarted, Actor: "bob", Metadata: map[string]interface{}{"task_id": "task-1"}})
+	if _, err := NewEventSourcedAuditServiceWithCheckpoints(store, nil, checkpoints); err != nil {
+		t.Fatalf("NewEventSourcedAuditServiceWithCheckpoints failed: %v", err)
+	}
+	first, _ := store.GetLastEvent()
+	if id, _ := checkpoints.LoadCheckpoint("task_state"); id != first.ID {
+		t.Fatalf("expected a task_state checkpoint at %s, got %q", first.ID, id)
+	}
+
+	_ = store.Append(&events.BaseEvent{Type: events.EventTypeTaskCompleted, Actor: "bob", Metadata: map[string]interface{}{"task_id": "task-1"}})
+	svc, err := NewEventSourcedAuditServiceWithCheckpoints(store, nil, checkpoints)
+	if err != nil {
+		t.Fatalf("NewEventSourcedAuditServiceWithCheckpoints failed: %v", err)
+	}
+	state := svc.GetTaskState("task-1")
+	if state == nil || state.Owner != "bob" || state.CompletedAt == nil {
+		t.Fatalf("expected the restored and caught-up state, got %+v", state)
+	}
+	if got := len(svc.GetTimeline()); got != 2 {
+		t.Errorf("expected the full timeline, got %d entries", got)
+	}
+}
+
 func TestEventSourcedAuditService_NilPublisher(t *testing.T) {
 	tmpDir := t.TempDir()
 	store, err := storage.NewFileEventStore(tmpDir)
@@ -125,10 +125,10 @@ func (s *OrgService) AggregateMetrics() (*org.OrgMetrics, error) {
 // projectMetricsFor reports metrics for a discovered project entry, supporting
 // both root projects and sub-projects under <Path>/.roady/projects/<SubProject>.
 func (s *OrgService) projectMetricsFor(p DiscoveredProject) org.ProjectMetrics {
-	repo, repoErr := storage.NewFilesystemRepositoryForProject(p.Path, p.SubProject)
+	repo, repoErr := storage.OpenRepository(p.Path, p.SubProject)
 	if repoErr != nil {
-		// Invalid sub-project name; fall back to legacy root repo so we don't
-		// silently drop the entry.
+		// Invalid sub-project name or storage config; fall back to legacy
+		// root repo so we don't silently drop the entry.
 		repo = storage.NewFilesystemRepository(p.Path)
 	}
 	spec, _ := repo.LoadSpec()
@@ -225,7 +225,7 @@ func (s *OrgService) SaveOrgConfig(config *org.OrgConfig) error {
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(filepath.Join(dir, "org.yaml"), data, 0600)
+	return storage.WriteFileAtomic(filepath.Join(dir, "org.yaml"), data, 0600)
 }
 
 // LoadMergedPolicy loads org-level SharedPolicy and overlays project-level policy.yaml values.
@@ -241,7 +241,10 @@ func (s *OrgService) LoadMergedPolicy(projectPath string) (*policy.PolicyConfig,
 	}
 
 	// Overlay project-level policy
-	repo := storage.NewFilesystemRepository(projectPath)
+	repo, err := storage.OpenRepository(projectPath, "")
+	if err != nil {
+		return nil, err
+	}
 	projectPolicy, err := repo.LoadPolicy()
 	if err == nil && projectPolicy != nil {
 		if projectPolicy.MaxWIP > 0 {
@@ -253,6 +256,7 @@ func (s *OrgService) LoadMergedPolicy(projectPath string) (*policy.PolicyConfig,
 		if projectPolicy.TokenLimit > 0 {
 			merged.TokenLimit = projectPolicy.TokenLimit
 		}
+		merged.Rules = projectPolicy.Rules
 	}
 
 	return merged, nil
@@ -269,7 +273,7 @@ func (s *OrgService) DetectCrossDrift() (*org.CrossDriftReport, error) {
 	report := &org.CrossDriftReport{}
 
 	for _, p := range projects {
-		repo, repoErr := storage.NewFilesystemRepositoryForProject(p.Path, p.SubProject)
+		repo, repoErr := storage.OpenRepository(p.Path, p.SubProject)
 		if repoErr != nil {
 			continue
 		}
@@ -3,11 +3,14 @@ package application
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/felixgeelhaar/roady/pkg/domain"
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	"github.com/felixgeelhaar/roady/pkg/domain/project"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
 )
 
 type PlanService struct {
@@ -15,6 +18,7 @@ type PlanService struct {
 	audit       domain.AuditLogger
 	reconciler  *planning.PlanReconciler
 	coordinator *project.Coordinator
+	team        *TeamService
 }
 
 func NewPlanService(repo domain.WorkspaceRepository, audit domain.AuditLogger) *PlanService {
@@ -26,6 +30,27 @@ func NewPlanService(repo domain.WorkspaceRepository, audit domain.AuditLogger) *
 	}
 }
 
+// SetProjectDirectory lets ready and unlocked tasks account for
+// "@project:task-id" dependencies; name is this project's name in dir.
+func (s *PlanService) SetProjectDirectory(name string, dir project.ProjectDirectory) {
+	s.coordinator.SetProjectDirectory(name, dir)
+}
+
+// SetTeam enforces the roles of team members changing the plan. Without a
+// team every actor may change it.
+func (s *PlanService) SetTeam(t *TeamService) {
+	s.team = t
+}
+
+// authorize checks that the member ctx carries may edit the plan when a
+// team is set.
+func (s *PlanService) authorize(ctx context.Context) error {
+	if s.team == nil {
+		return nil
+	}
+	return team.AuthorizeCaller(ctx, team.PermissionEditPlan)
+}
+
 // GeneratePlan updates the Plan based on the current Spec using a default heuristic.
 func (s *PlanService) GeneratePlan(ctx context.Context) (*planning.Plan, error) {
 	if ctx == nil {
@@ -37,7 +62,11 @@ func (s *PlanService) GeneratePlan(ctx context.Context) (*planning.Plan, error)
 	default:
 	}
 
-	if err := s.audit.Log("plan.generate", "cli", nil); err != nil {
+	actor := team.ActorFrom(ctx, "cli")
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.audit.Log("plan.generate", actor, nil); err != nil {
 		return nil, fmt.Errorf("write audit log: %w", err)
 	}
 	spec, err := s.repo.LoadSpec()
@@ -60,6 +89,7 @@ func (s *PlanService) GeneratePlan(ctx context.Context) (*planning.Plan, error)
 				DependsOn:   []string{},
 				Origin:      planning.OriginHeuristic,
 				Source:      featSource,
+				Files:       feat.Files,
 			})
 			continue
 		}
@@ -69,9 +99,14 @@ func (s *PlanService) GeneratePlan(ctx context.Context) (*planning.Plan, error)
 			if deps == nil {
 				deps = []string{}
 			}
-			// Map requirement IDs to task IDs (prefix with task-)
+			// Map requirement IDs to task IDs (prefix with task-);
+			// @project:task-id references already name a task
 			taskDeps := make([]string, len(deps))
 			for i, d := range deps {
+				if planning.ParseTaskRef(d).External {
+					taskDeps[i] = d
+					continue
+				}
 				taskDeps[i] = fmt.Sprintf("task-%s", d)
 			}
 
@@ -81,6 +116,10 @@ func (s *PlanService) GeneratePlan(ctx context.Context) (*planning.Plan, error)
 			if (planning.TaskSource{}) == source {
 				source = featSource
 			}
+			files := req.Files
+			if len(files) == 0 {
+				files = feat.Files
+			}
 
 			heuristicTasks = append(heuristicTasks, planning.Task{
 				ID:          fmt.Sprintf("task-%s", req.ID),
@@ -92,6 +131,9 @@ func (s *PlanService) GeneratePlan(ctx context.Context) (*planning.Plan, error)
 				DependsOn:   taskDeps,
 				Origin:      planning.OriginHeuristic,
 				Source:      source,
+				Files:       files,
+
+				AcceptanceCriteria: req.AcceptanceCriteria,
 			})
 		}
 	}
@@ -101,12 +143,22 @@ func (s *PlanService) GeneratePlan(ctx context.Context) (*planning.Plan, error)
 
 // UpdatePlan allows external agents (AI) to provide a specific list of tasks.
 func (s *PlanService) UpdatePlan(tasks []planning.Task) (*planning.Plan, error) {
+	return s.UpdatePlanWithActor(context.Background(), tasks, "ai")
+}
+
+// UpdatePlanWithActor reconciles the plan with a list of tasks on behalf of
+// the member ctx carries, or actor when there is none.
+func (s *PlanService) UpdatePlanWithActor(ctx context.Context, tasks []planning.Task, actor string) (*planning.Plan, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	actor = team.ActorFrom(ctx, actor)
 	plan, err := s.ReconcilePlan(tasks)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := s.audit.Log("plan.update_smart", "ai", map[string]interface{}{
+	if err := s.audit.Log("plan.update_smart", actor, map[string]interface{}{
 		"plan_id":    plan.ID,
 		"spec_id":    plan.SpecID,
 		"task_count": len(tasks),
@@ -161,21 +213,161 @@ func (s *PlanService) GetUsage() (*domain.UsageStats, error) {
 }
 
 func (s *PlanService) ApprovePlan() error {
-	return s.ApprovePlanWithActor("cli")
+	return s.ApprovePlanWithActor(context.Background(), "cli")
 }
 
-// ApprovePlanWithActor atomically approves the plan and initializes task states.
-func (s *PlanService) ApprovePlanWithActor(actor string) error {
-	ctx := context.Background()
+// ApprovePlanWithActor atomically approves the plan and initializes task states
+// on behalf of the member ctx carries, or actor when there is none.
+// The approved plan is recorded in the plan history when the workspace keeps one.
+func (s *PlanService) ApprovePlanWithActor(ctx context.Context, actor string) error {
+	if err := s.authorize(ctx); err != nil {
+		return err
+	}
+	actor = team.ActorFrom(ctx, actor)
 	if err := s.coordinator.ApprovePlan(ctx, actor); err != nil {
 		if err == project.ErrNoPlan {
 			return fmt.Errorf("no plan found to approve")
 		}
 		return err
 	}
+	return s.recordRevision(actor)
+}
+
+// recordRevision appends the current plan to the history unless it is
+// already the latest revision.
+func (s *PlanService) recordRevision(actor string) error {
+	history, ok := s.repo.(planning.RevisionRepository)
+	if !ok {
+		return nil
+	}
+	plan, err := s.repo.LoadPlan()
+	if err != nil || plan == nil {
+		return err
+	}
+	revisions, err := history.ListPlanRevisions()
+	if err != nil {
+		return err
+	}
+	rev := planning.NewPlanRevision(plan, actor)
+	if n := len(revisions); n > 0 && revisions[n-1].Hash == rev.Hash {
+		return nil
+	}
+	rev.Restored = slices.ContainsFunc(revisions, func(r planning.PlanRevision) bool { return r.Hash == rev.Hash })
+	if err := history.SavePlanRevision(plan, rev); err != nil {
+		return fmt.Errorf("record plan revision: %w", err)
+	}
 	return nil
 }
 
+// planHistory returns the workspace's plan history store.
+func (s *PlanService) planHistory() (planning.RevisionRepository, error) {
+	history, ok := s.repo.(planning.RevisionRepository)
+	if !ok {
+		return nil, fmt.Errorf("plan history requires a filesystem workspace")
+	}
+	return history, nil
+}
+
+// PlanHistory lists approved plan revisions, oldest first.
+func (s *PlanService) PlanHistory() ([]planning.PlanRevision, error) {
+	history, err := s.planHistory()
+	if err != nil {
+		return nil, err
+	}
+	return history.ListPlanRevisions()
+}
+
+// PlanRevisionCurrent refers to the current plan.json in DiffPlanRevisions.
+const PlanRevisionCurrent = "current"
+
+// loadRevision resolves ref to a plan: PlanRevisionCurrent, or a full or
+// abbreviated revision hash from the history.
+func (s *PlanService) loadRevision(ref string) (*planning.Plan, planning.PlanRevision, error) {
+	if ref == PlanRevisionCurrent {
+		plan, err := s.repo.LoadPlan()
+		if err != nil {
+			return nil, planning.PlanRevision{}, err
+		}
+		if plan == nil {
+			return nil, planning.PlanRevision{}, fmt.Errorf("no plan found")
+		}
+		return plan, planning.PlanRevision{Hash: plan.RevisionHash(), PlanID: plan.ID, SpecID: plan.SpecID, TaskCount: len(plan.Tasks)}, nil
+	}
+	history, err := s.planHistory()
+	if err != nil {
+		return nil, planning.PlanRevision{}, err
+	}
+	revisions, err := history.ListPlanRevisions()
+	if err != nil {
+		return nil, planning.PlanRevision{}, err
+	}
+	rev, err := planning.ResolveRevision(revisions, ref)
+	if err != nil {
+		return nil, planning.PlanRevision{}, err
+	}
+	plan, err := history.LoadPlanRevision(rev.Hash)
+	if err != nil {
+		return nil, planning.PlanRevision{}, err
+	}
+	return plan, rev, nil
+}
+
+// DiffPlanRevisions compares two plan revisions. Each ref is a revision hash
+// (or unique prefix) or PlanRevisionCurrent.
+func (s *PlanService) DiffPlanRevisions(from, to string) (*planning.PlanDiff, error) {
+	base, _, err := s.loadRevision(from)
+	if err != nil {
+		return nil, err
+	}
+	current, _, err := s.loadRevision(to)
+	if err != nil {
+		return nil, err
+	}
+	return planning.DiffPlans(base, current), nil
+}
+
+// RollbackPlan restores a revision from the history as the current plan.
+// The restored plan is pending and has to be approved again; task execution
+// state is kept. It acts as the member ctx carries, or actor when there is
+// none.
+func (s *PlanService) RollbackPlan(ctx context.Context, ref, actor string) (*planning.Plan, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	actor = team.ActorFrom(ctx, actor)
+	if ref == PlanRevisionCurrent {
+		return nil, fmt.Errorf("rollback needs a revision from the plan history")
+	}
+	plan, rev, err := s.loadRevision(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := s.repo.LoadPlan()
+	if err != nil {
+		return nil, err
+	}
+	fromHash := ""
+	if from != nil {
+		fromHash = from.RevisionHash()
+	}
+
+	plan.ApprovalStatus = planning.ApprovalPending
+	plan.UpdatedAt = time.Now()
+	if err := s.audit.Log(events.EventTypePlanRolledBack, actor, map[string]interface{}{
+		"plan_id":   plan.ID,
+		"spec_id":   plan.SpecID,
+		"revision":  rev.Hash,
+		"from_hash": fromHash,
+	}); err != nil {
+		return nil, fmt.Errorf("write audit log: %w", err)
+	}
+	if err := s.repo.SavePlan(plan); err != nil {
+		return nil, fmt.Errorf("failed to save plan: %w", err)
+	}
+	return plan, nil
+}
+
 func (s *PlanService) PrunePlan() error {
 	spec, err := s.repo.LoadSpec()
 	if err != nil {
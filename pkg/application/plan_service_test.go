@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"os"
+	"slices"
 	"strings"
 	"testing"
 
@@ -408,6 +409,42 @@ func TestPlanService_PropagatesSourceFromSpec(t *testing.T) {
 	}
 }
 
+func TestPlanService_PropagatesFilesFromSpec(t *testing.T) {
+	repo := storage.NewFilesystemRepository(t.TempDir())
+	_ = repo.Initialize()
+	service := application.NewPlanService(repo, application.NewAuditService(repo))
+
+	if err := repo.SaveSpec(&spec.ProductSpec{
+		ID: "spec-files",
+		Features: []spec.Feature{{
+			ID:    "auth",
+			Title: "Auth",
+			Files: []string{"pkg/auth/**"},
+			Requirements: []spec.Requirement{
+				{ID: "auth-signup", Title: "Sign up", Files: []string{"pkg/auth/signup.go"}, AcceptanceCriteria: []string{"Email is confirmed"}},
+				{ID: "auth-login", Title: "Log in"},
+			},
+		}},
+	}); err != nil {
+		t.Fatalf("save spec: %v", err)
+	}
+
+	plan, err := service.GeneratePlan(context.Background())
+	if err != nil {
+		t.Fatalf("GeneratePlan: %v", err)
+	}
+
+	want := map[string]string{"task-auth-signup": "pkg/auth/signup.go", "task-auth-login": "pkg/auth/**"}
+	for _, task := range plan.Tasks {
+		if len(task.Files) != 1 || task.Files[0] != want[task.ID] {
+			t.Errorf("task %q files = %v, want [%s]", task.ID, task.Files, want[task.ID])
+		}
+		if task.ID == "task-auth-signup" && (len(task.AcceptanceCriteria) != 1 || task.AcceptanceCriteria[0] != "Email is confirmed") {
+			t.Errorf("task %q acceptance criteria = %v", task.ID, task.AcceptanceCriteria)
+		}
+	}
+}
+
 func TestPlanService_UpdatePlanKeepsOrphans(t *testing.T) {
 	repo := &MockRepo{
 		Spec: &spec.ProductSpec{
@@ -969,3 +1006,66 @@ func TestPlanService_ReconcilePlanKeepsOrphans(t *testing.T) {
 		t.Fatalf("orphan task was dropped from plan: %+v", updated.Tasks)
 	}
 }
+
+func TestPlanService_HistoryDiffRollback(t *testing.T) {
+	repo := storage.NewFilesystemRepository(t.TempDir())
+	_ = repo.Initialize()
+	service := application.NewPlanService(repo, application.NewAuditService(repo))
+
+	v1 := &planning.Plan{ID: "p1", SpecID: "s1", ApprovalStatus: planning.ApprovalPending, Tasks: []planning.Task{
+		{ID: "t1", Title: "Login"},
+	}}
+	_ = repo.SavePlan(v1)
+	if err := service.ApprovePlanWithActor(context.Background(), "alice"); err != nil {
+		t.Fatalf("approve v1: %v", err)
+	}
+	// Approving an unchanged plan does not add a revision.
+	if err := service.ApprovePlanWithActor(context.Background(), "alice"); err != nil {
+		t.Fatalf("re-approve v1: %v", err)
+	}
+
+	v2 := &planning.Plan{ID: "p1", SpecID: "s1", ApprovalStatus: planning.ApprovalPending, Tasks: []planning.Task{
+		{ID: "t1", Title: "Login", Estimate: "2h"},
+		{ID: "t2", Title: "Logout", DependsOn: []string{"t1"}},
+	}}
+	_ = repo.SavePlan(v2)
+	if err := service.ApprovePlanWithActor(context.Background(), "bob"); err != nil {
+		t.Fatalf("approve v2: %v", err)
+	}
+
+	history, err := service.PlanHistory()
+	if err != nil || len(history) != 2 || history[0].Hash != v1.RevisionHash() || history[1].ApprovedBy != "bob" {
+		t.Fatalf("unexpected history: %+v, %v", history, err)
+	}
+
+	diff, err := service.DiffPlanRevisions(history[0].Hash[:8], application.PlanRevisionCurrent)
+	if err != nil {
+		t.Fatalf("DiffPlanRevisions: %v", err)
+	}
+	if len(diff.Tasks) != 2 || diff.Tasks[0].Field != "estimate" || diff.Tasks[1].TaskID != "t2" || len(diff.Dependencies) != 1 {
+		t.Errorf("unexpected diff: %+v", diff)
+	}
+
+	if _, err := service.RollbackPlan(context.Background(), application.PlanRevisionCurrent, "carol"); err == nil {
+		t.Error("expected rollback to the current plan to fail")
+	}
+	restored, err := service.RollbackPlan(context.Background(), history[0].Hash, "carol")
+	if err != nil {
+		t.Fatalf("RollbackPlan: %v", err)
+	}
+	if restored.ApprovalStatus != planning.ApprovalPending || len(restored.Tasks) != 1 {
+		t.Errorf("rollback should restore v1 as pending: %+v", restored)
+	}
+	if err := service.ApprovePlanWithActor(context.Background(), "carol"); err != nil {
+		t.Fatalf("approve rollback: %v", err)
+	}
+	history, _ = service.PlanHistory()
+	if len(history) != 3 || history[2].Hash != v1.RevisionHash() || !history[2].Restored {
+		t.Errorf("expected the rollback to be recorded as a restored revision: %+v", history)
+	}
+
+	events, _ := repo.LoadEvents()
+	if !slices.ContainsFunc(events, func(e domain.Event) bool { return e.Action == "plan.rolled_back" }) {
+		t.Error("expected a plan.rolled_back event")
+	}
+}
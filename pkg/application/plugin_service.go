@@ -48,11 +48,11 @@ type ValidationResult struct {
 
 // PluginService manages plugin registration, validation, and health.
 type PluginService struct {
-	repo *storage.FilesystemRepository
+	repo storage.Repository
 }
 
 // NewPluginService creates a new PluginService.
-func NewPluginService(repo *storage.FilesystemRepository) *PluginService {
+func NewPluginService(repo storage.Repository) *PluginService {
 	return &PluginService{repo: repo}
 }
 
@@ -96,6 +96,21 @@ func (s *PluginService) UnregisterPlugin(name string) error {
 	return s.repo.RemovePluginConfig(name)
 }
 
+// SetConflictPolicy sets how a plugin's two-way field sync resolves fields
+// changed on both sides.
+func (s *PluginService) SetConflictPolicy(name, policy string) error {
+	p, err := plugin.ParseConflictPolicy(policy)
+	if err != nil {
+		return err
+	}
+	cfg, err := s.repo.GetPluginConfig(name)
+	if err != nil {
+		return err
+	}
+	cfg.ConflictPolicy = p
+	return s.repo.SetPluginConfig(name, *cfg)
+}
+
 // ListPlugins returns all registered plugins with status information.
 func (s *PluginService) ListPlugins() ([]PluginInfo, error) {
 	configs, err := s.repo.LoadPluginConfigs()
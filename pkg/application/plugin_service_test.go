@@ -43,6 +43,33 @@ func TestPluginService_RegisterAndList(t *testing.T) {
 	}
 }
 
+func TestPluginService_SetConflictPolicy(t *testing.T) {
+	root := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(root, ".roady"), 0700)
+	binPath := filepath.Join(root, "fake-plugin")
+	_ = os.WriteFile(binPath, []byte("#!/bin/sh\n"), 0755)
+
+	repo := storage.NewFilesystemRepository(root)
+	svc := application.NewPluginService(repo)
+	if err := svc.RegisterPlugin("test-plugin", binPath); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.SetConflictPolicy("test-plugin", "remote-wins"); err != nil {
+		t.Fatalf("SetConflictPolicy: %v", err)
+	}
+	cfg, _ := repo.GetPluginConfig("test-plugin")
+	if cfg.ConflictPolicy != plugin.ConflictRemoteWins || cfg.Binary != binPath {
+		t.Errorf("config = %+v", cfg)
+	}
+	if err := svc.SetConflictPolicy("test-plugin", "coin-flip"); err == nil {
+		t.Error("expected an unknown policy to be rejected")
+	}
+	if err := svc.SetConflictPolicy("missing", "roady-wins"); err == nil {
+		t.Error("expected error for unknown plugin")
+	}
+}
+
 func TestPluginService_Unregister(t *testing.T) {
 	root := t.TempDir()
 	roadyDir := filepath.Join(root, ".roady")
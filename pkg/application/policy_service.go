@@ -1,26 +1,51 @@
 package application
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/felixgeelhaar/roady/pkg/domain"
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	"github.com/felixgeelhaar/roady/pkg/domain/policy"
 	"github.com/felixgeelhaar/roady/pkg/domain/policy/rules"
+	"github.com/felixgeelhaar/roady/pkg/domain/project"
 	"github.com/felixgeelhaar/roady/pkg/storage"
 )
 
 type PolicyService struct {
-	repo domain.WorkspaceRepository
+	repo     domain.WorkspaceRepository
+	projects project.ProjectDirectory // resolves @project:task-id dependencies; may be nil
 }
 
 func NewPolicyService(repo domain.WorkspaceRepository) *PolicyService {
 	return &PolicyService{repo: repo}
 }
 
+// SetProjectDirectory lets dependency checks resolve "@project:task-id"
+// dependencies on other projects of the workspace.
+func (s *PolicyService) SetProjectDirectory(dir project.ProjectDirectory) {
+	s.projects = dir
+}
+
+// externalStatus returns the status of a task in another project.
+func (s *PolicyService) externalStatus(ref planning.TaskRef) (planning.TaskStatus, error) {
+	if s.projects == nil {
+		return "", fmt.Errorf("%w: %s (cross-project dependencies need a workspace)", project.ErrUnknownProject, ref)
+	}
+	state, err := s.projects.LoadState(context.Background(), ref.Project)
+	if err != nil {
+		return "", err
+	}
+	if state == nil {
+		return planning.StatusPending, nil
+	}
+	return state.GetTaskStatus(ref.TaskID), nil
+}
+
 // CheckCompliance validates the current plan against active policies.
 func (s *PolicyService) CheckCompliance() ([]policy.Violation, error) {
 	plan, err := s.repo.LoadPlan()
@@ -33,6 +58,22 @@ func (s *PolicyService) CheckCompliance() ([]policy.Violation, error) {
 		return nil, fmt.Errorf("failed to load state: %w", err)
 	}
 
+	activeRules, err := s.ActiveRules()
+	if err != nil {
+		return nil, err
+	}
+
+	policySet := policy.PolicySet{
+		Rules: activeRules,
+	}
+
+	return policySet.Validate(plan, state), nil
+}
+
+// ActiveRules returns the rules CheckCompliance evaluates: the built-in
+// max-wip and dependency checks followed by the declarative rules from
+// policy.yaml.
+func (s *PolicyService) ActiveRules() ([]policy.Rule, error) {
 	cfg, err := s.repo.LoadPolicy()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load policy: %w", err)
@@ -42,16 +83,34 @@ func (s *PolicyService) CheckCompliance() ([]policy.Violation, error) {
 	if cfg != nil {
 		activeRules = append(activeRules, &rules.MaxWIPRule{Limit: cfg.MaxWIP})
 	}
-	activeRules = append(activeRules, &rules.DependencyRule{})
-
-	policySet := policy.PolicySet{
-		Rules: activeRules,
+	depRule := &rules.DependencyRule{}
+	if s.projects != nil {
+		depRule.External = s.externalStatus
 	}
+	activeRules = append(activeRules, depRule)
 
-	return policySet.Validate(plan, state), nil
+	declarative, err := compilePolicyRules(cfg)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range declarative {
+		activeRules = append(activeRules, r)
+	}
+	return activeRules, nil
 }
 
 func (s *PolicyService) ValidateTransition(taskID string, event string) error {
+	return s.ValidateTransitionAs(taskID, event, "", "")
+}
+
+// ValidateTransitionAs validates a transition with the actor and evidence that
+// will accompany it, so declarative rules on owner or evidence see the state
+// the task will actually have after the transition.
+func (s *PolicyService) ValidateTransitionAs(taskID, event, actor, evidence string) error {
+	if err := s.validateDeclarativeRules(taskID, event, actor, evidence); err != nil {
+		return err
+	}
+
 	if event != "start" {
 		return nil
 	}
@@ -96,6 +155,18 @@ func (s *PolicyService) ValidateTransition(taskID string, event string) error {
 			return err
 		}
 		for _, depID := range targetTask.DependsOn {
+			// Handle Cross-project Dependency (format: "@project:task-id")
+			if ref := planning.ParseTaskRef(depID); ref.External {
+				extStatus, err := s.externalStatus(ref)
+				if err != nil {
+					return fmt.Errorf("cannot verify dependency '%s': %w", depID, err)
+				}
+				if !extStatus.IsComplete() {
+					return fmt.Errorf("cannot start task '%s': it depends on '%s' in project '%s', which is currently '%s'", taskID, ref.TaskID, ref.Project, extStatus)
+				}
+				continue
+			}
+
 			// Handle Cross-repo Dependency (format: "project-name:task-id")
 			if strings.Contains(depID, ":") {
 				parts := strings.Split(depID, ":")
@@ -107,7 +178,10 @@ func (s *PolicyService) ValidateTransition(taskID string, event string) error {
 					return fmt.Errorf("cannot start task '%s': depends on external project '%s' which cannot be found", taskID, extProject)
 				}
 
-				extRepo := storage.NewFilesystemRepository(extRepoPath)
+				extRepo, err := storage.OpenRepository(extRepoPath, "")
+				if err != nil {
+					return fmt.Errorf("cannot verify dependency '%s': %w", depID, err)
+				}
 				extState, err := extRepo.LoadState()
 				if err != nil {
 					return fmt.Errorf("cannot verify dependency '%s': failed to load external state", depID)
@@ -134,6 +208,98 @@ func (s *PolicyService) ValidateTransition(taskID string, event string) error {
 	return nil
 }
 
+// validateDeclarativeRules evaluates the error-level rules from policy.yaml
+// against the task as it would look after the transition is applied.
+func (s *PolicyService) validateDeclarativeRules(taskID, event, actor, evidence string) error {
+	cfg, err := s.repo.LoadPolicy()
+	if err != nil {
+		return fmt.Errorf("failed to load policy: %w", err)
+	}
+	if cfg == nil || len(cfg.Rules) == 0 {
+		return nil
+	}
+	compiled, err := compilePolicyRules(cfg)
+	if err != nil {
+		return err
+	}
+
+	plan, err := s.repo.LoadPlan()
+	if err != nil || plan == nil {
+		return nil
+	}
+	var task *planning.Task
+	for i := range plan.Tasks {
+		if plan.Tasks[i].ID == taskID {
+			task = &plan.Tasks[i]
+			break
+		}
+	}
+	if task == nil {
+		return nil
+	}
+
+	var result planning.TaskResult
+	if state, err := s.repo.LoadState(); err == nil && state != nil {
+		result = state.TaskStates[taskID]
+	}
+	projected, ok := projectTransition(result, event, actor, evidence)
+	if !ok {
+		return nil // Invalid transitions are reported by the state machine.
+	}
+
+	for _, rule := range compiled {
+		if !rule.Gates(event) {
+			continue
+		}
+		if violations := rule.ValidateTask(*task, projected); len(violations) > 0 {
+			return fmt.Errorf("cannot %s task '%s': policy rule '%s' violated: %s", event, taskID, rule.ID(), violations[0].Message)
+		}
+	}
+	return nil
+}
+
+// projectTransition returns the task result as the coordinator would leave it
+// after applying event.
+func projectTransition(result planning.TaskResult, event, actor, evidence string) (planning.TaskResult, bool) {
+	current := result.Status
+	if current == "" {
+		current = planning.StatusPending
+	}
+	target, err := current.TransitionWith(event)
+	if err != nil {
+		return result, false
+	}
+
+	now := time.Now()
+	result.Status = target
+	result.Evidence = append([]string(nil), result.Evidence...)
+	switch event {
+	case "start":
+		result.StartedAt = &now
+		if actor != "" {
+			result.Owner = actor
+		}
+	case "complete":
+		result.CompletedAt = &now
+	}
+	if evidence != "" && event != "verify" {
+		result.Evidence = append(result.Evidence, evidence)
+	}
+	return result, true
+}
+
+// compilePolicyRules compiles the declarative rules section of policy.yaml.
+func compilePolicyRules(cfg *policy.PolicyConfig) ([]*rules.DeclarativeRule, error) {
+	if cfg == nil || len(cfg.Rules) == 0 {
+		return nil, nil
+	}
+	compiled, err := rules.CompileAll(cfg.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy rules: %w", err)
+	}
+	return compiled, nil
+}
+
 func (s *PolicyService) findExternalProject(name string) (string, bool) {
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -150,7 +316,10 @@ func (s *PolicyService) findExternalProject(name string) (string, bool) {
 		}
 		if info.IsDir() && info.Name() == ".roady" {
 			projectDir := filepath.Dir(path)
-			repo := storage.NewFilesystemRepository(projectDir)
+			repo, openErr := storage.OpenRepository(projectDir, "")
+			if openErr != nil {
+				return nil
+			}
 			spec, loadErr := repo.LoadSpec()
 			if loadErr == nil && spec != nil && (spec.ID == name || spec.Title == name) {
 				foundPath = projectDir
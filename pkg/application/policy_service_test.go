@@ -3,11 +3,13 @@ package application_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/felixgeelhaar/roady/pkg/application"
 	"github.com/felixgeelhaar/roady/pkg/domain"
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/policy"
 	"github.com/felixgeelhaar/roady/pkg/domain/spec"
 	"github.com/felixgeelhaar/roady/pkg/storage"
 )
@@ -150,3 +152,114 @@ func TestPolicyService_ValidateTransition_ExternalDependency(t *testing.T) {
 		t.Fatalf("expected external dependency to pass, got %v", err)
 	}
 }
+
+func TestPolicyService_CheckCompliance_DeclarativeRules(t *testing.T) {
+	repo := &MockRepo{
+		Policy: &domain.PolicyConfig{
+			MaxWIP: 5,
+			Rules: []policy.RuleConfig{{
+				ID:        "high-priority-estimate",
+				Type:      policy.RuleTypeRequireField,
+				Selector:  policy.Selector{Priority: []planning.TaskPriority{planning.PriorityHigh}},
+				Condition: policy.Condition{Field: "estimate"},
+				Level:     policy.ViolationError,
+			}},
+		},
+		Plan: &planning.Plan{Tasks: []planning.Task{
+			{ID: "t1", Priority: planning.PriorityHigh},
+			{ID: "t2", Priority: planning.PriorityHigh, Estimate: "2h"},
+		}},
+		State: planning.NewExecutionState("p"),
+	}
+
+	violations, err := application.NewPolicyService(repo).CheckCompliance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 1 || violations[0].RuleID != "high-priority-estimate" || violations[0].TaskID != "t1" {
+		t.Fatalf("unexpected violations: %+v", violations)
+	}
+}
+
+func TestPolicyService_CheckCompliance_InvalidRule(t *testing.T) {
+	repo := &MockRepo{
+		Policy: &domain.PolicyConfig{Rules: []policy.RuleConfig{{ID: "bad", Type: "unknown"}}},
+		Plan:   &planning.Plan{},
+		State:  planning.NewExecutionState("p"),
+	}
+	if _, err := application.NewPolicyService(repo).CheckCompliance(); err == nil {
+		t.Fatal("expected error for invalid rule")
+	}
+}
+
+func TestPolicyService_ValidateTransition_DeclarativeRule(t *testing.T) {
+	repo := &MockRepo{
+		Policy: &domain.PolicyConfig{
+			Rules: []policy.RuleConfig{{
+				ID:        "blocked-owner",
+				Type:      policy.RuleTypeRequireField,
+				Selector:  policy.Selector{Status: []planning.TaskStatus{planning.StatusBlocked}},
+				Condition: policy.Condition{Field: "owner"},
+				Level:     policy.ViolationError,
+			}},
+		},
+		Plan: &planning.Plan{Tasks: []planning.Task{{ID: "t1"}, {ID: "t2"}}},
+		State: &planning.ExecutionState{TaskStates: map[string]planning.TaskResult{
+			"t1": {Status: planning.StatusPending},
+			"t2": {Status: planning.StatusPending, Owner: "alice"},
+		}},
+	}
+	service := application.NewPolicyService(repo)
+
+	if err := service.ValidateTransition("t1", "block"); err == nil || !strings.Contains(err.Error(), "blocked-owner") {
+		t.Fatalf("expected blocked-owner violation, got %v", err)
+	}
+	if err := service.ValidateTransition("t2", "block"); err != nil {
+		t.Fatalf("owned task should be blockable: %v", err)
+	}
+	if err := service.ValidateTransition("t1", "start"); err != nil {
+		t.Fatalf("rule should not affect start: %v", err)
+	}
+}
+
+func TestPolicyService_ValidateTransitionAs_UsesEvidence(t *testing.T) {
+	repo := &MockRepo{
+		Policy: &domain.PolicyConfig{
+			Rules: []policy.RuleConfig{{
+				ID:        "done-evidence",
+				Type:      policy.RuleTypeRequireField,
+				Selector:  policy.Selector{Status: []planning.TaskStatus{planning.StatusDone}},
+				Condition: policy.Condition{Field: "evidence"},
+				Level:     policy.ViolationError,
+			}},
+		},
+		Plan: &planning.Plan{Tasks: []planning.Task{{ID: "t1"}}},
+		State: &planning.ExecutionState{TaskStates: map[string]planning.TaskResult{
+			"t1": {Status: planning.StatusInProgress},
+		}},
+	}
+	service := application.NewPolicyService(repo)
+
+	if err := service.ValidateTransitionAs("t1", "complete", "", ""); err == nil {
+		t.Fatal("expected completion without evidence to be rejected")
+	}
+	if err := service.ValidateTransitionAs("t1", "complete", "", "commit abc123"); err != nil {
+		t.Fatalf("completion with evidence should pass: %v", err)
+	}
+}
+
+func TestPolicyService_ValidateTransition_MalformedPolicy(t *testing.T) {
+	root := t.TempDir()
+	repo := storage.NewFilesystemRepository(root)
+	if err := repo.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".roady", "policy.yaml"), []byte("rules: [unclosed"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	service := application.NewPolicyService(repo)
+
+	if err := service.ValidateTransition("t1", "complete"); err == nil || !strings.Contains(err.Error(), "failed to load policy") {
+		t.Fatalf("expected malformed policy to fail validation, got %v", err)
+	}
+}
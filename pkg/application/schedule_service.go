@@ -0,0 +1,80 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/storage"
+)
+
+// ScheduleService simulates the schedule of the remaining work.
+type ScheduleService struct {
+	repo storage.Repository
+}
+
+// NewScheduleService creates a new schedule service.
+func NewScheduleService(repo storage.Repository) *ScheduleService {
+	return &ScheduleService{repo: repo}
+}
+
+// ScheduleRequest overrides how many tasks can run in parallel. Zero
+// values fall back to the team (members who can transition tasks) and the
+// policy's max_wip.
+type ScheduleRequest struct {
+	TeamSize int
+	MaxWIP   int
+}
+
+// GetSchedule computes the critical path, slack and capacity-limited
+// schedule of the current plan. It returns nil when there is no plan.
+func (s *ScheduleService) GetSchedule(req ScheduleRequest) (*planning.Schedule, error) {
+	plan, err := s.repo.LoadPlan()
+	if err != nil {
+		return nil, fmt.Errorf("load plan: %w", err)
+	}
+	if plan == nil {
+		return nil, nil
+	}
+	state, err := s.repo.LoadState()
+	if err != nil {
+		return nil, fmt.Errorf("load state: %w", err)
+	}
+
+	capacity, err := s.capacity(req)
+	if err != nil {
+		return nil, err
+	}
+	return planning.BuildSchedule(plan, state, planning.ScheduleOptions{Capacity: capacity})
+}
+
+// capacity is the smaller of the team size and the WIP limit; zero means
+// neither is known.
+func (s *ScheduleService) capacity(req ScheduleRequest) (int, error) {
+	teamSize, wip := req.TeamSize, req.MaxWIP
+	if teamSize == 0 {
+		cfg, err := s.repo.LoadTeam()
+		if err != nil {
+			return 0, fmt.Errorf("load team: %w", err)
+		}
+		for _, m := range cfg.Members {
+			if m.Role.CanTransitionTasks() {
+				teamSize++
+			}
+		}
+	}
+	if wip == 0 {
+		cfg, err := s.repo.LoadPolicy()
+		if err != nil {
+			return 0, fmt.Errorf("load policy: %w", err)
+		}
+		wip = cfg.MaxWIP
+	}
+
+	switch {
+	case teamSize > 0 && wip > 0:
+		return min(teamSize, wip), nil
+	case teamSize > 0:
+		return teamSize, nil
+	}
+	return max(wip, 0), nil
+}
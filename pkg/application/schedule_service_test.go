@@ -0,0 +1,86 @@
+package application_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/felixgeelhaar/roady/pkg/application"
+	"github.com/felixgeelhaar/roady/pkg/domain"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
+	"github.com/felixgeelhaar/roady/pkg/storage"
+)
+
+func newScheduleTestRepo(t *testing.T) *storage.FilesystemRepository {
+	t.Helper()
+	repo := storage.NewFilesystemRepository(t.TempDir())
+	if err := repo.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	plan := &planning.Plan{ID: "p", Tasks: []planning.Task{
+		{ID: "task-a", Estimate: "1d"},
+		{ID: "task-b", Estimate: "1d"},
+		{ID: "task-c", Estimate: "2d", DependsOn: []string{"task-a"}},
+	}}
+	if err := repo.SavePlan(plan); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SaveState(planning.NewExecutionState("p")); err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
+
+func TestScheduleService_GetSchedule(t *testing.T) {
+	repo := newScheduleTestRepo(t)
+	if err := repo.SaveTeam(&team.TeamConfig{Members: []team.Member{
+		{Name: "alice", Role: team.RoleMember},
+		{Name: "bob", Role: team.RoleAdmin},
+		{Name: "carol", Role: team.RoleViewer},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	svc := application.NewScheduleService(repo)
+
+	// Two members and the default max_wip of 3: a and b run in parallel.
+	sched, err := svc.GetSchedule(application.ScheduleRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sched.Capacity != 2 || sched.TotalHours != 24 {
+		t.Errorf("capacity %d, total %v; want 2, 24", sched.Capacity, sched.TotalHours)
+	}
+	if !slices.Equal(sched.CriticalPath, []string{"task-a", "task-c"}) {
+		t.Errorf("critical path = %v", sched.CriticalPath)
+	}
+
+	if err := repo.SavePolicy(&domain.PolicyConfig{MaxWIP: 1}); err != nil {
+		t.Fatal(err)
+	}
+	sched, err = svc.GetSchedule(application.ScheduleRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sched.Capacity != 1 || sched.TotalHours != 32 {
+		t.Errorf("capacity %d, total %v; want 1, 32", sched.Capacity, sched.TotalHours)
+	}
+
+	sched, err = svc.GetSchedule(application.ScheduleRequest{TeamSize: 3, MaxWIP: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sched.Capacity != 3 {
+		t.Errorf("capacity = %d, want 3", sched.Capacity)
+	}
+}
+
+func TestScheduleService_NoPlan(t *testing.T) {
+	repo := storage.NewFilesystemRepository(t.TempDir())
+	if err := repo.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	sched, err := application.NewScheduleService(repo).GetSchedule(application.ScheduleRequest{})
+	if err != nil || sched != nil {
+		t.Errorf("GetSchedule() = %v, %v; want nil, nil", sched, err)
+	}
+}
@@ -0,0 +1,100 @@
+package application
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/spec"
+)
+
+// adrDirs are the directory names conventionally holding decision records.
+var adrDirs = map[string]bool{"adr": true, "adrs": true, "decisions": true}
+
+// adrStatusLine matches inline status lines such as "Status: Accepted" or
+// "* Status: accepted".
+var adrStatusLine = regexp.MustCompile(`(?i)^(?:[-*]\s*)?(?:\*\*)?status(?:\*\*)?:\s*(?:\*\*)?\s*(.+?)(?:\*\*)?$`)
+
+// ADRImporter turns accepted architecture decision records into spec
+// constraints. It handles markdown files directly in an adr, adrs or
+// decisions directory and understands both the Nygard "## Status" section and MADR
+// style "Status:" lines or front-matter. Records in any other status
+// (proposed, superseded, deprecated) yield no constraint.
+type ADRImporter struct{}
+
+func (ADRImporter) Name() string { return "adr" }
+
+func (ADRImporter) Match(path string) bool {
+	if !hasExt(path, ".md", ".markdown") {
+		return false
+	}
+	return adrDirs[strings.ToLower(filepath.Base(filepath.Dir(path)))]
+}
+
+func (ADRImporter) Import(r io.Reader, doc string) (*spec.ProductSpec, error) {
+	var (
+		title     string
+		titleLine int
+		status    string
+		section   string
+		decision  []string
+		lineNum   int
+	)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "# ") && title == "":
+			title, titleLine = strings.TrimSpace(strings.TrimPrefix(line, "# ")), lineNum
+			continue
+		case strings.HasPrefix(line, "## "):
+			section = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(line, "## ")))
+			continue
+		case line == "" || line == "---":
+			continue
+		}
+
+		if m := adrStatusLine.FindStringSubmatch(line); m != nil && status == "" {
+			status = m[1]
+			continue
+		}
+		switch {
+		case section == "status" && status == "":
+			status = line
+		case strings.HasPrefix(section, "decision"):
+			decision = append(decision, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+	if title == "" {
+		return nil, fmt.Errorf("%s: decision record has no title", doc)
+	}
+
+	productSpec := &spec.ProductSpec{
+		ID:          "imported-spec",
+		Version:     "0.1.0",
+		Constraints: []spec.Constraint{},
+		Features:    []spec.Feature{},
+	}
+	if !strings.HasPrefix(strings.ToLower(strings.Trim(status, "*_ ")), "accepted") {
+		return productSpec, nil
+	}
+
+	description := title
+	if len(decision) > 0 {
+		description = fmt.Sprintf("%s: %s", title, strings.Join(decision, " "))
+	}
+	name := strings.TrimSuffix(filepath.Base(doc), filepath.Ext(doc))
+	productSpec.Constraints = append(productSpec.Constraints, spec.Constraint{
+		ID:          "adr-" + strings.TrimPrefix(slugify(name), "adr-"),
+		Description: description,
+		Source:      spec.Source{Doc: doc, Line: titleLine},
+	})
+	return productSpec, nil
+}
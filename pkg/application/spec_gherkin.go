@@ -0,0 +1,127 @@
+package application
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/spec"
+)
+
+// gherkinSteps are the keywords whose lines become acceptance criteria.
+var gherkinSteps = []string{"Given ", "When ", "Then ", "And ", "But ", "* "}
+
+// GherkinImporter turns Gherkin .feature files into features whose
+// scenarios are requirements. Each scenario's steps become its acceptance
+// criteria; Background steps are prepended to every scenario.
+type GherkinImporter struct{}
+
+func (GherkinImporter) Name() string { return "gherkin" }
+
+func (GherkinImporter) Match(path string) bool {
+	return hasExt(path, ".feature")
+}
+
+func (GherkinImporter) Import(r io.Reader, doc string) (*spec.ProductSpec, error) {
+	productSpec := &spec.ProductSpec{
+		ID:          "imported-spec",
+		Version:     "0.1.0",
+		Constraints: []spec.Constraint{},
+		Features:    []spec.Feature{},
+	}
+	ids := make(requirementIDs)
+
+	var (
+		feature      *spec.Feature
+		req          *spec.Requirement
+		background   []string
+		inBackground bool
+		inDocString  bool
+		lineNum      int
+	)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, `"""`) || strings.HasPrefix(line, "```") {
+			inDocString = !inDocString
+			continue
+		}
+		if inDocString || line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "@") || strings.HasPrefix(line, "|") {
+			continue
+		}
+
+		keyword, rest, _ := strings.Cut(line, ":")
+		rest = strings.TrimSpace(rest)
+		switch keyword {
+		case "Feature":
+			productSpec.Features = append(productSpec.Features, spec.Feature{
+				ID:     slugify(rest),
+				Title:  rest,
+				Source: spec.Source{Doc: doc, Line: lineNum},
+			})
+			feature, req, background, inBackground = &productSpec.Features[len(productSpec.Features)-1], nil, nil, false
+			if productSpec.Title == "" {
+				productSpec.Title = rest
+			}
+			continue
+		case "Background":
+			req, inBackground = nil, true
+			continue
+		case "Rule", "Examples", "Scenarios":
+			req, inBackground = nil, false
+			continue
+		case "Scenario", "Scenario Outline", "Scenario Template", "Example":
+			if feature == nil {
+				return nil, fmt.Errorf("%s:%d: scenario outside a Feature", doc, lineNum)
+			}
+			inBackground = false
+			feature.Requirements = append(feature.Requirements, spec.Requirement{
+				ID:                 ids.derive(feature.ID, rest),
+				Title:              rest,
+				DependsOn:          []string{},
+				Source:             spec.Source{Doc: doc, Line: lineNum},
+				AcceptanceCriteria: append([]string(nil), background...),
+			})
+			req = &feature.Requirements[len(feature.Requirements)-1]
+			ids[req.ID] = true
+			continue
+		}
+
+		if isGherkinStep(line) {
+			switch {
+			case inBackground:
+				background = append(background, line)
+			case req != nil:
+				req.AcceptanceCriteria = append(req.AcceptanceCriteria, line)
+			}
+			continue
+		}
+
+		// Free text describes the innermost open element.
+		switch {
+		case req != nil:
+			req.Description = strings.TrimSpace(req.Description + "\n" + line)
+		case feature != nil && !inBackground:
+			feature.Description = strings.TrimSpace(feature.Description + "\n" + line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+	if len(productSpec.Features) == 0 {
+		return nil, fmt.Errorf("%s: no Feature found", doc)
+	}
+	return productSpec, nil
+}
+
+func isGherkinStep(line string) bool {
+	for _, kw := range gherkinSteps {
+		if strings.HasPrefix(line, kw) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package application
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/spec"
+)
+
+// DefaultSpecImporters returns the built-in spec importers in match order.
+// ADRs come before plain markdown so decision records under docs/adr are
+// read as constraints rather than features.
+func DefaultSpecImporters() []spec.Importer {
+	return []spec.Importer{
+		ADRImporter{},
+		MarkdownImporter{},
+		GherkinImporter{},
+		OpenAPIImporter{},
+	}
+}
+
+// MarkdownImporter reads markdown documents with ParseMarkdownSpec.
+type MarkdownImporter struct{}
+
+func (MarkdownImporter) Name() string { return "markdown" }
+
+func (MarkdownImporter) Match(path string) bool {
+	return hasExt(path, ".md", ".markdown")
+}
+
+func (MarkdownImporter) Import(r io.Reader, doc string) (*spec.ProductSpec, error) {
+	return ParseMarkdownSpec(r, doc)
+}
+
+// requirementIDs tracks the requirement IDs used in one document.
+type requirementIDs map[string]bool
+
+// derive builds an unused requirement ID from a title, prefixing the feature
+// ID and then numbering when the slug is already taken.
+func (ids requirementIDs) derive(featureID, title string) string {
+	words := strings.FieldsFunc(slugify(title), func(r rune) bool { return r == '-' })
+	if len(words) > 8 {
+		words = words[:8]
+	}
+	base := strings.Join(words, "-")
+	if base == "" {
+		base = "req"
+	}
+	if !ids[base] {
+		return base
+	}
+	return ids.numbered(featureID + "-" + base)
+}
+
+// numbered returns base, or base suffixed -2, -3, ... when it is already
+// taken.
+func (ids requirementIDs) numbered(base string) string {
+	if !ids[base] {
+		return base
+	}
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s-%d", base, n)
+		if !ids[candidate] {
+			return candidate
+		}
+	}
+}
+
+func hasExt(path string, exts ...string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, e := range exts {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,262 @@
+package application_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/roady/pkg/application"
+	"github.com/felixgeelhaar/roady/pkg/domain/spec"
+	"github.com/felixgeelhaar/roady/pkg/storage"
+)
+
+const petstoreOpenAPI = `openapi: 3.0.3
+info:
+  title: Pet Store
+  version: 2.1.0
+tags:
+  - name: Pets
+    description: Manage pets
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List pets
+      tags: [Pets]
+    post:
+      summary: Create a pet
+      description: Adds a pet to the store.
+      tags: [Pets]
+  /health:
+    get:
+      operationId: health
+`
+
+const loginFeature = `@auth
+Feature: Login
+  Users sign in with email.
+
+  Background:
+    Given a registered user
+
+  Scenario: Successful login
+    When they submit valid credentials
+    Then they see the dashboard
+
+  Scenario Outline: Locked account
+    When they fail <n> times
+    Then the account is locked
+
+    Examples:
+      | n |
+      | 5 |
+`
+
+const acceptedADR = `# 3. Use PostgreSQL
+
+## Status
+
+Accepted
+
+## Decision
+
+We store all data in PostgreSQL.
+`
+
+func TestOpenAPIImporter(t *testing.T) {
+	imp := application.OpenAPIImporter{}
+	dir := t.TempDir()
+	for name, body := range map[string]string{
+		"openapi.yaml":  petstoreOpenAPI,
+		"swagger.json":  `{"swagger": "2.0", "paths": {}}`,
+		"settings.yaml": "debug: true\nopenapi_url: /docs\n",
+		"package.json":  `{"name": "web", "version": "1.0.0"}`,
+		"openapi.md":    petstoreOpenAPI,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, want := range map[string]bool{
+		"openapi.yaml":  true,
+		"swagger.json":  true,
+		"settings.yaml": false,
+		"package.json":  false,
+		"openapi.md":    false,
+		"missing.yaml":  false,
+	} {
+		if got := imp.Match(filepath.Join(dir, name)); got != want {
+			t.Errorf("Match(%s) = %v, want %v", name, got, want)
+		}
+	}
+
+	s, err := imp.Import(strings.NewReader(petstoreOpenAPI), "api/openapi.yaml")
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if s.ID != "pet-store" || s.Version != "2.1.0" {
+		t.Errorf("unexpected spec header: %+v", s)
+	}
+	if len(s.Features) != 2 {
+		t.Fatalf("expected pets and api features, got %+v", s.Features)
+	}
+
+	pets := s.Features[0]
+	if pets.ID != "pets" || pets.Description != "Manage pets" || pets.Source.Line != 6 {
+		t.Errorf("unexpected pets feature: %+v", pets)
+	}
+	if len(pets.Requirements) != 2 {
+		t.Fatalf("expected 2 pet operations, got %d", len(pets.Requirements))
+	}
+	list, create := pets.Requirements[0], pets.Requirements[1]
+	if list.ID != "list-pets" || list.Title != "List pets" || list.Source.Line != 10 {
+		t.Errorf("unexpected list requirement: %+v", list)
+	}
+	if create.ID != "post-pets" || !strings.Contains(create.Description, "POST /pets") {
+		t.Errorf("unexpected create requirement: %+v", create)
+	}
+
+	api := s.Features[1]
+	if api.ID != "api" || len(api.Requirements) != 1 || api.Requirements[0].Title != "GET /health" {
+		t.Errorf("untagged operations should land in the api feature: %+v", api)
+	}
+
+	if _, err := imp.Import(strings.NewReader("name: not-an-api\n"), "config.yaml"); err == nil {
+		t.Error("expected an error for a non-OpenAPI document")
+	}
+}
+
+func TestGherkinImporter(t *testing.T) {
+	s, err := application.GherkinImporter{}.Import(strings.NewReader(loginFeature), "features/login.feature")
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if len(s.Features) != 1 {
+		t.Fatalf("expected 1 feature, got %d", len(s.Features))
+	}
+	f := s.Features[0]
+	if f.ID != "login" || f.Description != "Users sign in with email." || f.Source.Line != 2 {
+		t.Errorf("unexpected feature: %+v", f)
+	}
+	if len(f.Requirements) != 2 {
+		t.Fatalf("expected 2 scenarios, got %d", len(f.Requirements))
+	}
+
+	ok := f.Requirements[0]
+	want := []string{"Given a registered user", "When they submit valid credentials", "Then they see the dashboard"}
+	if ok.ID != "successful-login" || ok.Source.Line != 8 || strings.Join(ok.AcceptanceCriteria, "|") != strings.Join(want, "|") {
+		t.Errorf("unexpected scenario: %+v", ok)
+	}
+	if locked := f.Requirements[1]; locked.ID != "locked-account" || len(locked.AcceptanceCriteria) != 3 {
+		t.Errorf("unexpected outline: %+v", locked)
+	}
+}
+
+func TestADRImporter(t *testing.T) {
+	imp := application.ADRImporter{}
+	if !imp.Match("docs/adr/0003-use-postgresql.md") || imp.Match("docs/guide.md") {
+		t.Fatal("unexpected Match result")
+	}
+	if imp.Match("decisions/archive/notes.md") || imp.Match("adr/tools/README.md") {
+		t.Error("only files directly in an ADR directory are records")
+	}
+
+	s, err := imp.Import(strings.NewReader(acceptedADR), "docs/adr/0003-use-postgresql.md")
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if len(s.Constraints) != 1 {
+		t.Fatalf("expected 1 constraint, got %+v", s.Constraints)
+	}
+	c := s.Constraints[0]
+	if c.ID != "adr-0003-use-postgresql" || c.Description != "3. Use PostgreSQL: We store all data in PostgreSQL." {
+		t.Errorf("unexpected constraint: %+v", c)
+	}
+	if c.Source.Doc != "docs/adr/0003-use-postgresql.md" || c.Source.Line != 1 {
+		t.Errorf("unexpected source: %+v", c.Source)
+	}
+
+	proposed := "---\nstatus: proposed\n---\n# Use Kafka\n"
+	s, err = imp.Import(strings.NewReader(proposed), "docs/adr/0004-use-kafka.md")
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if len(s.Constraints) != 0 {
+		t.Errorf("proposed ADRs must not become constraints: %+v", s.Constraints)
+	}
+}
+
+func TestSpecService_AnalyzeDirectory_MixedSources(t *testing.T) {
+	tempDir := t.TempDir()
+	repo := storage.NewFilesystemRepository(tempDir)
+	if err := repo.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	service := application.NewSpecService(repo)
+
+	files := map[string]string{
+		"docs/adr/0003-use-postgresql.md": acceptedADR,
+		"features/login.feature":          loginFeature,
+		"api/openapi.yaml":                petstoreOpenAPI,
+		"config/settings.yaml":            "debug: true\n",
+		".github/workflows/ci.yml":        "on: push\n",
+	}
+	for name, body := range files {
+		path := filepath.Join(tempDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s, err := service.AnalyzeDirectory(tempDir)
+	if err != nil {
+		t.Fatalf("AnalyzeDirectory: %v", err)
+	}
+	var ids []string
+	for _, f := range s.Features {
+		ids = append(ids, f.ID)
+	}
+	if got := strings.Join(ids, ","); got != "pets,api,login" {
+		t.Errorf("features = %s", got)
+	}
+	if len(s.Constraints) != 1 || s.Constraints[0].ID != "adr-0003-use-postgresql" {
+		t.Errorf("constraints = %+v", s.Constraints)
+	}
+}
+
+type csvImporter struct{}
+
+func (csvImporter) Name() string           { return "csv" }
+func (csvImporter) Match(path string) bool { return strings.HasSuffix(path, ".csv") }
+func (csvImporter) Import(r io.Reader, doc string) (*spec.ProductSpec, error) {
+	return &spec.ProductSpec{ID: "csv", Features: []spec.Feature{{ID: "from-csv", Title: "From CSV", Source: spec.Source{Doc: doc, Line: 1}}}}, nil
+}
+
+func TestSpecService_RegisterImporter(t *testing.T) {
+	repo := &MockRepo{}
+	service := application.NewSpecService(repo)
+	path := filepath.Join(t.TempDir(), "backlog.csv")
+	if err := os.WriteFile(path, []byte("id,title\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.ImportFile(path); err == nil {
+		t.Fatal("expected an error before the importer is registered")
+	}
+	service.RegisterImporter(csvImporter{})
+	if imp := service.Importer(path); imp == nil || imp.Name() != "csv" {
+		t.Fatalf("Importer(%s) = %v", path, imp)
+	}
+	s, err := service.ImportFile(path)
+	if err != nil {
+		t.Fatalf("ImportFile: %v", err)
+	}
+	if repo.Spec != s || s.Features[0].ID != "from-csv" {
+		t.Errorf("unexpected imported spec: %+v", s)
+	}
+}
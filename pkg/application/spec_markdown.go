@@ -0,0 +1,344 @@
+package application
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// specFrontMatter is the YAML metadata accepted at the top of a document and
+// directly under a feature or requirement heading. Priority and estimate
+// cascade from document to feature to requirement; a feature's depends_on
+// applies to its requirements that declare none.
+type specFrontMatter struct {
+	ID          string   `yaml:"id"`
+	Title       string   `yaml:"title"`
+	Version     string   `yaml:"version"`
+	Description string   `yaml:"description"`
+	Priority    string   `yaml:"priority"`
+	Estimate    string   `yaml:"estimate"`
+	DependsOn   []string `yaml:"depends_on"`
+	Files       []string `yaml:"files"`
+}
+
+var (
+	headingPattern  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	listItemPattern = regexp.MustCompile(`^(\s*)(?:[-*+]|\d+[.)])\s+(?:\[[ xX]\]\s+)?(.*)$`)
+	criteriaPattern = regexp.MustCompile(`(?i)^(?:\*\*|__)?acceptance criteria:?(?:\*\*|__)?:?$`)
+)
+
+// ParseMarkdownSpec deterministically converts a markdown document into a
+// ProductSpec:
+//
+//   - "#" is the spec title, "##" a feature, "###" a requirement;
+//   - top-level bullet or checkbox items directly under a feature are
+//     requirements too;
+//   - an "Acceptance criteria" label or heading starts a list of criteria for
+//     the current requirement;
+//   - a "---" delimited YAML block at the top of the document or directly
+//     under a heading sets id, priority, estimate, depends_on and files.
+//
+// Every feature and requirement records its source line in doc.
+func ParseMarkdownSpec(r io.Reader, doc string) (*spec.ProductSpec, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+
+	p := &markdownParser{
+		doc:   doc,
+		lines: lines,
+		ids:   make(requirementIDs),
+		feats: make(requirementIDs),
+		spec: &spec.ProductSpec{
+			ID:          "imported-spec",
+			Version:     "0.1.0",
+			Constraints: []spec.Constraint{},
+			Features:    []spec.Feature{},
+		},
+	}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	return p.spec, nil
+}
+
+type markdownParser struct {
+	doc   string
+	lines []string
+	spec  *spec.ProductSpec
+	ids   requirementIDs
+	feats requirementIDs // feature IDs, numbered when headings repeat
+
+	docMeta     specFrontMatter
+	featureMeta specFrontMatter
+
+	inFeature     bool // a "##" section is open
+	inRequirement bool // a "###" section is open
+	inBulletReq   bool // the last top-level bullet became a requirement
+	inCriteria    bool // list items are acceptance criteria
+	inFence       bool
+}
+
+func (p *markdownParser) parse() error {
+	i := 0
+	if len(p.lines) > 0 && strings.TrimSpace(p.lines[0]) == "---" {
+		next, err := p.readFrontMatter(0, &p.docMeta, true)
+		if err != nil {
+			return err
+		}
+		i = next
+		p.applyDocMeta()
+	}
+
+	for ; i < len(p.lines); i++ {
+		raw := p.lines[i]
+		line := strings.TrimSpace(raw)
+		lineNum := i + 1
+
+		if strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~") {
+			p.inFence = !p.inFence
+			p.appendText(raw)
+			continue
+		}
+		if p.inFence {
+			p.appendText(raw)
+			continue
+		}
+
+		if m := headingPattern.FindStringSubmatch(line); m != nil {
+			level, title := len(m[1]), m[2]
+			switch level {
+			case 1:
+				if p.spec.Title == "" {
+					p.spec.Title = title
+				}
+			case 2:
+				i = p.startFeature(title, lineNum, i)
+			case 3:
+				i = p.startRequirement(title, lineNum, i)
+			default:
+				if criteriaPattern.MatchString(title) && p.currentRequirement() != nil {
+					p.inCriteria = true
+				} else {
+					p.inCriteria = false
+					p.appendText(line)
+				}
+			}
+			continue
+		}
+
+		if criteriaPattern.MatchString(line) && p.currentRequirement() != nil {
+			p.inCriteria = true
+			continue
+		}
+
+		if m := listItemPattern.FindStringSubmatch(raw); m != nil {
+			p.addListItem(len(m[1]), strings.TrimSpace(m[2]), lineNum)
+			continue
+		}
+
+		if line == "" {
+			p.appendText("")
+			continue
+		}
+		p.inCriteria = false
+		p.inBulletReq = false
+		p.appendText(line)
+	}
+
+	p.finish()
+	return nil
+}
+
+func (p *markdownParser) startFeature(title string, lineNum, i int) int {
+	p.featureMeta = specFrontMatter{}
+	next, _ := p.readFrontMatter(i+1, &p.featureMeta, false)
+	p.openFeature(title, lineNum)
+	return next - 1
+}
+
+func (p *markdownParser) openFeature(title string, lineNum int) {
+	p.inFeature, p.inRequirement, p.inBulletReq, p.inCriteria = true, false, false, false
+	id := p.featureMeta.ID
+	if id == "" {
+		id = p.feats.numbered(slugify(title))
+	}
+	p.feats[id] = true
+	p.spec.Features = append(p.spec.Features, spec.Feature{
+		ID:     id,
+		Title:  title,
+		Source: spec.Source{Doc: p.doc, Line: lineNum},
+		Files:  p.featureMeta.Files,
+	})
+}
+
+func (p *markdownParser) startRequirement(title string, lineNum, i int) int {
+	if !p.inFeature {
+		// A requirement before any feature heading belongs to an implicit
+		// feature named after the document.
+		name := p.spec.Title
+		if name == "" {
+			name = "General"
+		}
+		p.openFeature(name, lineNum)
+	}
+	p.inRequirement, p.inBulletReq, p.inCriteria = true, false, false
+
+	var meta specFrontMatter
+	next, _ := p.readFrontMatter(i+1, &meta, false)
+	p.addRequirement(title, lineNum, meta)
+	return next - 1
+}
+
+func (p *markdownParser) addListItem(indent int, text string, lineNum int) {
+	if text == "" {
+		return
+	}
+	if p.inCriteria {
+		if req := p.currentRequirement(); req != nil {
+			req.AcceptanceCriteria = append(req.AcceptanceCriteria, text)
+			return
+		}
+	}
+	switch {
+	case p.inRequirement:
+		p.appendText(fmt.Sprintf("%s- %s", strings.Repeat(" ", indent), text))
+	case p.inFeature && indent == 0:
+		p.addRequirement(text, lineNum, specFrontMatter{})
+		p.inBulletReq = true
+	default:
+		p.appendText("- " + text)
+	}
+}
+
+func (p *markdownParser) addRequirement(title string, lineNum int, meta specFrontMatter) {
+	f := &p.spec.Features[len(p.spec.Features)-1]
+	id := meta.ID
+	if id == "" {
+		id = p.ids.derive(f.ID, title)
+	}
+	p.ids[id] = true
+
+	req := spec.Requirement{
+		ID:        id,
+		Title:     title,
+		Priority:  firstNonEmpty(meta.Priority, p.featureMeta.Priority, p.docMeta.Priority),
+		Estimate:  firstNonEmpty(meta.Estimate, p.featureMeta.Estimate, p.docMeta.Estimate),
+		DependsOn: meta.DependsOn,
+		Files:     meta.Files,
+		Source:    spec.Source{Doc: p.doc, Line: lineNum},
+	}
+	if req.DependsOn == nil {
+		req.DependsOn = []string{}
+		for _, dep := range p.featureMeta.DependsOn {
+			if dep != id {
+				req.DependsOn = append(req.DependsOn, dep)
+			}
+		}
+	}
+	f.Requirements = append(f.Requirements, req)
+}
+
+// readFrontMatter parses a "---" delimited YAML block starting at line
+// index start. It returns the index of the first line after the block, or
+// start when there is no block. Unless strict, a block that is not valid
+// metadata is taken to be a pair of horizontal rules and left in place.
+func (p *markdownParser) readFrontMatter(start int, into *specFrontMatter, strict bool) (int, error) {
+	if start >= len(p.lines) || strings.TrimSpace(p.lines[start]) != "---" {
+		return start, nil
+	}
+	for end := start + 1; end < len(p.lines); end++ {
+		line := strings.TrimSpace(p.lines[end])
+		if headingPattern.MatchString(line) {
+			return start, nil // a horizontal rule, not front-matter
+		}
+		if line != "---" {
+			continue
+		}
+		body := strings.Join(p.lines[start+1:end], "\n")
+		var meta specFrontMatter
+		if err := yaml.Unmarshal([]byte(body), &meta); err != nil {
+			if !strict {
+				return start, nil
+			}
+			return start, fmt.Errorf("%s:%d: invalid front-matter: %w", p.doc, start+1, err)
+		}
+		*into = meta
+		return end + 1, nil
+	}
+	return start, nil
+}
+
+func (p *markdownParser) applyDocMeta() {
+	if p.docMeta.ID != "" {
+		p.spec.ID = p.docMeta.ID
+	}
+	if p.docMeta.Title != "" {
+		p.spec.Title = p.docMeta.Title
+	}
+	if p.docMeta.Version != "" {
+		p.spec.Version = p.docMeta.Version
+	}
+	if p.docMeta.Description != "" {
+		p.spec.Description = p.docMeta.Description
+	}
+}
+
+func (p *markdownParser) currentRequirement() *spec.Requirement {
+	if !p.inRequirement && !p.inBulletReq {
+		return nil
+	}
+	f := &p.spec.Features[len(p.spec.Features)-1]
+	if len(f.Requirements) == 0 {
+		return nil
+	}
+	return &f.Requirements[len(f.Requirements)-1]
+}
+
+// appendText adds a line to the description of the innermost open element.
+// Before the first feature only the first non-empty line becomes the spec
+// description.
+func (p *markdownParser) appendText(line string) {
+	switch {
+	case p.inRequirement || p.inBulletReq:
+		req := p.currentRequirement()
+		req.Description += line + "\n"
+	case p.inFeature:
+		f := &p.spec.Features[len(p.spec.Features)-1]
+		f.Description += line + "\n"
+	default:
+		if p.spec.Description == "" && strings.TrimSpace(line) != "" {
+			p.spec.Description = strings.TrimSpace(line)
+		}
+	}
+}
+
+func (p *markdownParser) finish() {
+	for i := range p.spec.Features {
+		f := &p.spec.Features[i]
+		f.Description = strings.TrimSpace(f.Description)
+		for j := range f.Requirements {
+			f.Requirements[j].Description = strings.TrimSpace(f.Requirements[j].Description)
+		}
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
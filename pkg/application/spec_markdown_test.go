@@ -0,0 +1,197 @@
+package application_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/roady/pkg/application"
+)
+
+const structuredSpecDoc = `---
+id: shop
+version: 1.2.0
+priority: medium
+---
+# Shop
+
+Online store.
+
+## Checkout
+---
+priority: high
+files: [internal/checkout/**]
+---
+Paying for a cart.
+
+### Card payments
+---
+id: card-pay
+estimate: 3d
+depends_on: [cart]
+---
+Accept Visa and Mastercard.
+
+**Acceptance criteria:**
+- [ ] Declined cards show an error
+- [x] Receipts are emailed
+
+### Refunds
+Partial refunds are allowed.
+
+## Cart
+- [ ] Add items
+  - quantity defaults to 1
+- [x] Remove items
+
+Acceptance criteria:
+1. Removing the last item empties the cart
+
+` + "```" + `
+### not a requirement
+` + "```" + `
+`
+
+func TestParseMarkdownSpec_Structured(t *testing.T) {
+	s, err := application.ParseMarkdownSpec(strings.NewReader(structuredSpecDoc), "docs/shop.md")
+	if err != nil {
+		t.Fatalf("ParseMarkdownSpec: %v", err)
+	}
+
+	if s.ID != "shop" || s.Version != "1.2.0" || s.Title != "Shop" || s.Description != "Online store." {
+		t.Fatalf("unexpected spec header: %+v", s)
+	}
+	if len(s.Features) != 2 {
+		t.Fatalf("expected 2 features, got %d", len(s.Features))
+	}
+
+	checkout := s.Features[0]
+	if checkout.ID != "checkout" || checkout.Source.Line != 10 || checkout.Description != "Paying for a cart." {
+		t.Errorf("unexpected checkout feature: %+v", checkout)
+	}
+	if len(checkout.Files) != 1 || checkout.Files[0] != "internal/checkout/**" {
+		t.Errorf("feature files = %v", checkout.Files)
+	}
+	if len(checkout.Requirements) != 2 {
+		t.Fatalf("expected 2 checkout requirements, got %d", len(checkout.Requirements))
+	}
+
+	card := checkout.Requirements[0]
+	if card.ID != "card-pay" || card.Priority != "high" || card.Estimate != "3d" {
+		t.Errorf("unexpected card requirement: %+v", card)
+	}
+	if len(card.DependsOn) != 1 || card.DependsOn[0] != "cart" {
+		t.Errorf("depends_on = %v", card.DependsOn)
+	}
+	if card.Source.Doc != "docs/shop.md" || card.Source.Line != 17 {
+		t.Errorf("card source = %+v", card.Source)
+	}
+	if card.Description != "Accept Visa and Mastercard." {
+		t.Errorf("card description = %q", card.Description)
+	}
+	if len(card.AcceptanceCriteria) != 2 || card.AcceptanceCriteria[0] != "Declined cards show an error" {
+		t.Errorf("card criteria = %v", card.AcceptanceCriteria)
+	}
+
+	refunds := checkout.Requirements[1]
+	if refunds.ID != "refunds" || refunds.Priority != "high" || refunds.Source.Line != 29 {
+		t.Errorf("unexpected refunds requirement: %+v", refunds)
+	}
+
+	cart := s.Features[1]
+	if len(cart.Requirements) != 2 {
+		t.Fatalf("expected 2 cart requirements, got %+v", cart.Requirements)
+	}
+	add, remove := cart.Requirements[0], cart.Requirements[1]
+	if add.ID != "add-items" || add.Priority != "medium" || add.Source.Line != 33 {
+		t.Errorf("unexpected add requirement: %+v", add)
+	}
+	if add.Description != "- quantity defaults to 1" {
+		t.Errorf("add description = %q", add.Description)
+	}
+	if remove.ID != "remove-items" || len(remove.AcceptanceCriteria) != 1 {
+		t.Errorf("unexpected remove requirement: %+v", remove)
+	}
+	if !strings.Contains(remove.Description, "not a requirement") {
+		t.Errorf("fenced heading should stay in the description, got %q", remove.Description)
+	}
+}
+
+func TestParseMarkdownSpec_FeatureDependsOn(t *testing.T) {
+	doc := `## Checkout
+---
+depends_on: [cart, "@auth:task-login"]
+---
+### Card payments
+### Refunds
+---
+depends_on: [card-payments]
+---
+### Cart
+`
+	s, err := application.ParseMarkdownSpec(strings.NewReader(doc), "shop.md")
+	if err != nil {
+		t.Fatalf("ParseMarkdownSpec: %v", err)
+	}
+	reqs := s.Features[0].Requirements
+	for i, want := range []string{"cart,@auth:task-login", "card-payments", "@auth:task-login"} {
+		if got := strings.Join(reqs[i].DependsOn, ","); got != want {
+			t.Errorf("%s depends_on = %q, want %q", reqs[i].ID, got, want)
+		}
+	}
+}
+
+func TestParseMarkdownSpec_UniqueRequirementIDs(t *testing.T) {
+	doc := "## Web\n- Login\n- Login\n\n## Mobile\n- Login\n"
+	s, err := application.ParseMarkdownSpec(strings.NewReader(doc), "ids.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, f := range s.Features {
+		for _, r := range f.Requirements {
+			ids = append(ids, r.ID)
+		}
+	}
+	want := "login,web-login,mobile-login"
+	if got := strings.Join(ids, ","); got != want {
+		t.Errorf("ids = %s, want %s", got, want)
+	}
+}
+
+func TestParseMarkdownSpec_UniqueFeatureIDs(t *testing.T) {
+	doc := "## Auth\n- Login\n\n## Auth\n- Logout\n\n## Auth!\n- Reset\n"
+	s, err := application.ParseMarkdownSpec(strings.NewReader(doc), "features.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, f := range s.Features {
+		ids = append(ids, f.ID)
+	}
+	want := "auth,auth-2,auth-3"
+	if got := strings.Join(ids, ","); got != want {
+		t.Errorf("feature ids = %s, want %s", got, want)
+	}
+}
+
+func TestParseMarkdownSpec_ImplicitFeatureAndRules(t *testing.T) {
+	doc := "# Notes\n\n### Export CSV\n---\n\nText between rules.\n\n---\n"
+	s, err := application.ParseMarkdownSpec(strings.NewReader(doc), "notes.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Features) != 1 || s.Features[0].ID != "notes" {
+		t.Fatalf("expected implicit notes feature, got %+v", s.Features)
+	}
+	req := s.Features[0].Requirements[0]
+	if req.ID != "export-csv" || !strings.Contains(req.Description, "Text between rules.") {
+		t.Errorf("horizontal rules should not be read as front-matter: %+v", req)
+	}
+}
+
+func TestParseMarkdownSpec_InvalidFrontMatter(t *testing.T) {
+	doc := "---\nid: [unterminated\n---\n# Title\n"
+	if _, err := application.ParseMarkdownSpec(strings.NewReader(doc), "bad.md"); err == nil {
+		t.Fatal("expected an error for invalid document front-matter")
+	}
+}
@@ -0,0 +1,187 @@
+package application
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// openAPIMethods are the path item keys that hold operations, in the order
+// the OpenAPI specification lists them.
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// camelBoundary splits camelCase operation IDs such as listPets.
+var camelBoundary = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+// openAPIVersionKey finds the top-level openapi or swagger key of a YAML or
+// JSON document.
+var openAPIVersionKey = regexp.MustCompile(`(?m)^["']?(?:openapi|swagger)["']?\s*:|"(?:openapi|swagger)"\s*:`)
+
+// openAPISniffSize is how much of a file Match reads looking for the
+// version key.
+const openAPISniffSize = 64 * 1024
+
+// OpenAPIImporter turns OpenAPI 3 and Swagger 2 documents (YAML or JSON)
+// into a spec with one feature per tag and one requirement per operation.
+// Untagged operations are grouped under an "api" feature.
+type OpenAPIImporter struct{}
+
+func (OpenAPIImporter) Name() string { return "openapi" }
+
+// Match accepts YAML and JSON files that declare an openapi or swagger
+// version near the top, leaving other configuration files to later
+// importers.
+func (OpenAPIImporter) Match(path string) bool {
+	if !hasExt(path, ".yaml", ".yml", ".json") {
+		return false
+	}
+	// #nosec G304 -- path is a spec document chosen by the user
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = f.Close() }()
+	head, err := io.ReadAll(io.LimitReader(f, openAPISniffSize))
+	if err != nil {
+		return false
+	}
+	return openAPIVersionKey.Match(head)
+}
+
+func (OpenAPIImporter) Import(r io.Reader, doc string) (*spec.ProductSpec, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("%s: invalid OpenAPI document: %w", doc, err)
+	}
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s: not an OpenAPI document", doc)
+	}
+	top := root.Content[0]
+	if mappingValue(top, "openapi") == nil && mappingValue(top, "swagger") == nil {
+		return nil, fmt.Errorf("%s: not an OpenAPI document (no openapi or swagger version)", doc)
+	}
+
+	productSpec := &spec.ProductSpec{
+		ID:          "imported-spec",
+		Version:     "0.1.0",
+		Constraints: []spec.Constraint{},
+		Features:    []spec.Feature{},
+	}
+	if info := mappingValue(top, "info"); info != nil {
+		productSpec.Title = scalarValue(info, "title")
+		productSpec.Description = strings.TrimSpace(scalarValue(info, "description"))
+		if id := slugify(productSpec.Title); id != "" {
+			productSpec.ID = id
+		}
+		if v := scalarValue(info, "version"); v != "" {
+			productSpec.Version = v
+		}
+	}
+
+	features := make(map[string]int)
+	addFeature := func(name, description string, line int) int {
+		id := slugify(name)
+		if i, ok := features[id]; ok {
+			return i
+		}
+		features[id] = len(productSpec.Features)
+		productSpec.Features = append(productSpec.Features, spec.Feature{
+			ID:          id,
+			Title:       name,
+			Description: strings.TrimSpace(description),
+			Source:      spec.Source{Doc: doc, Line: line},
+		})
+		return features[id]
+	}
+	// Declared tags fix the feature order and carry descriptions.
+	if tags := mappingValue(top, "tags"); tags != nil && tags.Kind == yaml.SequenceNode {
+		for _, tag := range tags.Content {
+			if name := scalarValue(tag, "name"); name != "" {
+				addFeature(name, scalarValue(tag, "description"), tag.Line)
+			}
+		}
+	}
+
+	ids := make(requirementIDs)
+	paths := mappingValue(top, "paths")
+	if paths == nil || paths.Kind != yaml.MappingNode {
+		return productSpec, nil
+	}
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		path, item := paths.Content[i].Value, paths.Content[i+1]
+		for _, method := range openAPIMethods {
+			key, op := mappingEntry(item, method)
+			if op == nil || op.Kind != yaml.MappingNode {
+				continue
+			}
+
+			tag := "API"
+			if tags := mappingValue(op, "tags"); tags != nil && tags.Kind == yaml.SequenceNode && len(tags.Content) > 0 {
+				tag = tags.Content[0].Value
+			}
+			f := &productSpec.Features[addFeature(tag, "", key.Line)]
+
+			signature := fmt.Sprintf("%s %s", strings.ToUpper(method), path)
+			title := scalarValue(op, "summary")
+			if title == "" {
+				title = signature
+			}
+			description := signature
+			if d := strings.TrimSpace(scalarValue(op, "description")); d != "" {
+				description += "\n\n" + d
+			}
+
+			var id string
+			if opID := slugify(camelBoundary.ReplaceAllString(scalarValue(op, "operationId"), "$1-$2")); opID != "" && !ids[opID] {
+				id = opID
+			} else {
+				id = ids.derive(f.ID, method+" "+path)
+			}
+			ids[id] = true
+
+			f.Requirements = append(f.Requirements, spec.Requirement{
+				ID:          id,
+				Title:       title,
+				Description: description,
+				DependsOn:   []string{},
+				Source:      spec.Source{Doc: doc, Line: key.Line},
+			})
+		}
+	}
+	return productSpec, nil
+}
+
+// mappingEntry returns the key and value nodes for key in a mapping node.
+func mappingEntry(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	_, v := mappingEntry(node, key)
+	return v
+}
+
+func scalarValue(node *yaml.Node, key string) string {
+	v := mappingValue(node, key)
+	if v == nil || v.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return v.Value
+}
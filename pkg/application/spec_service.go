@@ -1,27 +1,65 @@
 package application
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/felixgeelhaar/roady/pkg/domain"
 	"github.com/felixgeelhaar/roady/pkg/domain/spec"
+	"github.com/felixgeelhaar/roady/pkg/storage"
+	"gopkg.in/yaml.v3"
 )
 
+// DiffAgainstLock is the Diff baseline that compares with spec.lock.json.
+const DiffAgainstLock = "lock"
+
 type SpecService struct {
-	repo domain.WorkspaceRepository
+	repo      domain.WorkspaceRepository
+	importers []spec.Importer
 }
 
 func NewSpecService(repo domain.WorkspaceRepository) *SpecService {
-	return &SpecService{repo: repo}
+	return &SpecService{repo: repo, importers: DefaultSpecImporters()}
+}
+
+// RegisterImporter adds a spec importer that is tried before the built-in
+// ones, so it can take over file types they already handle.
+func (s *SpecService) RegisterImporter(imp spec.Importer) {
+	s.importers = append([]spec.Importer{imp}, s.importers...)
+}
+
+// Importer returns the importer that handles path, or nil.
+func (s *SpecService) Importer(path string) spec.Importer {
+	for _, imp := range s.importers {
+		if imp.Match(path) {
+			return imp
+		}
+	}
+	return nil
 }
 
 // ImportFromMarkdown reads a markdown file and converts it into a ProductSpec.
 func (s *SpecService) ImportFromMarkdown(path string) (*spec.ProductSpec, error) {
-	productSpec, err := s.parseMarkdownFile(path)
+	return s.importWith(MarkdownImporter{}, path)
+}
+
+// ImportFile converts a single document into a ProductSpec using the
+// importer selected by its path (markdown, ADR, Gherkin or OpenAPI).
+func (s *SpecService) ImportFile(path string) (*spec.ProductSpec, error) {
+	imp := s.Importer(path)
+	if imp == nil {
+		return nil, fmt.Errorf("no spec importer handles %s", path)
+	}
+	return s.importWith(imp, path)
+}
+
+func (s *SpecService) importWith(imp spec.Importer, path string) (*spec.ProductSpec, error) {
+	productSpec, err := importFile(imp, path)
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +71,8 @@ func (s *SpecService) ImportFromMarkdown(path string) (*spec.ProductSpec, error)
 	return productSpec, nil
 }
 
-// AnalyzeDirectory crawls a directory for markdown files and merges them into a single Spec.
+// AnalyzeDirectory crawls a directory for documents a registered importer
+// handles and merges them into a single Spec.
 func (s *SpecService) AnalyzeDirectory(root string) (*spec.ProductSpec, error) {
 	mergedSpec := &spec.ProductSpec{
 		ID:          "analyzed-spec",
@@ -46,15 +85,17 @@ func (s *SpecService) AnalyzeDirectory(root string) (*spec.ProductSpec, error) {
 		if err != nil {
 			return err
 		}
-		if !info.IsDir() && (strings.HasSuffix(info.Name(), ".md") || strings.HasSuffix(info.Name(), ".markdown")) {
-			// Skip roady internal docs or hidden files
-			if strings.Contains(path, ".roady") || strings.HasPrefix(info.Name(), ".") {
-				return nil
+		// Skip roady internal docs and hidden files or directories
+		if path != root && strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
 			}
-
-			fileSpec, err := s.parseMarkdownFile(path)
+			return nil
+		}
+		if imp := s.Importer(path); !info.IsDir() && imp != nil {
+			fileSpec, err := importFile(imp, path)
 			if err != nil {
-				return nil // Skip files that fail to parse
+				return nil // Skip files that fail to parse or are not in the importer's format
 			}
 
 			// 1. Merge Title/Description
@@ -74,8 +115,12 @@ func (s *SpecService) AnalyzeDirectory(root string) (*spec.ProductSpec, error) {
 						if !strings.Contains(existingFeat.Description, newFeat.Description) {
 							mergedSpec.Features[i].Description += "\n\n---\n\n" + newFeat.Description
 						}
-						// Merge Requirements
-						mergedSpec.Features[i].Requirements = append(mergedSpec.Features[i].Requirements, newFeat.Requirements...)
+						// Merge Requirements, keeping the first definition of an ID
+						for _, req := range newFeat.Requirements {
+							if !hasRequirement(mergedSpec.Features[i], req.ID) {
+								mergedSpec.Features[i].Requirements = append(mergedSpec.Features[i].Requirements, req)
+							}
+						}
 						found = true
 						break
 					}
@@ -85,8 +130,12 @@ func (s *SpecService) AnalyzeDirectory(root string) (*spec.ProductSpec, error) {
 				}
 			}
 
-			// 3. Merge Constraints
-			mergedSpec.Constraints = append(mergedSpec.Constraints, fileSpec.Constraints...)
+			// 3. Merge Constraints, keeping the first definition of an ID
+			for _, c := range fileSpec.Constraints {
+				if !hasConstraint(mergedSpec.Constraints, c.ID) {
+					mergedSpec.Constraints = append(mergedSpec.Constraints, c)
+				}
+			}
 		}
 		return nil
 	})
@@ -106,7 +155,7 @@ func (s *SpecService) AnalyzeDirectory(root string) (*spec.ProductSpec, error) {
 	return mergedSpec, nil
 }
 
-func (s *SpecService) parseMarkdownFile(path string) (*spec.ProductSpec, error) {
+func importFile(imp spec.Importer, path string) (*spec.ProductSpec, error) {
 	cleanPath := filepath.Clean(path)
 	file, err := os.Open(cleanPath)
 	if err != nil {
@@ -114,65 +163,90 @@ func (s *SpecService) parseMarkdownFile(path string) (*spec.ProductSpec, error)
 	}
 	defer file.Close() //nolint:errcheck // best-effort close on read path
 
-	scanner := bufio.NewScanner(file)
+	return imp.Import(file, cleanPath)
+}
 
-	productSpec := &spec.ProductSpec{
-		ID:          "imported-spec",
-		Version:     "0.1.0",
-		Constraints: []spec.Constraint{},
-		Features:    []spec.Feature{},
+func hasRequirement(f spec.Feature, id string) bool {
+	for _, req := range f.Requirements {
+		if req.ID == id {
+			return true
+		}
 	}
+	return false
+}
 
-	var currentFeature *spec.Feature
-	var descriptionBuilder strings.Builder
-	lineNum := 0
+func hasConstraint(constraints []spec.Constraint, id string) bool {
+	for _, c := range constraints {
+		if c.ID == id {
+			return true
+		}
+	}
+	return false
+}
 
-	for scanner.Scan() {
-		lineNum++
-		line := strings.TrimSpace(scanner.Text())
+func (s *SpecService) GetSpec() (*spec.ProductSpec, error) {
 
-		switch {
-		case strings.HasPrefix(line, "# "):
-			productSpec.Title = strings.TrimSpace(strings.TrimPrefix(line, "# "))
-		case strings.HasPrefix(line, "## "):
-			if currentFeature != nil {
-				currentFeature.Description = strings.TrimSpace(descriptionBuilder.String())
-				productSpec.Features = append(productSpec.Features, *currentFeature)
-				descriptionBuilder.Reset()
-			}
+	return s.repo.LoadSpec()
 
-			title := strings.TrimSpace(strings.TrimPrefix(line, "## "))
-			id := strings.ToLower(strings.ReplaceAll(title, " ", "-"))
-			currentFeature = &spec.Feature{
-				ID:     id,
-				Title:  title,
-				Source: spec.Source{Doc: cleanPath, Line: lineNum},
-			}
-		default:
-			if currentFeature != nil {
-				descriptionBuilder.WriteString(line + "\n")
-			} else if productSpec.Description == "" && line != "" {
-				productSpec.Description = line
-			}
-		}
-	}
+}
 
-	if currentFeature != nil {
-		currentFeature.Description = strings.TrimSpace(descriptionBuilder.String())
-		productSpec.Features = append(productSpec.Features, *currentFeature)
+// Diff compares the current spec with a baseline. against is "lock" (or
+// empty) for the spec lock, or any git ref whose committed spec.yaml should
+// serve as the base.
+func (s *SpecService) Diff(ctx context.Context, against string) (*spec.SpecDiff, error) {
+	current, err := s.repo.LoadSpec()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load spec: %w", err)
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading file: %w", err)
+	var base *spec.ProductSpec
+	if against == "" || against == DiffAgainstLock {
+		base, err = s.repo.LoadSpecLock()
+		if err != nil {
+			return nil, fmt.Errorf("no spec lock to diff against (run 'roady drift accept' first): %w", err)
+		}
+	} else {
+		base, err = s.specAtRef(ctx, against)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return productSpec, nil
+	return spec.Diff(base, current), nil
 }
 
-func (s *SpecService) GetSpec() (*spec.ProductSpec, error) {
+// specAtRef loads spec.yaml as committed at the given git ref.
+func (s *SpecService) specAtRef(ctx context.Context, ref string) (*spec.ProductSpec, error) {
+	if strings.HasPrefix(ref, "-") || strings.ContainsAny(ref, " \t\n:") {
+		return nil, fmt.Errorf("invalid git ref %q", ref)
+	}
+	resolver, ok := s.repo.(interface{ ResolvePath(string) (string, error) })
+	if !ok {
+		return nil, fmt.Errorf("spec diff against a git ref requires a filesystem workspace")
+	}
+	path, err := resolver.ResolvePath(storage.SpecFile)
+	if err != nil {
+		return nil, err
+	}
 
-	return s.repo.LoadSpec()
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// #nosec G204 -- ref is validated above and passed as a single argument
+	cmd := exec.CommandContext(ctx, "git", "-C", filepath.Dir(path), "show", ref+":./"+filepath.Base(path))
+	out, err := cmd.Output()
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("git show timed out after 30 seconds")
+		}
+		return nil, fmt.Errorf("failed to read spec at %s: %w", ref, err)
+	}
 
+	var base spec.ProductSpec
+	if err := yaml.Unmarshal(out, &base); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal spec at %s: %w", ref, err)
+	}
+	return &base, nil
 }
 
 // AddFeature programmatically adds a new functional unit and syncs it back to documentation.
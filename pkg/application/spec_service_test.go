@@ -1,7 +1,9 @@
 package application_test
 
 import (
+	"context"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -221,3 +223,68 @@ func TestSpecService_AddFeature(t *testing.T) {
 		t.Fatalf("expected backlog to include feature, got %q", string(content))
 	}
 }
+
+func TestSpecService_Diff_AgainstLock(t *testing.T) {
+	repo := storage.NewFilesystemRepository(t.TempDir())
+	_ = repo.Initialize()
+	service := application.NewSpecService(repo)
+
+	if _, err := service.Diff(context.Background(), ""); err == nil {
+		t.Fatal("expected error without a spec lock")
+	}
+
+	base := &spec.ProductSpec{ID: "p", Title: "P", Features: []spec.Feature{
+		{ID: "f1", Title: "F1", Requirements: []spec.Requirement{{ID: "r1", Title: "R1", Priority: "low"}}},
+	}}
+	_ = repo.SaveSpecLock(base)
+	current := &spec.ProductSpec{ID: "p", Title: "P", Features: []spec.Feature{
+		{ID: "f1", Title: "F1", Requirements: []spec.Requirement{{ID: "r1", Title: "R1", Priority: "high"}}},
+	}}
+	_ = repo.SaveSpec(current)
+
+	d, err := service.Diff(context.Background(), application.DiffAgainstLock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Changes) != 1 || d.Changes[0].Field != "priority" || d.Changes[0].New != "high" {
+		t.Fatalf("unexpected changes: %+v", d.Changes)
+	}
+}
+
+func TestSpecService_Diff_AgainstGitRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	repo := storage.NewFilesystemRepository(dir)
+	_ = repo.Initialize()
+	service := application.NewSpecService(repo)
+
+	_ = repo.SaveSpec(&spec.ProductSpec{ID: "p", Title: "P", Features: []spec.Feature{{ID: "f1", Title: "F1"}}})
+	git("init", "-q")
+	git("add", ".")
+	git("commit", "-q", "-m", "initial")
+
+	_ = repo.SaveSpec(&spec.ProductSpec{ID: "p", Title: "P", Features: []spec.Feature{{ID: "f1", Title: "F1"}, {ID: "f2", Title: "F2"}}})
+
+	d, err := service.Diff(context.Background(), "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Changes) != 1 || d.Changes[0].Kind != spec.ChangeAdded || d.Changes[0].ID != "f2" {
+		t.Fatalf("unexpected changes: %+v", d.Changes)
+	}
+
+	if _, err := service.Diff(context.Background(), "--output=x"); err == nil {
+		t.Error("expected option-like ref to be rejected")
+	}
+}
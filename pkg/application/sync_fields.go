@@ -0,0 +1,154 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	domainPlugin "github.com/felixgeelhaar/roady/pkg/domain/plugin"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
+)
+
+// SyncBaselineRepository stores the field sync baseline of each plugin.
+// Plugin config repositories that implement it enable two-way field sync.
+type SyncBaselineRepository interface {
+	LoadSyncBaseline(name string) (*domainPlugin.SyncBaseline, error)
+	SaveSyncBaseline(name string, baseline *domainPlugin.SyncBaseline) error
+}
+
+// syncFields reconciles Roady's tasks with the external changes returned by
+// Sync, pushes Roady's changes, applies the external ones and records the new
+// baseline. The baseline is only recorded when the push succeeded, so failed
+// changes are pushed again on the next sync.
+func (s *SyncService) syncFields(fs domainPlugin.FieldSyncer, name, provider string, policy domainPlugin.ConflictPolicy, remote []domainPlugin.TaskChange) ([]string, error) {
+	baselines, ok := s.pluginRepo.(SyncBaselineRepository)
+	if !ok {
+		return nil, nil
+	}
+	baseline, err := baselines.LoadSyncBaseline(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sync baseline: %w", err)
+	}
+	if baseline == nil {
+		baseline = domainPlugin.NewSyncBaseline()
+	}
+
+	plan, err := s.repo.LoadPlan()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load plan: %w", err)
+	}
+	state, err := s.repo.LoadState()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state: %w", err)
+	}
+
+	changes := domainPlugin.ReconcileChanges(baseline, plan, state, remote, provider, policy)
+	results := []string{}
+	pushed := true
+	if len(changes.Push) > 0 {
+		res, err := fs.PushChanges(changes.Push)
+		switch {
+		case errors.Is(err, domainPlugin.ErrFieldSyncUnsupported):
+			pushed = false
+		case err != nil:
+			results = append(results, fmt.Sprintf("Field Sync: push failed (%v)", err))
+			pushed = false
+		default:
+			results = append(results, fmt.Sprintf("Field Sync: pushed %d change(s)", len(changes.Push)))
+			for id, ref := range res.LinkUpdates {
+				results = append(results, s.applyLink(id, provider, ref))
+			}
+			for _, e := range res.Errors {
+				results = append(results, fmt.Sprintf("Plugin Error: %s", e))
+			}
+		}
+	}
+
+	for _, c := range changes.Conflicts {
+		results = append(results, fmt.Sprintf("Conflict Task %s %s: roady %q, remote %q; %s wins", c.TaskID, c.Field, c.Roady, c.Remote, c.Winner))
+	}
+	for _, c := range changes.Apply {
+		results = append(results, s.applyChange(c, provider)...)
+	}
+
+	if !pushed {
+		return results, nil
+	}
+	if plan, err = s.repo.LoadPlan(); err != nil {
+		return nil, fmt.Errorf("failed to load plan: %w", err)
+	}
+	if state, err = s.repo.LoadState(); err != nil {
+		return nil, fmt.Errorf("failed to load state: %w", err)
+	}
+	baseline.Record(plan, state, time.Now())
+	if err := baselines.SaveSyncBaseline(name, baseline); err != nil {
+		return nil, fmt.Errorf("failed to save sync baseline: %w", err)
+	}
+	return results, nil
+}
+
+// applyChange applies an external field change and describes the outcome.
+func (s *SyncService) applyChange(c domainPlugin.TaskChange, provider string) []string {
+	var results []string
+
+	if c.Kind == domainPlugin.ChangeCreate {
+		task := planning.Task{ID: c.TaskID, Title: *c.Title}
+		if c.Description != nil {
+			task.Description = *c.Description
+		}
+		if c.Priority != nil {
+			task.Priority = *c.Priority
+		}
+		if err := s.taskSvc.AddTask(context.Background(), task, "sync-plugin"); err != nil {
+			return []string{fmt.Sprintf("Create Task %s: error (%v)", c.TaskID, err)}
+		}
+		results = append(results, fmt.Sprintf("Create Task %s: %s", c.TaskID, task.Title))
+		if c.ExternalRef != nil {
+			results = append(results, s.applyLink(c.TaskID, provider, *c.ExternalRef))
+		}
+	} else if c.Title != nil || c.Description != nil || c.Priority != nil {
+		edit := TaskEdit{Title: c.Title, Description: c.Description, Priority: c.Priority}
+		fields := domainPlugin.TaskChange{Title: c.Title, Description: c.Description, Priority: c.Priority}.Fields()
+		if err := s.taskSvc.EditTask(context.Background(), c.TaskID, "sync-plugin", edit); err != nil {
+			results = append(results, fmt.Sprintf("Edit Task %s: error (%v)", c.TaskID, err))
+		} else {
+			results = append(results, fmt.Sprintf("Edit Task %s: %s", c.TaskID, strings.Join(fields, ", ")))
+		}
+	}
+
+	if c.Owner != nil {
+		if err := s.taskSvc.AssignTask(team.WithActor(context.Background(), "sync-plugin"), c.TaskID, *c.Owner); err != nil {
+			results = append(results, fmt.Sprintf("Assign Task %s: error (%v)", c.TaskID, err))
+		} else {
+			results = append(results, fmt.Sprintf("Assign Task %s: %s", c.TaskID, *c.Owner))
+		}
+	}
+
+	added := 0
+	for _, comment := range c.Comments {
+		if comment.Provider == "" {
+			comment.Provider = provider
+		}
+		ok, err := s.taskSvc.CommentTask(c.TaskID, comment)
+		if err != nil {
+			results = append(results, fmt.Sprintf("Comment Task %s: error (%v)", c.TaskID, err))
+			continue
+		}
+		if ok {
+			added++
+		}
+	}
+	if added > 0 {
+		results = append(results, fmt.Sprintf("Comment Task %s: %d new comment(s)", c.TaskID, added))
+	}
+
+	if c.Kind == domainPlugin.ChangeClose {
+		if res := s.applyStatus(c.TaskID, planning.StatusDone); res != "" {
+			results = append(results, res)
+		}
+	}
+	return results
+}
@@ -1,10 +1,13 @@
 package application
 
 import (
+	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/felixgeelhaar/roady/pkg/domain"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	domainPlugin "github.com/felixgeelhaar/roady/pkg/domain/plugin"
 	"github.com/felixgeelhaar/roady/pkg/plugin"
 )
@@ -42,7 +45,7 @@ func (s *SyncService) SyncWithNamedPlugin(name string) ([]string, error) {
 		return nil, fmt.Errorf("failed to load plugin config '%s': %w", name, err)
 	}
 
-	return s.SyncWithPluginConfig(cfg.Binary, cfg.Config)
+	return s.syncWithPlugin(name, cfg.Binary, cfg.Config, cfg.ConflictPolicy)
 }
 
 // SyncWithPlugin syncs using a plugin binary path (uses empty config, relies on env vars)
@@ -52,6 +55,11 @@ func (s *SyncService) SyncWithPlugin(pluginPath string) ([]string, error) {
 
 // SyncWithPluginConfig syncs using a plugin binary path with explicit configuration
 func (s *SyncService) SyncWithPluginConfig(pluginPath string, config map[string]string) ([]string, error) {
+	return s.syncWithPlugin(filepath.Base(pluginPath), pluginPath, config, "")
+}
+
+// syncWithPlugin runs a sync; name keys the plugin's field sync baseline.
+func (s *SyncService) syncWithPlugin(name, pluginPath string, config map[string]string, policy domainPlugin.ConflictPolicy) ([]string, error) {
 	loader := plugin.NewLoader()
 	defer loader.Cleanup()
 
@@ -80,42 +88,17 @@ func (s *SyncService) SyncWithPluginConfig(pluginPath string, config map[string]
 	}
 
 	results := []string{}
+	provider := providerForPlugin(pluginPath)
 
 	// 1. Handle Link Updates
-	provider := "external"
-	switch {
-	case strings.Contains(pluginPath, "linear"):
-		provider = "linear"
-	case strings.Contains(pluginPath, "jira"):
-		provider = "jira"
-	case strings.Contains(pluginPath, "github"):
-		provider = "github"
-	}
-
 	for id, ref := range result.LinkUpdates {
-		if err := s.taskSvc.LinkTask(id, provider, ref); err != nil {
-			results = append(results, fmt.Sprintf("Link Task %s: error (%v)", id, err))
-		} else {
-			results = append(results, fmt.Sprintf("Link Task %s: linked to %s (%s)", id, provider, ref.Identifier))
-		}
+		results = append(results, s.applyLink(id, provider, ref))
 	}
 
 	// 2. Handle Status Updates
 	for id, status := range result.StatusUpdates {
-		var event string
-		switch status {
-		case "done":
-			event = "complete"
-		case "in_progress":
-			event = "start"
-		}
-
-		if event != "" {
-			if err := s.taskSvc.TransitionTask(id, event, "sync-plugin", ""); err != nil {
-				results = append(results, fmt.Sprintf("Status Task %s: skip (%v)", id, err))
-			} else {
-				results = append(results, fmt.Sprintf("Status Task %s: %s", id, status))
-			}
+		if res := s.applyStatus(id, status); res != "" {
+			results = append(results, res)
 		}
 	}
 
@@ -123,9 +106,131 @@ func (s *SyncService) SyncWithPluginConfig(pluginPath string, config map[string]
 		results = append(results, fmt.Sprintf("Plugin Error: %s", e))
 	}
 
+	// 3. Exchange field changes with plugins that support them
+	if fs, ok := syncer.(domainPlugin.FieldSyncer); ok {
+		fieldResults, err := s.syncFields(fs, name, provider, policy, result.Changes)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, fieldResults...)
+	}
+
 	return results, nil
 }
 
+// WatchWithNamedPlugin watches a named plugin configuration from plugins.yaml.
+// See WatchWithPluginConfig.
+func (s *SyncService) WatchWithNamedPlugin(ctx context.Context, name string, report func(string)) error {
+	if s.pluginRepo == nil {
+		return fmt.Errorf("plugin configuration not available")
+	}
+
+	cfg, err := s.pluginRepo.GetPluginConfig(name)
+	if err != nil {
+		return fmt.Errorf("failed to load plugin config '%s': %w", name, err)
+	}
+
+	return s.WatchWithPluginConfig(ctx, cfg.Binary, cfg.Config, report)
+}
+
+// WatchWithPluginConfig subscribes to the plugin's change feed and applies
+// each external status change and link as it arrives, without a full sync.
+// Every applied change is passed to report. It returns when ctx is cancelled
+// or the feed ends, and fails with domainPlugin.ErrSubscribeUnsupported for
+// plugins without a feed.
+func (s *SyncService) WatchWithPluginConfig(ctx context.Context, pluginPath string, config map[string]string, report func(string)) error {
+	loader := plugin.NewLoader()
+	defer loader.Cleanup()
+
+	syncer, err := loader.Load(pluginPath)
+	if err != nil {
+		return fmt.Errorf("failed to load plugin: %w", err)
+	}
+	subscriber, ok := syncer.(domainPlugin.Subscriber)
+	if !ok {
+		return fmt.Errorf("%w (plugin protocol version %d)", domainPlugin.ErrSubscribeUnsupported, loader.ProtocolVersion(pluginPath))
+	}
+
+	plan, err := s.repo.LoadPlan()
+	if err != nil {
+		return fmt.Errorf("failed to load plan: %w", err)
+	}
+
+	state, err := s.repo.LoadState()
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+
+	if err := syncer.Init(config); err != nil {
+		return fmt.Errorf("failed to initialize plugin: %w", err)
+	}
+
+	defaultProvider := providerForPlugin(pluginPath)
+	eventTypes := []string{domainPlugin.EventStatusChanged, domainPlugin.EventLinked}
+	err = subscriber.Subscribe(ctx, plan, state, eventTypes, func(e domainPlugin.ExternalEvent) error {
+		var res string
+		switch {
+		case e.Type == domainPlugin.EventStatusChanged:
+			res = s.applyStatus(e.TaskID, e.Status)
+		case e.Type == domainPlugin.EventLinked && e.ExternalRef != nil:
+			provider := e.Provider
+			if provider == "" {
+				provider = defaultProvider
+			}
+			res = s.applyLink(e.TaskID, provider, *e.ExternalRef)
+		}
+		if res != "" && report != nil {
+			report(res)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("subscription failed: %w", err)
+	}
+	return nil
+}
+
+// providerForPlugin guesses the external system from the plugin binary name.
+func providerForPlugin(pluginPath string) string {
+	switch {
+	case strings.Contains(pluginPath, "linear"):
+		return "linear"
+	case strings.Contains(pluginPath, "jira"):
+		return "jira"
+	case strings.Contains(pluginPath, "github"):
+		return "github"
+	}
+	return "external"
+}
+
+// applyLink links a task to an external item and describes the outcome.
+func (s *SyncService) applyLink(id, provider string, ref planning.ExternalRef) string {
+	if err := s.taskSvc.LinkTask(id, provider, ref); err != nil {
+		return fmt.Sprintf("Link Task %s: error (%v)", id, err)
+	}
+	return fmt.Sprintf("Link Task %s: linked to %s (%s)", id, provider, ref.Identifier)
+}
+
+// applyStatus transitions a task to an external status and describes the
+// outcome. Statuses without a matching transition are ignored.
+func (s *SyncService) applyStatus(id string, status planning.TaskStatus) string {
+	var event string
+	switch status {
+	case "done":
+		event = "complete"
+	case "in_progress":
+		event = "start"
+	}
+
+	if event == "" {
+		return ""
+	}
+	if err := s.taskSvc.TransitionTask(id, event, "sync-plugin", ""); err != nil {
+		return fmt.Sprintf("Status Task %s: skip (%v)", id, err)
+	}
+	return fmt.Sprintf("Status Task %s: %s", id, status)
+}
+
 // ListPluginConfigs returns all configured plugin names
 func (s *SyncService) ListPluginConfigs() ([]string, error) {
 	if s.pluginRepo == nil {
@@ -1,7 +1,13 @@
 package application_test
 
 import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/felixgeelhaar/roady/pkg/application"
 	"github.com/felixgeelhaar/roady/pkg/domain"
@@ -114,6 +120,106 @@ func TestSyncService_SyncWithPlugin_InvalidBinary(t *testing.T) {
 	}
 }
 
+func TestSyncService_Watch_Errors(t *testing.T) {
+	pluginRepo := &MockPluginConfigRepo{configs: plugin.NewPluginConfigs()}
+	svc, _ := newTestSyncService(pluginRepo)
+
+	if err := svc.WatchWithNamedPlugin(context.Background(), "nonexistent", nil); err == nil {
+		t.Error("expected error for nonexistent plugin")
+	}
+	if err := svc.WatchWithPluginConfig(context.Background(), "/nonexistent/binary", nil, nil); err == nil {
+		t.Error("expected error for invalid plugin binary")
+	}
+}
+
+func TestSyncService_WatchWithPlugin(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping plugin build in short mode")
+	}
+	bin := filepath.Join(t.TempDir(), "roady-plugin-mock")
+	if out, err := exec.Command("go", "build", "-o", bin, "../../cmd/roady-plugin-mock").CombinedOutput(); err != nil {
+		t.Skipf("cannot build mock plugin: %v\n%s", err, out)
+	}
+
+	svc, repo := newTestSyncService(&MockPluginConfigRepo{configs: plugin.NewPluginConfigs()})
+	repo.State.TaskStates["t1"] = planning.TaskResult{Status: planning.StatusInProgress, Owner: "alice"}
+
+	// The mock plugin reports every in-progress task as done, then idles.
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	var reports []string
+	err := svc.WatchWithPluginConfig(ctx, bin, map[string]string{}, func(res string) {
+		reports = append(reports, res)
+		cancel()
+	})
+	if err != nil {
+		t.Fatalf("WatchWithPluginConfig: %v", err)
+	}
+	if len(reports) != 1 || !strings.Contains(reports[0], "Status Task t1: done") {
+		t.Fatalf("expected t1 to be completed, got %v", reports)
+	}
+	if got := repo.State.TaskStates["t1"].Status; got != planning.StatusDone {
+		t.Errorf("expected t1 done, got %s", got)
+	}
+}
+
+// baselinePluginRepo is a plugin config repository that stores sync
+// baselines.
+type baselinePluginRepo struct {
+	MockPluginConfigRepo
+	baselines map[string]*plugin.SyncBaseline
+}
+
+func (m *baselinePluginRepo) LoadSyncBaseline(name string) (*plugin.SyncBaseline, error) {
+	return m.baselines[name], nil
+}
+
+func (m *baselinePluginRepo) SaveSyncBaseline(name string, b *plugin.SyncBaseline) error {
+	m.baselines[name] = b
+	return nil
+}
+
+func TestSyncService_SyncFields(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping plugin build in short mode")
+	}
+	bin := filepath.Join(t.TempDir(), "roady-plugin-mock")
+	if out, err := exec.Command("go", "build", "-o", bin, "../../cmd/roady-plugin-mock").CombinedOutput(); err != nil {
+		t.Skipf("cannot build mock plugin: %v\n%s", err, out)
+	}
+
+	configs := plugin.NewPluginConfigs()
+	configs.Set("mock", plugin.PluginConfig{Binary: bin, Config: map[string]string{}, ConflictPolicy: plugin.ConflictRoadyWins})
+	pluginRepo := &baselinePluginRepo{MockPluginConfigRepo: MockPluginConfigRepo{configs: configs}, baselines: map[string]*plugin.SyncBaseline{}}
+	svc, repo := newTestSyncService(pluginRepo)
+
+	// t1 was never synced, so it is created in the mock system and linked.
+	results, err := svc.SyncWithNamedPlugin("mock")
+	if err != nil {
+		t.Fatalf("SyncWithNamedPlugin: %v", err)
+	}
+	if ref, ok := repo.State.TaskStates["t1"].ExternalRefs["external"]; !ok || ref.ID != "MOCK-t1" {
+		t.Fatalf("expected t1 to be linked to MOCK-t1, got %+v (results %v)", repo.State.TaskStates["t1"].ExternalRefs, results)
+	}
+	baseline := pluginRepo.baselines["mock"]
+	if baseline == nil || baseline.Tasks["t1"].Title != "Task 1" {
+		t.Fatalf("expected a baseline to be recorded, got %+v", baseline)
+	}
+
+	// A later title edit is pushed as an update.
+	repo.Plan.Tasks[0].Title = "Renamed"
+	results, err = svc.SyncWithNamedPlugin("mock")
+	if err != nil {
+		t.Fatalf("SyncWithNamedPlugin: %v", err)
+	}
+	if !slices.Contains(results, "Field Sync: pushed 1 change(s)") {
+		t.Errorf("expected the rename to be pushed, got %v", results)
+	}
+	if got := pluginRepo.baselines["mock"].Tasks["t1"].Title; got != "Renamed" {
+		t.Errorf("expected the baseline to follow the rename, got %q", got)
+	}
+}
+
 func TestSyncService_ListPluginConfigs(t *testing.T) {
 	configs := plugin.NewPluginConfigs()
 	configs.Set("github", plugin.PluginConfig{Binary: "/bin/gh"})
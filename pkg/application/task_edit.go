@@ -0,0 +1,251 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/project"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
+	"github.com/google/uuid"
+)
+
+// TaskEdit holds the task fields to change; nil fields are left as they are.
+type TaskEdit struct {
+	Title       *string
+	Description *string
+	Priority    *planning.TaskPriority
+	Estimate    *string
+	DependsOn   *[]string
+}
+
+// EditTask changes a task's fields in the plan; see markPlanEdited. It acts
+// as the member ctx carries, or actor when there is none.
+func (s *TaskService) EditTask(ctx context.Context, taskID, actor string, edit TaskEdit) error {
+	if err := s.authorize(ctx, team.PermissionEditPlan); err != nil {
+		return err
+	}
+	actor = team.ActorFrom(ctx, actor)
+	changed := map[string]interface{}{"task_id": taskID}
+	err := updatePlan(s.repo, func(plan *planning.Plan) error {
+		idx := taskIndex(plan, taskID)
+		if idx < 0 {
+			return fmt.Errorf("%w: %s", project.ErrTaskNotFound, taskID)
+		}
+
+		task := &plan.Tasks[idx]
+		if edit.Title != nil {
+			if strings.TrimSpace(*edit.Title) == "" {
+				return fmt.Errorf("task title cannot be empty")
+			}
+			task.Title = *edit.Title
+			changed["title"] = *edit.Title
+		}
+		if edit.Description != nil {
+			task.Description = *edit.Description
+			changed["description"] = *edit.Description
+		}
+		if edit.Priority != nil {
+			if !edit.Priority.IsValid() {
+				return fmt.Errorf("invalid priority: %s", *edit.Priority)
+			}
+			task.Priority = *edit.Priority
+			changed["priority"] = string(*edit.Priority)
+		}
+		if edit.Estimate != nil {
+			if _, err := planning.ParseEstimate(*edit.Estimate); err != nil {
+				return err
+			}
+			task.Estimate = *edit.Estimate
+			changed["estimate"] = *edit.Estimate
+		}
+		if edit.DependsOn != nil {
+			if err := checkDependencies(plan, taskID, *edit.DependsOn); err != nil {
+				return err
+			}
+			task.DependsOn = *edit.DependsOn
+			changed["depends_on"] = *edit.DependsOn
+		}
+		return markPlanEdited(plan)
+	})
+	if err != nil {
+		return err
+	}
+	return s.audit.Log("task.edit", actor, changed)
+}
+
+// AddTask appends a new task to the plan; see markPlanEdited. An empty ID
+// is derived from the title and an empty priority defaults to medium. It
+// acts as the member ctx carries, or actor when there is none.
+func (s *TaskService) AddTask(ctx context.Context, task planning.Task, actor string) error {
+	if err := s.authorize(ctx, team.PermissionEditPlan); err != nil {
+		return err
+	}
+	actor = team.ActorFrom(ctx, actor)
+	if strings.TrimSpace(task.Title) == "" {
+		return fmt.Errorf("task title cannot be empty")
+	}
+	if task.ID != "" {
+		if _, err := domain.NewTaskID(task.ID); err != nil {
+			return err
+		}
+	}
+	if task.Priority == "" {
+		task.Priority = planning.PriorityMedium
+	}
+	if !task.Priority.IsValid() {
+		return fmt.Errorf("invalid priority: %s", task.Priority)
+	}
+	if _, err := planning.ParseEstimate(task.Estimate); err != nil {
+		return err
+	}
+
+	err := updatePlan(s.repo, func(plan *planning.Plan) error {
+		if task.ID == "" {
+			task.ID = newTaskID(task.Title, plan)
+		} else if taskIndex(plan, task.ID) >= 0 {
+			return fmt.Errorf("task already exists: %s", task.ID)
+		}
+		if err := checkDependencies(plan, task.ID, task.DependsOn); err != nil {
+			return err
+		}
+		plan.Tasks = append(plan.Tasks, task)
+		return markPlanEdited(plan)
+	})
+	if err != nil {
+		return err
+	}
+	return s.audit.Log("task.create", actor, map[string]interface{}{
+		"task_id": task.ID,
+		"title":   task.Title,
+	})
+}
+
+// checkDependencies checks that each dependency of task self names another
+// task in the plan. References to other projects' tasks ("@project:id") are
+// resolved across the workspace and are not checked here; cycles are left
+// to the plan's DAG validation.
+func checkDependencies(plan *planning.Plan, self string, deps []string) error {
+	for _, dep := range deps {
+		ref := planning.ParseTaskRef(dep)
+		if ref.External {
+			continue
+		}
+		if ref.TaskID == self {
+			return fmt.Errorf("task cannot depend on itself: %s", dep)
+		}
+		if taskIndex(plan, ref.TaskID) < 0 {
+			return fmt.Errorf("unknown dependency: %s", dep)
+		}
+	}
+	return nil
+}
+
+var taskIDCleaner = regexp.MustCompile(`[^a-z0-9]+`)
+
+// newTaskID derives a task ID from the title, suffixed to be unique in the
+// plan.
+func newTaskID(title string, plan *planning.Plan) string {
+	slug := strings.Trim(taskIDCleaner.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if len(slug) > 40 {
+		slug = strings.TrimRight(slug[:40], "-")
+	}
+	base := "task-" + slug
+	if slug == "" {
+		base = "task"
+	}
+	id := base
+	for n := 2; taskIndex(plan, id) >= 0; n++ {
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+	return id
+}
+
+// markPlanEdited validates the dependency graph of a plan whose tasks were
+// added or edited and returns it to pending approval, so a single-task
+// change is held to the same rules as a whole-plan UpdatePlan.
+func markPlanEdited(plan *planning.Plan) error {
+	if err := plan.ValidateDAG(); err != nil {
+		return fmt.Errorf("invalid plan dependency graph: %w", err)
+	}
+	plan.ApprovalStatus = planning.ApprovalPending
+	plan.UpdatedAt = time.Now()
+	return nil
+}
+
+// planUpdater is implemented by repositories that load, change and save the
+// plan under the project lock.
+type planUpdater interface {
+	UpdatePlan(fn func(p *planning.Plan) error) error
+}
+
+// updatePlan applies fn to the stored plan, under the project lock when the
+// repository has one.
+func updatePlan(repo domain.WorkspaceRepository, fn func(p *planning.Plan) error) error {
+	if u, ok := repo.(planUpdater); ok {
+		return u.UpdatePlan(fn)
+	}
+	plan, err := repo.LoadPlan()
+	if err != nil {
+		return err
+	}
+	if plan == nil {
+		return fmt.Errorf("no plan found")
+	}
+	if err := fn(plan); err != nil {
+		return err
+	}
+	return repo.SavePlan(plan)
+}
+
+// CommentTask adds a comment to a task, filling in its ID and creation time
+// when unset. It reports false when the comment was already synced from the
+// same provider.
+func (s *TaskService) CommentTask(taskID string, comment planning.TaskComment) (bool, error) {
+	if strings.TrimSpace(comment.Body) == "" {
+		return false, fmt.Errorf("comment cannot be empty")
+	}
+	plan, err := s.repo.LoadPlan()
+	if err != nil {
+		return false, err
+	}
+	if plan == nil || taskIndex(plan, taskID) < 0 {
+		return false, fmt.Errorf("task not found in plan: %s", taskID)
+	}
+
+	state, err := s.repo.LoadState()
+	if err != nil {
+		return false, err
+	}
+	if comment.ID == "" {
+		comment.ID = uuid.New().String()
+	}
+	if comment.CreatedAt.IsZero() {
+		comment.CreatedAt = time.Now()
+	}
+	if !state.AddComment(taskID, comment) {
+		return false, nil
+	}
+
+	if err := s.repo.SaveState(state); err != nil {
+		return false, err
+	}
+	return true, s.audit.Log("task.comment", comment.Author, map[string]interface{}{
+		"task_id":    taskID,
+		"comment_id": comment.ID,
+		"provider":   comment.Provider,
+	})
+}
+
+func taskIndex(plan *planning.Plan, taskID string) int {
+	for i, t := range plan.Tasks {
+		if t.ID == taskID {
+			return i
+		}
+	}
+	return -1
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/felixgeelhaar/roady/pkg/domain"
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	"github.com/felixgeelhaar/roady/pkg/domain/project"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
 )
 
 type TaskService struct {
@@ -16,6 +20,8 @@ type TaskService struct {
 	audit       domain.AuditLogger
 	policy      *PolicyService
 	coordinator *project.Coordinator
+	runner      planning.VerificationRunner
+	team        *TeamService
 }
 
 func NewTaskService(repo domain.WorkspaceRepository, audit domain.AuditLogger, policy *PolicyService) *TaskService {
@@ -27,12 +33,36 @@ func NewTaskService(repo domain.WorkspaceRepository, audit domain.AuditLogger, p
 	}
 }
 
+// SetTeam enforces the roles of team members acting on tasks. Without a
+// team every actor may change any task.
+func (s *TaskService) SetTeam(t *TeamService) {
+	s.team = t
+}
+
+// authorize checks that the member ctx carries holds the permission when a
+// team is set.
+func (s *TaskService) authorize(ctx context.Context, p team.Permission) error {
+	if s.team == nil {
+		return nil
+	}
+	return team.AuthorizeCaller(ctx, p)
+}
+
 func (s *TaskService) TransitionTask(taskID string, event string, actor string, evidence string) error {
-	ctx := context.Background()
+	return s.TransitionTaskContext(context.Background(), taskID, event, actor, evidence)
+}
+
+// TransitionTaskContext is TransitionTask on behalf of the member ctx
+// carries, or actor when there is none.
+func (s *TaskService) TransitionTaskContext(ctx context.Context, taskID string, event string, actor string, evidence string) error {
+	if err := s.authorize(ctx, team.PermissionTransitionTasks); err != nil {
+		return err
+	}
+	actor = team.ActorFrom(ctx, actor)
 
 	// Validate policy first if policy service is available
 	if s.policy != nil {
-		if err := s.policy.ValidateTransition(taskID, event); err != nil {
+		if err := s.policy.ValidateTransitionAs(taskID, event, actor, evidence); err != nil {
 			return err
 		}
 	}
@@ -127,6 +157,15 @@ func (s *TaskService) mapCoordinatorError(err error, event string) error {
 		return fmt.Errorf("cannot start task %s: dependency %s is not complete (status: %s)", depErr.TaskID, depErr.DependencyID, depErr.Status)
 	}
 
+	var criteriaErr *project.CriteriaError
+	if errors.As(err, &criteriaErr) {
+		lines := make([]string, len(criteriaErr.Missing))
+		for i, c := range criteriaErr.Missing {
+			lines[i] = fmt.Sprintf("  %d. %s", slices.Index(criteriaErr.Criteria, c)+1, c)
+		}
+		return fmt.Errorf("cannot %s task %s: acceptance criteria need evidence (commit, test, URL or text):\n%s", event, criteriaErr.TaskID, strings.Join(lines, "\n"))
+	}
+
 	var transErr *project.TransitionError
 	if errors.As(err, &transErr) {
 		return fmt.Errorf("cannot %s task %s: invalid transition from %s", transErr.Event, transErr.TaskID, transErr.FromStatus)
@@ -245,8 +284,11 @@ func (s *TaskService) StartTask(ctx context.Context, taskID, owner, rateID strin
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	if err := s.authorize(ctx, team.PermissionTransitionTasks); err != nil {
+		return err
+	}
 	if s.policy != nil {
-		if err := s.policy.ValidateTransition(taskID, "start"); err != nil {
+		if err := s.policy.ValidateTransitionAs(taskID, "start", owner, ""); err != nil {
 			return err
 		}
 	}
@@ -262,8 +304,11 @@ func (s *TaskService) CompleteTask(ctx context.Context, taskID, evidence string)
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	if err := s.authorize(ctx, team.PermissionTransitionTasks); err != nil {
+		return nil, err
+	}
 	if s.policy != nil {
-		if err := s.policy.ValidateTransition(taskID, "complete"); err != nil {
+		if err := s.policy.ValidateTransitionAs(taskID, "complete", "", evidence); err != nil {
 			return nil, err
 		}
 	}
@@ -279,6 +324,14 @@ func (s *TaskService) BlockTask(ctx context.Context, taskID, reason string) erro
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	if err := s.authorize(ctx, team.PermissionTransitionTasks); err != nil {
+		return err
+	}
+	if s.policy != nil {
+		if err := s.policy.ValidateTransitionAs(taskID, "block", "", reason); err != nil {
+			return err
+		}
+	}
 	err := s.coordinator.BlockTask(ctx, taskID, reason)
 	if err != nil {
 		return s.mapCoordinatorError(err, "block")
@@ -291,6 +344,14 @@ func (s *TaskService) UnblockTask(ctx context.Context, taskID string) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	if err := s.authorize(ctx, team.PermissionTransitionTasks); err != nil {
+		return err
+	}
+	if s.policy != nil {
+		if err := s.policy.ValidateTransitionAs(taskID, "unblock", "", ""); err != nil {
+			return err
+		}
+	}
 	err := s.coordinator.UnblockTask(ctx, taskID)
 	if err != nil {
 		return s.mapCoordinatorError(err, "unblock")
@@ -304,26 +365,192 @@ func (s *TaskService) ReopenTask(ctx context.Context, taskID string) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	if err := s.authorize(ctx, team.PermissionTransitionTasks); err != nil {
+		return err
+	}
+	if s.policy != nil {
+		if err := s.policy.ValidateTransitionAs(taskID, "reopen", "", ""); err != nil {
+			return err
+		}
+	}
 	if err := s.coordinator.ReopenTask(ctx, taskID); err != nil {
 		return s.mapCoordinatorError(err, "reopen")
 	}
 	return nil
 }
 
-// VerifyTask marks a completed task as verified.
-func (s *TaskService) VerifyTask(ctx context.Context, taskID, verifier string) error {
+// VerifyTask marks a completed task as verified. Tasks with acceptance
+// criteria need evidence for each one, supplied here or recorded earlier.
+// The verifier is the member ctx carries, or verifier when there is none.
+func (s *TaskService) VerifyTask(ctx context.Context, taskID, verifier string, evidence ...planning.CriterionEvidence) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	err := s.coordinator.VerifyTask(ctx, taskID, verifier)
+	if err := s.authorize(ctx, team.PermissionTransitionTasks); err != nil {
+		return err
+	}
+	verifier = team.ActorFrom(ctx, verifier)
+	if s.policy != nil {
+		if err := s.policy.ValidateTransitionAs(taskID, "verify", verifier, ""); err != nil {
+			return err
+		}
+	}
+	err := s.coordinator.VerifyTask(ctx, taskID, verifier, evidence...)
 	if err != nil {
 		return s.mapCoordinatorError(err, "verify")
 	}
-	return nil
+	return s.audit.Log("task.transition", verifier, map[string]interface{}{
+		"task_id":  taskID,
+		"event":    "verify",
+		"status":   string(planning.StatusVerified),
+		"verifier": verifier,
+		"criteria": len(evidence),
+	})
+}
+
+// SetVerificationRunner sets the runner used by VerifyTaskWithRun.
+func (s *TaskService) SetVerificationRunner(runner planning.VerificationRunner) {
+	s.runner = runner
+}
+
+// SetProjectDirectory lets task transitions resolve "@project:task-id"
+// dependencies and notify other projects of tasks a completion unlocked;
+// name is this project's name in dir.
+func (s *TaskService) SetProjectDirectory(name string, dir project.ProjectDirectory) {
+	s.coordinator.SetProjectDirectory(name, dir)
+}
+
+// VerifyTaskWithRun runs the verification declared for the task, or for its
+// feature, records the run and emits a task.verification_run event. A
+// passing run becomes task evidence and the task is then verified as by
+// VerifyTask; a failing run is recorded and the transition refused. The
+// timeout, when non-zero, overrides the declared one.
+func (s *TaskService) VerifyTaskWithRun(ctx context.Context, taskID, verifier string, timeout time.Duration, evidence ...planning.CriterionEvidence) (planning.VerificationRun, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := s.authorize(ctx, team.PermissionTransitionTasks); err != nil {
+		return planning.VerificationRun{}, err
+	}
+	verifier = team.ActorFrom(ctx, verifier)
+	if s.runner == nil {
+		return planning.VerificationRun{}, fmt.Errorf("no verification runner configured")
+	}
+	plan, err := s.repo.LoadPlan()
+	if err != nil {
+		return planning.VerificationRun{}, err
+	}
+	if plan == nil {
+		return planning.VerificationRun{}, fmt.Errorf("no plan found")
+	}
+	idx := slices.IndexFunc(plan.Tasks, func(t planning.Task) bool { return t.ID == taskID })
+	if idx < 0 {
+		return planning.VerificationRun{}, fmt.Errorf("task not found in plan")
+	}
+	v, ok := plan.VerificationFor(plan.Tasks[idx])
+	if !ok {
+		return planning.VerificationRun{}, fmt.Errorf("task %s declares no verification; set verify.command or verify.test on the task or under feature_verify in the plan", taskID)
+	}
+	if timeout > 0 {
+		v.Timeout = timeout.String()
+	}
+	if err := v.Validate(); err != nil {
+		return planning.VerificationRun{}, fmt.Errorf("task %s: %w", taskID, err)
+	}
+
+	// Refuse before running anything if the task cannot be verified yet.
+	state, err := s.repo.LoadState()
+	if err != nil {
+		return planning.VerificationRun{}, err
+	}
+	if state != nil {
+		if status := state.GetTaskStatus(taskID); !status.CanTransitionWith("verify") {
+			return planning.VerificationRun{}, s.mapCoordinatorError(&project.TransitionError{
+				TaskID:     taskID,
+				FromStatus: string(status),
+				ToStatus:   string(planning.StatusVerified),
+				Event:      "verify",
+			}, "verify")
+		}
+	}
+
+	run, err := s.runner.Run(ctx, v)
+	if err != nil {
+		return planning.VerificationRun{}, err
+	}
+	run.RanBy = verifier
+	if err := s.coordinator.RecordVerificationRun(ctx, taskID, run); err != nil {
+		return run, s.mapCoordinatorError(err, "verify")
+	}
+	if err := s.audit.Log(events.EventTypeTaskVerifyRun, verifier, map[string]interface{}{
+		"task_id":     taskID,
+		"command":     run.Command,
+		"exit_code":   run.ExitCode,
+		"timed_out":   run.TimedOut,
+		"passed":      run.Passed(),
+		"duration_ms": run.DurationMS,
+		"digest":      run.Digest,
+	}); err != nil {
+		return run, err
+	}
+
+	if !run.Passed() {
+		outcome := fmt.Sprintf("exited with %d", run.ExitCode)
+		if run.TimedOut {
+			limit, _ := v.TimeoutDuration()
+			outcome = "timed out after " + limit.String()
+		}
+		return run, fmt.Errorf("cannot verify task %s: `%s` %s\n%s", taskID, run.Command, outcome, lastLines(run.Output, 20))
+	}
+	return run, s.VerifyTask(ctx, taskID, verifier, evidence...)
+}
+
+// lastLines returns the last n lines of output, indented for display.
+func lastLines(output string, n int) string {
+	lines := strings.Split(output, "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return "  " + strings.Join(lines, "\n  ")
+}
+
+// ParseCriteriaEvidence reads "N=evidence" arguments for a task's
+// acceptance criteria (see planning.ParseCriterionEvidence).
+func (s *TaskService) ParseCriteriaEvidence(taskID string, raw []string) ([]planning.CriterionEvidence, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	plan, err := s.repo.LoadPlan()
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil {
+		return nil, fmt.Errorf("no plan found")
+	}
+	for _, t := range plan.Tasks {
+		if t.ID != taskID {
+			continue
+		}
+		evidence := make([]planning.CriterionEvidence, 0, len(raw))
+		for _, r := range raw {
+			ev, err := planning.ParseCriterionEvidence(t, r)
+			if err != nil {
+				return nil, err
+			}
+			evidence = append(evidence, ev)
+		}
+		return evidence, nil
+	}
+	return nil, fmt.Errorf("task not found in plan")
 }
 
 // AssignTask sets the owner on a task without requiring a status transition.
-func (s *TaskService) AssignTask(_ context.Context, taskID, assignee string) error {
+// The assignment is audited as the member ctx carries, or the actor named
+// with team.WithActor.
+func (s *TaskService) AssignTask(ctx context.Context, taskID, assignee string) error {
+	if err := s.authorize(ctx, team.PermissionTransitionTasks); err != nil {
+		return err
+	}
 	plan, err := s.repo.LoadPlan()
 	if err != nil {
 		return err
@@ -354,7 +581,7 @@ func (s *TaskService) AssignTask(_ context.Context, taskID, assignee string) err
 		return err
 	}
 
-	return s.audit.Log("task.assign", assignee, map[string]interface{}{
+	return s.audit.Log("task.assign", team.ActorFrom(ctx, ""), map[string]interface{}{
 		"task_id":  taskID,
 		"assignee": assignee,
 	})
@@ -3,10 +3,16 @@ package application_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/felixgeelhaar/roady/pkg/application"
+	"github.com/felixgeelhaar/roady/pkg/domain"
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/policy"
+	"github.com/felixgeelhaar/roady/pkg/domain/project"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
 )
 
 func TestTaskService_Transition_Mock(t *testing.T) {
@@ -364,6 +370,134 @@ func TestTaskService_VerifyTask_Context(t *testing.T) {
 	}
 }
 
+func TestTaskService_VerifyTask_AcceptanceCriteria(t *testing.T) {
+	repo := &MockRepo{
+		Plan: &planning.Plan{
+			Tasks:          []planning.Task{{ID: "t1", AcceptanceCriteria: []string{"Errors are shown", "Receipts are emailed"}}},
+			ApprovalStatus: planning.ApprovalApproved,
+		},
+		State: &planning.ExecutionState{
+			TaskStates: map[string]planning.TaskResult{
+				"t1": {Status: planning.StatusDone},
+			},
+		},
+	}
+	service := application.NewTaskService(repo, application.NewAuditService(repo), application.NewPolicyService(repo))
+
+	evidence, err := service.ParseCriteriaEvidence("t1", []string{"1=test:TestErrors"})
+	if err != nil {
+		t.Fatalf("ParseCriteriaEvidence: %v", err)
+	}
+	err = service.VerifyTask(context.Background(), "t1", "reviewer", evidence...)
+	if err == nil || !strings.Contains(err.Error(), "2. Receipts are emailed") {
+		t.Fatalf("expected the missing criterion to be listed, got %v", err)
+	}
+	if _, err := service.ParseCriteriaEvidence("missing", []string{"1=x"}); err == nil {
+		t.Error("expected an error for an unknown task")
+	}
+
+	evidence, err = service.ParseCriteriaEvidence("t1", []string{"1=test:TestErrors", "2=https://ci.example.com/1"})
+	if err != nil {
+		t.Fatalf("ParseCriteriaEvidence: %v", err)
+	}
+	if err := service.VerifyTask(context.Background(), "t1", "reviewer", evidence...); err != nil {
+		t.Fatalf("VerifyTask: %v", err)
+	}
+	if got := repo.State.TaskStates["t1"]; got.Status != planning.StatusVerified || got.CriteriaEvidence[1].Kind != planning.EvidenceURL {
+		t.Errorf("unexpected result: %+v", got)
+	}
+}
+
+type stubRunner struct {
+	run  planning.VerificationRun
+	got  []planning.Verification
+	errs error
+}
+
+func (r *stubRunner) Run(_ context.Context, v planning.Verification) (planning.VerificationRun, error) {
+	r.got = append(r.got, v)
+	run := r.run
+	run.Command = v.String()
+	return run, r.errs
+}
+
+func TestTaskService_VerifyTaskWithRun(t *testing.T) {
+	repo := &MockRepo{
+		Plan: &planning.Plan{
+			Tasks: []planning.Task{
+				{ID: "t1", FeatureID: "auth", Priority: planning.PriorityHigh},
+				{ID: "t2", FeatureID: "billing", Verify: &planning.Verification{Command: "make e2e"}},
+				{ID: "t3", FeatureID: "docs"},
+			},
+			FeatureVerify:  map[string]planning.Verification{"auth": {Test: "TestLogin"}},
+			ApprovalStatus: planning.ApprovalApproved,
+		},
+		State: &planning.ExecutionState{
+			TaskStates: map[string]planning.TaskResult{
+				"t1": {Status: planning.StatusDone},
+				"t2": {Status: planning.StatusInProgress},
+				"t3": {Status: planning.StatusDone},
+			},
+		},
+		Policy: &domain.PolicyConfig{Rules: []policy.RuleConfig{{
+			ID:        "high-priority-run-verified",
+			Type:      policy.RuleTypeRequireField,
+			Selector:  policy.Selector{Priority: []planning.TaskPriority{planning.PriorityHigh}, Status: []planning.TaskStatus{planning.StatusVerified}},
+			Condition: policy.Condition{Field: "verification_run"},
+			Level:     policy.ViolationError,
+		}}},
+	}
+	audit := newTestAudit()
+	service := application.NewTaskService(repo, audit, application.NewPolicyService(repo))
+	ctx := context.Background()
+
+	if _, err := service.VerifyTaskWithRun(ctx, "t1", "ci", 0); err == nil || !strings.Contains(err.Error(), "runner") {
+		t.Fatalf("expected an error without a runner, got %v", err)
+	}
+	if err := service.VerifyTask(ctx, "t1", "ci"); err == nil || !strings.Contains(err.Error(), "verification_run") {
+		t.Fatalf("policy should require a run-backed verification, got %v", err)
+	}
+
+	runner := &stubRunner{run: planning.VerificationRun{ExitCode: 1, Output: "--- FAIL: TestLogin", Digest: "d"}}
+	service.SetVerificationRunner(runner)
+	_, err := service.VerifyTaskWithRun(ctx, "t1", "ci", 0)
+	if err == nil || !strings.Contains(err.Error(), "exited with 1") || !strings.Contains(err.Error(), "--- FAIL: TestLogin") {
+		t.Fatalf("expected the failing run to refuse verification, got %v", err)
+	}
+	if got := repo.State.TaskStates["t1"]; got.Status != planning.StatusDone || got.VerificationRun == nil || len(got.Evidence) != 0 {
+		t.Fatalf("failed run should be recorded without evidence: %+v", got)
+	}
+	if len(audit.Events) != 1 || audit.Events[0].Action != "task.verification_run" || audit.Events[0].Metadata["passed"] != false {
+		t.Fatalf("expected a verification_run event, got %+v", audit.Events)
+	}
+
+	runner.run.ExitCode = 0
+	run, err := service.VerifyTaskWithRun(ctx, "t1", "ci", 30*time.Second)
+	if err != nil {
+		t.Fatalf("VerifyTaskWithRun: %v", err)
+	}
+	if runner.got[1].Test != "TestLogin" || runner.got[1].Timeout != "30s" {
+		t.Errorf("expected the feature's test with the overridden timeout, got %+v", runner.got[1])
+	}
+	if run.RanBy != "ci" || !run.Passed() {
+		t.Errorf("unexpected run: %+v", run)
+	}
+	got := repo.State.TaskStates["t1"]
+	if got.Status != planning.StatusVerified || len(got.Evidence) != 1 || !strings.HasPrefix(got.Evidence[0], "verify-run: `go test -run 'TestLogin' ./...` exit 0") {
+		t.Errorf("unexpected result: %+v", got)
+	}
+
+	if _, err := service.VerifyTaskWithRun(ctx, "t2", "ci", 0); err == nil || !strings.Contains(err.Error(), "invalid transition") {
+		t.Errorf("expected an in-progress task to be refused before running, got %v", err)
+	}
+	if len(runner.got) != 2 {
+		t.Errorf("runner should not run for a task that cannot be verified")
+	}
+	if _, err := service.VerifyTaskWithRun(ctx, "t3", "ci", 0); err == nil || !strings.Contains(err.Error(), "declares no verification") {
+		t.Errorf("expected an error for a task without verification, got %v", err)
+	}
+}
+
 func TestTaskService_AssignTask(t *testing.T) {
 	repo := &MockRepo{
 		Plan: &planning.Plan{
@@ -388,6 +522,33 @@ func TestTaskService_AssignTask(t *testing.T) {
 	}
 }
 
+func TestTaskService_AssignAndVerify_AuditActor(t *testing.T) {
+	repo := &MockRepo{
+		Plan: &planning.Plan{Tasks: []planning.Task{{ID: "t1"}}, ApprovalStatus: planning.ApprovalApproved},
+		State: &planning.ExecutionState{TaskStates: map[string]planning.TaskResult{
+			"t1": {Status: planning.StatusDone},
+		}},
+	}
+	audit := newTestAudit()
+	service := application.NewTaskService(repo, audit, application.NewPolicyService(repo))
+
+	if err := service.AssignTask(team.WithActor(context.Background(), "carol"), "t1", "alice"); err != nil {
+		t.Fatalf("AssignTask: %v", err)
+	}
+	if got := audit.Events[len(audit.Events)-1].Actor; got != "carol" {
+		t.Errorf("assign audited as %q, want the assigning user carol", got)
+	}
+
+	mo := team.WithCaller(context.Background(), team.Member{Name: "mo", Role: team.RoleMember})
+	if err := service.VerifyTask(mo, "t1", "reviewer"); err != nil {
+		t.Fatalf("VerifyTask: %v", err)
+	}
+	last := audit.Events[len(audit.Events)-1]
+	if last.Actor != "mo" || last.Metadata["verifier"] != "mo" {
+		t.Errorf("verify audited as %q (verifier %v), want the caller mo", last.Actor, last.Metadata["verifier"])
+	}
+}
+
 func TestTaskService_AssignTask_NotFound(t *testing.T) {
 	repo := &MockRepo{
 		Plan: &planning.Plan{
@@ -422,6 +583,177 @@ func TestTaskService_AssignTask_NoPlan(t *testing.T) {
 	}
 }
 
+func TestTaskService_EditTask(t *testing.T) {
+	repo := &MockRepo{
+		Plan:  &planning.Plan{Tasks: []planning.Task{{ID: "t1", Title: "Old", Priority: planning.PriorityLow}}},
+		State: planning.NewExecutionState("p1"),
+	}
+	service := application.NewTaskService(repo, application.NewAuditService(repo), application.NewPolicyService(repo))
+
+	title := "New"
+	high := planning.PriorityHigh
+	if err := service.EditTask(context.Background(), "t1", "alice", application.TaskEdit{Title: &title, Priority: &high}); err != nil {
+		t.Fatalf("EditTask: %v", err)
+	}
+	if got := repo.Plan.Tasks[0]; got.Title != "New" || got.Priority != planning.PriorityHigh {
+		t.Errorf("task = %+v", got)
+	}
+
+	empty := ""
+	if err := service.EditTask(context.Background(), "t1", "alice", application.TaskEdit{Title: &empty}); err == nil {
+		t.Error("expected an empty title to be rejected")
+	}
+	bogus := planning.TaskPriority("urgent")
+	if err := service.EditTask(context.Background(), "t1", "alice", application.TaskEdit{Priority: &bogus}); err == nil {
+		t.Error("expected an invalid priority to be rejected")
+	}
+	if err := service.EditTask(context.Background(), "missing", "alice", application.TaskEdit{Title: &title}); err == nil {
+		t.Error("expected error for missing task")
+	}
+}
+
+func TestTaskService_AddTask(t *testing.T) {
+	repo := &MockRepo{
+		Plan:  &planning.Plan{Tasks: []planning.Task{{ID: "t1", Title: "One"}}},
+		State: planning.NewExecutionState("p1"),
+	}
+	service := application.NewTaskService(repo, application.NewAuditService(repo), application.NewPolicyService(repo))
+
+	if err := service.AddTask(context.Background(), planning.Task{ID: "t2", Title: "Two"}, "alice"); err != nil {
+		t.Fatalf("AddTask: %v", err)
+	}
+	if len(repo.Plan.Tasks) != 2 || repo.Plan.Tasks[1].Priority != planning.PriorityMedium {
+		t.Errorf("tasks = %+v", repo.Plan.Tasks)
+	}
+	if err := service.AddTask(context.Background(), planning.Task{ID: "t1", Title: "Again"}, "alice"); err == nil {
+		t.Error("expected a duplicate id to be rejected")
+	}
+	if err := service.AddTask(context.Background(), planning.Task{ID: "t3"}, "alice"); err == nil {
+		t.Error("expected a task without a title to be rejected")
+	}
+	for _, id := range []string{"../t4", "t 4", "@other:t4"} {
+		if err := service.AddTask(context.Background(), planning.Task{ID: id, Title: "Bad"}, "alice"); err == nil {
+			t.Errorf("expected task id %q to be rejected", id)
+		}
+	}
+	for _, task := range []planning.Task{
+		{ID: "t5", Title: "Five", DependsOn: []string{"t9"}},
+		{ID: "t5", Title: "Five", DependsOn: []string{"t5"}},
+		{ID: "t5", Title: "Five", Estimate: "soon"},
+	} {
+		if err := service.AddTask(context.Background(), task, "alice"); err == nil {
+			t.Errorf("expected %+v to be rejected", task)
+		}
+	}
+
+	// Another project's task is resolved across the workspace, not here.
+	if err := service.AddTask(context.Background(), planning.Task{Title: "Ship it", DependsOn: []string{"t1", "@billing:t9"}}, "alice"); err != nil {
+		t.Fatalf("AddTask with an external dependency: %v", err)
+	}
+	if got := repo.Plan.Tasks[len(repo.Plan.Tasks)-1]; got.ID != "task-ship-it" {
+		t.Errorf("generated id = %q, want task-ship-it", got.ID)
+	}
+	if err := service.AddTask(context.Background(), planning.Task{Title: "Ship it"}, "alice"); err != nil {
+		t.Fatalf("AddTask: %v", err)
+	}
+	if got := repo.Plan.Tasks[len(repo.Plan.Tasks)-1].ID; got != "task-ship-it-2" {
+		t.Errorf("generated id = %q, want task-ship-it-2", got)
+	}
+}
+
+func TestTaskService_EditTask_Fields(t *testing.T) {
+	repo := &MockRepo{
+		Plan:  &planning.Plan{Tasks: []planning.Task{{ID: "t1", Title: "One"}, {ID: "t2", Title: "Two"}}},
+		State: planning.NewExecutionState("p1"),
+	}
+	service := application.NewTaskService(repo, application.NewAuditService(repo), application.NewPolicyService(repo))
+	ctx := context.Background()
+
+	estimate, deps := "2d", []string{"t1", "@auth:login"}
+	if err := service.EditTask(ctx, "t2", "alice", application.TaskEdit{Estimate: &estimate, DependsOn: &deps}); err != nil {
+		t.Fatalf("EditTask: %v", err)
+	}
+	if got := repo.Plan.Tasks[1]; got.Estimate != "2d" || strings.Join(got.DependsOn, ",") != "t1,@auth:login" {
+		t.Errorf("edited task = %+v", got)
+	}
+
+	bad, unknown, self := "soon", []string{"t9"}, []string{"t2"}
+	for _, edit := range []application.TaskEdit{{Estimate: &bad}, {DependsOn: &unknown}, {DependsOn: &self}} {
+		if err := service.EditTask(ctx, "t2", "alice", edit); err == nil {
+			t.Errorf("expected %+v to be rejected", edit)
+		}
+	}
+	if err := service.EditTask(ctx, "t9", "alice", application.TaskEdit{Estimate: &estimate}); !errors.Is(err, project.ErrTaskNotFound) {
+		t.Errorf("EditTask(t9) = %v, want ErrTaskNotFound", err)
+	}
+}
+
+func TestTaskService_EditAndAdd_LikePlanUpdates(t *testing.T) {
+	repo := &MockRepo{
+		Plan: &planning.Plan{ApprovalStatus: planning.ApprovalApproved, Tasks: []planning.Task{
+			{ID: "t1", Title: "One"},
+			{ID: "t2", Title: "Two", DependsOn: []string{"t1"}},
+		}},
+		State: planning.NewExecutionState("p1"),
+	}
+	service := application.NewTaskService(repo, application.NewAuditService(repo), application.NewPolicyService(repo))
+
+	title := "Renamed"
+	if err := service.EditTask(context.Background(), "t1", "sync-plugin", application.TaskEdit{Title: &title}); err != nil {
+		t.Fatalf("EditTask: %v", err)
+	}
+	if repo.Plan.ApprovalStatus != planning.ApprovalPending {
+		t.Errorf("approval after edit = %s, want pending", repo.Plan.ApprovalStatus)
+	}
+
+	repo.Plan.ApprovalStatus = planning.ApprovalApproved
+	err := service.AddTask(context.Background(), planning.Task{ID: "t0", Title: "Zero", DependsOn: []string{"t2"}}, "sync-plugin")
+	if err != nil {
+		t.Fatalf("AddTask: %v", err)
+	}
+	if repo.Plan.ApprovalStatus != planning.ApprovalPending {
+		t.Errorf("approval after add = %s, want pending", repo.Plan.ApprovalStatus)
+	}
+
+	// t1 -> t3 -> t1 would close a cycle through the new task.
+	repo.Plan.Tasks[0].DependsOn = []string{"t3"}
+	err = service.AddTask(context.Background(), planning.Task{ID: "t3", Title: "Three", DependsOn: []string{"t1"}}, "sync-plugin")
+	if err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("expected a cycle to be rejected, got %v", err)
+	}
+}
+
+func TestTaskService_CommentTask(t *testing.T) {
+	repo := &MockRepo{
+		Plan:  &planning.Plan{Tasks: []planning.Task{{ID: "t1"}}},
+		State: planning.NewExecutionState("p1"),
+	}
+	service := application.NewTaskService(repo, application.NewAuditService(repo), application.NewPolicyService(repo))
+
+	added, err := service.CommentTask("t1", planning.TaskComment{Author: "alice", Body: "Started"})
+	if err != nil || !added {
+		t.Fatalf("CommentTask = %v, %v", added, err)
+	}
+	c := repo.State.TaskStates["t1"].Comments[0]
+	if c.ID == "" || c.CreatedAt.IsZero() {
+		t.Errorf("expected the comment ID and time to be filled in, got %+v", c)
+	}
+
+	synced := planning.TaskComment{Author: "bob", Body: "LGTM", Provider: "jira", ExternalID: "42"}
+	if added, _ := service.CommentTask("t1", synced); !added {
+		t.Error("expected the synced comment to be added")
+	}
+	if added, _ := service.CommentTask("t1", synced); added {
+		t.Error("expected the synced comment to be added only once")
+	}
+	if _, err := service.CommentTask("missing", planning.TaskComment{Body: "x"}); err == nil {
+		t.Error("expected error for missing task")
+	}
+	if _, err := service.CommentTask("t1", planning.TaskComment{Body: " "}); err == nil {
+		t.Error("expected an empty comment to be rejected")
+	}
+}
+
 func TestTaskService_AssignThenStart(t *testing.T) {
 	repo := &MockRepo{
 		Plan: &planning.Plan{
@@ -1,7 +1,10 @@
 package application
 
 import (
+	"context"
+	"crypto/ed25519"
 	"fmt"
+	"time"
 
 	"github.com/felixgeelhaar/roady/pkg/domain"
 	"github.com/felixgeelhaar/roady/pkg/domain/team"
@@ -10,11 +13,11 @@ import (
 
 // TeamService manages team membership and role-based access.
 type TeamService struct {
-	repo  *storage.FilesystemRepository
+	repo  storage.Repository
 	audit domain.AuditLogger
 }
 
-func NewTeamService(repo *storage.FilesystemRepository, audit domain.AuditLogger) *TeamService {
+func NewTeamService(repo storage.Repository, audit domain.AuditLogger) *TeamService {
 	return &TeamService{repo: repo, audit: audit}
 }
 
@@ -23,8 +26,25 @@ func (s *TeamService) ListMembers() (*team.TeamConfig, error) {
 	return s.repo.LoadTeam()
 }
 
-// AddMember adds or updates a team member.
+// AddMember adds or updates a team member. The change is recorded as made by
+// the member themselves and is not subject to roles; surfaces acting for a
+// caller use AddMemberWithActor.
 func (s *TeamService) AddMember(name string, role team.Role) error {
+	return s.addMember(context.Background(), name, role, name)
+}
+
+// AddMemberWithActor adds or updates a team member on behalf of the member
+// ctx carries, who needs the manage_team permission, or of actor when there
+// is none.
+func (s *TeamService) AddMemberWithActor(ctx context.Context, name string, role team.Role, actor string) error {
+	return s.addMember(ctx, name, role, actor)
+}
+
+func (s *TeamService) addMember(ctx context.Context, name string, role team.Role, actor string) error {
+	if err := team.AuthorizeCaller(ctx, team.PermissionManageTeam); err != nil {
+		return err
+	}
+	actor = team.ActorFrom(ctx, actor)
 	cfg, err := s.repo.LoadTeam()
 	if err != nil {
 		return fmt.Errorf("load team: %w", err)
@@ -38,14 +58,30 @@ func (s *TeamService) AddMember(name string, role team.Role) error {
 		return fmt.Errorf("save team: %w", err)
 	}
 
-	return s.audit.Log("team.add_member", name, map[string]interface{}{
+	return s.audit.Log("team.add_member", actor, map[string]interface{}{
 		"member": name,
 		"role":   string(role),
 	})
 }
 
-// RemoveMember removes a team member.
+// RemoveMember removes a team member and revokes their API tokens. Like
+// AddMember it is recorded as made by the member and not subject to roles.
 func (s *TeamService) RemoveMember(name string) error {
+	return s.removeMember(context.Background(), name, name)
+}
+
+// RemoveMemberWithActor removes a team member and revokes their API tokens
+// on behalf of the member ctx carries, who needs the manage_team
+// permission, or of actor when there is none.
+func (s *TeamService) RemoveMemberWithActor(ctx context.Context, name, actor string) error {
+	return s.removeMember(ctx, name, actor)
+}
+
+func (s *TeamService) removeMember(ctx context.Context, name, actor string) error {
+	if err := team.AuthorizeCaller(ctx, team.PermissionManageTeam); err != nil {
+		return err
+	}
+	actor = team.ActorFrom(ctx, actor)
 	cfg, err := s.repo.LoadTeam()
 	if err != nil {
 		return fmt.Errorf("load team: %w", err)
@@ -58,12 +94,38 @@ func (s *TeamService) RemoveMember(name string) error {
 	if err := s.repo.SaveTeam(cfg); err != nil {
 		return fmt.Errorf("save team: %w", err)
 	}
+	if _, err := s.revokeTokens(name, ""); err != nil {
+		return err
+	}
 
-	return s.audit.Log("team.remove_member", name, map[string]interface{}{
+	return s.audit.Log("team.remove_member", actor, map[string]interface{}{
 		"member": name,
 	})
 }
 
+// SetPublicKey registers the key that verifies the audit events a member or
+// agent signs.
+func (s *TeamService) SetPublicKey(name string, pub ed25519.PublicKey) error {
+	cfg, err := s.repo.LoadTeam()
+	if err != nil {
+		return fmt.Errorf("load team: %w", err)
+	}
+
+	key := team.EncodePublicKey(pub)
+	if err := cfg.SetPublicKey(name, key); err != nil {
+		return err
+	}
+
+	if err := s.repo.SaveTeam(cfg); err != nil {
+		return fmt.Errorf("save team: %w", err)
+	}
+
+	return s.audit.Log("team.set_key", name, map[string]interface{}{
+		"member":     name,
+		"public_key": key,
+	})
+}
+
 // GetMemberRole returns the role for a given member name, or empty if not found.
 func (s *TeamService) GetMemberRole(name string) (team.Role, error) {
 	cfg, err := s.repo.LoadTeam()
@@ -77,3 +139,111 @@ func (s *TeamService) GetMemberRole(name string) (team.Role, error) {
 	}
 	return m.Role, nil
 }
+
+// Authorize checks that actor holds the permission. Once the team has
+// members, actors that are not on it are refused.
+func (s *TeamService) Authorize(actor string, p team.Permission) error {
+	cfg, err := s.repo.LoadTeam()
+	if err != nil {
+		return fmt.Errorf("load team: %w", err)
+	}
+	return cfg.Authorize(actor, p)
+}
+
+// IssueToken creates an API token for a team member and returns it. Only its
+// hash is stored, so the token cannot be shown again; issuing with the same
+// label replaces the member's previous token of that label.
+func (s *TeamService) IssueToken(member, label string) (string, error) {
+	cfg, err := s.repo.LoadTeam()
+	if err != nil {
+		return "", fmt.Errorf("load team: %w", err)
+	}
+	if cfg.FindMember(member) == nil {
+		return "", fmt.Errorf("member not found: %s", member)
+	}
+
+	creds, err := s.repo.LoadCredentials()
+	if err != nil {
+		return "", fmt.Errorf("load credentials: %w", err)
+	}
+	token, err := creds.Issue(member, label, time.Now())
+	if err != nil {
+		return "", err
+	}
+	if err := s.repo.SaveCredentials(creds); err != nil {
+		return "", fmt.Errorf("save credentials: %w", err)
+	}
+
+	return token, s.audit.Log("team.issue_token", "cli", map[string]interface{}{
+		"member": member,
+		"label":  label,
+	})
+}
+
+// RevokeTokens removes a member's token with the given label, or all of
+// their tokens when label is empty, and returns how many were revoked.
+func (s *TeamService) RevokeTokens(member, label string) (int, error) {
+	n, err := s.revokeTokens(member, label)
+	if err != nil || n == 0 {
+		return n, err
+	}
+	return n, s.audit.Log("team.revoke_token", "cli", map[string]interface{}{
+		"member":  member,
+		"label":   label,
+		"revoked": n,
+	})
+}
+
+func (s *TeamService) revokeTokens(member, label string) (int, error) {
+	creds, err := s.repo.LoadCredentials()
+	if err != nil {
+		return 0, fmt.Errorf("load credentials: %w", err)
+	}
+	n := creds.Revoke(member, label)
+	if n == 0 {
+		return 0, nil
+	}
+	if err := s.repo.SaveCredentials(creds); err != nil {
+		return 0, fmt.Errorf("save credentials: %w", err)
+	}
+	return n, nil
+}
+
+// ListTokens returns the issued API tokens. Their hashes are omitted from JSON.
+func (s *TeamService) ListTokens() ([]team.Credential, error) {
+	creds, err := s.repo.LoadCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("load credentials: %w", err)
+	}
+	return creds.Tokens, nil
+}
+
+// HasTokens reports whether any API token has been issued. Network surfaces
+// require a member token once one exists.
+func (s *TeamService) HasTokens() (bool, error) {
+	tokens, err := s.ListTokens()
+	return len(tokens) > 0, err
+}
+
+// Authenticate resolves an API token to the team member it was issued to.
+// Tokens of members who have left the team no longer authenticate.
+func (s *TeamService) Authenticate(token string) (*team.Member, error) {
+	creds, err := s.repo.LoadCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("load credentials: %w", err)
+	}
+	cred := creds.Lookup(token)
+	if cred == nil {
+		return nil, team.ErrInvalidToken
+	}
+	cfg, err := s.repo.LoadTeam()
+	if err != nil {
+		return nil, fmt.Errorf("load team: %w", err)
+	}
+	m := cfg.FindMember(cred.Member)
+	if m == nil {
+		return nil, team.ErrInvalidToken
+	}
+	member := *m
+	return &member, nil
+}
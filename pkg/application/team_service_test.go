@@ -1,6 +1,8 @@
 package application_test
 
 import (
+	"context"
+	"errors"
 	"os"
 	"testing"
 
@@ -262,3 +264,159 @@ func TestTeamService_GetMemberRole(t *testing.T) {
 		})
 	}
 }
+
+func TestTeamService_Tokens(t *testing.T) {
+	svc, _, audit, cleanup := newTeamTestHarness(t)
+	defer cleanup()
+
+	if _, err := svc.IssueToken("ghost", ""); err == nil {
+		t.Fatal("expected error issuing a token for a non-member")
+	}
+	if has, _ := svc.HasTokens(); has {
+		t.Fatal("fresh repo should have no tokens")
+	}
+
+	if err := svc.AddMember("alice", team.RoleMember); err != nil {
+		t.Fatal(err)
+	}
+	token, err := svc.IssueToken("alice", "laptop")
+	if err != nil {
+		t.Fatalf("IssueToken: %v", err)
+	}
+	if has, _ := svc.HasTokens(); !has {
+		t.Fatal("expected HasTokens after issuing")
+	}
+
+	m, err := svc.Authenticate(token)
+	if err != nil || m == nil || m.Name != "alice" || m.Role != team.RoleMember {
+		t.Fatalf("Authenticate = %+v, %v", m, err)
+	}
+	if _, err := svc.Authenticate("rdy_bogus"); !errors.Is(err, team.ErrInvalidToken) {
+		t.Fatalf("Authenticate(bogus) = %v, want ErrInvalidToken", err)
+	}
+
+	tokens, err := svc.ListTokens()
+	if err != nil || len(tokens) != 1 || tokens[0].Label != "laptop" {
+		t.Fatalf("ListTokens = %+v, %v", tokens, err)
+	}
+
+	if n, err := svc.RevokeTokens("alice", "laptop"); err != nil || n != 1 {
+		t.Fatalf("RevokeTokens = %d, %v", n, err)
+	}
+	if _, err := svc.Authenticate(token); !errors.Is(err, team.ErrInvalidToken) {
+		t.Fatalf("revoked token authenticated: %v", err)
+	}
+
+	last := audit.Events[len(audit.Events)-1]
+	if last.Action != "team.revoke_token" {
+		t.Fatalf("expected team.revoke_token audit event, got %s", last.Action)
+	}
+}
+
+func TestTeamService_RemoveMemberRevokesTokens(t *testing.T) {
+	svc, _, _, cleanup := newTeamTestHarness(t)
+	defer cleanup()
+
+	_ = svc.AddMember("alice", team.RoleMember)
+	token, err := svc.IssueToken("alice", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RemoveMember("alice"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Authenticate(token); !errors.Is(err, team.ErrInvalidToken) {
+		t.Fatalf("token of removed member authenticated: %v", err)
+	}
+	if has, _ := svc.HasTokens(); has {
+		t.Fatal("expected tokens to be revoked with the member")
+	}
+}
+
+func TestTeamService_WithActorEnforcesRole(t *testing.T) {
+	svc, _, audit, cleanup := newTeamTestHarness(t)
+	defer cleanup()
+
+	_ = svc.AddMember("ada", team.RoleAdmin)
+	_ = svc.AddMember("mo", team.RoleMember)
+	member := team.WithCaller(context.Background(), team.Member{Name: "mo", Role: team.RoleMember})
+	admin := team.WithCaller(context.Background(), team.Member{Name: "ada", Role: team.RoleAdmin})
+
+	if err := svc.AddMemberWithActor(member, "zed", team.RoleViewer, "ai-agent"); !errors.Is(err, team.ErrForbidden) {
+		t.Fatalf("member adding a member = %v, want ErrForbidden", err)
+	}
+	if err := svc.RemoveMemberWithActor(member, "ada", "ada"); !errors.Is(err, team.ErrForbidden) {
+		t.Fatalf("member removing a member = %v, want ErrForbidden", err)
+	}
+
+	audit.Events = nil
+	if err := svc.AddMemberWithActor(admin, "zed", team.RoleViewer, "ai-agent"); err != nil {
+		t.Fatalf("admin adding a member: %v", err)
+	}
+	if len(audit.Events) != 1 || audit.Events[0].Actor != "ada" {
+		t.Fatalf("expected add recorded as ada, got %+v", audit.Events)
+	}
+}
+
+func TestTeamService_AuthorizeRefusesUnknownActors(t *testing.T) {
+	svc, _, _, cleanup := newTeamTestHarness(t)
+	defer cleanup()
+
+	if err := svc.Authorize("webhook", team.PermissionTransitionTasks); err != nil {
+		t.Fatalf("actor without a team = %v, want nil", err)
+	}
+	_ = svc.AddMember("mo", team.RoleMember)
+	if err := svc.Authorize("webhook", team.PermissionTransitionTasks); !errors.Is(err, team.ErrForbidden) {
+		t.Fatalf("actor not on the team = %v, want ErrForbidden", err)
+	}
+	if err := svc.Authorize("mo", team.PermissionTransitionTasks); err != nil {
+		t.Fatalf("member = %v, want nil", err)
+	}
+}
+
+func TestServices_EnforceTeamRoles(t *testing.T) {
+	teamSvc, repo, audit, cleanup := newTeamTestHarness(t)
+	defer cleanup()
+
+	_ = teamSvc.AddMember("vi", team.RoleViewer)
+	_ = teamSvc.AddMember("mo", team.RoleMember)
+
+	tasks := application.NewTaskService(repo, audit, nil)
+	tasks.SetTeam(teamSvc)
+	plans := application.NewPlanService(repo, audit)
+	plans.SetTeam(teamSvc)
+
+	viewer := team.WithCaller(context.Background(), team.Member{Name: "vi", Role: team.RoleViewer})
+	member := team.WithCaller(context.Background(), team.Member{Name: "mo", Role: team.RoleMember})
+
+	if err := tasks.TransitionTaskContext(viewer, "t1", "start", "ai-agent", ""); !errors.Is(err, team.ErrForbidden) {
+		t.Errorf("viewer TransitionTaskContext = %v, want ErrForbidden", err)
+	}
+	if err := tasks.StartTask(viewer, "t1", "", ""); !errors.Is(err, team.ErrForbidden) {
+		t.Errorf("viewer StartTask = %v, want ErrForbidden", err)
+	}
+	if _, err := tasks.CompleteTask(viewer, "t1", ""); !errors.Is(err, team.ErrForbidden) {
+		t.Errorf("viewer CompleteTask = %v, want ErrForbidden", err)
+	}
+	if err := tasks.AssignTask(viewer, "t1", "mo"); !errors.Is(err, team.ErrForbidden) {
+		t.Errorf("viewer AssignTask = %v, want ErrForbidden", err)
+	}
+	title := "renamed"
+	if err := tasks.EditTask(viewer, "t1", "ai-agent", application.TaskEdit{Title: &title}); !errors.Is(err, team.ErrForbidden) {
+		t.Errorf("viewer EditTask = %v, want ErrForbidden", err)
+	}
+	if err := plans.ApprovePlanWithActor(viewer, "ai-agent"); !errors.Is(err, team.ErrForbidden) {
+		t.Errorf("viewer ApprovePlanWithActor = %v, want ErrForbidden", err)
+	}
+	if _, err := plans.UpdatePlanWithActor(viewer, nil, "ai-agent"); !errors.Is(err, team.ErrForbidden) {
+		t.Errorf("viewer UpdatePlanWithActor = %v, want ErrForbidden", err)
+	}
+
+	// Members pass the role check and fail later on the empty workspace.
+	if err := tasks.TransitionTaskContext(member, "t1", "start", "", ""); errors.Is(err, team.ErrForbidden) {
+		t.Errorf("member TransitionTaskContext was forbidden: %v", err)
+	}
+	if err := plans.ApprovePlanWithActor(member, "cli"); errors.Is(err, team.ErrForbidden) {
+		t.Errorf("member ApprovePlanWithActor was forbidden: %v", err)
+	}
+}
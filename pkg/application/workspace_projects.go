@@ -0,0 +1,124 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/felixgeelhaar/roady/pkg/domain"
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/project"
+	"github.com/felixgeelhaar/roady/pkg/storage"
+)
+
+// WorkspaceProjects is the project directory of a workspace root: the root
+// project in <root>/.roady/ and the sub-projects under
+// <root>/.roady/projects/<name>/. It resolves "@project:task-id"
+// dependencies and records unlock notifications in the audit trail of the
+// project whose tasks were unlocked.
+type WorkspaceProjects struct {
+	root      string
+	openAudit func(project string) (domain.AuditLogger, error)
+}
+
+// NewWorkspaceProjects creates the project directory of a workspace root.
+func NewWorkspaceProjects(root string) *WorkspaceProjects {
+	return &WorkspaceProjects{root: root}
+}
+
+// SetAuditOpener sets how the audit trail of another project is opened to
+// notify it of unlocked tasks. Without one, nothing is recorded.
+func (w *WorkspaceProjects) SetAuditOpener(open func(project string) (domain.AuditLogger, error)) {
+	w.openAudit = open
+}
+
+// Projects implements project.ProjectDirectory.
+func (w *WorkspaceProjects) Projects(ctx context.Context) ([]string, error) {
+	var names []string
+	if _, err := os.Stat(filepath.Join(w.root, storage.RoadyDir)); err == nil {
+		names = append(names, "")
+	}
+	entries, err := os.ReadDir(filepath.Join(w.root, storage.RoadyDir, storage.ProjectsDir))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && storage.ValidateProjectName(entry.Name()) == nil {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// LoadPlan implements project.ProjectDirectory.
+func (w *WorkspaceProjects) LoadPlan(ctx context.Context, name string) (*planning.Plan, error) {
+	repo, err := w.open(name)
+	if err != nil {
+		return nil, err
+	}
+	return repo.LoadPlan()
+}
+
+// LoadState implements project.ProjectDirectory.
+func (w *WorkspaceProjects) LoadState(ctx context.Context, name string) (*planning.ExecutionState, error) {
+	repo, err := w.open(name)
+	if err != nil {
+		return nil, err
+	}
+	return repo.LoadState()
+}
+
+// NotifyUnlocked implements project.UnlockNotifier by recording a
+// task.unlocked event in each unlocked task's project.
+func (w *WorkspaceProjects) NotifyUnlocked(ctx context.Context, by string, unlocked []string) error {
+	if w.openAudit == nil {
+		return nil
+	}
+	byProject := make(map[string][]string)
+	for _, dep := range unlocked {
+		ref := planning.ParseTaskRef(dep)
+		byProject[ref.Project] = append(byProject[ref.Project], ref.TaskID)
+	}
+
+	var firstErr error
+	for name, taskIDs := range byProject {
+		audit, err := w.openAudit(name)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("open audit of project %q: %w", name, err)
+			}
+			continue
+		}
+		for _, id := range taskIDs {
+			err := audit.Log(events.EventTypeTaskUnlocked, "system", map[string]interface{}{
+				"task_id":     id,
+				"unlocked_by": by,
+			})
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// open opens an existing project of the workspace.
+func (w *WorkspaceProjects) open(name string) (storage.Repository, error) {
+	fs, err := storage.NewFilesystemRepositoryForProject(w.root, name)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %v", project.ErrUnknownProject, name, err)
+	}
+	if _, err := os.Stat(fs.ProjectBase()); err != nil {
+		return nil, fmt.Errorf("%w %q", project.ErrUnknownProject, name)
+	}
+	return storage.OpenRepository(w.root, name)
+}
+
+var (
+	_ project.ProjectDirectory = (*WorkspaceProjects)(nil)
+	_ project.UnlockNotifier   = (*WorkspaceProjects)(nil)
+)
@@ -0,0 +1,147 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/roady/pkg/application"
+	"github.com/felixgeelhaar/roady/pkg/domain"
+	"github.com/felixgeelhaar/roady/pkg/domain/dependency"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/project"
+	"github.com/felixgeelhaar/roady/pkg/storage"
+)
+
+// crossProjectWorkspace creates a workspace whose "web" sub-project has a
+// task depending on a task of the "api" sub-project.
+func crossProjectWorkspace(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	projects := map[string][]planning.Task{
+		"":    {{ID: "task-setup"}},
+		"api": {{ID: "task-auth"}},
+		"web": {{ID: "task-ui", DependsOn: []string{"@api:task-auth", "@:task-setup"}}},
+	}
+	for name, tasks := range projects {
+		repo, err := storage.NewFilesystemRepositoryForProject(root, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Initialize(); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.SavePlan(&planning.Plan{ID: name, ApprovalStatus: planning.ApprovalApproved, Tasks: tasks}); err != nil {
+			t.Fatal(err)
+		}
+		state := planning.NewExecutionState(name)
+		if name == "" {
+			state.TaskStates["task-setup"] = planning.TaskResult{Status: planning.StatusVerified}
+		}
+		if err := repo.SaveState(state); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+// projectTaskService returns a task service for a project of root.
+func projectTaskService(t *testing.T, root, name string, projects *application.WorkspaceProjects) *application.TaskService {
+	t.Helper()
+	repo, err := storage.NewFilesystemRepositoryForProject(root, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := application.NewPolicyService(repo)
+	policy.SetProjectDirectory(projects)
+	svc := application.NewTaskService(repo, &MockAuditLogger{}, policy)
+	svc.SetProjectDirectory(name, projects)
+	return svc
+}
+
+func TestWorkspaceProjects_Load(t *testing.T) {
+	root := crossProjectWorkspace(t)
+	projects := application.NewWorkspaceProjects(root)
+	ctx := context.Background()
+
+	names, err := projects.Projects(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(names, []string{"", "api", "web"}) {
+		t.Errorf("projects = %q", names)
+	}
+	plan, err := projects.LoadPlan(ctx, "api")
+	if err != nil || plan.ID != "api" {
+		t.Errorf("LoadPlan(api) = %v, %v", plan, err)
+	}
+	for _, name := range []string{"billing", "../api"} {
+		if _, err := projects.LoadState(ctx, name); !errors.Is(err, project.ErrUnknownProject) {
+			t.Errorf("LoadState(%q): expected ErrUnknownProject, got %v", name, err)
+		}
+	}
+}
+
+func TestWorkspaceProjects_CrossProjectTransitions(t *testing.T) {
+	root := crossProjectWorkspace(t)
+	projects := application.NewWorkspaceProjects(root)
+	audits := map[string]*MockAuditLogger{}
+	projects.SetAuditOpener(func(name string) (domain.AuditLogger, error) {
+		audits[name] = &MockAuditLogger{}
+		return audits[name], nil
+	})
+	api := projectTaskService(t, root, "api", projects)
+	web := projectTaskService(t, root, "web", projects)
+	ctx := context.Background()
+
+	err := web.TransitionTask("task-ui", "start", "alice", "")
+	if err == nil || !strings.Contains(err.Error(), "task-auth") {
+		t.Fatalf("expected the api dependency to block the start, got %v", err)
+	}
+
+	if err := api.TransitionTask("task-auth", "start", "bob", ""); err != nil {
+		t.Fatal(err)
+	}
+	unlocked, err := api.CompleteTask(ctx, "task-auth", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(unlocked, []string{"@web:task-ui"}) {
+		t.Errorf("unlocked = %v", unlocked)
+	}
+
+	webAudit := audits["web"]
+	if webAudit == nil || len(webAudit.Logs) != 1 {
+		t.Fatalf("expected one event in web's audit trail, got %+v", audits)
+	}
+	if action, meta := webAudit.Logs[0][0], webAudit.Logs[0][2].(map[string]interface{}); action != "task.unlocked" || meta["task_id"] != "task-ui" || meta["unlocked_by"] != "@api:task-auth" {
+		t.Errorf("unexpected notification %v", webAudit.Logs[0])
+	}
+
+	if err := web.TransitionTask("task-ui", "start", "alice", ""); err != nil {
+		t.Errorf("expected the start to succeed, got %v", err)
+	}
+}
+
+func TestDependencyService_GetTaskDependencies(t *testing.T) {
+	root := crossProjectWorkspace(t)
+	svc := application.NewDependencyService(nil, root)
+	svc.SetProjectDirectory(application.NewWorkspaceProjects(root))
+
+	graph, deps, err := svc.GetTaskDependencies(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []dependency.TaskDependency{
+		{Project: "web", TaskID: "task-ui", DependsOn: "@api:task-auth", Status: planning.StatusPending},
+		{Project: "web", TaskID: "task-ui", DependsOn: "@:task-setup", Status: planning.StatusVerified},
+	}
+	if !slices.Equal(deps, want) {
+		t.Errorf("dependencies = %+v, want %+v", deps, want)
+	}
+	if graph.Cycle() != nil {
+		t.Errorf("unexpected cycle %v", graph.Cycle())
+	}
+}
@@ -4,14 +4,27 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/felixgeelhaar/roady/pkg/domain"
+	"github.com/felixgeelhaar/roady/pkg/domain/merge"
 	"github.com/felixgeelhaar/roady/pkg/storage"
 )
 
+// MergeDriverName is the git merge driver registered by InstallMergeDriver.
+const MergeDriverName = "roady"
+
+// mergeDriverPatterns are the .gitattributes patterns routed to the driver.
+var mergeDriverPatterns = []string{
+	storage.RoadyDir + "/**/" + merge.PlanFile,
+	storage.RoadyDir + "/**/" + merge.StateFile,
+	storage.RoadyDir + "/**/" + merge.EventsFile,
+}
+
 // WorkspaceSyncService handles git-based synchronization of the .roady/ directory.
 type WorkspaceSyncService struct {
 	root  string
@@ -20,10 +33,12 @@ type WorkspaceSyncService struct {
 
 // SyncResult holds the outcome of a push or pull operation.
 type SyncResult struct {
-	Action   string   `json:"action"`
-	Files    []string `json:"files,omitempty"`
-	Conflict bool     `json:"conflict"`
-	Message  string   `json:"message"`
+	Action    string           `json:"action"`
+	Files     []string         `json:"files,omitempty"`
+	Conflict  bool             `json:"conflict"`
+	Conflicts []merge.Conflict `json:"conflicts,omitempty"`
+	Merged    []string         `json:"merged,omitempty"`
+	Message   string           `json:"message"`
 }
 
 func NewWorkspaceSyncService(root string, audit domain.AuditLogger) *WorkspaceSyncService {
@@ -81,20 +96,31 @@ func (s *WorkspaceSyncService) Pull(ctx context.Context) (*SyncResult, error) {
 	pullErr := s.git(ctx, "pull", "--rebase")
 
 	// Pop stash if we stashed
+	var merged []string
 	if hasChanges {
 		popErr := s.git(ctx, "stash", "pop")
 		if popErr != nil {
-			// Merge conflict
-			conflictFiles := s.mustChangedFiles(ctx)
-			_ = s.audit.Log("workspace.pull_conflict", "cli", map[string]interface{}{
-				"files": conflictFiles,
-			})
-			return &SyncResult{
-				Action:   "pull",
-				Files:    conflictFiles,
-				Conflict: true,
-				Message:  "Merge conflict in .roady/ files. Resolve manually, then run 'roady workspace push'.",
-			}, nil
+			// Merge conflict: merge the roady artifacts semantically
+			var conflicts []merge.Conflict
+			var unresolved []string
+			merged, conflicts, unresolved = s.resolveConflicts(ctx)
+			if len(conflicts) > 0 || len(unresolved) > 0 {
+				_ = s.audit.Log("workspace.pull_conflict", "cli", map[string]interface{}{
+					"files":     unresolved,
+					"conflicts": len(conflicts),
+				})
+				return &SyncResult{
+					Action:    "pull",
+					Files:     unresolved,
+					Conflict:  true,
+					Conflicts: conflicts,
+					Merged:    merged,
+					Message:   "Merge conflict in .roady/ files. Resolve manually, then run 'roady workspace push'.",
+				}, nil
+			}
+			// Everything merged: finish the pop
+			_ = s.git(ctx, "reset", "-q", "--", storage.RoadyDir+"/")
+			_ = s.git(ctx, "stash", "drop")
 		}
 	}
 
@@ -102,6 +128,17 @@ func (s *WorkspaceSyncService) Pull(ctx context.Context) (*SyncResult, error) {
 		return nil, fmt.Errorf("git pull: %w", pullErr)
 	}
 
+	if len(merged) > 0 {
+		_ = s.audit.Log("workspace.pull", "cli", map[string]interface{}{
+			"merged": merged,
+		})
+		return &SyncResult{
+			Action:  "pull",
+			Merged:  merged,
+			Message: fmt.Sprintf("Pulled latest workspace state, merged %d file(s)", len(merged)),
+		}, nil
+	}
+
 	_ = s.audit.Log("workspace.pull", "cli", nil)
 
 	return &SyncResult{
@@ -110,6 +147,119 @@ func (s *WorkspaceSyncService) Pull(ctx context.Context) (*SyncResult, error) {
 	}, nil
 }
 
+// resolveConflicts merges the unmerged .roady/ artifacts left by a failed
+// stash pop. Stage 2 is the pulled version and stage 3 the local one. It
+// returns the merged files, the true conflicts, and the files still
+// unmerged: those merge cannot handle and those with conflicts, which are
+// left for the user with the merged result written out.
+func (s *WorkspaceSyncService) resolveConflicts(ctx context.Context) (merged []string, conflicts []merge.Conflict, unresolved []string) {
+	out, err := s.gitOutput(ctx, "diff", "--name-only", "--diff-filter=U", "--", storage.RoadyDir+"/")
+	if err != nil {
+		return nil, nil, s.mustChangedFiles(ctx)
+	}
+	for _, path := range strings.Fields(string(out)) {
+		if !merge.Supported(path) {
+			unresolved = append(unresolved, path)
+			continue
+		}
+		base, _ := s.gitOutput(ctx, "show", ":1:"+path)
+		ours, oErr := s.gitOutput(ctx, "show", ":2:"+path)
+		theirs, tErr := s.gitOutput(ctx, "show", ":3:"+path)
+		if oErr != nil || tErr != nil {
+			// Deleted on one side
+			unresolved = append(unresolved, path)
+			continue
+		}
+		res, err := merge.Merge(path, base, ours, theirs)
+		if err != nil {
+			unresolved = append(unresolved, path)
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(s.root, path), res.Data, 0600); err != nil {
+			unresolved = append(unresolved, path)
+			continue
+		}
+		if len(res.Conflicts) > 0 {
+			conflicts = append(conflicts, res.Conflicts...)
+			unresolved = append(unresolved, path)
+			continue
+		}
+		_ = s.git(ctx, "add", "--", path)
+		merged = append(merged, path)
+	}
+	return merged, conflicts, unresolved
+}
+
+// MergeDriver merges a .roady/ artifact as a git merge driver invoked with
+// "%O %A %B %P": the merge result is written over oursFile. Conflicting
+// values keep ours and are returned in the result.
+func (s *WorkspaceSyncService) MergeDriver(baseFile, oursFile, theirsFile, path string) (*merge.Result, error) {
+	// #nosec G304 -- paths are temporary files handed over by git
+	base, err := os.ReadFile(baseFile)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("read base: %w", err)
+	}
+	// #nosec G304 -- see above
+	ours, err := os.ReadFile(oursFile)
+	if err != nil {
+		return nil, fmt.Errorf("read ours: %w", err)
+	}
+	// #nosec G304 -- see above
+	theirs, err := os.ReadFile(theirsFile)
+	if err != nil {
+		return nil, fmt.Errorf("read theirs: %w", err)
+	}
+	res, err := merge.Merge(path, base, ours, theirs)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(oursFile, res.Data, 0600); err != nil {
+		return nil, fmt.Errorf("write result: %w", err)
+	}
+	return res, nil
+}
+
+// InstallMergeDriver registers the roady merge driver in the repository's
+// git config and routes plan, state and event files to it in
+// .gitattributes. It is safe to run again.
+func (s *WorkspaceSyncService) InstallMergeDriver(ctx context.Context) error {
+	if err := s.git(ctx, "config", "merge."+MergeDriverName+".name", "Roady semantic merge"); err != nil {
+		return fmt.Errorf("git config: %w", err)
+	}
+	if err := s.git(ctx, "config", "merge."+MergeDriverName+".driver", "roady workspace merge-driver %O %A %B %P"); err != nil {
+		return fmt.Errorf("git config: %w", err)
+	}
+
+	path := filepath.Join(s.root, ".gitattributes")
+	// #nosec G304 -- .gitattributes of the project root
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("read .gitattributes: %w", err)
+	}
+	lines := make(map[string]bool)
+	for _, line := range strings.Split(string(existing), "\n") {
+		lines[strings.Join(strings.Fields(line), " ")] = true
+	}
+	var add bytes.Buffer
+	for _, pattern := range mergeDriverPatterns {
+		line := pattern + " merge=" + MergeDriverName
+		if !lines[line] {
+			add.WriteString(line + "\n")
+		}
+	}
+	if add.Len() == 0 {
+		return nil
+	}
+	if len(existing) > 0 && !bytes.HasSuffix(existing, []byte("\n")) {
+		existing = append(existing, '\n')
+	}
+	// #nosec G306 -- .gitattributes is committed and world-readable
+	if err := os.WriteFile(path, append(existing, add.Bytes()...), 0644); err != nil {
+		return fmt.Errorf("write .gitattributes: %w", err)
+	}
+	return nil
+}
+
 func (s *WorkspaceSyncService) git(ctx context.Context, args ...string) error {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
@@ -124,6 +274,21 @@ func (s *WorkspaceSyncService) git(ctx context.Context, args ...string) error {
 	return nil
 }
 
+func (s *WorkspaceSyncService) gitOutput(ctx context.Context, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = s.root
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
 func (s *WorkspaceSyncService) changedFiles(ctx context.Context) ([]string, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
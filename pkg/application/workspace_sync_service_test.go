@@ -1,9 +1,16 @@
 package application_test
 
 import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/felixgeelhaar/roady/pkg/application"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 )
 
 type MockAuditLogger struct {
@@ -73,3 +80,197 @@ func TestWorkspaceSyncService_New(t *testing.T) {
 		t.Error("expected service to not be nil")
 	}
 }
+
+// gitWorkspace clones a fresh shared remote into two working copies, both
+// starting from the given state.
+func gitWorkspace(t *testing.T, state *planning.ExecutionState) (upstream, local string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("Requires git to be available")
+	}
+	for _, env := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(env, "roady")
+	}
+	for _, env := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(env, "roady@example.com")
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+
+	dir := t.TempDir()
+	remote := filepath.Join(dir, "remote.git")
+	upstream, local = filepath.Join(dir, "upstream"), filepath.Join(dir, "local")
+	runGit(t, dir, "init", "-q", "--bare", "-b", "main", remote)
+	runGit(t, dir, "clone", "-q", remote, upstream)
+	runGit(t, upstream, "checkout", "-q", "-b", "main")
+	writeState(t, upstream, state)
+	runGit(t, upstream, "add", ".")
+	runGit(t, upstream, "commit", "-q", "-m", "init")
+	runGit(t, upstream, "push", "-q", "origin", "main")
+	runGit(t, dir, "clone", "-q", remote, local)
+	return upstream, local
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return string(out)
+}
+
+func writeState(t *testing.T, root string, state *planning.ExecutionState) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(root, ".roady"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.MarshalIndent(state, "", "  ")
+	if err := os.WriteFile(filepath.Join(root, ".roady", "state.json"), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readState(t *testing.T, root string) *planning.ExecutionState {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(root, ".roady", "state.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var state planning.ExecutionState
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatalf("state.json is not valid JSON: %v\n%s", err, data)
+	}
+	return &state
+}
+
+func withStatuses(version int, statuses map[string]planning.TaskStatus) *planning.ExecutionState {
+	state := &planning.ExecutionState{ProjectID: "p", Version: version, TaskStates: map[string]planning.TaskResult{}}
+	for id, status := range statuses {
+		state.TaskStates[id] = planning.TaskResult{Status: status}
+	}
+	return state
+}
+
+func TestWorkspaceSyncService_Pull_MergesState(t *testing.T) {
+	upstream, local := gitWorkspace(t, withStatuses(1, map[string]planning.TaskStatus{
+		"a": planning.StatusPending, "b": planning.StatusPending,
+	}))
+
+	writeState(t, upstream, withStatuses(2, map[string]planning.TaskStatus{
+		"a": planning.StatusDone, "b": planning.StatusPending,
+	}))
+	runGit(t, upstream, "commit", "-q", "-am", "finish a")
+	runGit(t, upstream, "push", "-q")
+	writeState(t, local, withStatuses(2, map[string]planning.TaskStatus{
+		"a": planning.StatusInProgress, "b": planning.StatusInProgress,
+	}))
+
+	audit := &MockAuditLogger{}
+	result, err := application.NewWorkspaceSyncService(local, audit).Pull(context.Background())
+	if err != nil {
+		t.Fatalf("pull: %v", err)
+	}
+	if result.Conflict || len(result.Merged) != 1 {
+		t.Fatalf("expected a clean merge, got %+v", result)
+	}
+
+	state := readState(t, local)
+	if state.TaskStates["a"].Status != planning.StatusDone || state.TaskStates["b"].Status != planning.StatusInProgress {
+		t.Errorf("expected both sides' progress, got %+v", state.TaskStates)
+	}
+	if state.Version != 3 {
+		t.Errorf("version = %d, want 3", state.Version)
+	}
+	if stashes := runGit(t, local, "stash", "list"); stashes != "" {
+		t.Errorf("expected the stash to be dropped, got %s", stashes)
+	}
+	if staged := runGit(t, local, "diff", "--cached", "--name-only"); staged != "" {
+		t.Errorf("expected the merged changes to be left unstaged, got %s", staged)
+	}
+}
+
+func TestWorkspaceSyncService_Pull_ReportsConflicts(t *testing.T) {
+	upstream, local := gitWorkspace(t, withStatuses(1, map[string]planning.TaskStatus{"a": planning.StatusPending}))
+
+	writeState(t, upstream, withStatuses(2, map[string]planning.TaskStatus{"a": planning.StatusBlocked}))
+	runGit(t, upstream, "commit", "-q", "-am", "block a")
+	runGit(t, upstream, "push", "-q")
+	writeState(t, local, withStatuses(2, map[string]planning.TaskStatus{"a": planning.StatusInProgress}))
+
+	result, err := application.NewWorkspaceSyncService(local, &MockAuditLogger{}).Pull(context.Background())
+	if err != nil {
+		t.Fatalf("pull: %v", err)
+	}
+	if !result.Conflict || len(result.Conflicts) != 1 || result.Conflicts[0].Path != "task_states[a].status" {
+		t.Fatalf("expected a status conflict, got %+v", result)
+	}
+	if state := readState(t, local); state.TaskStates["a"].Status != planning.StatusBlocked {
+		t.Errorf("expected the pulled status in the merged file, got %s", state.TaskStates["a"].Status)
+	}
+}
+
+func TestWorkspaceSyncService_MergeDriver(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]*planning.ExecutionState{
+		"base":   withStatuses(1, map[string]planning.TaskStatus{"a": planning.StatusPending, "b": planning.StatusPending}),
+		"ours":   withStatuses(2, map[string]planning.TaskStatus{"a": planning.StatusDone, "b": planning.StatusPending}),
+		"theirs": withStatuses(2, map[string]planning.TaskStatus{"a": planning.StatusPending, "b": planning.StatusBlocked}),
+	}
+	for name, state := range files {
+		data, _ := json.Marshal(state)
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	svc := application.NewWorkspaceSyncService(dir, nil)
+	res, err := svc.MergeDriver(filepath.Join(dir, "base"), filepath.Join(dir, "ours"), filepath.Join(dir, "theirs"), ".roady/state.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Conflicts) != 0 {
+		t.Fatalf("unexpected conflicts %v", res.Conflicts)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "ours"))
+	var merged planning.ExecutionState
+	_ = json.Unmarshal(data, &merged)
+	if merged.TaskStates["a"].Status != planning.StatusDone || merged.TaskStates["b"].Status != planning.StatusBlocked {
+		t.Errorf("expected the result written over ours, got %s", data)
+	}
+
+	if _, err := svc.MergeDriver(filepath.Join(dir, "base"), filepath.Join(dir, "ours"), filepath.Join(dir, "theirs"), ".roady/spec.yaml"); err == nil {
+		t.Error("expected unsupported files to fail")
+	}
+}
+
+func TestWorkspaceSyncService_InstallMergeDriver(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("Requires git to be available")
+	}
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q")
+	if err := os.WriteFile(filepath.Join(dir, ".gitattributes"), []byte("*.png binary"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := application.NewWorkspaceSyncService(dir, nil)
+	for i := 0; i < 2; i++ {
+		if err := svc.InstallMergeDriver(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if driver := runGit(t, dir, "config", "merge.roady.driver"); !strings.Contains(driver, "roady workspace merge-driver %O %A %B %P") {
+		t.Errorf("driver = %q", driver)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, ".gitattributes"))
+	want := "*.png binary\n.roady/**/plan.json merge=roady\n.roady/**/state.json merge=roady\n.roady/**/events.jsonl merge=roady\n"
+	if string(data) != want {
+		t.Errorf(".gitattributes = %q, want %q", data, want)
+	}
+	if attr := runGit(t, dir, "check-attr", "merge", ".roady/projects/api/state.json"); !strings.Contains(attr, "merge: roady") {
+		t.Errorf("check-attr = %q", attr)
+	}
+}
@@ -10,23 +10,43 @@ import (
 
 // Event represents a single auditable action in the system.
 type Event struct {
-	ID        string                 `json:"id"`
-	Timestamp time.Time              `json:"timestamp"`
-	Action    string                 `json:"action"`
-	Actor     string                 `json:"actor"` // "human" or "ai"
-	Metadata  map[string]interface{} `json:"metadata,omitempty"`
-	PrevHash  string                 `json:"prev_hash,omitempty"` // Hash of the preceding event
-	Hash      string                 `json:"hash,omitempty"`      // Deterministic hash of this event
+	ID          string                 `json:"id"`
+	Timestamp   time.Time              `json:"timestamp"`
+	Action      string                 `json:"action"`
+	AggregateID string                 `json:"aggregate_id,omitempty"` // Set by the event store; part of the hash
+	Actor       string                 `json:"actor"`                  // "human" or "ai"
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	PrevHash    string                 `json:"prev_hash,omitempty"` // Hash of the preceding event
+	Hash        string                 `json:"hash,omitempty"`      // Deterministic hash of this event
+	Signer      string                 `json:"signer,omitempty"`    // Identity whose key signed Hash
+	Signature   string                 `json:"signature,omitempty"` // ed25519 signature of SignedHash
+	// SignedPrevHash is the PrevHash the event was signed with, kept when a
+	// merge re-chains a signed event onto another log.
+	SignedPrevHash *string `json:"signed_prev_hash,omitempty"`
+}
+
+// SignedHash returns the hash the signature covers: Hash, or for an event
+// re-chained by a merge, its hash at the position it was signed in.
+func (e *Event) SignedHash() string {
+	if e.SignedPrevHash == nil {
+		return e.Hash
+	}
+	signed := *e
+	signed.PrevHash = *e.SignedPrevHash
+	return signed.CalculateHash()
 }
 
 // CalculateHash generates a deterministic SHA256 hash of the event data.
 func (e *Event) CalculateHash() string {
 	h := sha256.New()
-	// Deterministic sequence: PrevHash + ID + Timestamp + Action + Actor + Metadata
+	// Deterministic sequence: PrevHash + ID + Timestamp + Action + AggregateID + Actor + Metadata.
+	// AggregateID is empty for events recorded by AuditService, which keeps
+	// their hashes unchanged and matches events.BaseEvent for the rest.
 	h.Write([]byte(e.PrevHash))
 	h.Write([]byte(e.ID))
 	h.Write([]byte(e.Timestamp.Format(time.RFC3339Nano)))
 	h.Write([]byte(e.Action))
+	h.Write([]byte(e.AggregateID))
 	h.Write([]byte(e.Actor))
 	h.Write([]byte(canonicalJSON(e.Metadata)))
 	return hex.EncodeToString(h.Sum(nil))
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"testing"
 	"time"
 )
@@ -125,3 +127,19 @@ func TestCanonicalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestEventCalculateHashAggregate(t *testing.T) {
+	legacy := &Event{ID: "e1", Action: "plan.approved", Actor: "cli", Timestamp: time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)}
+
+	// Events without an aggregate keep the hash they were recorded with.
+	sum := sha256.Sum256([]byte("e1" + "2026-01-01T12:00:00Z" + "plan.approved" + "cli"))
+	if got := legacy.CalculateHash(); got != hex.EncodeToString(sum[:]) {
+		t.Fatalf("hash of an event without an aggregate changed: %s", got)
+	}
+
+	withAggregate := *legacy
+	withAggregate.AggregateID = "task-1"
+	if legacy.CalculateHash() == withAggregate.CalculateHash() {
+		t.Fatal("hash should change when the aggregate changes")
+	}
+}
@@ -345,6 +345,10 @@ type DependencySummary struct {
 	ByType            map[DependencyType]int `json:"by_type"`
 	UnhealthyCount    int                    `json:"unhealthy_count"`
 	HasCycles         bool                   `json:"has_cycles"`
+	// TaskDependencies are the cross-project task dependencies of the workspace.
+	TaskDependencies []TaskDependency `json:"task_dependencies,omitempty"`
+	// TaskCycle is a cycle of task dependencies spanning projects, if any.
+	TaskCycle []string `json:"task_cycle,omitempty"`
 }
 
 // GetSummary returns a summary of the dependency graph.
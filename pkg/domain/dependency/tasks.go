@@ -0,0 +1,103 @@
+package dependency
+
+import (
+	"sort"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+)
+
+// TaskDependency is a task depending on a task of another project in the
+// same workspace, written "@project:task-id" in its DependsOn.
+type TaskDependency struct {
+	// Project is the dependent task's project; "" is the root project.
+	Project string `json:"project"`
+	// TaskID is the dependent task.
+	TaskID string `json:"task_id"`
+	// DependsOn is the "@project:task-id" reference.
+	DependsOn string `json:"depends_on"`
+	// Status is the status of the task depended on, or "missing" when the
+	// project or task does not exist.
+	Status planning.TaskStatus `json:"status"`
+}
+
+// Met reports whether the task depended on is complete.
+func (d TaskDependency) Met() bool {
+	return d.Status.IsComplete()
+}
+
+// StatusMissing marks a dependency on a project or task that does not exist.
+const StatusMissing planning.TaskStatus = "missing"
+
+// TaskGraph is the task dependency graph of a workspace. Every task is named
+// by its "@project:task-id" reference and maps to the tasks it depends on.
+type TaskGraph map[string][]string
+
+// AddPlan adds the tasks of a project's plan, qualifying local dependencies
+// with the project.
+func (g TaskGraph) AddPlan(project string, plan *planning.Plan) {
+	if plan == nil {
+		return
+	}
+	for _, task := range plan.Tasks {
+		node := planning.ExternalTaskRef(project, task.ID)
+		deps := make([]string, 0, len(task.DependsOn))
+		for _, dep := range task.DependsOn {
+			ref := planning.ParseTaskRef(dep)
+			if !ref.External {
+				ref.Project = project
+			}
+			deps = append(deps, planning.ExternalTaskRef(ref.Project, ref.TaskID))
+		}
+		g[node] = deps
+	}
+}
+
+// Cycle returns the tasks of a dependency cycle, starting and ending with
+// the same task, or nil when the graph has none.
+func (g TaskGraph) Cycle() []string {
+	nodes := make([]string, 0, len(g))
+	for node := range g {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	marks := make(map[string]int, len(g))
+	var path []string
+	var visit func(node string) []string
+	visit = func(node string) []string {
+		switch marks[node] {
+		case visiting:
+			for i, n := range path {
+				if n == node {
+					return append(append([]string(nil), path[i:]...), node)
+				}
+			}
+		case done:
+			return nil
+		}
+		marks[node] = visiting
+		path = append(path, node)
+		for _, dep := range g[node] {
+			if cycle := visit(dep); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		marks[node] = done
+		return nil
+	}
+
+	for _, node := range nodes {
+		if marks[node] == unvisited {
+			if cycle := visit(node); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
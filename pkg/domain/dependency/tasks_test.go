@@ -0,0 +1,46 @@
+package dependency
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+)
+
+func TestTaskGraph(t *testing.T) {
+	g := TaskGraph{}
+	g.AddPlan("api", &planning.Plan{Tasks: []planning.Task{
+		{ID: "task-auth"},
+		{ID: "task-users", DependsOn: []string{"task-auth", "@web:task-ui"}},
+	}})
+	g.AddPlan("", &planning.Plan{Tasks: []planning.Task{{ID: "task-root", DependsOn: []string{"@api:task-auth"}}}})
+
+	if got := g["@api:task-users"]; !slices.Equal(got, []string{"@api:task-auth", "@web:task-ui"}) {
+		t.Errorf("edges = %v", got)
+	}
+	if got := g["@:task-root"]; !slices.Equal(got, []string{"@api:task-auth"}) {
+		t.Errorf("edges = %v", got)
+	}
+	if cycle := g.Cycle(); cycle != nil {
+		t.Fatalf("unexpected cycle %v", cycle)
+	}
+
+	g.AddPlan("web", &planning.Plan{Tasks: []planning.Task{{ID: "task-ui", DependsOn: []string{"@api:task-users"}}}})
+	want := []string{"@api:task-users", "@web:task-ui", "@api:task-users"}
+	if cycle := g.Cycle(); !slices.Equal(cycle, want) {
+		t.Errorf("cycle = %v, want %v", cycle, want)
+	}
+}
+
+func TestTaskDependency_Met(t *testing.T) {
+	for status, want := range map[planning.TaskStatus]bool{
+		planning.StatusDone:     true,
+		planning.StatusVerified: true,
+		planning.StatusPending:  false,
+		StatusMissing:           false,
+	} {
+		if got := (TaskDependency{Status: status}).Met(); got != want {
+			t.Errorf("Met() for %s = %v, want %v", status, got, want)
+		}
+	}
+}
@@ -1,7 +1,10 @@
 package drift
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	"github.com/felixgeelhaar/roady/pkg/domain/policy"
@@ -9,32 +12,171 @@ import (
 )
 
 // DriftDetector is a domain service that detects various types of drift
-// between spec, plan, code, and policy.
-type DriftDetector struct{}
+// between spec, plan, code, and policy. Checks are DriftRules held in a
+// registry; the built-in intent, plan, code and policy rules are registered
+// by NewDriftDetector and further rules can be added with Register.
+type DriftDetector struct {
+	registry *Registry
+}
 
-// NewDriftDetector creates a new DriftDetector instance.
+// NewDriftDetector creates a new DriftDetector instance with the built-in rules.
 func NewDriftDetector() *DriftDetector {
-	return &DriftDetector{}
+	d := &DriftDetector{registry: NewRegistry()}
+	for _, rule := range builtinRules(d) {
+		_ = d.registry.Register(rule) // Built-in IDs are unique.
+	}
+	return d
+}
+
+// Register adds a project-specific rule to the detector.
+func (d *DriftDetector) Register(rule DriftRule) error {
+	return d.registry.Register(rule)
+}
+
+// Registry exposes the detector's rule registry.
+func (d *DriftDetector) Registry() *Registry {
+	return d.registry
+}
+
+// ValidateConfig checks that every configured rule exists and that severity
+// overrides are valid.
+func (d *DriftDetector) ValidateConfig(cfg policy.DriftConfig) error {
+	for id, rc := range cfg.Rules {
+		if _, ok := d.registry.Lookup(id); !ok {
+			return fmt.Errorf("drift.rules: unknown rule %q (known: %s)", id, strings.Join(d.registry.IDs(), ", "))
+		}
+		if rc.Severity != "" {
+			if _, err := ParseSeverity(rc.Severity); err != nil {
+				return fmt.Errorf("drift.rules.%s: %w", id, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Detect runs every enabled rule in registration order. Each returned issue
+// carries the ID of the rule that produced it, with the configured severity
+// override applied.
+func (d *DriftDetector) Detect(in Input, cfg policy.DriftConfig) ([]Issue, error) {
+	if err := d.ValidateConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	issues := make([]Issue, 0)
+	for _, rule := range d.registry.Rules() {
+		rc := cfg.Rules[rule.ID()]
+		if !rc.IsEnabled() {
+			continue
+		}
+		for _, issue := range rule.Detect(in) {
+			issue.RuleID = rule.ID()
+			if rc.Severity != "" {
+				issue.Severity = Severity(rc.Severity)
+			}
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
 }
 
 // DetectIntentDrift checks if the current spec differs from the locked spec snapshot.
+// It is DetectSpecChanges without task linkage.
 func (d *DriftDetector) DetectIntentDrift(current, locked *spec.ProductSpec) []Issue {
+	return d.DetectSpecChanges(current, locked, nil)
+}
+
+// DetectSpecChanges diffs the current spec against the locked snapshot and
+// returns one issue per semantic change, pointing at the changed feature,
+// requirement or constraint and at the plan tasks derived from it.
+func (d *DriftDetector) DetectSpecChanges(current, locked *spec.ProductSpec, plan *planning.Plan) []Issue {
 	if locked == nil {
 		return nil
 	}
 
-	if current.Hash() != locked.Hash() {
-		return []Issue{{
-			ID:       "intent-drift",
-			Type:     DriftTypeSpec,
-			Category: CategoryMismatch,
-			Severity: SeverityMedium,
-			Message:  "The Specification has changed since the Plan was last updated. Your intent and plan may be out of sync.",
-			Hint:     "Review the changes and run 'roady plan generate' to align your plan with the new Spec.",
-		}}
+	diff := spec.Diff(locked, current)
+	issues := make([]Issue, 0, len(diff.Changes))
+	for _, c := range diff.Changes {
+		issues = append(issues, intentIssue(c, derivedTasks(c, plan)))
+	}
+	return issues
+}
+
+// intentIssue maps a spec change to a drift issue.
+func intentIssue(c spec.Change, taskIDs []string) Issue {
+	id := fmt.Sprintf("intent-%s-%s-%s", c.Kind, c.Element, c.ID)
+	if c.Field != "" && c.Field != "id" {
+		id = fmt.Sprintf("%s-%s", id, c.Field)
 	}
 
-	return nil
+	issue := Issue{
+		ID:          id,
+		Type:        DriftTypeSpec,
+		Category:    CategoryMismatch,
+		Severity:    SeverityMedium,
+		ComponentID: c.ID,
+		Path:        c.Source.Doc,
+		Line:        c.Source.Line,
+		TaskIDs:     taskIDs,
+		Hint:        "Review the change and run 'roady plan generate' to align your plan, or 'roady drift accept' to lock the new Spec.",
+	}
+
+	switch c.Kind {
+	case spec.ChangeAdded:
+		issue.Category = CategoryMissing
+		issue.Message = fmt.Sprintf("%s '%s' (%s) was added to the Spec since it was locked.", elementLabel(c.Element), c.ID, c.New)
+	case spec.ChangeRemoved:
+		issue.Category = CategoryOrphan
+		issue.Severity = SeverityHigh
+		issue.Message = fmt.Sprintf("%s '%s' (%s) was removed from the Spec since it was locked.", elementLabel(c.Element), c.ID, c.Old)
+	case spec.ChangeRenamed:
+		if c.OldID != "" {
+			issue.Message = fmt.Sprintf("%s '%s' was re-identified as '%s'.", elementLabel(c.Element), c.OldID, c.ID)
+		} else {
+			issue.Message = fmt.Sprintf("%s '%s' was renamed from '%s' to '%s'.", elementLabel(c.Element), c.ID, c.Old, c.New)
+		}
+	case spec.ChangeModified:
+		switch c.Field {
+		case "priority", "estimate", "depends_on", "files", "version":
+			issue.Severity = SeverityLow
+		}
+		issue.Message = fmt.Sprintf("%s '%s' changed %s from '%s' to '%s'.", elementLabel(c.Element), c.ID, c.Field, c.Old, c.New)
+	}
+	return issue
+}
+
+// derivedTasks returns the IDs of plan tasks implementing the changed element:
+// the task-<id> task for a requirement, every task of a feature.
+func derivedTasks(c spec.Change, plan *planning.Plan) []string {
+	if plan == nil {
+		return nil
+	}
+	var ids []string
+	for _, t := range plan.Tasks {
+		switch c.Element {
+		case spec.ElementRequirement:
+			if t.ID == "task-"+c.ID || (c.OldID != "" && t.ID == "task-"+c.OldID) {
+				ids = append(ids, t.ID)
+			}
+		case spec.ElementFeature:
+			if t.FeatureID == c.ID || (c.OldID != "" && t.FeatureID == c.OldID) {
+				ids = append(ids, t.ID)
+			}
+		}
+	}
+	return ids
+}
+
+func elementLabel(e spec.ElementKind) string {
+	switch e {
+	case spec.ElementFeature:
+		return "Feature"
+	case spec.ElementRequirement:
+		return "Requirement"
+	case spec.ElementConstraint:
+		return "Constraint"
+	default:
+		return "Spec"
+	}
 }
 
 // DetectPlanDrift checks for mismatches between the spec and plan.
@@ -66,6 +208,8 @@ func (d *DriftDetector) DetectPlanDrift(s *spec.ProductSpec, plan *planning.Plan
 					Category:    CategoryMissing,
 					Severity:    SeverityHigh,
 					ComponentID: r.ID,
+					Path:        r.Source.Doc,
+					Line:        r.Source.Line,
 					Message:     fmt.Sprintf("Requirement '%s' (Feature: %s) is missing from Plan.", r.Title, f.Title),
 					Hint:        "Run 'roady plan generate' to update your plan.",
 				})
@@ -84,6 +228,8 @@ func (d *DriftDetector) DetectPlanDrift(s *spec.ProductSpec, plan *planning.Plan
 					Category:    CategoryOrphan,
 					Severity:    SeverityMedium,
 					ComponentID: t.ID,
+					Path:        t.Source.Doc,
+					Line:        t.Source.Line,
 					Message:     fmt.Sprintf("Task '%s' (ID: %s) exists in Plan but corresponds to no active Feature or Requirement in Spec.", t.Title, t.ID),
 					Hint:        "Run 'roady plan prune' to remove orphan tasks or update your Spec to include this intent.",
 				})
@@ -96,6 +242,8 @@ func (d *DriftDetector) DetectPlanDrift(s *spec.ProductSpec, plan *planning.Plan
 
 // DetectCodeDrift checks for mismatches between the plan/state and actual code.
 // Returns issues for missing files, empty files, and uncommitted changes.
+// When the inspector is a GitInspector, completed tasks that declare Files
+// are also checked against their evidence commits and later history.
 func (d *DriftDetector) DetectCodeDrift(plan *planning.Plan, state *planning.ExecutionState, inspector CodeInspector) []Issue {
 	issues := make([]Issue, 0)
 
@@ -119,6 +267,7 @@ func (d *DriftDetector) DetectCodeDrift(plan *planning.Plan, state *planning.Exe
 					Category:    CategoryImplementation,
 					Severity:    SeverityCritical,
 					ComponentID: task.ID,
+					Path:        result.Path,
 					Message:     fmt.Sprintf("Task '%s' is DONE but path '%s' is missing.", task.Title, result.Path),
 					Hint:        "Restore the missing file or mark the task as incomplete using 'roady task reopen'.",
 				})
@@ -132,6 +281,7 @@ func (d *DriftDetector) DetectCodeDrift(plan *planning.Plan, state *planning.Exe
 						Category:    CategoryImplementation,
 						Severity:    SeverityHigh,
 						ComponentID: task.ID,
+						Path:        result.Path,
 						Message:     fmt.Sprintf("Task '%s' is DONE but file '%s' is empty.", task.Title, result.Path),
 						Hint:        "Ensure the task implementation is committed to the file.",
 					})
@@ -146,6 +296,7 @@ func (d *DriftDetector) DetectCodeDrift(plan *planning.Plan, state *planning.Exe
 						Category:    CategoryImplementation,
 						Severity:    SeverityMedium,
 						ComponentID: task.ID,
+						Path:        result.Path,
 						Message:     fmt.Sprintf("Task '%s' is DONE but file '%s' is %s (not committed).", task.Title, result.Path, gitStatus),
 						Hint:        "Commit your changes to verify the task completion.",
 					})
@@ -154,9 +305,145 @@ func (d *DriftDetector) DetectCodeDrift(plan *planning.Plan, state *planning.Exe
 		}
 	}
 
+	if gi, ok := inspector.(GitInspector); ok {
+		for _, task := range plan.Tasks {
+			issues = append(issues, d.detectOwnedCodeDrift(task, state.TaskStates[task.ID], gi)...)
+		}
+	}
+
 	return issues
 }
 
+// detectOwnedCodeDrift checks a done or verified task's declared files
+// against git: the files must still exist, the evidence commits must exist,
+// touch them and not be reverted, and no later commit may have modified them.
+func (d *DriftDetector) detectOwnedCodeDrift(task planning.Task, result planning.TaskResult, gi GitInspector) []Issue {
+	if len(task.Files) == 0 || (result.Status != planning.StatusDone && result.Status != planning.StatusVerified) {
+		return nil
+	}
+
+	issues := make([]Issue, 0)
+	files := strings.Join(task.Files, ", ")
+	newIssue := func(kind string, severity Severity, commits []string, msg, hint string) Issue {
+		return Issue{
+			ID:          fmt.Sprintf("%s-%s", kind, task.ID),
+			Type:        DriftTypeCode,
+			Category:    CategoryImplementation,
+			Severity:    severity,
+			ComponentID: task.ID,
+			Path:        task.Source.Doc,
+			Line:        task.Source.Line,
+			TaskIDs:     []string{task.ID},
+			Commits:     commits,
+			Message:     msg,
+			Hint:        hint,
+		}
+	}
+
+	tracked, err := gi.TrackedFiles(task.Files)
+	if err != nil {
+		return nil // git unavailable; the per-path checks still apply
+	}
+	if len(tracked) == 0 {
+		issues = append(issues, newIssue("missing-owned-code", SeverityCritical, nil,
+			fmt.Sprintf("Task '%s' is %s but none of its files (%s) exist in the repository.", task.Title, result.Status, files),
+			"Restore the deleted code or reopen the task with 'roady task reopen'."))
+	}
+
+	evidence := EvidenceCommits(result.Evidence)
+	var known, touched, deleted, unknown []string
+	for _, c := range evidence {
+		changed, err := gi.CommitFiles(c, task.Files)
+		if errors.Is(err, ErrCommitNotFound) {
+			unknown = append(unknown, c)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		known = append(known, c)
+		if len(changed) > 0 {
+			touched = append(touched, c)
+		}
+		for _, f := range changed {
+			if exists, _ := gi.FileExists(f); !exists && !slices.Contains(deleted, f) {
+				deleted = append(deleted, f)
+			}
+		}
+
+		if revert, _ := gi.RevertedBy(c); revert != "" {
+			issue := newIssue("reverted-code", SeverityHigh, []string{c, revert},
+				fmt.Sprintf("Evidence commit %s of task '%s' was reverted by %s.", shortHash(c), task.Title, shortHash(revert)),
+				"Re-apply the change or reopen the task with 'roady task reopen'.")
+			issue.ID = fmt.Sprintf("reverted-code-%s-%s", task.ID, shortHash(c))
+			issues = append(issues, issue)
+		}
+	}
+
+	if len(unknown) > 0 {
+		issues = append(issues, newIssue("unknown-commit", SeverityMedium, unknown,
+			fmt.Sprintf("Task '%s' cites commits that are not in the repository: %s.", task.Title, strings.Join(unknown, ", ")),
+			"The commits may have been rebased away; record the current hashes as evidence."))
+	}
+	if len(known) > 0 && len(touched) == 0 {
+		issues = append(issues, newIssue("untouched-code", SeverityMedium, known,
+			fmt.Sprintf("Task '%s' evidence commits (%s) touch none of its files (%s).", task.Title, strings.Join(shortHashes(known), ", "), files),
+			"Check the task's files globs or attach the commit that implemented it."))
+	}
+	if len(deleted) > 0 && len(tracked) > 0 {
+		issues = append(issues, newIssue("deleted-code", SeverityHigh, touched,
+			fmt.Sprintf("Files changed for task '%s' have since been deleted: %s.", task.Title, strings.Join(deleted, ", ")),
+			"Restore the files or reopen the task with 'roady task reopen'."))
+	}
+
+	if result.CompletedAt != nil {
+		later, err := gi.CommitsSince(*result.CompletedAt, task.Files)
+		if err == nil {
+			var refs, subjects []string
+			for _, c := range later {
+				if citedCommit(c.Hash, evidence) {
+					continue
+				}
+				refs = append(refs, c.Hash)
+				subjects = append(subjects, fmt.Sprintf("%s %s", shortHash(c.Hash), c.Subject))
+			}
+			if len(refs) > 0 {
+				issues = append(issues, newIssue("modified-after-done", SeverityMedium, refs,
+					fmt.Sprintf("Code owned by %s task '%s' changed after completion: %s.", result.Status, task.Title, strings.Join(subjects, "; ")),
+					"Review the changes and re-verify the task, or attach the commits as evidence."))
+			}
+		}
+	}
+
+	return issues
+}
+
+// citedCommit reports whether hash matches one of the (possibly
+// abbreviated) evidence commits.
+func citedCommit(hash string, evidence []string) bool {
+	for _, e := range evidence {
+		if strings.HasPrefix(hash, e) || strings.HasPrefix(e, hash) {
+			return true
+		}
+	}
+	return false
+}
+
+func shortHash(h string) string {
+	if len(h) > 8 {
+		return h[:8]
+	}
+	return h
+}
+
+func shortHashes(hs []string) []string {
+	out := make([]string, len(hs))
+	for i, h := range hs {
+		out[i] = shortHash(h)
+	}
+	return out
+}
+
 // DetectPolicyDrift converts policy violations to drift issues.
 func (d *DriftDetector) DetectPolicyDrift(violations []policy.Violation) []Issue {
 	issues := make([]Issue, 0, len(violations))
@@ -167,13 +454,19 @@ func (d *DriftDetector) DetectPolicyDrift(violations []policy.Violation) []Issue
 			severity = SeverityMedium
 		}
 
+		id := fmt.Sprintf("policy-%s", v.RuleID)
+		if v.TaskID != "" {
+			id = fmt.Sprintf("%s-%s", id, v.TaskID)
+		}
+
 		issues = append(issues, Issue{
-			ID:       fmt.Sprintf("policy-%s", v.RuleID),
-			Type:     DriftTypePolicy,
-			Category: CategoryViolation,
-			Severity: severity,
-			Message:  v.Message,
-			Hint:     "Adjust your execution state or update policy.yaml to resolve this violation.",
+			ID:          id,
+			Type:        DriftTypePolicy,
+			Category:    CategoryViolation,
+			Severity:    severity,
+			ComponentID: v.TaskID,
+			Message:     v.Message,
+			Hint:        "Adjust your execution state or update policy.yaml to resolve this violation.",
 		})
 	}
 
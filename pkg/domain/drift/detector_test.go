@@ -1,7 +1,9 @@
 package drift_test
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/felixgeelhaar/roady/pkg/domain/drift"
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
@@ -37,6 +39,47 @@ func TestDriftDetector_DetectIntentDrift(t *testing.T) {
 	}
 }
 
+func TestDriftDetector_DetectSpecChanges(t *testing.T) {
+	detector := drift.NewDriftDetector()
+
+	locked := &spec.ProductSpec{ID: "p", Features: []spec.Feature{
+		{ID: "f1", Title: "Auth", Requirements: []spec.Requirement{
+			{ID: "r1", Title: "Login", Description: "Email login"},
+			{ID: "r2", Title: "Logout"},
+		}},
+	}}
+	current := &spec.ProductSpec{ID: "p", Features: []spec.Feature{
+		{ID: "f1", Title: "Auth", Requirements: []spec.Requirement{
+			{ID: "r1", Title: "Login", Description: "Email and SSO login", Source: spec.Source{Doc: "docs/auth.md", Line: 12}},
+		}},
+	}}
+	plan := &planning.Plan{Tasks: []planning.Task{
+		{ID: "task-r1", FeatureID: "f1"},
+		{ID: "task-r2", FeatureID: "f1"},
+	}}
+
+	issues := detector.DetectSpecChanges(current, locked, plan)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+
+	modified := issues[0]
+	if modified.ID != "intent-modified-requirement-r1-description" || modified.Category != drift.CategoryMismatch {
+		t.Errorf("unexpected modified issue: %+v", modified)
+	}
+	if modified.Path != "docs/auth.md" || modified.Line != 12 {
+		t.Errorf("expected source location docs/auth.md:12, got %s:%d", modified.Path, modified.Line)
+	}
+	if len(modified.TaskIDs) != 1 || modified.TaskIDs[0] != "task-r1" {
+		t.Errorf("expected derived task task-r1, got %v", modified.TaskIDs)
+	}
+
+	removed := issues[1]
+	if removed.Category != drift.CategoryOrphan || removed.Severity != drift.SeverityHigh || removed.ComponentID != "r2" {
+		t.Errorf("unexpected removed issue: %+v", removed)
+	}
+}
+
 func TestDriftDetector_DetectPlanDrift(t *testing.T) {
 	detector := drift.NewDriftDetector()
 
@@ -138,6 +181,109 @@ func TestDriftDetector_DetectCodeDrift(t *testing.T) {
 	}
 }
 
+type mockGitInspector struct {
+	mockInspector
+	tracked  []string
+	commits  map[string][]string // commit -> owned files it changed
+	reverted map[string]string
+	later    []drift.Commit
+}
+
+func (m *mockGitInspector) TrackedFiles(patterns []string) ([]string, error) { return m.tracked, nil }
+func (m *mockGitInspector) CommitFiles(commit string, patterns []string) ([]string, error) {
+	files, ok := m.commits[commit]
+	if !ok {
+		return nil, drift.ErrCommitNotFound
+	}
+	return files, nil
+}
+func (m *mockGitInspector) RevertedBy(commit string) (string, error) { return m.reverted[commit], nil }
+func (m *mockGitInspector) CommitsSince(since time.Time, patterns []string) ([]drift.Commit, error) {
+	return m.later, nil
+}
+
+func TestDriftDetector_DetectCodeDrift_OwnedFiles(t *testing.T) {
+	detector := drift.NewDriftDetector()
+	done := time.Now().Add(-time.Hour)
+	plan := &planning.Plan{Tasks: []planning.Task{
+		{ID: "t1", Title: "Login", Files: []string{"pkg/auth/**"}},
+		{ID: "t2", Title: "No files"},
+	}}
+	state := &planning.ExecutionState{TaskStates: map[string]planning.TaskResult{
+		"t1": {Status: planning.StatusVerified, CompletedAt: &done, Evidence: []string{"Commit: aaaa1111", "see 9999ffff"}},
+		"t2": {Status: planning.StatusDone, Evidence: []string{"Commit: aaaa1111"}},
+	}}
+
+	// Healthy: evidence touches the files, nothing reverted, nothing later.
+	gi := &mockGitInspector{
+		mockInspector: mockInspector{exists: true, notEmpty: true, status: "clean"},
+		tracked:       []string{"pkg/auth/login.go"},
+		commits:       map[string][]string{"aaaa1111": {"pkg/auth/login.go"}, "9999ffff": nil},
+	}
+	if issues := detector.DetectCodeDrift(plan, state, gi); len(issues) != 0 {
+		t.Fatalf("expected no issues, got %+v", issues)
+	}
+
+	// Reverted evidence, later modification, unknown commit.
+	gi.reverted = map[string]string{"aaaa1111": "bbbb2222"}
+	gi.later = []drift.Commit{{Hash: "aaaa1111ffff"}, {Hash: "cccc3333", Subject: "refactor auth"}}
+	delete(gi.commits, "9999ffff")
+
+	issues := detector.DetectCodeDrift(plan, state, gi)
+	byKind := map[string]drift.Issue{}
+	for _, i := range issues {
+		if i.Category != drift.CategoryImplementation || i.ComponentID != "t1" {
+			t.Errorf("unexpected issue %+v", i)
+		}
+		byKind[i.ID] = i
+	}
+	if r, ok := byKind["reverted-code-t1-aaaa1111"]; !ok || len(r.Commits) != 2 || r.Commits[1] != "bbbb2222" {
+		t.Errorf("expected reverted-code issue with commit refs, got %+v", issues)
+	}
+	if m, ok := byKind["modified-after-done-t1"]; !ok || len(m.Commits) != 1 || m.Commits[0] != "cccc3333" {
+		t.Errorf("expected modified-after-done issue excluding evidence commit, got %+v", m)
+	}
+	if _, ok := byKind["unknown-commit-t1"]; !ok {
+		t.Errorf("expected unknown-commit issue, got %+v", issues)
+	}
+
+	// Owned files gone entirely.
+	gi = &mockGitInspector{
+		mockInspector: mockInspector{exists: false},
+		commits:       map[string][]string{"aaaa1111": {"pkg/auth/login.go"}, "9999ffff": nil},
+	}
+	issues = detector.DetectCodeDrift(plan, state, gi)
+	if len(issues) != 1 || issues[0].ID != "missing-owned-code-t1" || issues[0].Severity != drift.SeverityCritical {
+		t.Errorf("expected a single missing-owned-code issue, got %+v", issues)
+	}
+
+	// Evidence that touches none of the files.
+	gi = &mockGitInspector{
+		mockInspector: mockInspector{exists: true},
+		tracked:       []string{"pkg/auth/login.go"},
+		commits:       map[string][]string{"aaaa1111": nil, "9999ffff": nil},
+	}
+	issues = detector.DetectCodeDrift(plan, state, gi)
+	if len(issues) != 1 || issues[0].ID != "untouched-code-t1" {
+		t.Errorf("expected untouched-code issue, got %+v", issues)
+	}
+}
+
+func TestEvidenceCommits(t *testing.T) {
+	got := drift.EvidenceCommits([]string{"Commit: 3f2a9c1d", "defaced by 3f2a9c1d", "https://x/pull/1", "abc1234 and 0123456789abcdef",
+		"CI run 1234567890 passed on 20261015",
+		"verify-run: `make check` exit 0 in 2s (output sha256 " + strings.Repeat("0a", 32) + ")"})
+	want := []string{"3f2a9c1d", "abc1234", "0123456789abcdef"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	}
+}
+
 func TestDriftDetector_DetectPolicyDrift(t *testing.T) {
 	detector := drift.NewDriftDetector()
 
@@ -161,3 +307,103 @@ func TestDriftDetector_DetectPolicyDrift(t *testing.T) {
 		t.Errorf("expected high severity for error, got %s", issues[1].Severity)
 	}
 }
+
+func TestDriftDetector_Detect_StampsRuleIDs(t *testing.T) {
+	detector := drift.NewDriftDetector()
+
+	in := drift.Input{
+		Spec: &spec.ProductSpec{
+			ID:       "test",
+			Features: []spec.Feature{{ID: "f1", Requirements: []spec.Requirement{{ID: "r1", Title: "Req 1"}}}},
+		},
+		Plan:       &planning.Plan{},
+		Violations: []policy.Violation{{RuleID: "max-wip", Level: policy.ViolationWarning, Message: "too much"}},
+	}
+
+	issues, err := detector.Detect(in, policy.DriftConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules := map[string]int{}
+	for _, i := range issues {
+		rules[i.RuleID]++
+	}
+	if rules[drift.RulePlan] != 1 || rules[drift.RulePolicy] != 1 {
+		t.Fatalf("unexpected rule attribution: %v", rules)
+	}
+}
+
+func TestDriftDetector_Detect_ConfigOverrides(t *testing.T) {
+	detector := drift.NewDriftDetector()
+	disabled := false
+
+	in := drift.Input{
+		Spec: &spec.ProductSpec{
+			ID:       "test",
+			Features: []spec.Feature{{ID: "f1", Requirements: []spec.Requirement{{ID: "r1", Title: "Req 1"}}}},
+		},
+		Plan:       &planning.Plan{},
+		Violations: []policy.Violation{{RuleID: "max-wip", Message: "too much"}},
+	}
+	cfg := policy.DriftConfig{Rules: map[string]policy.DriftRuleConfig{
+		drift.RulePolicy: {Enabled: &disabled},
+		drift.RulePlan:   {Severity: "low"},
+	}}
+
+	issues, err := detector.Detect(in, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("expected only the plan issue, got %d", len(issues))
+	}
+	if issues[0].Severity != drift.SeverityLow {
+		t.Errorf("expected severity override to low, got %s", issues[0].Severity)
+	}
+}
+
+func TestDriftDetector_Detect_InvalidConfig(t *testing.T) {
+	detector := drift.NewDriftDetector()
+
+	if _, err := detector.Detect(drift.Input{}, policy.DriftConfig{Rules: map[string]policy.DriftRuleConfig{"nope": {}}}); err == nil {
+		t.Error("expected error for unknown rule")
+	}
+	if _, err := detector.Detect(drift.Input{}, policy.DriftConfig{Rules: map[string]policy.DriftRuleConfig{drift.RulePlan: {Severity: "urgent"}}}); err == nil {
+		t.Error("expected error for invalid severity")
+	}
+}
+
+func TestDriftDetector_RegisterCustomRule(t *testing.T) {
+	detector := drift.NewDriftDetector()
+	custom := drift.NewRule("deleted-source", "Tasks whose source doc was deleted", func(in drift.Input) []drift.Issue {
+		var issues []drift.Issue
+		for _, task := range in.Plan.Tasks {
+			if task.Source.Doc == "gone.md" {
+				issues = append(issues, drift.Issue{ID: "deleted-source-" + task.ID, ComponentID: task.ID, Severity: drift.SeverityMedium})
+			}
+		}
+		return issues
+	})
+	if err := detector.Register(custom); err != nil {
+		t.Fatal(err)
+	}
+	if err := detector.Register(custom); err == nil {
+		t.Error("expected duplicate registration to fail")
+	}
+
+	in := drift.Input{
+		Spec: &spec.ProductSpec{ID: "test"},
+		Plan: &planning.Plan{Tasks: []planning.Task{{ID: "t1", FeatureID: "f1", Source: planning.TaskSource{Doc: "gone.md"}}}},
+	}
+	cfg := policy.DriftConfig{Rules: map[string]policy.DriftRuleConfig{"deleted-source": {Severity: "high"}}}
+	issues, err := detector.Detect(in, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := &drift.Report{Issues: issues}
+	filtered := report.FilterByRule("deleted-source")
+	if len(filtered.Issues) != 1 || filtered.Issues[0].Severity != drift.SeverityHigh {
+		t.Fatalf("unexpected filtered issues: %+v", filtered.Issues)
+	}
+}
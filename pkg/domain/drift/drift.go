@@ -33,6 +33,22 @@ const (
 	SeverityCritical Severity = "critical"
 )
 
+// Rank orders severities from low (1) to critical (4). Unknown values rank 0.
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityLow:
+		return 1
+	case SeverityMedium:
+		return 2
+	case SeverityHigh:
+		return 3
+	case SeverityCritical:
+		return 4
+	default:
+		return 0
+	}
+}
+
 // Issue represents a single detected discrepancy.
 type Issue struct {
 	ID          string        `json:"id" yaml:"id"`
@@ -41,8 +57,12 @@ type Issue struct {
 	Severity    Severity      `json:"severity" yaml:"severity"`
 	ComponentID string        `json:"component_id" yaml:"component_id"` // ID of the feature or task
 	Message     string        `json:"message" yaml:"message"`
-	Path        string        `json:"path" yaml:"path"` // Path to the source of drift
-	Hint        string        `json:"hint" yaml:"hint"` // Suggested resolution
+	Path        string        `json:"path" yaml:"path"`                             // Path to the source of drift
+	Line        int           `json:"line,omitempty" yaml:"line,omitempty"`         // 1-based line in Path, when known
+	TaskIDs     []string      `json:"task_ids,omitempty" yaml:"task_ids,omitempty"` // Plan tasks affected by the issue
+	Commits     []string      `json:"commits,omitempty" yaml:"commits,omitempty"`   // Git commits the issue refers to
+	Hint        string        `json:"hint" yaml:"hint"`                             // Suggested resolution
+	RuleID      string        `json:"rule_id,omitempty" yaml:"rule_id,omitempty"`   // ID of the DriftRule that raised the issue
 }
 
 // Report is a collection of issues found during a drift detection run.
@@ -52,6 +72,25 @@ type Report struct {
 	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
 }
 
+// FilterByRule returns a copy of the report containing only issues raised by
+// the given rules. With no rule IDs the report is returned unchanged.
+func (r *Report) FilterByRule(ruleIDs ...string) *Report {
+	if len(ruleIDs) == 0 {
+		return r
+	}
+	keep := make(map[string]bool, len(ruleIDs))
+	for _, id := range ruleIDs {
+		keep[id] = true
+	}
+	filtered := &Report{ID: r.ID, CreatedAt: r.CreatedAt, Issues: make([]Issue, 0)}
+	for _, i := range r.Issues {
+		if keep[i.RuleID] {
+			filtered.Issues = append(filtered.Issues, i)
+		}
+	}
+	return filtered
+}
+
 func (r *Report) HasCriticalDrift() bool {
 	for _, i := range r.Issues {
 		if i.Severity == SeverityCritical {
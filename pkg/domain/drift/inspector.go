@@ -1,5 +1,12 @@
 package drift
 
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
 // CodeInspector defines the interface for inspecting the actual codebase.
 type CodeInspector interface {
 	// FileExists checks if a file exists at the given path.
@@ -9,3 +16,54 @@ type CodeInspector interface {
 	// GitStatus returns the git status of the file ("clean", "modified", "untracked", "ignored", "missing", or "error").
 	GitStatus(path string) (string, error)
 }
+
+// ErrCommitNotFound is returned by GitInspector when a commit does not exist
+// in the repository.
+var ErrCommitNotFound = errors.New("commit not found")
+
+// Commit is a git commit as reported by a GitInspector.
+type Commit struct {
+	Hash    string
+	Time    time.Time
+	Subject string
+}
+
+// GitInspector extends CodeInspector with history checks for tasks that
+// declare the files they own. DetectCodeDrift uses it when the inspector
+// implements it. Patterns are git glob pathspecs relative to the repository
+// root; a plain directory matches everything beneath it.
+type GitInspector interface {
+	CodeInspector
+	// TrackedFiles returns the tracked files matching patterns.
+	TrackedFiles(patterns []string) ([]string, error)
+	// CommitFiles returns the files changed by commit that match patterns,
+	// or ErrCommitNotFound.
+	CommitFiles(commit string, patterns []string) ([]string, error)
+	// RevertedBy returns the hash of a commit that reverts commit, or "".
+	RevertedBy(commit string) (string, error)
+	// CommitsSince returns the commits after since that modify files
+	// matching patterns, oldest first.
+	CommitsSince(since time.Time, patterns []string) ([]Commit, error)
+}
+
+// commitPattern matches abbreviated or full commit hashes. EvidenceCommits
+// also requires a digit and a letter, so hex-only words such as "defaced"
+// and numbers such as CI run IDs or dates are not mistaken for hashes.
+var commitPattern = regexp.MustCompile(`\b[0-9a-f]{7,40}\b`)
+
+// EvidenceCommits extracts commit hashes from task evidence entries such as
+// "Commit: 3f2a9c1..." recorded by git sync, in order and without duplicates.
+func EvidenceCommits(evidence []string) []string {
+	seen := make(map[string]bool)
+	var commits []string
+	for _, e := range evidence {
+		for _, m := range commitPattern.FindAllString(e, -1) {
+			if seen[m] || !strings.ContainsAny(m, "0123456789") || !strings.ContainsAny(m, "abcdef") {
+				continue
+			}
+			seen[m] = true
+			commits = append(commits, m)
+		}
+	}
+	return commits
+}
@@ -0,0 +1,144 @@
+package drift
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/policy"
+	"github.com/felixgeelhaar/roady/pkg/domain/spec"
+)
+
+// Built-in drift rule IDs. These are the keys used under drift.rules in
+// policy.yaml and the values of Issue.RuleID.
+const (
+	RuleIntent = "intent" // Spec vs spec.lock
+	RulePlan   = "plan"   // Plan vs Spec
+	RuleCode   = "code"   // Code vs State
+	RulePolicy = "policy" // Policy vs State
+)
+
+// Input is the project snapshot a drift rule inspects. Fields may be nil
+// when the corresponding artifact does not exist.
+type Input struct {
+	Spec       *spec.ProductSpec
+	Lock       *spec.ProductSpec
+	Plan       *planning.Plan
+	State      *planning.ExecutionState
+	Inspector  CodeInspector
+	Violations []policy.Violation
+}
+
+// DriftRule is a single drift check. Implementations must be deterministic
+// and side-effect free; the detector stamps RuleID and applies configured
+// severity overrides to the issues they return.
+type DriftRule interface {
+	ID() string
+	Description() string
+	Detect(in Input) []Issue
+}
+
+// Registry holds the drift rules known to a detector, in registration order.
+type Registry struct {
+	rules []DriftRule
+	index map[string]int
+}
+
+// NewRegistry creates an empty rule registry.
+func NewRegistry() *Registry {
+	return &Registry{index: make(map[string]int)}
+}
+
+// Register adds a rule. Rule IDs must be unique.
+func (r *Registry) Register(rule DriftRule) error {
+	if rule == nil || rule.ID() == "" {
+		return fmt.Errorf("drift rule must have an id")
+	}
+	if _, exists := r.index[rule.ID()]; exists {
+		return fmt.Errorf("drift rule %q is already registered", rule.ID())
+	}
+	r.index[rule.ID()] = len(r.rules)
+	r.rules = append(r.rules, rule)
+	return nil
+}
+
+// Lookup returns the rule registered under id.
+func (r *Registry) Lookup(id string) (DriftRule, bool) {
+	i, ok := r.index[id]
+	if !ok {
+		return nil, false
+	}
+	return r.rules[i], true
+}
+
+// Rules returns all registered rules in registration order.
+func (r *Registry) Rules() []DriftRule {
+	out := make([]DriftRule, len(r.rules))
+	copy(out, r.rules)
+	return out
+}
+
+// IDs returns the registered rule IDs sorted alphabetically.
+func (r *Registry) IDs() []string {
+	ids := make([]string, 0, len(r.rules))
+	for _, rule := range r.rules {
+		ids = append(ids, rule.ID())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ParseSeverity validates a severity string from configuration.
+func ParseSeverity(s string) (Severity, error) {
+	switch sev := Severity(s); sev {
+	case SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical:
+		return sev, nil
+	default:
+		return "", fmt.Errorf("invalid severity %q (expected low, medium, high or critical)", s)
+	}
+}
+
+// ruleFunc adapts a detection function to the DriftRule interface.
+type ruleFunc struct {
+	id          string
+	description string
+	detect      func(in Input) []Issue
+}
+
+func (r ruleFunc) ID() string              { return r.id }
+func (r ruleFunc) Description() string     { return r.description }
+func (r ruleFunc) Detect(in Input) []Issue { return r.detect(in) }
+
+// NewRule builds a DriftRule from a detection function, for project-specific
+// checks that do not need their own type.
+func NewRule(id, description string, detect func(in Input) []Issue) DriftRule {
+	return ruleFunc{id: id, description: description, detect: detect}
+}
+
+// builtinRules returns the checks that ship with Roady, backed by the
+// detector's Detect*Drift methods.
+func builtinRules(d *DriftDetector) []DriftRule {
+	return []DriftRule{
+		NewRule(RuleIntent, "Spec has changed since it was locked", func(in Input) []Issue {
+			if in.Spec == nil {
+				return nil
+			}
+			return d.DetectSpecChanges(in.Spec, in.Lock, in.Plan)
+		}),
+		NewRule(RulePlan, "Requirements without tasks and tasks without requirements", func(in Input) []Issue {
+			if in.Spec == nil {
+				return nil
+			}
+			return d.DetectPlanDrift(in.Spec, in.Plan)
+		}),
+		NewRule(RuleCode, "Completed tasks whose code is missing, empty or uncommitted", func(in Input) []Issue {
+			if in.Inspector == nil {
+				return nil
+			}
+			return d.DetectCodeDrift(in.Plan, in.State, in.Inspector)
+		}),
+		NewRule(RulePolicy, "Policy violations in the current execution state", func(in Input) []Issue {
+			return d.DetectPolicyDrift(in.Violations)
+		}),
+	}
+}
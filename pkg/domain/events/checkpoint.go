@@ -0,0 +1,74 @@
+package events
+
+import "errors"
+
+// CatchUp brings a projection up to date with the store. A SnapshotProjection
+// with a saved snapshot is restored from it and only the events appended
+// since its checkpoint are applied; otherwise, or if the checkpoint event is
+// no longer in the store, the projection is rebuilt from every event. The
+// new state is saved as the projection's snapshot. Snapshots are only a
+// cache, so failing to load or save one is not an error. With a nil
+// checkpoint store the projection is always rebuilt.
+//
+// Events recorded by the legacy audit log carry only an action; it is used
+// as their type.
+func CatchUp(p Projection, store EventStore, checkpoints ProjectionStore) error {
+	sp, resumable := p.(SnapshotProjection)
+	if checkpoints == nil || !resumable {
+		evts, err := store.LoadAll()
+		if err != nil {
+			return err
+		}
+		return p.Rebuild(withTypes(evts))
+	}
+
+	lastID, state, err := checkpoints.LoadSnapshot(p.Name())
+	if err != nil {
+		lastID, state = "", nil
+	}
+
+	evts, err := resume(sp, store, lastID, state)
+	if errors.Is(err, ErrEventNotFound) {
+		if evts, err = store.LoadAll(); err == nil {
+			err = p.Rebuild(withTypes(evts))
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(evts) == 0 {
+		return nil
+	}
+	if data, err := sp.Snapshot(); err == nil {
+		_ = checkpoints.SaveSnapshot(p.Name(), evts[len(evts)-1].ID, data)
+	}
+	return nil
+}
+
+// resume restores a snapshot and applies the events after it. It returns
+// ErrEventNotFound if there is no usable snapshot.
+func resume(p SnapshotProjection, store EventStore, lastID string, state []byte) ([]*BaseEvent, error) {
+	if lastID == "" || state == nil || p.Restore(state) != nil {
+		return nil, ErrEventNotFound
+	}
+	evts, err := store.LoadAfter(lastID)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range withTypes(evts) {
+		if err := p.Apply(e); err != nil {
+			return nil, err
+		}
+	}
+	return evts, nil
+}
+
+func withTypes(evts []*BaseEvent) []*BaseEvent {
+	for _, e := range evts {
+		if e.Type == "" {
+			e.Type = e.Action
+		}
+	}
+	return evts
+}
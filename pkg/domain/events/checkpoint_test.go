@@ -0,0 +1,183 @@
+package events_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+)
+
+// memoryStore is an in-memory EventStore that counts full reads.
+type memoryStore struct {
+	evts     []*events.BaseEvent
+	loadAlls int
+}
+
+func (s *memoryStore) Append(e *events.BaseEvent) error { s.evts = append(s.evts, e); return nil }
+func (s *memoryStore) LoadAll() ([]*events.BaseEvent, error) {
+	s.loadAlls++
+	return s.copyOf(s.evts), nil
+}
+func (s *memoryStore) LoadByAggregate(string, string) ([]*events.BaseEvent, error) { return nil, nil }
+func (s *memoryStore) LoadByType(string) ([]*events.BaseEvent, error)              { return nil, nil }
+func (s *memoryStore) LoadSince(time.Time) ([]*events.BaseEvent, error)            { return nil, nil }
+func (s *memoryStore) LoadRange(time.Time, time.Time) ([]*events.BaseEvent, error) { return nil, nil }
+func (s *memoryStore) GetLastEvent() (*events.BaseEvent, error)                    { return nil, nil }
+func (s *memoryStore) Count() (int, error)                                         { return len(s.evts), nil }
+func (s *memoryStore) LoadAfter(id string) ([]*events.BaseEvent, error) {
+	for i, e := range s.evts {
+		if e.ID == id {
+			return s.copyOf(s.evts[i+1:]), nil
+		}
+	}
+	return nil, events.ErrEventNotFound
+}
+
+func (s *memoryStore) copyOf(evts []*events.BaseEvent) []*events.BaseEvent {
+	return append([]*events.BaseEvent(nil), evts...)
+}
+
+// memoryCheckpoints is an in-memory ProjectionStore.
+type memoryCheckpoints struct {
+	ids    map[string]string
+	states map[string][]byte
+}
+
+func newMemoryCheckpoints() *memoryCheckpoints {
+	return &memoryCheckpoints{ids: map[string]string{}, states: map[string][]byte{}}
+}
+
+func (c *memoryCheckpoints) SaveCheckpoint(name, id string) error { c.ids[name] = id; return nil }
+func (c *memoryCheckpoints) LoadCheckpoint(name string) (string, error) {
+	return c.ids[name], nil
+}
+func (c *memoryCheckpoints) SaveSnapshot(name, id string, state []byte) error {
+	c.ids[name], c.states[name] = id, state
+	return nil
+}
+func (c *memoryCheckpoints) LoadSnapshot(name string) (string, []byte, error) {
+	return c.ids[name], c.states[name], nil
+}
+
+func taskEvent(id, eventType, taskID string) *events.BaseEvent {
+	return &events.BaseEvent{
+		ID:        id,
+		Type:      eventType,
+		Timestamp: time.Now(),
+		Metadata:  map[string]interface{}{"task_id": taskID},
+	}
+}
+
+func TestCatchUp_ResumesFromSnapshot(t *testing.T) {
+	store := &memoryStore{}
+	_ = store.Append(taskEvent("e1", events.EventTypeTaskStarted, "t1"))
+	_ = store.Append(taskEvent("e2", events.EventTypeTaskCompleted, "t1"))
+	checkpoints := newMemoryCheckpoints()
+
+	first := events.NewTaskStateProjection()
+	if err := events.CatchUp(first, store, checkpoints); err != nil {
+		t.Fatalf("CatchUp: %v", err)
+	}
+	if checkpoints.ids["task_state"] != "e2" || store.loadAlls != 1 {
+		t.Fatalf("expected a snapshot at e2 after one full read, got %q after %d", checkpoints.ids["task_state"], store.loadAlls)
+	}
+
+	// Legacy audit events only carry an action.
+	_ = store.Append(&events.BaseEvent{ID: "e3", Action: events.EventTypeTaskStarted, Metadata: map[string]interface{}{"task_id": "t2"}})
+
+	second := events.NewTaskStateProjection()
+	if err := events.CatchUp(second, store, checkpoints); err != nil {
+		t.Fatalf("CatchUp: %v", err)
+	}
+	if store.loadAlls != 1 {
+		t.Errorf("expected the second run to resume without a full read, got %d", store.loadAlls)
+	}
+	if s := second.GetState("t1"); s == nil || s.Status != planning.StatusDone {
+		t.Errorf("restored state for t1 = %+v", s)
+	}
+	if s := second.GetState("t2"); s == nil || s.Status != planning.StatusInProgress {
+		t.Errorf("state for t2 after resuming = %+v", s)
+	}
+	if checkpoints.ids["task_state"] != "e3" {
+		t.Errorf("expected the checkpoint to advance to e3, got %q", checkpoints.ids["task_state"])
+	}
+}
+
+func TestCatchUp_RebuildsWithoutUsableSnapshot(t *testing.T) {
+	store := &memoryStore{}
+	_ = store.Append(taskEvent("e1", events.EventTypeTaskCompleted, "t1"))
+	_ = store.Append(taskEvent("e2", events.EventTypeTaskVerified, "t1"))
+
+	// A checkpoint at an event that is no longer in the store.
+	checkpoints := newMemoryCheckpoints()
+	_ = checkpoints.SaveSnapshot("velocity", "gone", []byte(`{"completions":[]}`))
+	p := events.NewVelocityProjection(7)
+	if err := events.CatchUp(p, store, checkpoints); err != nil {
+		t.Fatalf("CatchUp: %v", err)
+	}
+	if store.loadAlls != 1 || p.GetCompletionVelocity() == 0 || p.GetVerificationVelocity() == 0 {
+		t.Errorf("expected a full rebuild, got %d reads", store.loadAlls)
+	}
+	if checkpoints.ids["velocity"] != "e2" {
+		t.Errorf("expected a fresh checkpoint, got %q", checkpoints.ids["velocity"])
+	}
+
+	// A corrupt snapshot is rebuilt too.
+	_ = checkpoints.SaveSnapshot("velocity", "e1", []byte("not json"))
+	if err := events.CatchUp(events.NewVelocityProjection(7), store, checkpoints); err != nil {
+		t.Fatalf("CatchUp: %v", err)
+	}
+	if store.loadAlls != 2 {
+		t.Errorf("expected a corrupt snapshot to be rebuilt, got %d reads", store.loadAlls)
+	}
+
+	// Without a checkpoint store every run rebuilds.
+	if err := events.CatchUp(events.NewVelocityProjection(7), store, nil); err != nil {
+		t.Fatalf("CatchUp: %v", err)
+	}
+	if store.loadAlls != 3 {
+		t.Errorf("expected a rebuild without checkpoints, got %d reads", store.loadAlls)
+	}
+}
+
+func TestProjectionSnapshots_RoundTrip(t *testing.T) {
+	now := time.Now()
+	evts := []*events.BaseEvent{
+		makeEvent(events.EventTypeTaskCompleted, "alice", now, map[string]interface{}{"task_id": "t1"}),
+		makeEvent(events.EventTypeDriftDetected, "cli", now, map[string]interface{}{
+			"component_id": "f1", "drift_type": "missing_task", "issue_count": 1,
+		}),
+	}
+
+	projections := []events.SnapshotProjection{
+		events.NewTaskStateProjection(),
+		events.NewVelocityProjection(7),
+		events.NewExtendedVelocityProjection(7, 30),
+		events.NewDriftHistoryProjection(),
+	}
+	for _, p := range projections {
+		if err := p.Rebuild(evts); err != nil {
+			t.Fatalf("%s: Rebuild: %v", p.Name(), err)
+		}
+		data, err := p.Snapshot()
+		if err != nil {
+			t.Fatalf("%s: Snapshot: %v", p.Name(), err)
+		}
+		if err := p.Reset(); err != nil {
+			t.Fatal(err)
+		}
+		if err := p.Restore(data); err != nil {
+			t.Fatalf("%s: Restore: %v", p.Name(), err)
+		}
+		again, _ := p.Snapshot()
+		if string(again) != string(data) {
+			t.Errorf("%s: snapshot changed after restore:\n%s\n%s", p.Name(), data, again)
+		}
+	}
+
+	drift := projections[3].(*events.DriftHistoryProjection)
+	if len(drift.GetDriftHistory()) != 1 || len(drift.GetActiveDebtItems()) != 1 {
+		t.Errorf("restored drift history = %+v", drift.GetDriftHistory())
+	}
+}
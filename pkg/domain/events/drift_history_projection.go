@@ -1,6 +1,7 @@
 package events
 
 import (
+	"encoding/json"
 	"sync"
 	"time"
 
@@ -162,6 +163,36 @@ func (p *DriftHistoryProjection) Reset() error {
 	return nil
 }
 
+// driftHistorySnapshot is the persisted state of a DriftHistoryProjection.
+type driftHistorySnapshot struct {
+	History  []DriftSnapshot           `json:"history"`
+	Items    map[string]*debt.DebtItem `json:"items"`
+	Resolved map[string]*debt.DebtItem `json:"resolved"`
+}
+
+func (p *DriftHistoryProjection) Snapshot() ([]byte, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return json.Marshal(driftHistorySnapshot{History: p.history, Items: p.items, Resolved: p.resolved})
+}
+
+func (p *DriftHistoryProjection) Restore(data []byte) error {
+	snap := driftHistorySnapshot{
+		History:  make([]DriftSnapshot, 0),
+		Items:    make(map[string]*debt.DebtItem),
+		Resolved: make(map[string]*debt.DebtItem),
+	}
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.history = snap.History
+	p.items = snap.Items
+	p.resolved = snap.Resolved
+	return nil
+}
+
 // GetActiveDebtItems returns all currently active debt items.
 func (p *DriftHistoryProjection) GetActiveDebtItems() []*debt.DebtItem {
 	p.mu.RLock()
@@ -34,6 +34,11 @@ type BaseEvent struct {
 	Metadata       map[string]interface{} `json:"metadata,omitempty"`
 	PrevHash       string                 `json:"prev_hash,omitempty"`
 	Hash           string                 `json:"hash,omitempty"`
+	Signer         string                 `json:"signer,omitempty"`
+	Signature      string                 `json:"signature,omitempty"`
+	// SignedPrevHash is the PrevHash the event was signed with, kept when a
+	// merge re-chains a signed event onto another log.
+	SignedPrevHash *string `json:"signed_prev_hash,omitempty"`
 }
 
 // Version returns the event version as an int.
@@ -65,6 +70,17 @@ func (e BaseEvent) AggregateID() string   { return e.AggregateID_ }
 func (e BaseEvent) AggregateType() string { return e.AggregateType_ }
 func (e BaseEvent) OccurredAt() time.Time { return e.Timestamp }
 
+// SignedHash returns the hash the signature covers: Hash, or for an event
+// re-chained by a merge, its hash at the position it was signed in.
+func (e *BaseEvent) SignedHash() string {
+	if e.SignedPrevHash == nil {
+		return e.Hash
+	}
+	signed := *e
+	signed.PrevHash = *e.SignedPrevHash
+	return signed.CalculateHash()
+}
+
 // CalculateHash generates a deterministic SHA256 hash of the event.
 func (e *BaseEvent) CalculateHash() string {
 	h := sha256.New()
@@ -236,11 +252,14 @@ const (
 	EventTypePlanCreated       = "plan.created"
 	EventTypePlanApproved      = "plan.approved"
 	EventTypePlanRejected      = "plan.rejected"
+	EventTypePlanRolledBack    = "plan.rolled_back"
 	EventTypeTaskStarted       = "task.started"
 	EventTypeTaskCompleted     = "task.completed"
 	EventTypeTaskVerified      = "task.verified"
+	EventTypeTaskVerifyRun     = "task.verification_run"
 	EventTypeTaskBlocked       = "task.blocked"
 	EventTypeTaskUnblocked     = "task.unblocked"
+	EventTypeTaskUnlocked      = "task.unlocked" // Dependencies in another project completed
 	EventTypeTaskTransitioned  = "task.transitioned"
 	EventTypeExternalRefLinked = "external_ref.linked"
 	EventTypeSyncCompleted     = "sync.completed"
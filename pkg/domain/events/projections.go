@@ -1,6 +1,7 @@
 package events
 
 import (
+	"encoding/json"
 	"sync"
 	"time"
 
@@ -52,7 +53,7 @@ func (p *TaskStateProjection) Apply(event *BaseEvent) error {
 
 	switch event.Type {
 	case EventTypeTaskStarted:
-		taskID := event.Metadata["task_id"].(string)
+		taskID := getStringMetadata(event.Metadata, "task_id")
 		state := p.getOrCreate(taskID)
 		state.Status = planning.StatusInProgress
 		state.Owner = event.Actor
@@ -60,49 +61,49 @@ func (p *TaskStateProjection) Apply(event *BaseEvent) error {
 		state.StartedAt = &ts
 
 	case EventTypeTaskCompleted:
-		taskID := event.Metadata["task_id"].(string)
+		taskID := getStringMetadata(event.Metadata, "task_id")
 		state := p.getOrCreate(taskID)
 		state.Status = planning.StatusDone
 		ts := event.Timestamp
 		state.CompletedAt = &ts
 
 	case EventTypeTaskVerified:
-		taskID := event.Metadata["task_id"].(string)
+		taskID := getStringMetadata(event.Metadata, "task_id")
 		state := p.getOrCreate(taskID)
 		state.Status = planning.StatusVerified
 		ts := event.Timestamp
 		state.VerifiedAt = &ts
 
 	case EventTypeTaskBlocked:
-		taskID := event.Metadata["task_id"].(string)
+		taskID := getStringMetadata(event.Metadata, "task_id")
 		state := p.getOrCreate(taskID)
 		state.Status = planning.StatusBlocked
 		ts := event.Timestamp
 		state.BlockedAt = &ts
 
 	case EventTypeTaskUnblocked:
-		taskID := event.Metadata["task_id"].(string)
+		taskID := getStringMetadata(event.Metadata, "task_id")
 		state := p.getOrCreate(taskID)
 		state.Status = planning.StatusPending
 		state.BlockedAt = nil
 
 	case EventTypeTaskTransitioned:
-		taskID := event.Metadata["task_id"].(string)
+		taskID := getStringMetadata(event.Metadata, "task_id")
 		state := p.getOrCreate(taskID)
 		if toStatus, ok := event.Metadata["to_status"].(string); ok {
 			state.Status = planning.TaskStatus(toStatus)
 		}
 
 	case EventTypeExternalRefLinked:
-		taskID := event.Metadata["task_id"].(string)
+		taskID := getStringMetadata(event.Metadata, "task_id")
 		state := p.getOrCreate(taskID)
 		if state.ExternalRefs == nil {
 			state.ExternalRefs = make(map[string]ExternalRefState)
 		}
-		provider := event.Metadata["provider"].(string)
+		provider := getStringMetadata(event.Metadata, "provider")
 		state.ExternalRefs[provider] = ExternalRefState{
 			Provider:   provider,
-			ExternalID: event.Metadata["external_id"].(string),
+			ExternalID: getStringMetadata(event.Metadata, "external_id"),
 			URL:        getStringMetadata(event.Metadata, "url"),
 			LinkedAt:   event.Timestamp,
 		}
@@ -128,6 +129,23 @@ func (p *TaskStateProjection) Reset() error {
 	return nil
 }
 
+func (p *TaskStateProjection) Snapshot() ([]byte, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return json.Marshal(p.states)
+}
+
+func (p *TaskStateProjection) Restore(data []byte) error {
+	states := make(map[string]*TaskState)
+	if err := json.Unmarshal(data, &states); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.states = states
+	return nil
+}
+
 func (p *TaskStateProjection) getOrCreate(taskID string) *TaskState {
 	if state, ok := p.states[taskID]; ok {
 		return state
@@ -216,6 +234,30 @@ func (p *VelocityProjection) Reset() error {
 	return nil
 }
 
+// velocitySnapshot is the persisted state of a VelocityProjection.
+type velocitySnapshot struct {
+	Completions   []time.Time `json:"completions"`
+	Verifications []time.Time `json:"verifications"`
+}
+
+func (p *VelocityProjection) Snapshot() ([]byte, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return json.Marshal(velocitySnapshot{Completions: p.completions, Verifications: p.verifications})
+}
+
+func (p *VelocityProjection) Restore(data []byte) error {
+	var snap velocitySnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.completions = append(make([]time.Time, 0, len(snap.Completions)), snap.Completions...)
+	p.verifications = append(make([]time.Time, 0, len(snap.Verifications)), snap.Verifications...)
+	return nil
+}
+
 // GetCompletionVelocity returns tasks completed per day in the window.
 func (p *VelocityProjection) GetCompletionVelocity() float64 {
 	p.mu.RLock()
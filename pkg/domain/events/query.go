@@ -0,0 +1,198 @@
+package events
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EventQuery provides filtering options for event queries.
+//
+// EventTypes and Metadata values are glob patterns ("task.*"), matched with
+// path.Match. Actor is a prefix ("ai" matches "ai-agent" and "ai:claude"),
+// or a glob when it contains *, ? or [. An event matches when it satisfies
+// every filter that is set; Limit and Offset page through the matches in
+// chronological order.
+type EventQuery struct {
+	AggregateType string
+	AggregateID   string
+	EventTypes    []string
+	Actor         string
+	Metadata      map[string]string
+	Since         *time.Time
+	Until         *time.Time
+	Limit         int
+	Offset        int
+}
+
+// Querier is implemented by event stores that can evaluate an EventQuery
+// themselves.
+type Querier interface {
+	Query(q EventQuery) ([]*BaseEvent, error)
+}
+
+// Query returns the events in store that match q, using the store's own
+// Query when it has one.
+func Query(store EventStore, q EventQuery) ([]*BaseEvent, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	if querier, ok := store.(Querier); ok {
+		return querier.Query(q)
+	}
+	all, err := store.LoadAll()
+	if err != nil {
+		return nil, err
+	}
+	return q.Filter(all), nil
+}
+
+// Validate reports malformed patterns and negative paging values.
+func (q EventQuery) Validate() error {
+	for _, pattern := range q.EventTypes {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid event type pattern %q", pattern)
+		}
+	}
+	if _, err := path.Match(q.Actor, ""); err != nil {
+		return fmt.Errorf("invalid actor pattern %q", q.Actor)
+	}
+	for key, pattern := range q.Metadata {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q for metadata key %q", pattern, key)
+		}
+	}
+	if q.Limit < 0 || q.Offset < 0 {
+		return fmt.Errorf("limit and offset must not be negative")
+	}
+	if q.Since != nil && q.Until != nil && q.Until.Before(*q.Since) {
+		return fmt.Errorf("until is before since")
+	}
+	return nil
+}
+
+// Matches reports whether e satisfies every filter of q. Paging is ignored.
+func (q EventQuery) Matches(e *BaseEvent) bool {
+	if q.AggregateType != "" && e.AggregateType_ != q.AggregateType {
+		return false
+	}
+	if q.AggregateID != "" && e.AggregateID_ != q.AggregateID {
+		return false
+	}
+	if len(q.EventTypes) > 0 && !q.matchesType(e) {
+		return false
+	}
+	if q.Actor != "" && !actorMatch(q.Actor, e.Actor) {
+		return false
+	}
+	for key, pattern := range q.Metadata {
+		v, ok := e.Metadata[key]
+		if !ok || !globMatch(pattern, fmt.Sprint(v)) {
+			return false
+		}
+	}
+	if q.Since != nil && e.Timestamp.Before(*q.Since) {
+		return false
+	}
+	if q.Until != nil && e.Timestamp.After(*q.Until) {
+		return false
+	}
+	return true
+}
+
+// Filter returns the events that match q, paged by Offset and Limit.
+func (q EventQuery) Filter(evts []*BaseEvent) []*BaseEvent {
+	result := []*BaseEvent{}
+	skipped := 0
+	for _, e := range evts {
+		if !q.Matches(e) {
+			continue
+		}
+		if skipped < q.Offset {
+			skipped++
+			continue
+		}
+		result = append(result, e)
+		if q.Limit > 0 && len(result) == q.Limit {
+			break
+		}
+	}
+	return result
+}
+
+// matchesType checks the event type, falling back to the action of legacy
+// audit events.
+func (q EventQuery) matchesType(e *BaseEvent) bool {
+	eventType := e.Type
+	if eventType == "" {
+		eventType = e.Action
+	}
+	for _, pattern := range q.EventTypes {
+		if globMatch(pattern, eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// actorMatch matches an actor by prefix, or by glob when the pattern has
+// glob metacharacters.
+func actorMatch(pattern, actor string) bool {
+	if !strings.ContainsAny(pattern, "*?[") {
+		return strings.HasPrefix(actor, pattern)
+	}
+	return globMatch(pattern, actor)
+}
+
+func globMatch(pattern, value string) bool {
+	ok, err := path.Match(pattern, value)
+	return err == nil && ok
+}
+
+// ParseMetadataFilters parses "key=value" pairs into a metadata filter.
+func ParseMetadataFilters(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	filters := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid metadata filter %q: use key=value", pair)
+		}
+		filters[key] = value
+	}
+	return filters, nil
+}
+
+// relativeTimePattern matches look-back windows such as "7d" or "2w".
+var relativeTimePattern = regexp.MustCompile(`^(\d+)(d|w)$`)
+
+// ParseQueryTime parses a query bound: a look-back window relative to now
+// ("36h", "7d", "2w"), a date (2006-01-02) or an RFC 3339 timestamp.
+func ParseQueryTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if m := relativeTimePattern.FindStringSubmatch(value); m != nil {
+		n, err := strconv.Atoi(m[1])
+		if err == nil {
+			days := n
+			if m[2] == "w" {
+				days *= 7
+			}
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q: use a window (36h, 7d, 2w), YYYY-MM-DD or RFC 3339", value)
+}
@@ -0,0 +1,117 @@
+package events_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+)
+
+func TestEventQuery_Filter(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	evts := []*events.BaseEvent{
+		{ID: "e1", Type: events.EventTypeTaskStarted, Actor: "ai:claude", AggregateType_: "task", AggregateID_: "t1", Timestamp: now.Add(-48 * time.Hour), Metadata: map[string]interface{}{"owner": "bob"}},
+		{ID: "e2", Type: events.EventTypeTaskCompleted, Actor: "alice", AggregateType_: "task", AggregateID_: "t1", Timestamp: now.Add(-24 * time.Hour)},
+		{ID: "e3", Action: events.EventTypePlanApproved, Actor: "ai:claude", Timestamp: now.Add(-time.Hour), Metadata: map[string]interface{}{"tasks": 3}},
+		{ID: "e4", Type: events.EventTypeTaskStarted, Actor: "alice", AggregateType_: "task", AggregateID_: "t2", Timestamp: now},
+	}
+	since := now.Add(-24 * time.Hour)
+	until := now.Add(-time.Hour)
+
+	tests := []struct {
+		name  string
+		query events.EventQuery
+		want  []string
+	}{
+		{"empty", events.EventQuery{}, []string{"e1", "e2", "e3", "e4"}},
+		{"type glob", events.EventQuery{EventTypes: []string{"task.*"}}, []string{"e1", "e2", "e4"}},
+		{"legacy action", events.EventQuery{EventTypes: []string{"plan.*"}}, []string{"e3"}},
+		{"any of several types", events.EventQuery{EventTypes: []string{"task.completed", "plan.approved"}}, []string{"e2", "e3"}},
+		{"actor glob", events.EventQuery{Actor: "ai*"}, []string{"e1", "e3"}},
+		{"actor prefix", events.EventQuery{Actor: "ai"}, []string{"e1", "e3"}},
+		{"actor prefix is anchored", events.EventQuery{Actor: "claude"}, []string{}},
+		{"aggregate", events.EventQuery{AggregateType: "task", AggregateID: "t1"}, []string{"e1", "e2"}},
+		{"metadata", events.EventQuery{Metadata: map[string]string{"owner": "b*"}}, []string{"e1"}},
+		{"non-string metadata", events.EventQuery{Metadata: map[string]string{"tasks": "3"}}, []string{"e3"}},
+		{"time window", events.EventQuery{Since: &since, Until: &until}, []string{"e2", "e3"}},
+		{"paging", events.EventQuery{Offset: 1, Limit: 2}, []string{"e2", "e3"}},
+		{"combined", events.EventQuery{EventTypes: []string{"task.*"}, Actor: "alice", Limit: 1}, []string{"e2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.query.Filter(evts)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d events, want %v", len(got), tt.want)
+			}
+			for i, e := range got {
+				if e.ID != tt.want[i] {
+					t.Errorf("event %d = %s, want %s", i, e.ID, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEventQuery_Validate(t *testing.T) {
+	since := time.Now()
+	until := since.Add(-time.Hour)
+	for name, q := range map[string]events.EventQuery{
+		"type pattern":     {EventTypes: []string{"task.["}},
+		"actor pattern":    {Actor: "["},
+		"metadata pattern": {Metadata: map[string]string{"k": "["}},
+		"negative limit":   {Limit: -1},
+		"inverted window":  {Since: &since, Until: &until},
+	} {
+		if err := q.Validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestQuery_FallsBackToLoadAll(t *testing.T) {
+	store := &memoryStore{}
+	_ = store.Append(taskEvent("e1", events.EventTypeTaskStarted, "t1"))
+	_ = store.Append(taskEvent("e2", events.EventTypeTaskCompleted, "t1"))
+
+	got, err := events.Query(store, events.EventQuery{EventTypes: []string{"*.completed"}})
+	if err != nil || len(got) != 1 || got[0].ID != "e2" {
+		t.Errorf("Query = %+v, %v", got, err)
+	}
+	if _, err := events.Query(store, events.EventQuery{Offset: -1}); err == nil {
+		t.Error("expected an invalid query to be rejected")
+	}
+}
+
+func TestParseQueryTime(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Time{
+		"7d":                   now.AddDate(0, 0, -7),
+		"2w":                   now.AddDate(0, 0, -14),
+		"36h":                  now.Add(-36 * time.Hour),
+		"2026-03-01T08:00:00Z": time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC),
+	}
+	for value, want := range tests {
+		got, err := events.ParseQueryTime(value, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("ParseQueryTime(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+	if got, err := events.ParseQueryTime("2026-03-01", now); err != nil || got.Day() != 1 {
+		t.Errorf("ParseQueryTime(date) = %v, %v", got, err)
+	}
+	for _, value := range []string{"", "yesterday", "-3h", "7x"} {
+		if _, err := events.ParseQueryTime(value, now); err == nil {
+			t.Errorf("ParseQueryTime(%q): expected an error", value)
+		}
+	}
+}
+
+func TestParseMetadataFilters(t *testing.T) {
+	got, err := events.ParseMetadataFilters([]string{"status=blocked", "reason=a=b"})
+	if err != nil || got["status"] != "blocked" || got["reason"] != "a=b" {
+		t.Errorf("ParseMetadataFilters = %v, %v", got, err)
+	}
+	if _, err := events.ParseMetadataFilters([]string{"=x"}); err == nil {
+		t.Error("expected an empty key to be rejected")
+	}
+}
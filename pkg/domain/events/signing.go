@@ -0,0 +1,125 @@
+package events
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrBadSignature is returned when a signature does not match the signer's
+// public key.
+var ErrBadSignature = errors.New("signature does not match")
+
+// Signer signs events and audit checkpoints with an identity's ed25519 key.
+// The identity is a team member or agent name whose public key is listed in
+// team.yaml.
+type Signer struct {
+	ID  string
+	key ed25519.PrivateKey
+}
+
+// NewSigner returns a signer for the identity id.
+func NewSigner(id string, key ed25519.PrivateKey) (*Signer, error) {
+	if id == "" {
+		return nil, fmt.Errorf("signer identity cannot be empty")
+	}
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid ed25519 private key for %s", id)
+	}
+	return &Signer{ID: id, key: key}, nil
+}
+
+// PublicKey returns the signer's public key.
+func (s *Signer) PublicKey() ed25519.PublicKey {
+	return s.key.Public().(ed25519.PublicKey)
+}
+
+// SignEvent signs the event's hash, which covers its content and its link to
+// the previous event. Call it after the hash is set.
+func (s *Signer) SignEvent(e *BaseEvent) {
+	e.Signer = s.ID
+	e.Signature = s.sign([]byte(e.Hash))
+}
+
+// SignAnchor signs the anchor of an archived event segment.
+func (s *Signer) SignAnchor(anchor string) string {
+	return s.sign(anchorPayload(anchor))
+}
+
+// Checkpoint returns a signed checkpoint of a log of count events ending
+// with the given event.
+func (s *Signer) Checkpoint(count int, eventID, hash string, at time.Time) AuditCheckpoint {
+	c := AuditCheckpoint{Events: count, EventID: eventID, Hash: hash, CreatedAt: at.UTC(), Signer: s.ID}
+	c.Signature = s.sign(c.payload())
+	return c
+}
+
+func (s *Signer) sign(message []byte) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(s.key, message))
+}
+
+// KeyRing maps signer identities to their public keys.
+type KeyRing map[string]ed25519.PublicKey
+
+// VerifyHash checks an event signature made by signer over hash.
+func (k KeyRing) VerifyHash(signer, hash, signature string) error {
+	return k.verify(signer, []byte(hash), signature)
+}
+
+// VerifyAnchor checks an archived segment's anchor signature made by signer.
+func (k KeyRing) VerifyAnchor(signer, anchor, signature string) error {
+	return k.verify(signer, anchorPayload(anchor), signature)
+}
+
+// VerifyCheckpoint checks the checkpoint's signature.
+func (k KeyRing) VerifyCheckpoint(c AuditCheckpoint) error {
+	return k.verify(c.Signer, c.payload(), c.Signature)
+}
+
+func (k KeyRing) verify(signer string, message []byte, signature string) error {
+	pub, ok := k[signer]
+	if !ok {
+		return fmt.Errorf("no public key for signer %q", signer)
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || !ed25519.Verify(pub, message, sig) {
+		return fmt.Errorf("signer %q: %w", signer, ErrBadSignature)
+	}
+	return nil
+}
+
+// AuditCheckpoint is a signed statement of the length and head of the event
+// log at a point in time. Exported checkpoints can be kept out-of-band: a log
+// whose history was rewritten, even with every hash recomputed, no longer
+// contains the checkpointed event at the checkpointed position.
+type AuditCheckpoint struct {
+	Events    int       `json:"events"`
+	EventID   string    `json:"event_id"`
+	Hash      string    `json:"hash"`
+	CreatedAt time.Time `json:"created_at"`
+	Signer    string    `json:"signer"`
+	Signature string    `json:"signature"`
+}
+
+// payload is the signed representation of the checkpoint.
+func (c AuditCheckpoint) payload() []byte {
+	return fmt.Appendf(nil, "roady-audit-checkpoint\n%d\n%s\n%s\n%s",
+		c.Events, c.EventID, c.Hash, c.CreatedAt.UTC().Format(time.RFC3339Nano))
+}
+
+// anchorPayload is the signed representation of a segment anchor, kept
+// apart from event hashes so one signature cannot stand in for the other.
+func anchorPayload(anchor string) []byte {
+	return []byte("roady-event-anchor\n" + anchor)
+}
+
+// AuditCheckpointLog persists signed audit checkpoints.
+type AuditCheckpointLog interface {
+	// AppendCheckpoint records a checkpoint.
+	AppendCheckpoint(c AuditCheckpoint) error
+
+	// LoadCheckpoints returns the recorded checkpoints, oldest first.
+	LoadCheckpoints() ([]AuditCheckpoint, error)
+}
@@ -1,9 +1,14 @@
 package events
 
 import (
+	"errors"
 	"time"
 )
 
+// ErrEventNotFound is returned by EventStore.LoadAfter when the given event
+// is not in the store.
+var ErrEventNotFound = errors.New("event not found")
+
 // EventStore provides persistence for domain events.
 type EventStore interface {
 	// Append adds a new event to the store, chaining it to the previous event.
@@ -24,6 +29,11 @@ type EventStore interface {
 	// LoadRange returns events within a time range.
 	LoadRange(from, to time.Time) ([]*BaseEvent, error)
 
+	// LoadAfter returns the events appended after the event with the given
+	// ID, or all events if the ID is empty. It returns ErrEventNotFound if
+	// the event is not in the store.
+	LoadAfter(eventID string) ([]*BaseEvent, error)
+
 	// GetLastEvent returns the most recent event (for hash chaining).
 	GetLastEvent() (*BaseEvent, error)
 
@@ -46,6 +56,18 @@ type Projection interface {
 	Reset() error
 }
 
+// SnapshotProjection is a projection whose state can be saved and restored,
+// so that it can resume from a checkpoint instead of replaying every event.
+type SnapshotProjection interface {
+	Projection
+
+	// Snapshot returns the projection state.
+	Snapshot() ([]byte, error)
+
+	// Restore replaces the projection state with a snapshot.
+	Restore(data []byte) error
+}
+
 // ProjectionStore persists projection state.
 type ProjectionStore interface {
 	// SaveCheckpoint saves the last processed event ID for a projection.
@@ -53,6 +75,14 @@ type ProjectionStore interface {
 
 	// LoadCheckpoint returns the last processed event ID.
 	LoadCheckpoint(projectionName string) (string, error)
+
+	// SaveSnapshot saves a projection's state together with the ID of the
+	// last event applied to it.
+	SaveSnapshot(projectionName string, lastEventID string, state []byte) error
+
+	// LoadSnapshot returns the last saved state and its event ID. Both are
+	// empty if the projection has no snapshot.
+	LoadSnapshot(projectionName string) (lastEventID string, state []byte, err error)
 }
 
 // EventPublisher broadcasts events to subscribers.
@@ -66,14 +96,3 @@ type EventPublisher interface {
 
 // EventHandler processes published events.
 type EventHandler func(event *BaseEvent) error
-
-// EventQuery provides filtering options for event queries.
-type EventQuery struct {
-	AggregateType string
-	AggregateID   string
-	EventTypes    []string
-	Since         *time.Time
-	Until         *time.Time
-	Limit         int
-	Offset        int
-}
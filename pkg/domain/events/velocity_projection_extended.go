@@ -1,6 +1,7 @@
 package events
 
 import (
+	"encoding/json"
 	"math"
 	"sort"
 	"sync"
@@ -83,6 +84,23 @@ func (p *ExtendedVelocityProjection) Reset() error {
 	return nil
 }
 
+func (p *ExtendedVelocityProjection) Snapshot() ([]byte, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return json.Marshal(p.completions)
+}
+
+func (p *ExtendedVelocityProjection) Restore(data []byte) error {
+	completions := make([]completionRecord, 0)
+	if err := json.Unmarshal(data, &completions); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.completions = completions
+	return nil
+}
+
 // GetVelocityWindows returns velocity data for all configured windows.
 func (p *ExtendedVelocityProjection) GetVelocityWindows() []analytics.VelocityWindow {
 	p.mu.RLock()
@@ -0,0 +1,119 @@
+package merge
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/felixgeelhaar/roady/pkg/domain"
+)
+
+// eventLine is one event of an events.jsonl file. The raw fields are kept so
+// that re-chaining an event changes nothing but its chain fields.
+type eventLine struct {
+	raw    []byte
+	event  domain.Event
+	fields map[string]json.RawMessage
+}
+
+func parseEvents(data []byte, side string) ([]eventLine, error) {
+	var lines []eventLine
+	for i, raw := range bytes.Split(data, []byte("\n")) {
+		raw = bytes.TrimSpace(raw)
+		if len(raw) == 0 {
+			continue
+		}
+		l := eventLine{raw: raw}
+		if err := json.Unmarshal(raw, &l.event); err != nil {
+			return nil, fmt.Errorf("parse %s line %d: %w", side, i+1, err)
+		}
+		if err := json.Unmarshal(raw, &l.fields); err != nil {
+			return nil, fmt.Errorf("parse %s line %d: %w", side, i+1, err)
+		}
+		lines = append(lines, l)
+	}
+	return lines, nil
+}
+
+// mergeEventFiles keeps ours' log as it is and appends the events only
+// theirs recorded, re-chained onto ours' last hash. A signed event that moves
+// keeps its signature and records the PrevHash it was signed with, so it
+// still verifies.
+func mergeEventFiles(base, ours, theirs []byte) (*Result, error) {
+	b, err := parseEvents(base, "base")
+	if err != nil {
+		return nil, err
+	}
+	o, err := parseEvents(ours, "ours")
+	if err != nil {
+		return nil, err
+	}
+	t, err := parseEvents(theirs, "theirs")
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(b)+len(o))
+	for _, side := range [][]eventLine{b, o} {
+		for _, l := range side {
+			known[l.event.ID] = true
+		}
+	}
+
+	var out bytes.Buffer
+	prev := ""
+	for _, l := range o {
+		out.Write(l.raw)
+		out.WriteByte('\n')
+		prev = l.event.Hash
+	}
+
+	var appended, signed int
+	for _, l := range t {
+		if known[l.event.ID] {
+			continue
+		}
+		known[l.event.ID] = true
+		appended++
+
+		e := l.event
+		if e.Signer != "" && e.SignedPrevHash == nil && e.PrevHash != prev {
+			original := e.PrevHash
+			e.SignedPrevHash = &original
+			data, _ := json.Marshal(original)
+			l.fields["signed_prev_hash"] = data
+			signed++
+		}
+		e.PrevHash = prev
+		e.Hash = e.CalculateHash()
+		prev = e.Hash
+
+		setField(l.fields, "prev_hash", e.PrevHash)
+		setField(l.fields, "hash", e.Hash)
+		data, err := json.Marshal(l.fields)
+		if err != nil {
+			return nil, err
+		}
+		out.Write(data)
+		out.WriteByte('\n')
+	}
+
+	res := &Result{Data: out.Bytes()}
+	if appended > 0 {
+		res.Notes = append(res.Notes, fmt.Sprintf("appended %d event(s) and re-chained them", appended))
+	}
+	if signed > 0 {
+		res.Notes = append(res.Notes, fmt.Sprintf("kept the signatures of %d re-chained event(s) with the hash they were signed at", signed))
+	}
+	return res, nil
+}
+
+// setField sets an omitempty string field of a raw event.
+func setField(fields map[string]json.RawMessage, name, value string) {
+	if value == "" {
+		delete(fields, name)
+		return
+	}
+	data, _ := json.Marshal(value)
+	fields[name] = data
+}
@@ -0,0 +1,156 @@
+// Package merge three-way merges the JSON artifacts under .roady/ so that
+// concurrent edits by different people combine instead of conflicting.
+//
+// Plans are merged task by task, execution state entry by entry and event
+// logs by appending and re-chaining the events only one side has. Only a
+// value changed differently on both sides is a conflict; the merged result
+// keeps "ours" for it and reports it.
+package merge
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// Artifacts merged by Merge, by base name.
+const (
+	PlanFile   = "plan.json"
+	StateFile  = "state.json"
+	EventsFile = "events.jsonl"
+)
+
+// Conflict is a value changed differently on both sides.
+type Conflict struct {
+	File   string `json:"file"`
+	Path   string `json:"path"` // e.g. tasks[task-a].title
+	Base   string `json:"base"`
+	Ours   string `json:"ours"`
+	Theirs string `json:"theirs"`
+}
+
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s %s: ours %s, theirs %s (base %s)", c.File, c.Path, c.Ours, c.Theirs, c.Base)
+}
+
+// Result is a merged artifact.
+type Result struct {
+	Data      []byte
+	Conflicts []Conflict
+	Notes     []string // What the merge did beyond combining values
+}
+
+// Supported reports whether Merge understands the file at path.
+func Supported(path string) bool {
+	switch filepath.Base(path) {
+	case PlanFile, StateFile, EventsFile:
+		return true
+	}
+	return false
+}
+
+// Merge three-way merges the artifact at path; base may be empty when both
+// sides added the file. Events re-chained after "ours" keep their signature,
+// which is checked against the hash they were signed with.
+func Merge(path string, base, ours, theirs []byte) (*Result, error) {
+	name := filepath.Base(path)
+	var (
+		res *Result
+		err error
+	)
+	switch name {
+	case PlanFile:
+		res, err = mergePlanFiles(base, ours, theirs)
+	case StateFile:
+		res, err = mergeStateFiles(base, ours, theirs)
+	case EventsFile:
+		res, err = mergeEventFiles(base, ours, theirs)
+	default:
+		return nil, fmt.Errorf("cannot merge %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("merge %s: %w", path, err)
+	}
+	for i := range res.Conflicts {
+		res.Conflicts[i].File = path
+	}
+	return res, nil
+}
+
+// decode unmarshals a side of the merge; empty input leaves v untouched.
+func decode(data []byte, v interface{}, side string) error {
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parse %s: %w", side, err)
+	}
+	return nil
+}
+
+// encode formats a merged artifact the way the storage layer writes it.
+func encode(v interface{}) ([]byte, error) {
+	return json.MarshalIndent(v, "", "  ")
+}
+
+// mergeFields three-way merges the exported fields of the structs b, o and
+// t into out, which starts as a copy of o. Fields named in skip are left to
+// the caller.
+func mergeFields(path string, b, o, t, out reflect.Value, skip map[string]bool) []Conflict {
+	var conflicts []Conflict
+	typ := o.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || skip[field.Name] {
+			continue
+		}
+		bv, ov, tv := b.Field(i).Interface(), o.Field(i).Interface(), t.Field(i).Interface()
+		value, conflict := threeWay(bv, ov, tv)
+		out.Field(i).Set(reflect.ValueOf(value))
+		if conflict {
+			conflicts = append(conflicts, newConflict(path+"."+jsonName(field), bv, ov, tv))
+		}
+	}
+	return conflicts
+}
+
+// threeWay picks the merged value of one field. The second result reports
+// a conflict, in which case ours is returned.
+func threeWay(base, ours, theirs interface{}) (interface{}, bool) {
+	switch {
+	case equal(ours, theirs), equal(base, theirs):
+		return ours, false
+	case equal(base, ours):
+		return theirs, false
+	default:
+		return ours, true
+	}
+}
+
+// equal compares values by their JSON form, which is what is stored.
+// reflect.DeepEqual would tell apart equal times decoded with separate
+// time zone values.
+func equal(a, b interface{}) bool {
+	return show(a) == show(b)
+}
+
+func newConflict(path string, base, ours, theirs interface{}) Conflict {
+	return Conflict{Path: path, Base: show(base), Ours: show(ours), Theirs: show(theirs)}
+}
+
+func show(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+func jsonName(f reflect.StructField) string {
+	if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return f.Name
+}
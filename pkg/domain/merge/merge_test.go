@@ -0,0 +1,298 @@
+package merge_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain"
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+	"github.com/felixgeelhaar/roady/pkg/domain/merge"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+)
+
+func mustJSON(t *testing.T, v interface{}) []byte {
+	t.Helper()
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestSupported(t *testing.T) {
+	for path, want := range map[string]bool{
+		".roady/plan.json":                 true,
+		".roady/projects/api/state.json":   true,
+		".roady/events.jsonl":              true,
+		".roady/spec.yaml":                 false,
+		".roady/plugins.yaml":              false,
+		".roady/audit-checkpoints.jsonl":   false,
+		".roady/projects/api/events.jsonl": true,
+	} {
+		if got := merge.Supported(path); got != want {
+			t.Errorf("Supported(%s) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestPlans(t *testing.T) {
+	base := &planning.Plan{ID: "p1", Tasks: []planning.Task{
+		{ID: "a", Title: "A", Priority: planning.PriorityLow},
+		{ID: "b", Title: "B"},
+		{ID: "c", Title: "C"},
+	}}
+	ours := &planning.Plan{ID: "p1", ApprovalStatus: planning.ApprovalApproved, Tasks: []planning.Task{
+		{ID: "a", Title: "A renamed", Priority: planning.PriorityLow},
+		{ID: "b", Title: "B"},
+		{ID: "c", Title: "C"},
+		{ID: "d", Title: "Ours"},
+	}}
+	theirs := &planning.Plan{ID: "p1", Tasks: []planning.Task{
+		{ID: "a", Title: "A", Priority: planning.PriorityHigh},
+		{ID: "c", Title: "C"},
+		{ID: "e", Title: "Theirs"},
+	}}
+
+	merged, conflicts := merge.Plans(base, ours, theirs)
+	if len(conflicts) != 0 {
+		t.Fatalf("unexpected conflicts %v", conflicts)
+	}
+	var ids []string
+	for _, task := range merged.Tasks {
+		ids = append(ids, task.ID)
+	}
+	if got := strings.Join(ids, ","); got != "a,c,d,e" {
+		t.Errorf("tasks = %s, want a,c,d,e (b removed by theirs)", got)
+	}
+	if a := merged.Tasks[0]; a.Title != "A renamed" || a.Priority != planning.PriorityHigh {
+		t.Errorf("task a = %+v, want both edits", a)
+	}
+	if merged.ApprovalStatus != planning.ApprovalApproved {
+		t.Errorf("approval = %s", merged.ApprovalStatus)
+	}
+}
+
+func TestPlans_Conflicts(t *testing.T) {
+	base := &planning.Plan{Tasks: []planning.Task{{ID: "a", Title: "A"}, {ID: "b", Title: "B"}}}
+	ours := &planning.Plan{Tasks: []planning.Task{{ID: "a", Title: "Ours"}, {ID: "b", Title: "B"}}}
+	theirs := &planning.Plan{Tasks: []planning.Task{{ID: "a", Title: "Theirs"}}}
+
+	// b was removed by theirs and not changed by ours: no conflict.
+	merged, conflicts := merge.Plans(base, ours, theirs)
+	if len(conflicts) != 1 || conflicts[0].Path != "tasks[a].title" || conflicts[0].Ours != `"Ours"` || conflicts[0].Theirs != `"Theirs"` {
+		t.Fatalf("conflicts = %+v", conflicts)
+	}
+	if len(merged.Tasks) != 1 || merged.Tasks[0].Title != "Ours" {
+		t.Errorf("expected ours to be kept, got %+v", merged.Tasks)
+	}
+
+	// A task removed on one side and edited on the other conflicts.
+	theirs = &planning.Plan{Tasks: []planning.Task{{ID: "a", Title: "A"}, {ID: "b", Title: "B edited"}}}
+	ours = &planning.Plan{Tasks: []planning.Task{{ID: "a", Title: "A"}}}
+	if _, conflicts := merge.Plans(base, ours, theirs); len(conflicts) != 1 || conflicts[0].Path != "tasks[b]" {
+		t.Errorf("conflicts = %+v", conflicts)
+	}
+}
+
+func TestStates(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	t1, t2 := t0.Add(time.Hour), t0.Add(2*time.Hour)
+	base := &planning.ExecutionState{Version: 4, TaskStates: map[string]planning.TaskResult{
+		"a": {Status: planning.StatusInProgress, StartedAt: &t0, ElapsedMinutes: 30},
+		"b": {Status: planning.StatusPending},
+		"c": {Status: planning.StatusPending, Owner: "alice"},
+	}}
+	ours := &planning.ExecutionState{Version: 5, UpdatedAt: t1, TaskStates: map[string]planning.TaskResult{
+		"a": {Status: planning.StatusDone, StartedAt: &t0, CompletedAt: &t1, ElapsedMinutes: 45, Evidence: []string{"abc"},
+			Comments: []planning.TaskComment{{ID: "c1", Body: "ours"}}},
+		"b": {Status: planning.StatusPending, Owner: "bob"},
+		"c": {Status: planning.StatusPending, Owner: "alice"},
+	}}
+	theirs := &planning.ExecutionState{Version: 6, UpdatedAt: t2, TaskStates: map[string]planning.TaskResult{
+		"a": {Status: planning.StatusVerified, StartedAt: &t0, CompletedAt: &t2, ElapsedMinutes: 40, Evidence: []string{"def"},
+			Comments:     []planning.TaskComment{{ID: "c2", Body: "theirs"}},
+			ExternalRefs: map[string]planning.ExternalRef{"jira": {ID: "J-1", LastSyncedAt: t2}}},
+		"b": {Status: planning.StatusInProgress, StartedAt: &t2},
+		"d": {Status: planning.StatusPending},
+	}}
+
+	merged, conflicts := merge.States(base, ours, theirs)
+	if len(conflicts) != 0 {
+		t.Fatalf("unexpected conflicts %v", conflicts)
+	}
+	if merged.Version != 7 || !merged.UpdatedAt.Equal(t2) {
+		t.Errorf("version %d updated %s", merged.Version, merged.UpdatedAt)
+	}
+
+	a := merged.TaskStates["a"]
+	if a.Status != planning.StatusVerified || !a.CompletedAt.Equal(t2) {
+		t.Errorf("a: expected verified (further along) with theirs' completion, got %s %v", a.Status, a.CompletedAt)
+	}
+	if a.ElapsedMinutes != 55 {
+		t.Errorf("a: elapsed = %d, want 30+15+10", a.ElapsedMinutes)
+	}
+	if len(a.Evidence) != 2 || len(a.Comments) != 2 || a.ExternalRefs["jira"].ID != "J-1" {
+		t.Errorf("a: expected evidence, comments and refs combined, got %+v", a)
+	}
+
+	b := merged.TaskStates["b"]
+	if b.Status != planning.StatusInProgress || b.Owner != "bob" {
+		t.Errorf("b: expected theirs' start and ours' owner, got %+v", b)
+	}
+	if _, ok := merged.TaskStates["c"]; ok {
+		t.Error("c: expected the entry removed by theirs to stay removed")
+	}
+	if _, ok := merged.TaskStates["d"]; !ok {
+		t.Error("d: expected the entry added by theirs")
+	}
+}
+
+func TestStates_Conflicts(t *testing.T) {
+	base := &planning.ExecutionState{TaskStates: map[string]planning.TaskResult{"a": {Status: planning.StatusPending}}}
+	ours := &planning.ExecutionState{TaskStates: map[string]planning.TaskResult{"a": {Status: planning.StatusBlocked, Owner: "alice"}}}
+	theirs := &planning.ExecutionState{TaskStates: map[string]planning.TaskResult{"a": {Status: planning.StatusInProgress, Owner: "bob"}}}
+
+	merged, conflicts := merge.States(base, ours, theirs)
+	if len(conflicts) != 2 {
+		t.Fatalf("expected status and owner conflicts, got %+v", conflicts)
+	}
+	if a := merged.TaskStates["a"]; a.Status != planning.StatusBlocked || a.Owner != "alice" {
+		t.Errorf("expected ours to be kept, got %+v", a)
+	}
+}
+
+// eventLog builds a hash-chained events.jsonl continuing from prev.
+func eventLog(t *testing.T, prev string, signer *events.Signer, ids ...string) ([]byte, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	for i, id := range ids {
+		e := domain.Event{ID: id, Action: "task.started", Actor: "cli", Timestamp: time.Date(2026, 3, 1, 9, i, 0, 0, time.UTC), PrevHash: prev}
+		e.Hash = e.CalculateHash()
+		if signer != nil {
+			signed := events.BaseEvent{Hash: e.Hash}
+			signer.SignEvent(&signed)
+			e.Signer, e.Signature = signed.Signer, signed.Signature
+		}
+		prev = e.Hash
+		data, _ := json.Marshal(e)
+		buf.Write(append(data, '\n'))
+	}
+	return buf.Bytes(), prev
+}
+
+func newSigner(t *testing.T, id string) *events.Signer {
+	t.Helper()
+	_, key, _ := ed25519.GenerateKey(nil)
+	s, err := events.NewSigner(id, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestMerge_Events(t *testing.T) {
+	alice, bob := newSigner(t, "alice"), newSigner(t, "bob")
+	base, head := eventLog(t, "", nil, "e1", "e2")
+	oursNew, _ := eventLog(t, head, nil, "o1")
+	aliceNew, _ := eventLog(t, head, alice, "t1")
+	bobNew, _ := eventLog(t, head, bob, "t2")
+	ours := append(append([]byte{}, base...), oursNew...)
+	theirs := append(append(append([]byte{}, base...), aliceNew...), bobNew...)
+
+	res, err := merge.Merge(".roady/events.jsonl", base, ours, theirs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Conflicts) != 0 || len(res.Notes) != 2 {
+		t.Errorf("conflicts %v, notes %v", res.Conflicts, res.Notes)
+	}
+
+	var merged []domain.Event
+	for _, line := range bytes.Split(bytes.TrimSpace(res.Data), []byte("\n")) {
+		var e domain.Event
+		if err := json.Unmarshal(line, &e); err != nil {
+			t.Fatal(err)
+		}
+		merged = append(merged, e)
+	}
+	var ids []string
+	prev := ""
+	for i, e := range merged {
+		ids = append(ids, e.ID)
+		if e.PrevHash != prev || e.Hash != e.CalculateHash() {
+			t.Errorf("event %d (%s) is not chained", i, e.ID)
+		}
+		prev = e.Hash
+	}
+	if got := strings.Join(ids, ","); got != "e1,e2,o1,t1,t2" {
+		t.Fatalf("events = %s", got)
+	}
+
+	// Both signatures survive and verify against the hash they were made
+	// over, whoever runs the merge.
+	keys := events.KeyRing{"alice": alice.PublicKey(), "bob": bob.PublicKey()}
+	for _, e := range merged[3:] {
+		if e.Signer == "" || e.SignedPrevHash == nil || *e.SignedPrevHash == e.PrevHash {
+			t.Errorf("event %s: signer %q, signed prev hash %v", e.ID, e.Signer, e.SignedPrevHash)
+			continue
+		}
+		if err := keys.VerifyHash(e.Signer, e.SignedHash(), e.Signature); err != nil {
+			t.Errorf("event %s: %v", e.ID, err)
+		}
+	}
+
+	// Editing a re-chained signed event is still caught.
+	forged := merged[4]
+	forged.Actor = "mallory"
+	if err := keys.VerifyHash(forged.Signer, forged.SignedHash(), forged.Signature); err == nil {
+		t.Error("expected an edited event to fail verification")
+	}
+
+	// Merging the merged log again keeps the original signing position.
+	otherNew, _ := eventLog(t, head, nil, "o2")
+	again, err := merge.Merge(".roady/events.jsonl", base, append(append([]byte{}, base...), otherNew...), res.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := bytes.Split(bytes.TrimSpace(again.Data), []byte("\n"))
+	var last domain.Event
+	if err := json.Unmarshal(lines[len(lines)-1], &last); err != nil {
+		t.Fatal(err)
+	}
+	if err := keys.VerifyHash(last.Signer, last.SignedHash(), last.Signature); err != nil {
+		t.Errorf("twice re-chained event: %v", err)
+	}
+}
+
+func TestMerge_Files(t *testing.T) {
+	base := mustJSON(t, planning.Plan{Tasks: []planning.Task{{ID: "a", Title: "A"}}})
+	ours := mustJSON(t, planning.Plan{Tasks: []planning.Task{{ID: "a", Title: "A"}, {ID: "b", Title: "B"}}})
+	theirs := mustJSON(t, planning.Plan{Tasks: []planning.Task{{ID: "a", Title: "A2"}}})
+
+	res, err := merge.Merge(".roady/plan.json", base, ours, theirs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var plan planning.Plan
+	if err := json.Unmarshal(res.Data, &plan); err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Tasks) != 2 || plan.Tasks[0].Title != "A2" {
+		t.Errorf("plan = %+v", plan.Tasks)
+	}
+
+	// Both sides added the file.
+	if _, err := merge.Merge(".roady/state.json", nil, []byte(`{"task_states":{}}`), []byte(`{"task_states":{}}`)); err != nil {
+		t.Errorf("expected a missing base to merge, got %v", err)
+	}
+	if _, err := merge.Merge(".roady/plan.json", base, []byte("<<<<<<<"), theirs); err == nil {
+		t.Error("expected invalid JSON to fail")
+	}
+	if _, err := merge.Merge(".roady/spec.yaml", nil, nil, nil); err == nil {
+		t.Error("expected an unsupported file to fail")
+	}
+}
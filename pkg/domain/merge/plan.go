@@ -0,0 +1,100 @@
+package merge
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+)
+
+func mergePlanFiles(base, ours, theirs []byte) (*Result, error) {
+	var b, o, t planning.Plan
+	if err := decode(base, &b, "base"); err != nil {
+		return nil, err
+	}
+	if err := decode(ours, &o, "ours"); err != nil {
+		return nil, err
+	}
+	if err := decode(theirs, &t, "theirs"); err != nil {
+		return nil, err
+	}
+
+	merged, conflicts := Plans(&b, &o, &t)
+	data, err := encode(merged)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Data: data, Conflicts: conflicts}, nil
+}
+
+// Plans three-way merges plans. Tasks are matched by ID: tasks added on
+// either side are kept, tasks removed on one side and unchanged on the other
+// are removed, and tasks changed on both sides are merged field by field.
+// Merged tasks keep ours' order, followed by the tasks only theirs added.
+func Plans(base, ours, theirs *planning.Plan) (*planning.Plan, []Conflict) {
+	merged := *ours
+	conflicts := mergeFields("plan", reflect.ValueOf(*base), reflect.ValueOf(*ours), reflect.ValueOf(*theirs),
+		reflect.ValueOf(&merged).Elem(), map[string]bool{"Tasks": true, "UpdatedAt": true})
+	if theirs.UpdatedAt.After(merged.UpdatedAt) {
+		merged.UpdatedAt = theirs.UpdatedAt
+	}
+
+	baseTasks, theirTasks := tasksByID(base.Tasks), tasksByID(theirs.Tasks)
+	ourTasks := tasksByID(ours.Tasks)
+	merged.Tasks = nil
+	for _, o := range ours.Tasks {
+		b, inBase := baseTasks[o.ID]
+		t, inTheirs := theirTasks[o.ID]
+		switch {
+		case inTheirs && inBase:
+			task, taskConflicts := mergeTask(b, o, t)
+			merged.Tasks = append(merged.Tasks, task)
+			conflicts = append(conflicts, taskConflicts...)
+		case inTheirs:
+			// Added on both sides.
+			task, taskConflicts := mergeTask(planning.Task{ID: o.ID}, o, t)
+			merged.Tasks = append(merged.Tasks, task)
+			conflicts = append(conflicts, taskConflicts...)
+		case inBase && equal(b, o):
+			// Removed by theirs.
+		case inBase:
+			merged.Tasks = append(merged.Tasks, o)
+			conflicts = append(conflicts, newConflict(taskPath(o.ID), b, o, nil))
+		default:
+			merged.Tasks = append(merged.Tasks, o)
+		}
+	}
+	for _, t := range theirs.Tasks {
+		if _, ok := ourTasks[t.ID]; ok {
+			continue
+		}
+		b, inBase := baseTasks[t.ID]
+		switch {
+		case !inBase:
+			merged.Tasks = append(merged.Tasks, t)
+		case !equal(b, t):
+			// Removed by ours but changed by theirs: keep the removal.
+			conflicts = append(conflicts, newConflict(taskPath(t.ID), b, nil, t))
+		}
+	}
+	return &merged, conflicts
+}
+
+func mergeTask(b, o, t planning.Task) (planning.Task, []Conflict) {
+	merged := o
+	conflicts := mergeFields(taskPath(o.ID), reflect.ValueOf(b), reflect.ValueOf(o), reflect.ValueOf(t),
+		reflect.ValueOf(&merged).Elem(), nil)
+	return merged, conflicts
+}
+
+func tasksByID(tasks []planning.Task) map[string]planning.Task {
+	m := make(map[string]planning.Task, len(tasks))
+	for _, t := range tasks {
+		m[t.ID] = t
+	}
+	return m
+}
+
+func taskPath(id string) string {
+	return fmt.Sprintf("tasks[%s]", id)
+}
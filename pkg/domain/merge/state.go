@@ -0,0 +1,169 @@
+package merge
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+)
+
+func mergeStateFiles(base, ours, theirs []byte) (*Result, error) {
+	var b, o, t planning.ExecutionState
+	if err := decode(base, &b, "base"); err != nil {
+		return nil, err
+	}
+	if err := decode(ours, &o, "ours"); err != nil {
+		return nil, err
+	}
+	if err := decode(theirs, &t, "theirs"); err != nil {
+		return nil, err
+	}
+
+	merged, conflicts := States(&b, &o, &t)
+	data, err := encode(merged)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Data: data, Conflicts: conflicts}, nil
+}
+
+// States three-way merges execution states task by task. When both sides
+// changed a task's status, the one further along the FSM wins; blocked and
+// in progress on different sides conflict. Evidence, criteria evidence,
+// comments and external refs are combined, logged minutes are added up, and
+// the latest verification run and sync time win. The merged version is
+// above both sides' so writers holding either one reload first.
+func States(base, ours, theirs *planning.ExecutionState) (*planning.ExecutionState, []Conflict) {
+	merged := *ours
+	conflicts := mergeFields("state", reflect.ValueOf(*base), reflect.ValueOf(*ours), reflect.ValueOf(*theirs),
+		reflect.ValueOf(&merged).Elem(), map[string]bool{"TaskStates": true, "Version": true, "UpdatedAt": true})
+	merged.Version = max(ours.Version, theirs.Version) + 1
+	if theirs.UpdatedAt.After(merged.UpdatedAt) {
+		merged.UpdatedAt = theirs.UpdatedAt
+	}
+
+	ids := make(map[string]bool)
+	for _, s := range []*planning.ExecutionState{base, ours, theirs} {
+		for id := range s.TaskStates {
+			ids[id] = true
+		}
+	}
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+
+	merged.TaskStates = make(map[string]planning.TaskResult, len(ids))
+	for _, id := range sorted {
+		b, inBase := base.TaskStates[id]
+		o, inOurs := ours.TaskStates[id]
+		t, inTheirs := theirs.TaskStates[id]
+		switch {
+		case inOurs && inTheirs:
+			result, resultConflicts := mergeTaskResult(id, b, o, t)
+			merged.TaskStates[id] = result
+			conflicts = append(conflicts, resultConflicts...)
+		case inOurs && (!inBase || !equal(b, o)):
+			merged.TaskStates[id] = o
+		case inTheirs && (!inBase || !equal(b, t)):
+			merged.TaskStates[id] = t
+		}
+	}
+	return &merged, conflicts
+}
+
+func mergeTaskResult(id string, b, o, t planning.TaskResult) (planning.TaskResult, []Conflict) {
+	path := fmt.Sprintf("task_states[%s]", id)
+	merged := o
+	conflicts := mergeFields(path, reflect.ValueOf(b), reflect.ValueOf(o), reflect.ValueOf(t), reflect.ValueOf(&merged).Elem(),
+		map[string]bool{
+			"Status": true, "CompletedAt": true, "StartedAt": true, "Evidence": true, "CriteriaEvidence": true,
+			"Comments": true, "ExternalRefs": true, "VerificationRun": true, "ElapsedMinutes": true,
+		})
+
+	// The status and its completion time come from the same side.
+	status, conflict := threeWay(b.Status, o.Status, t.Status)
+	if conflict {
+		switch {
+		case t.Status.Progress() > o.Status.Progress():
+			status, conflict = t.Status, false
+		case t.Status.Progress() < o.Status.Progress():
+			conflict = false
+		default:
+			conflicts = append(conflicts, newConflict(path+".status", b.Status, o.Status, t.Status))
+		}
+	}
+	merged.Status = status.(planning.TaskStatus)
+	if merged.Status == t.Status && merged.Status != o.Status {
+		merged.CompletedAt = t.CompletedAt
+	} else if merged.Status == o.Status && o.Status == t.Status {
+		merged.CompletedAt = earliest(o.CompletedAt, t.CompletedAt)
+	}
+	merged.StartedAt = earliest(o.StartedAt, t.StartedAt)
+
+	merged.Evidence = unionStrings(o.Evidence, t.Evidence)
+	merged.CriteriaEvidence = union(o.CriteriaEvidence, t.CriteriaEvidence, func(e planning.CriterionEvidence) string {
+		return e.Criterion + "\x00" + string(e.Kind) + "\x00" + e.Value
+	})
+	merged.Comments = union(o.Comments, t.Comments, func(c planning.TaskComment) string { return c.ID })
+	merged.ExternalRefs = mergeRefs(o.ExternalRefs, t.ExternalRefs)
+
+	switch {
+	case o.VerificationRun == nil:
+		merged.VerificationRun = t.VerificationRun
+	case t.VerificationRun != nil && t.VerificationRun.RanAt.After(o.VerificationRun.RanAt):
+		merged.VerificationRun = t.VerificationRun
+	}
+
+	// Time logged on both sides adds up.
+	merged.ElapsedMinutes = o.ElapsedMinutes + t.ElapsedMinutes - b.ElapsedMinutes
+	return merged, conflicts
+}
+
+func earliest(a, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.Before(*a)) {
+		return b
+	}
+	return a
+}
+
+func unionStrings(a, b []string) []string {
+	return union(a, b, func(s string) string { return s })
+}
+
+// union returns a followed by the items of b whose key a lacks.
+func union[T any](a, b []T, key func(T) string) []T {
+	seen := make(map[string]bool, len(a))
+	out := append([]T(nil), a...)
+	for _, item := range a {
+		seen[key(item)] = true
+	}
+	for _, item := range b {
+		if k := key(item); !seen[k] {
+			seen[k] = true
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// mergeRefs combines external refs; for a provider linked on both sides the
+// more recently synced ref wins.
+func mergeRefs(a, b map[string]planning.ExternalRef) map[string]planning.ExternalRef {
+	if a == nil && b == nil {
+		return nil
+	}
+	out := make(map[string]planning.ExternalRef, len(a)+len(b))
+	for k, v := range a {
+		out[k] = v
+	}
+	for k, v := range b {
+		if cur, ok := out[k]; !ok || v.LastSyncedAt.After(cur.LastSyncedAt) {
+			out[k] = v
+		}
+	}
+	return out
+}
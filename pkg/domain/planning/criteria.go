@@ -0,0 +1,154 @@
+package planning
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EvidenceKind classifies the evidence recorded against an acceptance
+// criterion.
+type EvidenceKind string
+
+const (
+	EvidenceCommit EvidenceKind = "commit"
+	EvidenceTest   EvidenceKind = "test"
+	EvidenceURL    EvidenceKind = "url"
+	EvidenceText   EvidenceKind = "text"
+)
+
+// commitHashPattern matches abbreviated or full commit hashes.
+var commitHashPattern = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// CriterionEvidence proves that a task satisfies one of its acceptance
+// criteria.
+type CriterionEvidence struct {
+	Criterion  string       `json:"criterion"`
+	Kind       EvidenceKind `json:"kind"`
+	Value      string       `json:"value"`
+	RecordedBy string       `json:"recorded_by,omitempty"`
+	RecordedAt time.Time    `json:"recorded_at"`
+}
+
+// Validate checks that the evidence value is well-formed for its kind.
+func (e CriterionEvidence) Validate() error {
+	if strings.TrimSpace(e.Value) == "" {
+		return fmt.Errorf("evidence for %q is empty", e.Criterion)
+	}
+	switch e.Kind {
+	case EvidenceCommit:
+		if !commitHashPattern.MatchString(e.Value) {
+			return fmt.Errorf("invalid commit hash %q", e.Value)
+		}
+	case EvidenceURL:
+		u, err := url.Parse(e.Value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid URL %q", e.Value)
+		}
+	case EvidenceTest, EvidenceText:
+	default:
+		return fmt.Errorf("unknown evidence kind %q (expected commit, test, url or text)", e.Kind)
+	}
+	return nil
+}
+
+// ParseEvidence reads an evidence value of the form "kind:value", where kind
+// is commit, test, url or text. Without a known prefix the kind is inferred:
+// http(s) links are URLs, hex strings of 7-40 characters are commits, and
+// anything else is free text.
+func ParseEvidence(raw string) (EvidenceKind, string) {
+	raw = strings.TrimSpace(raw)
+	if prefix, value, ok := strings.Cut(raw, ":"); ok {
+		switch kind := EvidenceKind(strings.ToLower(prefix)); kind {
+		case EvidenceCommit, EvidenceTest, EvidenceURL, EvidenceText:
+			return kind, strings.TrimSpace(value)
+		}
+	}
+	switch {
+	case strings.HasPrefix(raw, "http://") || strings.HasPrefix(raw, "https://"):
+		return EvidenceURL, raw
+	case commitHashPattern.MatchString(raw):
+		return EvidenceCommit, raw
+	default:
+		return EvidenceText, raw
+	}
+}
+
+// ParseCriterionEvidence reads "N=evidence" where N is the 1-based number of
+// one of the task's acceptance criteria, and returns validated evidence for
+// that criterion.
+func ParseCriterionEvidence(task Task, raw string) (CriterionEvidence, error) {
+	num, value, ok := strings.Cut(raw, "=")
+	if !ok {
+		return CriterionEvidence{}, fmt.Errorf("criterion evidence %q must be N=evidence", raw)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(num))
+	if err != nil || n < 1 || n > len(task.AcceptanceCriteria) {
+		return CriterionEvidence{}, fmt.Errorf("task %s has no acceptance criterion %s (it has %d)", task.ID, strings.TrimSpace(num), len(task.AcceptanceCriteria))
+	}
+	kind, value := ParseEvidence(value)
+	ev := CriterionEvidence{Criterion: task.AcceptanceCriteria[n-1], Kind: kind, Value: value}
+	if err := ev.Validate(); err != nil {
+		return CriterionEvidence{}, err
+	}
+	return ev, nil
+}
+
+// CriteriaCoverage summarises how many of a task's acceptance criteria have
+// evidence.
+type CriteriaCoverage struct {
+	Total   int      `json:"total"`
+	Covered int      `json:"covered"`
+	Missing []string `json:"missing,omitempty"`
+}
+
+// Complete reports whether every criterion has evidence. Tasks without
+// criteria are trivially complete.
+func (c CriteriaCoverage) Complete() bool {
+	return c.Covered == c.Total
+}
+
+// String renders the coverage as "covered/total".
+func (c CriteriaCoverage) String() string {
+	return fmt.Sprintf("%d/%d", c.Covered, c.Total)
+}
+
+// Coverage computes the acceptance criteria coverage of the task from the
+// evidence recorded in result.
+func (t Task) Coverage(result TaskResult) CriteriaCoverage {
+	proven := make(map[string]bool, len(result.CriteriaEvidence))
+	for _, ev := range result.CriteriaEvidence {
+		proven[ev.Criterion] = true
+	}
+	c := CriteriaCoverage{Total: len(t.AcceptanceCriteria)}
+	for _, criterion := range t.AcceptanceCriteria {
+		if proven[criterion] {
+			c.Covered++
+		} else {
+			c.Missing = append(c.Missing, criterion)
+		}
+	}
+	return c
+}
+
+// RecordCriterionEvidence stores evidence for a task's criterion, replacing
+// earlier evidence for the same criterion.
+func (s *ExecutionState) RecordCriterionEvidence(taskID string, ev CriterionEvidence) {
+	result := s.TaskStates[taskID]
+	replaced := false
+	for i, existing := range result.CriteriaEvidence {
+		if existing.Criterion == ev.Criterion {
+			result.CriteriaEvidence[i] = ev
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		result.CriteriaEvidence = append(result.CriteriaEvidence, ev)
+	}
+	s.TaskStates[taskID] = result
+	s.UpdatedAt = time.Now()
+}
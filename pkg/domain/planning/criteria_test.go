@@ -0,0 +1,70 @@
+package planning
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseEvidence(t *testing.T) {
+	tests := []struct {
+		raw   string
+		kind  EvidenceKind
+		value string
+	}{
+		{"commit:3f2a9c1", EvidenceCommit, "3f2a9c1"},
+		{"test: TestLogin", EvidenceTest, "TestLogin"},
+		{"url:https://ci.example.com/run/1", EvidenceURL, "https://ci.example.com/run/1"},
+		{"https://github.com/org/repo/pull/7", EvidenceURL, "https://github.com/org/repo/pull/7"},
+		{"3f2a9c1d", EvidenceCommit, "3f2a9c1d"},
+		{"Checked manually: works", EvidenceText, "Checked manually: works"},
+	}
+	for _, tt := range tests {
+		kind, value := ParseEvidence(tt.raw)
+		if kind != tt.kind || value != tt.value {
+			t.Errorf("ParseEvidence(%q) = %s %q, want %s %q", tt.raw, kind, value, tt.kind, tt.value)
+		}
+	}
+}
+
+func TestParseCriterionEvidence(t *testing.T) {
+	task := Task{ID: "t1", AcceptanceCriteria: []string{"Errors are shown", "Receipts are emailed"}}
+
+	ev, err := ParseCriterionEvidence(task, "2=test:TestReceipt")
+	if err != nil {
+		t.Fatalf("ParseCriterionEvidence: %v", err)
+	}
+	if ev.Criterion != "Receipts are emailed" || ev.Kind != EvidenceTest || ev.Value != "TestReceipt" {
+		t.Errorf("unexpected evidence: %+v", ev)
+	}
+
+	for _, raw := range []string{"no-number", "0=abc", "3=text:x", "1=commit:not-a-hash", "1=url:nope", "1="} {
+		if _, err := ParseCriterionEvidence(task, raw); err == nil {
+			t.Errorf("expected error for %q", raw)
+		}
+	}
+}
+
+func TestTask_Coverage(t *testing.T) {
+	task := Task{ID: "t1", AcceptanceCriteria: []string{"a", "b", "c"}}
+	state := NewExecutionState("p")
+
+	if c := task.Coverage(state.TaskStates["t1"]); c.Complete() || c.String() != "0/3" {
+		t.Fatalf("expected 0/3, got %s", c)
+	}
+
+	state.RecordCriterionEvidence("t1", CriterionEvidence{Criterion: "a", Kind: EvidenceText, Value: "first"})
+	state.RecordCriterionEvidence("t1", CriterionEvidence{Criterion: "a", Kind: EvidenceText, Value: "second"})
+	state.RecordCriterionEvidence("t1", CriterionEvidence{Criterion: "c", Kind: EvidenceCommit, Value: "abc1234"})
+
+	if got := state.TaskStates["t1"].CriteriaEvidence; len(got) != 2 || got[0].Value != "second" {
+		t.Fatalf("evidence for a criterion should be replaced, got %+v", got)
+	}
+	c := task.Coverage(state.TaskStates["t1"])
+	if c.String() != "2/3" || strings.Join(c.Missing, ",") != "b" {
+		t.Errorf("unexpected coverage: %+v", c)
+	}
+
+	if !(Task{}).Coverage(TaskResult{}).Complete() {
+		t.Error("a task without criteria should be complete")
+	}
+}
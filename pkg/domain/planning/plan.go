@@ -3,6 +3,7 @@ package planning
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"time"
 )
 
@@ -32,6 +33,8 @@ type Plan struct {
 	ApprovalStatus ApprovalStatus `json:"approval_status" yaml:"approval_status"`
 	CreatedAt      time.Time      `json:"created_at" yaml:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at" yaml:"updated_at"`
+
+	FeatureVerify map[string]Verification `json:"feature_verify,omitempty" yaml:"feature_verify,omitempty"` // Feature ID -> verification for its tasks
 }
 
 type TaskPriority string
@@ -92,9 +95,15 @@ type Task struct {
 	FeatureID   string       `json:"feature_id" yaml:"feature_id"` // Link to the feature in the spec
 	Origin      TaskOrigin   `json:"origin,omitempty" yaml:"origin,omitempty"`
 	Source      TaskSource   `json:"source,omitempty" yaml:"source,omitempty"`
+	Files       []string     `json:"files,omitempty" yaml:"files,omitempty"` // Globs or package directories the task owns
+
+	AcceptanceCriteria []string      `json:"acceptance_criteria,omitempty" yaml:"acceptance_criteria,omitempty"` // Inherited from the spec requirement
+	Verify             *Verification `json:"verify,omitempty" yaml:"verify,omitempty"`                           // Overrides the feature's verification
 }
 
-// Hash returns a deterministic hash of the plan structure.
+// Hash returns a deterministic hash of the plan structure: its identity and
+// task IDs. Approvals and drift baselines are stored against it, so its
+// inputs must not change.
 func (p *Plan) Hash() string {
 	h := sha256.New()
 	h.Write([]byte(p.ID))
@@ -104,3 +113,31 @@ func (p *Plan) Hash() string {
 	}
 	return hex.EncodeToString(h.Sum(nil))
 }
+
+// RevisionHash returns a deterministic hash of the plan's content: its
+// identity, every task's fields and the per-feature verifications. Approval
+// status and timestamps are excluded, so the hash identifies a plan
+// revision.
+func (p *Plan) RevisionHash() string {
+	h := sha256.New()
+	h.Write([]byte(p.ID))
+	h.Write([]byte(p.SpecID))
+	for _, t := range p.Tasks {
+		// Hash the task as it reads back from plan.json, where an empty
+		// priority loads as medium and dependencies may be null or [].
+		if t.Priority == "" {
+			t.Priority = PriorityMedium
+		}
+		if len(t.DependsOn) == 0 {
+			t.DependsOn = nil
+		}
+		// Task marshals deterministically (struct fields in order, no maps).
+		data, _ := json.Marshal(t)
+		h.Write(data)
+	}
+	if len(p.FeatureVerify) > 0 {
+		data, _ := json.Marshal(p.FeatureVerify) // map keys are sorted
+		h.Write(data)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -1,6 +1,7 @@
 package planning_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
@@ -17,4 +18,43 @@ func TestPlan_Hash(t *testing.T) {
 	if p1.Hash() == p3.Hash() {
 		t.Error("Hashes should differ")
 	}
+
+	// The inputs are fixed: approvals and drift baselines store the hash.
+	if got, want := p1.Hash(), "c84f85366e72da14f21bc1631c04719756f30b787e32dbfa2177af68072d41c4"; got != want {
+		t.Errorf("Hash() = %s, want %s", got, want)
+	}
+	p2.Tasks[0].Estimate = "2h"
+	if p1.Hash() != p2.Hash() {
+		t.Error("Hash should only cover task IDs")
+	}
+}
+
+func TestPlan_RevisionHash(t *testing.T) {
+	p1 := &planning.Plan{ID: "1", SpecID: "s1", Tasks: []planning.Task{{ID: "t1"}}}
+	p2 := &planning.Plan{ID: "1", SpecID: "s1", Tasks: []planning.Task{{ID: "t1"}}}
+
+	if p1.RevisionHash() != p2.RevisionHash() {
+		t.Error("Revision hashes should match")
+	}
+	p2.Tasks[0].Estimate = "2h"
+	if p1.RevisionHash() == p2.RevisionHash() {
+		t.Error("Revision hashes should cover task fields")
+	}
+	p2.Tasks[0].Estimate = ""
+	p2.ApprovalStatus = planning.ApprovalApproved
+	if p1.RevisionHash() != p2.RevisionHash() {
+		t.Error("Revision hashes should ignore approval status")
+	}
+
+	data, err := json.Marshal(p1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded planning.Plan
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.RevisionHash() != p1.RevisionHash() {
+		t.Error("Revision hashes should survive a JSON round trip")
+	}
 }
@@ -54,14 +54,21 @@ func (r *PlanReconciler) Reconcile(existing *Plan, proposedTasks []Task, opts Re
 		UpdatedAt:      time.Now(),
 		Tasks:          make([]Task, 0),
 	}
+	if existing != nil {
+		newPlan.FeatureVerify = existing.FeatureVerify
+	}
 
 	// Process proposed tasks
 	for _, proposed := range proposedTasks {
 		if proposed.ID == "" || proposed.Title == "" {
 			continue // Skip malformed proposed tasks
 		}
-		// Task already exists - use the proposed structure.
+		// Task already exists - use the proposed structure, keeping a
+		// verification declared by hand in the plan.
 		// Execution state (Status/Path) is persisted separately in state.json.
+		if current, ok := currentTaskState[proposed.ID]; ok && proposed.Verify == nil {
+			proposed.Verify = current.Verify
+		}
 		delete(currentTaskState, proposed.ID)
 		newPlan.Tasks = append(newPlan.Tasks, proposed)
 	}
@@ -11,3 +11,11 @@ type StateRepository interface {
 	Save(state *ExecutionState) error
 	Load() (*ExecutionState, error)
 }
+
+// RevisionRepository persists approved plan revisions, content-addressed by
+// Plan.Hash, together with the ordered approval history.
+type RevisionRepository interface {
+	SavePlanRevision(plan *Plan, rev PlanRevision) error
+	LoadPlanRevision(hash string) (*Plan, error)
+	ListPlanRevisions() ([]PlanRevision, error)
+}
@@ -0,0 +1,212 @@
+package planning
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// PlanRevision is one entry in the plan history, recorded each time a plan
+// is approved. The plan itself is stored once per content hash.
+type PlanRevision struct {
+	Hash       string    `json:"hash"`
+	PlanID     string    `json:"plan_id"`
+	SpecID     string    `json:"spec_id"`
+	TaskCount  int       `json:"task_count"`
+	ApprovedBy string    `json:"approved_by"`
+	ApprovedAt time.Time `json:"approved_at"`
+	Restored   bool      `json:"restored,omitempty"` // Re-approval of an earlier revision, e.g. after a rollback
+}
+
+// ShortHash returns the abbreviated revision hash used in listings.
+func (r PlanRevision) ShortHash() string {
+	return ShortRevision(r.Hash)
+}
+
+// ShortRevision abbreviates a revision hash to 12 characters.
+func ShortRevision(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
+
+// NewPlanRevision describes the approval of plan by approver.
+func NewPlanRevision(plan *Plan, approver string) PlanRevision {
+	return PlanRevision{
+		Hash:       plan.RevisionHash(),
+		PlanID:     plan.ID,
+		SpecID:     plan.SpecID,
+		TaskCount:  len(plan.Tasks),
+		ApprovedBy: approver,
+		ApprovedAt: time.Now(),
+	}
+}
+
+// ResolveRevision finds the revision whose hash equals or starts with ref.
+// Prefixes must be at least 4 characters and unambiguous. When a hash was
+// approved more than once, the latest approval is returned.
+func ResolveRevision(revisions []PlanRevision, ref string) (PlanRevision, error) {
+	ref = strings.ToLower(strings.TrimSpace(ref))
+	if len(ref) < 4 {
+		return PlanRevision{}, fmt.Errorf("revision %q is too short; use at least 4 characters of the hash", ref)
+	}
+	var match *PlanRevision
+	for i := len(revisions) - 1; i >= 0; i-- {
+		r := revisions[i]
+		if !strings.HasPrefix(r.Hash, ref) {
+			continue
+		}
+		if match != nil && match.Hash != r.Hash {
+			return PlanRevision{}, fmt.Errorf("revision %q is ambiguous: matches %s and %s", ref, match.ShortHash(), r.ShortHash())
+		}
+		if match == nil {
+			match = &revisions[i]
+		}
+	}
+	if match == nil {
+		return PlanRevision{}, fmt.Errorf("revision %q not found in plan history", ref)
+	}
+	return *match, nil
+}
+
+// TaskChangeKind classifies a task change between two plans.
+type TaskChangeKind string
+
+const (
+	TaskAdded    TaskChangeKind = "added"
+	TaskRemoved  TaskChangeKind = "removed"
+	TaskModified TaskChangeKind = "modified"
+)
+
+// TaskChange describes one task-level difference between two plans. Field,
+// Old and New are set for modifications; Title for additions and removals.
+type TaskChange struct {
+	Kind   TaskChangeKind `json:"kind"`
+	TaskID string         `json:"task_id"`
+	Title  string         `json:"title,omitempty"`
+	Field  string         `json:"field,omitempty"`
+	Old    string         `json:"old,omitempty"`
+	New    string         `json:"new,omitempty"`
+}
+
+// DependencyChange describes a dependency edge From -> To (From depends on
+// To) that was added or removed.
+type DependencyChange struct {
+	Kind TaskChangeKind `json:"kind"`
+	From string         `json:"from"`
+	To   string         `json:"to"`
+}
+
+// PlanDiff is the structured difference from a base plan to a current plan.
+type PlanDiff struct {
+	BaseHash     string             `json:"base_hash"`
+	CurrentHash  string             `json:"current_hash"`
+	Tasks        []TaskChange       `json:"tasks"`
+	Dependencies []DependencyChange `json:"dependencies"`
+}
+
+// IsEmpty reports whether the two plans have the same tasks and edges.
+func (d *PlanDiff) IsEmpty() bool {
+	return d == nil || (len(d.Tasks) == 0 && len(d.Dependencies) == 0)
+}
+
+// DiffPlans computes the task and dependency-edge changes needed to turn base
+// into current. Tasks are matched by ID. Task changes follow the current
+// plan's order, with removals last; edges are sorted.
+func DiffPlans(base, current *Plan) *PlanDiff {
+	if base == nil {
+		base = &Plan{}
+	}
+	if current == nil {
+		current = &Plan{}
+	}
+
+	d := &PlanDiff{
+		BaseHash:     base.RevisionHash(),
+		CurrentHash:  current.RevisionHash(),
+		Tasks:        make([]TaskChange, 0),
+		Dependencies: make([]DependencyChange, 0),
+	}
+
+	baseByID := make(map[string]Task, len(base.Tasks))
+	for _, t := range base.Tasks {
+		baseByID[t.ID] = t
+	}
+	currentIDs := make(map[string]bool, len(current.Tasks))
+	for _, t := range current.Tasks {
+		currentIDs[t.ID] = true
+		old, ok := baseByID[t.ID]
+		if !ok {
+			d.Tasks = append(d.Tasks, TaskChange{Kind: TaskAdded, TaskID: t.ID, Title: t.Title})
+			continue
+		}
+		for _, f := range taskFields(old, t) {
+			if f.old != f.new {
+				d.Tasks = append(d.Tasks, TaskChange{Kind: TaskModified, TaskID: t.ID, Field: f.name, Old: f.old, New: f.new})
+			}
+		}
+	}
+	for _, t := range base.Tasks {
+		if !currentIDs[t.ID] {
+			d.Tasks = append(d.Tasks, TaskChange{Kind: TaskRemoved, TaskID: t.ID, Title: t.Title})
+		}
+	}
+
+	baseEdges, currentEdges := dependencyEdges(base), dependencyEdges(current)
+	for _, e := range currentEdges {
+		if !slices.Contains(baseEdges, e) {
+			d.Dependencies = append(d.Dependencies, DependencyChange{Kind: TaskAdded, From: e[0], To: e[1]})
+		}
+	}
+	for _, e := range baseEdges {
+		if !slices.Contains(currentEdges, e) {
+			d.Dependencies = append(d.Dependencies, DependencyChange{Kind: TaskRemoved, From: e[0], To: e[1]})
+		}
+	}
+	return d
+}
+
+type taskField struct{ name, old, new string }
+
+// taskFields lists the compared task fields, rendered as strings.
+func taskFields(old, cur Task) []taskField {
+	return []taskField{
+		{"title", old.Title, cur.Title},
+		{"description", old.Description, cur.Description},
+		{"priority", string(old.Priority), string(cur.Priority)},
+		{"estimate", old.Estimate, cur.Estimate},
+		{"feature_id", old.FeatureID, cur.FeatureID},
+		{"files", strings.Join(old.Files, ", "), strings.Join(cur.Files, ", ")},
+		{"acceptance_criteria", strings.Join(old.AcceptanceCriteria, "; "), strings.Join(cur.AcceptanceCriteria, "; ")},
+		{"verify", verificationString(old.Verify), verificationString(cur.Verify)},
+	}
+}
+
+func verificationString(v *Verification) string {
+	if v == nil || v.IsZero() {
+		return ""
+	}
+	return v.String()
+}
+
+// dependencyEdges returns the plan's sorted, de-duplicated dependency edges.
+func dependencyEdges(p *Plan) [][2]string {
+	edges := make([][2]string, 0)
+	for _, t := range p.Tasks {
+		for _, dep := range t.DependsOn {
+			e := [2]string{t.ID, dep}
+			if !slices.Contains(edges, e) {
+				edges = append(edges, e)
+			}
+		}
+	}
+	slices.SortFunc(edges, func(a, b [2]string) int {
+		if c := strings.Compare(a[0], b[0]); c != 0 {
+			return c
+		}
+		return strings.Compare(a[1], b[1])
+	})
+	return edges
+}
@@ -0,0 +1,64 @@
+package planning_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+)
+
+func TestDiffPlans(t *testing.T) {
+	base := &planning.Plan{ID: "p1", Tasks: []planning.Task{
+		{ID: "t1", Title: "Login", Priority: planning.PriorityMedium},
+		{ID: "t2", Title: "Logout", DependsOn: []string{"t1"}},
+		{ID: "t3", Title: "Audit"},
+	}}
+	current := &planning.Plan{ID: "p1", Tasks: []planning.Task{
+		{ID: "t1", Title: "Login", Priority: planning.PriorityHigh, Verify: &planning.Verification{Test: "TestLogin"}},
+		{ID: "t2", Title: "Logout", DependsOn: []string{"t4"}},
+		{ID: "t4", Title: "Sessions"},
+	}}
+
+	d := planning.DiffPlans(base, current)
+	got := make([]string, 0, len(d.Tasks))
+	for _, c := range d.Tasks {
+		got = append(got, string(c.Kind)+" "+c.TaskID+" "+c.Field)
+	}
+	want := []string{"modified t1 priority", "modified t1 verify", "added t4 ", "removed t3 "}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("task changes = %v, want %v", got, want)
+	}
+	if len(d.Dependencies) != 2 ||
+		d.Dependencies[0] != (planning.DependencyChange{Kind: planning.TaskAdded, From: "t2", To: "t4"}) ||
+		d.Dependencies[1] != (planning.DependencyChange{Kind: planning.TaskRemoved, From: "t2", To: "t1"}) {
+		t.Errorf("unexpected dependency changes: %+v", d.Dependencies)
+	}
+	if d.BaseHash != base.RevisionHash() || d.CurrentHash != current.RevisionHash() {
+		t.Error("diff should carry both plan hashes")
+	}
+	if !planning.DiffPlans(base, base).IsEmpty() {
+		t.Error("a plan should not differ from itself")
+	}
+}
+
+func TestResolveRevision(t *testing.T) {
+	revisions := []planning.PlanRevision{
+		{Hash: "abcd1111", ApprovedBy: "alice"},
+		{Hash: "abce2222", ApprovedBy: "bob"},
+		{Hash: "abcd1111", ApprovedBy: "carol", Restored: true},
+	}
+
+	rev, err := planning.ResolveRevision(revisions, "ABCD")
+	if err != nil || rev.ApprovedBy != "carol" {
+		t.Errorf("expected the latest approval of abcd1111, got %+v, %v", rev, err)
+	}
+	if _, err := planning.ResolveRevision(revisions, "abc"); err == nil || !strings.Contains(err.Error(), "too short") {
+		t.Errorf("expected a too-short error, got %v", err)
+	}
+	if _, err := planning.ResolveRevision(append(revisions, planning.PlanRevision{Hash: "abce2299"}), "abce"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("expected an ambiguity error, got %v", err)
+	}
+	if _, err := planning.ResolveRevision(revisions, "ffff"); err == nil {
+		t.Error("expected an error for an unknown revision")
+	}
+}
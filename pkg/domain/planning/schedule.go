@@ -0,0 +1,421 @@
+package planning
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultTaskEstimate is the duration assumed for tasks without a valid
+// estimate.
+const DefaultTaskEstimate = HoursPerDay * time.Hour
+
+// ScheduleOptions configures BuildSchedule.
+type ScheduleOptions struct {
+	// Capacity is the number of tasks that can be in progress at once,
+	// usually the smaller of the team size and the WIP limit. Zero means
+	// unlimited, which makes the schedule follow the critical path.
+	Capacity int
+	// DefaultEstimate is used for tasks without a valid estimate; zero
+	// means DefaultTaskEstimate.
+	DefaultEstimate time.Duration
+	// Now is when the schedule starts; zero means time.Now().
+	Now time.Time
+}
+
+// ScheduledTask is one task of a Schedule. Times are work hours from the
+// start of the schedule.
+type ScheduledTask struct {
+	ID        string     `json:"id"`
+	Title     string     `json:"title"`
+	Status    TaskStatus `json:"status"`
+	DependsOn []string   `json:"depends_on,omitempty"`
+	// Estimated is false when the task has no valid estimate and the
+	// default estimate was used.
+	Estimated bool `json:"estimated"`
+	// Remaining is the work left: zero for complete tasks, the estimate
+	// minus the time already spent for tasks in progress.
+	Remaining float64 `json:"remaining_hours"`
+
+	EarliestStart  float64 `json:"earliest_start"`
+	EarliestFinish float64 `json:"earliest_finish"`
+	LatestStart    float64 `json:"latest_start"`
+	LatestFinish   float64 `json:"latest_finish"`
+	// Slack is how long the task can slip without delaying the project.
+	Slack    float64 `json:"slack"`
+	Critical bool    `json:"critical"`
+
+	// Start and Finish place the task within the capacity limit.
+	Start  float64 `json:"start"`
+	Finish float64 `json:"finish"`
+}
+
+// Schedule is the critical path analysis and capacity-limited schedule of
+// a plan's remaining work.
+type Schedule struct {
+	StartsAt time.Time       `json:"starts_at"`
+	Capacity int             `json:"capacity"`
+	Tasks    []ScheduledTask `json:"tasks"`
+	// CriticalPath lists the remaining tasks, in order, that determine
+	// the shortest possible duration.
+	CriticalPath []string `json:"critical_path"`
+	// CriticalPathHours is the shortest possible duration in work hours,
+	// assuming unlimited capacity.
+	CriticalPathHours float64 `json:"critical_path_hours"`
+	// TotalHours is the duration in work hours within the capacity limit.
+	TotalHours float64 `json:"total_hours"`
+	// Unestimated lists the tasks scheduled with the default estimate.
+	Unestimated []string `json:"unestimated,omitempty"`
+}
+
+// scheduleNode is a task during scheduling; durations keep the passes exact.
+type scheduleNode struct {
+	task      Task
+	status    TaskStatus
+	deps      []*scheduleNode
+	succs     []*scheduleNode
+	remaining time.Duration
+	estimated bool
+	es, ef    time.Duration
+	ls, lf    time.Duration
+	start     time.Duration
+	finish    time.Duration
+	scheduled bool
+}
+
+// BuildSchedule computes the earliest and latest start, the slack and the
+// critical path of every task from its estimate and the execution state,
+// then schedules the remaining work within opts.Capacity. Dependencies on
+// other projects and on unknown tasks are ignored.
+func BuildSchedule(plan *Plan, state *ExecutionState, opts ScheduleOptions) (*Schedule, error) {
+	if err := plan.ValidateDAG(); err != nil {
+		return nil, err
+	}
+	if opts.DefaultEstimate <= 0 {
+		opts.DefaultEstimate = DefaultTaskEstimate
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	sched := &Schedule{StartsAt: opts.Now, Capacity: max(opts.Capacity, 0)}
+	nodes := make([]*scheduleNode, 0, len(plan.Tasks))
+	byID := make(map[string]*scheduleNode, len(plan.Tasks))
+	for _, task := range plan.Tasks {
+		n := &scheduleNode{task: task, status: StatusPending, estimated: true}
+		var result TaskResult
+		if state != nil {
+			if r, ok := state.TaskStates[task.ID]; ok {
+				result = r
+				n.status = r.Status
+			}
+		}
+		estimate, err := ParseEstimate(task.Estimate)
+		work := estimate.Duration()
+		if err != nil || estimate.IsZero() {
+			work = opts.DefaultEstimate
+			n.estimated = false
+			sched.Unestimated = append(sched.Unestimated, task.ID)
+		}
+		n.remaining = remainingWork(n.status, result, work, opts.Now)
+		nodes = append(nodes, n)
+		byID[task.ID] = n
+	}
+	for _, n := range nodes {
+		for _, dep := range n.task.DependsOn {
+			if d, ok := byID[dep]; ok {
+				n.deps = append(n.deps, d)
+				d.succs = append(d.succs, n)
+			}
+		}
+	}
+
+	order := topologicalOrder(nodes)
+	var length time.Duration
+	for _, n := range order {
+		n.es = 0
+		for _, d := range n.deps {
+			n.es = max(n.es, d.ef)
+		}
+		n.ef = n.es + n.remaining
+		length = max(length, n.ef)
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		n := order[i]
+		n.lf = length
+		for _, s := range n.succs {
+			n.lf = min(n.lf, s.ls)
+		}
+		n.ls = n.lf - n.remaining
+	}
+
+	sched.TotalHours = scheduleWithin(nodes, sched.Capacity).Hours()
+	sched.CriticalPathHours = length.Hours()
+	sched.CriticalPath = criticalPath(nodes, length)
+
+	for _, n := range nodes {
+		deps := make([]string, 0, len(n.deps))
+		for _, d := range n.deps {
+			deps = append(deps, d.task.ID)
+		}
+		sched.Tasks = append(sched.Tasks, ScheduledTask{
+			ID:             n.task.ID,
+			Title:          n.task.Title,
+			Status:         n.status,
+			DependsOn:      deps,
+			Estimated:      n.estimated,
+			Remaining:      n.remaining.Hours(),
+			EarliestStart:  n.es.Hours(),
+			EarliestFinish: n.ef.Hours(),
+			LatestStart:    n.ls.Hours(),
+			LatestFinish:   n.lf.Hours(),
+			Slack:          (n.ls - n.es).Hours(),
+			Critical:       n.critical(),
+			Start:          n.start.Hours(),
+			Finish:         n.finish.Hours(),
+		})
+	}
+	return sched, nil
+}
+
+// critical reports whether the task is remaining work without slack.
+func (n *scheduleNode) critical() bool {
+	return !n.status.IsComplete() && n.ls == n.es
+}
+
+// remainingWork returns the work left on a task. Time spent on a task in
+// progress is counted in work hours, HoursPerDay per calendar day.
+func remainingWork(status TaskStatus, result TaskResult, work time.Duration, now time.Time) time.Duration {
+	switch {
+	case status.IsComplete():
+		return 0
+	case status == StatusInProgress && result.StartedAt != nil:
+		spent := now.Sub(*result.StartedAt) * HoursPerDay / 24
+		return max(work-spent, 0)
+	}
+	return work
+}
+
+// topologicalOrder returns the nodes with every task after its
+// dependencies, keeping plan order otherwise.
+func topologicalOrder(nodes []*scheduleNode) []*scheduleNode {
+	order := make([]*scheduleNode, 0, len(nodes))
+	visited := make(map[*scheduleNode]bool, len(nodes))
+	var visit func(n *scheduleNode)
+	visit = func(n *scheduleNode) {
+		if visited[n] {
+			return
+		}
+		visited[n] = true
+		for _, d := range n.deps {
+			visit(d)
+		}
+		order = append(order, n)
+	}
+	for _, n := range nodes {
+		visit(n)
+	}
+	return order
+}
+
+// scheduleWithin places the remaining work with at most capacity tasks in
+// progress at once (zero is unlimited) and returns when it finishes. Tasks
+// already in progress keep their slot; ready tasks are started by least
+// latest start, then priority, then plan order.
+func scheduleWithin(nodes []*scheduleNode, capacity int) time.Duration {
+	rank := make(map[*scheduleNode]int, len(nodes))
+	var running []*scheduleNode
+	for i, n := range nodes {
+		rank[n] = i
+		if n.status.IsComplete() || n.status == StatusInProgress {
+			n.scheduled = true
+			n.finish = n.remaining
+			if n.status == StatusInProgress {
+				running = append(running, n)
+			}
+		}
+	}
+
+	var now, end time.Duration
+	for {
+		kept := running[:0]
+		for _, n := range running {
+			if n.finish > now {
+				kept = append(kept, n)
+			}
+		}
+		running = kept
+
+		var ready []*scheduleNode
+		for _, n := range nodes {
+			if !n.scheduled && n.depsFinishedBy(now) {
+				ready = append(ready, n)
+			}
+		}
+		sort.SliceStable(ready, func(i, j int) bool {
+			a, b := ready[i], ready[j]
+			if a.ls != b.ls {
+				return a.ls < b.ls
+			}
+			if c := a.task.Priority.Compare(b.task.Priority); c != 0 {
+				return c > 0
+			}
+			return rank[a] < rank[b]
+		})
+
+		instant := false
+		for _, n := range ready {
+			if capacity > 0 && len(running) >= capacity {
+				break
+			}
+			n.scheduled = true
+			n.start, n.finish = now, now+n.remaining
+			if n.remaining == 0 {
+				instant = true
+				continue
+			}
+			running = append(running, n)
+		}
+		if instant {
+			continue
+		}
+		if len(running) == 0 {
+			break
+		}
+		now = running[0].finish
+		for _, n := range running[1:] {
+			now = min(now, n.finish)
+		}
+	}
+
+	for _, n := range nodes {
+		end = max(end, n.finish)
+	}
+	return end
+}
+
+// depsFinishedBy reports whether every dependency is scheduled to finish
+// by t.
+func (n *scheduleNode) depsFinishedBy(t time.Duration) bool {
+	for _, d := range n.deps {
+		if !d.scheduled || d.finish > t {
+			return false
+		}
+	}
+	return true
+}
+
+// criticalPath follows critical tasks back from the one finishing last.
+func criticalPath(nodes []*scheduleNode, length time.Duration) []string {
+	var last *scheduleNode
+	for _, n := range nodes {
+		if n.critical() && n.ef == length && n.remaining > 0 {
+			last = n
+			break
+		}
+	}
+	var path []string
+	for n := last; n != nil; {
+		path = append([]string{n.task.ID}, path...)
+		var prev *scheduleNode
+		for _, d := range n.deps {
+			if d.critical() && d.ef == n.es && d.remaining > 0 {
+				prev = d
+				break
+			}
+		}
+		n = prev
+	}
+	return path
+}
+
+// Task returns the scheduled task with the given ID.
+func (s *Schedule) Task(id string) (ScheduledTask, bool) {
+	for _, t := range s.Tasks {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return ScheduledTask{}, false
+}
+
+// Date returns the calendar time a number of work hours after the start,
+// counting HoursPerDay hours per weekday and skipping weekends.
+func (s *Schedule) Date(hours float64) time.Time {
+	t := s.StartsAt
+	days := int(hours / HoursPerDay)
+	rest := time.Duration((hours - float64(days*HoursPerDay)) * float64(time.Hour))
+	for days > 0 {
+		t = t.AddDate(0, 0, 1)
+		if t.Weekday() != time.Saturday && t.Weekday() != time.Sunday {
+			days--
+		}
+	}
+	return t.Add(rest).Round(time.Minute)
+}
+
+// FinishesAt returns the calendar time the scheduled work is complete.
+func (s *Schedule) FinishesAt() time.Time {
+	return s.Date(s.TotalHours)
+}
+
+// Gantt renders the remaining tasks as a text Gantt chart at most width
+// characters wide for the bars; critical tasks are drawn with '#'.
+func (s *Schedule) Gantt(width int) string {
+	if width <= 0 {
+		width = 60
+	}
+	scale := 1.0
+	if s.TotalHours > float64(width) {
+		scale = float64(width) / s.TotalHours
+	}
+	idWidth := 0
+	for _, t := range s.Tasks {
+		idWidth = max(idWidth, len(t.ID))
+	}
+
+	var b strings.Builder
+	for _, t := range s.Tasks {
+		if t.Remaining == 0 {
+			continue
+		}
+		from := int(math.Round(t.Start * scale))
+		to := max(int(math.Round(t.Finish*scale)), from+1)
+		bar := "="
+		if t.Critical {
+			bar = "#"
+		}
+		fmt.Fprintf(&b, "%-*s |%s%s\n", idWidth, t.ID, strings.Repeat(" ", from), strings.Repeat(bar, to-from))
+	}
+	return b.String()
+}
+
+// Mermaid renders the remaining tasks as a Mermaid gantt diagram with
+// critical tasks marked "crit".
+func (s *Schedule) Mermaid() string {
+	const layout = "2006-01-02 15:04"
+	var b strings.Builder
+	b.WriteString("gantt\n")
+	b.WriteString("    dateFormat YYYY-MM-DD HH:mm\n")
+	b.WriteString("    axisFormat %m-%d\n")
+	for _, t := range s.Tasks {
+		if t.Remaining == 0 {
+			continue
+		}
+		tags := ""
+		switch {
+		case t.Critical:
+			tags = "crit, "
+		case t.Status == StatusInProgress:
+			tags = "active, "
+		}
+		title := strings.NewReplacer(":", " ", "#", " ", ";", " ").Replace(t.Title)
+		if title == "" {
+			title = t.ID
+		}
+		fmt.Fprintf(&b, "    %s :%s%s, %s, %s\n", title, tags, t.ID, s.Date(t.Start).Format(layout), s.Date(t.Finish).Format(layout))
+	}
+	return b.String()
+}
@@ -0,0 +1,143 @@
+package planning_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+)
+
+// schedulePlan is a diamond: design feeds api (2d) and ui (1d), both
+// feeding release.
+func schedulePlan() *planning.Plan {
+	return &planning.Plan{Tasks: []planning.Task{
+		{ID: "design", Title: "Design", Estimate: "1d"},
+		{ID: "api", Title: "API", Estimate: "2d", DependsOn: []string{"design"}},
+		{ID: "ui", Title: "UI", Estimate: "1d", DependsOn: []string{"design"}},
+		{ID: "release", Title: "Release", Estimate: "4h", DependsOn: []string{"api", "ui", "@ops:task-deploy"}},
+	}}
+}
+
+// monday is a Monday morning, so work-day arithmetic crosses no weekend
+// within the first five days.
+var monday = time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+
+func TestBuildSchedule_CriticalPath(t *testing.T) {
+	sched, err := planning.BuildSchedule(schedulePlan(), nil, planning.ScheduleOptions{Now: monday})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(sched.CriticalPath, []string{"design", "api", "release"}) {
+		t.Errorf("critical path = %v", sched.CriticalPath)
+	}
+	if sched.CriticalPathHours != 28 || sched.TotalHours != 28 {
+		t.Errorf("hours = %v/%v, want 28/28", sched.CriticalPathHours, sched.TotalHours)
+	}
+
+	ui, _ := sched.Task("ui")
+	if ui.EarliestStart != 8 || ui.LatestStart != 16 || ui.Slack != 8 || ui.Critical {
+		t.Errorf("ui = %+v", ui)
+	}
+	release, _ := sched.Task("release")
+	if release.EarliestStart != 24 || release.Slack != 0 || !release.Critical {
+		t.Errorf("release = %+v", release)
+	}
+	if !slices.Equal(release.DependsOn, []string{"api", "ui"}) {
+		t.Errorf("release depends on %v, want the local tasks only", release.DependsOn)
+	}
+}
+
+func TestBuildSchedule_Capacity(t *testing.T) {
+	sched, err := planning.BuildSchedule(schedulePlan(), nil, planning.ScheduleOptions{Capacity: 1, Now: monday})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// One person works through all 4.5 days; api goes first because it has
+	// no slack.
+	if sched.TotalHours != 36 {
+		t.Errorf("total hours = %v, want 36", sched.TotalHours)
+	}
+	api, _ := sched.Task("api")
+	ui, _ := sched.Task("ui")
+	if api.Start != 8 || ui.Start != 24 {
+		t.Errorf("api starts at %v, ui at %v", api.Start, ui.Start)
+	}
+	if sched.CriticalPathHours != 28 {
+		t.Errorf("critical path hours = %v, want 28", sched.CriticalPathHours)
+	}
+}
+
+func TestBuildSchedule_State(t *testing.T) {
+	started := monday.Add(-24 * time.Hour) // one calendar day = one work day
+	state := planning.NewExecutionState("p")
+	state.TaskStates["design"] = planning.TaskResult{Status: planning.StatusDone}
+	state.TaskStates["api"] = planning.TaskResult{Status: planning.StatusInProgress, StartedAt: &started}
+
+	plan := schedulePlan()
+	plan.Tasks[2].Estimate = "" // ui falls back to the default estimate
+	sched, err := planning.BuildSchedule(plan, state, planning.ScheduleOptions{Capacity: 1, Now: monday})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	design, _ := sched.Task("design")
+	api, _ := sched.Task("api")
+	if design.Remaining != 0 || design.Critical || api.Remaining != 8 || api.Start != 0 {
+		t.Errorf("design = %+v, api = %+v", design, api)
+	}
+	if !slices.Equal(sched.Unestimated, []string{"ui"}) {
+		t.Errorf("unestimated = %v", sched.Unestimated)
+	}
+	// api (8h left) and ui (8h) run one after the other, then release.
+	if sched.TotalHours != 20 {
+		t.Errorf("total hours = %v, want 20", sched.TotalHours)
+	}
+	if got := sched.FinishesAt(); !got.Equal(time.Date(2026, 10, 14, 13, 0, 0, 0, time.UTC)) {
+		t.Errorf("finishes at %v", got)
+	}
+}
+
+func TestBuildSchedule_Cycle(t *testing.T) {
+	plan := &planning.Plan{Tasks: []planning.Task{
+		{ID: "a", DependsOn: []string{"b"}},
+		{ID: "b", DependsOn: []string{"a"}},
+	}}
+	if _, err := planning.BuildSchedule(plan, nil, planning.ScheduleOptions{}); err == nil {
+		t.Error("expected a cycle error")
+	}
+}
+
+func TestSchedule_Date_SkipsWeekends(t *testing.T) {
+	friday := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	sched := &planning.Schedule{StartsAt: friday}
+	if got := sched.Date(12); !got.Equal(time.Date(2026, 10, 19, 13, 0, 0, 0, time.UTC)) {
+		t.Errorf("Date(12) = %v, want Monday 13:00", got)
+	}
+}
+
+func TestSchedule_Render(t *testing.T) {
+	sched, err := planning.BuildSchedule(schedulePlan(), nil, planning.ScheduleOptions{Now: monday})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gantt := sched.Gantt(36)
+	if !strings.Contains(gantt, "design  |########\n") || !strings.Contains(gantt, "ui      |        ========\n") {
+		t.Errorf("unexpected gantt:\n%s", gantt)
+	}
+
+	mermaid := sched.Mermaid()
+	for _, want := range []string{
+		"gantt\n",
+		"    API :crit, api, 2026-10-13 09:00, 2026-10-15 09:00\n",
+		"    UI :ui, 2026-10-13 09:00, 2026-10-14 09:00\n",
+	} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("mermaid missing %q:\n%s", want, mermaid)
+		}
+	}
+}
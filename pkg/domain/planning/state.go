@@ -25,11 +25,14 @@ func (e *ConflictError) Error() string {
 
 // TaskResult captures the progress of a single task.
 type TaskResult struct {
-	Status       TaskStatus             `json:"status"`
-	Path         string                 `json:"path"`
-	Owner        string                 `json:"owner,omitempty"`    // Who is currently working on this?
-	Evidence     []string               `json:"evidence,omitempty"` // List of evidence (commit hashes, links, etc.)
-	ExternalRefs map[string]ExternalRef `json:"external_refs,omitempty"`
+	Status           TaskStatus             `json:"status"`
+	Path             string                 `json:"path"`
+	Owner            string                 `json:"owner,omitempty"`             // Who is currently working on this?
+	Evidence         []string               `json:"evidence,omitempty"`          // List of evidence (commit hashes, links, etc.)
+	CriteriaEvidence []CriterionEvidence    `json:"criteria_evidence,omitempty"` // Evidence per acceptance criterion, recorded on verify
+	VerificationRun  *VerificationRun       `json:"verification_run,omitempty"`  // Latest `task verify --run` result
+	ExternalRefs     map[string]ExternalRef `json:"external_refs,omitempty"`
+	Comments         []TaskComment          `json:"comments,omitempty"`
 
 	// Time tracking fields
 	StartedAt      *time.Time `json:"started_at,omitempty"`   // When task moved to in_progress
@@ -46,6 +49,17 @@ type ExternalRef struct {
 	LastSyncedAt time.Time `json:"last_synced_at"`
 }
 
+// TaskComment is a comment on a task, written in Roady or synced from an
+// external system.
+type TaskComment struct {
+	ID         string    `json:"id"`
+	Author     string    `json:"author"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"created_at"`
+	Provider   string    `json:"provider,omitempty"`    // External system the comment came from; empty for Roady comments
+	ExternalID string    `json:"external_id,omitempty"` // The comment's ID in that system
+}
+
 func NewExecutionState(projectID string) *ExecutionState {
 	return &ExecutionState{
 		ProjectID:  projectID,
@@ -112,6 +126,27 @@ func (s *ExecutionState) SetExternalRef(taskID string, provider string, ref Exte
 	s.UpdatedAt = time.Now()
 }
 
+// AddComment appends a comment to a task's result. A comment already synced
+// from the same provider with the same external ID is not added again; the
+// return value reports whether the comment was added.
+func (s *ExecutionState) AddComment(taskID string, comment TaskComment) bool {
+	result := s.TaskStates[taskID]
+	if comment.ExternalID != "" {
+		for _, c := range result.Comments {
+			if c.Provider == comment.Provider && c.ExternalID == comment.ExternalID {
+				return false
+			}
+		}
+	}
+	if result.Status == "" {
+		result.Status = StatusPending
+	}
+	result.Comments = append(result.Comments, comment)
+	s.TaskStates[taskID] = result
+	s.UpdatedAt = time.Now()
+	return true
+}
+
 // CountByStatus returns the count of tasks with the given status.
 func (s *ExecutionState) CountByStatus(status TaskStatus) int {
 	count := 0
@@ -94,6 +94,26 @@ func TestExecutionState_AddEvidence(t *testing.T) {
 	}
 }
 
+func TestExecutionState_AddComment(t *testing.T) {
+	state := NewExecutionState("test")
+
+	if !state.AddComment("t1", TaskComment{ID: "c1", Author: "alice", Body: "Looks good"}) {
+		t.Fatal("expected a Roady comment to be added")
+	}
+	synced := TaskComment{ID: "c2", Author: "bob", Body: "Blocked on API", Provider: "linear", ExternalID: "lin-c-1"}
+	if !state.AddComment("t1", synced) {
+		t.Fatal("expected a synced comment to be added")
+	}
+	if state.AddComment("t1", synced) {
+		t.Error("expected a comment synced twice to be added once")
+	}
+
+	result := state.TaskStates["t1"]
+	if len(result.Comments) != 2 || result.Status != StatusPending {
+		t.Errorf("expected 2 comments on a pending task, got %+v", result)
+	}
+}
+
 func TestAddEvidence_InitializesStatusForNewTask(t *testing.T) {
 	state := NewExecutionState("test")
 
@@ -0,0 +1,38 @@
+package planning
+
+import "strings"
+
+// TaskRef is a dependency as written in Task.DependsOn: the ID of a task in
+// the same plan, or "@project:task-id" for a task of another project in the
+// workspace. "@:task-id" refers to the root project.
+type TaskRef struct {
+	Project  string // Project of an external reference; "" is the root project
+	TaskID   string
+	External bool
+}
+
+// ParseTaskRef parses a DependsOn entry. Entries without the "@project:"
+// prefix are local task IDs.
+func ParseTaskRef(dep string) TaskRef {
+	rest, ok := strings.CutPrefix(dep, "@")
+	if !ok {
+		return TaskRef{TaskID: dep}
+	}
+	project, id, ok := strings.Cut(rest, ":")
+	if !ok || id == "" {
+		return TaskRef{TaskID: dep}
+	}
+	return TaskRef{Project: project, TaskID: id, External: true}
+}
+
+// ExternalTaskRef formats a reference to a task of another project.
+func ExternalTaskRef(project, taskID string) string {
+	return "@" + project + ":" + taskID
+}
+
+func (r TaskRef) String() string {
+	if r.External {
+		return ExternalTaskRef(r.Project, r.TaskID)
+	}
+	return r.TaskID
+}
@@ -0,0 +1,29 @@
+package planning
+
+import "testing"
+
+func TestParseTaskRef(t *testing.T) {
+	tests := []struct {
+		dep  string
+		want TaskRef
+	}{
+		{"task-a", TaskRef{TaskID: "task-a"}},
+		{"@api:task-a", TaskRef{Project: "api", TaskID: "task-a", External: true}},
+		{"@:task-a", TaskRef{TaskID: "task-a", External: true}},
+		{"@api", TaskRef{TaskID: "@api"}},
+		{"@api:", TaskRef{TaskID: "@api:"}},
+		{"repo:task-a", TaskRef{TaskID: "repo:task-a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dep, func(t *testing.T) {
+			got := ParseTaskRef(tt.dep)
+			if got != tt.want {
+				t.Errorf("ParseTaskRef(%q) = %+v, want %+v", tt.dep, got, tt.want)
+			}
+			if got.String() != tt.dep {
+				t.Errorf("String() = %q, want %q", got.String(), tt.dep)
+			}
+		})
+	}
+}
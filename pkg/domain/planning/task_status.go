@@ -129,6 +129,24 @@ func (s TaskStatus) IsFinal() bool {
 	return s == StatusVerified
 }
 
+// Progress ranks a status by how far along the FSM it is: pending, then
+// in progress or blocked, then done, then verified. Unknown statuses rank
+// below pending.
+func (s TaskStatus) Progress() int {
+	switch s {
+	case StatusPending:
+		return 0
+	case StatusInProgress, StatusBlocked:
+		return 1
+	case StatusDone:
+		return 2
+	case StatusVerified:
+		return 3
+	default:
+		return -1
+	}
+}
+
 // IsComplete returns true if the task is done or verified.
 func (s TaskStatus) IsComplete() bool {
 	return s == StatusDone || s == StatusVerified
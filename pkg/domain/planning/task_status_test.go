@@ -28,6 +28,18 @@ func TestTaskStatus_IsValid(t *testing.T) {
 	}
 }
 
+func TestTaskStatus_Progress(t *testing.T) {
+	order := []TaskStatus{TaskStatus("bogus"), StatusPending, StatusInProgress, StatusDone, StatusVerified}
+	for i := 1; i < len(order); i++ {
+		if order[i-1].Progress() >= order[i].Progress() {
+			t.Errorf("expected %s to rank below %s", order[i-1], order[i])
+		}
+	}
+	if StatusBlocked.Progress() != StatusInProgress.Progress() {
+		t.Error("expected blocked and in_progress to rank equally")
+	}
+}
+
 func TestTaskStatus_CanTransitionTo(t *testing.T) {
 	tests := []struct {
 		from  TaskStatus
@@ -0,0 +1,139 @@
+package planning
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultVerificationTimeout bounds verification commands that do not set
+// their own timeout.
+const DefaultVerificationTimeout = 10 * time.Minute
+
+// Verification declares how a task is verified by running something locally:
+// either a shell command or a `go test -run` pattern.
+type Verification struct {
+	Command string `json:"command,omitempty" yaml:"command,omitempty"` // Run with sh -c from the project root
+	Test    string `json:"test,omitempty" yaml:"test,omitempty"`       // Pattern for go test -run
+	Package string `json:"package,omitempty" yaml:"package,omitempty"` // Packages for Test; defaults to ./...
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"` // e.g. "90s", "5m"
+}
+
+// IsZero reports whether no verification has been declared.
+func (v Verification) IsZero() bool {
+	return v.Command == "" && v.Test == ""
+}
+
+// Validate checks that exactly one of Command and Test is set and that the
+// timeout parses.
+func (v Verification) Validate() error {
+	switch {
+	case v.Command == "" && v.Test == "":
+		return fmt.Errorf("verification needs a command or a test pattern")
+	case v.Command != "" && v.Test != "":
+		return fmt.Errorf("verification sets both command and test; choose one")
+	}
+	_, err := v.TimeoutDuration()
+	return err
+}
+
+// TimeoutDuration returns the declared timeout, or DefaultVerificationTimeout.
+func (v Verification) TimeoutDuration() (time.Duration, error) {
+	if strings.TrimSpace(v.Timeout) == "" {
+		return DefaultVerificationTimeout, nil
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(v.Timeout))
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid verification timeout %q (expected e.g. 90s, 5m)", v.Timeout)
+	}
+	return d, nil
+}
+
+// Args returns the program and arguments to execute.
+func (v Verification) Args() []string {
+	if v.Command != "" {
+		return []string{"sh", "-c", v.Command}
+	}
+	pkg := v.Package
+	if pkg == "" {
+		pkg = "./..."
+	}
+	return []string{"go", "test", "-run", v.Test, pkg}
+}
+
+// String renders the command line as the user would type it.
+func (v Verification) String() string {
+	if v.Command != "" {
+		return v.Command
+	}
+	args := v.Args()
+	args[3] = "'" + args[3] + "'"
+	return strings.Join(args, " ")
+}
+
+// VerificationFor returns the verification declared on the task, falling
+// back to the one declared for its feature.
+func (p *Plan) VerificationFor(task Task) (Verification, bool) {
+	if task.Verify != nil && !task.Verify.IsZero() {
+		return *task.Verify, true
+	}
+	if v, ok := p.FeatureVerify[task.FeatureID]; ok && !v.IsZero() {
+		return v, true
+	}
+	return Verification{}, false
+}
+
+// VerificationRun records one execution of a task's verification.
+type VerificationRun struct {
+	Command    string    `json:"command"`
+	ExitCode   int       `json:"exit_code"`
+	TimedOut   bool      `json:"timed_out,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+	Digest     string    `json:"digest"`           // sha256 of the full combined output
+	Output     string    `json:"output,omitempty"` // Tail of the combined output
+	RanBy      string    `json:"ran_by,omitempty"`
+	RanAt      time.Time `json:"ran_at"`
+}
+
+// Passed reports whether the command exited zero within its timeout.
+func (r VerificationRun) Passed() bool {
+	return r.ExitCode == 0 && !r.TimedOut
+}
+
+// Duration returns how long the run took.
+func (r VerificationRun) Duration() time.Duration {
+	return time.Duration(r.DurationMS) * time.Millisecond
+}
+
+// Evidence renders the run as a task evidence entry. The digest is the full
+// 64-character hash so it is never mistaken for a commit.
+func (r VerificationRun) Evidence() string {
+	outcome := fmt.Sprintf("exit %d", r.ExitCode)
+	if r.TimedOut {
+		outcome = "timed out"
+	}
+	return fmt.Sprintf("verify-run: `%s` %s in %s (output sha256 %s)", r.Command, outcome, r.Duration().Round(time.Millisecond), r.Digest)
+}
+
+// VerificationRunner executes a task's verification from the project root.
+// A command that runs but fails is reported through the run's exit code;
+// the error is reserved for commands that could not be started.
+type VerificationRunner interface {
+	Run(ctx context.Context, v Verification) (VerificationRun, error)
+}
+
+// RecordVerificationRun stores the latest verification run for a task and,
+// when it passed, adds it to the task's evidence.
+func (s *ExecutionState) RecordVerificationRun(taskID string, run VerificationRun) {
+	result := s.TaskStates[taskID]
+	if result.Status == "" {
+		result.Status = StatusPending
+	}
+	result.VerificationRun = &run
+	if run.Passed() {
+		result.Evidence = append(result.Evidence, run.Evidence())
+	}
+	s.TaskStates[taskID] = result
+	s.UpdatedAt = time.Now()
+}
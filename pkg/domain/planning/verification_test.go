@@ -0,0 +1,84 @@
+package planning
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerification_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		v    Verification
+		ok   bool
+	}{
+		{"command", Verification{Command: "make check"}, true},
+		{"test with timeout", Verification{Test: "TestLogin", Timeout: "90s"}, true},
+		{"empty", Verification{}, false},
+		{"both", Verification{Command: "make", Test: "TestLogin"}, false},
+		{"bad timeout", Verification{Test: "TestLogin", Timeout: "soon"}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.v.Validate(); (err == nil) != tt.ok {
+			t.Errorf("%s: Validate() = %v", tt.name, err)
+		}
+	}
+
+	if d, _ := (Verification{Command: "true"}).TimeoutDuration(); d != DefaultVerificationTimeout {
+		t.Errorf("default timeout = %s", d)
+	}
+}
+
+func TestVerification_Args(t *testing.T) {
+	v := Verification{Test: "TestLogin|TestLogout", Package: "./pkg/auth/..."}
+	if got := strings.Join(v.Args(), " "); got != "go test -run TestLogin|TestLogout ./pkg/auth/..." {
+		t.Errorf("Args() = %s", got)
+	}
+	if got := v.String(); got != "go test -run 'TestLogin|TestLogout' ./pkg/auth/..." {
+		t.Errorf("String() = %s", got)
+	}
+	if got := (Verification{Test: "TestX"}).Args()[4]; got != "./..." {
+		t.Errorf("default package = %s", got)
+	}
+	if got := (Verification{Command: "make check"}).Args(); len(got) != 3 || got[2] != "make check" {
+		t.Errorf("command Args() = %v", got)
+	}
+}
+
+func TestPlan_VerificationFor(t *testing.T) {
+	plan := &Plan{FeatureVerify: map[string]Verification{"auth": {Test: "TestAuth"}}}
+
+	own := Task{ID: "t1", FeatureID: "auth", Verify: &Verification{Command: "make e2e"}}
+	if v, ok := plan.VerificationFor(own); !ok || v.Command != "make e2e" {
+		t.Errorf("task verification should win: %+v", v)
+	}
+	inherited := Task{ID: "t2", FeatureID: "auth"}
+	if v, ok := plan.VerificationFor(inherited); !ok || v.Test != "TestAuth" {
+		t.Errorf("expected feature verification, got %+v", v)
+	}
+	if _, ok := plan.VerificationFor(Task{ID: "t3", FeatureID: "billing"}); ok {
+		t.Error("expected no verification for billing")
+	}
+}
+
+func TestExecutionState_RecordVerificationRun(t *testing.T) {
+	state := NewExecutionState("p")
+	digest := strings.Repeat("ab12", 16)
+	failed := VerificationRun{Command: "make check", ExitCode: 2, Digest: digest, RanAt: time.Now()}
+	state.RecordVerificationRun("t1", failed)
+
+	result := state.TaskStates["t1"]
+	if result.VerificationRun == nil || result.VerificationRun.Passed() || len(result.Evidence) != 0 {
+		t.Fatalf("failed run must be recorded without evidence: %+v", result)
+	}
+
+	passed := VerificationRun{Command: "make check", DurationMS: 1500, Digest: digest, RanAt: time.Now()}
+	state.RecordVerificationRun("t1", passed)
+	result = state.TaskStates["t1"]
+	if !result.VerificationRun.Passed() || len(result.Evidence) != 1 {
+		t.Fatalf("passing run must add evidence: %+v", result)
+	}
+	if want := "verify-run: `make check` exit 0 in 1.5s (output sha256 " + digest + ")"; result.Evidence[0] != want {
+		t.Errorf("evidence = %q", result.Evidence[0])
+	}
+}
@@ -0,0 +1,367 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+)
+
+// ChangeKind says what a TaskChange does to its task.
+type ChangeKind string
+
+const (
+	ChangeCreate ChangeKind = "create"
+	ChangeUpdate ChangeKind = "update"
+	ChangeClose  ChangeKind = "close"
+)
+
+// Task fields carried by a TaskChange.
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldPriority    = "priority"
+	FieldOwner       = "owner"
+)
+
+// TaskChange is a field-level change to one task, either made in Roady and
+// pushed to the external system or made there and returned by Sync. Nil
+// fields are unchanged and Comments holds only new comments.
+type TaskChange struct {
+	Kind        ChangeKind             `json:"kind"`
+	TaskID      string                 `json:"task_id"`
+	ExternalRef *planning.ExternalRef  `json:"external_ref,omitempty"` // The external item; set by plugins on remote changes
+	Title       *string                `json:"title,omitempty"`
+	Description *string                `json:"description,omitempty"`
+	Priority    *planning.TaskPriority `json:"priority,omitempty"`
+	Owner       *string                `json:"owner,omitempty"`
+	Comments    []planning.TaskComment `json:"comments,omitempty"`
+	UpdatedAt   time.Time              `json:"updated_at"` // When the change was made, for last-writer-wins
+}
+
+// Fields returns the names of the fields the change sets.
+func (c TaskChange) Fields() []string {
+	var fields []string
+	if c.Title != nil {
+		fields = append(fields, FieldTitle)
+	}
+	if c.Description != nil {
+		fields = append(fields, FieldDescription)
+	}
+	if c.Priority != nil {
+		fields = append(fields, FieldPriority)
+	}
+	if c.Owner != nil {
+		fields = append(fields, FieldOwner)
+	}
+	return fields
+}
+
+// IsEmpty reports whether the change does nothing.
+func (c TaskChange) IsEmpty() bool {
+	return c.Kind == ChangeUpdate && len(c.Fields()) == 0 && len(c.Comments) == 0
+}
+
+// FieldSyncer is implemented by syncers that exchange field-level changes.
+// Sync returns the external changes in SyncResult.Changes; PushChanges
+// applies Roady's changes and returns the refs of created items in
+// SyncResult.LinkUpdates.
+type FieldSyncer interface {
+	PushChanges(changes []TaskChange) (*SyncResult, error)
+}
+
+// ErrFieldSyncUnsupported is returned by PushChanges when the plugin cannot
+// apply field-level changes.
+var ErrFieldSyncUnsupported = errors.New("plugin does not support field changes")
+
+// ConflictPolicy decides which side wins when a field was changed both in
+// Roady and in the external system since the last sync.
+type ConflictPolicy string
+
+const (
+	ConflictRoadyWins      ConflictPolicy = "roady-wins"
+	ConflictRemoteWins     ConflictPolicy = "remote-wins"
+	ConflictLastWriterWins ConflictPolicy = "last-writer-wins"
+)
+
+// ParseConflictPolicy parses a policy name; the empty string selects
+// last-writer-wins.
+func ParseConflictPolicy(s string) (ConflictPolicy, error) {
+	switch p := ConflictPolicy(s); p {
+	case "":
+		return ConflictLastWriterWins, nil
+	case ConflictRoadyWins, ConflictRemoteWins, ConflictLastWriterWins:
+		return p, nil
+	}
+	return "", fmt.Errorf("invalid conflict policy %q (want %s, %s or %s)", s, ConflictRoadyWins, ConflictRemoteWins, ConflictLastWriterWins)
+}
+
+// Conflict is a field changed on both sides since the last sync.
+type Conflict struct {
+	TaskID string `json:"task_id"`
+	Field  string `json:"field"`
+	Roady  string `json:"roady"`
+	Remote string `json:"remote"`
+	Winner string `json:"winner"` // "roady" or "remote"
+}
+
+// FieldSnapshot is a task's synced field values.
+type FieldSnapshot struct {
+	Title       string                `json:"title"`
+	Description string                `json:"description"`
+	Priority    planning.TaskPriority `json:"priority"`
+	Owner       string                `json:"owner,omitempty"`
+	Closed      bool                  `json:"closed,omitempty"`
+	Comments    []string              `json:"comments,omitempty"` // IDs of the Roady comments already pushed
+}
+
+// SyncBaseline holds every task's fields as of the last field sync with one
+// plugin, which tells a change on one side from a conflict.
+type SyncBaseline struct {
+	Tasks    map[string]FieldSnapshot `json:"tasks"`
+	SyncedAt time.Time                `json:"synced_at"`
+}
+
+// NewSyncBaseline returns an empty baseline.
+func NewSyncBaseline() *SyncBaseline {
+	return &SyncBaseline{Tasks: make(map[string]FieldSnapshot)}
+}
+
+// Record replaces the baseline with the current plan and state.
+func (b *SyncBaseline) Record(plan *planning.Plan, state *planning.ExecutionState, at time.Time) {
+	b.Tasks = make(map[string]FieldSnapshot, len(plan.Tasks))
+	for _, t := range plan.Tasks {
+		snap := snapshotTask(t, state)
+		for _, c := range state.TaskStates[t.ID].Comments {
+			if c.Provider == "" {
+				snap.Comments = append(snap.Comments, c.ID)
+			}
+		}
+		b.Tasks[t.ID] = snap
+	}
+	b.SyncedAt = at
+}
+
+// snapshotTask returns a task's current field values. Comments are left out.
+func snapshotTask(t planning.Task, state *planning.ExecutionState) FieldSnapshot {
+	priority := t.Priority
+	if priority == "" {
+		priority = planning.PriorityMedium
+	}
+	result := state.TaskStates[t.ID]
+	return FieldSnapshot{
+		Title:       t.Title,
+		Description: t.Description,
+		Priority:    priority,
+		Owner:       result.Owner,
+		Closed:      result.Status.IsComplete(),
+	}
+}
+
+// ChangePlan is the outcome of reconciling Roady and the external system
+// against a baseline.
+type ChangePlan struct {
+	Apply     []TaskChange // External changes to apply in Roady
+	Push      []TaskChange // Roady changes to push to the external system
+	Conflicts []Conflict
+}
+
+// ReconcileChanges compares Roady's plan and state and the external changes
+// with the baseline. Fields changed on one side are copied to the other; a
+// field changed on both sides to different values is a conflict decided by
+// policy, where last-writer-wins compares the external change's UpdatedAt
+// with the plan's (title, description, priority) or the state's (owner)
+// UpdatedAt. provider names the external system in ExternalRefs and
+// comments. Tasks never synced before are created externally unless they
+// are already linked.
+func ReconcileChanges(baseline *SyncBaseline, plan *planning.Plan, state *planning.ExecutionState, remote []TaskChange, provider string, policy ConflictPolicy) ChangePlan {
+	var out ChangePlan
+	byTask := mergeChanges(remote)
+
+	for _, t := range plan.Tasks {
+		current := snapshotTask(t, state)
+		result := state.TaskStates[t.ID]
+		var ref *planning.ExternalRef
+		if r, ok := result.ExternalRefs[provider]; ok {
+			ref = &r
+		}
+		rc, hasRemote := byTask[t.ID]
+		delete(byTask, t.ID)
+
+		base, synced := baseline.Tasks[t.ID]
+		if !synced {
+			if ref == nil && !hasRemote {
+				out.Push = append(out.Push, createChange(t, current, result, plan.UpdatedAt))
+				continue
+			}
+			// Linked before field sync was enabled: adopt Roady's values.
+			base = current
+		}
+
+		push := TaskChange{Kind: ChangeUpdate, TaskID: t.ID, ExternalRef: ref}
+		apply := TaskChange{Kind: ChangeUpdate, TaskID: t.ID, ExternalRef: rc.ExternalRef, UpdatedAt: rc.UpdatedAt}
+
+		fields := []struct {
+			name              string
+			roady, base       string
+			remote            *string
+			roadyTime         time.Time
+			setPush, setApply func(string)
+		}{
+			{FieldTitle, current.Title, base.Title, rc.Title, plan.UpdatedAt,
+				func(v string) { push.Title = &v }, func(v string) { apply.Title = &v }},
+			{FieldDescription, current.Description, base.Description, rc.Description, plan.UpdatedAt,
+				func(v string) { push.Description = &v }, func(v string) { apply.Description = &v }},
+			{FieldPriority, string(current.Priority), string(base.Priority), priorityString(rc.Priority), plan.UpdatedAt,
+				func(v string) { p := planning.TaskPriority(v); push.Priority = &p }, func(v string) { p := planning.TaskPriority(v); apply.Priority = &p }},
+			{FieldOwner, current.Owner, base.Owner, rc.Owner, state.UpdatedAt,
+				func(v string) { push.Owner = &v }, func(v string) { apply.Owner = &v }},
+		}
+		for _, f := range fields {
+			roadyChanged := f.roady != f.base
+			remoteChanged := f.remote != nil && *f.remote != f.base
+			switch {
+			case roadyChanged && remoteChanged && f.roady != *f.remote:
+				c := Conflict{TaskID: t.ID, Field: f.name, Roady: f.roady, Remote: *f.remote, Winner: "roady"}
+				if remoteWins(policy, rc.UpdatedAt, f.roadyTime) {
+					c.Winner = "remote"
+					f.setApply(*f.remote)
+				} else {
+					f.setPush(f.roady)
+				}
+				out.Conflicts = append(out.Conflicts, c)
+			case roadyChanged && !remoteChanged:
+				f.setPush(f.roady)
+			case remoteChanged && !roadyChanged:
+				f.setApply(*f.remote)
+			}
+		}
+
+		if current.Closed && !base.Closed && rc.Kind != ChangeClose {
+			push.Kind = ChangeClose
+		}
+		if rc.Kind == ChangeClose && !current.Closed {
+			apply.Kind = ChangeClose
+		}
+
+		pushed := make(map[string]bool, len(base.Comments))
+		for _, id := range base.Comments {
+			pushed[id] = true
+		}
+		for _, c := range result.Comments {
+			if c.Provider == "" && !pushed[c.ID] {
+				push.Comments = append(push.Comments, c)
+			}
+		}
+		apply.Comments = rc.Comments
+
+		if !push.IsEmpty() {
+			push.UpdatedAt = plan.UpdatedAt
+			if state.UpdatedAt.After(push.UpdatedAt) {
+				push.UpdatedAt = state.UpdatedAt
+			}
+			out.Push = append(out.Push, push)
+		}
+		if !apply.IsEmpty() {
+			out.Apply = append(out.Apply, apply)
+		}
+	}
+
+	// Items created externally become new tasks; other changes to tasks
+	// Roady does not know are dropped.
+	for _, id := range sortedKeys(byTask) {
+		if rc := byTask[id]; rc.Kind == ChangeCreate && rc.Title != nil {
+			out.Apply = append(out.Apply, rc)
+		}
+	}
+	return out
+}
+
+// createChange describes a task for the external system to create.
+func createChange(t planning.Task, current FieldSnapshot, result planning.TaskResult, at time.Time) TaskChange {
+	c := TaskChange{
+		Kind:        ChangeCreate,
+		TaskID:      t.ID,
+		Title:       &current.Title,
+		Description: &current.Description,
+		Priority:    &current.Priority,
+		UpdatedAt:   at,
+	}
+	if current.Owner != "" {
+		c.Owner = &current.Owner
+	}
+	for _, cm := range result.Comments {
+		if cm.Provider == "" {
+			c.Comments = append(c.Comments, cm)
+		}
+	}
+	return c
+}
+
+// mergeChanges folds the external changes into one per task, later changes
+// overriding earlier ones field by field.
+func mergeChanges(changes []TaskChange) map[string]TaskChange {
+	merged := make(map[string]TaskChange)
+	for _, c := range changes {
+		m, ok := merged[c.TaskID]
+		if !ok {
+			merged[c.TaskID] = c
+			continue
+		}
+		if c.Kind != ChangeUpdate {
+			m.Kind = c.Kind
+		}
+		if c.ExternalRef != nil {
+			m.ExternalRef = c.ExternalRef
+		}
+		if c.Title != nil {
+			m.Title = c.Title
+		}
+		if c.Description != nil {
+			m.Description = c.Description
+		}
+		if c.Priority != nil {
+			m.Priority = c.Priority
+		}
+		if c.Owner != nil {
+			m.Owner = c.Owner
+		}
+		m.Comments = append(m.Comments, c.Comments...)
+		if c.UpdatedAt.After(m.UpdatedAt) {
+			m.UpdatedAt = c.UpdatedAt
+		}
+		merged[c.TaskID] = m
+	}
+	return merged
+}
+
+// remoteWins applies the conflict policy to a field changed on both sides.
+func remoteWins(policy ConflictPolicy, remoteAt, roadyAt time.Time) bool {
+	switch policy {
+	case ConflictRemoteWins:
+		return true
+	case ConflictRoadyWins:
+		return false
+	default:
+		return remoteAt.After(roadyAt)
+	}
+}
+
+func priorityString(p *planning.TaskPriority) *string {
+	if p == nil {
+		return nil
+	}
+	s := string(*p)
+	return &s
+}
+
+func sortedKeys(m map[string]TaskChange) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
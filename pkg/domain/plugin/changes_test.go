@@ -0,0 +1,170 @@
+package plugin_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/plugin"
+)
+
+func strPtr(s string) *string { return &s }
+
+// syncedFixture returns a plan and state whose one task t1 is linked to
+// "mock" and recorded in a baseline.
+func syncedFixture(t *testing.T) (*plugin.SyncBaseline, *planning.Plan, *planning.ExecutionState) {
+	t.Helper()
+	synced := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	plan := &planning.Plan{
+		Tasks:     []planning.Task{{ID: "t1", Title: "Login", Description: "Form", Priority: planning.PriorityMedium}},
+		UpdatedAt: synced,
+	}
+	state := planning.NewExecutionState("p")
+	state.TaskStates["t1"] = planning.TaskResult{
+		Status:       planning.StatusPending,
+		Owner:        "alice",
+		ExternalRefs: map[string]planning.ExternalRef{"mock": {ID: "M-1"}},
+	}
+	state.UpdatedAt = synced
+	baseline := plugin.NewSyncBaseline()
+	baseline.Record(plan, state, synced)
+	return baseline, plan, state
+}
+
+func TestParseConflictPolicy(t *testing.T) {
+	if p, err := plugin.ParseConflictPolicy(""); err != nil || p != plugin.ConflictLastWriterWins {
+		t.Errorf("empty policy = %q, %v", p, err)
+	}
+	if p, err := plugin.ParseConflictPolicy("remote-wins"); err != nil || p != plugin.ConflictRemoteWins {
+		t.Errorf("remote-wins = %q, %v", p, err)
+	}
+	if _, err := plugin.ParseConflictPolicy("first-wins"); err == nil {
+		t.Error("expected an unknown policy to be rejected")
+	}
+}
+
+func TestReconcileChanges_OneSided(t *testing.T) {
+	baseline, plan, state := syncedFixture(t)
+	plan.Tasks[0].Title = "Login page"
+	high := planning.PriorityHigh
+	remote := []plugin.TaskChange{{Kind: plugin.ChangeUpdate, TaskID: "t1", Priority: &high, Owner: strPtr("bob")}}
+
+	out := plugin.ReconcileChanges(baseline, plan, state, remote, "mock", plugin.ConflictLastWriterWins)
+	if len(out.Conflicts) != 0 {
+		t.Fatalf("unexpected conflicts %+v", out.Conflicts)
+	}
+	if len(out.Push) != 1 || out.Push[0].Title == nil || *out.Push[0].Title != "Login page" || out.Push[0].Priority != nil {
+		t.Errorf("expected only the title to be pushed, got %+v", out.Push)
+	}
+	if out.Push[0].ExternalRef == nil || out.Push[0].ExternalRef.ID != "M-1" {
+		t.Errorf("expected the push to carry the external ref, got %+v", out.Push[0].ExternalRef)
+	}
+	if len(out.Apply) != 1 || *out.Apply[0].Priority != high || *out.Apply[0].Owner != "bob" || out.Apply[0].Title != nil {
+		t.Errorf("expected priority and owner to be applied, got %+v", out.Apply)
+	}
+}
+
+func TestReconcileChanges_Unchanged(t *testing.T) {
+	baseline, plan, state := syncedFixture(t)
+	// The remote echoes the synced values back; nothing changed.
+	remote := []plugin.TaskChange{{Kind: plugin.ChangeUpdate, TaskID: "t1", Title: strPtr("Login")}}
+	out := plugin.ReconcileChanges(baseline, plan, state, remote, "mock", plugin.ConflictLastWriterWins)
+	if len(out.Push) != 0 || len(out.Apply) != 0 || len(out.Conflicts) != 0 {
+		t.Errorf("expected no changes, got %+v", out)
+	}
+}
+
+func TestReconcileChanges_ConflictPolicies(t *testing.T) {
+	roadyAt := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		policy   plugin.ConflictPolicy
+		remoteAt time.Time
+		winner   string
+	}{
+		{plugin.ConflictRoadyWins, roadyAt.Add(time.Hour), "roady"},
+		{plugin.ConflictRemoteWins, roadyAt.Add(-time.Hour), "remote"},
+		{plugin.ConflictLastWriterWins, roadyAt.Add(time.Hour), "remote"},
+		{plugin.ConflictLastWriterWins, roadyAt.Add(-time.Hour), "roady"},
+	}
+	for _, tt := range tests {
+		baseline, plan, state := syncedFixture(t)
+		plan.Tasks[0].Title = "Roady title"
+		plan.UpdatedAt = roadyAt
+		remote := []plugin.TaskChange{{Kind: plugin.ChangeUpdate, TaskID: "t1", Title: strPtr("Remote title"), UpdatedAt: tt.remoteAt}}
+
+		out := plugin.ReconcileChanges(baseline, plan, state, remote, "mock", tt.policy)
+		if len(out.Conflicts) != 1 || out.Conflicts[0].Winner != tt.winner || out.Conflicts[0].Field != plugin.FieldTitle {
+			t.Errorf("%s at %s: conflicts = %+v", tt.policy, tt.remoteAt, out.Conflicts)
+			continue
+		}
+		pushed := len(out.Push) == 1 && out.Push[0].Title != nil
+		applied := len(out.Apply) == 1 && out.Apply[0].Title != nil
+		if pushed != (tt.winner == "roady") || applied != (tt.winner == "remote") {
+			t.Errorf("%s: push %+v, apply %+v", tt.policy, out.Push, out.Apply)
+		}
+	}
+}
+
+func TestReconcileChanges_SameValueIsNoConflict(t *testing.T) {
+	baseline, plan, state := syncedFixture(t)
+	plan.Tasks[0].Title = "Same"
+	remote := []plugin.TaskChange{{Kind: plugin.ChangeUpdate, TaskID: "t1", Title: strPtr("Same")}}
+	out := plugin.ReconcileChanges(baseline, plan, state, remote, "mock", plugin.ConflictRoadyWins)
+	if len(out.Conflicts) != 0 || len(out.Push) != 0 || len(out.Apply) != 0 {
+		t.Errorf("expected converging edits to be a no-op, got %+v", out)
+	}
+}
+
+func TestReconcileChanges_CreateCloseAndComments(t *testing.T) {
+	baseline, plan, state := syncedFixture(t)
+	plan.Tasks = append(plan.Tasks, planning.Task{ID: "t2", Title: "New"})
+	state.AddComment("t1", planning.TaskComment{ID: "c1", Author: "alice", Body: "Started"})
+	remoteComment := planning.TaskComment{ID: "r1", Author: "bob", Body: "Looks good", Provider: "mock", ExternalID: "99"}
+	remote := []plugin.TaskChange{
+		{Kind: plugin.ChangeClose, TaskID: "t1", Comments: []planning.TaskComment{remoteComment}},
+		{Kind: plugin.ChangeCreate, TaskID: "M-7", Title: strPtr("Filed externally"), ExternalRef: &planning.ExternalRef{ID: "M-7"}},
+		{Kind: plugin.ChangeUpdate, TaskID: "unknown", Title: strPtr("Ignored")},
+	}
+
+	out := plugin.ReconcileChanges(baseline, plan, state, remote, "mock", plugin.ConflictLastWriterWins)
+
+	var sawCreate, sawComment bool
+	for _, c := range out.Push {
+		switch c.TaskID {
+		case "t2":
+			sawCreate = c.Kind == plugin.ChangeCreate && *c.Title == "New" && *c.Priority == planning.PriorityMedium
+		case "t1":
+			sawComment = len(c.Comments) == 1 && c.Comments[0].ID == "c1"
+		}
+	}
+	if !sawCreate || !sawComment {
+		t.Errorf("expected t2 to be created and c1 to be pushed, got %+v", out.Push)
+	}
+	if len(out.Apply) != 2 {
+		t.Fatalf("expected the close and the external create to be applied, got %+v", out.Apply)
+	}
+	if out.Apply[0].Kind != plugin.ChangeClose || len(out.Apply[0].Comments) != 1 {
+		t.Errorf("apply[0] = %+v", out.Apply[0])
+	}
+	if out.Apply[1].Kind != plugin.ChangeCreate || out.Apply[1].TaskID != "M-7" {
+		t.Errorf("apply[1] = %+v", out.Apply[1])
+	}
+
+	// Once recorded, pushed comments are not pushed again.
+	baseline.Record(plan, state, time.Now())
+	out = plugin.ReconcileChanges(baseline, plan, state, nil, "mock", plugin.ConflictLastWriterWins)
+	if len(out.Push) != 0 {
+		t.Errorf("expected nothing to push after recording, got %+v", out.Push)
+	}
+}
+
+func TestReconcileChanges_RoadyClose(t *testing.T) {
+	baseline, plan, state := syncedFixture(t)
+	r := state.TaskStates["t1"]
+	r.Status = planning.StatusDone
+	state.TaskStates["t1"] = r
+	out := plugin.ReconcileChanges(baseline, plan, state, nil, "mock", plugin.ConflictLastWriterWins)
+	if len(out.Push) != 1 || out.Push[0].Kind != plugin.ChangeClose {
+		t.Errorf("expected a close to be pushed, got %+v", out.Push)
+	}
+}
@@ -6,6 +6,8 @@ type PluginConfig struct {
 	Binary string `yaml:"binary" json:"binary"`
 	// Config holds the plugin-specific configuration key-value pairs
 	Config map[string]string `yaml:"config" json:"config"`
+	// ConflictPolicy decides field conflicts during two-way sync
+	ConflictPolicy ConflictPolicy `yaml:"conflict_policy,omitempty" json:"conflict_policy,omitempty"`
 }
 
 // PluginConfigs holds all configured plugins by name
@@ -1,7 +1,10 @@
 package plugin
 
 import (
+	"context"
+	"errors"
 	"net/rpc"
+	"time"
 
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	goplugin "github.com/hashicorp/go-plugin"
@@ -24,6 +27,39 @@ type SyncResult struct {
 	StatusUpdates map[string]planning.TaskStatus  `json:"status_updates"`
 	LinkUpdates   map[string]planning.ExternalRef `json:"link_updates"`
 	Errors        []string                        `json:"errors"`
+	Changes       []TaskChange                    `json:"changes,omitempty"` // Field changes made externally; see FieldSyncer
+}
+
+// External event types streamed by a Subscriber.
+const (
+	EventStatusChanged = "status_changed" // Status holds the task's new status
+	EventLinked        = "linked"         // ExternalRef holds the new link
+)
+
+// ExternalEvent is a change made in the external system.
+type ExternalEvent struct {
+	ID          string                `json:"id"`
+	Type        string                `json:"type"`
+	TaskID      string                `json:"task_id"`
+	Status      planning.TaskStatus   `json:"status,omitempty"`
+	Provider    string                `json:"provider,omitempty"`
+	Timestamp   time.Time             `json:"timestamp"`
+	Metadata    map[string]string     `json:"metadata,omitempty"`
+	ExternalRef *planning.ExternalRef `json:"external_ref,omitempty"`
+}
+
+// ErrSubscribeUnsupported is returned by Subscribe when the plugin has no
+// change feed.
+var ErrSubscribeUnsupported = errors.New("plugin does not support subscriptions")
+
+// Subscriber is implemented by syncers that can stream external changes
+// instead of waiting for the next Sync. It is only available over version 2
+// of the plugin protocol.
+type Subscriber interface {
+	// Subscribe calls handle for each change of the given types (all types
+	// when empty) until ctx is cancelled, the feed ends or handle fails. It
+	// returns nil when ctx is cancelled.
+	Subscribe(ctx context.Context, plan *planning.Plan, state *planning.ExecutionState, eventTypes []string, handle func(ExternalEvent) error) error
 }
 
 // SyncerPlugin is the implementation of plugin.Plugin so we can serve/consume this.
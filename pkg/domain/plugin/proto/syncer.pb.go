@@ -179,6 +179,7 @@ type SyncResponse struct {
 	StatusUpdates map[string]string       `protobuf:"bytes,1,rep,name=status_updates,json=statusUpdates,proto3" json:"status_updates,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	LinkUpdates   map[string]*ExternalRef `protobuf:"bytes,2,rep,name=link_updates,json=linkUpdates,proto3" json:"link_updates,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	Errors        []string                `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	Changes       []*TaskChange           `protobuf:"bytes,4,rep,name=changes,proto3" json:"changes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -234,6 +235,13 @@ func (x *SyncResponse) GetErrors() []string {
 	return nil
 }
 
+func (x *SyncResponse) GetChanges() []*TaskChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
 // PushRequest contains a task status change to push.
 type PushRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -340,17 +348,268 @@ func (x *PushResponse) GetError() string {
 	return ""
 }
 
-// SubscribeRequest contains subscription parameters.
+// PushChangesRequest contains the changes to apply externally.
+type PushChangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*TaskChange          `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushChangesRequest) Reset() {
+	*x = PushChangesRequest{}
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushChangesRequest) ProtoMessage() {}
+
+func (x *PushChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushChangesRequest.ProtoReflect.Descriptor instead.
+func (*PushChangesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PushChangesRequest) GetChanges() []*TaskChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// TaskChange is a field-level change to one task. Only the fields named in
+// fields are set; comments holds only new comments.
+type TaskChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	TaskId        string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	ExternalRef   *ExternalRef           `protobuf:"bytes,3,opt,name=external_ref,json=externalRef,proto3" json:"external_ref,omitempty"`
+	Fields        []string               `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`
+	Title         string                 `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Priority      string                 `protobuf:"bytes,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	Owner         string                 `protobuf:"bytes,8,opt,name=owner,proto3" json:"owner,omitempty"`
+	Comments      []*Comment             `protobuf:"bytes,9,rep,name=comments,proto3" json:"comments,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskChange) Reset() {
+	*x = TaskChange{}
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskChange) ProtoMessage() {}
+
+func (x *TaskChange) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskChange.ProtoReflect.Descriptor instead.
+func (*TaskChange) Descriptor() ([]byte, []int) {
+	return file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TaskChange) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *TaskChange) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *TaskChange) GetExternalRef() *ExternalRef {
+	if x != nil {
+		return x.ExternalRef
+	}
+	return nil
+}
+
+func (x *TaskChange) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *TaskChange) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TaskChange) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TaskChange) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *TaskChange) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *TaskChange) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *TaskChange) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Comment is a comment on a task.
+type Comment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Author        string                 `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Provider      string                 `protobuf:"bytes,5,opt,name=provider,proto3" json:"provider,omitempty"`
+	ExternalId    string                 `protobuf:"bytes,6,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Comment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Comment) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Comment) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *Comment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Comment) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Comment) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+// SubscribeRequest contains subscription parameters. The plan and state let
+// the plugin map external items back to Roady tasks.
 type SubscribeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	EventTypes    []string               `protobuf:"bytes,1,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	Plan          *Plan                  `protobuf:"bytes,2,opt,name=plan,proto3" json:"plan,omitempty"`
+	State         *ExecutionState        `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SubscribeRequest) Reset() {
 	*x = SubscribeRequest{}
-	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[6]
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -362,7 +621,7 @@ func (x *SubscribeRequest) String() string {
 func (*SubscribeRequest) ProtoMessage() {}
 
 func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[6]
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -375,7 +634,7 @@ func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
 func (*SubscribeRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP(), []int{6}
+	return file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *SubscribeRequest) GetEventTypes() []string {
@@ -385,7 +644,21 @@ func (x *SubscribeRequest) GetEventTypes() []string {
 	return nil
 }
 
-// Event represents an event from the external system.
+func (x *SubscribeRequest) GetPlan() *Plan {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetState() *ExecutionState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+// Event represents a change in the external system.
 type Event struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -395,13 +668,14 @@ type Event struct {
 	Provider      string                 `protobuf:"bytes,5,opt,name=provider,proto3" json:"provider,omitempty"`
 	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	Metadata      map[string]string      `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ExternalRef   *ExternalRef           `protobuf:"bytes,8,opt,name=external_ref,json=externalRef,proto3" json:"external_ref,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Event) Reset() {
 	*x = Event{}
-	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[7]
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -413,7 +687,7 @@ func (x *Event) String() string {
 func (*Event) ProtoMessage() {}
 
 func (x *Event) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[7]
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -426,7 +700,7 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Event.ProtoReflect.Descriptor instead.
 func (*Event) Descriptor() ([]byte, []int) {
-	return file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP(), []int{7}
+	return file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Event) GetId() string {
@@ -478,22 +752,30 @@ func (x *Event) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *Event) GetExternalRef() *ExternalRef {
+	if x != nil {
+		return x.ExternalRef
+	}
+	return nil
+}
+
 // Plan represents a Roady plan.
 type Plan struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	SpecId         string                 `protobuf:"bytes,2,opt,name=spec_id,json=specId,proto3" json:"spec_id,omitempty"`
-	Tasks          []*Task                `protobuf:"bytes,3,rep,name=tasks,proto3" json:"tasks,omitempty"`
-	ApprovalStatus string                 `protobuf:"bytes,4,opt,name=approval_status,json=approvalStatus,proto3" json:"approval_status,omitempty"`
-	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	state          protoimpl.MessageState   `protogen:"open.v1"`
+	Id             string                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SpecId         string                   `protobuf:"bytes,2,opt,name=spec_id,json=specId,proto3" json:"spec_id,omitempty"`
+	Tasks          []*Task                  `protobuf:"bytes,3,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	ApprovalStatus string                   `protobuf:"bytes,4,opt,name=approval_status,json=approvalStatus,proto3" json:"approval_status,omitempty"`
+	CreatedAt      *timestamppb.Timestamp   `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp   `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	FeatureVerify  map[string]*Verification `protobuf:"bytes,7,rep,name=feature_verify,json=featureVerify,proto3" json:"feature_verify,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Plan) Reset() {
 	*x = Plan{}
-	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[8]
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -505,7 +787,7 @@ func (x *Plan) String() string {
 func (*Plan) ProtoMessage() {}
 
 func (x *Plan) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[8]
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -518,7 +800,7 @@ func (x *Plan) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Plan.ProtoReflect.Descriptor instead.
 func (*Plan) Descriptor() ([]byte, []int) {
-	return file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP(), []int{8}
+	return file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *Plan) GetId() string {
@@ -563,23 +845,35 @@ func (x *Plan) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Plan) GetFeatureVerify() map[string]*Verification {
+	if x != nil {
+		return x.FeatureVerify
+	}
+	return nil
+}
+
 // Task represents a task in a plan.
 type Task struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Id            string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                  `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                  `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Phase         string                  `protobuf:"bytes,4,opt,name=phase,proto3" json:"phase,omitempty"`
-	Outcome       string                  `protobuf:"bytes,5,opt,name=outcome,proto3" json:"outcome,omitempty"`
-	Dependencies  []string                `protobuf:"bytes,6,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
-	ExternalRefs  map[string]*ExternalRef `protobuf:"bytes,7,rep,name=external_refs,json=externalRefs,proto3" json:"external_refs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title              string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description        string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Priority           string                 `protobuf:"bytes,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	Estimate           string                 `protobuf:"bytes,5,opt,name=estimate,proto3" json:"estimate,omitempty"`
+	Dependencies       []string               `protobuf:"bytes,6,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	FeatureId          string                 `protobuf:"bytes,7,opt,name=feature_id,json=featureId,proto3" json:"feature_id,omitempty"`
+	Origin             string                 `protobuf:"bytes,8,opt,name=origin,proto3" json:"origin,omitempty"`
+	Source             *TaskSource            `protobuf:"bytes,9,opt,name=source,proto3" json:"source,omitempty"`
+	Files              []string               `protobuf:"bytes,10,rep,name=files,proto3" json:"files,omitempty"`
+	AcceptanceCriteria []string               `protobuf:"bytes,11,rep,name=acceptance_criteria,json=acceptanceCriteria,proto3" json:"acceptance_criteria,omitempty"`
+	Verify             *Verification          `protobuf:"bytes,12,opt,name=verify,proto3" json:"verify,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *Task) Reset() {
 	*x = Task{}
-	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[9]
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -591,7 +885,7 @@ func (x *Task) String() string {
 func (*Task) ProtoMessage() {}
 
 func (x *Task) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[9]
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -604,7 +898,7 @@ func (x *Task) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Task.ProtoReflect.Descriptor instead.
 func (*Task) Descriptor() ([]byte, []int) {
-	return file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP(), []int{9}
+	return file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Task) GetId() string {
@@ -628,16 +922,16 @@ func (x *Task) GetDescription() string {
 	return ""
 }
 
-func (x *Task) GetPhase() string {
+func (x *Task) GetPriority() string {
 	if x != nil {
-		return x.Phase
+		return x.Priority
 	}
 	return ""
 }
 
-func (x *Task) GetOutcome() string {
+func (x *Task) GetEstimate() string {
 	if x != nil {
-		return x.Outcome
+		return x.Estimate
 	}
 	return ""
 }
@@ -649,36 +943,196 @@ func (x *Task) GetDependencies() []string {
 	return nil
 }
 
-func (x *Task) GetExternalRefs() map[string]*ExternalRef {
+func (x *Task) GetFeatureId() string {
 	if x != nil {
-		return x.ExternalRefs
+		return x.FeatureId
 	}
-	return nil
+	return ""
 }
 
-// ExecutionState represents the execution state of tasks.
-type ExecutionState struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskStates    map[string]*TaskResult `protobuf:"bytes,1,rep,name=task_states,json=taskStates,proto3" json:"task_states,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Task) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
 }
 
-func (x *ExecutionState) Reset() {
-	*x = ExecutionState{}
-	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[10]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Task) GetSource() *TaskSource {
+	if x != nil {
+		return x.Source
+	}
+	return nil
 }
 
-func (x *ExecutionState) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
+func (x *Task) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *Task) GetAcceptanceCriteria() []string {
+	if x != nil {
+		return x.AcceptanceCriteria
+	}
+	return nil
+}
+
+func (x *Task) GetVerify() *Verification {
+	if x != nil {
+		return x.Verify
+	}
+	return nil
+}
+
+// TaskSource points at the document line a task was planned from.
+type TaskSource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Doc           string                 `protobuf:"bytes,1,opt,name=doc,proto3" json:"doc,omitempty"`
+	Line          int64                  `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskSource) Reset() {
+	*x = TaskSource{}
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskSource) ProtoMessage() {}
+
+func (x *TaskSource) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskSource.ProtoReflect.Descriptor instead.
+func (*TaskSource) Descriptor() ([]byte, []int) {
+	return file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TaskSource) GetDoc() string {
+	if x != nil {
+		return x.Doc
+	}
+	return ""
+}
+
+func (x *TaskSource) GetLine() int64 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+// Verification declares how a task is verified.
+type Verification struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Command       string                 `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	Test          string                 `protobuf:"bytes,2,opt,name=test,proto3" json:"test,omitempty"`
+	Package       string                 `protobuf:"bytes,3,opt,name=package,proto3" json:"package,omitempty"`
+	Timeout       string                 `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Verification) Reset() {
+	*x = Verification{}
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Verification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Verification) ProtoMessage() {}
+
+func (x *Verification) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Verification.ProtoReflect.Descriptor instead.
+func (*Verification) Descriptor() ([]byte, []int) {
+	return file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Verification) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *Verification) GetTest() string {
+	if x != nil {
+		return x.Test
+	}
+	return ""
+}
+
+func (x *Verification) GetPackage() string {
+	if x != nil {
+		return x.Package
+	}
+	return ""
+}
+
+func (x *Verification) GetTimeout() string {
+	if x != nil {
+		return x.Timeout
+	}
+	return ""
+}
+
+// ExecutionState represents the execution state of tasks.
+type ExecutionState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskStates    map[string]*TaskResult `protobuf:"bytes,1,rep,name=task_states,json=taskStates,proto3" json:"task_states,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ProjectId     string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Version       int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecutionState) Reset() {
+	*x = ExecutionState{}
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
 func (*ExecutionState) ProtoMessage() {}
 
 func (x *ExecutionState) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[10]
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -691,7 +1145,7 @@ func (x *ExecutionState) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecutionState.ProtoReflect.Descriptor instead.
 func (*ExecutionState) Descriptor() ([]byte, []int) {
-	return file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP(), []int{10}
+	return file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ExecutionState) GetTaskStates() map[string]*TaskResult {
@@ -701,21 +1155,49 @@ func (x *ExecutionState) GetTaskStates() map[string]*TaskResult {
 	return nil
 }
 
+func (x *ExecutionState) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *ExecutionState) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ExecutionState) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
 // TaskResult represents the result/state of a task.
 type TaskResult struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Status        string                  `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	Owner         string                  `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
-	StartedAt     *timestamppb.Timestamp  `protobuf:"bytes,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
-	CompletedAt   *timestamppb.Timestamp  `protobuf:"bytes,4,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
-	ExternalRefs  map[string]*ExternalRef `protobuf:"bytes,5,rep,name=external_refs,json=externalRefs,proto3" json:"external_refs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState  `protogen:"open.v1"`
+	Status           string                  `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Owner            string                  `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	StartedAt        *timestamppb.Timestamp  `protobuf:"bytes,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt      *timestamppb.Timestamp  `protobuf:"bytes,4,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	ExternalRefs     map[string]*ExternalRef `protobuf:"bytes,5,rep,name=external_refs,json=externalRefs,proto3" json:"external_refs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Path             string                  `protobuf:"bytes,6,opt,name=path,proto3" json:"path,omitempty"`
+	Evidence         []string                `protobuf:"bytes,7,rep,name=evidence,proto3" json:"evidence,omitempty"`
+	CriteriaEvidence []*CriterionEvidence    `protobuf:"bytes,8,rep,name=criteria_evidence,json=criteriaEvidence,proto3" json:"criteria_evidence,omitempty"`
+	VerificationRun  *VerificationRun        `protobuf:"bytes,9,opt,name=verification_run,json=verificationRun,proto3" json:"verification_run,omitempty"`
+	ElapsedMinutes   int64                   `protobuf:"varint,10,opt,name=elapsed_minutes,json=elapsedMinutes,proto3" json:"elapsed_minutes,omitempty"`
+	RateId           string                  `protobuf:"bytes,11,opt,name=rate_id,json=rateId,proto3" json:"rate_id,omitempty"`
+	Comments         []*Comment              `protobuf:"bytes,12,rep,name=comments,proto3" json:"comments,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *TaskResult) Reset() {
 	*x = TaskResult{}
-	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[11]
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -727,7 +1209,7 @@ func (x *TaskResult) String() string {
 func (*TaskResult) ProtoMessage() {}
 
 func (x *TaskResult) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[11]
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -740,7 +1222,7 @@ func (x *TaskResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TaskResult.ProtoReflect.Descriptor instead.
 func (*TaskResult) Descriptor() ([]byte, []int) {
-	return file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP(), []int{11}
+	return file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *TaskResult) GetStatus() string {
@@ -778,6 +1260,233 @@ func (x *TaskResult) GetExternalRefs() map[string]*ExternalRef {
 	return nil
 }
 
+func (x *TaskResult) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *TaskResult) GetEvidence() []string {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+func (x *TaskResult) GetCriteriaEvidence() []*CriterionEvidence {
+	if x != nil {
+		return x.CriteriaEvidence
+	}
+	return nil
+}
+
+func (x *TaskResult) GetVerificationRun() *VerificationRun {
+	if x != nil {
+		return x.VerificationRun
+	}
+	return nil
+}
+
+func (x *TaskResult) GetElapsedMinutes() int64 {
+	if x != nil {
+		return x.ElapsedMinutes
+	}
+	return 0
+}
+
+func (x *TaskResult) GetRateId() string {
+	if x != nil {
+		return x.RateId
+	}
+	return ""
+}
+
+func (x *TaskResult) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+// CriterionEvidence is the evidence recorded for one acceptance criterion.
+type CriterionEvidence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Criterion     string                 `protobuf:"bytes,1,opt,name=criterion,proto3" json:"criterion,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	RecordedBy    string                 `protobuf:"bytes,4,opt,name=recorded_by,json=recordedBy,proto3" json:"recorded_by,omitempty"`
+	RecordedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CriterionEvidence) Reset() {
+	*x = CriterionEvidence{}
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CriterionEvidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CriterionEvidence) ProtoMessage() {}
+
+func (x *CriterionEvidence) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CriterionEvidence.ProtoReflect.Descriptor instead.
+func (*CriterionEvidence) Descriptor() ([]byte, []int) {
+	return file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CriterionEvidence) GetCriterion() string {
+	if x != nil {
+		return x.Criterion
+	}
+	return ""
+}
+
+func (x *CriterionEvidence) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *CriterionEvidence) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *CriterionEvidence) GetRecordedBy() string {
+	if x != nil {
+		return x.RecordedBy
+	}
+	return ""
+}
+
+func (x *CriterionEvidence) GetRecordedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return nil
+}
+
+// VerificationRun is the latest result of a task's verification command.
+type VerificationRun struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Command       string                 `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	ExitCode      int64                  `protobuf:"varint,2,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	TimedOut      bool                   `protobuf:"varint,3,opt,name=timed_out,json=timedOut,proto3" json:"timed_out,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Digest        string                 `protobuf:"bytes,5,opt,name=digest,proto3" json:"digest,omitempty"`
+	Output        string                 `protobuf:"bytes,6,opt,name=output,proto3" json:"output,omitempty"`
+	RanBy         string                 `protobuf:"bytes,7,opt,name=ran_by,json=ranBy,proto3" json:"ran_by,omitempty"`
+	RanAt         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=ran_at,json=ranAt,proto3" json:"ran_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerificationRun) Reset() {
+	*x = VerificationRun{}
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerificationRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerificationRun) ProtoMessage() {}
+
+func (x *VerificationRun) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerificationRun.ProtoReflect.Descriptor instead.
+func (*VerificationRun) Descriptor() ([]byte, []int) {
+	return file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *VerificationRun) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *VerificationRun) GetExitCode() int64 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *VerificationRun) GetTimedOut() bool {
+	if x != nil {
+		return x.TimedOut
+	}
+	return false
+}
+
+func (x *VerificationRun) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *VerificationRun) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *VerificationRun) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *VerificationRun) GetRanBy() string {
+	if x != nil {
+		return x.RanBy
+	}
+	return ""
+}
+
+func (x *VerificationRun) GetRanAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RanAt
+	}
+	return nil
+}
+
 // ExternalRef represents a reference to an external system.
 type ExternalRef struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -791,7 +1500,7 @@ type ExternalRef struct {
 
 func (x *ExternalRef) Reset() {
 	*x = ExternalRef{}
-	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[12]
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -803,7 +1512,7 @@ func (x *ExternalRef) String() string {
 func (*ExternalRef) ProtoMessage() {}
 
 func (x *ExternalRef) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[12]
+	mi := &file_pkg_domain_plugin_proto_syncer_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -816,7 +1525,7 @@ func (x *ExternalRef) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExternalRef.ProtoReflect.Descriptor instead.
 func (*ExternalRef) Descriptor() ([]byte, []int) {
-	return file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP(), []int{12}
+	return file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *ExternalRef) GetId() string {
@@ -851,9 +1560,9 @@ var File_pkg_domain_plugin_proto_syncer_proto protoreflect.FileDescriptor
 
 const file_pkg_domain_plugin_proto_syncer_proto_rawDesc = "" +
 	"\n" +
-	"$pkg/domain/plugin/proto/syncer.proto\x12\x0froady.plugin.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8a\x01\n" +
+	"$pkg/domain/plugin/proto/syncer.proto\x12\x0froady.plugin.v2\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8a\x01\n" +
 	"\vInitRequest\x12@\n" +
-	"\x06config\x18\x01 \x03(\v2(.roady.plugin.v1.InitRequest.ConfigEntryR\x06config\x1a9\n" +
+	"\x06config\x18\x01 \x03(\v2(.roady.plugin.v2.InitRequest.ConfigEntryR\x06config\x1a9\n" +
 	"\vConfigEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\">\n" +
@@ -861,27 +1570,55 @@ const file_pkg_domain_plugin_proto_syncer_proto_rawDesc = "" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\"o\n" +
 	"\vSyncRequest\x12)\n" +
-	"\x04plan\x18\x01 \x01(\v2\x15.roady.plugin.v1.PlanR\x04plan\x125\n" +
-	"\x05state\x18\x02 \x01(\v2\x1f.roady.plugin.v1.ExecutionStateR\x05state\"\xf2\x02\n" +
+	"\x04plan\x18\x01 \x01(\v2\x15.roady.plugin.v2.PlanR\x04plan\x125\n" +
+	"\x05state\x18\x02 \x01(\v2\x1f.roady.plugin.v2.ExecutionStateR\x05state\"\xa9\x03\n" +
 	"\fSyncResponse\x12W\n" +
-	"\x0estatus_updates\x18\x01 \x03(\v20.roady.plugin.v1.SyncResponse.StatusUpdatesEntryR\rstatusUpdates\x12Q\n" +
-	"\flink_updates\x18\x02 \x03(\v2..roady.plugin.v1.SyncResponse.LinkUpdatesEntryR\vlinkUpdates\x12\x16\n" +
-	"\x06errors\x18\x03 \x03(\tR\x06errors\x1a@\n" +
+	"\x0estatus_updates\x18\x01 \x03(\v20.roady.plugin.v2.SyncResponse.StatusUpdatesEntryR\rstatusUpdates\x12Q\n" +
+	"\flink_updates\x18\x02 \x03(\v2..roady.plugin.v2.SyncResponse.LinkUpdatesEntryR\vlinkUpdates\x12\x16\n" +
+	"\x06errors\x18\x03 \x03(\tR\x06errors\x125\n" +
+	"\achanges\x18\x04 \x03(\v2\x1b.roady.plugin.v2.TaskChangeR\achanges\x1a@\n" +
 	"\x12StatusUpdatesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a\\\n" +
 	"\x10LinkUpdatesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x122\n" +
-	"\x05value\x18\x02 \x01(\v2\x1c.roady.plugin.v1.ExternalRefR\x05value:\x028\x01\">\n" +
+	"\x05value\x18\x02 \x01(\v2\x1c.roady.plugin.v2.ExternalRefR\x05value:\x028\x01\">\n" +
 	"\vPushRequest\x12\x17\n" +
 	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\">\n" +
 	"\fPushResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"3\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"K\n" +
+	"\x12PushChangesRequest\x125\n" +
+	"\achanges\x18\x01 \x03(\v2\x1b.roady.plugin.v2.TaskChangeR\achanges\"\xed\x02\n" +
+	"\n" +
+	"TaskChange\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\x12?\n" +
+	"\fexternal_ref\x18\x03 \x01(\v2\x1c.roady.plugin.v2.ExternalRefR\vexternalRef\x12\x16\n" +
+	"\x06fields\x18\x04 \x03(\tR\x06fields\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bpriority\x18\a \x01(\tR\bpriority\x12\x14\n" +
+	"\x05owner\x18\b \x01(\tR\x05owner\x124\n" +
+	"\bcomments\x18\t \x03(\v2\x18.roady.plugin.v2.CommentR\bcomments\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xbd\x01\n" +
+	"\aComment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06author\x18\x02 \x01(\tR\x06author\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1a\n" +
+	"\bprovider\x18\x05 \x01(\tR\bprovider\x12\x1f\n" +
+	"\vexternal_id\x18\x06 \x01(\tR\n" +
+	"externalId\"\x95\x01\n" +
 	"\x10SubscribeRequest\x12\x1f\n" +
 	"\vevent_types\x18\x01 \x03(\tR\n" +
-	"eventTypes\"\xb1\x02\n" +
+	"eventTypes\x12)\n" +
+	"\x04plan\x18\x02 \x01(\v2\x15.roady.plugin.v2.PlanR\x04plan\x125\n" +
+	"\x05state\x18\x03 \x01(\v2\x1f.roady.plugin.v2.ExecutionStateR\x05state\"\xf2\x02\n" +
 	"\x05Event\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12\x17\n" +
@@ -889,36 +1626,59 @@ const file_pkg_domain_plugin_proto_syncer_proto_rawDesc = "" +
 	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1a\n" +
 	"\bprovider\x18\x05 \x01(\tR\bprovider\x128\n" +
 	"\ttimestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12@\n" +
-	"\bmetadata\x18\a \x03(\v2$.roady.plugin.v1.Event.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\a \x03(\v2$.roady.plugin.v2.Event.MetadataEntryR\bmetadata\x12?\n" +
+	"\fexternal_ref\x18\b \x01(\v2\x1c.roady.plugin.v2.ExternalRefR\vexternalRef\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xfb\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xad\x03\n" +
 	"\x04Plan\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\aspec_id\x18\x02 \x01(\tR\x06specId\x12+\n" +
-	"\x05tasks\x18\x03 \x03(\v2\x15.roady.plugin.v1.TaskR\x05tasks\x12'\n" +
+	"\x05tasks\x18\x03 \x03(\v2\x15.roady.plugin.v2.TaskR\x05tasks\x12'\n" +
 	"\x0fapproval_status\x18\x04 \x01(\tR\x0eapprovalStatus\x129\n" +
 	"\n" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xcf\x02\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12O\n" +
+	"\x0efeature_verify\x18\a \x03(\v2(.roady.plugin.v2.Plan.FeatureVerifyEntryR\rfeatureVerify\x1a_\n" +
+	"\x12FeatureVerifyEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x123\n" +
+	"\x05value\x18\x02 \x01(\v2\x1d.roady.plugin.v2.VerificationR\x05value:\x028\x01\"\x94\x03\n" +
 	"\x04Task\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05phase\x18\x04 \x01(\tR\x05phase\x12\x18\n" +
-	"\aoutcome\x18\x05 \x01(\tR\aoutcome\x12\"\n" +
-	"\fdependencies\x18\x06 \x03(\tR\fdependencies\x12L\n" +
-	"\rexternal_refs\x18\a \x03(\v2'.roady.plugin.v1.Task.ExternalRefsEntryR\fexternalRefs\x1a]\n" +
-	"\x11ExternalRefsEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x122\n" +
-	"\x05value\x18\x02 \x01(\v2\x1c.roady.plugin.v1.ExternalRefR\x05value:\x028\x01\"\xbe\x01\n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\tR\bpriority\x12\x1a\n" +
+	"\bestimate\x18\x05 \x01(\tR\bestimate\x12\"\n" +
+	"\fdependencies\x18\x06 \x03(\tR\fdependencies\x12\x1d\n" +
+	"\n" +
+	"feature_id\x18\a \x01(\tR\tfeatureId\x12\x16\n" +
+	"\x06origin\x18\b \x01(\tR\x06origin\x123\n" +
+	"\x06source\x18\t \x01(\v2\x1b.roady.plugin.v2.TaskSourceR\x06source\x12\x14\n" +
+	"\x05files\x18\n" +
+	" \x03(\tR\x05files\x12/\n" +
+	"\x13acceptance_criteria\x18\v \x03(\tR\x12acceptanceCriteria\x125\n" +
+	"\x06verify\x18\f \x01(\v2\x1d.roady.plugin.v2.VerificationR\x06verify\"2\n" +
+	"\n" +
+	"TaskSource\x12\x10\n" +
+	"\x03doc\x18\x01 \x01(\tR\x03doc\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\x03R\x04line\"p\n" +
+	"\fVerification\x12\x18\n" +
+	"\acommand\x18\x01 \x01(\tR\acommand\x12\x12\n" +
+	"\x04test\x18\x02 \x01(\tR\x04test\x12\x18\n" +
+	"\apackage\x18\x03 \x01(\tR\apackage\x12\x18\n" +
+	"\atimeout\x18\x04 \x01(\tR\atimeout\"\xb2\x02\n" +
 	"\x0eExecutionState\x12P\n" +
-	"\vtask_states\x18\x01 \x03(\v2/.roady.plugin.v1.ExecutionState.TaskStatesEntryR\n" +
-	"taskStates\x1aZ\n" +
+	"\vtask_states\x18\x01 \x03(\v2/.roady.plugin.v2.ExecutionState.TaskStatesEntryR\n" +
+	"taskStates\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tR\tprojectId\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1aZ\n" +
 	"\x0fTaskStatesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x121\n" +
-	"\x05value\x18\x02 \x01(\v2\x1b.roady.plugin.v1.TaskResultR\x05value:\x028\x01\"\xe7\x02\n" +
+	"\x05value\x18\x02 \x01(\v2\x1b.roady.plugin.v2.TaskResultR\x05value:\x028\x01\"\xad\x05\n" +
 	"\n" +
 	"TaskResult\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
@@ -926,22 +1686,49 @@ const file_pkg_domain_plugin_proto_syncer_proto_rawDesc = "" +
 	"\n" +
 	"started_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
 	"\fcompleted_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12R\n" +
-	"\rexternal_refs\x18\x05 \x03(\v2-.roady.plugin.v1.TaskResult.ExternalRefsEntryR\fexternalRefs\x1a]\n" +
+	"\rexternal_refs\x18\x05 \x03(\v2-.roady.plugin.v2.TaskResult.ExternalRefsEntryR\fexternalRefs\x12\x12\n" +
+	"\x04path\x18\x06 \x01(\tR\x04path\x12\x1a\n" +
+	"\bevidence\x18\a \x03(\tR\bevidence\x12O\n" +
+	"\x11criteria_evidence\x18\b \x03(\v2\".roady.plugin.v2.CriterionEvidenceR\x10criteriaEvidence\x12K\n" +
+	"\x10verification_run\x18\t \x01(\v2 .roady.plugin.v2.VerificationRunR\x0fverificationRun\x12'\n" +
+	"\x0felapsed_minutes\x18\n" +
+	" \x01(\x03R\x0eelapsedMinutes\x12\x17\n" +
+	"\arate_id\x18\v \x01(\tR\x06rateId\x124\n" +
+	"\bcomments\x18\f \x03(\v2\x18.roady.plugin.v2.CommentR\bcomments\x1a]\n" +
 	"\x11ExternalRefsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x122\n" +
-	"\x05value\x18\x02 \x01(\v2\x1c.roady.plugin.v1.ExternalRefR\x05value:\x028\x01\"\x91\x01\n" +
+	"\x05value\x18\x02 \x01(\v2\x1c.roady.plugin.v2.ExternalRefR\x05value:\x028\x01\"\xb9\x01\n" +
+	"\x11CriterionEvidence\x12\x1c\n" +
+	"\tcriterion\x18\x01 \x01(\tR\tcriterion\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x1f\n" +
+	"\vrecorded_by\x18\x04 \x01(\tR\n" +
+	"recordedBy\x12;\n" +
+	"\vrecorded_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"recordedAt\"\x80\x02\n" +
+	"\x0fVerificationRun\x12\x18\n" +
+	"\acommand\x18\x01 \x01(\tR\acommand\x12\x1b\n" +
+	"\texit_code\x18\x02 \x01(\x03R\bexitCode\x12\x1b\n" +
+	"\ttimed_out\x18\x03 \x01(\bR\btimedOut\x12\x1f\n" +
+	"\vduration_ms\x18\x04 \x01(\x03R\n" +
+	"durationMs\x12\x16\n" +
+	"\x06digest\x18\x05 \x01(\tR\x06digest\x12\x16\n" +
+	"\x06output\x18\x06 \x01(\tR\x06output\x12\x15\n" +
+	"\x06ran_by\x18\a \x01(\tR\x05ranBy\x121\n" +
+	"\x06ran_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x05ranAt\"\x91\x01\n" +
 	"\vExternalRef\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1e\n" +
 	"\n" +
 	"identifier\x18\x02 \x01(\tR\n" +
 	"identifier\x12\x10\n" +
 	"\x03url\x18\x03 \x01(\tR\x03url\x12@\n" +
-	"\x0elast_synced_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\flastSyncedAt2\xa1\x02\n" +
+	"\x0elast_synced_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\flastSyncedAt2\xf4\x02\n" +
 	"\x06Syncer\x12C\n" +
-	"\x04Init\x12\x1c.roady.plugin.v1.InitRequest\x1a\x1d.roady.plugin.v1.InitResponse\x12C\n" +
-	"\x04Sync\x12\x1c.roady.plugin.v1.SyncRequest\x1a\x1d.roady.plugin.v1.SyncResponse\x12C\n" +
-	"\x04Push\x12\x1c.roady.plugin.v1.PushRequest\x1a\x1d.roady.plugin.v1.PushResponse\x12H\n" +
-	"\tSubscribe\x12!.roady.plugin.v1.SubscribeRequest\x1a\x16.roady.plugin.v1.Event0\x01B8Z6github.com/felixgeelhaar/roady/pkg/domain/plugin/protob\x06proto3"
+	"\x04Init\x12\x1c.roady.plugin.v2.InitRequest\x1a\x1d.roady.plugin.v2.InitResponse\x12C\n" +
+	"\x04Sync\x12\x1c.roady.plugin.v2.SyncRequest\x1a\x1d.roady.plugin.v2.SyncResponse\x12C\n" +
+	"\x04Push\x12\x1c.roady.plugin.v2.PushRequest\x1a\x1d.roady.plugin.v2.PushResponse\x12H\n" +
+	"\tSubscribe\x12!.roady.plugin.v2.SubscribeRequest\x1a\x16.roady.plugin.v2.Event0\x01\x12Q\n" +
+	"\vPushChanges\x12#.roady.plugin.v2.PushChangesRequest\x1a\x1d.roady.plugin.v2.SyncResponseB8Z6github.com/felixgeelhaar/roady/pkg/domain/plugin/protob\x06proto3"
 
 var (
 	file_pkg_domain_plugin_proto_syncer_proto_rawDescOnce sync.Once
@@ -955,64 +1742,90 @@ func file_pkg_domain_plugin_proto_syncer_proto_rawDescGZIP() []byte {
 	return file_pkg_domain_plugin_proto_syncer_proto_rawDescData
 }
 
-var file_pkg_domain_plugin_proto_syncer_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_pkg_domain_plugin_proto_syncer_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_pkg_domain_plugin_proto_syncer_proto_goTypes = []any{
-	(*InitRequest)(nil),           // 0: roady.plugin.v1.InitRequest
-	(*InitResponse)(nil),          // 1: roady.plugin.v1.InitResponse
-	(*SyncRequest)(nil),           // 2: roady.plugin.v1.SyncRequest
-	(*SyncResponse)(nil),          // 3: roady.plugin.v1.SyncResponse
-	(*PushRequest)(nil),           // 4: roady.plugin.v1.PushRequest
-	(*PushResponse)(nil),          // 5: roady.plugin.v1.PushResponse
-	(*SubscribeRequest)(nil),      // 6: roady.plugin.v1.SubscribeRequest
-	(*Event)(nil),                 // 7: roady.plugin.v1.Event
-	(*Plan)(nil),                  // 8: roady.plugin.v1.Plan
-	(*Task)(nil),                  // 9: roady.plugin.v1.Task
-	(*ExecutionState)(nil),        // 10: roady.plugin.v1.ExecutionState
-	(*TaskResult)(nil),            // 11: roady.plugin.v1.TaskResult
-	(*ExternalRef)(nil),           // 12: roady.plugin.v1.ExternalRef
-	nil,                           // 13: roady.plugin.v1.InitRequest.ConfigEntry
-	nil,                           // 14: roady.plugin.v1.SyncResponse.StatusUpdatesEntry
-	nil,                           // 15: roady.plugin.v1.SyncResponse.LinkUpdatesEntry
-	nil,                           // 16: roady.plugin.v1.Event.MetadataEntry
-	nil,                           // 17: roady.plugin.v1.Task.ExternalRefsEntry
-	nil,                           // 18: roady.plugin.v1.ExecutionState.TaskStatesEntry
-	nil,                           // 19: roady.plugin.v1.TaskResult.ExternalRefsEntry
-	(*timestamppb.Timestamp)(nil), // 20: google.protobuf.Timestamp
+	(*InitRequest)(nil),           // 0: roady.plugin.v2.InitRequest
+	(*InitResponse)(nil),          // 1: roady.plugin.v2.InitResponse
+	(*SyncRequest)(nil),           // 2: roady.plugin.v2.SyncRequest
+	(*SyncResponse)(nil),          // 3: roady.plugin.v2.SyncResponse
+	(*PushRequest)(nil),           // 4: roady.plugin.v2.PushRequest
+	(*PushResponse)(nil),          // 5: roady.plugin.v2.PushResponse
+	(*PushChangesRequest)(nil),    // 6: roady.plugin.v2.PushChangesRequest
+	(*TaskChange)(nil),            // 7: roady.plugin.v2.TaskChange
+	(*Comment)(nil),               // 8: roady.plugin.v2.Comment
+	(*SubscribeRequest)(nil),      // 9: roady.plugin.v2.SubscribeRequest
+	(*Event)(nil),                 // 10: roady.plugin.v2.Event
+	(*Plan)(nil),                  // 11: roady.plugin.v2.Plan
+	(*Task)(nil),                  // 12: roady.plugin.v2.Task
+	(*TaskSource)(nil),            // 13: roady.plugin.v2.TaskSource
+	(*Verification)(nil),          // 14: roady.plugin.v2.Verification
+	(*ExecutionState)(nil),        // 15: roady.plugin.v2.ExecutionState
+	(*TaskResult)(nil),            // 16: roady.plugin.v2.TaskResult
+	(*CriterionEvidence)(nil),     // 17: roady.plugin.v2.CriterionEvidence
+	(*VerificationRun)(nil),       // 18: roady.plugin.v2.VerificationRun
+	(*ExternalRef)(nil),           // 19: roady.plugin.v2.ExternalRef
+	nil,                           // 20: roady.plugin.v2.InitRequest.ConfigEntry
+	nil,                           // 21: roady.plugin.v2.SyncResponse.StatusUpdatesEntry
+	nil,                           // 22: roady.plugin.v2.SyncResponse.LinkUpdatesEntry
+	nil,                           // 23: roady.plugin.v2.Event.MetadataEntry
+	nil,                           // 24: roady.plugin.v2.Plan.FeatureVerifyEntry
+	nil,                           // 25: roady.plugin.v2.ExecutionState.TaskStatesEntry
+	nil,                           // 26: roady.plugin.v2.TaskResult.ExternalRefsEntry
+	(*timestamppb.Timestamp)(nil), // 27: google.protobuf.Timestamp
 }
 var file_pkg_domain_plugin_proto_syncer_proto_depIdxs = []int32{
-	13, // 0: roady.plugin.v1.InitRequest.config:type_name -> roady.plugin.v1.InitRequest.ConfigEntry
-	8,  // 1: roady.plugin.v1.SyncRequest.plan:type_name -> roady.plugin.v1.Plan
-	10, // 2: roady.plugin.v1.SyncRequest.state:type_name -> roady.plugin.v1.ExecutionState
-	14, // 3: roady.plugin.v1.SyncResponse.status_updates:type_name -> roady.plugin.v1.SyncResponse.StatusUpdatesEntry
-	15, // 4: roady.plugin.v1.SyncResponse.link_updates:type_name -> roady.plugin.v1.SyncResponse.LinkUpdatesEntry
-	20, // 5: roady.plugin.v1.Event.timestamp:type_name -> google.protobuf.Timestamp
-	16, // 6: roady.plugin.v1.Event.metadata:type_name -> roady.plugin.v1.Event.MetadataEntry
-	9,  // 7: roady.plugin.v1.Plan.tasks:type_name -> roady.plugin.v1.Task
-	20, // 8: roady.plugin.v1.Plan.created_at:type_name -> google.protobuf.Timestamp
-	20, // 9: roady.plugin.v1.Plan.updated_at:type_name -> google.protobuf.Timestamp
-	17, // 10: roady.plugin.v1.Task.external_refs:type_name -> roady.plugin.v1.Task.ExternalRefsEntry
-	18, // 11: roady.plugin.v1.ExecutionState.task_states:type_name -> roady.plugin.v1.ExecutionState.TaskStatesEntry
-	20, // 12: roady.plugin.v1.TaskResult.started_at:type_name -> google.protobuf.Timestamp
-	20, // 13: roady.plugin.v1.TaskResult.completed_at:type_name -> google.protobuf.Timestamp
-	19, // 14: roady.plugin.v1.TaskResult.external_refs:type_name -> roady.plugin.v1.TaskResult.ExternalRefsEntry
-	20, // 15: roady.plugin.v1.ExternalRef.last_synced_at:type_name -> google.protobuf.Timestamp
-	12, // 16: roady.plugin.v1.SyncResponse.LinkUpdatesEntry.value:type_name -> roady.plugin.v1.ExternalRef
-	12, // 17: roady.plugin.v1.Task.ExternalRefsEntry.value:type_name -> roady.plugin.v1.ExternalRef
-	11, // 18: roady.plugin.v1.ExecutionState.TaskStatesEntry.value:type_name -> roady.plugin.v1.TaskResult
-	12, // 19: roady.plugin.v1.TaskResult.ExternalRefsEntry.value:type_name -> roady.plugin.v1.ExternalRef
-	0,  // 20: roady.plugin.v1.Syncer.Init:input_type -> roady.plugin.v1.InitRequest
-	2,  // 21: roady.plugin.v1.Syncer.Sync:input_type -> roady.plugin.v1.SyncRequest
-	4,  // 22: roady.plugin.v1.Syncer.Push:input_type -> roady.plugin.v1.PushRequest
-	6,  // 23: roady.plugin.v1.Syncer.Subscribe:input_type -> roady.plugin.v1.SubscribeRequest
-	1,  // 24: roady.plugin.v1.Syncer.Init:output_type -> roady.plugin.v1.InitResponse
-	3,  // 25: roady.plugin.v1.Syncer.Sync:output_type -> roady.plugin.v1.SyncResponse
-	5,  // 26: roady.plugin.v1.Syncer.Push:output_type -> roady.plugin.v1.PushResponse
-	7,  // 27: roady.plugin.v1.Syncer.Subscribe:output_type -> roady.plugin.v1.Event
-	24, // [24:28] is the sub-list for method output_type
-	20, // [20:24] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	20, // 0: roady.plugin.v2.InitRequest.config:type_name -> roady.plugin.v2.InitRequest.ConfigEntry
+	11, // 1: roady.plugin.v2.SyncRequest.plan:type_name -> roady.plugin.v2.Plan
+	15, // 2: roady.plugin.v2.SyncRequest.state:type_name -> roady.plugin.v2.ExecutionState
+	21, // 3: roady.plugin.v2.SyncResponse.status_updates:type_name -> roady.plugin.v2.SyncResponse.StatusUpdatesEntry
+	22, // 4: roady.plugin.v2.SyncResponse.link_updates:type_name -> roady.plugin.v2.SyncResponse.LinkUpdatesEntry
+	7,  // 5: roady.plugin.v2.SyncResponse.changes:type_name -> roady.plugin.v2.TaskChange
+	7,  // 6: roady.plugin.v2.PushChangesRequest.changes:type_name -> roady.plugin.v2.TaskChange
+	19, // 7: roady.plugin.v2.TaskChange.external_ref:type_name -> roady.plugin.v2.ExternalRef
+	8,  // 8: roady.plugin.v2.TaskChange.comments:type_name -> roady.plugin.v2.Comment
+	27, // 9: roady.plugin.v2.TaskChange.updated_at:type_name -> google.protobuf.Timestamp
+	27, // 10: roady.plugin.v2.Comment.created_at:type_name -> google.protobuf.Timestamp
+	11, // 11: roady.plugin.v2.SubscribeRequest.plan:type_name -> roady.plugin.v2.Plan
+	15, // 12: roady.plugin.v2.SubscribeRequest.state:type_name -> roady.plugin.v2.ExecutionState
+	27, // 13: roady.plugin.v2.Event.timestamp:type_name -> google.protobuf.Timestamp
+	23, // 14: roady.plugin.v2.Event.metadata:type_name -> roady.plugin.v2.Event.MetadataEntry
+	19, // 15: roady.plugin.v2.Event.external_ref:type_name -> roady.plugin.v2.ExternalRef
+	12, // 16: roady.plugin.v2.Plan.tasks:type_name -> roady.plugin.v2.Task
+	27, // 17: roady.plugin.v2.Plan.created_at:type_name -> google.protobuf.Timestamp
+	27, // 18: roady.plugin.v2.Plan.updated_at:type_name -> google.protobuf.Timestamp
+	24, // 19: roady.plugin.v2.Plan.feature_verify:type_name -> roady.plugin.v2.Plan.FeatureVerifyEntry
+	13, // 20: roady.plugin.v2.Task.source:type_name -> roady.plugin.v2.TaskSource
+	14, // 21: roady.plugin.v2.Task.verify:type_name -> roady.plugin.v2.Verification
+	25, // 22: roady.plugin.v2.ExecutionState.task_states:type_name -> roady.plugin.v2.ExecutionState.TaskStatesEntry
+	27, // 23: roady.plugin.v2.ExecutionState.updated_at:type_name -> google.protobuf.Timestamp
+	27, // 24: roady.plugin.v2.TaskResult.started_at:type_name -> google.protobuf.Timestamp
+	27, // 25: roady.plugin.v2.TaskResult.completed_at:type_name -> google.protobuf.Timestamp
+	26, // 26: roady.plugin.v2.TaskResult.external_refs:type_name -> roady.plugin.v2.TaskResult.ExternalRefsEntry
+	17, // 27: roady.plugin.v2.TaskResult.criteria_evidence:type_name -> roady.plugin.v2.CriterionEvidence
+	18, // 28: roady.plugin.v2.TaskResult.verification_run:type_name -> roady.plugin.v2.VerificationRun
+	8,  // 29: roady.plugin.v2.TaskResult.comments:type_name -> roady.plugin.v2.Comment
+	27, // 30: roady.plugin.v2.CriterionEvidence.recorded_at:type_name -> google.protobuf.Timestamp
+	27, // 31: roady.plugin.v2.VerificationRun.ran_at:type_name -> google.protobuf.Timestamp
+	27, // 32: roady.plugin.v2.ExternalRef.last_synced_at:type_name -> google.protobuf.Timestamp
+	19, // 33: roady.plugin.v2.SyncResponse.LinkUpdatesEntry.value:type_name -> roady.plugin.v2.ExternalRef
+	14, // 34: roady.plugin.v2.Plan.FeatureVerifyEntry.value:type_name -> roady.plugin.v2.Verification
+	16, // 35: roady.plugin.v2.ExecutionState.TaskStatesEntry.value:type_name -> roady.plugin.v2.TaskResult
+	19, // 36: roady.plugin.v2.TaskResult.ExternalRefsEntry.value:type_name -> roady.plugin.v2.ExternalRef
+	0,  // 37: roady.plugin.v2.Syncer.Init:input_type -> roady.plugin.v2.InitRequest
+	2,  // 38: roady.plugin.v2.Syncer.Sync:input_type -> roady.plugin.v2.SyncRequest
+	4,  // 39: roady.plugin.v2.Syncer.Push:input_type -> roady.plugin.v2.PushRequest
+	9,  // 40: roady.plugin.v2.Syncer.Subscribe:input_type -> roady.plugin.v2.SubscribeRequest
+	6,  // 41: roady.plugin.v2.Syncer.PushChanges:input_type -> roady.plugin.v2.PushChangesRequest
+	1,  // 42: roady.plugin.v2.Syncer.Init:output_type -> roady.plugin.v2.InitResponse
+	3,  // 43: roady.plugin.v2.Syncer.Sync:output_type -> roady.plugin.v2.SyncResponse
+	5,  // 44: roady.plugin.v2.Syncer.Push:output_type -> roady.plugin.v2.PushResponse
+	10, // 45: roady.plugin.v2.Syncer.Subscribe:output_type -> roady.plugin.v2.Event
+	3,  // 46: roady.plugin.v2.Syncer.PushChanges:output_type -> roady.plugin.v2.SyncResponse
+	42, // [42:47] is the sub-list for method output_type
+	37, // [37:42] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_pkg_domain_plugin_proto_syncer_proto_init() }
@@ -1026,7 +1839,7 @@ func file_pkg_domain_plugin_proto_syncer_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_domain_plugin_proto_syncer_proto_rawDesc), len(file_pkg_domain_plugin_proto_syncer_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   20,
+			NumMessages:   27,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
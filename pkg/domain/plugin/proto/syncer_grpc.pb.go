@@ -19,10 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Syncer_Init_FullMethodName      = "/roady.plugin.v1.Syncer/Init"
-	Syncer_Sync_FullMethodName      = "/roady.plugin.v1.Syncer/Sync"
-	Syncer_Push_FullMethodName      = "/roady.plugin.v1.Syncer/Push"
-	Syncer_Subscribe_FullMethodName = "/roady.plugin.v1.Syncer/Subscribe"
+	Syncer_Init_FullMethodName        = "/roady.plugin.v2.Syncer/Init"
+	Syncer_Sync_FullMethodName        = "/roady.plugin.v2.Syncer/Sync"
+	Syncer_Push_FullMethodName        = "/roady.plugin.v2.Syncer/Push"
+	Syncer_Subscribe_FullMethodName   = "/roady.plugin.v2.Syncer/Subscribe"
+	Syncer_PushChanges_FullMethodName = "/roady.plugin.v2.Syncer/PushChanges"
 )
 
 // SyncerClient is the client API for Syncer service.
@@ -30,6 +31,9 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 //
 // Syncer service provides bidirectional sync between Roady and external systems.
+//
+// This is version 2 of the plugin protocol. Version 1 is the net/rpc protocol
+// of the Go SDK; the two are negotiated through the go-plugin handshake.
 type SyncerClient interface {
 	// Init initializes the syncer with configuration.
 	Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (*InitResponse, error)
@@ -37,8 +41,12 @@ type SyncerClient interface {
 	Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponse, error)
 	// Push pushes a status change to the external system.
 	Push(ctx context.Context, in *PushRequest, opts ...grpc.CallOption) (*PushResponse, error)
-	// Subscribe returns a stream of events from the external system (for webhooks).
+	// Subscribe streams changes made in the external system until the client
+	// cancels the call. Plugins without a change feed return UNIMPLEMENTED.
 	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+	// PushChanges applies field-level changes made in Roady to the external
+	// system. Plugins without two-way field sync return UNIMPLEMENTED.
+	PushChanges(ctx context.Context, in *PushChangesRequest, opts ...grpc.CallOption) (*SyncResponse, error)
 }
 
 type syncerClient struct {
@@ -98,11 +106,24 @@ func (c *syncerClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Syncer_SubscribeClient = grpc.ServerStreamingClient[Event]
 
+func (c *syncerClient) PushChanges(ctx context.Context, in *PushChangesRequest, opts ...grpc.CallOption) (*SyncResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncResponse)
+	err := c.cc.Invoke(ctx, Syncer_PushChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SyncerServer is the server API for Syncer service.
 // All implementations must embed UnimplementedSyncerServer
 // for forward compatibility.
 //
 // Syncer service provides bidirectional sync between Roady and external systems.
+//
+// This is version 2 of the plugin protocol. Version 1 is the net/rpc protocol
+// of the Go SDK; the two are negotiated through the go-plugin handshake.
 type SyncerServer interface {
 	// Init initializes the syncer with configuration.
 	Init(context.Context, *InitRequest) (*InitResponse, error)
@@ -110,8 +131,12 @@ type SyncerServer interface {
 	Sync(context.Context, *SyncRequest) (*SyncResponse, error)
 	// Push pushes a status change to the external system.
 	Push(context.Context, *PushRequest) (*PushResponse, error)
-	// Subscribe returns a stream of events from the external system (for webhooks).
+	// Subscribe streams changes made in the external system until the client
+	// cancels the call. Plugins without a change feed return UNIMPLEMENTED.
 	Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[Event]) error
+	// PushChanges applies field-level changes made in Roady to the external
+	// system. Plugins without two-way field sync return UNIMPLEMENTED.
+	PushChanges(context.Context, *PushChangesRequest) (*SyncResponse, error)
 	mustEmbedUnimplementedSyncerServer()
 }
 
@@ -134,6 +159,9 @@ func (UnimplementedSyncerServer) Push(context.Context, *PushRequest) (*PushRespo
 func (UnimplementedSyncerServer) Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[Event]) error {
 	return status.Error(codes.Unimplemented, "method Subscribe not implemented")
 }
+func (UnimplementedSyncerServer) PushChanges(context.Context, *PushChangesRequest) (*SyncResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PushChanges not implemented")
+}
 func (UnimplementedSyncerServer) mustEmbedUnimplementedSyncerServer() {}
 func (UnimplementedSyncerServer) testEmbeddedByValue()                {}
 
@@ -220,11 +248,29 @@ func _Syncer_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Syncer_SubscribeServer = grpc.ServerStreamingServer[Event]
 
+func _Syncer_PushChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncerServer).PushChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Syncer_PushChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncerServer).PushChanges(ctx, req.(*PushChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Syncer_ServiceDesc is the grpc.ServiceDesc for Syncer service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var Syncer_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "roady.plugin.v1.Syncer",
+	ServiceName: "roady.plugin.v2.Syncer",
 	HandlerType: (*SyncerServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
@@ -239,6 +285,10 @@ var Syncer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Push",
 			Handler:    _Syncer_Push_Handler,
 		},
+		{
+			MethodName: "PushChanges",
+			Handler:    _Syncer_PushChanges_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
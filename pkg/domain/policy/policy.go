@@ -16,6 +16,7 @@ type Violation struct {
 	RuleID  string         `json:"rule_id"`
 	Message string         `json:"message"`
 	Level   ViolationLevel `json:"level"`
+	TaskID  string         `json:"task_id,omitempty"`
 }
 
 // Rule defines a constraint that can be validated against a plan and state.
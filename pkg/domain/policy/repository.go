@@ -2,10 +2,33 @@ package policy
 
 // PolicyConfig is the serialized representation of policy.yaml
 type PolicyConfig struct {
-	MaxWIP      int  `yaml:"max_wip"`
-	AllowAI     bool `yaml:"allow_ai"`
-	TokenLimit  int  `yaml:"token_limit"`
-	BudgetHours int  `yaml:"budget_hours"`
+	MaxWIP      int          `yaml:"max_wip"`
+	AllowAI     bool         `yaml:"allow_ai"`
+	TokenLimit  int          `yaml:"token_limit"`
+	BudgetHours int          `yaml:"budget_hours"`
+	Rules       []RuleConfig `yaml:"rules,omitempty"`
+	Drift       DriftConfig  `yaml:"drift,omitempty"`
+}
+
+// DriftConfig tunes drift detection rules by rule ID:
+//
+//	drift:
+//	  rules:
+//	    code: {enabled: false}
+//	    plan: {severity: low}
+type DriftConfig struct {
+	Rules map[string]DriftRuleConfig `yaml:"rules,omitempty" json:"rules,omitempty"`
+}
+
+// DriftRuleConfig enables, disables or re-grades a single drift rule.
+type DriftRuleConfig struct {
+	Enabled  *bool  `yaml:"enabled,omitempty" json:"enabled,omitempty"`   // nil keeps the rule's default
+	Severity string `yaml:"severity,omitempty" json:"severity,omitempty"` // Overrides the severity of every issue the rule emits
+}
+
+// IsEnabled reports whether the rule should run, defaulting to true.
+func (c DriftRuleConfig) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
 }
 
 // Repository handles persistence of policy configurations.
@@ -0,0 +1,80 @@
+package policy
+
+import (
+	"slices"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+)
+
+// Declarative rule types supported in the rules section of policy.yaml.
+const (
+	// RuleTypeRequireField requires a task or task-state field to be set.
+	RuleTypeRequireField = "require_field"
+	// RuleTypeMaxDuration limits how long a task may stay in its current status.
+	RuleTypeMaxDuration = "max_duration"
+	// RuleTypeForbidStatus forbids matching tasks from resting in a status.
+	RuleTypeForbidStatus = "forbid_status"
+)
+
+// RuleConfig is a declarative rule as written in policy.yaml:
+//
+//	rules:
+//	  - id: high-priority-estimate
+//	    type: require_field
+//	    selector: {priority: [high]}
+//	    condition: {field: estimate}
+//	    level: error
+type RuleConfig struct {
+	ID        string         `yaml:"id" json:"id"`
+	Type      string         `yaml:"type" json:"type"`
+	Selector  Selector       `yaml:"selector,omitempty" json:"selector,omitempty"`
+	Condition Condition      `yaml:"condition,omitempty" json:"condition,omitempty"`
+	Level     ViolationLevel `yaml:"level,omitempty" json:"level,omitempty"`
+	Message   string         `yaml:"message,omitempty" json:"message,omitempty"`
+	On        []string       `yaml:"on,omitempty" json:"on,omitempty"` // Transition events the rule gates; empty means all
+}
+
+// Selector narrows a rule to a subset of tasks. Every non-empty list must
+// match (AND); values within a list are alternatives (OR). An empty selector
+// matches every task.
+type Selector struct {
+	Tasks    []string                `yaml:"tasks,omitempty" json:"tasks,omitempty"`
+	Priority []planning.TaskPriority `yaml:"priority,omitempty" json:"priority,omitempty"`
+	Origin   []planning.TaskOrigin   `yaml:"origin,omitempty" json:"origin,omitempty"`
+	Feature  []string                `yaml:"feature,omitempty" json:"feature,omitempty"`
+	Status   []planning.TaskStatus   `yaml:"status,omitempty" json:"status,omitempty"`
+}
+
+// Matches reports whether the task and its execution result satisfy the selector.
+func (s Selector) Matches(task planning.Task, result planning.TaskResult) bool {
+	if len(s.Tasks) > 0 && !slices.Contains(s.Tasks, task.ID) {
+		return false
+	}
+	if len(s.Priority) > 0 && !slices.Contains(s.Priority, task.Priority) {
+		return false
+	}
+	if len(s.Origin) > 0 && !slices.Contains(s.Origin, task.NormalisedOrigin()) {
+		return false
+	}
+	if len(s.Feature) > 0 && !slices.Contains(s.Feature, task.FeatureID) {
+		return false
+	}
+	if len(s.Status) > 0 {
+		status := result.Status
+		if status == "" {
+			status = planning.StatusPending
+		}
+		if !slices.Contains(s.Status, status) {
+			return false
+		}
+	}
+	return true
+}
+
+// Condition is the assertion a selected task must satisfy. Which fields are
+// meaningful depends on the rule type.
+type Condition struct {
+	Field       string                `yaml:"field,omitempty" json:"field,omitempty"`               // require_field
+	MaxDuration string                `yaml:"max_duration,omitempty" json:"max_duration,omitempty"` // max_duration, e.g. "3d", "36h"
+	Status      []planning.TaskStatus `yaml:"status,omitempty" json:"status,omitempty"`             // forbid_status
+}
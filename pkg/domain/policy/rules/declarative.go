@@ -0,0 +1,237 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/policy"
+)
+
+// fieldCheckers maps the field names accepted by require_field rules to a
+// predicate reporting whether the field is set.
+var fieldCheckers = map[string]func(planning.Task, planning.TaskResult) bool{
+	"title":       func(t planning.Task, _ planning.TaskResult) bool { return strings.TrimSpace(t.Title) != "" },
+	"description": func(t planning.Task, _ planning.TaskResult) bool { return strings.TrimSpace(t.Description) != "" },
+	"estimate":    func(t planning.Task, _ planning.TaskResult) bool { return strings.TrimSpace(t.Estimate) != "" },
+	"feature_id":  func(t planning.Task, _ planning.TaskResult) bool { return t.FeatureID != "" },
+	"source":      func(t planning.Task, _ planning.TaskResult) bool { return !t.Source.IsZero() },
+	"owner":       func(_ planning.Task, r planning.TaskResult) bool { return strings.TrimSpace(r.Owner) != "" },
+	"evidence":    func(_ planning.Task, r planning.TaskResult) bool { return len(r.Evidence) > 0 },
+	"path":        func(_ planning.Task, r planning.TaskResult) bool { return r.Path != "" },
+	// verification_run requires a passing `task verify --run`.
+	"verification_run": func(_ planning.Task, r planning.TaskResult) bool {
+		return r.VerificationRun != nil && r.VerificationRun.Passed()
+	},
+}
+
+// DeclarativeRule is a policy.Rule compiled from a policy.yaml rule entry.
+type DeclarativeRule struct {
+	cfg         policy.RuleConfig
+	maxDuration time.Duration
+
+	// Now returns the current time; defaults to time.Now. Overridable in tests.
+	Now func() time.Time
+}
+
+// Compile turns a declarative rule configuration into an executable rule,
+// validating the type, condition and level up front so misconfigured
+// policies fail at load time rather than silently passing.
+func Compile(cfg policy.RuleConfig) (*DeclarativeRule, error) {
+	if strings.TrimSpace(cfg.ID) == "" {
+		return nil, fmt.Errorf("policy rule of type %q is missing an id", cfg.Type)
+	}
+	switch cfg.Level {
+	case "":
+		cfg.Level = policy.ViolationWarning
+	case policy.ViolationWarning, policy.ViolationError:
+	default:
+		return nil, fmt.Errorf("policy rule %s: unknown level %q (expected warning or error)", cfg.ID, cfg.Level)
+	}
+
+	rule := &DeclarativeRule{cfg: cfg}
+	switch cfg.Type {
+	case policy.RuleTypeRequireField:
+		if _, ok := fieldCheckers[cfg.Condition.Field]; !ok {
+			return nil, fmt.Errorf("policy rule %s: unknown field %q (expected one of %s)", cfg.ID, cfg.Condition.Field, strings.Join(knownFields(), ", "))
+		}
+	case policy.RuleTypeMaxDuration:
+		d, err := parseDuration(cfg.Condition.MaxDuration)
+		if err != nil {
+			return nil, fmt.Errorf("policy rule %s: %w", cfg.ID, err)
+		}
+		rule.maxDuration = d
+	case policy.RuleTypeForbidStatus:
+		if len(cfg.Condition.Status) == 0 {
+			return nil, fmt.Errorf("policy rule %s: forbid_status requires condition.status", cfg.ID)
+		}
+		for _, s := range cfg.Condition.Status {
+			if !s.IsValid() {
+				return nil, fmt.Errorf("policy rule %s: invalid status %q", cfg.ID, s)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("policy rule %s: unknown rule type %q", cfg.ID, cfg.Type)
+	}
+	for _, s := range cfg.Selector.Status {
+		if !s.IsValid() {
+			return nil, fmt.Errorf("policy rule %s: invalid selector status %q", cfg.ID, s)
+		}
+	}
+	return rule, nil
+}
+
+// CompileAll compiles every rule entry, returning the first error encountered.
+func CompileAll(cfgs []policy.RuleConfig) ([]*DeclarativeRule, error) {
+	seen := make(map[string]bool, len(cfgs))
+	compiled := make([]*DeclarativeRule, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		if seen[cfg.ID] {
+			return nil, fmt.Errorf("duplicate policy rule id %q", cfg.ID)
+		}
+		seen[cfg.ID] = true
+		rule, err := Compile(cfg)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, rule)
+	}
+	return compiled, nil
+}
+
+func (r *DeclarativeRule) ID() string {
+	return r.cfg.ID
+}
+
+// Level returns the violation level the rule reports at.
+func (r *DeclarativeRule) Level() policy.ViolationLevel {
+	return r.cfg.Level
+}
+
+// Gates reports whether the rule blocks the given transition event. Only
+// error-level rules gate transitions; warnings are advisory.
+func (r *DeclarativeRule) Gates(event string) bool {
+	if r.cfg.Level != policy.ViolationError {
+		return false
+	}
+	return len(r.cfg.On) == 0 || slices.Contains(r.cfg.On, event)
+}
+
+func (r *DeclarativeRule) Validate(plan *planning.Plan, state *planning.ExecutionState) []policy.Violation {
+	if plan == nil || state == nil {
+		return nil
+	}
+
+	var violations []policy.Violation
+	for _, task := range plan.Tasks {
+		violations = append(violations, r.ValidateTask(task, state.TaskStates[task.ID])...)
+	}
+	return violations
+}
+
+// ValidateTask evaluates the rule against a single task and its result.
+func (r *DeclarativeRule) ValidateTask(task planning.Task, result planning.TaskResult) []policy.Violation {
+	if result.Status == "" {
+		result.Status = planning.StatusPending
+	}
+	if !r.cfg.Selector.Matches(task, result) {
+		return nil
+	}
+
+	var detail string
+	switch r.cfg.Type {
+	case policy.RuleTypeRequireField:
+		if fieldCheckers[r.cfg.Condition.Field](task, result) {
+			return nil
+		}
+		detail = fmt.Sprintf("Task '%s' is missing required field '%s'.", task.ID, r.cfg.Condition.Field)
+	case policy.RuleTypeMaxDuration:
+		since := statusSince(result)
+		if since == nil {
+			return nil
+		}
+		elapsed := r.now().Sub(*since)
+		if elapsed <= r.maxDuration {
+			return nil
+		}
+		detail = fmt.Sprintf("Task '%s' has been %s for %s (limit: %s).", task.ID, result.Status, elapsed.Round(time.Minute), r.cfg.Condition.MaxDuration)
+	case policy.RuleTypeForbidStatus:
+		if !slices.Contains(r.cfg.Condition.Status, result.Status) {
+			return nil
+		}
+		detail = fmt.Sprintf("Task '%s' may not be %s.", task.ID, result.Status)
+	}
+
+	msg := detail
+	if r.cfg.Message != "" {
+		msg = fmt.Sprintf("%s (%s)", r.cfg.Message, detail)
+	}
+	return []policy.Violation{{
+		RuleID:  r.cfg.ID,
+		Level:   r.cfg.Level,
+		Message: msg,
+		TaskID:  task.ID,
+	}}
+}
+
+func (r *DeclarativeRule) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+// statusSince returns when the task entered its current status, if known.
+func statusSince(result planning.TaskResult) *time.Time {
+	switch result.Status {
+	case planning.StatusInProgress:
+		return result.StartedAt
+	case planning.StatusDone, planning.StatusVerified:
+		return result.CompletedAt
+	default:
+		return nil
+	}
+}
+
+// durationPattern accepts calendar-day and week suffixes on top of the
+// units understood by time.ParseDuration.
+var durationPattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)(d|w)$`)
+
+// parseDuration parses wall-clock durations such as "36h", "3d" or "1w".
+// Unlike planning.ParseEstimate, days are 24h calendar days because the
+// rule measures elapsed time rather than effort.
+func parseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if s == "" {
+		return 0, fmt.Errorf("max_duration requires condition.max_duration")
+	}
+	if m := durationPattern.FindStringSubmatch(s); m != nil {
+		v, err := strconv.ParseFloat(m[1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid max_duration %q", s)
+		}
+		unit := 24 * time.Hour
+		if m[2] == "w" {
+			unit *= 7
+		}
+		return time.Duration(v * float64(unit)), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid max_duration %q (expected e.g. 36h, 3d, 1w)", s)
+	}
+	return d, nil
+}
+
+func knownFields() []string {
+	fields := make([]string, 0, len(fieldCheckers))
+	for f := range fieldCheckers {
+		fields = append(fields, f)
+	}
+	slices.Sort(fields)
+	return fields
+}
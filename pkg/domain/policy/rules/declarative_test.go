@@ -0,0 +1,182 @@
+package rules_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/policy"
+	"github.com/felixgeelhaar/roady/pkg/domain/policy/rules"
+)
+
+func TestCompile_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  policy.RuleConfig
+		want string
+	}{
+		{"missing id", policy.RuleConfig{Type: policy.RuleTypeRequireField}, "missing an id"},
+		{"unknown type", policy.RuleConfig{ID: "r", Type: "nope"}, "unknown rule type"},
+		{"unknown field", policy.RuleConfig{ID: "r", Type: policy.RuleTypeRequireField, Condition: policy.Condition{Field: "color"}}, "unknown field"},
+		{"bad duration", policy.RuleConfig{ID: "r", Type: policy.RuleTypeMaxDuration, Condition: policy.Condition{MaxDuration: "soon"}}, "invalid max_duration"},
+		{"no status", policy.RuleConfig{ID: "r", Type: policy.RuleTypeForbidStatus}, "requires condition.status"},
+		{"bad level", policy.RuleConfig{ID: "r", Type: policy.RuleTypeRequireField, Condition: policy.Condition{Field: "owner"}, Level: "fatal"}, "unknown level"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := rules.Compile(tt.cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestCompileAll_DuplicateID(t *testing.T) {
+	cfg := policy.RuleConfig{ID: "dup", Type: policy.RuleTypeRequireField, Condition: policy.Condition{Field: "owner"}}
+	if _, err := rules.CompileAll([]policy.RuleConfig{cfg, cfg}); err == nil {
+		t.Fatal("expected duplicate id error")
+	}
+}
+
+func TestDeclarativeRule_RequireField(t *testing.T) {
+	rule, err := rules.Compile(policy.RuleConfig{
+		ID:        "high-priority-estimate",
+		Type:      policy.RuleTypeRequireField,
+		Selector:  policy.Selector{Priority: []planning.TaskPriority{planning.PriorityHigh}},
+		Condition: policy.Condition{Field: "estimate"},
+		Level:     policy.ViolationError,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan := &planning.Plan{Tasks: []planning.Task{
+		{ID: "t1", Priority: planning.PriorityHigh},
+		{ID: "t2", Priority: planning.PriorityHigh, Estimate: "4h"},
+		{ID: "t3", Priority: planning.PriorityLow},
+	}}
+	violations := rule.Validate(plan, planning.NewExecutionState("p"))
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d", len(violations))
+	}
+	if violations[0].TaskID != "t1" || violations[0].Level != policy.ViolationError {
+		t.Errorf("unexpected violation: %+v", violations[0])
+	}
+}
+
+func TestDeclarativeRule_RequireOwnerOnBlocked(t *testing.T) {
+	rule, err := rules.Compile(policy.RuleConfig{
+		ID:        "blocked-owner",
+		Type:      policy.RuleTypeRequireField,
+		Selector:  policy.Selector{Status: []planning.TaskStatus{planning.StatusBlocked}},
+		Condition: policy.Condition{Field: "owner"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.Level() != policy.ViolationWarning {
+		t.Errorf("expected default warning level, got %s", rule.Level())
+	}
+
+	task := planning.Task{ID: "t1"}
+	if v := rule.ValidateTask(task, planning.TaskResult{Status: planning.StatusPending}); len(v) != 0 {
+		t.Errorf("pending task should not match selector")
+	}
+	if v := rule.ValidateTask(task, planning.TaskResult{Status: planning.StatusBlocked}); len(v) != 1 {
+		t.Errorf("expected violation for unowned blocked task")
+	}
+	if v := rule.ValidateTask(task, planning.TaskResult{Status: planning.StatusBlocked, Owner: "alice"}); len(v) != 0 {
+		t.Errorf("owned blocked task should pass")
+	}
+}
+
+func TestDeclarativeRule_RequireVerificationRun(t *testing.T) {
+	rule, err := rules.Compile(policy.RuleConfig{
+		ID:   "high-priority-run-verified",
+		Type: policy.RuleTypeRequireField,
+		Selector: policy.Selector{
+			Priority: []planning.TaskPriority{planning.PriorityHigh},
+			Status:   []planning.TaskStatus{planning.StatusVerified},
+		},
+		Condition: policy.Condition{Field: "verification_run"},
+		Level:     policy.ViolationError,
+		On:        []string{"verify"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	task := planning.Task{ID: "t1", Priority: planning.PriorityHigh}
+	verified := planning.TaskResult{Status: planning.StatusVerified}
+	if v := rule.ValidateTask(task, verified); len(v) != 1 {
+		t.Errorf("expected violation without a verification run")
+	}
+	verified.VerificationRun = &planning.VerificationRun{Command: "make check", ExitCode: 1}
+	if v := rule.ValidateTask(task, verified); len(v) != 1 {
+		t.Errorf("a failed run must not satisfy the rule")
+	}
+	verified.VerificationRun.ExitCode = 0
+	if v := rule.ValidateTask(task, verified); len(v) != 0 {
+		t.Errorf("a passing run should satisfy the rule: %+v", v)
+	}
+}
+
+func TestDeclarativeRule_MaxDuration(t *testing.T) {
+	rule, err := rules.Compile(policy.RuleConfig{
+		ID:        "stale-wip",
+		Type:      policy.RuleTypeMaxDuration,
+		Condition: policy.Condition{MaxDuration: "3d"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	rule.Now = func() time.Time { return now }
+
+	fresh := now.Add(-48 * time.Hour)
+	stale := now.Add(-73 * time.Hour)
+	task := planning.Task{ID: "t1"}
+
+	if v := rule.ValidateTask(task, planning.TaskResult{Status: planning.StatusInProgress, StartedAt: &fresh}); len(v) != 0 {
+		t.Errorf("task in progress for 2d should pass")
+	}
+	if v := rule.ValidateTask(task, planning.TaskResult{Status: planning.StatusInProgress, StartedAt: &stale}); len(v) != 1 {
+		t.Errorf("task in progress for >3d should violate")
+	}
+	if v := rule.ValidateTask(task, planning.TaskResult{Status: planning.StatusPending}); len(v) != 0 {
+		t.Errorf("task without a start time should pass")
+	}
+}
+
+func TestDeclarativeRule_ForbidStatusGates(t *testing.T) {
+	rule, err := rules.Compile(policy.RuleConfig{
+		ID:        "ai-verified",
+		Type:      policy.RuleTypeForbidStatus,
+		Selector:  policy.Selector{Origin: []planning.TaskOrigin{planning.OriginAI}},
+		Condition: policy.Condition{Status: []planning.TaskStatus{planning.StatusDone}},
+		Level:     policy.ViolationError,
+		On:        []string{"complete"},
+		Message:   "AI tasks must be verified",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rule.Gates("complete") || rule.Gates("start") {
+		t.Errorf("rule should gate only the complete event")
+	}
+
+	ai := planning.Task{ID: "t1", Origin: planning.OriginAI}
+	human := planning.Task{ID: "t2", Origin: planning.OriginHuman}
+	v := rule.ValidateTask(ai, planning.TaskResult{Status: planning.StatusDone})
+	if len(v) != 1 || !strings.Contains(v[0].Message, "AI tasks must be verified") {
+		t.Fatalf("expected custom message violation, got %+v", v)
+	}
+	if v := rule.ValidateTask(ai, planning.TaskResult{Status: planning.StatusVerified}); len(v) != 0 {
+		t.Errorf("verified AI task should pass")
+	}
+	if v := rule.ValidateTask(human, planning.TaskResult{Status: planning.StatusDone}); len(v) != 0 {
+		t.Errorf("human task should not match selector")
+	}
+}
@@ -7,7 +7,12 @@ import (
 	"github.com/felixgeelhaar/roady/pkg/domain/policy"
 )
 
-type DependencyRule struct{}
+// DependencyRule reports in-progress tasks whose dependencies are not done.
+// External resolves "@project:task-id" dependencies; without it they are
+// not checked.
+type DependencyRule struct {
+	External func(ref planning.TaskRef) (planning.TaskStatus, error)
+}
 
 func (r *DependencyRule) ID() string {
 	return "dependency-check"
@@ -32,6 +37,12 @@ func (r *DependencyRule) Validate(plan *planning.Plan, state *planning.Execution
 		}
 
 		for _, depID := range task.DependsOn {
+			if ref := planning.ParseTaskRef(depID); ref.External {
+				if v, ok := r.checkExternal(task.ID, ref); !ok {
+					violations = append(violations, v)
+				}
+				continue
+			}
 			if statusMap[depID] != planning.StatusDone {
 				violations = append(violations, policy.Violation{
 					RuleID:  r.ID(),
@@ -44,3 +55,26 @@ func (r *DependencyRule) Validate(plan *planning.Plan, state *planning.Execution
 
 	return violations
 }
+
+// checkExternal checks a dependency on another project's task.
+func (r *DependencyRule) checkExternal(taskID string, ref planning.TaskRef) (policy.Violation, bool) {
+	if r.External == nil {
+		return policy.Violation{}, true
+	}
+	status, err := r.External(ref)
+	switch {
+	case err != nil:
+		return policy.Violation{
+			RuleID:  r.ID(),
+			Level:   policy.ViolationError,
+			Message: fmt.Sprintf("Task '%s' is in progress but its dependency '%s' cannot be resolved: %v", taskID, ref, err),
+		}, false
+	case !status.IsComplete():
+		return policy.Violation{
+			RuleID:  r.ID(),
+			Level:   policy.ViolationError,
+			Message: fmt.Sprintf("Task '%s' is in progress but depends on '%s' which is %s.", taskID, ref, status),
+		}, false
+	}
+	return policy.Violation{}, true
+}
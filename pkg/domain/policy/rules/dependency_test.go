@@ -1,6 +1,8 @@
 package rules
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
@@ -32,3 +34,35 @@ func TestDependencyRuleDetectsViolation(t *testing.T) {
 		t.Fatalf("rule id mismatch: %s", violations[0].RuleID)
 	}
 }
+
+func TestDependencyRuleExternal(t *testing.T) {
+	plan := &planning.Plan{
+		Tasks: []planning.Task{
+			{ID: "task-ui", DependsOn: []string{"@api:task-auth", "@api:task-users", "@billing:task-x"}},
+		},
+	}
+	state := planning.NewExecutionState("web")
+	state.TaskStates["task-ui"] = planning.TaskResult{Status: planning.StatusInProgress}
+
+	// Without a resolver external dependencies are not checked.
+	if violations := (&DependencyRule{}).Validate(plan, state); len(violations) != 0 {
+		t.Fatalf("expected no violations, got %v", violations)
+	}
+
+	rule := &DependencyRule{External: func(ref planning.TaskRef) (planning.TaskStatus, error) {
+		switch {
+		case ref.Project != "api":
+			return "", errors.New("unknown project")
+		case ref.TaskID == "task-auth":
+			return planning.StatusVerified, nil
+		}
+		return planning.StatusInProgress, nil
+	}}
+	violations := rule.Validate(plan, state)
+	if len(violations) != 2 {
+		t.Fatalf("expected 2 violations, got %v", violations)
+	}
+	if !strings.Contains(violations[0].Message, "@api:task-users") || !strings.Contains(violations[1].Message, "cannot be resolved") {
+		t.Errorf("unexpected violations %v", violations)
+	}
+}
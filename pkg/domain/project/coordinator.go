@@ -3,6 +3,10 @@ package project
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
 	"sync"
 	"time"
 
@@ -37,6 +41,8 @@ type Coordinator struct {
 	planRepo  PlanRepository
 	stateRepo StateRepository
 	publisher EventPublisher
+	project   string           // this project's name in projects
+	projects  ProjectDirectory // resolves @project:task-id dependencies; may be nil
 }
 
 // NewCoordinator creates a new Coordinator.
@@ -78,24 +84,27 @@ func (c *Coordinator) ApprovePlan(ctx context.Context, approver string) error {
 	}
 
 	// Initialize execution state with all tasks in pending status
-	state, err := c.stateRepo.Load(ctx)
-	if err != nil {
-		return err
-	}
-	if state == nil {
-		state = planning.NewExecutionState(plan.ID)
-	}
+	err = retryOnConflict(ctx, func() error {
+		state, err := c.stateRepo.Load(ctx)
+		if err != nil {
+			return err
+		}
+		if state == nil {
+			state = planning.NewExecutionState(plan.ID)
+		}
 
-	// Initialize all tasks to pending
-	for _, task := range plan.Tasks {
-		if _, exists := state.TaskStates[task.ID]; !exists {
-			state.TaskStates[task.ID] = planning.TaskResult{
-				Status: planning.StatusPending,
+		// Initialize all tasks to pending
+		for _, task := range plan.Tasks {
+			if _, exists := state.TaskStates[task.ID]; !exists {
+				state.TaskStates[task.ID] = planning.TaskResult{
+					Status: planning.StatusPending,
+				}
 			}
 		}
-	}
 
-	if err := c.stateRepo.Save(ctx, state); err != nil {
+		return c.stateRepo.Save(ctx, state)
+	})
+	if err != nil {
 		return err
 	}
 
@@ -116,66 +125,73 @@ func (c *Coordinator) StartTask(ctx context.Context, taskID, owner, rateID strin
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	plan, err := c.planRepo.Load(ctx)
-	if err != nil {
-		return err
-	}
-	if plan == nil {
-		return ErrNoPlan
-	}
-	if !plan.ApprovalStatus.IsApproved() {
-		return ErrPlanNotApproved
-	}
+	err := retryOnConflict(ctx, func() error {
+		plan, err := c.planRepo.Load(ctx)
+		if err != nil {
+			return err
+		}
+		if plan == nil {
+			return ErrNoPlan
+		}
+		if !plan.ApprovalStatus.IsApproved() {
+			return ErrPlanNotApproved
+		}
 
-	// Find task in plan
-	task := findTask(plan, taskID)
-	if task == nil {
-		return ErrTaskNotFound
-	}
+		// Find task in plan
+		task := findTask(plan, taskID)
+		if task == nil {
+			return ErrTaskNotFound
+		}
 
-	state, err := c.stateRepo.Load(ctx)
-	if err != nil {
-		return err
-	}
-	if state == nil {
-		return ErrNoState
-	}
+		state, err := c.stateRepo.Load(ctx)
+		if err != nil {
+			return err
+		}
+		if state == nil {
+			return ErrNoState
+		}
 
-	// Check current status allows starting
-	currentStatus := state.GetTaskStatus(taskID)
-	if !currentStatus.CanTransitionWith("start") {
-		return &TransitionError{
-			TaskID:     taskID,
-			FromStatus: string(currentStatus),
-			ToStatus:   string(planning.StatusInProgress),
-			Event:      "start",
+		// Check current status allows starting
+		currentStatus := state.GetTaskStatus(taskID)
+		if !currentStatus.CanTransitionWith("start") {
+			return &TransitionError{
+				TaskID:     taskID,
+				FromStatus: string(currentStatus),
+				ToStatus:   string(planning.StatusInProgress),
+				Event:      "start",
+			}
 		}
-	}
 
-	// Validate dependencies
-	for _, depID := range task.DependsOn {
-		depStatus := state.GetTaskStatus(depID)
-		if !depStatus.IsComplete() {
-			return &DependencyError{
-				TaskID:       taskID,
-				DependencyID: depID,
-				Status:       string(depStatus),
+		// Validate dependencies, including those on other projects
+		deps := c.dependencies(ctx, state)
+		for _, depID := range task.DependsOn {
+			depStatus, err := deps.status(depID)
+			if err != nil {
+				return err
+			}
+			if !depStatus.IsComplete() {
+				return &DependencyError{
+					TaskID:       taskID,
+					DependencyID: depID,
+					Status:       string(depStatus),
+				}
 			}
 		}
-	}
 
-	// Update state
-	state.SetTaskStatus(taskID, planning.StatusInProgress)
-	state.SetTaskOwner(taskID, owner)
-	state.StartTask(taskID)
-	if rateID != "" {
-		result := state.TaskStates[taskID]
-		result.RateID = rateID
-		state.TaskStates[taskID] = result
-		state.UpdatedAt = time.Now()
-	}
+		// Update state
+		state.SetTaskStatus(taskID, planning.StatusInProgress)
+		state.SetTaskOwner(taskID, owner)
+		state.StartTask(taskID)
+		if rateID != "" {
+			result := state.TaskStates[taskID]
+			result.RateID = rateID
+			state.TaskStates[taskID] = result
+			state.UpdatedAt = time.Now()
+		}
 
-	if err := c.stateRepo.Save(ctx, state); err != nil {
+		return c.stateRepo.Save(ctx, state)
+	})
+	if err != nil {
 		return err
 	}
 
@@ -192,46 +208,52 @@ func (c *Coordinator) CompleteTask(ctx context.Context, taskID, evidence string)
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	plan, err := c.planRepo.Load(ctx)
-	if err != nil {
-		return nil, err
-	}
-	if plan == nil {
-		return nil, ErrNoPlan
-	}
+	var plan *planning.Plan
+	var state *planning.ExecutionState
+	err := retryOnConflict(ctx, func() error {
+		var err error
+		plan, err = c.planRepo.Load(ctx)
+		if err != nil {
+			return err
+		}
+		if plan == nil {
+			return ErrNoPlan
+		}
 
-	task := findTask(plan, taskID)
-	if task == nil {
-		return nil, ErrTaskNotFound
-	}
+		task := findTask(plan, taskID)
+		if task == nil {
+			return ErrTaskNotFound
+		}
 
-	state, err := c.stateRepo.Load(ctx)
-	if err != nil {
-		return nil, err
-	}
-	if state == nil {
-		return nil, ErrNoState
-	}
+		state, err = c.stateRepo.Load(ctx)
+		if err != nil {
+			return err
+		}
+		if state == nil {
+			return ErrNoState
+		}
 
-	// Check current status allows completion
-	currentStatus := state.GetTaskStatus(taskID)
-	if !currentStatus.CanTransitionWith("complete") {
-		return nil, &TransitionError{
-			TaskID:     taskID,
-			FromStatus: string(currentStatus),
-			ToStatus:   string(planning.StatusDone),
-			Event:      "complete",
+		// Check current status allows completion
+		currentStatus := state.GetTaskStatus(taskID)
+		if !currentStatus.CanTransitionWith("complete") {
+			return &TransitionError{
+				TaskID:     taskID,
+				FromStatus: string(currentStatus),
+				ToStatus:   string(planning.StatusDone),
+				Event:      "complete",
+			}
 		}
-	}
 
-	// Update state
-	state.SetTaskStatus(taskID, planning.StatusDone)
-	state.CompleteTask(taskID)
-	if evidence != "" {
-		state.AddEvidence(taskID, evidence)
-	}
+		// Update state
+		state.SetTaskStatus(taskID, planning.StatusDone)
+		state.CompleteTask(taskID)
+		if evidence != "" {
+			state.AddEvidence(taskID, evidence)
+		}
 
-	if err := c.stateRepo.Save(ctx, state); err != nil {
+		return c.stateRepo.Save(ctx, state)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -240,8 +262,14 @@ func (c *Coordinator) CompleteTask(ctx context.Context, taskID, evidence string)
 		_ = c.publisher.PublishTaskCompleted(ctx, taskID, evidence)
 	}
 
-	// Find newly unlocked tasks
-	unlocked := c.findUnlockedTasks(plan, state)
+	// Find newly unlocked tasks, here and in projects depending on this one
+	unlocked := c.findUnlockedTasks(ctx, plan, state)
+	if elsewhere := c.findUnlockedElsewhere(ctx, taskID); len(elsewhere) > 0 {
+		if notifier, ok := c.projects.(UnlockNotifier); ok {
+			_ = notifier.NotifyUnlocked(ctx, planning.ExternalTaskRef(c.project, taskID), elsewhere)
+		}
+		unlocked = append(unlocked, elsewhere...)
+	}
 
 	return unlocked, nil
 }
@@ -251,26 +279,29 @@ func (c *Coordinator) BlockTask(ctx context.Context, taskID, reason string) erro
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	state, err := c.stateRepo.Load(ctx)
-	if err != nil {
-		return err
-	}
-	if state == nil {
-		return ErrNoState
-	}
+	err := retryOnConflict(ctx, func() error {
+		state, err := c.stateRepo.Load(ctx)
+		if err != nil {
+			return err
+		}
+		if state == nil {
+			return ErrNoState
+		}
 
-	currentStatus := state.GetTaskStatus(taskID)
-	if !currentStatus.CanTransitionWith("block") {
-		return &TransitionError{
-			TaskID:     taskID,
-			FromStatus: string(currentStatus),
-			ToStatus:   string(planning.StatusBlocked),
-			Event:      "block",
+		currentStatus := state.GetTaskStatus(taskID)
+		if !currentStatus.CanTransitionWith("block") {
+			return &TransitionError{
+				TaskID:     taskID,
+				FromStatus: string(currentStatus),
+				ToStatus:   string(planning.StatusBlocked),
+				Event:      "block",
+			}
 		}
-	}
 
-	state.SetTaskStatus(taskID, planning.StatusBlocked)
-	if err := c.stateRepo.Save(ctx, state); err != nil {
+		state.SetTaskStatus(taskID, planning.StatusBlocked)
+		return c.stateRepo.Save(ctx, state)
+	})
+	if err != nil {
 		return err
 	}
 
@@ -287,26 +318,29 @@ func (c *Coordinator) UnblockTask(ctx context.Context, taskID string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	state, err := c.stateRepo.Load(ctx)
-	if err != nil {
-		return err
-	}
-	if state == nil {
-		return ErrNoState
-	}
+	err := retryOnConflict(ctx, func() error {
+		state, err := c.stateRepo.Load(ctx)
+		if err != nil {
+			return err
+		}
+		if state == nil {
+			return ErrNoState
+		}
 
-	currentStatus := state.GetTaskStatus(taskID)
-	if !currentStatus.CanTransitionWith("unblock") {
-		return &TransitionError{
-			TaskID:     taskID,
-			FromStatus: string(currentStatus),
-			ToStatus:   string(planning.StatusPending),
-			Event:      "unblock",
+		currentStatus := state.GetTaskStatus(taskID)
+		if !currentStatus.CanTransitionWith("unblock") {
+			return &TransitionError{
+				TaskID:     taskID,
+				FromStatus: string(currentStatus),
+				ToStatus:   string(planning.StatusPending),
+				Event:      "unblock",
+			}
 		}
-	}
 
-	state.SetTaskStatus(taskID, planning.StatusPending)
-	if err := c.stateRepo.Save(ctx, state); err != nil {
+		state.SetTaskStatus(taskID, planning.StatusPending)
+		return c.stateRepo.Save(ctx, state)
+	})
+	if err != nil {
 		return err
 	}
 
@@ -325,26 +359,33 @@ func (c *Coordinator) ReopenTask(ctx context.Context, taskID string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	state, err := c.stateRepo.Load(ctx)
-	if err != nil {
-		return err
-	}
-	if state == nil {
-		return ErrNoState
-	}
+	err := retryOnConflict(ctx, func() error {
+		state, err := c.stateRepo.Load(ctx)
+		if err != nil {
+			return err
+		}
+		if state == nil {
+			return ErrNoState
+		}
 
-	currentStatus := state.GetTaskStatus(taskID)
-	if !currentStatus.CanTransitionWith("reopen") {
-		return &TransitionError{
-			TaskID:     taskID,
-			FromStatus: string(currentStatus),
-			ToStatus:   string(planning.StatusPending),
-			Event:      "reopen",
+		currentStatus := state.GetTaskStatus(taskID)
+		if !currentStatus.CanTransitionWith("reopen") {
+			return &TransitionError{
+				TaskID:     taskID,
+				FromStatus: string(currentStatus),
+				ToStatus:   string(planning.StatusPending),
+				Event:      "reopen",
+			}
 		}
-	}
 
-	state.SetTaskStatus(taskID, planning.StatusPending)
-	if err := c.stateRepo.Save(ctx, state); err != nil {
+		state.SetTaskStatus(taskID, planning.StatusPending)
+		// A reopened task has to pass its verification again.
+		result := state.TaskStates[taskID]
+		result.VerificationRun = nil
+		state.TaskStates[taskID] = result
+		return c.stateRepo.Save(ctx, state)
+	})
+	if err != nil {
 		return err
 	}
 
@@ -357,31 +398,104 @@ func (c *Coordinator) ReopenTask(ctx context.Context, taskID string) error {
 	return nil
 }
 
-// VerifyTask marks a completed task as verified.
-func (c *Coordinator) VerifyTask(ctx context.Context, taskID, verifier string) error {
+// VerifyTask marks a completed task as verified. Evidence is recorded
+// against the task's acceptance criteria first; verification fails with a
+// CriteriaError while any criterion still lacks evidence.
+func (c *Coordinator) VerifyTask(ctx context.Context, taskID, verifier string, evidence ...planning.CriterionEvidence) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	state, err := c.stateRepo.Load(ctx)
-	if err != nil {
-		return err
-	}
-	if state == nil {
-		return ErrNoState
-	}
+	return retryOnConflict(ctx, func() error {
+		state, err := c.stateRepo.Load(ctx)
+		if err != nil {
+			return err
+		}
+		if state == nil {
+			return ErrNoState
+		}
 
-	currentStatus := state.GetTaskStatus(taskID)
-	if !currentStatus.CanTransitionWith("verify") {
-		return &TransitionError{
-			TaskID:     taskID,
-			FromStatus: string(currentStatus),
-			ToStatus:   string(planning.StatusVerified),
-			Event:      "verify",
+		currentStatus := state.GetTaskStatus(taskID)
+		if !currentStatus.CanTransitionWith("verify") {
+			return &TransitionError{
+				TaskID:     taskID,
+				FromStatus: string(currentStatus),
+				ToStatus:   string(planning.StatusVerified),
+				Event:      "verify",
+			}
+		}
+
+		// Criteria come from the plan; without one the task has none to prove.
+		var task planning.Task
+		if c.planRepo != nil {
+			plan, err := c.planRepo.Load(ctx)
+			if err != nil {
+				return err
+			}
+			if plan != nil {
+				if t := findTask(plan, taskID); t != nil {
+					task = *t
+				}
+			}
+		}
+		// Record into a copy so a rejected verification leaves state untouched.
+		result := state.TaskStates[taskID]
+		result.CriteriaEvidence = slices.Clone(result.CriteriaEvidence)
+		staged := &planning.ExecutionState{TaskStates: map[string]planning.TaskResult{taskID: result}}
+		now := time.Now()
+		for _, ev := range evidence {
+			if !slices.Contains(task.AcceptanceCriteria, ev.Criterion) {
+				return fmt.Errorf("task %s has no acceptance criterion %q", taskID, ev.Criterion)
+			}
+			if err := ev.Validate(); err != nil {
+				return err
+			}
+			if ev.RecordedBy == "" {
+				ev.RecordedBy = verifier
+			}
+			if ev.RecordedAt.IsZero() {
+				ev.RecordedAt = now
+			}
+			staged.RecordCriterionEvidence(taskID, ev)
+		}
+		if coverage := task.Coverage(staged.TaskStates[taskID]); !coverage.Complete() {
+			return &CriteriaError{TaskID: taskID, Criteria: task.AcceptanceCriteria, Missing: coverage.Missing}
 		}
-	}
 
-	state.SetTaskStatus(taskID, planning.StatusVerified)
-	return c.stateRepo.Save(ctx, state)
+		state.TaskStates[taskID] = staged.TaskStates[taskID]
+		state.SetTaskStatus(taskID, planning.StatusVerified)
+		return c.stateRepo.Save(ctx, state)
+	})
+}
+
+// RecordVerificationRun stores the result of running a task's verification.
+// The task must be in a status that can be verified; a passing run is also
+// added to the task's evidence.
+func (c *Coordinator) RecordVerificationRun(ctx context.Context, taskID string, run planning.VerificationRun) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return retryOnConflict(ctx, func() error {
+		state, err := c.stateRepo.Load(ctx)
+		if err != nil {
+			return err
+		}
+		if state == nil {
+			return ErrNoState
+		}
+
+		currentStatus := state.GetTaskStatus(taskID)
+		if !currentStatus.CanTransitionWith("verify") {
+			return &TransitionError{
+				TaskID:     taskID,
+				FromStatus: string(currentStatus),
+				ToStatus:   string(planning.StatusVerified),
+				Event:      "verify",
+			}
+		}
+
+		state.RecordVerificationRun(taskID, run)
+		return c.stateRepo.Save(ctx, state)
+	})
 }
 
 // GetPlan returns the current plan.
@@ -399,8 +513,9 @@ func (c *Coordinator) GetState(ctx context.Context) (*planning.ExecutionState, e
 }
 
 // findUnlockedTasks returns task IDs that can now be started.
-func (c *Coordinator) findUnlockedTasks(plan *planning.Plan, state *planning.ExecutionState) []string {
+func (c *Coordinator) findUnlockedTasks(ctx context.Context, plan *planning.Plan, state *planning.ExecutionState) []string {
 	var unlocked []string
+	deps := c.dependencies(ctx, state)
 
 	for _, task := range plan.Tasks {
 		// Skip tasks that are not pending
@@ -409,15 +524,7 @@ func (c *Coordinator) findUnlockedTasks(plan *planning.Plan, state *planning.Exe
 		}
 
 		// Check if all dependencies are complete
-		allDepsComplete := true
-		for _, depID := range task.DependsOn {
-			if !state.GetTaskStatus(depID).IsComplete() {
-				allDepsComplete = false
-				break
-			}
-		}
-
-		if allDepsComplete {
+		if deps.met(task) {
 			unlocked = append(unlocked, task.ID)
 		}
 	}
@@ -425,6 +532,39 @@ func (c *Coordinator) findUnlockedTasks(plan *planning.Plan, state *planning.Exe
 	return unlocked
 }
 
+// Backoff for state saves that lose a race with another writer, e.g. a
+// second CLI invocation, the MCP server or the dashboard. Variables so tests
+// can shorten them.
+var (
+	conflictBackoff    = 5 * time.Millisecond
+	conflictMaxBackoff = 500 * time.Millisecond
+)
+
+// retryOnConflict runs op, which loads, modifies and saves the execution
+// state, and replays it from a fresh load while the save fails with a
+// *planning.ConflictError. A conflict means another writer's save went
+// through, so the writers as a whole always make progress; retries are
+// therefore bounded only by ctx. Waits between attempts grow exponentially
+// with jitter so competing writers spread out.
+func retryOnConflict(ctx context.Context, op func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := op()
+		var conflict *planning.ConflictError
+		if !errors.As(err, &conflict) {
+			return err
+		}
+		wait := conflictMaxBackoff
+		if attempt < 10 {
+			wait = min(conflictBackoff<<attempt, conflictMaxBackoff)
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(wait/2 + rand.N(wait/2+1)):
+		}
+	}
+}
+
 // findTask looks up a task in the plan by ID.
 func findTask(plan *planning.Plan, taskID string) *planning.Task {
 	for i := range plan.Tasks {
@@ -3,7 +3,9 @@ package project
 import (
 	"context"
 	"errors"
+	"maps"
 	"testing"
+	"time"
 
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 )
@@ -344,6 +346,85 @@ func TestCoordinator_VerifyTask(t *testing.T) {
 	}
 }
 
+func TestCoordinator_VerifyTask_RequiresCriteriaEvidence(t *testing.T) {
+	plan := &planning.Plan{
+		ID:             "plan-1",
+		ApprovalStatus: planning.ApprovalApproved,
+		Tasks: []planning.Task{
+			{ID: "task-1", AcceptanceCriteria: []string{"Errors are shown", "Receipts are emailed"}},
+		},
+	}
+	state := planning.NewExecutionState("plan-1")
+	state.TaskStates["task-1"] = planning.TaskResult{Status: planning.StatusDone}
+	stateRepo := &mockStateRepo{state: state}
+	coord := NewCoordinator(&mockPlanRepo{plan: plan}, stateRepo, nil)
+	ctx := context.Background()
+
+	partial := planning.CriterionEvidence{Criterion: "Errors are shown", Kind: planning.EvidenceTest, Value: "TestErrors"}
+	err := coord.VerifyTask(ctx, "task-1", "bob", partial)
+	var criteriaErr *CriteriaError
+	if !errors.As(err, &criteriaErr) || !errors.Is(err, ErrEvidenceRequired) {
+		t.Fatalf("expected CriteriaError, got %v", err)
+	}
+	if len(criteriaErr.Missing) != 1 || criteriaErr.Missing[0] != "Receipts are emailed" {
+		t.Errorf("missing = %v", criteriaErr.Missing)
+	}
+	if got := stateRepo.state.TaskStates["task-1"]; got.Status != planning.StatusDone || len(got.CriteriaEvidence) != 0 {
+		t.Fatalf("rejected verification must not change state: %+v", got)
+	}
+
+	err = coord.VerifyTask(ctx, "task-1", "bob", partial,
+		planning.CriterionEvidence{Criterion: "Receipts are emailed", Kind: planning.EvidenceCommit, Value: "3f2a9c1"})
+	if err != nil {
+		t.Fatalf("VerifyTask: %v", err)
+	}
+	got := stateRepo.state.TaskStates["task-1"]
+	if got.Status != planning.StatusVerified || len(got.CriteriaEvidence) != 2 {
+		t.Fatalf("unexpected result: %+v", got)
+	}
+	if got.CriteriaEvidence[0].RecordedBy != "bob" || got.CriteriaEvidence[0].RecordedAt.IsZero() {
+		t.Errorf("evidence should record who and when: %+v", got.CriteriaEvidence[0])
+	}
+
+	state.TaskStates["task-1"] = planning.TaskResult{Status: planning.StatusDone}
+	unknown := planning.CriterionEvidence{Criterion: "Not a criterion", Kind: planning.EvidenceText, Value: "x"}
+	if err := coord.VerifyTask(ctx, "task-1", "bob", unknown); err == nil {
+		t.Error("expected an error for evidence against an unknown criterion")
+	}
+}
+
+func TestCoordinator_RecordVerificationRun(t *testing.T) {
+	state := planning.NewExecutionState("plan-1")
+	state.TaskStates["task-1"] = planning.TaskResult{Status: planning.StatusInProgress}
+	stateRepo := &mockStateRepo{state: state}
+	coord := NewCoordinator(nil, stateRepo, nil)
+	ctx := context.Background()
+	run := planning.VerificationRun{Command: "make check", Digest: "d"}
+
+	if err := coord.RecordVerificationRun(ctx, "task-1", run); !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("expected ErrInvalidTransition for an in-progress task, got %v", err)
+	}
+
+	state.TaskStates["task-1"] = planning.TaskResult{Status: planning.StatusDone}
+	if err := coord.RecordVerificationRun(ctx, "task-1", run); err != nil {
+		t.Fatalf("RecordVerificationRun: %v", err)
+	}
+	got := stateRepo.state.TaskStates["task-1"]
+	if got.VerificationRun == nil || len(got.Evidence) != 1 || got.Status != planning.StatusDone {
+		t.Fatalf("unexpected result: %+v", got)
+	}
+
+	if err := coord.VerifyTask(ctx, "task-1", "bob"); err != nil {
+		t.Fatalf("VerifyTask: %v", err)
+	}
+	if err := coord.ReopenTask(ctx, "task-1"); err != nil {
+		t.Fatalf("ReopenTask: %v", err)
+	}
+	if got := stateRepo.state.TaskStates["task-1"]; got.VerificationRun != nil {
+		t.Error("reopening a task should discard its verification run")
+	}
+}
+
 func TestDependencyError(t *testing.T) {
 	err := &DependencyError{
 		TaskID:       "task-2",
@@ -591,3 +672,81 @@ func TestCoordinator_CompleteTask_NoPlan(t *testing.T) {
 		t.Errorf("Expected ErrNoPlan, got: %v", err)
 	}
 }
+
+// racingStateRepo simulates another process saving the state just before
+// this one, for the first `races` saves.
+type racingStateRepo struct {
+	mockStateRepo
+	races int
+	saves int
+}
+
+func (m *racingStateRepo) Load(ctx context.Context) (*planning.ExecutionState, error) {
+	clone := *m.state
+	clone.TaskStates = maps.Clone(m.state.TaskStates)
+	return &clone, nil
+}
+
+func (m *racingStateRepo) Save(ctx context.Context, state *planning.ExecutionState) error {
+	m.saves++
+	if m.races > 0 {
+		m.races--
+		m.state.Version++
+		return &planning.ConflictError{Expected: state.Version, Actual: m.state.Version}
+	}
+	if state.Version != m.state.Version {
+		return &planning.ConflictError{Expected: state.Version, Actual: m.state.Version}
+	}
+	state.Version++
+	m.state = state
+	return nil
+}
+
+func TestCoordinator_RetriesOnConflict(t *testing.T) {
+	plan := &planning.Plan{
+		ID:             "plan-1",
+		ApprovalStatus: planning.ApprovalApproved,
+		Tasks:          []planning.Task{{ID: "task-1"}},
+	}
+	state := planning.NewExecutionState("plan-1")
+	state.TaskStates["task-1"] = planning.TaskResult{Status: planning.StatusPending}
+	stateRepo := &racingStateRepo{mockStateRepo: mockStateRepo{state: state}, races: 3}
+	coord := NewCoordinator(&mockPlanRepo{plan: plan}, stateRepo, nil)
+	ctx := context.Background()
+
+	if err := coord.StartTask(ctx, "task-1", "alice", ""); err != nil {
+		t.Fatalf("StartTask should succeed after conflicts: %v", err)
+	}
+	if stateRepo.saves != 4 || stateRepo.state.TaskStates["task-1"].Status != planning.StatusInProgress {
+		t.Errorf("expected 3 conflicts then a save, got %d saves and %+v", stateRepo.saves, stateRepo.state.TaskStates["task-1"])
+	}
+
+	stateRepo.races = 1
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := coord.CompleteTask(canceled, "task-1", ""); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the retry to stop on cancellation, got %v", err)
+	}
+
+	// Retries continue past any fixed count while other writers keep
+	// winning, and stop only when the context ends.
+	defer func(b, m time.Duration) { conflictBackoff, conflictMaxBackoff = b, m }(conflictBackoff, conflictMaxBackoff)
+	conflictBackoff, conflictMaxBackoff = time.Microsecond, time.Millisecond
+	stateRepo.races, stateRepo.saves = 20, 0
+	if _, err := coord.CompleteTask(ctx, "task-1", ""); err != nil {
+		t.Fatalf("CompleteTask should outlast 20 conflicts: %v", err)
+	}
+	if stateRepo.saves != 21 {
+		t.Errorf("expected 20 conflicts then a save, got %d saves", stateRepo.saves)
+	}
+
+	stateRepo.state.TaskStates["task-1"] = planning.TaskResult{Status: planning.StatusInProgress}
+	stateRepo.races = 1 << 30
+	deadline, stop := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer stop()
+	_, err := coord.CompleteTask(deadline, "task-1", "")
+	var conflict *planning.ConflictError
+	if !errors.As(err, &conflict) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the last conflict and the deadline, got %v", err)
+	}
+}
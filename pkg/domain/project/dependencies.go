@@ -0,0 +1,134 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+)
+
+// ProjectDirectory gives the coordinator read access to the projects of its
+// workspace, which DependsOn entries of the form "@project:task-id" refer
+// to. The root project is named "".
+type ProjectDirectory interface {
+	// Projects lists the workspace's projects.
+	Projects(ctx context.Context) ([]string, error)
+	LoadPlan(ctx context.Context, project string) (*planning.Plan, error)
+	LoadState(ctx context.Context, project string) (*planning.ExecutionState, error)
+}
+
+// UnlockNotifier is implemented by project directories that tell other
+// projects when a completed task unlocked some of theirs.
+type UnlockNotifier interface {
+	// NotifyUnlocked announces the "@project:task-id" tasks unlocked by
+	// completing the task "by" refers to.
+	NotifyUnlocked(ctx context.Context, by string, unlocked []string) error
+}
+
+// SetProjectDirectory lets the coordinator resolve cross-project
+// dependencies; name is the coordinator's own project in dir.
+func (c *Coordinator) SetProjectDirectory(name string, dir ProjectDirectory) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.project = name
+	c.projects = dir
+}
+
+// dependencies resolves the status of dependencies from one project's point
+// of view, loading each other project's state at most once.
+type dependencies struct {
+	ctx    context.Context
+	self   string
+	state  *planning.ExecutionState
+	dir    ProjectDirectory
+	states map[string]*planning.ExecutionState
+}
+
+func (c *Coordinator) dependencies(ctx context.Context, state *planning.ExecutionState) *dependencies {
+	return newDependencies(ctx, c.project, state, c.projects)
+}
+
+func newDependencies(ctx context.Context, self string, state *planning.ExecutionState, dir ProjectDirectory) *dependencies {
+	return &dependencies{ctx: ctx, self: self, state: state, dir: dir, states: make(map[string]*planning.ExecutionState)}
+}
+
+// status returns the status of the task dep refers to.
+func (d *dependencies) status(dep string) (planning.TaskStatus, error) {
+	ref := planning.ParseTaskRef(dep)
+	if !ref.External || (d.dir != nil && ref.Project == d.self) {
+		return d.state.GetTaskStatus(ref.TaskID), nil
+	}
+	if d.dir == nil {
+		return "", fmt.Errorf("%w: %s (cross-project dependencies need a workspace)", ErrUnknownProject, dep)
+	}
+	state, ok := d.states[ref.Project]
+	if !ok {
+		var err error
+		if state, err = d.dir.LoadState(d.ctx, ref.Project); err != nil {
+			return "", fmt.Errorf("resolve dependency %s: %w", dep, err)
+		}
+		if state == nil {
+			state = planning.NewExecutionState("")
+		}
+		d.states[ref.Project] = state
+	}
+	return state.GetTaskStatus(ref.TaskID), nil
+}
+
+// met reports whether all of task's dependencies are complete. Dependencies
+// that cannot be resolved are not.
+func (d *dependencies) met(task planning.Task) bool {
+	for _, dep := range task.DependsOn {
+		status, err := d.status(dep)
+		if err != nil || !status.IsComplete() {
+			return false
+		}
+	}
+	return true
+}
+
+// findUnlockedElsewhere returns the pending tasks of other projects that
+// depend on taskID and now have all their dependencies complete, as
+// "@project:task-id" references.
+func (c *Coordinator) findUnlockedElsewhere(ctx context.Context, taskID string) []string {
+	if c.projects == nil {
+		return nil
+	}
+	names, err := c.projects.Projects(ctx)
+	if err != nil {
+		return nil
+	}
+	ref := planning.ExternalTaskRef(c.project, taskID)
+
+	var unlocked []string
+	for _, name := range names {
+		if name == c.project {
+			continue
+		}
+		plan, err := c.projects.LoadPlan(ctx, name)
+		if err != nil || plan == nil {
+			continue
+		}
+		var deps *dependencies
+		for _, task := range plan.Tasks {
+			if !slices.Contains(task.DependsOn, ref) {
+				continue
+			}
+			if deps == nil {
+				state, err := c.projects.LoadState(ctx, name)
+				if err != nil {
+					break
+				}
+				if state == nil {
+					state = planning.NewExecutionState(plan.ID)
+				}
+				deps = newDependencies(ctx, name, state, c.projects)
+			}
+			if deps.state.GetTaskStatus(task.ID) == planning.StatusPending && deps.met(task) {
+				unlocked = append(unlocked, planning.ExternalTaskRef(name, task.ID))
+			}
+		}
+	}
+	return unlocked
+}
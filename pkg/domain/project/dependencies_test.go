@@ -0,0 +1,153 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+)
+
+// mockDirectory is a workspace of projects backed by the coordinators'
+// mock repositories.
+type mockDirectory struct {
+	plans    map[string]*mockPlanRepo
+	states   map[string]*mockStateRepo
+	notified []string
+}
+
+func (m *mockDirectory) Projects(ctx context.Context) ([]string, error) {
+	var names []string
+	for name := range m.plans {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+func (m *mockDirectory) LoadPlan(ctx context.Context, project string) (*planning.Plan, error) {
+	repo, ok := m.plans[project]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownProject, project)
+	}
+	return repo.plan, nil
+}
+
+func (m *mockDirectory) LoadState(ctx context.Context, project string) (*planning.ExecutionState, error) {
+	repo, ok := m.states[project]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownProject, project)
+	}
+	return repo.state, nil
+}
+
+func (m *mockDirectory) NotifyUnlocked(ctx context.Context, by string, unlocked []string) error {
+	for _, ref := range unlocked {
+		m.notified = append(m.notified, by+"->"+ref)
+	}
+	return nil
+}
+
+// newWorkspace returns coordinators for an "api" project and a "web"
+// project whose task-ui depends on api's task-auth.
+func newWorkspace() (api, web *Coordinator, dir *mockDirectory) {
+	dir = &mockDirectory{plans: map[string]*mockPlanRepo{}, states: map[string]*mockStateRepo{}}
+	add := func(name string, tasks ...planning.Task) *Coordinator {
+		plan := &planning.Plan{ID: name, ApprovalStatus: planning.ApprovalApproved, Tasks: tasks}
+		state := planning.NewExecutionState(name)
+		for _, task := range tasks {
+			state.TaskStates[task.ID] = planning.TaskResult{Status: planning.StatusPending}
+		}
+		dir.plans[name] = &mockPlanRepo{plan: plan}
+		dir.states[name] = &mockStateRepo{state: state}
+		c := NewCoordinator(dir.plans[name], dir.states[name], nil)
+		c.SetProjectDirectory(name, dir)
+		return c
+	}
+	api = add("api", planning.Task{ID: "task-auth"})
+	web = add("web",
+		planning.Task{ID: "task-ui", DependsOn: []string{"@api:task-auth"}},
+		planning.Task{ID: "task-docs", DependsOn: []string{"task-ui"}},
+	)
+	return api, web, dir
+}
+
+func TestCoordinator_StartTask_CrossProject(t *testing.T) {
+	api, web, _ := newWorkspace()
+	ctx := context.Background()
+
+	err := web.StartTask(ctx, "task-ui", "alice", "")
+	var depErr *DependencyError
+	if !errors.As(err, &depErr) || depErr.DependencyID != "@api:task-auth" || depErr.Status != string(planning.StatusPending) {
+		t.Fatalf("expected the api dependency to block, got %v", err)
+	}
+
+	if err := api.StartTask(ctx, "task-auth", "bob", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := api.CompleteTask(ctx, "task-auth", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := web.StartTask(ctx, "task-ui", "alice", ""); err != nil {
+		t.Errorf("expected the task to start once api's task is done, got %v", err)
+	}
+}
+
+func TestCoordinator_StartTask_UnknownProject(t *testing.T) {
+	_, web, dir := newWorkspace()
+	dir.plans["web"].plan.Tasks[0].DependsOn = []string{"@billing:task-x"}
+
+	if err := web.StartTask(context.Background(), "task-ui", "alice", ""); !errors.Is(err, ErrUnknownProject) {
+		t.Errorf("expected ErrUnknownProject, got %v", err)
+	}
+
+	// Without a directory, cross-project dependencies cannot be resolved.
+	standalone := NewCoordinator(dir.plans["web"], dir.states["web"], nil)
+	if err := standalone.StartTask(context.Background(), "task-ui", "alice", ""); !errors.Is(err, ErrUnknownProject) {
+		t.Errorf("expected ErrUnknownProject, got %v", err)
+	}
+}
+
+func TestCoordinator_CompleteTask_UnlocksOtherProjects(t *testing.T) {
+	api, web, dir := newWorkspace()
+	ctx := context.Background()
+
+	ready, err := web.GetReadyTasks(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ready) != 0 {
+		t.Fatalf("expected no ready web tasks, got %v", ready)
+	}
+
+	if err := api.StartTask(ctx, "task-auth", "bob", ""); err != nil {
+		t.Fatal(err)
+	}
+	unlocked, err := api.CompleteTask(ctx, "task-auth", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(unlocked, []string{"@web:task-ui"}) {
+		t.Errorf("unlocked = %v", unlocked)
+	}
+	if !slices.Equal(dir.notified, []string{"@api:task-auth->@web:task-ui"}) {
+		t.Errorf("notified = %v", dir.notified)
+	}
+
+	ready, err = web.GetReadyTasks(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ready) != 1 || ready[0].ID != "task-ui" {
+		t.Errorf("expected task-ui to be ready, got %v", ready)
+	}
+	snapshot, err := web.GetProjectSnapshot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(snapshot.UnlockedTasks, []string{"task-ui"}) {
+		t.Errorf("unlocked tasks = %v", snapshot.UnlockedTasks)
+	}
+}
@@ -1,6 +1,10 @@
 package project
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // Domain errors for project coordination.
 var (
@@ -33,6 +37,10 @@ var (
 
 	// ErrEvidenceRequired indicates evidence is required for this operation.
 	ErrEvidenceRequired = errors.New("evidence required")
+
+	// ErrUnknownProject indicates a cross-project dependency names a project
+	// that is not part of the workspace.
+	ErrUnknownProject = errors.New("unknown project")
 )
 
 // DependencyError provides details about which dependency is blocking.
@@ -51,6 +59,23 @@ func (e *DependencyError) Is(target error) bool {
 	return target == ErrDependenciesNotMet
 }
 
+// CriteriaError lists the acceptance criteria still lacking evidence when a
+// task is verified.
+type CriteriaError struct {
+	TaskID   string
+	Criteria []string // all of the task's criteria, in order
+	Missing  []string
+}
+
+func (e *CriteriaError) Error() string {
+	return fmt.Sprintf("task %s has %d acceptance criteria without evidence: %s", e.TaskID, len(e.Missing), strings.Join(e.Missing, "; "))
+}
+
+// Is allows errors.Is to work with CriteriaError.
+func (e *CriteriaError) Is(target error) bool {
+	return target == ErrEvidenceRequired
+}
+
 // TransitionError provides details about an invalid transition.
 type TransitionError struct {
 	TaskID     string
@@ -61,6 +61,7 @@ func (c *Coordinator) GetProjectSnapshot(ctx context.Context) (*ProjectSnapshot,
 	// Calculate progress and categorize tasks
 	totalTasks := len(plan.Tasks)
 	completedCount := 0
+	deps := c.dependencies(ctx, state)
 
 	for _, task := range plan.Tasks {
 		status := state.GetTaskStatus(task.ID)
@@ -78,7 +79,7 @@ func (c *Coordinator) GetProjectSnapshot(ctx context.Context) (*ProjectSnapshot,
 			completedCount++
 		case status.IsPending():
 			// Check if this task is unlocked (all deps complete)
-			if isUnlocked(task, state) {
+			if deps.met(task) {
 				snapshot.UnlockedTasks = append(snapshot.UnlockedTasks, task.ID)
 			}
 		}
@@ -114,6 +115,7 @@ func (c *Coordinator) GetTaskSummaries(ctx context.Context) ([]TaskSummary, erro
 	}
 
 	summaries := make([]TaskSummary, 0, len(plan.Tasks))
+	deps := c.dependencies(ctx, state)
 
 	for _, task := range plan.Tasks {
 		status := state.GetTaskStatus(task.ID)
@@ -128,7 +130,7 @@ func (c *Coordinator) GetTaskSummaries(ctx context.Context) ([]TaskSummary, erro
 			Owner:       result.Owner,
 			DependsOn:   task.DependsOn,
 			IsBlocked:   status.IsBlocked(),
-			IsUnlocked:  status.IsPending() && isUnlocked(task, state),
+			IsUnlocked:  status.IsPending() && deps.met(task),
 		}
 
 		summaries = append(summaries, summary)
@@ -187,13 +189,3 @@ func (c *Coordinator) GetInProgressTasks(ctx context.Context) ([]TaskSummary, er
 
 	return inProgress, nil
 }
-
-// isUnlocked checks if a task has all its dependencies completed.
-func isUnlocked(task planning.Task, state *planning.ExecutionState) bool {
-	for _, depID := range task.DependsOn {
-		if !state.GetTaskStatus(depID).IsComplete() {
-			return false
-		}
-	}
-	return true
-}
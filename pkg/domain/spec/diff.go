@@ -0,0 +1,216 @@
+package spec
+
+import (
+	"slices"
+	"strings"
+)
+
+// ChangeKind classifies a single spec change.
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeRenamed  ChangeKind = "renamed"  // Title changed, or ID changed with identical content
+	ChangeModified ChangeKind = "modified" // A tracked field other than the title changed
+)
+
+// ElementKind identifies which part of the spec a change applies to.
+type ElementKind string
+
+const (
+	ElementSpec        ElementKind = "spec"
+	ElementFeature     ElementKind = "feature"
+	ElementRequirement ElementKind = "requirement"
+	ElementConstraint  ElementKind = "constraint"
+)
+
+// Change describes one semantic difference between two specs.
+type Change struct {
+	Kind      ChangeKind  `json:"kind" yaml:"kind"`
+	Element   ElementKind `json:"element" yaml:"element"`
+	ID        string      `json:"id" yaml:"id"`
+	OldID     string      `json:"old_id,omitempty" yaml:"old_id,omitempty"` // Set when an element was re-identified
+	FeatureID string      `json:"feature_id,omitempty" yaml:"feature_id,omitempty"`
+	Field     string      `json:"field,omitempty" yaml:"field,omitempty"` // Set for renamed/modified changes
+	Old       string      `json:"old,omitempty" yaml:"old,omitempty"`
+	New       string      `json:"new,omitempty" yaml:"new,omitempty"`
+	Source    Source      `json:"source,omitempty" yaml:"source,omitempty"` // Where the element lives (new side, or old side for removals)
+}
+
+// SpecDiff is the structured difference from a base spec to a current spec.
+type SpecDiff struct {
+	BaseHash    string   `json:"base_hash" yaml:"base_hash"`
+	CurrentHash string   `json:"current_hash" yaml:"current_hash"`
+	Changes     []Change `json:"changes" yaml:"changes"`
+}
+
+// IsEmpty reports whether the two specs are semantically identical.
+func (d *SpecDiff) IsEmpty() bool {
+	return d == nil || len(d.Changes) == 0
+}
+
+// Diff computes the semantic changes needed to turn base into current.
+// Features and requirements are matched by ID; an element that disappears
+// under one ID and reappears under another with the same title and
+// description is reported as a single rename. Changes are ordered spec,
+// features (with their requirements), then constraints.
+func Diff(base, current *ProductSpec) *SpecDiff {
+	if base == nil {
+		base = &ProductSpec{}
+	}
+	if current == nil {
+		current = &ProductSpec{}
+	}
+
+	d := &SpecDiff{BaseHash: base.Hash(), CurrentHash: current.Hash(), Changes: make([]Change, 0)}
+
+	for _, f := range []struct{ name, old, new string }{
+		{"title", base.Title, current.Title},
+		{"description", base.Description, current.Description},
+		{"version", base.Version, current.Version},
+	} {
+		if f.old != f.new {
+			d.Changes = append(d.Changes, Change{Kind: ChangeModified, Element: ElementSpec, ID: current.ID, Field: f.name, Old: f.old, New: f.new})
+		}
+	}
+
+	d.diffFeatures(base.Features, current.Features)
+	d.diffConstraints(base.Constraints, current.Constraints)
+	return d
+}
+
+func (d *SpecDiff) diffFeatures(base, current []Feature) {
+	baseByID := make(map[string]Feature, len(base))
+	for _, f := range base {
+		baseByID[f.ID] = f
+	}
+	currentIDs := make(map[string]bool, len(current))
+	for _, f := range current {
+		currentIDs[f.ID] = true
+	}
+
+	removed := make([]Feature, 0)
+	for _, f := range base {
+		if !currentIDs[f.ID] {
+			removed = append(removed, f)
+		}
+	}
+
+	for _, f := range current {
+		old, ok := baseByID[f.ID]
+		if !ok {
+			if i := slices.IndexFunc(removed, func(r Feature) bool { return sameContent(r.Title, r.Description, f.Title, f.Description) }); i >= 0 {
+				old = removed[i]
+				removed = slices.Delete(removed, i, i+1)
+				d.Changes = append(d.Changes, Change{Kind: ChangeRenamed, Element: ElementFeature, ID: f.ID, OldID: old.ID, Field: "id", Old: old.ID, New: f.ID, Source: f.Source})
+			} else {
+				d.Changes = append(d.Changes, Change{Kind: ChangeAdded, Element: ElementFeature, ID: f.ID, New: f.Title, Source: f.Source})
+				for _, r := range f.Requirements {
+					d.Changes = append(d.Changes, Change{Kind: ChangeAdded, Element: ElementRequirement, ID: r.ID, FeatureID: f.ID, New: r.Title, Source: r.Source})
+				}
+				continue
+			}
+		}
+
+		if old.Title != f.Title {
+			d.Changes = append(d.Changes, Change{Kind: ChangeRenamed, Element: ElementFeature, ID: f.ID, Field: "title", Old: old.Title, New: f.Title, Source: f.Source})
+		}
+		for _, fld := range []struct{ name, old, new string }{
+			{"description", old.Description, f.Description},
+			{"files", strings.Join(old.Files, ","), strings.Join(f.Files, ",")},
+		} {
+			if fld.old != fld.new {
+				d.Changes = append(d.Changes, Change{Kind: ChangeModified, Element: ElementFeature, ID: f.ID, Field: fld.name, Old: fld.old, New: fld.new, Source: f.Source})
+			}
+		}
+		d.diffRequirements(f.ID, old.Requirements, f.Requirements)
+	}
+
+	for _, f := range removed {
+		d.Changes = append(d.Changes, Change{Kind: ChangeRemoved, Element: ElementFeature, ID: f.ID, Old: f.Title, Source: f.Source})
+		for _, r := range f.Requirements {
+			d.Changes = append(d.Changes, Change{Kind: ChangeRemoved, Element: ElementRequirement, ID: r.ID, FeatureID: f.ID, Old: r.Title, Source: r.Source})
+		}
+	}
+}
+
+func (d *SpecDiff) diffRequirements(featureID string, base, current []Requirement) {
+	baseByID := make(map[string]Requirement, len(base))
+	for _, r := range base {
+		baseByID[r.ID] = r
+	}
+	currentIDs := make(map[string]bool, len(current))
+	for _, r := range current {
+		currentIDs[r.ID] = true
+	}
+
+	removed := make([]Requirement, 0)
+	for _, r := range base {
+		if !currentIDs[r.ID] {
+			removed = append(removed, r)
+		}
+	}
+
+	for _, r := range current {
+		old, ok := baseByID[r.ID]
+		if !ok {
+			i := slices.IndexFunc(removed, func(o Requirement) bool { return sameContent(o.Title, o.Description, r.Title, r.Description) })
+			if i < 0 {
+				d.Changes = append(d.Changes, Change{Kind: ChangeAdded, Element: ElementRequirement, ID: r.ID, FeatureID: featureID, New: r.Title, Source: r.Source})
+				continue
+			}
+			old = removed[i]
+			removed = slices.Delete(removed, i, i+1)
+			d.Changes = append(d.Changes, Change{Kind: ChangeRenamed, Element: ElementRequirement, ID: r.ID, OldID: old.ID, FeatureID: featureID, Field: "id", Old: old.ID, New: r.ID, Source: r.Source})
+		}
+
+		if old.Title != r.Title {
+			d.Changes = append(d.Changes, Change{Kind: ChangeRenamed, Element: ElementRequirement, ID: r.ID, FeatureID: featureID, Field: "title", Old: old.Title, New: r.Title, Source: r.Source})
+		}
+		for _, f := range []struct{ name, old, new string }{
+			{"description", old.Description, r.Description},
+			{"priority", old.Priority, r.Priority},
+			{"estimate", old.Estimate, r.Estimate},
+			{"depends_on", strings.Join(old.DependsOn, ","), strings.Join(r.DependsOn, ",")},
+			{"files", strings.Join(old.Files, ","), strings.Join(r.Files, ",")},
+			{"acceptance_criteria", strings.Join(old.AcceptanceCriteria, "; "), strings.Join(r.AcceptanceCriteria, "; ")},
+		} {
+			if f.old != f.new {
+				d.Changes = append(d.Changes, Change{Kind: ChangeModified, Element: ElementRequirement, ID: r.ID, FeatureID: featureID, Field: f.name, Old: f.old, New: f.new, Source: r.Source})
+			}
+		}
+	}
+
+	for _, r := range removed {
+		d.Changes = append(d.Changes, Change{Kind: ChangeRemoved, Element: ElementRequirement, ID: r.ID, FeatureID: featureID, Old: r.Title, Source: r.Source})
+	}
+}
+
+func (d *SpecDiff) diffConstraints(base, current []Constraint) {
+	baseByID := make(map[string]Constraint, len(base))
+	for _, c := range base {
+		baseByID[c.ID] = c
+	}
+	currentIDs := make(map[string]bool, len(current))
+	for _, c := range current {
+		currentIDs[c.ID] = true
+		old, ok := baseByID[c.ID]
+		switch {
+		case !ok:
+			d.Changes = append(d.Changes, Change{Kind: ChangeAdded, Element: ElementConstraint, ID: c.ID, New: c.Description, Source: c.Source})
+		case old.Description != c.Description:
+			d.Changes = append(d.Changes, Change{Kind: ChangeModified, Element: ElementConstraint, ID: c.ID, Field: "description", Old: old.Description, New: c.Description, Source: c.Source})
+		}
+	}
+	for _, c := range base {
+		if !currentIDs[c.ID] {
+			d.Changes = append(d.Changes, Change{Kind: ChangeRemoved, Element: ElementConstraint, ID: c.ID, Old: c.Description, Source: c.Source})
+		}
+	}
+}
+
+// sameContent reports whether two elements carry the same non-empty intent.
+func sameContent(titleA, descA, titleB, descB string) bool {
+	return titleA != "" && titleA == titleB && descA == descB
+}
@@ -0,0 +1,96 @@
+package spec_test
+
+import (
+	"testing"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/spec"
+)
+
+func TestDiff_Identical(t *testing.T) {
+	s := &spec.ProductSpec{ID: "p", Title: "P", Features: []spec.Feature{{ID: "f1", Title: "F1"}}}
+	d := spec.Diff(s, s)
+	if !d.IsEmpty() {
+		t.Errorf("expected empty diff, got %+v", d.Changes)
+	}
+	if d.BaseHash != d.CurrentHash {
+		t.Error("expected equal hashes for identical specs")
+	}
+}
+
+func TestDiff_FeaturesAndRequirements(t *testing.T) {
+	base := &spec.ProductSpec{ID: "p", Version: "1.0", Features: []spec.Feature{
+		{ID: "f1", Title: "Auth", Requirements: []spec.Requirement{
+			{ID: "r1", Title: "Login", Priority: "medium"},
+			{ID: "r2", Title: "Logout"},
+		}},
+		{ID: "f2", Title: "Billing", Requirements: []spec.Requirement{{ID: "r3", Title: "Invoices"}}},
+	}}
+	current := &spec.ProductSpec{ID: "p", Version: "1.1", Features: []spec.Feature{
+		{ID: "f1", Title: "Authentication", Requirements: []spec.Requirement{
+			{ID: "r1", Title: "Login", Priority: "high"},
+			{ID: "r4", Title: "Password reset"},
+		}},
+		{ID: "f3", Title: "Reports"},
+	}}
+
+	d := spec.Diff(base, current)
+
+	want := []struct {
+		kind    spec.ChangeKind
+		element spec.ElementKind
+		id      string
+		field   string
+	}{
+		{spec.ChangeModified, spec.ElementSpec, "p", "version"},
+		{spec.ChangeRenamed, spec.ElementFeature, "f1", "title"},
+		{spec.ChangeModified, spec.ElementRequirement, "r1", "priority"},
+		{spec.ChangeAdded, spec.ElementRequirement, "r4", ""},
+		{spec.ChangeRemoved, spec.ElementRequirement, "r2", ""},
+		{spec.ChangeAdded, spec.ElementFeature, "f3", ""},
+		{spec.ChangeRemoved, spec.ElementFeature, "f2", ""},
+		{spec.ChangeRemoved, spec.ElementRequirement, "r3", ""},
+	}
+	if len(d.Changes) != len(want) {
+		t.Fatalf("expected %d changes, got %d: %+v", len(want), len(d.Changes), d.Changes)
+	}
+	for i, w := range want {
+		c := d.Changes[i]
+		if c.Kind != w.kind || c.Element != w.element || c.ID != w.id || c.Field != w.field {
+			t.Errorf("change %d: got %s %s %s %q, want %s %s %s %q", i, c.Kind, c.Element, c.ID, c.Field, w.kind, w.element, w.id, w.field)
+		}
+	}
+	if d.Changes[4].FeatureID != "f1" {
+		t.Errorf("expected removed requirement to carry feature f1, got %q", d.Changes[4].FeatureID)
+	}
+}
+
+func TestDiff_ReidentifiedRequirement(t *testing.T) {
+	base := &spec.ProductSpec{Features: []spec.Feature{
+		{ID: "f1", Requirements: []spec.Requirement{{ID: "r-old", Title: "Login", Description: "Email"}}},
+	}}
+	current := &spec.ProductSpec{Features: []spec.Feature{
+		{ID: "f1", Requirements: []spec.Requirement{{ID: "r-new", Title: "Login", Description: "Email"}}},
+	}}
+
+	d := spec.Diff(base, current)
+	if len(d.Changes) != 1 {
+		t.Fatalf("expected a single rename, got %+v", d.Changes)
+	}
+	c := d.Changes[0]
+	if c.Kind != spec.ChangeRenamed || c.OldID != "r-old" || c.ID != "r-new" {
+		t.Errorf("unexpected change: %+v", c)
+	}
+}
+
+func TestDiff_Constraints(t *testing.T) {
+	base := &spec.ProductSpec{Constraints: []spec.Constraint{{ID: "c1", Description: "Go"}, {ID: "c2", Description: "Postgres"}}}
+	current := &spec.ProductSpec{Constraints: []spec.Constraint{{ID: "c1", Description: "Go 1.22"}, {ID: "c3", Description: "No CGO"}}}
+
+	d := spec.Diff(base, current)
+	if len(d.Changes) != 3 {
+		t.Fatalf("expected 3 changes, got %+v", d.Changes)
+	}
+	if d.Changes[0].Kind != spec.ChangeModified || d.Changes[1].Kind != spec.ChangeAdded || d.Changes[2].Kind != spec.ChangeRemoved {
+		t.Errorf("unexpected constraint changes: %+v", d.Changes)
+	}
+}
@@ -0,0 +1,19 @@
+package spec
+
+import "io"
+
+// Importer converts a source document into a ProductSpec fragment. Spec
+// analysis picks the first importer whose Match accepts a file's path, so
+// importers should select on extension and location, peeking at content
+// only where the extension is ambiguous (YAML, JSON).
+// Import returns an error when the document is not in the importer's format;
+// directory analysis skips such files.
+type Importer interface {
+	// Name identifies the importer in messages, e.g. "openapi".
+	Name() string
+	// Match reports whether the importer handles the file at path.
+	Match(path string) bool
+	// Import parses r. doc is recorded as the Source of every feature,
+	// requirement and constraint.
+	Import(r io.Reader, doc string) (*ProductSpec, error)
+}
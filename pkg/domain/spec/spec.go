@@ -61,6 +61,7 @@ type Feature struct {
 	Description  string        `json:"description" yaml:"description"`
 	Requirements []Requirement `json:"requirements" yaml:"requirements"`
 	Source       Source        `json:"source,omitempty" yaml:"source,omitempty"`
+	Files        []string      `json:"files,omitempty" yaml:"files,omitempty"` // Globs of the code owned by the feature
 }
 
 // Requirement represents a granular condition that a feature must satisfy.
@@ -72,12 +73,16 @@ type Requirement struct {
 	Estimate    string   `json:"estimate" yaml:"estimate"`
 	DependsOn   []string `json:"depends_on" yaml:"depends_on"`
 	Source      Source   `json:"source,omitempty" yaml:"source,omitempty"`
+	Files       []string `json:"files,omitempty" yaml:"files,omitempty"` // Globs of the code owned by the requirement
+
+	AcceptanceCriteria []string `json:"acceptance_criteria,omitempty" yaml:"acceptance_criteria,omitempty"`
 }
 
 // Constraint represents non-functional requirements or policies.
 type Constraint struct {
 	ID          string `json:"id" yaml:"id"`
 	Description string `json:"description" yaml:"description"`
+	Source      Source `json:"source,omitempty" yaml:"source,omitempty"`
 }
 
 // Hash returns a deterministic hash of the spec for drift detection.
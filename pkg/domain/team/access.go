@@ -0,0 +1,104 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrForbidden is returned when a member's role lacks the permission an
+	// operation needs.
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrInvalidToken is returned when an API token matches no team member.
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// Authorize checks that actor holds the permission. Once the team has
+// members, actors that are not on it are refused; without a team every
+// actor may act.
+func (t *TeamConfig) Authorize(actor string, p Permission) error {
+	if len(t.Members) == 0 {
+		return nil
+	}
+	m := t.FindMember(actor)
+	if m == nil {
+		return fmt.Errorf("%w: %q is not on the team", ErrForbidden, actor)
+	}
+	return m.Authorize(p)
+}
+
+// Authorize checks that the member's role holds the permission.
+func (m Member) Authorize(p Permission) error {
+	if m.Role.Allows(p) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s has role %s and cannot %s", ErrForbidden, m.Name, m.Role, permissionVerb(p))
+}
+
+func permissionVerb(p Permission) string {
+	switch p {
+	case PermissionTransitionTasks:
+		return "transition tasks"
+	case PermissionEditPlan:
+		return "edit the plan"
+	case PermissionManageTeam:
+		return "manage the team"
+	}
+	return string(p)
+}
+
+// AuthorizeCaller checks the permission against the role of the member ctx
+// carries. Requests without an authenticated caller come from the local
+// user, like the CLI, and are not subject to roles.
+func AuthorizeCaller(ctx context.Context, p Permission) error {
+	m, ok := CallerFrom(ctx)
+	if !ok {
+		return nil
+	}
+	return m.Authorize(p)
+}
+
+type (
+	callerKey struct{}
+	actorKey  struct{}
+)
+
+// WithCaller returns a context carrying the authenticated member a request
+// acts as.
+func WithCaller(ctx context.Context, m Member) context.Context {
+	return context.WithValue(ctx, callerKey{}, m)
+}
+
+// CallerFrom returns the member carried by ctx, if any.
+func CallerFrom(ctx context.Context) (Member, bool) {
+	if ctx == nil {
+		return Member{}, false
+	}
+	m, ok := ctx.Value(callerKey{}).(Member)
+	return m, ok
+}
+
+// WithActor returns a context naming who a request is recorded as made by
+// when no member is authenticated. Unlike WithCaller it grants nothing.
+func WithActor(ctx context.Context, name string) context.Context {
+	if name == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, actorKey{}, name)
+}
+
+// ActorFrom returns the name of the member carried by ctx, else the actor
+// named with WithActor, else fallback.
+func ActorFrom(ctx context.Context, fallback string) string {
+	if m, ok := CallerFrom(ctx); ok {
+		return m.Name
+	}
+	if ctx != nil {
+		if name, ok := ctx.Value(actorKey{}).(string); ok {
+			return name
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,84 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestTeamConfig_Authorize(t *testing.T) {
+	cfg := &TeamConfig{Members: []Member{
+		{Name: "ada", Role: RoleAdmin},
+		{Name: "mo", Role: RoleMember},
+		{Name: "vi", Role: RoleViewer},
+	}}
+
+	tests := []struct {
+		actor   string
+		perm    Permission
+		allowed bool
+	}{
+		{"ada", PermissionManageTeam, true},
+		{"mo", PermissionEditPlan, true},
+		{"mo", PermissionManageTeam, false},
+		{"vi", PermissionTransitionTasks, false},
+		{"vi", PermissionEditPlan, false},
+		{"cli", PermissionManageTeam, false},
+		{"ai-agent", PermissionTransitionTasks, false},
+		{"", PermissionEditPlan, false},
+	}
+	for _, tt := range tests {
+		err := cfg.Authorize(tt.actor, tt.perm)
+		if tt.allowed && err != nil {
+			t.Errorf("Authorize(%q, %s) = %v, want nil", tt.actor, tt.perm, err)
+		}
+		if !tt.allowed && !errors.Is(err, ErrForbidden) {
+			t.Errorf("Authorize(%q, %s) = %v, want ErrForbidden", tt.actor, tt.perm, err)
+		}
+	}
+}
+
+func TestTeamConfig_AuthorizeWithoutTeam(t *testing.T) {
+	if err := (&TeamConfig{}).Authorize("cli", PermissionManageTeam); err != nil {
+		t.Errorf("Authorize without members = %v, want nil", err)
+	}
+}
+
+func TestAuthorizeCaller(t *testing.T) {
+	if err := AuthorizeCaller(context.Background(), PermissionManageTeam); err != nil {
+		t.Errorf("local request = %v, want nil", err)
+	}
+	viewer := WithCaller(context.Background(), Member{Name: "vi", Role: RoleViewer})
+	if err := AuthorizeCaller(viewer, PermissionTransitionTasks); !errors.Is(err, ErrForbidden) {
+		t.Errorf("viewer = %v, want ErrForbidden", err)
+	}
+	// Naming an admin as actor does not lift the caller's role.
+	labelled := WithActor(viewer, "ada")
+	if err := AuthorizeCaller(labelled, PermissionTransitionTasks); !errors.Is(err, ErrForbidden) {
+		t.Errorf("viewer with actor label = %v, want ErrForbidden", err)
+	}
+}
+
+func TestCallerContext(t *testing.T) {
+	if got := ActorFrom(context.Background(), "dashboard"); got != "dashboard" {
+		t.Errorf("ActorFrom without caller = %q, want fallback", got)
+	}
+	ctx := WithCaller(context.Background(), Member{Name: "alice", Role: RoleMember})
+	m, ok := CallerFrom(ctx)
+	if !ok || m.Name != "alice" || m.Role != RoleMember {
+		t.Errorf("CallerFrom = %+v, %v", m, ok)
+	}
+	if got := ActorFrom(ctx, "dashboard"); got != "alice" {
+		t.Errorf("ActorFrom = %q, want alice", got)
+	}
+	if got := ActorFrom(WithActor(context.Background(), "ai-agent"), "dashboard"); got != "ai-agent" {
+		t.Errorf("ActorFrom with actor = %q, want ai-agent", got)
+	}
+	if got := ActorFrom(WithActor(ctx, "ai-agent"), "dashboard"); got != "alice" {
+		t.Errorf("ActorFrom with caller and actor = %q, want alice", got)
+	}
+	//nolint:staticcheck // nil context is tolerated on purpose
+	if _, ok := CallerFrom(nil); ok {
+		t.Error("nil context should carry no caller")
+	}
+}
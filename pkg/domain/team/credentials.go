@@ -0,0 +1,102 @@
+package team
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// tokenPrefix marks roady member API tokens so they are easy to spot in
+// logs and secret scanners.
+const tokenPrefix = "rdy_"
+
+// tokenHashPrefix marks the hash algorithm of Credential.Hash.
+const tokenHashPrefix = "sha256:"
+
+// Credential is an API token issued to a team member. Only the token's hash
+// is stored; the token itself is shown once when it is issued.
+type Credential struct {
+	Member    string    `yaml:"member" json:"member"`
+	Label     string    `yaml:"label,omitempty" json:"label,omitempty"`
+	Hash      string    `yaml:"hash" json:"-"`
+	CreatedAt time.Time `yaml:"created_at" json:"created_at"`
+}
+
+// Credentials holds the API tokens of the team. It is kept apart from
+// team.yaml so issuing and revoking tokens does not rewrite the roster; as
+// only hashes are stored, it can be shared like the rest of .roady/.
+type Credentials struct {
+	Tokens []Credential `yaml:"tokens" json:"tokens"`
+}
+
+// GenerateToken returns a new random member API token.
+func GenerateToken() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return tokenPrefix + base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// HashToken returns the stored form of a token.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return tokenHashPrefix + hex.EncodeToString(sum[:])
+}
+
+// Issue generates a token for member, records its hash under label and
+// returns the token. Issuing again with the same label replaces the old
+// token.
+func (c *Credentials) Issue(member, label string, now time.Time) (string, error) {
+	if member == "" {
+		return "", fmt.Errorf("member name cannot be empty")
+	}
+	token, err := GenerateToken()
+	if err != nil {
+		return "", err
+	}
+	c.Revoke(member, label)
+	c.Tokens = append(c.Tokens, Credential{
+		Member:    member,
+		Label:     label,
+		Hash:      HashToken(token),
+		CreatedAt: now,
+	})
+	return token, nil
+}
+
+// Revoke removes member's token with the given label, or all of member's
+// tokens when label is empty, and returns how many were removed.
+func (c *Credentials) Revoke(member, label string) int {
+	kept := c.Tokens[:0]
+	removed := 0
+	for _, cred := range c.Tokens {
+		if cred.Member == member && (label == "" || cred.Label == label) {
+			removed++
+			continue
+		}
+		kept = append(kept, cred)
+	}
+	c.Tokens = kept
+	return removed
+}
+
+// Lookup returns the credential the token was issued as, or nil. Hashes are
+// compared in constant time.
+func (c *Credentials) Lookup(token string) *Credential {
+	if token == "" {
+		return nil
+	}
+	hash := []byte(HashToken(token))
+	var found *Credential
+	for i := range c.Tokens {
+		if subtle.ConstantTimeCompare(hash, []byte(c.Tokens[i].Hash)) == 1 {
+			found = &c.Tokens[i]
+		}
+	}
+	return found
+}
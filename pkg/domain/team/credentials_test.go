@@ -0,0 +1,74 @@
+package team
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCredentials_IssueAndLookup(t *testing.T) {
+	var c Credentials
+	token, err := c.Issue("alice", "laptop", time.Now())
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if !strings.HasPrefix(token, tokenPrefix) {
+		t.Errorf("token %q lacks prefix %q", token, tokenPrefix)
+	}
+	if len(c.Tokens) != 1 || c.Tokens[0].Hash == token || !strings.HasPrefix(c.Tokens[0].Hash, tokenHashPrefix) {
+		t.Fatalf("expected one hashed credential, got %+v", c.Tokens)
+	}
+
+	cred := c.Lookup(token)
+	if cred == nil || cred.Member != "alice" || cred.Label != "laptop" {
+		t.Fatalf("Lookup = %+v, want alice/laptop", cred)
+	}
+	if c.Lookup("rdy_wrong") != nil {
+		t.Error("unknown token should not match")
+	}
+	if c.Lookup("") != nil {
+		t.Error("empty token should not match")
+	}
+}
+
+func TestCredentials_IssueReplacesLabel(t *testing.T) {
+	var c Credentials
+	first, _ := c.Issue("alice", "ci", time.Now())
+	second, _ := c.Issue("alice", "ci", time.Now())
+	if len(c.Tokens) != 1 {
+		t.Fatalf("expected reissue to replace the token, got %d tokens", len(c.Tokens))
+	}
+	if c.Lookup(first) != nil {
+		t.Error("replaced token should no longer match")
+	}
+	if c.Lookup(second) == nil {
+		t.Error("new token should match")
+	}
+}
+
+func TestCredentials_IssueEmptyMember(t *testing.T) {
+	var c Credentials
+	if _, err := c.Issue("", "x", time.Now()); err == nil {
+		t.Error("expected error for empty member")
+	}
+}
+
+func TestCredentials_Revoke(t *testing.T) {
+	var c Credentials
+	_, _ = c.Issue("alice", "a", time.Now())
+	_, _ = c.Issue("alice", "b", time.Now())
+	bob, _ := c.Issue("bob", "a", time.Now())
+
+	if n := c.Revoke("alice", "a"); n != 1 {
+		t.Errorf("Revoke(alice, a) = %d, want 1", n)
+	}
+	if n := c.Revoke("alice", ""); n != 1 {
+		t.Errorf("Revoke(alice, \"\") = %d, want 1", n)
+	}
+	if n := c.Revoke("alice", ""); n != 0 {
+		t.Errorf("second revoke = %d, want 0", n)
+	}
+	if c.Lookup(bob) == nil {
+		t.Error("bob's token should survive revoking alice's")
+	}
+}
@@ -1,6 +1,11 @@
 package team
 
-import "fmt"
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
 
 // Role defines the access level of a team member.
 type Role string
@@ -40,10 +45,56 @@ func (r Role) CanManageTeam() bool {
 	return r == RoleAdmin
 }
 
+// Permission names an operation guarded by a role.
+type Permission string
+
+const (
+	PermissionTransitionTasks Permission = "transition_tasks"
+	PermissionEditPlan        Permission = "edit_plan"
+	PermissionManageTeam      Permission = "manage_team"
+)
+
+// Allows reports whether the role grants the permission.
+func (r Role) Allows(p Permission) bool {
+	switch p {
+	case PermissionTransitionTasks:
+		return r.CanTransitionTasks()
+	case PermissionEditPlan:
+		return r.CanEditPlan()
+	case PermissionManageTeam:
+		return r.CanManageTeam()
+	}
+	return false
+}
+
 // Member represents a team member with a role.
 type Member struct {
 	Name string `yaml:"name" json:"name"`
 	Role Role   `yaml:"role" json:"role"`
+	// PublicKey verifies the audit events this member or agent signs, in
+	// the form "ed25519:<base64>".
+	PublicKey string `yaml:"public_key,omitempty" json:"public_key,omitempty"`
+}
+
+// publicKeyPrefix marks the key algorithm of Member.PublicKey.
+const publicKeyPrefix = "ed25519:"
+
+// EncodePublicKey returns the team.yaml form of an ed25519 public key.
+func EncodePublicKey(pub ed25519.PublicKey) string {
+	return publicKeyPrefix + base64.StdEncoding.EncodeToString(pub)
+}
+
+// ParsePublicKey parses a public key in the form "ed25519:<base64>".
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	encoded, ok := strings.CutPrefix(s, publicKeyPrefix)
+	if !ok {
+		return nil, fmt.Errorf("public key must start with %q", publicKeyPrefix)
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid ed25519 public key")
+	}
+	return ed25519.PublicKey(raw), nil
 }
 
 // TeamConfig holds the team configuration stored in .roady/team.yaml.
@@ -79,6 +130,35 @@ func (t *TeamConfig) AddMember(name string, role Role) error {
 	return nil
 }
 
+// SetPublicKey registers the signing key of an existing member.
+func (t *TeamConfig) SetPublicKey(name, publicKey string) error {
+	if _, err := ParsePublicKey(publicKey); err != nil {
+		return err
+	}
+	m := t.FindMember(name)
+	if m == nil {
+		return fmt.Errorf("member not found: %s", name)
+	}
+	m.PublicKey = publicKey
+	return nil
+}
+
+// PublicKeys returns the registered signing keys by member name.
+func (t *TeamConfig) PublicKeys() (map[string]ed25519.PublicKey, error) {
+	keys := make(map[string]ed25519.PublicKey)
+	for _, m := range t.Members {
+		if m.PublicKey == "" {
+			continue
+		}
+		pub, err := ParsePublicKey(m.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("public key of %s: %w", m.Name, err)
+		}
+		keys[m.Name] = pub
+	}
+	return keys, nil
+}
+
 // RemoveMember removes a member by name. Returns error if not found.
 func (t *TeamConfig) RemoveMember(name string) error {
 	for i := range t.Members {
@@ -1,6 +1,9 @@
 package team
 
-import "testing"
+import (
+	"crypto/ed25519"
+	"testing"
+)
 
 func TestRole_IsValid(t *testing.T) {
 	tests := []struct {
@@ -123,3 +126,30 @@ func TestTeamConfig_FindMember(t *testing.T) {
 		t.Error("expected nil for unknown member")
 	}
 }
+
+func TestTeamConfig_PublicKeys(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &TeamConfig{Members: []Member{{Name: "alice", Role: RoleAdmin}, {Name: "bob", Role: RoleMember}}}
+	if err := cfg.SetPublicKey("alice", EncodePublicKey(pub)); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.SetPublicKey("carol", EncodePublicKey(pub)); err == nil {
+		t.Error("expected an unknown member to be rejected")
+	}
+	if err := cfg.SetPublicKey("bob", "rsa:AAAA"); err == nil {
+		t.Error("expected a non-ed25519 key to be rejected")
+	}
+
+	keys, err := cfg.PublicKeys()
+	if err != nil || len(keys) != 1 || !keys["alice"].Equal(pub) {
+		t.Errorf("PublicKeys = %v, %v", keys, err)
+	}
+
+	cfg.Members[1].PublicKey = "ed25519:bm90IGEga2V5"
+	if _, err := cfg.PublicKeys(); err == nil {
+		t.Error("expected a malformed key in team.yaml to be reported")
+	}
+}
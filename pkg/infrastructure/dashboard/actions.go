@@ -2,7 +2,10 @@ package dashboard
 
 import (
 	"context"
+	"errors"
 	"net/http"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
 )
 
 // TaskActions exposes the subset of TaskService methods the Kanban board
@@ -15,6 +18,7 @@ type TaskActions interface {
 	BlockTask(ctx context.Context, taskID, reason string) error
 	UnblockTask(ctx context.Context, taskID string) error
 	ReopenTask(ctx context.Context, taskID string) error
+	AssignTask(ctx context.Context, taskID, assignee string) error
 }
 
 // EnableTaskActions wires POST handlers that mutate task state from the
@@ -38,8 +42,14 @@ func (s *Server) EnableOrgTaskActions(resolver OrgTaskActions) {
 // pickActions returns the TaskActions to use for the current request. If the
 // request carries a project_path or project form field and an OrgTaskActions
 // resolver is wired, the per-project actions are returned. Falls back to
-// s.taskActions otherwise.
+// s.taskActions otherwise. An authenticated caller whose role cannot
+// transition tasks gets team.ErrForbidden.
 func (s *Server) pickActions(r *http.Request) (TaskActions, error) {
+	if m, ok := team.CallerFrom(r.Context()); ok {
+		if err := m.Authorize(team.PermissionTransitionTasks); err != nil {
+			return nil, err
+		}
+	}
 	path := r.PostForm.Get("project_path")
 	proj := r.PostForm.Get("project")
 	if (path != "" || proj != "") && s.orgTaskActions != nil {
@@ -65,7 +75,7 @@ func (s *Server) handleTaskStart(w http.ResponseWriter, r *http.Request) {
 	}
 	actions, err := s.pickActions(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), actionErrorStatus(err))
 		return
 	}
 	if actions == nil {
@@ -77,9 +87,9 @@ func (s *Server) handleTaskStart(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "missing task id", http.StatusBadRequest)
 		return
 	}
-	owner := strDefault(r.PostForm.Get("owner"), "dashboard")
+	owner := strDefault(r.PostForm.Get("owner"), team.ActorFrom(r.Context(), "dashboard"))
 	if err := actions.StartTask(r.Context(), id, owner, ""); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), actionErrorStatus(err))
 		return
 	}
 	s.broadcastChange()
@@ -93,7 +103,7 @@ func (s *Server) handleTaskComplete(w http.ResponseWriter, r *http.Request) {
 	}
 	actions, err := s.pickActions(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), actionErrorStatus(err))
 		return
 	}
 	if actions == nil {
@@ -107,7 +117,7 @@ func (s *Server) handleTaskComplete(w http.ResponseWriter, r *http.Request) {
 	}
 	evidence := strDefault(r.PostForm.Get("evidence"), "completed via dashboard")
 	if _, err := actions.CompleteTask(r.Context(), id, evidence); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), actionErrorStatus(err))
 		return
 	}
 	s.broadcastChange()
@@ -121,7 +131,7 @@ func (s *Server) handleTaskBlock(w http.ResponseWriter, r *http.Request) {
 	}
 	actions, err := s.pickActions(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), actionErrorStatus(err))
 		return
 	}
 	if actions == nil {
@@ -135,7 +145,7 @@ func (s *Server) handleTaskBlock(w http.ResponseWriter, r *http.Request) {
 	}
 	reason := strDefault(r.PostForm.Get("reason"), "blocked via dashboard")
 	if err := actions.BlockTask(r.Context(), id, reason); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), actionErrorStatus(err))
 		return
 	}
 	s.broadcastChange()
@@ -149,7 +159,7 @@ func (s *Server) handleTaskUnblock(w http.ResponseWriter, r *http.Request) {
 	}
 	actions, err := s.pickActions(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), actionErrorStatus(err))
 		return
 	}
 	if actions == nil {
@@ -162,7 +172,7 @@ func (s *Server) handleTaskUnblock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := actions.UnblockTask(r.Context(), id); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), actionErrorStatus(err))
 		return
 	}
 	s.broadcastChange()
@@ -176,7 +186,7 @@ func (s *Server) handleTaskReopen(w http.ResponseWriter, r *http.Request) {
 	}
 	actions, err := s.pickActions(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), actionErrorStatus(err))
 		return
 	}
 	if actions == nil {
@@ -189,13 +199,22 @@ func (s *Server) handleTaskReopen(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := actions.ReopenTask(r.Context(), id); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), actionErrorStatus(err))
 		return
 	}
 	s.broadcastChange()
 	redirectAfterAction(w, r)
 }
 
+// actionErrorStatus is 403 when the caller's role forbids the action and
+// 400 for any other failure.
+func actionErrorStatus(err error) int {
+	if errors.Is(err, team.ErrForbidden) {
+		return http.StatusForbidden
+	}
+	return http.StatusBadRequest
+}
+
 func strDefault(v, def string) string {
 	if v == "" {
 		return def
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
 )
 
 type fakeTaskActions struct {
@@ -19,12 +20,14 @@ type fakeTaskActions struct {
 	blockCalled    *blockArgs
 	unblockCalled  *unblockArgs
 	reopenCalled   *reopenArgs
+	assignCalled   *assignArgs
 
 	startErr    error
 	completeErr error
 	blockErr    error
 	unblockErr  error
 	reopenErr   error
+	assignErr   error
 }
 
 type startArgs struct{ id, owner, rateID string }
@@ -32,6 +35,7 @@ type completeArgs struct{ id, evidence string }
 type blockArgs struct{ id, reason string }
 type unblockArgs struct{ id string }
 type reopenArgs struct{ id string }
+type assignArgs struct{ id, owner string }
 
 func (f *fakeTaskActions) StartTask(ctx context.Context, id, owner, rateID string) error {
 	f.startCalled = &startArgs{id, owner, rateID}
@@ -53,6 +57,10 @@ func (f *fakeTaskActions) ReopenTask(ctx context.Context, id string) error {
 	f.reopenCalled = &reopenArgs{id}
 	return f.reopenErr
 }
+func (f *fakeTaskActions) AssignTask(ctx context.Context, id, owner string) error {
+	f.assignCalled = &assignArgs{id, owner}
+	return f.assignErr
+}
 
 func newActionsServer(t *testing.T, actions TaskActions) *Server {
 	t.Helper()
@@ -203,3 +211,26 @@ var _ = func() {
 
 // pretty-print helper for debug.
 var _ = fmt.Stringer(nil)
+
+func TestHandleTaskStart_OwnerFromCaller(t *testing.T) {
+	a := &fakeTaskActions{}
+	srv := newActionsServer(t, a)
+
+	req := httptest.NewRequest(http.MethodPost, "/actions/task/start", strings.NewReader(url.Values{"id": {"t-1"}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = req.WithContext(team.WithCaller(req.Context(), team.Member{Name: "alice", Role: team.RoleMember}))
+	srv.handleTaskStart(httptest.NewRecorder(), req)
+
+	if a.startCalled == nil || a.startCalled.owner != "alice" {
+		t.Errorf("StartTask call = %+v, want owner alice", a.startCalled)
+	}
+}
+
+func TestHandleTaskAction_ForbiddenIs403(t *testing.T) {
+	a := &fakeTaskActions{completeErr: fmt.Errorf("%w: vi has role viewer", team.ErrForbidden)}
+	srv := newActionsServer(t, a)
+	rec := postForm(t, srv.handleTaskComplete, "/actions/task/complete", url.Values{"id": {"t-1"}})
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want 403", rec.Code)
+	}
+}
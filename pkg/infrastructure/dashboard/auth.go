@@ -1,9 +1,12 @@
 package dashboard
 
 import (
+	"context"
 	"crypto/subtle"
 	"net/http"
 	"strings"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
 )
 
 // EnableAuthToken protects every dashboard request with a shared token. Pass
@@ -18,6 +21,19 @@ func (s *Server) EnableAuthToken(token string) {
 	s.authToken = token
 }
 
+// MemberAuthenticator resolves a team member's API token to the member.
+type MemberAuthenticator interface {
+	Authenticate(token string) (*team.Member, error)
+}
+
+// EnableMemberAuth accepts team members' API tokens on the same surfaces as
+// the shared token. A request authenticated with a member token carries the
+// member in its context, so task actions run with the member's role and are
+// recorded under their name. Pass nil to accept only the shared token.
+func (s *Server) EnableMemberAuth(auth MemberAuthenticator) {
+	s.memberAuth = auth
+}
+
 const authCookieName = "roady_token"
 
 // authMiddleware enforces the shared token. The handler short-circuits with
@@ -25,11 +41,31 @@ const authCookieName = "roady_token"
 // ?token=<v> handshake sets the cookie + redirects to strip the secret from
 // the URL bar.
 func authMiddleware(token string, next http.Handler) http.Handler {
+	return memberAuthMiddleware(token, nil, next)
+}
+
+// memberAuthMiddleware enforces the shared token or a member token. Requests
+// authenticated as a member reach next with the member attached via
+// team.WithCaller.
+func memberAuthMiddleware(token string, members MemberAuthenticator, next http.Handler) http.Handler {
 	tokenBytes := []byte(token)
+	// authenticate returns the request context to continue with, or nil
+	// when the credential is not accepted.
+	authenticate := func(r *http.Request, cred string) context.Context {
+		if token != "" && constantTimeEqual(cred, token, tokenBytes) {
+			return r.Context()
+		}
+		if members != nil {
+			if m, err := members.Authenticate(cred); err == nil && m != nil {
+				return team.WithCaller(r.Context(), *m)
+			}
+		}
+		return nil
+	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// 1. Query-param handshake (one-time): set cookie + redirect without param.
 		if q := r.URL.Query().Get("token"); q != "" {
-			if constantTimeEqual(q, token, tokenBytes) {
+			if authenticate(r, q) != nil {
 				http.SetCookie(w, &http.Cookie{
 					Name:     authCookieName,
 					Value:    q,
@@ -51,16 +87,16 @@ func authMiddleware(token string, next http.Handler) http.Handler {
 
 		// 2. Cookie.
 		if c, err := r.Cookie(authCookieName); err == nil {
-			if constantTimeEqual(c.Value, token, tokenBytes) {
-				next.ServeHTTP(w, r)
+			if ctx := authenticate(r, c.Value); ctx != nil {
+				next.ServeHTTP(w, r.WithContext(ctx))
 				return
 			}
 		}
 
 		// 3. Bearer.
 		if h := r.Header.Get("Authorization"); strings.HasPrefix(h, "Bearer ") {
-			if constantTimeEqual(strings.TrimPrefix(h, "Bearer "), token, tokenBytes) {
-				next.ServeHTTP(w, r)
+			if ctx := authenticate(r, strings.TrimPrefix(h, "Bearer ")); ctx != nil {
+				next.ServeHTTP(w, r.WithContext(ctx))
 				return
 			}
 		}
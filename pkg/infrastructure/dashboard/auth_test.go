@@ -0,0 +1,69 @@
+package dashboard
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
+)
+
+type fakeMemberAuth map[string]team.Member
+
+func (f fakeMemberAuth) Authenticate(token string) (*team.Member, error) {
+	m, ok := f[token]
+	if !ok {
+		return nil, team.ErrInvalidToken
+	}
+	return &m, nil
+}
+
+// callerEcho writes the name of the request's caller, or "-" without one.
+var callerEcho = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	_, _ = w.Write([]byte(team.ActorFrom(r.Context(), "-")))
+})
+
+func TestMemberAuthMiddleware_AttachesMember(t *testing.T) {
+	members := fakeMemberAuth{"rdy_alice": {Name: "alice", Role: team.RoleMember}}
+	h := memberAuthMiddleware("", members, callerEcho)
+
+	req := httptest.NewRequest(http.MethodGet, "/x", nil)
+	req.Header.Set("Authorization", "Bearer rdy_alice")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != 200 || rec.Body.String() != "alice" {
+		t.Fatalf("bearer: got %d %q, want 200 alice", rec.Code, rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/x", nil)
+	req.AddCookie(&http.Cookie{Name: authCookieName, Value: "rdy_alice"})
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != 200 || rec.Body.String() != "alice" {
+		t.Fatalf("cookie: got %d %q, want 200 alice", rec.Code, rec.Body.String())
+	}
+}
+
+func TestMemberAuthMiddleware_SharedTokenHasNoCaller(t *testing.T) {
+	members := fakeMemberAuth{"rdy_alice": {Name: "alice", Role: team.RoleMember}}
+	h := memberAuthMiddleware("secret", members, callerEcho)
+
+	req := httptest.NewRequest(http.MethodGet, "/x", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != 200 || rec.Body.String() != "-" {
+		t.Fatalf("got %d %q, want 200 without caller", rec.Code, rec.Body.String())
+	}
+}
+
+func TestMemberAuthMiddleware_RejectsUnknownToken(t *testing.T) {
+	h := memberAuthMiddleware("", fakeMemberAuth{}, callerEcho)
+	req := httptest.NewRequest(http.MethodGet, "/x", nil)
+	req.Header.Set("Authorization", "Bearer rdy_nobody")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("got %d, want 401", rec.Code)
+	}
+}
@@ -0,0 +1,96 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/felixgeelhaar/roady/pkg/application"
+	"github.com/felixgeelhaar/roady/pkg/domain/billing"
+)
+
+// billingSummary is the billing page's data and the /api/billing payload.
+// Budget is nil when the policy sets no budget_hours.
+type billingSummary struct {
+	Costs  *billing.CostReport   `json:"costs"`
+	Budget *billing.BudgetStatus `json:"budget,omitempty"`
+}
+
+// budgetGauge is the billing page's budget bar: Fill is the used share
+// capped at 100%, Level picks its colour.
+type budgetGauge struct {
+	Fill  float64
+	Level string
+}
+
+func newBudgetGauge(b *billing.BudgetStatus) *budgetGauge {
+	if b == nil {
+		return nil
+	}
+	g := &budgetGauge{Fill: min(max(b.PercentUsed, 0), 100), Level: "ok"}
+	switch {
+	case b.OverBudget || b.PercentUsed >= 100:
+		g.Level = "over"
+	case b.PercentUsed >= 80:
+		g.Level = "warn"
+	}
+	return g
+}
+
+// billingData is the template data of the billing page.
+type billingData struct {
+	reportPage
+	Summary *billingSummary
+	Gauge   *budgetGauge
+}
+
+func loadBillingSummary(p BillingProvider) (*billingSummary, error) {
+	costs, err := p.GetCostReport(application.CostReportOpts{})
+	if err != nil {
+		return nil, err
+	}
+	budget, err := p.GetBudgetStatus()
+	if err != nil {
+		return nil, err
+	}
+	return &billingSummary{Costs: costs, Budget: budget}, nil
+}
+
+func (s *Server) handleBilling(w http.ResponseWriter, r *http.Request) {
+	data := billingData{reportPage: s.newReportPage(r, "Billing")}
+	in, err := s.insightsFor(r)
+	switch {
+	case err != nil:
+		data.Error = err.Error()
+	case in.Billing == nil:
+		data.Error = "Billing is not enabled for this dashboard."
+	default:
+		summary, err := loadBillingSummary(in.Billing)
+		if err != nil {
+			data.Error = err.Error()
+			break
+		}
+		data.Summary = summary
+		data.Gauge = newBudgetGauge(summary.Budget)
+	}
+	s.render(w, "billing.html", data)
+}
+
+func (s *Server) handleAPIBilling(w http.ResponseWriter, r *http.Request) {
+	in, err := s.insightsFor(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if in.Billing == nil {
+		http.Error(w, "billing not enabled", http.StatusNotFound)
+		return
+	}
+	summary, err := loadBillingSummary(in.Billing)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(summary)
+}
@@ -0,0 +1,138 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/debt"
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+)
+
+const (
+	// debtTopDebtors is how many components the debt page ranks.
+	debtTopDebtors = 10
+	// debtTrendDays is the window of the debt page's trend and chart.
+	debtTrendDays = 30
+)
+
+// debtSummary is the debt page's data and the /api/debt payload.
+type debtSummary struct {
+	Health     string                 `json:"health"`
+	Report     *debt.DebtReport       `json:"report"`
+	TopDebtors []*debt.DebtScore      `json:"top_debtors"`
+	Trend      events.DriftTrend      `json:"trend"`
+	History    []events.DriftSnapshot `json:"history"`
+}
+
+// trendBar is one day of the debt trend chart; Height is a percentage of
+// the busiest day.
+type trendBar struct {
+	Day    string
+	Issues int
+	Height float64
+}
+
+// debtData is the template data of the debt page.
+type debtData struct {
+	reportPage
+	Summary      *debtSummary
+	Bars         []trendBar
+	TrendPercent float64
+}
+
+func loadDebtSummary(r *http.Request, p DebtProvider) (*debtSummary, error) {
+	report, err := p.GetDebtReport(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	trend, err := p.GetDriftTrend(debtTrendDays)
+	if err != nil {
+		return nil, err
+	}
+	history, err := p.GetDriftHistory(debtTrendDays)
+	if err != nil {
+		return nil, err
+	}
+	return &debtSummary{
+		Health:     report.GetHealthLevel(),
+		Report:     report,
+		TopDebtors: report.GetTopDebtors(debtTopDebtors),
+		Trend:      trend,
+		History:    history,
+	}, nil
+}
+
+// buildTrendBars sums the detected issues per day over the last days days,
+// oldest first.
+func buildTrendBars(history []events.DriftSnapshot, now time.Time, days int) []trendBar {
+	midnight := func(t time.Time) time.Time {
+		t = t.In(now.Location())
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, now.Location())
+	}
+	start := midnight(now).AddDate(0, 0, 1-days)
+	bars := make([]trendBar, days)
+	for i := range bars {
+		bars[i].Day = start.AddDate(0, 0, i).Format("Jan 2")
+	}
+	for _, snap := range history {
+		// Round so days that are 23 or 25 hours long still land right.
+		day := int(math.Round(midnight(snap.Timestamp).Sub(start).Hours() / 24))
+		if day >= 0 && day < days {
+			bars[day].Issues += snap.IssueCount
+		}
+	}
+
+	peak := 0
+	for _, b := range bars {
+		peak = max(peak, b.Issues)
+	}
+	if peak > 0 {
+		for i := range bars {
+			bars[i].Height = float64(bars[i].Issues) / float64(peak) * 100
+		}
+	}
+	return bars
+}
+
+func (s *Server) handleDebt(w http.ResponseWriter, r *http.Request) {
+	data := debtData{reportPage: s.newReportPage(r, "Debt")}
+	in, err := s.insightsFor(r)
+	switch {
+	case err != nil:
+		data.Error = err.Error()
+	case in.Debt == nil:
+		data.Error = "Debt analysis is not enabled for this dashboard."
+	default:
+		summary, err := loadDebtSummary(r, in.Debt)
+		if err != nil {
+			data.Error = err.Error()
+			break
+		}
+		data.Summary = summary
+		data.Bars = buildTrendBars(summary.History, time.Now(), debtTrendDays)
+		data.TrendPercent = summary.Trend.Change * 100
+	}
+	s.render(w, "debt.html", data)
+}
+
+func (s *Server) handleAPIDebt(w http.ResponseWriter, r *http.Request) {
+	in, err := s.insightsFor(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if in.Debt == nil {
+		http.Error(w, "debt analysis not enabled", http.StatusNotFound)
+		return
+	}
+	summary, err := loadDebtSummary(r, in.Debt)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(summary)
+}
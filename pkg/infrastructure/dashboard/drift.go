@@ -0,0 +1,98 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/drift"
+)
+
+// severityOrder ranks drift severities for display, most severe first.
+var severityOrder = map[drift.Severity]int{
+	drift.SeverityCritical: 0,
+	drift.SeverityHigh:     1,
+	drift.SeverityMedium:   2,
+	drift.SeverityLow:      3,
+}
+
+// severityCount is one cell of the drift page's summary strip.
+type severityCount struct {
+	Severity drift.Severity
+	Count    int
+}
+
+// driftData is the template data of the drift page.
+type driftData struct {
+	reportPage
+	Report     *drift.Report
+	Issues     []drift.Issue
+	Severities []severityCount
+}
+
+// sortedIssues returns the report's issues, most severe first, grouped by
+// component within a severity.
+func sortedIssues(report *drift.Report) []drift.Issue {
+	issues := append([]drift.Issue(nil), report.Issues...)
+	sort.SliceStable(issues, func(i, j int) bool {
+		si, sj := severityOrder[issues[i].Severity], severityOrder[issues[j].Severity]
+		if si != sj {
+			return si < sj
+		}
+		return issues[i].ComponentID < issues[j].ComponentID
+	})
+	return issues
+}
+
+func countSeverities(issues []drift.Issue) []severityCount {
+	counts := map[drift.Severity]int{}
+	for _, iss := range issues {
+		counts[iss.Severity]++
+	}
+	var out []severityCount
+	for _, sev := range []drift.Severity{drift.SeverityCritical, drift.SeverityHigh, drift.SeverityMedium, drift.SeverityLow} {
+		out = append(out, severityCount{Severity: sev, Count: counts[sev]})
+	}
+	return out
+}
+
+func (s *Server) handleDrift(w http.ResponseWriter, r *http.Request) {
+	data := driftData{reportPage: s.newReportPage(r, "Drift")}
+	in, err := s.insightsFor(r)
+	switch {
+	case err != nil:
+		data.Error = err.Error()
+	case in.Drift == nil:
+		data.Error = "Drift detection is not enabled for this dashboard."
+	default:
+		report, err := in.Drift.DetectDrift(r.Context())
+		if err != nil {
+			data.Error = err.Error()
+			break
+		}
+		data.Report = report
+		data.Issues = sortedIssues(report)
+		data.Severities = countSeverities(report.Issues)
+	}
+	s.render(w, "drift.html", data)
+}
+
+func (s *Server) handleAPIDrift(w http.ResponseWriter, r *http.Request) {
+	in, err := s.insightsFor(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if in.Drift == nil {
+		http.Error(w, "drift detection not enabled", http.StatusNotFound)
+		return
+	}
+	report, err := in.Drift.DetectDrift(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,246 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/felixgeelhaar/roady/pkg/application"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/project"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
+)
+
+// PlanEditor exposes the TaskService methods the Kanban board needs to create
+// and edit tasks, so tasks are validated as from the CLI and sync: the
+// dependency graph is checked and the plan returns to pending approval like
+// any other update.
+type PlanEditor interface {
+	AddTask(ctx context.Context, task planning.Task, actor string) error
+	EditTask(ctx context.Context, taskID, actor string, edit application.TaskEdit) error
+}
+
+// EnablePlanEditing wires the POST handlers that create and edit tasks from
+// the Kanban board. Pass nil to keep the plan read-only.
+func (s *Server) EnablePlanEditing(editor PlanEditor) {
+	s.planEditor = editor
+}
+
+// OrgPlanEditor resolves a PlanEditor for a (project_path, project) pair so
+// tasks can be created and edited on sub-projects.
+type OrgPlanEditor interface {
+	ResolvePlanEditor(projectPath, project string) (PlanEditor, error)
+}
+
+// EnableOrgPlanEditing routes create and edit forms that carry project_path
+// or project to that project's plan. Without it such forms are refused.
+func (s *Server) EnableOrgPlanEditing(resolver OrgPlanEditor) {
+	s.orgPlanEditor = resolver
+}
+
+// TeamRoster lists the members of team.yaml, offered as task owners.
+type TeamRoster interface {
+	ListMembers() (*team.TeamConfig, error)
+}
+
+// EnableTeamRoster restricts Kanban owner assignment to the team's members.
+// Without a roster any owner name is accepted.
+func (s *Server) EnableTeamRoster(roster TeamRoster) {
+	s.roster = roster
+}
+
+// pickEditor returns the PlanEditor for the current request, resolving the
+// project_path and project form fields like pickActions. An authenticated
+// caller whose role cannot edit the plan gets team.ErrForbidden.
+func (s *Server) pickEditor(r *http.Request) (PlanEditor, error) {
+	if m, ok := team.CallerFrom(r.Context()); ok {
+		if err := m.Authorize(team.PermissionEditPlan); err != nil {
+			return nil, err
+		}
+	}
+	path := r.PostForm.Get("project_path")
+	proj := r.PostForm.Get("project")
+	if path != "" || proj != "" {
+		if s.orgPlanEditor == nil {
+			return nil, fmt.Errorf("editing other projects' plans is not enabled")
+		}
+		return s.orgPlanEditor.ResolvePlanEditor(path, proj)
+	}
+	return s.planEditor, nil
+}
+
+// ownerNames returns the names on the team roster, or nil without one.
+func (s *Server) ownerNames() []string {
+	if s.roster == nil {
+		return nil
+	}
+	cfg, err := s.roster.ListMembers()
+	if err != nil || cfg == nil {
+		return nil
+	}
+	names := make([]string, 0, len(cfg.Members))
+	for _, m := range cfg.Members {
+		names = append(names, m.Name)
+	}
+	return names
+}
+
+func (s *Server) handleTaskCreate(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	editor, err := s.pickEditor(r)
+	if err != nil {
+		http.Error(w, err.Error(), actionErrorStatus(err))
+		return
+	}
+	if editor == nil {
+		http.Error(w, "plan editing not enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	edit := taskEditFromForm(r)
+	task := planning.Task{
+		ID:        strings.TrimSpace(r.PostForm.Get("id")),
+		FeatureID: strings.TrimSpace(r.PostForm.Get("feature_id")),
+		Origin:    planning.OriginHuman,
+	}
+	if edit.Title != nil {
+		task.Title = *edit.Title
+	}
+	if edit.Description != nil {
+		task.Description = *edit.Description
+	}
+	if edit.Priority != nil {
+		task.Priority = *edit.Priority
+	}
+	if edit.Estimate != nil {
+		task.Estimate = *edit.Estimate
+	}
+	if edit.DependsOn != nil {
+		task.DependsOn = *edit.DependsOn
+	}
+
+	if err := editor.AddTask(r.Context(), task, "dashboard"); err != nil {
+		http.Error(w, err.Error(), editorErrorStatus(err))
+		return
+	}
+	s.broadcastChange()
+	redirectAfterAction(w, r)
+}
+
+func (s *Server) handleTaskEdit(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	editor, err := s.pickEditor(r)
+	if err != nil {
+		http.Error(w, err.Error(), actionErrorStatus(err))
+		return
+	}
+	if editor == nil {
+		http.Error(w, "plan editing not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	id := r.PostForm.Get("id")
+	if id == "" {
+		http.Error(w, "missing task id", http.StatusBadRequest)
+		return
+	}
+
+	if err := editor.EditTask(r.Context(), id, "dashboard", taskEditFromForm(r)); err != nil {
+		http.Error(w, err.Error(), editorErrorStatus(err))
+		return
+	}
+	s.broadcastChange()
+	redirectAfterAction(w, r)
+}
+
+func (s *Server) handleTaskAssign(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	actions, err := s.pickActions(r)
+	if err != nil {
+		http.Error(w, err.Error(), actionErrorStatus(err))
+		return
+	}
+	if actions == nil {
+		http.Error(w, "task actions not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	id := r.PostForm.Get("id")
+	if id == "" {
+		http.Error(w, "missing task id", http.StatusBadRequest)
+		return
+	}
+	owner := strings.TrimSpace(r.PostForm.Get("owner"))
+	// The roster is this project's team; other projects check their own.
+	local := r.PostForm.Get("project_path") == "" && r.PostForm.Get("project") == ""
+	if names := s.ownerNames(); owner != "" && local && len(names) > 0 && !containsString(names, owner) {
+		http.Error(w, fmt.Sprintf("%s is not a team member", owner), http.StatusBadRequest)
+		return
+	}
+	if err := actions.AssignTask(team.WithActor(r.Context(), "dashboard"), id, owner); err != nil {
+		http.Error(w, err.Error(), actionErrorStatus(err))
+		return
+	}
+	s.broadcastChange()
+	redirectAfterAction(w, r)
+}
+
+// taskEditFromForm reads the task fields present in the form. Absent fields
+// stay nil, so a form may edit a single field; TaskService validates them.
+func taskEditFromForm(r *http.Request) application.TaskEdit {
+	form := r.PostForm
+	var edit application.TaskEdit
+	if _, ok := form["title"]; ok {
+		title := strings.TrimSpace(form.Get("title"))
+		edit.Title = &title
+	}
+	if _, ok := form["description"]; ok {
+		description := strings.TrimSpace(form.Get("description"))
+		edit.Description = &description
+	}
+	if _, ok := form["priority"]; ok {
+		p := planning.TaskPriority(strings.ToLower(strings.TrimSpace(form.Get("priority"))))
+		edit.Priority = &p
+	}
+	if _, ok := form["estimate"]; ok {
+		estimate := strings.TrimSpace(form.Get("estimate"))
+		edit.Estimate = &estimate
+	}
+	if _, ok := form["depends_on"]; ok {
+		deps := parseDependencies(form.Get("depends_on"))
+		edit.DependsOn = &deps
+	}
+	return edit
+}
+
+// parseDependencies splits a comma- or space-separated list of task
+// references, dropping repeats.
+func parseDependencies(value string) []string {
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})
+	deps := []string{}
+	for _, id := range fields {
+		if !containsString(deps, id) {
+			deps = append(deps, id)
+		}
+	}
+	return deps
+}
+
+// editorErrorStatus maps a task create or edit error to its HTTP status.
+func editorErrorStatus(err error) int {
+	if errors.Is(err, project.ErrTaskNotFound) {
+		return http.StatusNotFound
+	}
+	return actionErrorStatus(err)
+}
@@ -0,0 +1,326 @@
+package dashboard
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/roady/pkg/application"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
+	"github.com/felixgeelhaar/roady/pkg/storage"
+)
+
+// planEditorFixture is a TaskService over a filesystem project, so the
+// editor tests exercise the same validation as the CLI and sync.
+type planEditorFixture struct {
+	t    *testing.T
+	repo *storage.FilesystemRepository
+}
+
+func (f *planEditorFixture) tasks() []planning.Task {
+	f.t.Helper()
+	plan, err := f.repo.LoadPlan()
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	return plan.Tasks
+}
+
+func (f *planEditorFixture) task(id string) planning.Task {
+	for _, t := range f.tasks() {
+		if t.ID == id {
+			return t
+		}
+	}
+	return planning.Task{}
+}
+
+// lastActor returns the actor of the latest audit event.
+func (f *planEditorFixture) lastActor() string {
+	f.t.Helper()
+	events, err := f.repo.LoadEvents()
+	if err != nil || len(events) == 0 {
+		f.t.Fatalf("events = %v, %v", events, err)
+	}
+	return events[len(events)-1].Actor
+}
+
+type fakeRoster struct{ names []string }
+
+func (f fakeRoster) ListMembers() (*team.TeamConfig, error) {
+	cfg := &team.TeamConfig{}
+	for _, n := range f.names {
+		cfg.Members = append(cfg.Members, team.Member{Name: n, Role: team.RoleMember})
+	}
+	return cfg, nil
+}
+
+func newEditorFixture(t *testing.T) (*application.TaskService, *planEditorFixture) {
+	t.Helper()
+	repo := storage.NewFilesystemRepository(t.TempDir())
+	if err := repo.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SavePlan(&planning.Plan{ID: "p1", Tasks: []planning.Task{
+		{ID: "t-1", Title: "Design", Priority: planning.PriorityMedium, Origin: planning.OriginAI},
+		{ID: "t-2", Title: "Build", DependsOn: []string{"t-1"}},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	svc := application.NewTaskService(repo, application.NewAuditService(repo), application.NewPolicyService(repo))
+	return svc, &planEditorFixture{t: t, repo: repo}
+}
+
+func newEditorServer(t *testing.T) (*Server, *planEditorFixture) {
+	t.Helper()
+	editor, fixture := newEditorFixture(t)
+	srv := newActionsServer(t, &fakeTaskActions{})
+	srv.EnablePlanEditing(editor)
+	return srv, fixture
+}
+
+func TestHandleTaskCreate_HumanOrigin(t *testing.T) {
+	srv, editor := newEditorServer(t)
+	rec := postForm(t, srv.handleTaskCreate, "/actions/task/create", url.Values{
+		"title":      {"Write the docs!"},
+		"priority":   {"high"},
+		"estimate":   {"2d"},
+		"depends_on": {"t-1, t-2"},
+	})
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	got := editor.task("task-write-the-docs")
+	if got.Title != "Write the docs!" || got.Priority != planning.PriorityHigh || got.Estimate != "2d" {
+		t.Errorf("created task = %+v", got)
+	}
+	if got.Origin != planning.OriginHuman {
+		t.Errorf("origin = %q, want human", got.Origin)
+	}
+	if strings.Join(got.DependsOn, ",") != "t-1,t-2" {
+		t.Errorf("depends_on = %v", got.DependsOn)
+	}
+	if len(editor.tasks()) != 3 || editor.lastActor() != "dashboard" {
+		t.Errorf("tasks = %d, actor = %q", len(editor.tasks()), editor.lastActor())
+	}
+}
+
+func TestHandleTaskCreate_ExternalDependency(t *testing.T) {
+	srv, editor := newEditorServer(t)
+	rec := postForm(t, srv.handleTaskCreate, "/actions/task/create", url.Values{
+		"id":         {"t-3"},
+		"title":      {"Integrate billing"},
+		"depends_on": {"t-1 @billing:task-invoices"},
+	})
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := editor.task("t-3").DependsOn; strings.Join(got, ",") != "t-1,@billing:task-invoices" {
+		t.Errorf("depends_on = %v", got)
+	}
+
+	rec = postForm(t, srv.handleTaskEdit, "/actions/task/edit", url.Values{"id": {"t-2"}, "depends_on": {"@auth:task-login"}})
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("edit status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := editor.task("t-2").DependsOn; strings.Join(got, ",") != "@auth:task-login" {
+		t.Errorf("edited depends_on = %v", got)
+	}
+}
+
+func TestHandleTaskCreate_Rejects(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		form url.Values
+	}{
+		{"missing title", url.Values{"priority": {"low"}}},
+		{"duplicate id", url.Values{"id": {"t-1"}, "title": {"Again"}}},
+		{"unknown dependency", url.Values{"title": {"X"}, "depends_on": {"t-9"}}},
+		{"bad priority", url.Values{"title": {"X"}, "priority": {"urgent"}}},
+		{"bad estimate", url.Values{"title": {"X"}, "estimate": {"soon"}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, editor := newEditorServer(t)
+			rec := postForm(t, srv.handleTaskCreate, "/actions/task/create", tc.form)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", rec.Code)
+			}
+			if len(editor.tasks()) != 2 {
+				t.Errorf("plan changed: %d tasks", len(editor.tasks()))
+			}
+		})
+	}
+}
+
+func TestHandleTaskCreate_UniqueID(t *testing.T) {
+	srv, editor := newEditorServer(t)
+	for i := 0; i < 2; i++ {
+		postForm(t, srv.handleTaskCreate, "/actions/task/create", url.Values{"title": {"Review"}})
+	}
+	if editor.task("task-review").ID == "" || editor.task("task-review-2").ID == "" {
+		t.Errorf("tasks = %+v, want task-review and task-review-2", editor.tasks())
+	}
+}
+
+func TestHandleTaskEdit(t *testing.T) {
+	srv, editor := newEditorServer(t)
+	rec := postForm(t, srv.handleTaskEdit, "/actions/task/edit", url.Values{
+		"id":          {"t-1"},
+		"title":       {"Design v2"},
+		"description": {"with diagrams"},
+		"estimate":    {"4h"},
+	})
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	got := editor.task("t-1")
+	if got.Title != "Design v2" || got.Description != "with diagrams" || got.Estimate != "4h" {
+		t.Errorf("edited task = %+v", got)
+	}
+	if got.Priority != planning.PriorityMedium {
+		t.Errorf("priority = %q, want it left alone", got.Priority)
+	}
+	if got.Origin != planning.OriginAI {
+		t.Errorf("origin = %q, want the AI provenance kept", got.Origin)
+	}
+}
+
+type fakeOrgPlanEditor map[string]PlanEditor
+
+func (f fakeOrgPlanEditor) ResolvePlanEditor(projectPath, project string) (PlanEditor, error) {
+	if e, ok := f[projectPath+"|"+project]; ok {
+		return e, nil
+	}
+	return nil, fmt.Errorf("unknown project %s / %s", projectPath, project)
+}
+
+func TestHandleTaskCreate_OtherProject(t *testing.T) {
+	srv, root := newEditorServer(t)
+	form := url.Values{"id": {"t-3"}, "title": {"Login"}, "project_path": {"/ws"}, "project": {"auth"}}
+
+	// Without a resolver the form must not land on the root plan.
+	if rec := postForm(t, srv.handleTaskCreate, "/actions/task/create", form); rec.Code != http.StatusBadRequest {
+		t.Errorf("no resolver: status = %d, want 400", rec.Code)
+	}
+
+	authEditor, auth := newEditorFixture(t)
+	srv.EnableOrgPlanEditing(fakeOrgPlanEditor{"/ws|auth": authEditor})
+	if rec := postForm(t, srv.handleTaskCreate, "/actions/task/create", form); rec.Code != http.StatusSeeOther {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if auth.task("t-3").Title != "Login" {
+		t.Errorf("auth tasks = %+v, want t-3", auth.tasks())
+	}
+	if root.task("t-3").ID != "" {
+		t.Error("task was created in the root project")
+	}
+}
+
+func TestHandleTaskEdit_DependencyCycle(t *testing.T) {
+	srv, editor := newEditorServer(t)
+	rec := postForm(t, srv.handleTaskEdit, "/actions/task/edit", url.Values{"id": {"t-1"}, "depends_on": {"t-2"}})
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "cycle") {
+		t.Errorf("status = %d, body = %q; want 400 cycle", rec.Code, rec.Body.String())
+	}
+	if len(editor.task("t-1").DependsOn) != 0 {
+		t.Error("cyclic edit was saved")
+	}
+
+	rec = postForm(t, srv.handleTaskEdit, "/actions/task/edit", url.Values{"id": {"t-1"}, "depends_on": {"t-1"}})
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("self dependency status = %d, want 400", rec.Code)
+	}
+}
+
+func TestHandleTaskEdit_NotFound(t *testing.T) {
+	srv, _ := newEditorServer(t)
+	rec := postForm(t, srv.handleTaskEdit, "/actions/task/edit", url.Values{"id": {"t-9"}, "title": {"X"}})
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", rec.Code)
+	}
+}
+
+func TestHandleTaskEdit_ViewerForbidden(t *testing.T) {
+	srv, editor := newEditorServer(t)
+	req := httptest.NewRequest(http.MethodPost, "/actions/task/edit", strings.NewReader(url.Values{"id": {"t-1"}, "title": {"X"}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = req.WithContext(team.WithCaller(req.Context(), team.Member{Name: "vi", Role: team.RoleViewer}))
+	rec := httptest.NewRecorder()
+	srv.handleTaskEdit(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want 403", rec.Code)
+	}
+	if editor.task("t-1").Title != "Design" {
+		t.Error("forbidden edit was saved")
+	}
+}
+
+func TestHandleTaskEdit_Disabled(t *testing.T) {
+	srv := newActionsServer(t, &fakeTaskActions{})
+	for _, h := range []http.HandlerFunc{srv.handleTaskCreate, srv.handleTaskEdit} {
+		rec := postForm(t, h, "/actions/task/edit", url.Values{"id": {"t-1"}, "title": {"X"}})
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Errorf("status = %d, want 503", rec.Code)
+		}
+	}
+}
+
+func TestHandleTaskAssign_Roster(t *testing.T) {
+	a := &fakeTaskActions{}
+	srv := newActionsServer(t, a)
+	srv.EnableTeamRoster(fakeRoster{names: []string{"alice", "bob"}})
+
+	rec := postForm(t, srv.handleTaskAssign, "/actions/task/assign", url.Values{"id": {"t-1"}, "owner": {"bob"}})
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if a.assignCalled == nil || a.assignCalled.id != "t-1" || a.assignCalled.owner != "bob" {
+		t.Errorf("AssignTask call = %+v", a.assignCalled)
+	}
+
+	a.assignCalled = nil
+	rec = postForm(t, srv.handleTaskAssign, "/actions/task/assign", url.Values{"id": {"t-1"}, "owner": {"mallory"}})
+	if rec.Code != http.StatusBadRequest || a.assignCalled != nil {
+		t.Errorf("non-member: status = %d, call = %+v; want 400 and no call", rec.Code, a.assignCalled)
+	}
+
+	rec = postForm(t, srv.handleTaskAssign, "/actions/task/assign", url.Values{"id": {"t-1"}, "owner": {""}})
+	if rec.Code != http.StatusSeeOther || a.assignCalled == nil || a.assignCalled.owner != "" {
+		t.Errorf("unassign: status = %d, call = %+v", rec.Code, a.assignCalled)
+	}
+}
+
+func TestKanbanPage_EditForms(t *testing.T) {
+	srv, err := NewServer(":0", &kanbanStubProvider{
+		plan:  &planning.Plan{Tasks: []planning.Task{{ID: "t-1", Title: "Design"}}},
+		state: &planning.ExecutionState{TaskStates: map[string]planning.TaskResult{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.EnableTaskActions(&fakeTaskActions{})
+	srv.EnablePlanEditing(&application.TaskService{})
+	srv.EnableTeamRoster(fakeRoster{names: []string{"alice"}})
+
+	rec := httptest.NewRecorder()
+	srv.handleKanban(rec, httptest.NewRequest(http.MethodGet, "/kanban", nil))
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, body)
+	}
+	for _, want := range []string{
+		`action="/actions/task/create"`,
+		`action="/actions/task/edit"`,
+		`action="/actions/task/assign"`,
+		`<option value="alice">alice</option>`,
+		`<option value="medium" selected>medium</option>`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("kanban page missing %s", want)
+		}
+	}
+}
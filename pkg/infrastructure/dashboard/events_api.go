@@ -0,0 +1,98 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+)
+
+// EventQuerier searches the project's event store. The dashboard server takes
+// this as an optional dependency; when nil, /api/events stays unregistered.
+type EventQuerier interface {
+	QueryEvents(q events.EventQuery) ([]*events.BaseEvent, error)
+}
+
+// defaultEventQueryLimit caps /api/events when no limit is given.
+const defaultEventQueryLimit = 100
+
+// EnableEventQuery wires GET /api/events over the event store. Pass nil to
+// leave the endpoint out.
+func (s *Server) EnableEventQuery(q EventQuerier) {
+	s.eventQuerier = q
+}
+
+// handleAPIEvents serves GET /api/events. Filters mirror `roady audit query`:
+// type (repeatable glob), actor, aggregate, aggregate_type, since, until,
+// meta (repeatable key=glob), limit and offset. With EnableOrgInsights it
+// also takes project_path and project.
+func (s *Server) handleAPIEvents(w http.ResponseWriter, r *http.Request) {
+	q, err := parseEventQuery(r, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	in, err := s.insightsFor(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if in.Events == nil {
+		http.Error(w, "event search not enabled", http.StatusNotFound)
+		return
+	}
+	evts, err := in.Events.QueryEvents(q)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(evts)
+}
+
+// parseEventQuery builds an EventQuery from the request's query string.
+func parseEventQuery(r *http.Request, now time.Time) (events.EventQuery, error) {
+	params := r.URL.Query()
+	q := events.EventQuery{
+		AggregateType: params.Get("aggregate_type"),
+		AggregateID:   params.Get("aggregate"),
+		EventTypes:    params["type"],
+		Actor:         params.Get("actor"),
+		Limit:         defaultEventQueryLimit,
+	}
+	var err error
+	if q.Metadata, err = events.ParseMetadataFilters(params["meta"]); err != nil {
+		return q, err
+	}
+	for _, bound := range []struct {
+		name string
+		dst  **time.Time
+	}{{"since", &q.Since}, {"until", &q.Until}} {
+		value := params.Get(bound.name)
+		if value == "" {
+			continue
+		}
+		t, err := events.ParseQueryTime(value, now)
+		if err != nil {
+			return q, fmt.Errorf("%s: %w", bound.name, err)
+		}
+		*bound.dst = &t
+	}
+	for _, page := range []struct {
+		name string
+		dst  *int
+	}{{"limit", &q.Limit}, {"offset", &q.Offset}} {
+		value := params.Get(page.name)
+		if value == "" {
+			continue
+		}
+		if *page.dst, err = strconv.Atoi(value); err != nil {
+			return q, fmt.Errorf("invalid %s %q", page.name, value)
+		}
+	}
+	return q, q.Validate()
+}
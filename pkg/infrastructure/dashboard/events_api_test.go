@@ -0,0 +1,66 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+)
+
+type fakeEventQuerier struct {
+	evts []*events.BaseEvent
+	last events.EventQuery
+}
+
+func (f *fakeEventQuerier) QueryEvents(q events.EventQuery) ([]*events.BaseEvent, error) {
+	f.last = q
+	return q.Filter(f.evts), nil
+}
+
+func TestHandleAPIEvents(t *testing.T) {
+	now := time.Now()
+	querier := &fakeEventQuerier{evts: []*events.BaseEvent{
+		{ID: "e1", Type: events.EventTypeTaskStarted, Actor: "ai:claude", AggregateID_: "t1", Timestamp: now.Add(-time.Hour)},
+		{ID: "e2", Type: events.EventTypeTaskCompleted, Actor: "alice", AggregateID_: "t1", Timestamp: now.Add(-time.Hour)},
+		{ID: "e3", Type: events.EventTypeTaskStarted, Actor: "ai:claude", AggregateID_: "t2", Timestamp: now.AddDate(0, 0, -30)},
+	}}
+	srv, err := NewServer(":0", &kanbanStubProvider{plan: &planning.Plan{}, state: &planning.ExecutionState{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.EnableEventQuery(querier)
+
+	rec := httptest.NewRecorder()
+	srv.handleAPIEvents(rec, httptest.NewRequest(http.MethodGet, "/api/events?type=task.*&actor=ai*&since=7d", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	var got []events.BaseEvent
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != "e1" {
+		t.Errorf("expected e1 only, got %+v", got)
+	}
+	if querier.last.Limit != defaultEventQueryLimit {
+		t.Errorf("expected the default limit, got %d", querier.last.Limit)
+	}
+
+	rec = httptest.NewRecorder()
+	srv.handleAPIEvents(rec, httptest.NewRequest(http.MethodGet, "/api/events?aggregate=t1&offset=1&limit=5", nil))
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil || len(got) != 1 || got[0].ID != "e2" {
+		t.Errorf("expected e2, got %+v, %v", got, err)
+	}
+
+	for _, query := range []string{"since=soon", "limit=many", "meta=status", "type=task.["} {
+		rec = httptest.NewRecorder()
+		srv.handleAPIEvents(rec, httptest.NewRequest(http.MethodGet, "/api/events?"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/analytics"
+)
+
+// Burndown chart viewBox, in SVG user units.
+const (
+	burndownWidth  = 600
+	burndownHeight = 200
+)
+
+// burndownPoint is one /api/forecast burndown entry.
+type burndownPoint struct {
+	Date      string `json:"date"`
+	Actual    int    `json:"actual"`
+	Projected int    `json:"projected"`
+}
+
+// forecastView is the /api/forecast payload; it matches the roady_forecast
+// MCP tool.
+type forecastView struct {
+	Remaining      int             `json:"remaining"`
+	Completed      int             `json:"completed"`
+	Total          int             `json:"total"`
+	Velocity       float64         `json:"velocity"`
+	EstimatedDays  float64         `json:"estimated_days"`
+	CompletionRate float64         `json:"completion_rate"`
+	Trend          string          `json:"trend"`
+	TrendSlope     float64         `json:"trend_slope"`
+	Confidence     float64         `json:"confidence"`
+	CILow          float64         `json:"ci_low"`
+	CIExpected     float64         `json:"ci_expected"`
+	CIHigh         float64         `json:"ci_high"`
+	Burndown       []burndownPoint `json:"burndown"`
+	DataPoints     int             `json:"data_points"`
+}
+
+func newForecastView(f *analytics.ForecastResult) forecastView {
+	v := forecastView{
+		Remaining:      f.RemainingTasks,
+		Completed:      f.CompletedTasks,
+		Total:          f.TotalTasks,
+		Velocity:       f.Velocity,
+		EstimatedDays:  f.EstimatedDays,
+		CompletionRate: f.CompletionRate(),
+		Trend:          string(f.Trend.Direction),
+		TrendSlope:     f.Trend.Slope,
+		Confidence:     f.Trend.Confidence,
+		CILow:          f.ConfidenceInterval.Low,
+		CIExpected:     f.ConfidenceInterval.Expected,
+		CIHigh:         f.ConfidenceInterval.High,
+		Burndown:       make([]burndownPoint, 0, len(f.Burndown)),
+		DataPoints:     f.DataPoints,
+	}
+	for _, p := range f.Burndown {
+		v.Burndown = append(v.Burndown, burndownPoint{
+			Date:      p.Date.Format("2006-01-02"),
+			Actual:    p.Actual,
+			Projected: p.Projected,
+		})
+	}
+	return v
+}
+
+// burndownChart is the forecast page's SVG chart: polyline point lists for
+// the actual and projected remaining tasks.
+type burndownChart struct {
+	Width     int
+	Height    int
+	Actual    string
+	Projected string
+	From      string
+	To        string
+	Total     int
+}
+
+// buildBurndownChart plots the burndown over time, scaled to the plan's
+// total tasks. The projection starts where the actual line ends (or at the
+// remaining tasks today when nothing is complete yet).
+func buildBurndownChart(f *analytics.ForecastResult, now time.Time) *burndownChart {
+	type sample struct {
+		at    time.Time
+		value int
+	}
+	var actual, projected []sample
+	for _, p := range f.Burndown {
+		if p.IsProjected() {
+			projected = append(projected, sample{p.Date, p.Projected})
+		} else {
+			actual = append(actual, sample{p.Date, p.Actual})
+		}
+	}
+	if len(projected) > 0 {
+		start := sample{now, f.RemainingTasks}
+		if len(actual) > 0 {
+			start = actual[len(actual)-1]
+		}
+		projected = append([]sample{start}, projected...)
+	}
+	all := append(append([]sample(nil), actual...), projected...)
+	if len(all) < 2 || f.TotalTasks == 0 {
+		return nil
+	}
+
+	from, to := all[0].at, all[0].at
+	for _, s := range all {
+		if s.at.Before(from) {
+			from = s.at
+		}
+		if s.at.After(to) {
+			to = s.at
+		}
+	}
+	span := to.Sub(from).Seconds()
+	plot := func(samples []sample) string {
+		points := make([]string, 0, len(samples))
+		for _, s := range samples {
+			x := 0.0
+			if span > 0 {
+				x = s.at.Sub(from).Seconds() / span * burndownWidth
+			}
+			y := burndownHeight - float64(s.value)/float64(f.TotalTasks)*burndownHeight
+			points = append(points, fmt.Sprintf("%.1f,%.1f", x, y))
+		}
+		return strings.Join(points, " ")
+	}
+
+	return &burndownChart{
+		Width:     burndownWidth,
+		Height:    burndownHeight,
+		Actual:    plot(actual),
+		Projected: plot(projected),
+		From:      from.Format("Jan 2"),
+		To:        to.Format("Jan 2"),
+		Total:     f.TotalTasks,
+	}
+}
+
+// forecastData is the template data of the forecast page.
+type forecastData struct {
+	reportPage
+	Forecast *forecastView
+	Chart    *burndownChart
+}
+
+func (s *Server) handleForecast(w http.ResponseWriter, r *http.Request) {
+	data := forecastData{reportPage: s.newReportPage(r, "Forecast")}
+	in, err := s.insightsFor(r)
+	switch {
+	case err != nil:
+		data.Error = err.Error()
+	case in.Forecast == nil:
+		data.Error = "Forecasting is not enabled for this dashboard."
+	default:
+		f, err := in.Forecast.GetForecast()
+		if err != nil {
+			data.Error = err.Error()
+			break
+		}
+		if f != nil {
+			view := newForecastView(f)
+			data.Forecast = &view
+			data.Chart = buildBurndownChart(f, time.Now())
+		}
+	}
+	s.render(w, "forecast.html", data)
+}
+
+func (s *Server) handleAPIForecast(w http.ResponseWriter, r *http.Request) {
+	in, err := s.insightsFor(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if in.Forecast == nil {
+		http.Error(w, "forecasting not enabled", http.StatusNotFound)
+		return
+	}
+	f, err := in.Forecast.GetForecast()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if f == nil {
+		_ = json.NewEncoder(w).Encode(nil)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(newForecastView(f))
+}
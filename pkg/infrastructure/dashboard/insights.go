@@ -0,0 +1,171 @@
+package dashboard
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"net/url"
+
+	"github.com/felixgeelhaar/roady/pkg/application"
+	"github.com/felixgeelhaar/roady/pkg/domain/analytics"
+	"github.com/felixgeelhaar/roady/pkg/domain/billing"
+	"github.com/felixgeelhaar/roady/pkg/domain/debt"
+	"github.com/felixgeelhaar/roady/pkg/domain/drift"
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+)
+
+// DriftProvider detects drift between the spec, plan and code.
+type DriftProvider interface {
+	DetectDrift(ctx context.Context) (*drift.Report, error)
+}
+
+// DebtProvider scores the drift debt of the project and its history.
+type DebtProvider interface {
+	GetDebtReport(ctx context.Context) (*debt.DebtReport, error)
+	GetDriftTrend(windowDays int) (events.DriftTrend, error)
+	GetDriftHistory(windowDays int) ([]events.DriftSnapshot, error)
+}
+
+// ForecastProvider projects the completion of the plan from velocity.
+type ForecastProvider interface {
+	GetForecast() (*analytics.ForecastResult, error)
+}
+
+// BillingProvider reports logged cost and the budget consumption.
+type BillingProvider interface {
+	GetCostReport(opts application.CostReportOpts) (*billing.CostReport, error)
+	GetBudgetStatus() (*billing.BudgetStatus, error)
+}
+
+// Insights bundles the per-project services behind the drift, debt,
+// forecast, billing and timeline pages. A nil field leaves its page showing
+// a hint and its /api route unregistered.
+type Insights struct {
+	Drift    DriftProvider
+	Debt     DebtProvider
+	Forecast ForecastProvider
+	Billing  BillingProvider
+	Events   EventQuerier
+}
+
+// EnableInsights wires the drift, debt, forecast, billing and timeline pages
+// and their /api routes for the dashboard's own project. Events falls back
+// to the querier given to EnableEventQuery.
+func (s *Server) EnableInsights(in Insights) {
+	s.insights = in
+}
+
+// InsightsResolver resolves the Insights of a (project_path, project) pair so
+// the report pages can show sub-projects discovered by the org view.
+type InsightsResolver interface {
+	ResolveInsights(projectPath, project string) (Insights, error)
+}
+
+// EnableOrgInsights lets every report page and its /api route take
+// project_path and project query parameters. Pass nil to limit them to the
+// dashboard's own project.
+func (s *Server) EnableOrgInsights(resolver InsightsResolver) {
+	s.orgInsights = resolver
+}
+
+// projectScope identifies the project a report page shows. The zero value
+// is the dashboard's own project.
+type projectScope struct {
+	Path    string
+	Project string
+}
+
+func requestScope(r *http.Request) projectScope {
+	q := r.URL.Query()
+	return projectScope{Path: q.Get("project_path"), Project: q.Get("project")}
+}
+
+// IsLocal reports whether the scope is the dashboard's own project.
+func (p projectScope) IsLocal() bool {
+	return p.Path == "" && p.Project == ""
+}
+
+// Query is the scope as a URL query string, without the leading "?".
+func (p projectScope) Query() string {
+	if p.IsLocal() {
+		return ""
+	}
+	v := url.Values{}
+	if p.Path != "" {
+		v.Set("project_path", p.Path)
+	}
+	if p.Project != "" {
+		v.Set("project", p.Project)
+	}
+	return v.Encode()
+}
+
+// scopeLink is one entry of a report page's project switcher.
+type scopeLink struct {
+	Label   string
+	Query   template.URL
+	Current bool
+}
+
+// insightsFor returns the Insights of the project the request asks for.
+func (s *Server) insightsFor(r *http.Request) (Insights, error) {
+	scope := requestScope(r)
+	if scope.IsLocal() || s.orgInsights == nil {
+		in := s.insights
+		if in.Events == nil {
+			in.Events = s.eventQuerier
+		}
+		return in, nil
+	}
+	return s.orgInsights.ResolveInsights(scope.Path, scope.Project)
+}
+
+// scopeLinks lists the discovered projects for the report page's switcher.
+// It is empty unless both the org view and an InsightsResolver are wired.
+func (s *Server) scopeLinks(r *http.Request) []scopeLink {
+	if s.orgProvider == nil || s.orgInsights == nil {
+		return nil
+	}
+	projects, err := s.orgProvider.DiscoverProjectsWithSub()
+	if err != nil || len(projects) < 2 {
+		return nil
+	}
+	current := requestScope(r)
+	links := []scopeLink{{Label: "this project", Current: current.IsLocal()}}
+	for _, p := range projects {
+		scope := projectScope{Path: p.Path, Project: p.SubProject}
+		links = append(links, scopeLink{
+			Label:   projectLabel(p),
+			Query:   template.URL(scope.Query()),
+			Current: scope == current,
+		})
+	}
+	return links
+}
+
+// reportPage is the data every report template shares. Scope is the
+// project query string links on the page carry along.
+type reportPage struct {
+	Title    string
+	Scope    template.URL
+	Project  projectScope
+	Projects []scopeLink
+	Error    string
+}
+
+func (s *Server) newReportPage(r *http.Request, title string) reportPage {
+	scope := requestScope(r)
+	return reportPage{
+		Title:    title,
+		Scope:    template.URL(scope.Query()),
+		Project:  scope,
+		Projects: s.scopeLinks(r),
+	}
+}
+
+// hasInsight reports whether an /api route backed by pick should be
+// registered: when the local project has the provider or any sub-project
+// might.
+func (s *Server) hasInsight(pick func(Insights) bool) bool {
+	return pick(s.insights) || s.orgInsights != nil
+}
@@ -0,0 +1,274 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/application"
+	"github.com/felixgeelhaar/roady/pkg/domain/analytics"
+	"github.com/felixgeelhaar/roady/pkg/domain/billing"
+	"github.com/felixgeelhaar/roady/pkg/domain/debt"
+	"github.com/felixgeelhaar/roady/pkg/domain/drift"
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+)
+
+// fakeInsights implements every report provider with canned data; label
+// tags the data so tests can tell projects apart.
+type fakeInsights struct {
+	label string
+}
+
+func (f *fakeInsights) DetectDrift(context.Context) (*drift.Report, error) {
+	return &drift.Report{ID: "d1", CreatedAt: time.Now(), Issues: []drift.Issue{
+		{ID: "i1", Type: drift.DriftTypePlan, Severity: drift.SeverityLow, ComponentID: f.label + "-low", Message: "minor"},
+		{ID: "i2", Type: drift.DriftTypePlan, Severity: drift.SeverityCritical, ComponentID: f.label + "-crit", Message: "missing task"},
+	}}, nil
+}
+
+func (f *fakeInsights) GetDebtReport(context.Context) (*debt.DebtReport, error) {
+	report := debt.NewDebtReport()
+	report.AddItem(debt.NewDebtItem(f.label+"-core", drift.DriftTypePlan, "orphan"))
+	report.Finalize()
+	return report, nil
+}
+
+func (f *fakeInsights) GetDriftTrend(windowDays int) (events.DriftTrend, error) {
+	return events.DriftTrend{Direction: "increasing", Change: 0.5, WindowDays: windowDays}, nil
+}
+
+func (f *fakeInsights) GetDriftHistory(int) ([]events.DriftSnapshot, error) {
+	return []events.DriftSnapshot{{Timestamp: time.Now(), IssueCount: 3}}, nil
+}
+
+func (f *fakeInsights) GetForecast() (*analytics.ForecastResult, error) {
+	now := time.Now()
+	return &analytics.ForecastResult{
+		RemainingTasks: 2, CompletedTasks: 2, TotalTasks: 4, Velocity: 1, EstimatedDays: 2,
+		Burndown: []analytics.BurndownPoint{
+			{Date: now.AddDate(0, 0, -2), Actual: 3},
+			{Date: now, Actual: 2},
+			{Date: now.AddDate(0, 0, 1), Projected: 1},
+		},
+	}, nil
+}
+
+func (f *fakeInsights) GetCostReport(application.CostReportOpts) (*billing.CostReport, error) {
+	report := billing.NewCostReport("EUR")
+	report.Entries = []billing.CostReportEntry{{TaskID: f.label + "-t1", RateName: "Senior", Hours: 2, Cost: 200, Currency: "EUR"}}
+	report.TotalHours, report.TotalCost, report.TotalWithTax = 2, 200, 200
+	return report, nil
+}
+
+func (f *fakeInsights) GetBudgetStatus() (*billing.BudgetStatus, error) {
+	return &billing.BudgetStatus{BudgetHours: 10, UsedHours: 9, Remaining: 1, PercentUsed: 90, Currency: "EUR"}, nil
+}
+
+func (f *fakeInsights) insights(q EventQuerier) Insights {
+	return Insights{Drift: f, Debt: f, Forecast: f, Billing: f, Events: q}
+}
+
+// fakeInsightsResolver resolves every sub-project to fakeInsights labelled
+// with its name.
+type fakeInsightsResolver struct {
+	gotPath, gotProject string
+}
+
+func (f *fakeInsightsResolver) ResolveInsights(projectPath, project string) (Insights, error) {
+	f.gotPath, f.gotProject = projectPath, project
+	if project == "missing" {
+		return Insights{}, errors.New("project not found")
+	}
+	return (&fakeInsights{label: project}).insights(&fakeEventQuerier{}), nil
+}
+
+func newInsightsTestServer(t *testing.T) *Server {
+	t.Helper()
+	srv, err := NewServer(":0", &kanbanStubProvider{plan: &planning.Plan{}, state: &planning.ExecutionState{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	querier := &fakeEventQuerier{evts: []*events.BaseEvent{
+		{ID: "e1", Type: "task.started", Actor: "alice", AggregateID_: "t1", Timestamp: now.Add(-2 * time.Hour)},
+		{ID: "e2", Type: "plan.approved", Actor: "bob", AggregateID_: "p1", Timestamp: now.Add(-time.Hour), Metadata: map[string]interface{}{"plan_id": "p1"}},
+		{ID: "e3", Type: "task.completed", Actor: "alice", AggregateID_: "t1", Timestamp: now.Add(-30 * time.Minute)},
+		{ID: "old", Type: "task.started", Actor: "alice", AggregateID_: "t0", Timestamp: now.AddDate(0, 0, -30)},
+	}}
+	srv.EnableInsights((&fakeInsights{label: "local"}).insights(querier))
+	return srv
+}
+
+func get(t *testing.T, h http.HandlerFunc, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec
+}
+
+func TestReportPages_Render(t *testing.T) {
+	srv := newInsightsTestServer(t)
+	for _, tc := range []struct {
+		name    string
+		handler http.HandlerFunc
+		want    []string
+	}{
+		{"drift", srv.handleDrift, []string{`class="sev-critical">critical`, "local-crit", "missing task"}},
+		{"debt", srv.handleDebt, []string{"local-core", "increasing", "&#43;50%", `class="trend"`}},
+		{"forecast", srv.handleForecast, []string{"50%", `<polyline class="actual"`, `<polyline class="projected"`}},
+		{"billing", srv.handleBilling, []string{`class="warn" style="width: 90.00%"`, "local-t1", "200.00 EUR"}},
+		{"timeline", srv.handleTimeline, []string{"plan.approved", "plan_id=p1", `value="7d"`}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := get(t, tc.handler, "/"+tc.name)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, body %s", rec.Code, rec.Body.String())
+			}
+			body := rec.Body.String()
+			for _, want := range tc.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("body missing %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestReportPages_NotEnabled(t *testing.T) {
+	srv, err := NewServer(":0", &kanbanStubProvider{plan: &planning.Plan{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, h := range map[string]http.HandlerFunc{
+		"drift": srv.handleDrift, "debt": srv.handleDebt, "forecast": srv.handleForecast,
+		"billing": srv.handleBilling, "timeline": srv.handleTimeline,
+	} {
+		if body := get(t, h, "/"+name).Body.String(); !strings.Contains(body, "not enabled") {
+			t.Errorf("%s: expected a hint when no provider is wired", name)
+		}
+	}
+}
+
+func TestTimeline_NewestFirstAndFiltered(t *testing.T) {
+	srv := newInsightsTestServer(t)
+
+	body := get(t, srv.handleTimeline, "/timeline").Body.String()
+	if strings.Index(body, "task.completed") > strings.Index(body, "plan.approved") {
+		t.Error("timeline should list the newest event first")
+	}
+	if strings.Contains(body, ">t0<") {
+		t.Error("events older than the default week should be hidden")
+	}
+
+	body = get(t, srv.handleTimeline, "/timeline?type=task.*&actor=&since=60d").Body.String()
+	if strings.Contains(body, "plan.approved") || !strings.Contains(body, ">t0<") {
+		t.Error("type and since filters were not applied")
+	}
+
+	if body := get(t, srv.handleTimeline, "/timeline?since=yesterday-ish").Body.String(); !strings.Contains(body, "invalid time") {
+		t.Error("expected an error for an invalid since")
+	}
+}
+
+func TestReportAPIs(t *testing.T) {
+	srv := newInsightsTestServer(t)
+
+	var report drift.Report
+	if err := json.Unmarshal(get(t, srv.handleAPIDrift, "/api/drift").Body.Bytes(), &report); err != nil || len(report.Issues) != 2 {
+		t.Errorf("/api/drift = %+v, %v", report, err)
+	}
+
+	var summary struct {
+		Health     string            `json:"health"`
+		TopDebtors []*debt.DebtScore `json:"top_debtors"`
+	}
+	if err := json.Unmarshal(get(t, srv.handleAPIDebt, "/api/debt").Body.Bytes(), &summary); err != nil || len(summary.TopDebtors) != 1 || summary.Health == "" {
+		t.Errorf("/api/debt = %+v, %v", summary, err)
+	}
+
+	var forecast forecastView
+	if err := json.Unmarshal(get(t, srv.handleAPIForecast, "/api/forecast").Body.Bytes(), &forecast); err != nil || forecast.CompletionRate != 50 || len(forecast.Burndown) != 3 {
+		t.Errorf("/api/forecast = %+v, %v", forecast, err)
+	}
+
+	var costs billingSummary
+	if err := json.Unmarshal(get(t, srv.handleAPIBilling, "/api/billing").Body.Bytes(), &costs); err != nil || costs.Budget == nil || costs.Costs.TotalCost != 200 {
+		t.Errorf("/api/billing = %+v, %v", costs, err)
+	}
+}
+
+func TestReportPages_SubProjects(t *testing.T) {
+	srv := newInsightsTestServer(t)
+	resolver := &fakeInsightsResolver{}
+	srv.EnableOrgInsights(resolver)
+	srv.EnableOrgKanban(&stubOrgProvider{items: []application.DiscoveredProject{
+		{Path: "/ws"}, {Path: "/ws", SubProject: "api"},
+	}}, nil)
+
+	body := get(t, srv.handleDrift, "/drift?project_path=/ws&project=api").Body.String()
+	if resolver.gotPath != "/ws" || resolver.gotProject != "api" || !strings.Contains(body, "api-crit") {
+		t.Errorf("expected the api sub-project's drift, resolver got %q/%q", resolver.gotPath, resolver.gotProject)
+	}
+	for _, want := range []string{`href="?project=api&amp;project_path=%2Fws" class="current"`, `href="/api/drift?project=api&amp;project_path=%2Fws"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %q", want)
+		}
+	}
+
+	var forecast forecastView
+	rec := get(t, srv.handleAPIForecast, "/api/forecast?project=api")
+	if err := json.Unmarshal(rec.Body.Bytes(), &forecast); err != nil || resolver.gotProject != "api" {
+		t.Errorf("/api/forecast did not resolve the sub-project: %v", err)
+	}
+
+	if rec := get(t, srv.handleAPIBilling, "/api/billing?project=missing"); rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown sub-project: status = %d, want 400", rec.Code)
+	}
+
+	// Without a project the dashboard's own project is shown.
+	if body := get(t, srv.handleDrift, "/drift").Body.String(); !strings.Contains(body, "local-crit") {
+		t.Error("expected the local project's drift")
+	}
+}
+
+func TestBuildTrendBars(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	bars := buildTrendBars([]events.DriftSnapshot{
+		{Timestamp: now.Add(-time.Hour), IssueCount: 4},
+		{Timestamp: now.AddDate(0, 0, -1), IssueCount: 1},
+		{Timestamp: now.AddDate(0, 0, -1).Add(time.Hour), IssueCount: 1},
+		{Timestamp: now.AddDate(0, 0, -40), IssueCount: 9},
+	}, now, 30)
+
+	if len(bars) != 30 || bars[29].Day != "Mar 10" || bars[0].Day != "Feb 9" {
+		t.Fatalf("bars span %s..%s, want Feb 9..Mar 10", bars[0].Day, bars[len(bars)-1].Day)
+	}
+	if bars[29].Issues != 4 || bars[29].Height != 100 || bars[28].Issues != 2 || bars[28].Height != 50 {
+		t.Errorf("last two days = %+v, %+v", bars[28], bars[29])
+	}
+}
+
+func TestBuildBurndownChart(t *testing.T) {
+	f, _ := (&fakeInsights{}).GetForecast()
+	chart := buildBurndownChart(f, time.Now())
+	if chart == nil {
+		t.Fatal("expected a chart")
+	}
+	// Three days span 600 units; 3 of 4 tasks remaining sits at y=50.
+	if !strings.HasPrefix(chart.Actual, "0.0,50.0 ") || !strings.HasSuffix(chart.Projected, "600.0,150.0") {
+		t.Errorf("actual %q, projected %q", chart.Actual, chart.Projected)
+	}
+	if strings.Fields(chart.Projected)[0] != strings.Fields(chart.Actual)[1] {
+		t.Error("the projection should start where the actual line ends")
+	}
+
+	if buildBurndownChart(&analytics.ForecastResult{TotalTasks: 3}, time.Now()) != nil {
+		t.Error("no chart without burndown points")
+	}
+}
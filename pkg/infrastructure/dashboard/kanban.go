@@ -70,12 +70,13 @@ func buildKanbanBoard(plan *planning.Plan, state *planning.ExecutionState) Kanba
 	}
 
 	for _, task := range plan.Tasks {
-		view := TaskView{Task: task, Status: planning.StatusPending}
+		view := TaskView{Task: task, Status: planning.StatusPending, Criteria: task.Coverage(planning.TaskResult{})}
 		if state != nil {
 			if r, ok := state.TaskStates[task.ID]; ok {
 				view.Status = r.Status
 				view.Owner = r.Owner
 				view.HasLinks = len(r.ExternalRefs) > 0
+				view.Criteria = task.Coverage(r)
 			}
 		}
 
@@ -138,10 +139,21 @@ type kanbanData struct {
 	Board          KanbanBoard
 	Error          string
 	ActionsEnabled bool
+	EditEnabled    bool
+	Owners         []string // team members offered as owners; empty = free text
+	Priorities     []planning.TaskPriority
+	CSRFToken      string // set for OIDC sessions; action forms must send it
 }
 
 func (s *Server) handleKanban(w http.ResponseWriter, r *http.Request) {
-	data := kanbanData{Title: "Kanban", ActionsEnabled: s.taskActions != nil}
+	data := kanbanData{
+		Title:          "Kanban",
+		ActionsEnabled: s.taskActions != nil,
+		EditEnabled:    s.planEditor != nil,
+		Owners:         s.ownerNames(),
+		Priorities:     planning.AllTaskPriorities(),
+		CSRFToken:      csrfToken(r),
+	}
 
 	plan, err := s.provider.GetPlan()
 	if err != nil {
@@ -151,6 +163,7 @@ func (s *Server) handleKanban(w http.ResponseWriter, r *http.Request) {
 	}
 	state, _ := s.provider.GetState()
 	data.Board = buildKanbanBoard(plan, state)
+	markCritical(&data.Board, s.schedule())
 
 	s.render(w, "kanban.html", data)
 }
@@ -163,6 +176,7 @@ func (s *Server) handleAPIKanban(w http.ResponseWriter, r *http.Request) {
 	}
 	state, _ := s.provider.GetState()
 	board := buildKanbanBoard(plan, state)
+	markCritical(&board, s.schedule())
 
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(board)
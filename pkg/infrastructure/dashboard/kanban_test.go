@@ -162,6 +162,32 @@ func TestKanbanHTMLHandler_RendersAllColumns(t *testing.T) {
 	}
 }
 
+func TestKanbanHTMLHandler_CriteriaCoverage(t *testing.T) {
+	plan := sampleKanbanPlan()
+	plan.Tasks[3].AcceptanceCriteria = []string{"Errors are shown", "Receipts are emailed"}
+	state := sampleKanbanState()
+	state.TaskStates["d-done"] = planning.TaskResult{
+		Status:           planning.StatusDone,
+		CriteriaEvidence: []planning.CriterionEvidence{{Criterion: "Errors are shown", Kind: planning.EvidenceTest, Value: "TestErrors"}},
+	}
+
+	board := buildKanbanBoard(plan, state)
+	done := board.Columns[4].Tasks[0]
+	if done.Criteria.String() != "1/2" {
+		t.Fatalf("coverage = %s, want 1/2", done.Criteria)
+	}
+
+	srv, err := NewServer(":0", &kanbanStubProvider{plan: plan, state: state})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	srv.handleKanban(rec, httptest.NewRequest(http.MethodGet, "/kanban", nil))
+	if body := rec.Body.String(); !strings.Contains(body, `class="card-criteria"`) || !strings.Contains(body, "☑ 1/2") {
+		t.Error("expected a criteria coverage badge on the card")
+	}
+}
+
 func TestKanbanHTMLHandler_DragDropMarkup(t *testing.T) {
 	// Drag-and-drop attributes + JS only render when task actions are wired.
 	withActions, err := NewServer(":0", &kanbanStubProvider{plan: sampleKanbanPlan(), state: sampleKanbanState()})
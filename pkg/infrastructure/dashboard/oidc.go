@@ -0,0 +1,495 @@
+package dashboard
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
+	"golang.org/x/oauth2"
+)
+
+// OIDCConfig configures OpenID Connect login for the dashboard.
+//
+// A user may sign in when their verified email's domain is a key of Domains
+// or one of their groups is a key of Groups. Their role is the highest one
+// among all matches.
+type OIDCConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the dashboard's /auth/callback URL as registered with
+	// the provider, e.g. https://roady.example.com/auth/callback.
+	RedirectURL string
+	// Scopes are requested in addition to openid, email and profile.
+	Scopes []string
+
+	Domains map[string]team.Role
+	Groups  map[string]team.Role
+	// GroupsClaim names the ID token claim holding the user's groups.
+	// Defaults to "groups".
+	GroupsClaim string
+
+	// SessionTTL bounds how long a login lasts. Defaults to 12 hours.
+	SessionTTL time.Duration
+	// HTTPClient reaches the provider. Defaults to a client with a 10s timeout.
+	HTTPClient *http.Client
+}
+
+// OIDCProvider signs dashboard users in with an OpenID Connect provider
+// using the authorization code flow with PKCE, and keeps their sessions.
+type OIDCProvider struct {
+	cfg        OIDCConfig
+	oauth      oauth2.Config
+	verifier   *idTokenVerifier
+	client     *http.Client
+	endSession string
+	secure     bool
+
+	sessions *sessionStore
+	logins   *loginStore
+}
+
+// NewOIDCProvider discovers the provider's endpoints and keys at
+// cfg.Issuer.
+func NewOIDCProvider(ctx context.Context, cfg OIDCConfig) (*OIDCProvider, error) {
+	if cfg.Issuer == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+		return nil, fmt.Errorf("OIDC issuer, client ID and redirect URL are required")
+	}
+	if len(cfg.Domains) == 0 && len(cfg.Groups) == 0 {
+		return nil, fmt.Errorf("OIDC login needs at least one allowed email domain or group")
+	}
+	for _, roles := range []map[string]team.Role{cfg.Domains, cfg.Groups} {
+		for match, role := range roles {
+			if !role.IsValid() {
+				return nil, fmt.Errorf("invalid role %q for %s", role, match)
+			}
+		}
+	}
+	redirect, err := url.Parse(cfg.RedirectURL)
+	if err != nil || redirect.Host == "" {
+		return nil, fmt.Errorf("invalid OIDC redirect URL %q", cfg.RedirectURL)
+	}
+	domains := make(map[string]team.Role, len(cfg.Domains))
+	for d, role := range cfg.Domains {
+		domains[strings.ToLower(d)] = role
+	}
+	cfg.Domains = domains
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = "groups"
+	}
+	if cfg.SessionTTL <= 0 {
+		cfg.SessionTTL = 12 * time.Hour
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	meta, err := discoverProvider(ctx, client, cfg.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OIDCProvider{
+		cfg: cfg,
+		oauth: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       append([]string{"openid", "email", "profile"}, cfg.Scopes...),
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  meta.AuthorizationEndpoint,
+				TokenURL: meta.TokenEndpoint,
+			},
+		},
+		verifier: &idTokenVerifier{
+			issuer:   meta.Issuer,
+			clientID: cfg.ClientID,
+			keys:     newKeySet(client, meta.JWKSURI),
+			now:      time.Now,
+		},
+		client:     client,
+		endSession: meta.EndSessionEndpoint,
+		secure:     redirect.Scheme == "https",
+		sessions:   newSessionStore(cfg.SessionTTL),
+		logins:     newLoginStore(loginTTL),
+	}, nil
+}
+
+// EnableOIDC makes users sign in with the provider. Browsers without a
+// session are sent to /auth/login; the shared and member tokens keep
+// working for API clients. Session requests that change state must carry
+// the session's CSRF token. Pass nil to disable OIDC login.
+func (s *Server) EnableOIDC(p *OIDCProvider) {
+	s.oidc = p
+}
+
+const (
+	sessionCookieName = "roady_session"
+	loginCookieName   = "roady_login"
+	csrfFieldName     = "csrf_token"
+	csrfHeaderName    = "X-CSRF-Token"
+
+	// loginTTL bounds the time between /auth/login and the callback.
+	loginTTL = 10 * time.Minute
+)
+
+// session is a signed-in dashboard user.
+type session struct {
+	Member  team.Member
+	CSRF    string
+	IDToken string
+	Expires time.Time
+}
+
+type sessionKey struct{}
+
+// sessionFrom returns the OIDC session of the request, if any.
+func sessionFrom(ctx context.Context) (*session, bool) {
+	sess, ok := ctx.Value(sessionKey{}).(*session)
+	return sess, ok
+}
+
+// csrfToken is the CSRF token forms on the page must send, empty when the
+// request is not made with an OIDC session.
+func csrfToken(r *http.Request) string {
+	if sess, ok := sessionFrom(r.Context()); ok {
+		return sess.CSRF
+	}
+	return ""
+}
+
+type sessionStore struct {
+	ttl time.Duration
+
+	mu       sync.Mutex
+	sessions map[string]*session
+}
+
+func newSessionStore(ttl time.Duration) *sessionStore {
+	return &sessionStore{ttl: ttl, sessions: map[string]*session{}}
+}
+
+func (st *sessionStore) create(m team.Member, idToken string) (string, *session, error) {
+	id, err := randomString()
+	if err != nil {
+		return "", nil, err
+	}
+	csrf, err := randomString()
+	if err != nil {
+		return "", nil, err
+	}
+	now := time.Now()
+	sess := &session{Member: m, CSRF: csrf, IDToken: idToken, Expires: now.Add(st.ttl)}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	for k, old := range st.sessions {
+		if now.After(old.Expires) {
+			delete(st.sessions, k)
+		}
+	}
+	st.sessions[id] = sess
+	return id, sess, nil
+}
+
+func (st *sessionStore) get(id string) *session {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	sess, ok := st.sessions[id]
+	if !ok {
+		return nil
+	}
+	if time.Now().After(sess.Expires) {
+		delete(st.sessions, id)
+		return nil
+	}
+	return sess
+}
+
+func (st *sessionStore) remove(id string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	delete(st.sessions, id)
+}
+
+// pendingLogin is a login started at /auth/login and awaiting its callback.
+type pendingLogin struct {
+	Nonce    string
+	Verifier string
+	Next     string
+	Expires  time.Time
+}
+
+// loginStore keeps pending logins by their state parameter.
+type loginStore struct {
+	ttl time.Duration
+
+	mu     sync.Mutex
+	logins map[string]pendingLogin
+}
+
+func newLoginStore(ttl time.Duration) *loginStore {
+	return &loginStore{ttl: ttl, logins: map[string]pendingLogin{}}
+}
+
+func (st *loginStore) add(state string, l pendingLogin) {
+	now := time.Now()
+	l.Expires = now.Add(st.ttl)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	for k, old := range st.logins {
+		if now.After(old.Expires) {
+			delete(st.logins, k)
+		}
+	}
+	st.logins[state] = l
+}
+
+// take returns and forgets the pending login, so a state is used once.
+func (st *loginStore) take(state string) (pendingLogin, bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	l, ok := st.logins[state]
+	delete(st.logins, state)
+	if !ok || time.Now().After(l.Expires) {
+		return pendingLogin{}, false
+	}
+	return l, true
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// safeNext keeps post-login redirects on the dashboard.
+func safeNext(next string) string {
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.HasPrefix(next, "/\\") {
+		return "/"
+	}
+	return next
+}
+
+func (p *OIDCProvider) setCookie(w http.ResponseWriter, name, value string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   p.secure,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   maxAge,
+	})
+}
+
+func (p *OIDCProvider) handleLogin(w http.ResponseWriter, r *http.Request) {
+	state, err := randomString()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	nonce, err := randomString()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	verifier := oauth2.GenerateVerifier()
+	p.logins.add(state, pendingLogin{Nonce: nonce, Verifier: verifier, Next: safeNext(r.URL.Query().Get("next"))})
+
+	// The cookie binds the callback to the browser that started the login.
+	p.setCookie(w, loginCookieName, state, int(loginTTL.Seconds()))
+	http.Redirect(w, r, p.oauth.AuthCodeURL(state,
+		oauth2.SetAuthURLParam("nonce", nonce),
+		oauth2.S256ChallengeOption(verifier),
+	), http.StatusFound)
+}
+
+func (p *OIDCProvider) handleCallback(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if e := q.Get("error"); e != "" {
+		http.Error(w, "login failed: "+e+" "+q.Get("error_description"), http.StatusUnauthorized)
+		return
+	}
+	state := q.Get("state")
+	c, err := r.Cookie(loginCookieName)
+	if state == "" || err != nil || subtle.ConstantTimeCompare([]byte(c.Value), []byte(state)) != 1 {
+		http.Error(w, "login failed: state mismatch, start again at /auth/login", http.StatusBadRequest)
+		return
+	}
+	p.setCookie(w, loginCookieName, "", -1)
+	login, ok := p.logins.take(state)
+	if !ok {
+		http.Error(w, "login failed: login expired, start again at /auth/login", http.StatusBadRequest)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), oauth2.HTTPClient, p.client)
+	tok, err := p.oauth.Exchange(ctx, q.Get("code"), oauth2.VerifierOption(login.Verifier))
+	if err != nil {
+		log.Printf("OIDC code exchange failed: %v", err)
+		http.Error(w, "login failed: code exchange", http.StatusUnauthorized)
+		return
+	}
+	rawID, _ := tok.Extra("id_token").(string)
+	if rawID == "" {
+		http.Error(w, "login failed: no ID token", http.StatusUnauthorized)
+		return
+	}
+	claims, err := p.verifier.Verify(r.Context(), rawID, login.Nonce)
+	if err != nil {
+		log.Printf("OIDC login rejected: %v", err)
+		http.Error(w, "login failed: "+err.Error(), http.StatusUnauthorized)
+		return
+	}
+	member, err := p.memberFor(claims)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	id, _, err := p.sessions.create(member, rawID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	p.setCookie(w, sessionCookieName, id, int(p.cfg.SessionTTL.Seconds()))
+	http.Redirect(w, r, login.Next, http.StatusSeeOther)
+}
+
+// memberFor maps the claims of a verified ID token to the team member the
+// user acts as. The member is named by their email when the provider has
+// verified it, otherwise by the subject namespaced by the issuer; names the
+// user can pick, like preferred_username, are never used.
+func (p *OIDCProvider) memberFor(c *idTokenClaims) (team.Member, error) {
+	verified := c.Email != "" && bool(c.EmailVerified)
+	var role team.Role
+	if verified {
+		if _, domain, ok := strings.Cut(c.Email, "@"); ok {
+			role = higherRole(role, p.cfg.Domains[strings.ToLower(domain)])
+		}
+	}
+	for _, g := range c.Groups(p.cfg.GroupsClaim) {
+		role = higherRole(role, p.cfg.Groups[g])
+	}
+	if role == "" {
+		return team.Member{}, fmt.Errorf("%w: %s is not in an allowed email domain or group", team.ErrForbidden, c.Subject)
+	}
+
+	name := c.Issuer + "#" + c.Subject
+	if verified {
+		name = c.Email
+	}
+	return team.Member{Name: name, Role: role}, nil
+}
+
+// higherRole returns the role with more permissions; an empty role has none.
+func higherRole(a, b team.Role) team.Role {
+	rank := map[team.Role]int{team.RoleViewer: 1, team.RoleMember: 2, team.RoleAdmin: 3}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+// handleLogoutPage asks for confirmation, so logging out stays a POST that
+// carries the CSRF token.
+func (s *Server) handleLogoutPage(w http.ResponseWriter, r *http.Request) {
+	sess, ok := sessionFrom(r.Context())
+	if !ok {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+	s.render(w, "logout.html", struct {
+		Title     string
+		User      string
+		CSRFToken string
+	}{"Log out", sess.Member.Name, sess.CSRF})
+}
+
+func (p *OIDCProvider) handleLogout(w http.ResponseWriter, r *http.Request) {
+	sess, _ := sessionFrom(r.Context())
+	if c, err := r.Cookie(sessionCookieName); err == nil {
+		p.sessions.remove(c.Value)
+	}
+	p.setCookie(w, sessionCookieName, "", -1)
+
+	dest := "/"
+	if p.endSession != "" && sess != nil {
+		q := url.Values{"client_id": {p.cfg.ClientID}, "id_token_hint": {sess.IDToken}}
+		dest = p.endSession + "?" + q.Encode()
+	}
+	http.Redirect(w, r, dest, http.StatusSeeOther)
+}
+
+// validCSRF checks the token a state-changing request sends as form field
+// or header against its session.
+func validCSRF(r *http.Request, sess *session) bool {
+	got := r.Header.Get(csrfHeaderName)
+	if got == "" {
+		got = r.PostFormValue(csrfFieldName)
+	}
+	return got != "" && subtle.ConstantTimeCompare([]byte(got), []byte(sess.CSRF)) == 1
+}
+
+// hasTokenCredential reports whether the request presents a shared or member
+// token rather than relying on an OIDC session.
+func hasTokenCredential(r *http.Request) bool {
+	if strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") || r.URL.Query().Has("token") {
+		return true
+	}
+	_, err := r.Cookie(authCookieName)
+	return err == nil
+}
+
+// middleware lets /auth/* through, serves requests with a valid session as
+// the session's member, hands token-authenticated requests to tokenAuth
+// (nil when no token gate is configured) and sends everyone else to log in.
+func (p *OIDCProvider) middleware(next, tokenAuth http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie(sessionCookieName); err == nil {
+			if sess := p.sessions.get(c.Value); sess != nil {
+				if !isSafeMethod(r.Method) && !validCSRF(r, sess) {
+					http.Error(w, "invalid CSRF token", http.StatusForbidden)
+					return
+				}
+				ctx := team.WithCaller(r.Context(), sess.Member)
+				ctx = context.WithValue(ctx, sessionKey{}, sess)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+		}
+
+		switch {
+		case r.URL.Path == "/auth/login" || r.URL.Path == "/auth/callback":
+			next.ServeHTTP(w, r)
+		case r.URL.Path == "/auth/logout" && !isSafeMethod(r.Method):
+			// Without a live session there is nothing to protect; just
+			// clear the cookie.
+			p.handleLogout(w, r)
+		case tokenAuth != nil && hasTokenCredential(r):
+			tokenAuth.ServeHTTP(w, r)
+		case isSafeMethod(r.Method) && strings.Contains(r.Header.Get("Accept"), "text/html"):
+			http.Redirect(w, r, "/auth/login?"+url.Values{"next": {r.URL.RequestURI()}}.Encode(), http.StatusFound)
+		default:
+			w.Header().Set("WWW-Authenticate", `Bearer realm="roady-dashboard"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+		}
+	})
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
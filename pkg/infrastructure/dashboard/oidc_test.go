@@ -0,0 +1,462 @@
+package dashboard
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
+)
+
+// fakeIdP is an in-process OpenID Provider. Its authorization endpoint signs
+// the configured user in without a prompt.
+type fakeIdP struct {
+	*httptest.Server
+	key      *rsa.PrivateKey
+	clientID string
+	secret   string
+
+	mu     sync.Mutex
+	claims map[string]any // claims of the user who signs in next
+	codes  map[string]fakeGrant
+	// mutate, when set, changes the claims of issued ID tokens.
+	mutate func(map[string]any)
+}
+
+type fakeGrant struct {
+	nonce, challenge, redirect string
+}
+
+func newFakeIdP(t *testing.T) *fakeIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &fakeIdP{key: key, clientID: "roady", secret: "s3cret", codes: map[string]fakeGrant{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(providerMetadata{
+			Issuer:                idp.URL,
+			AuthorizationEndpoint: idp.URL + "/authorize",
+			TokenEndpoint:         idp.URL + "/token",
+			JWKSURI:               idp.URL + "/jwks",
+			EndSessionEndpoint:    idp.URL + "/logout",
+		})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []jsonWebKey{{
+			Kty: "RSA", Kid: "k1", Use: "sig",
+			N: base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E: base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("GET /authorize", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("client_id") != idp.clientID || q.Get("code_challenge_method") != "S256" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		code, _ := randomString()
+		idp.mu.Lock()
+		idp.codes[code] = fakeGrant{nonce: q.Get("nonce"), challenge: q.Get("code_challenge"), redirect: q.Get("redirect_uri")}
+		idp.mu.Unlock()
+		http.Redirect(w, r, q.Get("redirect_uri")+"?"+url.Values{"code": {code}, "state": {q.Get("state")}}.Encode(), http.StatusFound)
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		id, secret, ok := r.BasicAuth()
+		if !ok {
+			id, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+		}
+		idp.mu.Lock()
+		grant, found := idp.codes[r.PostForm.Get("code")]
+		delete(idp.codes, r.PostForm.Get("code"))
+		idp.mu.Unlock()
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if id != idp.clientID || secret != idp.secret || !found ||
+			base64.RawURLEncoding.EncodeToString(sum[:]) != grant.challenge ||
+			r.PostForm.Get("redirect_uri") != grant.redirect {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		claims := idp.claimsFor(grant.nonce)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "at", "token_type": "Bearer", "expires_in": 3600,
+			"id_token": idp.sign(t, claims),
+		})
+	})
+	idp.Server = httptest.NewServer(mux)
+	t.Cleanup(idp.Close)
+	return idp
+}
+
+func (idp *fakeIdP) signIn(claims map[string]any) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	idp.claims = claims
+}
+
+// claimsFor returns the ID token claims of the signed-in user.
+func (idp *fakeIdP) claimsFor(nonce string) map[string]any {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	now := time.Now()
+	claims := map[string]any{
+		"iss": idp.URL, "aud": idp.clientID, "sub": "user-1", "nonce": nonce,
+		"iat": now.Unix(), "exp": now.Add(time.Hour).Unix(),
+	}
+	for k, v := range idp.claims {
+		claims[k] = v
+	}
+	if idp.mutate != nil {
+		idp.mutate(claims)
+	}
+	return claims
+}
+
+func (idp *fakeIdP) sign(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "k1", "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, idp.key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// newOIDCDashboard serves a dashboard with task actions behind OIDC login
+// against idp.
+func newOIDCDashboard(t *testing.T, idp *fakeIdP, actions TaskActions) (*httptest.Server, *http.Client) {
+	t.Helper()
+	plan := &planning.Plan{Tasks: []planning.Task{{ID: "t-1", Title: "Ready task"}}}
+	srv, err := NewServer(":0", &kanbanStubProvider{plan: plan, state: &planning.ExecutionState{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.EnableTaskActions(actions)
+	srv.EnableAuthToken("shared")
+
+	// The redirect URL must be known before the provider, and the provider
+	// before the handler, so the test server routes through a late-bound
+	// handler.
+	var h http.Handler
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { h.ServeHTTP(w, r) }))
+	t.Cleanup(ts.Close)
+
+	p, err := NewOIDCProvider(context.Background(), OIDCConfig{
+		Issuer:       idp.URL,
+		ClientID:     idp.clientID,
+		ClientSecret: idp.secret,
+		RedirectURL:  ts.URL + "/auth/callback",
+		Domains:      map[string]team.Role{"example.com": team.RoleMember},
+		Groups:       map[string]team.Role{"roady-admins": team.RoleAdmin, "auditors": team.RoleViewer},
+	})
+	if err != nil {
+		t.Fatalf("NewOIDCProvider: %v", err)
+	}
+	srv.EnableOIDC(p)
+	h = srv.handler()
+
+	jar, _ := cookiejar.New(nil)
+	return ts, &http.Client{Jar: jar}
+}
+
+func getHTML(t *testing.T, c *http.Client, u string) (*http.Response, string) {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, u, nil)
+	req.Header.Set("Accept", "text/html")
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // test
+	body, _ := io.ReadAll(resp.Body)
+	return resp, string(body)
+}
+
+var csrfInput = regexp.MustCompile(`name="csrf_token" value="([^"]+)"`)
+
+func TestOIDC_LoginActAndLogout(t *testing.T) {
+	idp := newFakeIdP(t)
+	idp.signIn(map[string]any{"email": "ada@example.com", "email_verified": true})
+	a := &fakeTaskActions{}
+	ts, client := newOIDCDashboard(t, idp, a)
+
+	resp, body := getHTML(t, client, ts.URL+"/kanban")
+	if resp.StatusCode != http.StatusOK || resp.Request.URL.Path != "/kanban" {
+		t.Fatalf("login flow ended at %s with %d", resp.Request.URL, resp.StatusCode)
+	}
+	m := csrfInput.FindStringSubmatch(body)
+	if m == nil {
+		t.Fatal("Kanban page lacks the CSRF token")
+	}
+	csrf := m[1]
+
+	post := func(form url.Values, header string) int {
+		req, _ := http.NewRequest(http.MethodPost, ts.URL+"/actions/task/start", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if header != "" {
+			req.Header.Set(csrfHeaderName, header)
+		}
+		noFollow := *client
+		noFollow.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+		resp, err := noFollow.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if code := post(url.Values{"id": {"t-1"}}, ""); code != http.StatusForbidden {
+		t.Errorf("action without CSRF token: got %d, want 403", code)
+	}
+	if code := post(url.Values{"id": {"t-1"}, "csrf_token": {"forged"}}, ""); code != http.StatusForbidden {
+		t.Errorf("action with forged CSRF token: got %d, want 403", code)
+	}
+	if code := post(url.Values{"id": {"t-1"}, "csrf_token": {csrf}}, ""); code != http.StatusSeeOther {
+		t.Fatalf("action with CSRF form field: got %d, want 303", code)
+	}
+	if a.startCalled == nil || a.startCalled.owner != "ada@example.com" {
+		t.Errorf("StartTask call = %+v, want owner ada@example.com", a.startCalled)
+	}
+	if code := post(url.Values{"id": {"t-2"}}, csrf); code != http.StatusSeeOther {
+		t.Errorf("action with CSRF header: got %d, want 303", code)
+	}
+
+	// Logging out needs the CSRF token too, then ends the session at the
+	// provider.
+	_, page := getHTML(t, client, ts.URL+"/auth/logout")
+	if !strings.Contains(page, "ada@example.com") || !strings.Contains(page, csrf) {
+		t.Fatalf("logout page lacks user or CSRF token: %s", page)
+	}
+	noFollow := *client
+	noFollow.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	resp, err := noFollow.PostForm(ts.URL+"/auth/logout", url.Values{"csrf_token": {"forged"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("logout with forged CSRF token: got %d, want 403", resp.StatusCode)
+	}
+	resp, err = noFollow.PostForm(ts.URL+"/auth/logout", url.Values{"csrf_token": {csrf}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if loc := resp.Header.Get("Location"); !strings.HasPrefix(loc, idp.URL+"/logout?") {
+		t.Errorf("logout redirected to %q, want the provider's end-session endpoint", loc)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/api/plan", nil)
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("API after logout: got %d, want 401", resp.StatusCode)
+	}
+}
+
+func TestOIDC_GroupRoles(t *testing.T) {
+	idp := newFakeIdP(t)
+	// The unverified email does not count; the group makes the user a viewer.
+	idp.signIn(map[string]any{"email": "vic@example.com", "email_verified": false, "groups": []string{"auditors"}})
+	a := &fakeTaskActions{}
+	ts, client := newOIDCDashboard(t, idp, a)
+
+	_, body := getHTML(t, client, ts.URL+"/kanban")
+	m := csrfInput.FindStringSubmatch(body)
+	if m == nil {
+		t.Fatal("Kanban page lacks the CSRF token")
+	}
+	resp, err := client.PostForm(ts.URL+"/actions/task/start", url.Values{"id": {"t-1"}, "csrf_token": {m[1]}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden || a.startCalled != nil {
+		t.Errorf("viewer action: got %d (called %v), want 403", resp.StatusCode, a.startCalled != nil)
+	}
+}
+
+func TestOIDC_RejectsUsersOutsideDomainsAndGroups(t *testing.T) {
+	idp := newFakeIdP(t)
+	idp.signIn(map[string]any{"email": "eve@elsewhere.org", "email_verified": true, "groups": []string{"other"}})
+	ts, client := newOIDCDashboard(t, idp, &fakeTaskActions{})
+
+	resp, _ := getHTML(t, client, ts.URL+"/kanban")
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("got %d, want 403", resp.StatusCode)
+	}
+}
+
+func TestOIDC_RejectsBadIDTokens(t *testing.T) {
+	cases := map[string]func(map[string]any){
+		"wrong audience": func(c map[string]any) { c["aud"] = "someone-else" },
+		"wrong issuer":   func(c map[string]any) { c["iss"] = "https://evil.example" },
+		"expired":        func(c map[string]any) { c["exp"] = time.Now().Add(-time.Hour).Unix() },
+		"replayed nonce": func(c map[string]any) { c["nonce"] = "old" },
+	}
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			idp := newFakeIdP(t)
+			idp.signIn(map[string]any{"email": "ada@example.com", "email_verified": true})
+			idp.mutate = mutate
+			ts, client := newOIDCDashboard(t, idp, &fakeTaskActions{})
+
+			resp, _ := getHTML(t, client, ts.URL+"/kanban")
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("got %d, want 401", resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestOIDC_CallbackNeedsLoginState(t *testing.T) {
+	idp := newFakeIdP(t)
+	ts, client := newOIDCDashboard(t, idp, &fakeTaskActions{})
+
+	resp, _ := getHTML(t, client, ts.URL+"/auth/callback?code=x&state=unknown")
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("callback without login: got %d, want 400", resp.StatusCode)
+	}
+}
+
+func TestOIDC_TokensStillWork(t *testing.T) {
+	idp := newFakeIdP(t)
+	ts, client := newOIDCDashboard(t, idp, &fakeTaskActions{})
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/api/plan", nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("API without credentials: got %d, want 401", resp.StatusCode)
+	}
+
+	req.Header.Set("Authorization", "Bearer shared")
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("API with shared token: got %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestIDTokenVerifier_RejectsForgedSignature(t *testing.T) {
+	idp := newFakeIdP(t)
+	v := &idTokenVerifier{
+		issuer: idp.URL, clientID: idp.clientID,
+		keys: newKeySet(http.DefaultClient, idp.URL+"/jwks"), now: time.Now,
+	}
+	claims := idp.claimsFor("n")
+	token := idp.sign(t, claims)
+	if _, err := v.Verify(context.Background(), token, "n"); err != nil {
+		t.Fatalf("valid token rejected: %v", err)
+	}
+
+	parts := strings.Split(token, ".")
+	claims["sub"] = "admin"
+	payload, _ := json.Marshal(claims)
+	forged := parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload) + "." + parts[2]
+	if _, err := v.Verify(context.Background(), forged, "n"); err == nil {
+		t.Error("token with altered claims accepted")
+	}
+
+	none := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","kid":"k1"}`)) + "." + parts[1] + "."
+	if _, err := v.Verify(context.Background(), none, "n"); err == nil {
+		t.Error("unsigned token accepted")
+	}
+}
+
+func TestNewOIDCProvider_Validation(t *testing.T) {
+	idp := newFakeIdP(t)
+	base := OIDCConfig{Issuer: idp.URL, ClientID: "roady", RedirectURL: "http://localhost:3000/auth/callback"}
+
+	if _, err := NewOIDCProvider(context.Background(), base); err == nil {
+		t.Error("expected error without allowed domains or groups")
+	}
+	bad := base
+	bad.Domains = map[string]team.Role{"example.com": "owner"}
+	if _, err := NewOIDCProvider(context.Background(), bad); err == nil {
+		t.Error("expected error for an invalid role")
+	}
+	wrong := base
+	wrong.Domains = map[string]team.Role{"example.com": team.RoleMember}
+	wrong.Issuer = idp.URL + "/other"
+	if _, err := NewOIDCProvider(context.Background(), wrong); err == nil {
+		t.Error("expected discovery error for an unknown issuer")
+	}
+}
+
+func TestSafeNext(t *testing.T) {
+	for in, want := range map[string]string{
+		"/kanban?x=1":          "/kanban?x=1",
+		"":                     "/",
+		"//evil.example":       "/",
+		"/\\evil.example":      "/",
+		"https://evil.example": "/",
+	} {
+		if got := safeNext(in); got != want {
+			t.Errorf("safeNext(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestOIDC_MemberNames(t *testing.T) {
+	p := &OIDCProvider{cfg: OIDCConfig{
+		Domains:     map[string]team.Role{"example.com": team.RoleMember},
+		Groups:      map[string]team.Role{"staff": team.RoleViewer},
+		GroupsClaim: "groups",
+	}}
+	staff := map[string]json.RawMessage{"groups": json.RawMessage(`["staff"]`), "preferred_username": json.RawMessage(`"ada@example.com"`)}
+
+	tests := []struct {
+		name   string
+		claims idTokenClaims
+		want   string
+	}{
+		{"verified email", idTokenClaims{Issuer: "https://idp", Subject: "u1", Email: "ada@example.com", EmailVerified: true}, "ada@example.com"},
+		{"unverified email", idTokenClaims{Issuer: "https://idp", Subject: "u2", Email: "ada@example.com", Raw: staff}, "https://idp#u2"},
+		{"no email", idTokenClaims{Issuer: "https://idp", Subject: "u3", Raw: staff}, "https://idp#u3"},
+	}
+	for _, tt := range tests {
+		m, err := p.memberFor(&tt.claims)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if m.Name != tt.want {
+			t.Errorf("%s: member %q, want %q", tt.name, m.Name, tt.want)
+		}
+	}
+}
@@ -0,0 +1,375 @@
+package dashboard
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errInvalidIDToken wraps every ID token verification failure.
+var errInvalidIDToken = errors.New("invalid ID token")
+
+// clockSkew is the leeway allowed on the ID token's expiry and issue time.
+const clockSkew = time.Minute
+
+// providerMetadata is the part of the OpenID Provider discovery document
+// the dashboard uses.
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+	EndSessionEndpoint    string `json:"end_session_endpoint,omitempty"`
+}
+
+// discoverProvider fetches the discovery document of issuer and checks that
+// it names issuer as its own.
+func discoverProvider(ctx context.Context, client *http.Client, issuer string) (*providerMetadata, error) {
+	var meta providerMetadata
+	wellKnown := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	if err := getJSON(ctx, client, wellKnown, &meta); err != nil {
+		return nil, fmt.Errorf("discover OIDC provider: %w", err)
+	}
+	if strings.TrimSuffix(meta.Issuer, "/") != strings.TrimSuffix(issuer, "/") {
+		return nil, fmt.Errorf("discover OIDC provider: issuer %q does not match %q", meta.Issuer, issuer)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, fmt.Errorf("discover OIDC provider: %s lacks authorization, token or JWKS endpoint", wellKnown)
+	}
+	return &meta, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck // read-only response body
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// jsonWebKey is one key of a JWKS document. Only RSA and EC P-256 signing
+// keys are used.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		exp := new(big.Int).SetBytes(e)
+		if !exp.IsInt64() || exp.Int64() > 1<<31-1 || exp.Int64() < 3 {
+			return nil, fmt.Errorf("unsupported RSA exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		uncompressed := append(append([]byte{4}, leftPad(x, 32)...), leftPad(y, 32)...)
+		return ecdsa.ParseUncompressedPublicKey(elliptic.P256(), uncompressed)
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+func leftPad(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	return append(make([]byte, size-len(b)), b...)
+}
+
+// keySet caches the provider's signing keys by key ID. Unknown key IDs
+// trigger a refetch, at most once per minRefresh, so key rotation is picked
+// up without restarting the dashboard.
+type keySet struct {
+	url    string
+	client *http.Client
+
+	mu      sync.Mutex
+	keys    map[string]crypto.PublicKey
+	fetched time.Time
+}
+
+const minRefresh = time.Minute
+
+func newKeySet(client *http.Client, url string) *keySet {
+	return &keySet{url: url, client: client}
+}
+
+func (k *keySet) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if key := k.lookup(kid); key != nil {
+		return key, nil
+	}
+	if time.Since(k.fetched) < minRefresh {
+		return nil, fmt.Errorf("%w: unknown key %q", errInvalidIDToken, kid)
+	}
+	if err := k.refresh(ctx); err != nil {
+		return nil, err
+	}
+	if key := k.lookup(kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: unknown key %q", errInvalidIDToken, kid)
+}
+
+// lookup finds the key by ID. A token without a key ID matches a set that
+// holds a single key.
+func (k *keySet) lookup(kid string) crypto.PublicKey {
+	if kid == "" && len(k.keys) == 1 {
+		for _, key := range k.keys {
+			return key
+		}
+	}
+	return k.keys[kid]
+}
+
+func (k *keySet) refresh(ctx context.Context) error {
+	var doc struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := getJSON(ctx, k.client, k.url, &doc); err != nil {
+		return fmt.Errorf("fetch JWKS: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey, len(doc.Keys))
+	for _, jwk := range doc.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		pub, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = pub
+	}
+	k.keys = keys
+	k.fetched = time.Now()
+	return nil
+}
+
+// idTokenClaims are the ID token claims the dashboard reads. Raw keeps every
+// claim so the configured groups claim can be looked up.
+type idTokenClaims struct {
+	Issuer          string       `json:"iss"`
+	Subject         string       `json:"sub"`
+	Audience        stringList   `json:"aud"`
+	AuthorizedParty string       `json:"azp"`
+	Expiry          float64      `json:"exp"`
+	IssuedAt        float64      `json:"iat"`
+	Nonce           string       `json:"nonce"`
+	Email           string       `json:"email"`
+	EmailVerified   flexibleBool `json:"email_verified"`
+
+	Raw map[string]json.RawMessage `json:"-"`
+}
+
+// stringList decodes a claim that is either a string or an array of them.
+type stringList []string
+
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*l = stringList{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*l = many
+	return nil
+}
+
+// flexibleBool decodes a boolean claim that some providers send as a string.
+type flexibleBool bool
+
+func (b *flexibleBool) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "true":
+		*b = true
+	case "false", "null":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	return nil
+}
+
+// Groups returns the string values of the named claim.
+func (c *idTokenClaims) Groups(claim string) []string {
+	raw, ok := c.Raw[claim]
+	if !ok {
+		return nil
+	}
+	var groups stringList
+	if err := json.Unmarshal(raw, &groups); err != nil {
+		return nil
+	}
+	return groups
+}
+
+// idTokenVerifier checks ID tokens issued to one client by one provider.
+type idTokenVerifier struct {
+	issuer   string
+	clientID string
+	keys     *keySet
+	now      func() time.Time
+}
+
+// Verify checks the token's signature, issuer, audience, expiry and nonce
+// and returns its claims.
+func (v *idTokenVerifier) Verify(ctx context.Context, token, nonce string) (*idTokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed", errInvalidIDToken)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: header: %v", errInvalidIDToken, err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature: %v", errInvalidIDToken, err)
+	}
+	key, err := v.keys.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), sig); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidIDToken, err)
+	}
+
+	var claims idTokenClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: claims: %v", errInvalidIDToken, err)
+	}
+	if err := decodeSegment(parts[1], &claims.Raw); err != nil {
+		return nil, fmt.Errorf("%w: claims: %v", errInvalidIDToken, err)
+	}
+
+	now := v.now()
+	switch {
+	case claims.Issuer != v.issuer:
+		return nil, fmt.Errorf("%w: issued by %q", errInvalidIDToken, claims.Issuer)
+	case !containsString(claims.Audience, v.clientID):
+		return nil, fmt.Errorf("%w: not issued to this client", errInvalidIDToken)
+	case len(claims.Audience) > 1 && claims.AuthorizedParty != "" && claims.AuthorizedParty != v.clientID:
+		return nil, fmt.Errorf("%w: authorized party %q", errInvalidIDToken, claims.AuthorizedParty)
+	case claims.Expiry == 0 || now.After(unixTime(claims.Expiry).Add(clockSkew)):
+		return nil, fmt.Errorf("%w: expired", errInvalidIDToken)
+	case claims.IssuedAt != 0 && unixTime(claims.IssuedAt).After(now.Add(clockSkew)):
+		return nil, fmt.Errorf("%w: issued in the future", errInvalidIDToken)
+	case claims.Subject == "":
+		return nil, fmt.Errorf("%w: no subject", errInvalidIDToken)
+	case claims.Nonce != nonce:
+		return nil, fmt.Errorf("%w: nonce mismatch", errInvalidIDToken)
+	}
+	return &claims, nil
+}
+
+func decodeSegment(seg string, v any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// verifySignature checks a JWS signature. Only asymmetric algorithms are
+// accepted, so a token cannot be signed with "none" or the client secret.
+func verifySignature(alg string, key crypto.PublicKey, signed, sig []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384":
+		hash = crypto.SHA384
+	case "RS512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return fmt.Errorf("algorithm %s does not match RSA key", alg)
+		}
+		return rsa.VerifyPKCS1v15(pub, hash, digest, sig)
+	case *ecdsa.PublicKey:
+		if alg != "ES256" || len(sig) != 64 {
+			return fmt.Errorf("algorithm %s does not match EC key", alg)
+		}
+		r := new(big.Int).SetBytes(sig[:32])
+		s := new(big.Int).SetBytes(sig[32:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return errors.New("signature mismatch")
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported key %T", key)
+}
+
+func unixTime(secs float64) time.Time {
+	return time.Unix(int64(secs), 0)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package dashboard
 
 import (
 	"encoding/json"
+	"html/template"
 	"net/http"
 	"path/filepath"
 	"sort"
@@ -31,6 +32,9 @@ type OrgKanbanRef struct {
 	SubProject string `json:"sub_project,omitempty"`
 	Total      int    `json:"total"`
 	Done       int    `json:"done"`
+
+	// ReportQuery selects the project on the report pages.
+	ReportQuery template.URL `json:"-"`
 }
 
 // OrgKanbanProvider supplies the discovery walker. In tests this is a fake;
@@ -41,10 +45,10 @@ type OrgKanbanProvider interface {
 
 // repoOpener constructs a storage repository for a discovered project. Split
 // out so tests can inject in-memory data.
-type repoOpener func(p application.DiscoveredProject) (*storage.FilesystemRepository, error)
+type repoOpener func(p application.DiscoveredProject) (storage.Repository, error)
 
-func defaultRepoOpener(p application.DiscoveredProject) (*storage.FilesystemRepository, error) {
-	return storage.NewFilesystemRepositoryForProject(p.Path, p.SubProject)
+func defaultRepoOpener(p application.DiscoveredProject) (storage.Repository, error) {
+	return storage.OpenRepository(p.Path, p.SubProject)
 }
 
 // buildOrgKanbanBoard discovers every project under root and aggregates their
@@ -72,6 +76,7 @@ func buildOrgKanbanBoard(prov OrgKanbanProvider, open repoOpener) OrgKanbanBoard
 
 	for _, p := range projects {
 		label := projectLabel(p)
+		query := template.URL(projectScope{Path: p.Path, Project: p.SubProject}.Query())
 		repo, err := open(p)
 		if err != nil {
 			continue
@@ -79,7 +84,7 @@ func buildOrgKanbanBoard(prov OrgKanbanProvider, open repoOpener) OrgKanbanBoard
 		plan, err := repo.LoadPlan()
 		if err != nil || plan == nil {
 			board.Projects = append(board.Projects, OrgKanbanRef{
-				Label: label, Path: repo.ProjectBase(), SubProject: p.SubProject,
+				Label: label, Path: repo.ProjectBase(), SubProject: p.SubProject, ReportQuery: query,
 			})
 			continue
 		}
@@ -98,10 +103,11 @@ func buildOrgKanbanBoard(prov OrgKanbanProvider, open repoOpener) OrgKanbanBoard
 			}
 		}
 		ref := OrgKanbanRef{
-			Label:      label,
-			Path:       repo.ProjectBase(),
-			SubProject: p.SubProject,
-			Total:      sub.TotalTasks,
+			Label:       label,
+			Path:        repo.ProjectBase(),
+			SubProject:  p.SubProject,
+			Total:       sub.TotalTasks,
+			ReportQuery: query,
 		}
 		for _, sc := range sub.Columns {
 			if sc.Status == string(planning.StatusDone) {
@@ -142,10 +148,13 @@ func projectLabel(p application.DiscoveredProject) string {
 	return base + "/" + p.SubProject
 }
 
-// orgKanbanData is the template input.
+// orgKanbanData is the template input. Reports links each project to its
+// report pages.
 type orgKanbanData struct {
-	Title string
-	Board OrgKanbanBoard
+	Title     string
+	Board     OrgKanbanBoard
+	Reports   bool
+	CSRFToken string // set for OIDC sessions; actions must send it
 }
 
 // orgKanbanHandler returns an http.HandlerFunc bound to a provider+opener.
@@ -153,7 +162,7 @@ type orgKanbanData struct {
 func (s *Server) orgKanbanHandler(prov OrgKanbanProvider, open repoOpener) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		board := buildOrgKanbanBoard(prov, open)
-		s.render(w, "org_kanban.html", orgKanbanData{Title: "Org Kanban", Board: board})
+		s.render(w, "org_kanban.html", orgKanbanData{Title: "Org Kanban", Board: board, Reports: s.orgInsights != nil, CSRFToken: csrfToken(r)})
 	}
 }
 
@@ -0,0 +1,122 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+)
+
+// ScheduleProvider computes the critical path and simulated schedule of the
+// current plan. The dashboard server takes this as an optional dependency;
+// when nil, /schedule shows a hint and the Kanban board marks no critical
+// tasks.
+type ScheduleProvider interface {
+	GetSchedule() (*planning.Schedule, error)
+}
+
+// EnableSchedule wires GET /api/schedule, the /schedule page and critical
+// task highlighting on the Kanban board. Pass nil to leave them out.
+func (s *Server) EnableSchedule(p ScheduleProvider) {
+	s.scheduleProvider = p
+}
+
+// scheduleRow is one Gantt row; Offset and Width are percentages of the
+// total duration.
+type scheduleRow struct {
+	Task   planning.ScheduledTask
+	Offset float64
+	Width  float64
+	Start  string
+	Finish string
+}
+
+// scheduleData is the template data of the schedule page.
+type scheduleData struct {
+	Title    string
+	Schedule *planning.Schedule
+	Rows     []scheduleRow
+	Finishes string
+	Error    string
+}
+
+// buildScheduleRows lays out the remaining tasks as Gantt bars.
+func buildScheduleRows(sched *planning.Schedule) []scheduleRow {
+	var rows []scheduleRow
+	for _, t := range sched.Tasks {
+		if t.Remaining == 0 {
+			continue
+		}
+		row := scheduleRow{
+			Task:   t,
+			Start:  sched.Date(t.Start).Format("Jan 2 15:04"),
+			Finish: sched.Date(t.Finish).Format("Jan 2 15:04"),
+		}
+		if sched.TotalHours > 0 {
+			row.Offset = t.Start / sched.TotalHours * 100
+			row.Width = (t.Finish - t.Start) / sched.TotalHours * 100
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// markCritical flags the board's critical tasks and records the slack of
+// the remaining ones.
+func markCritical(board *KanbanBoard, sched *planning.Schedule) {
+	if sched == nil {
+		return
+	}
+	for i := range board.Columns {
+		for j := range board.Columns[i].Tasks {
+			view := &board.Columns[i].Tasks[j]
+			if t, ok := sched.Task(view.Task.ID); ok && t.Remaining > 0 {
+				view.Critical = t.Critical
+				view.Slack = t.Slack
+			}
+		}
+	}
+}
+
+// schedule returns the current schedule, or nil when no provider is wired
+// or it fails.
+func (s *Server) schedule() *planning.Schedule {
+	if s.scheduleProvider == nil {
+		return nil
+	}
+	sched, err := s.scheduleProvider.GetSchedule()
+	if err != nil {
+		return nil
+	}
+	return sched
+}
+
+func (s *Server) handleSchedule(w http.ResponseWriter, r *http.Request) {
+	data := scheduleData{Title: "Schedule"}
+	if s.scheduleProvider == nil {
+		data.Error = "Scheduling is not enabled for this dashboard."
+		s.render(w, "schedule.html", data)
+		return
+	}
+
+	sched, err := s.scheduleProvider.GetSchedule()
+	if err != nil {
+		data.Error = err.Error()
+	} else if sched != nil {
+		data.Schedule = sched
+		data.Rows = buildScheduleRows(sched)
+		data.Finishes = sched.FinishesAt().Format("Mon Jan 2 15:04")
+	}
+	s.render(w, "schedule.html", data)
+}
+
+func (s *Server) handleAPISchedule(w http.ResponseWriter, r *http.Request) {
+	sched, err := s.scheduleProvider.GetSchedule()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(sched)
+}
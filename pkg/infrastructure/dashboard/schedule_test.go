@@ -0,0 +1,102 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+)
+
+// stubScheduleProvider schedules the stub plan with unlimited capacity.
+type stubScheduleProvider struct {
+	provider *kanbanStubProvider
+	err      error
+}
+
+func (s *stubScheduleProvider) GetSchedule() (*planning.Schedule, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return planning.BuildSchedule(s.provider.plan, s.provider.state, planning.ScheduleOptions{})
+}
+
+func newScheduleTestServer(t *testing.T) *Server {
+	t.Helper()
+	plan := sampleKanbanPlan()
+	plan.Tasks[0].Estimate = "2d" // a-ready is the longest remaining task
+	provider := &kanbanStubProvider{plan: plan, state: sampleKanbanState()}
+	srv, err := NewServer(":0", provider)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.EnableSchedule(&stubScheduleProvider{provider: provider})
+	return srv
+}
+
+func TestScheduleHTMLHandler(t *testing.T) {
+	srv := newScheduleTestServer(t)
+	rec := httptest.NewRecorder()
+	srv.handleSchedule(rec, httptest.NewRequest(http.MethodGet, "/schedule", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{"Critical path", `<tr class="critical">`, `class="bar critical" style="left: 0.00%; width: 100.00%"`, "gantt\n"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %q", want)
+		}
+	}
+	if strings.Contains(body, ">d-done<") {
+		t.Error("complete tasks should not be scheduled")
+	}
+
+	disabled, err := NewServer(":0", &kanbanStubProvider{plan: sampleKanbanPlan()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec = httptest.NewRecorder()
+	disabled.handleSchedule(rec, httptest.NewRequest(http.MethodGet, "/schedule", nil))
+	if !strings.Contains(rec.Body.String(), "Scheduling is not enabled") {
+		t.Error("expected a hint when no schedule provider is wired")
+	}
+}
+
+func TestScheduleAPIHandler(t *testing.T) {
+	srv := newScheduleTestServer(t)
+	rec := httptest.NewRecorder()
+	srv.handleAPISchedule(rec, httptest.NewRequest(http.MethodGet, "/api/schedule", nil))
+
+	var sched planning.Schedule
+	if err := json.Unmarshal(rec.Body.Bytes(), &sched); err != nil {
+		t.Fatalf("json decode: %v", err)
+	}
+	if len(sched.CriticalPath) != 1 || sched.CriticalPath[0] != "a-ready" {
+		t.Errorf("critical path = %v", sched.CriticalPath)
+	}
+
+	srv.EnableSchedule(&stubScheduleProvider{err: errors.New("cycle detected")})
+	rec = httptest.NewRecorder()
+	srv.handleAPISchedule(rec, httptest.NewRequest(http.MethodGet, "/api/schedule", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", rec.Code)
+	}
+}
+
+func TestKanbanHTMLHandler_CriticalTasks(t *testing.T) {
+	srv := newScheduleTestServer(t)
+	rec := httptest.NewRecorder()
+	srv.handleKanban(rec, httptest.NewRequest(http.MethodGet, "/kanban", nil))
+
+	body := rec.Body.String()
+	if strings.Count(body, `class="card critical"`) != 1 || !strings.Contains(body, "◆ critical") {
+		t.Error("expected exactly one critical card")
+	}
+	if !strings.Contains(body, "slack 8.0h") {
+		t.Error("expected the slack of non-critical tasks on their cards")
+	}
+}
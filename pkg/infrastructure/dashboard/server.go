@@ -9,6 +9,7 @@ import (
 	"html/template"
 	"log"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
@@ -40,12 +41,43 @@ type Server struct {
 	taskActions    TaskActions
 	orgTaskActions OrgTaskActions
 
-	// Optional SSE hub for live updates. Created on first /events subscription.
+	// Optional plan editing and owner roster. When set, the Kanban board
+	// creates and edits tasks and offers team members as owners. See
+	// EnablePlanEditing, EnableOrgPlanEditing and EnableTeamRoster.
+	planEditor    PlanEditor
+	orgPlanEditor OrgPlanEditor
+	roster        TeamRoster
+
+	// Optional event search. When set, GET /api/events is registered. See
+	// EnableEventQuery.
+	eventQuerier EventQuerier
+
+	// Optional drift, debt, forecast, billing and timeline reports. See
+	// EnableInsights and EnableOrgInsights.
+	insights    Insights
+	orgInsights InsightsResolver
+
+	// Optional schedule. When set, GET /api/schedule is registered and the
+	// Kanban board highlights critical tasks. See EnableSchedule.
+	scheduleProvider ScheduleProvider
+
+	// SSE hub for live updates on /events.
 	sse *sseHub
 
-	// Optional bearer-token gate for every request. When empty, the server is
-	// public. See EnableAuthToken.
-	authToken string
+	// Optional event-log tail feeding /events. See EnableEventFeed.
+	feedMu     sync.Mutex
+	eventFeed  EventFeed
+	feedCursor string
+
+	// Optional bearer-token gate for every request. When empty and no
+	// member authenticator is set, the server is public. See EnableAuthToken
+	// and EnableMemberAuth.
+	authToken  string
+	memberAuth MemberAuthenticator
+
+	// Optional OpenID Connect login. When set, browsers sign in at
+	// /auth/login and keep a session. See EnableOIDC.
+	oidc *OIDCProvider
 }
 
 // NewServer creates a new dashboard server.
@@ -65,11 +97,25 @@ func NewServer(addr string, provider DataProvider) (*Server, error) {
 		addr:     addr,
 		provider: provider,
 		tmpl:     tmpl,
+		sse:      newSSEHub(),
 	}, nil
 }
 
 // Start starts the dashboard server.
 func (s *Server) Start() error {
+	s.server = &http.Server{
+		Addr:         s.addr,
+		Handler:      s.handler(),
+		ReadTimeout:  15 * time.Second,
+		WriteTimeout: 0, // SSE needs no write timeout
+	}
+
+	log.Printf("Dashboard server starting on %s", s.addr)
+	return s.server.ListenAndServe()
+}
+
+// handler builds the routes and wraps them in the configured auth.
+func (s *Server) handler() http.Handler {
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("GET /", s.handleIndex)
@@ -79,6 +125,7 @@ func (s *Server) Start() error {
 	mux.HandleFunc("GET /api/plan", s.handleAPIPlan)
 	mux.HandleFunc("GET /api/state", s.handleAPIState)
 	mux.HandleFunc("GET /api/kanban", s.handleAPIKanban)
+	mux.HandleFunc("GET /schedule", s.handleSchedule)
 
 	if s.orgProvider != nil {
 		mux.HandleFunc("GET /org/kanban", s.orgKanbanHandler(s.orgProvider, s.orgRepoOpener))
@@ -91,6 +138,40 @@ func (s *Server) Start() error {
 		mux.HandleFunc("POST /actions/task/block", s.handleTaskBlock)
 		mux.HandleFunc("POST /actions/task/unblock", s.handleTaskUnblock)
 		mux.HandleFunc("POST /actions/task/reopen", s.handleTaskReopen)
+		mux.HandleFunc("POST /actions/task/assign", s.handleTaskAssign)
+	}
+
+	if s.planEditor != nil {
+		mux.HandleFunc("POST /actions/task/create", s.handleTaskCreate)
+		mux.HandleFunc("POST /actions/task/edit", s.handleTaskEdit)
+	}
+
+	if s.eventQuerier != nil || s.hasInsight(func(in Insights) bool { return in.Events != nil }) {
+		mux.HandleFunc("GET /api/events", s.handleAPIEvents)
+	}
+
+	// Report pages. Always registered; they show a hint when their provider
+	// is not wired.
+	mux.HandleFunc("GET /drift", s.handleDrift)
+	mux.HandleFunc("GET /debt", s.handleDebt)
+	mux.HandleFunc("GET /forecast", s.handleForecast)
+	mux.HandleFunc("GET /billing", s.handleBilling)
+	mux.HandleFunc("GET /timeline", s.handleTimeline)
+	if s.hasInsight(func(in Insights) bool { return in.Drift != nil }) {
+		mux.HandleFunc("GET /api/drift", s.handleAPIDrift)
+	}
+	if s.hasInsight(func(in Insights) bool { return in.Debt != nil }) {
+		mux.HandleFunc("GET /api/debt", s.handleAPIDebt)
+	}
+	if s.hasInsight(func(in Insights) bool { return in.Forecast != nil }) {
+		mux.HandleFunc("GET /api/forecast", s.handleAPIForecast)
+	}
+	if s.hasInsight(func(in Insights) bool { return in.Billing != nil }) {
+		mux.HandleFunc("GET /api/billing", s.handleAPIBilling)
+	}
+
+	if s.scheduleProvider != nil {
+		mux.HandleFunc("GET /api/schedule", s.handleAPISchedule)
 	}
 
 	// SSE live-update stream. Always registered; clients reconnect on disconnect.
@@ -99,20 +180,23 @@ func (s *Server) Start() error {
 	}
 	mux.HandleFunc("GET /events", s.handleEvents)
 
-	handler := http.Handler(mux)
-	if s.authToken != "" {
-		handler = authMiddleware(s.authToken, handler)
+	if s.oidc != nil {
+		mux.HandleFunc("GET /auth/login", s.oidc.handleLogin)
+		mux.HandleFunc("GET /auth/callback", s.oidc.handleCallback)
+		mux.HandleFunc("GET /auth/logout", s.handleLogoutPage)
+		mux.HandleFunc("POST /auth/logout", s.oidc.handleLogout)
 	}
 
-	s.server = &http.Server{
-		Addr:         s.addr,
-		Handler:      handler,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 0, // SSE needs no write timeout
+	handler := http.Handler(mux)
+	var tokenAuth http.Handler
+	if s.authToken != "" || s.memberAuth != nil {
+		tokenAuth = memberAuthMiddleware(s.authToken, s.memberAuth, mux)
+		handler = tokenAuth
 	}
-
-	log.Printf("Dashboard server starting on %s", s.addr)
-	return s.server.ListenAndServe()
+	if s.oidc != nil {
+		handler = s.oidc.middleware(mux, tokenAuth)
+	}
+	return handler
 }
 
 // Shutdown gracefully shuts down the server.
@@ -139,9 +223,12 @@ type TaskView struct {
 	Status       planning.TaskStatus
 	Owner        string
 	HasLinks     bool
-	ProjectLabel string // set on cross-project Kanban cards; empty for per-project views
-	ProjectPath  string // workspace root, set on org-kanban cards so actions can route to it
-	ProjectName  string // sub-project name (empty = root project), set on org-kanban cards
+	Criteria     planning.CriteriaCoverage // acceptance criteria with evidence
+	ProjectLabel string                    // set on cross-project Kanban cards; empty for per-project views
+	ProjectPath  string                    // workspace root, set on org-kanban cards so actions can route to it
+	ProjectName  string                    // sub-project name (empty = root project), set on org-kanban cards
+	Critical     bool                      // on the critical path, set on Kanban cards when a schedule is wired
+	Slack        float64                   // work hours the task can slip, set with Critical
 }
 
 // DashboardStats holds summary statistics.
@@ -272,13 +359,14 @@ func buildTaskViews(plan *planning.Plan, state *planning.ExecutionState) []TaskV
 	views := make([]TaskView, 0, len(plan.Tasks))
 
 	for _, task := range plan.Tasks {
-		view := TaskView{Task: task, Status: planning.StatusPending}
+		view := TaskView{Task: task, Status: planning.StatusPending, Criteria: task.Coverage(planning.TaskResult{})}
 
 		if state != nil {
 			if result, ok := state.TaskStates[task.ID]; ok {
 				view.Status = result.Status
 				view.Owner = result.Owner
 				view.HasLinks = len(result.ExternalRefs) > 0
+				view.Criteria = task.Coverage(result)
 			}
 		}
 
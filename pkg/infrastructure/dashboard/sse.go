@@ -1,34 +1,49 @@
 package dashboard
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
 )
 
+// sseMessage is one frame of the /events stream. ID is the event-store ID
+// for messages derived from the event log and empty for ad-hoc notifications.
+type sseMessage struct {
+	ID    string
+	Event string
+	Data  string
+}
+
 // sseHub fans state-change notifications out to every connected client.
-// Each /events subscriber owns a buffered channel; broadcastChange drops the
+// Each /events subscriber owns a buffered channel; broadcast drops the
 // message rather than blocking when a subscriber is slow — the client will
 // catch up on the next event or reload.
 type sseHub struct {
 	mu      sync.RWMutex
-	clients map[chan string]struct{}
+	clients map[chan sseMessage]struct{}
 }
 
 func newSSEHub() *sseHub {
-	return &sseHub{clients: map[chan string]struct{}{}}
+	return &sseHub{clients: map[chan sseMessage]struct{}{}}
 }
 
-func (h *sseHub) subscribe() chan string {
-	ch := make(chan string, 4)
+func (h *sseHub) subscribe() chan sseMessage {
+	ch := make(chan sseMessage, 16)
 	h.mu.Lock()
 	h.clients[ch] = struct{}{}
 	h.mu.Unlock()
 	return ch
 }
 
-func (h *sseHub) unsubscribe(ch chan string) {
+func (h *sseHub) unsubscribe(ch chan sseMessage) {
 	h.mu.Lock()
 	if _, ok := h.clients[ch]; ok {
 		delete(h.clients, ch)
@@ -37,29 +52,166 @@ func (h *sseHub) unsubscribe(ch chan string) {
 	h.mu.Unlock()
 }
 
-func (h *sseHub) broadcast(event string) {
+func (h *sseHub) broadcast(msg sseMessage) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 	for ch := range h.clients {
 		select {
-		case ch <- event:
+		case ch <- msg:
 		default:
 			// drop on slow client; they'll resync on next event or reload
 		}
 	}
 }
 
+// EventFeed reads the project's event log from a cursor. The dashboard
+// server takes this as an optional dependency; when set, /events streams
+// every task, plan, drift and spec event appended to the store — including
+// those written by other processes such as MCP agents or `roady git sync` —
+// and honours Last-Event-ID so reconnecting clients replay what they missed.
+type EventFeed interface {
+	LoadAfter(eventID string) ([]*events.BaseEvent, error)
+}
+
+// EnableEventFeed wires the event log into the /events stream. Events that
+// already exist are not broadcast; call PollEvents whenever the store may
+// have grown. Pass nil to fall back to dashboard-action notifications only.
+func (s *Server) EnableEventFeed(f EventFeed) {
+	s.feedMu.Lock()
+	defer s.feedMu.Unlock()
+	s.eventFeed = f
+	s.feedCursor = ""
+	if f == nil {
+		return
+	}
+	if evts, err := f.LoadAfter(""); err == nil && len(evts) > 0 {
+		s.feedCursor = evts[len(evts)-1].ID
+	}
+}
+
+// PollEvents broadcasts the events appended to the store since the last
+// poll. It is safe to call concurrently and is a no-op without a feed.
+func (s *Server) PollEvents() error {
+	s.feedMu.Lock()
+	defer s.feedMu.Unlock()
+	if s.eventFeed == nil || s.sse == nil {
+		return nil
+	}
+
+	evts, err := s.eventFeed.LoadAfter(s.feedCursor)
+	if errors.Is(err, events.ErrEventNotFound) {
+		// The log was rewritten under us; restart from its tail and tell
+		// clients to reload rather than guess what changed.
+		evts, err = s.eventFeed.LoadAfter("")
+		if err != nil {
+			return fmt.Errorf("load events: %w", err)
+		}
+		s.feedCursor = ""
+		if len(evts) > 0 {
+			s.feedCursor = evts[len(evts)-1].ID
+		}
+		s.sse.broadcast(sseMessage{Event: "resync", Data: timestampData()})
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("load events: %w", err)
+	}
+
+	for _, ev := range evts {
+		s.feedCursor = ev.ID
+		if msg, ok := eventMessage(ev); ok {
+			s.sse.broadcast(msg)
+		}
+	}
+	return nil
+}
+
+// eventMessage maps a stored event onto a typed SSE message whose data is
+// the event as JSON. Event types the dashboard does not render are skipped.
+func eventMessage(ev *events.BaseEvent) (sseMessage, bool) {
+	name := sseEventName(ev.Type)
+	if name == "" {
+		return sseMessage{}, false
+	}
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return sseMessage{}, false
+	}
+	return sseMessage{ID: ev.ID, Event: name, Data: string(data)}, true
+}
+
+// sseEventName is the SSE event name of a stored event type, or "" when the
+// type is not streamed. Task events keep the `task-changed` name the boards
+// already listen for.
+func sseEventName(eventType string) string {
+	switch {
+	case strings.HasPrefix(eventType, "task."):
+		return "task-changed"
+	case eventType == events.EventTypePlanApproved:
+		return "plan-approved"
+	case strings.HasPrefix(eventType, "plan."):
+		return "plan-changed"
+	case eventType == events.EventTypeDriftDetected:
+		return "drift-detected"
+	case strings.HasPrefix(eventType, "drift."):
+		return "drift-changed"
+	case strings.HasPrefix(eventType, "spec."):
+		return "spec-changed"
+	}
+	return ""
+}
+
+func timestampData() string {
+	return strconv.FormatInt(time.Now().UnixNano(), 10)
+}
+
 // broadcastChange notifies all /events subscribers that the board state has
 // mutated. Called from every action handler after a successful transition.
+// With an event feed the action's own event carries the notification, so
+// the feed is polled instead of sending a bare `task-changed`.
 func (s *Server) broadcastChange() {
-	if s.sse != nil {
-		s.sse.broadcast("task-changed")
+	if s.sse == nil {
+		return
+	}
+	if s.hasEventFeed() {
+		_ = s.PollEvents()
+		return
+	}
+	s.sse.broadcast(sseMessage{Event: "task-changed", Data: timestampData()})
+}
+
+func (s *Server) hasEventFeed() bool {
+	s.feedMu.Lock()
+	defer s.feedMu.Unlock()
+	return s.eventFeed != nil
+}
+
+// lastEventID is the resume cursor of an /events request: the Last-Event-ID
+// header EventSource sends on reconnect, or the last_event_id query
+// parameter for clients resuming after a page load.
+func lastEventID(r *http.Request) string {
+	if id := r.Header.Get("Last-Event-ID"); id != "" {
+		return id
 	}
+	return r.URL.Query().Get("last_event_id")
+}
+
+func writeSSE(w io.Writer, msg sseMessage) error {
+	if msg.ID != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", msg.ID); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", msg.Event, msg.Data)
+	return err
 }
 
 // handleEvents serves the SSE stream. The client opens an EventSource on
-// /events and receives `event: task-changed\ndata: <ts>\n\n` whenever the
-// server mutates state. A 25s heartbeat keeps proxies from dropping the
+// /events and receives typed events (`task-changed`, `plan-approved`,
+// `plan-changed`, `drift-detected`, `drift-changed`, `spec-changed`) whose
+// data is the stored event as JSON; without an event feed only a bare
+// `task-changed` is sent after dashboard actions. A `resync` event means the
+// client should reload. A 25s heartbeat keeps proxies from dropping the
 // connection.
 func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
 	flusher, ok := w.(http.Flusher)
@@ -75,6 +227,8 @@ func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
 	if s.sse == nil {
 		s.sse = newSSEHub()
 	}
+	// Subscribe before replaying so nothing appended in between is lost;
+	// replayed IDs are skipped when they arrive live.
 	ch := s.sse.subscribe()
 	defer s.sse.unsubscribe(ch)
 
@@ -82,6 +236,11 @@ func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
 	if _, err := fmt.Fprintf(w, ": connected at %s\n\n", time.Now().UTC().Format(time.RFC3339)); err != nil {
 		return
 	}
+
+	replayed, err := s.replayEvents(w, lastEventID(r))
+	if err != nil {
+		return
+	}
 	flusher.Flush()
 
 	heartbeat := time.NewTicker(25 * time.Second)
@@ -96,14 +255,46 @@ func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 			flusher.Flush()
-		case ev, ok := <-ch:
+		case msg, ok := <-ch:
 			if !ok {
 				return
 			}
-			if _, err := fmt.Fprintf(w, "event: %s\ndata: %d\n\n", ev, time.Now().UnixNano()); err != nil {
+			if _, dup := replayed[msg.ID]; dup && msg.ID != "" {
+				continue
+			}
+			if err := writeSSE(w, msg); err != nil {
 				return
 			}
 			flusher.Flush()
 		}
 	}
 }
+
+// replayEvents writes the streamed events that follow lastID and returns
+// their IDs. An unknown ID (archived away or from another project) yields a
+// single `resync` event instead.
+func (s *Server) replayEvents(w io.Writer, lastID string) (map[string]struct{}, error) {
+	replayed := map[string]struct{}{}
+	s.feedMu.Lock()
+	feed := s.eventFeed
+	s.feedMu.Unlock()
+	if lastID == "" || feed == nil {
+		return replayed, nil
+	}
+
+	missed, err := feed.LoadAfter(lastID)
+	if err != nil {
+		return replayed, writeSSE(w, sseMessage{Event: "resync", Data: timestampData()})
+	}
+	for _, ev := range missed {
+		msg, ok := eventMessage(ev)
+		if !ok {
+			continue
+		}
+		if err := writeSSE(w, msg); err != nil {
+			return nil, err
+		}
+		replayed[msg.ID] = struct{}{}
+	}
+	return replayed, nil
+}
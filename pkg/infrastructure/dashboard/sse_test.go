@@ -0,0 +1,205 @@
+package dashboard
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+)
+
+// fakeEventFeed is an in-memory event log with LoadAfter semantics matching
+// the storage backends.
+type fakeEventFeed struct {
+	mu   sync.Mutex
+	evts []*events.BaseEvent
+}
+
+func (f *fakeEventFeed) append(id, typ string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.evts = append(f.evts, &events.BaseEvent{ID: id, Type: typ, AggregateID_: "t1", Actor: "agent"})
+}
+
+func (f *fakeEventFeed) LoadAfter(id string) ([]*events.BaseEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if id == "" {
+		return append([]*events.BaseEvent(nil), f.evts...), nil
+	}
+	for i, ev := range f.evts {
+		if ev.ID == id {
+			return append([]*events.BaseEvent(nil), f.evts[i+1:]...), nil
+		}
+	}
+	return nil, events.ErrEventNotFound
+}
+
+func newFeedTestServer(t *testing.T, feed *fakeEventFeed) *Server {
+	t.Helper()
+	srv, err := NewServer(":0", &kanbanStubProvider{plan: &planning.Plan{}, state: &planning.ExecutionState{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.EnableEventFeed(feed)
+	return srv
+}
+
+func receive(t *testing.T, ch chan sseMessage) sseMessage {
+	t.Helper()
+	select {
+	case msg := <-ch:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("no message received")
+	}
+	return sseMessage{}
+}
+
+func TestSSEEventName(t *testing.T) {
+	for typ, want := range map[string]string{
+		"task.transition": "task-changed",
+		"task.completed":  "task-changed",
+		"plan.approved":   "plan-approved",
+		"plan.generate":   "plan-changed",
+		"drift.detected":  "drift-detected",
+		"drift.accepted":  "drift-changed",
+		"spec.update":     "spec-changed",
+		"billing.rate":    "",
+	} {
+		if got := sseEventName(typ); got != want {
+			t.Errorf("sseEventName(%q) = %q, want %q", typ, got, want)
+		}
+	}
+}
+
+func TestPollEvents_BroadcastsNewEvents(t *testing.T) {
+	feed := &fakeEventFeed{}
+	feed.append("e1", "task.started")
+	srv := newFeedTestServer(t, feed)
+	ch := srv.sse.subscribe()
+	defer srv.sse.unsubscribe(ch)
+
+	feed.append("e2", "task.transition")
+	feed.append("e3", "billing.rate")
+	feed.append("e4", "plan.approved")
+	if err := srv.PollEvents(); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := receive(t, ch)
+	if msg.ID != "e2" || msg.Event != "task-changed" {
+		t.Errorf("first message = %+v, want e2 task-changed", msg)
+	}
+	var ev events.BaseEvent
+	if err := json.Unmarshal([]byte(msg.Data), &ev); err != nil || ev.Actor != "agent" {
+		t.Errorf("data = %s (%v), want the event as JSON", msg.Data, err)
+	}
+	if msg = receive(t, ch); msg.ID != "e4" || msg.Event != "plan-approved" {
+		t.Errorf("second message = %+v, want e4 plan-approved", msg)
+	}
+
+	// Nothing new: nothing sent.
+	if err := srv.PollEvents(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case msg := <-ch:
+		t.Errorf("unexpected message %+v", msg)
+	default:
+	}
+}
+
+func TestPollEvents_ResyncsWhenLogRewritten(t *testing.T) {
+	feed := &fakeEventFeed{}
+	feed.append("e1", "task.started")
+	srv := newFeedTestServer(t, feed)
+	ch := srv.sse.subscribe()
+	defer srv.sse.unsubscribe(ch)
+
+	feed.evts = nil
+	feed.append("x1", "task.started")
+	if err := srv.PollEvents(); err != nil {
+		t.Fatal(err)
+	}
+	if msg := receive(t, ch); msg.Event != "resync" {
+		t.Errorf("got %+v, want resync", msg)
+	}
+
+	feed.append("x2", "spec.update")
+	if err := srv.PollEvents(); err != nil {
+		t.Fatal(err)
+	}
+	if msg := receive(t, ch); msg.ID != "x2" {
+		t.Errorf("got %+v, want x2 after the resync", msg)
+	}
+}
+
+func TestBroadcastChange_PollsEventFeed(t *testing.T) {
+	feed := &fakeEventFeed{}
+	srv := newFeedTestServer(t, feed)
+	ch := srv.sse.subscribe()
+	defer srv.sse.unsubscribe(ch)
+
+	feed.append("e1", "task.completed")
+	srv.broadcastChange()
+	if msg := receive(t, ch); msg.ID != "e1" || msg.Event != "task-changed" {
+		t.Errorf("got %+v, want the action's event", msg)
+	}
+}
+
+// readSSE returns the stream up to the first frame of the given event.
+func readSSE(t *testing.T, url, lastID, until string) string {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	req.Header.Set("Last-Event-ID", lastID)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var b strings.Builder
+	sc := bufio.NewScanner(resp.Body)
+	for sc.Scan() {
+		line := sc.Text()
+		b.WriteString(line + "\n")
+		if line == "event: "+until {
+			break
+		}
+	}
+	return b.String()
+}
+
+func TestHandleEvents_ReplaysAfterLastEventID(t *testing.T) {
+	feed := &fakeEventFeed{}
+	feed.append("e1", "task.started")
+	feed.append("e2", "drift.detected")
+	feed.append("e3", "spec.update")
+	srv := newFeedTestServer(t, feed)
+	ts := httptest.NewServer(http.HandlerFunc(srv.handleEvents))
+	defer ts.Close()
+
+	got := readSSE(t, ts.URL, "e1", "spec-changed")
+	for _, want := range []string{"id: e2\nevent: drift-detected\ndata: {", "id: e3\nevent: spec-changed"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("stream missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "id: e1") {
+		t.Error("events up to Last-Event-ID should not be replayed")
+	}
+
+	if got := readSSE(t, ts.URL, "gone", "resync"); !strings.Contains(got, "event: resync") {
+		t.Errorf("unknown Last-Event-ID should ask the client to resync:\n%s", got)
+	}
+}
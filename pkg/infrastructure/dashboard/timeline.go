@@ -0,0 +1,132 @@
+package dashboard
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+)
+
+const (
+	// timelineDefaultSince is the timeline page's look-back when no since
+	// filter is given.
+	timelineDefaultSince = "7d"
+	// timelineMaxRows caps the timeline page; the newest events are kept.
+	timelineMaxRows = 200
+)
+
+// timelineFilter echoes the timeline page's filter form.
+type timelineFilter struct {
+	Type      string
+	Actor     string
+	Aggregate string
+	Since     string
+	Until     string
+}
+
+// timelineRow is one event on the timeline page.
+type timelineRow struct {
+	Time      string
+	Type      string
+	Actor     string
+	Aggregate string
+	Details   string
+}
+
+// timelineData is the template data of the timeline page.
+type timelineData struct {
+	reportPage
+	Filter    timelineFilter
+	Rows      []timelineRow
+	Matched   int
+	Truncated bool
+}
+
+func newTimelineRow(ev *events.BaseEvent) timelineRow {
+	keys := make([]string, 0, len(ev.Metadata))
+	for k := range ev.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	details := make([]string, 0, len(keys))
+	for _, k := range keys {
+		details = append(details, fmt.Sprintf("%s=%v", k, ev.Metadata[k]))
+	}
+	return timelineRow{
+		Time:      ev.Timestamp.Local().Format("2006-01-02 15:04:05"),
+		Type:      ev.Type,
+		Actor:     ev.Actor,
+		Aggregate: ev.AggregateID_,
+		Details:   strings.Join(details, " "),
+	}
+}
+
+// handleTimeline serves the audit timeline, newest first. It takes the
+// /api/events filters; since defaults to the last week and, unlike the API,
+// no limit applies before the newest timelineMaxRows are picked.
+func (s *Server) handleTimeline(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	data := timelineData{
+		reportPage: s.newReportPage(r, "Timeline"),
+		Filter: timelineFilter{
+			Type:      params.Get("type"),
+			Actor:     params.Get("actor"),
+			Aggregate: params.Get("aggregate"),
+			Since:     params.Get("since"),
+			Until:     params.Get("until"),
+		},
+	}
+	if !params.Has("since") {
+		data.Filter.Since = timelineDefaultSince
+	}
+
+	in, err := s.insightsFor(r)
+	if err != nil {
+		data.Error = err.Error()
+		s.render(w, "timeline.html", data)
+		return
+	}
+	if in.Events == nil {
+		data.Error = "The audit timeline is not enabled for this dashboard."
+		s.render(w, "timeline.html", data)
+		return
+	}
+
+	now := time.Now()
+	q, err := parseEventQuery(r, now)
+	if err == nil && data.Filter.Since != "" && q.Since == nil {
+		var since time.Time
+		since, err = events.ParseQueryTime(data.Filter.Since, now)
+		q.Since = &since
+	}
+	if err != nil {
+		data.Error = err.Error()
+		s.render(w, "timeline.html", data)
+		return
+	}
+	// Empty form fields arrive as type= and must not filter.
+	q.EventTypes = slices.DeleteFunc(q.EventTypes, func(t string) bool { return t == "" })
+	if !params.Has("limit") || params.Get("limit") == "" {
+		q.Limit = 0
+	}
+
+	evts, err := in.Events.QueryEvents(q)
+	if err != nil {
+		data.Error = err.Error()
+		s.render(w, "timeline.html", data)
+		return
+	}
+	data.Matched = len(evts)
+	if len(evts) > timelineMaxRows {
+		evts = evts[len(evts)-timelineMaxRows:]
+		data.Truncated = true
+	}
+	for i := len(evts) - 1; i >= 0; i-- {
+		data.Rows = append(data.Rows, newTimelineRow(evts[i]))
+	}
+	s.render(w, "timeline.html", data)
+}
@@ -231,13 +231,13 @@ func TestSSEHub_BroadcastDeliversToSubscribers(t *testing.T) {
 	a := h.subscribe()
 	b := h.subscribe()
 
-	go h.broadcast("task-changed")
+	go h.broadcast(sseMessage{Event: "task-changed"})
 
-	for _, ch := range []chan string{a, b} {
+	for _, ch := range []chan sseMessage{a, b} {
 		select {
 		case msg := <-ch:
-			if msg != "task-changed" {
-				t.Errorf("got %q, want task-changed", msg)
+			if msg.Event != "task-changed" {
+				t.Errorf("got %q, want task-changed", msg.Event)
 			}
 		case <-time.After(time.Second):
 			t.Fatal("subscriber timed out")
@@ -291,4 +291,5 @@ var _ TaskActions = (interface {
 	BlockTask(ctx context.Context, taskID, reason string) error
 	UnblockTask(ctx context.Context, taskID string) error
 	ReopenTask(ctx context.Context, taskID string) error
+	AssignTask(ctx context.Context, taskID, assignee string) error
 })(nil)
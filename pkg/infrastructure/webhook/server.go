@@ -4,13 +4,16 @@ package webhook
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
 )
 
 // Event represents a webhook event from an external system.
@@ -19,6 +22,7 @@ type Event struct {
 	EventType  string                 `json:"event_type"`
 	ExternalID string                 `json:"external_id"`
 	TaskID     string                 `json:"task_id"`
+	Actor      string                 `json:"actor,omitempty"`
 	Status     planning.TaskStatus    `json:"status,omitempty"`
 	Metadata   map[string]interface{} `json:"metadata,omitempty"`
 	Timestamp  time.Time              `json:"timestamp"`
@@ -36,6 +40,11 @@ type Handler interface {
 	ParseEvent(r *http.Request) (*Event, error)
 }
 
+// MemberAuthenticator resolves a team member's API token to the member.
+type MemberAuthenticator interface {
+	Authenticate(token string) (*team.Member, error)
+}
+
 // EventProcessor handles incoming webhook events.
 type EventProcessor interface {
 	ProcessEvent(ctx context.Context, event *Event) error
@@ -43,13 +52,16 @@ type EventProcessor interface {
 
 // Server is the HTTP webhook server.
 type Server struct {
-	addr      string
-	handlers  map[string]Handler
-	secrets   map[string]string // provider -> secret
-	processor EventProcessor
-	server    *http.Server
-	mu        sync.RWMutex
-	events    []Event // Recent events for debugging
+	addr       string
+	handlers   map[string]Handler
+	secrets    map[string]string // provider -> secret
+	processor  EventProcessor
+	actor      string
+	member     *team.Member
+	memberAuth MemberAuthenticator
+	server     *http.Server
+	mu         sync.RWMutex
+	events     []Event // Recent events for debugging
 }
 
 // NewServer creates a new webhook server.
@@ -77,6 +89,32 @@ func (s *Server) SetSecret(provider, secret string) {
 	s.secrets[provider] = secret
 }
 
+// SetActor names who incoming events are attributed to. The processor
+// receives it as Event.Actor and in the context via team.WithActor; it is an
+// audit label and grants no role. Use SetActorMember once there is a team.
+func (s *Server) SetActor(actor string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.actor, s.member = actor, nil
+}
+
+// SetActorMember sets the team member incoming events act as. The processor
+// receives the member's name as Event.Actor and the member as caller via
+// team.WithCaller, so the member's role applies and audit events name them.
+func (s *Server) SetActorMember(m team.Member) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.actor, s.member = m.Name, &m
+}
+
+// EnableMemberAuth requires a team member's API token as a Bearer token on
+// the recent-events endpoint. Provider webhooks keep their signature checks.
+func (s *Server) EnableMemberAuth(auth MemberAuthenticator) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.memberAuth = auth
+}
+
 // Start starts the webhook server.
 func (s *Server) Start() error {
 	mux := http.NewServeMux()
@@ -124,6 +162,7 @@ func (s *Server) handleWebhook(provider string) http.HandlerFunc {
 		s.mu.RLock()
 		handler, ok := s.handlers[provider]
 		secret := s.secrets[provider]
+		actor, member := s.actor, s.member
 		s.mu.RUnlock()
 
 		if !ok {
@@ -146,14 +185,27 @@ func (s *Server) handleWebhook(provider string) http.HandlerFunc {
 			return
 		}
 
+		ctx := r.Context()
+		if actor != "" {
+			event.Actor = actor
+			ctx = team.WithActor(ctx, actor)
+		}
+		if member != nil {
+			ctx = team.WithCaller(ctx, *member)
+		}
+
 		// Store event for debugging
 		s.storeEvent(event)
 
 		// Process the event
 		if s.processor != nil {
-			if err := s.processor.ProcessEvent(r.Context(), event); err != nil {
+			if err := s.processor.ProcessEvent(ctx, event); err != nil {
 				log.Printf("Failed to process %s event: %v", provider, err)
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				status := http.StatusInternalServerError
+				if errors.Is(err, team.ErrForbidden) {
+					status = http.StatusForbidden
+				}
+				http.Error(w, err.Error(), status)
 				return
 			}
 		}
@@ -165,6 +217,18 @@ func (s *Server) handleWebhook(provider string) http.HandlerFunc {
 }
 
 func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	auth := s.memberAuth
+	s.mu.RUnlock()
+	if auth != nil {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if m, err := auth.Authenticate(token); !ok || err != nil || m == nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="roady-webhook"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+
 	s.mu.RLock()
 	events := make([]Event, len(s.events))
 	copy(events, s.events)
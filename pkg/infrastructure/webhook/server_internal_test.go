@@ -8,6 +8,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -15,6 +16,7 @@ import (
 	"time"
 
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
 )
 
 type mockProcessor struct {
@@ -305,3 +307,116 @@ func TestServer_StartAndShutdown(t *testing.T) {
 		t.Errorf("expected ErrServerClosed, got %v", err)
 	}
 }
+
+// --- member identity tests ---
+
+type callerProcessor struct {
+	caller string
+	err    error
+}
+
+func (p *callerProcessor) ProcessEvent(ctx context.Context, _ *Event) error {
+	p.caller = team.ActorFrom(ctx, "")
+	return p.err
+}
+
+type fakeMemberAuth map[string]team.Member
+
+func (f fakeMemberAuth) Authenticate(token string) (*team.Member, error) {
+	m, ok := f[token]
+	if !ok {
+		return nil, team.ErrInvalidToken
+	}
+	return &m, nil
+}
+
+func postGitHubIssue(srv *Server) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/webhooks/github", bytes.NewReader(validGitHubIssuePayload()))
+	r.Header.Set("X-GitHub-Event", "issues")
+	srv.handleWebhook("github")(w, r)
+	return w
+}
+
+func TestHandleWebhook_Actor(t *testing.T) {
+	proc := &callerProcessor{}
+	srv := NewServer(":0", proc)
+	srv.RegisterHandler(NewGitHubHandler())
+	srv.SetActor("ci-bot")
+
+	if w := postGitHubIssue(srv); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if proc.caller != "ci-bot" {
+		t.Errorf("processor caller = %q, want ci-bot", proc.caller)
+	}
+	if got := srv.RecentEvents()[0].Actor; got != "ci-bot" {
+		t.Errorf("event actor = %q, want ci-bot", got)
+	}
+}
+
+// transitionProcessor authorizes a task transition like the CLI processor.
+type transitionProcessor struct{}
+
+func (transitionProcessor) ProcessEvent(ctx context.Context, _ *Event) error {
+	return team.AuthorizeCaller(ctx, team.PermissionTransitionTasks)
+}
+
+func TestHandleWebhook_ActorMember(t *testing.T) {
+	srv := NewServer(":0", transitionProcessor{})
+	srv.RegisterHandler(NewGitHubHandler())
+
+	srv.SetActorMember(team.Member{Name: "ci-bot", Role: team.RoleMember})
+	if w := postGitHubIssue(srv); w.Code != http.StatusOK {
+		t.Errorf("member actor: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := srv.RecentEvents()[0].Actor; got != "ci-bot" {
+		t.Errorf("event actor = %q, want ci-bot", got)
+	}
+
+	srv.SetActorMember(team.Member{Name: "ci-bot", Role: team.RoleViewer})
+	if w := postGitHubIssue(srv); w.Code != http.StatusForbidden {
+		t.Errorf("viewer actor: expected 403, got %d", w.Code)
+	}
+
+	// A plain actor is only a label and carries no caller to refuse.
+	srv.SetActor("ci-bot")
+	if w := postGitHubIssue(srv); w.Code != http.StatusOK {
+		t.Errorf("label actor: expected 200, got %d", w.Code)
+	}
+}
+
+func TestHandleWebhook_ForbiddenIs403(t *testing.T) {
+	proc := &callerProcessor{err: fmt.Errorf("%w: ci-bot has role viewer", team.ErrForbidden)}
+	srv := NewServer(":0", proc)
+	srv.RegisterHandler(NewGitHubHandler())
+	srv.SetActor("ci-bot")
+
+	if w := postGitHubIssue(srv); w.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", w.Code)
+	}
+}
+
+func TestHandleEvents_MemberAuth(t *testing.T) {
+	srv := NewServer(":0", nil)
+	srv.EnableMemberAuth(fakeMemberAuth{"rdy_alice": {Name: "alice", Role: team.RoleViewer}})
+
+	for _, tc := range []struct {
+		header string
+		want   int
+	}{
+		{"", http.StatusUnauthorized},
+		{"Bearer rdy_wrong", http.StatusUnauthorized},
+		{"Bearer rdy_alice", http.StatusOK},
+	} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/events", nil)
+		if tc.header != "" {
+			r.Header.Set("Authorization", tc.header)
+		}
+		srv.handleEvents(w, r)
+		if w.Code != tc.want {
+			t.Errorf("Authorization %q: got %d, want %d", tc.header, w.Code, tc.want)
+		}
+	}
+}
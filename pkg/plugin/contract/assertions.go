@@ -2,12 +2,19 @@
 package contract
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	domainPlugin "github.com/felixgeelhaar/roady/pkg/domain/plugin"
+	infraPlugin "github.com/felixgeelhaar/roady/pkg/plugin"
 )
 
+// SubscribeWindow is how long AssertSubscribe listens before cancelling.
+var SubscribeWindow = 2 * time.Second
+
 // Result captures the outcome of a single contract assertion.
 type Result struct {
 	Name    string
@@ -85,3 +92,74 @@ func AssertPushInvalidTask(syncer domainPlugin.Syncer) Result {
 	}
 	return Result{Name: "PushInvalidTask", Passed: true, Message: fmt.Sprintf("Push correctly rejected empty ID: %v", err)}
 }
+
+// AssertSubscribe verifies that a subscription streams well-formed events
+// and ends cleanly when cancelled. Plugins without a change feed pass.
+func AssertSubscribe(syncer domainPlugin.Syncer) Result {
+	sub, ok := syncer.(domainPlugin.Subscriber)
+	if !ok {
+		return Result{Name: "Subscribe", Passed: true, Message: "Syncer does not implement Subscribe (acceptable)"}
+	}
+
+	plan := &planning.Plan{Tasks: []planning.Task{{ID: "task-1", Title: "Test Task"}}}
+	state := planning.NewExecutionState("test")
+	state.TaskStates["task-1"] = planning.TaskResult{Status: planning.StatusInProgress}
+
+	ctx, cancel := context.WithTimeout(context.Background(), SubscribeWindow)
+	defer cancel()
+
+	var received []domainPlugin.ExternalEvent
+	done := make(chan error, 1)
+	go func() {
+		done <- sub.Subscribe(ctx, plan, state, nil, func(e domainPlugin.ExternalEvent) error {
+			received = append(received, e)
+			return nil
+		})
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-time.After(SubscribeWindow + 5*time.Second):
+		return Result{Name: "Subscribe", Passed: false, Message: "Subscribe did not return after its context was cancelled"}
+	}
+	if errors.Is(err, domainPlugin.ErrSubscribeUnsupported) {
+		return Result{Name: "Subscribe", Passed: true, Message: "Plugin has no change feed (acceptable)"}
+	}
+	if err != nil {
+		return Result{Name: "Subscribe", Passed: false, Message: fmt.Sprintf("Subscribe failed: %v", err)}
+	}
+	for _, e := range received {
+		if msg := eventProblem(e); msg != "" {
+			return Result{Name: "Subscribe", Passed: false, Message: msg}
+		}
+	}
+	return Result{Name: "Subscribe", Passed: true, Message: fmt.Sprintf("Subscribe streamed %d events", len(received))}
+}
+
+// eventProblem describes what makes an external event malformed.
+func eventProblem(e domainPlugin.ExternalEvent) string {
+	if e.TaskID == "" {
+		return fmt.Sprintf("event %q has no task ID", e.ID)
+	}
+	switch e.Type {
+	case domainPlugin.EventStatusChanged:
+		if !e.Status.IsValid() {
+			return fmt.Sprintf("event %q has invalid status %q", e.ID, e.Status)
+		}
+	case domainPlugin.EventLinked:
+		if e.ExternalRef == nil {
+			return fmt.Sprintf("link event %q has no external ref", e.ID)
+		}
+	}
+	return ""
+}
+
+// AssertProtocolVersion verifies that a plugin binary negotiated the current
+// plugin protocol, which carries the full task model and Subscribe.
+func AssertProtocolVersion(version int) Result {
+	if version < infraPlugin.ProtocolVersionGRPC {
+		return Result{Name: "ProtocolVersion", Passed: false, Message: fmt.Sprintf("Plugin serves protocol version %d; serve it with plugin.Serve to speak version %d", version, infraPlugin.ProtocolVersionGRPC)}
+	}
+	return Result{Name: "ProtocolVersion", Passed: true, Message: fmt.Sprintf("Plugin negotiated protocol version %d", version)}
+}
@@ -21,9 +21,10 @@ func NewContractSuite() *ContractSuite {
 
 // SuiteResult aggregates results from running the full contract suite.
 type SuiteResult struct {
-	Results []Result
-	Passed  int
-	Failed  int
+	Results         []Result
+	Passed          int
+	Failed          int
+	ProtocolVersion int // Negotiated plugin protocol version; 0 when run in-process
 }
 
 // RunWithSyncer runs the contract suite against an already-loaded syncer instance.
@@ -35,21 +36,25 @@ func (s *ContractSuite) RunWithSyncer(syncer domainPlugin.Syncer) *SuiteResult {
 		AssertSyncWithTasks,
 		AssertPushValidTask,
 		AssertPushInvalidTask,
+		AssertSubscribe,
 	}
 
 	sr := &SuiteResult{}
 	for _, assert := range assertions {
-		result := assert(syncer)
-		sr.Results = append(sr.Results, result)
-		if result.Passed {
-			sr.Passed++
-		} else {
-			sr.Failed++
-		}
+		sr.add(assert(syncer))
 	}
 	return sr
 }
 
+func (sr *SuiteResult) add(result Result) {
+	sr.Results = append(sr.Results, result)
+	if result.Passed {
+		sr.Passed++
+	} else {
+		sr.Failed++
+	}
+}
+
 // RunBinary loads a plugin binary and runs the full contract suite.
 func (s *ContractSuite) RunBinary(path string) (*SuiteResult, error) {
 	defer s.loader.Cleanup()
@@ -59,5 +64,8 @@ func (s *ContractSuite) RunBinary(path string) (*SuiteResult, error) {
 		return nil, fmt.Errorf("load plugin: %w", err)
 	}
 
-	return s.RunWithSyncer(syncer), nil
+	sr := s.RunWithSyncer(syncer)
+	sr.ProtocolVersion = s.loader.ProtocolVersion(path)
+	sr.add(AssertProtocolVersion(sr.ProtocolVersion))
+	return sr, nil
 }
@@ -1,9 +1,11 @@
 package contract
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	domainPlugin "github.com/felixgeelhaar/roady/pkg/domain/plugin"
@@ -170,3 +172,46 @@ func TestRunBinary_NotExecutable(t *testing.T) {
 		t.Error("expected error for non-executable file")
 	}
 }
+
+// streamingSyncer streams one event and waits for cancellation.
+type streamingSyncer struct {
+	fakeSyncer
+	event domainPlugin.ExternalEvent
+}
+
+func (s *streamingSyncer) Subscribe(ctx context.Context, plan *planning.Plan, state *planning.ExecutionState, eventTypes []string, handle func(domainPlugin.ExternalEvent) error) error {
+	if err := handle(s.event); err != nil {
+		return err
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func TestAssertSubscribe(t *testing.T) {
+	SubscribeWindow = 50 * time.Millisecond
+
+	if r := AssertSubscribe(&fakeSyncer{}); !r.Passed {
+		t.Errorf("expected syncers without Subscribe to pass: %s", r.Message)
+	}
+	valid := &streamingSyncer{event: domainPlugin.ExternalEvent{ID: "e1", Type: domainPlugin.EventStatusChanged, TaskID: "task-1", Status: planning.StatusDone}}
+	if r := AssertSubscribe(valid); !r.Passed {
+		t.Errorf("expected a well-formed stream to pass: %s", r.Message)
+	}
+	invalid := &streamingSyncer{event: domainPlugin.ExternalEvent{ID: "e1", Type: domainPlugin.EventStatusChanged, TaskID: "task-1", Status: "finished"}}
+	if r := AssertSubscribe(invalid); r.Passed {
+		t.Error("expected an invalid status to fail")
+	}
+	unlinked := &streamingSyncer{event: domainPlugin.ExternalEvent{ID: "e1", Type: domainPlugin.EventLinked, TaskID: "task-1"}}
+	if r := AssertSubscribe(unlinked); r.Passed {
+		t.Error("expected a link event without a ref to fail")
+	}
+}
+
+func TestAssertProtocolVersion(t *testing.T) {
+	if r := AssertProtocolVersion(1); r.Passed {
+		t.Error("expected protocol version 1 to fail")
+	}
+	if r := AssertProtocolVersion(2); !r.Passed {
+		t.Errorf("expected protocol version 2 to pass: %s", r.Message)
+	}
+}
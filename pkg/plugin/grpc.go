@@ -4,15 +4,37 @@ package plugin
 import (
 	"context"
 	"errors"
+	"io"
 	"time"
 
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 	domainPlugin "github.com/felixgeelhaar/roady/pkg/domain/plugin"
 	pb "github.com/felixgeelhaar/roady/pkg/domain/plugin/proto"
+	goplugin "github.com/hashicorp/go-plugin"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// SyncerGRPCPlugin serves and consumes a Syncer over version 2 of the plugin
+// protocol.
+type SyncerGRPCPlugin struct {
+	goplugin.NetRPCUnsupportedPlugin
+	Impl domainPlugin.Syncer
+}
+
+// GRPCServer registers the syncer with the plugin's gRPC server.
+func (p *SyncerGRPCPlugin) GRPCServer(_ *goplugin.GRPCBroker, s *grpc.Server) error {
+	pb.RegisterSyncerServer(s, &GRPCServer{Impl: p.Impl})
+	return nil
+}
+
+// GRPCClient returns a Syncer that calls the plugin over conn.
+func (p *SyncerGRPCPlugin) GRPCClient(_ context.Context, _ *goplugin.GRPCBroker, conn *grpc.ClientConn) (interface{}, error) {
+	return NewGRPCClient(conn), nil
+}
+
 // GRPCClient is a client that implements the Syncer interface over gRPC.
 type GRPCClient struct {
 	client pb.SyncerClient
@@ -72,6 +94,62 @@ func (c *GRPCClient) Push(taskID string, status planning.TaskStatus) error {
 	return nil
 }
 
+// Subscribe implements Subscriber over gRPC.
+func (c *GRPCClient) Subscribe(ctx context.Context, plan *planning.Plan, state *planning.ExecutionState, eventTypes []string, handle func(domainPlugin.ExternalEvent) error) error {
+	stream, err := c.client.Subscribe(ctx, &pb.SubscribeRequest{
+		EventTypes: eventTypes,
+		Plan:       planToProto(plan),
+		State:      stateToProto(state),
+	})
+	if err != nil {
+		return subscribeError(ctx, err)
+	}
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return subscribeError(ctx, err)
+		}
+		if err := handle(eventFromProto(event)); err != nil {
+			return err
+		}
+	}
+}
+
+// subscribeError maps the error ending a subscription stream.
+func subscribeError(ctx context.Context, err error) error {
+	switch {
+	case ctx.Err() != nil:
+		return nil
+	case status.Code(err) == codes.Unimplemented:
+		return domainPlugin.ErrSubscribeUnsupported
+	default:
+		return err
+	}
+}
+
+// PushChanges implements FieldSyncer over gRPC.
+func (c *GRPCClient) PushChanges(changes []domainPlugin.TaskChange) (*domainPlugin.SyncResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	resp, err := c.client.PushChanges(ctx, &pb.PushChangesRequest{Changes: changesToProto(changes)})
+	if status.Code(err) == codes.Unimplemented {
+		return nil, domainPlugin.ErrFieldSyncUnsupported
+	}
+	if err != nil {
+		return nil, err
+	}
+	return syncResultFromProto(resp), nil
+}
+
+var (
+	_ domainPlugin.Subscriber  = (*GRPCClient)(nil)
+	_ domainPlugin.FieldSyncer = (*GRPCClient)(nil)
+)
+
 // GRPCServer wraps a Syncer implementation as a gRPC server.
 type GRPCServer struct {
 	pb.UnimplementedSyncerServer
@@ -110,9 +188,37 @@ func (s *GRPCServer) Push(ctx context.Context, req *pb.PushRequest) (*pb.PushRes
 }
 
 // Subscribe implements the gRPC Subscribe method for streaming events.
+// Syncers that do not implement Subscriber answer UNIMPLEMENTED.
 func (s *GRPCServer) Subscribe(req *pb.SubscribeRequest, stream pb.Syncer_SubscribeServer) error {
-	// Streaming not implemented in this iteration
-	return nil
+	sub, ok := s.Impl.(domainPlugin.Subscriber)
+	if !ok {
+		return status.Error(codes.Unimplemented, domainPlugin.ErrSubscribeUnsupported.Error())
+	}
+	err := sub.Subscribe(stream.Context(), planFromProto(req.Plan), stateFromProto(req.State), req.EventTypes,
+		func(e domainPlugin.ExternalEvent) error {
+			return stream.Send(eventToProto(e))
+		})
+	if errors.Is(err, domainPlugin.ErrSubscribeUnsupported) {
+		return status.Error(codes.Unimplemented, err.Error())
+	}
+	return err
+}
+
+// PushChanges implements the gRPC PushChanges method. Syncers that do not
+// implement FieldSyncer answer UNIMPLEMENTED.
+func (s *GRPCServer) PushChanges(ctx context.Context, req *pb.PushChangesRequest) (*pb.SyncResponse, error) {
+	fs, ok := s.Impl.(domainPlugin.FieldSyncer)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, domainPlugin.ErrFieldSyncUnsupported.Error())
+	}
+	result, err := fs.PushChanges(changesFromProto(req.Changes))
+	if errors.Is(err, domainPlugin.ErrFieldSyncUnsupported) {
+		return nil, status.Error(codes.Unimplemented, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return syncResultToProto(result), nil
 }
 
 // Conversion functions
@@ -127,6 +233,14 @@ func planToProto(plan *planning.Plan) *pb.Plan {
 		tasks[i] = taskToProto(t)
 	}
 
+	var featureVerify map[string]*pb.Verification
+	if len(plan.FeatureVerify) > 0 {
+		featureVerify = make(map[string]*pb.Verification, len(plan.FeatureVerify))
+		for k, v := range plan.FeatureVerify {
+			featureVerify[k] = verificationToProto(&v)
+		}
+	}
+
 	return &pb.Plan{
 		Id:             plan.ID,
 		SpecId:         plan.SpecID,
@@ -134,16 +248,41 @@ func planToProto(plan *planning.Plan) *pb.Plan {
 		ApprovalStatus: string(plan.ApprovalStatus),
 		CreatedAt:      timestamppb.New(plan.CreatedAt),
 		UpdatedAt:      timestamppb.New(plan.UpdatedAt),
+		FeatureVerify:  featureVerify,
 	}
 }
 
 func taskToProto(t planning.Task) *pb.Task {
+	var source *pb.TaskSource
+	if !t.Source.IsZero() {
+		source = &pb.TaskSource{Doc: t.Source.Doc, Line: int64(t.Source.Line)}
+	}
+
 	return &pb.Task{
-		Id:           t.ID,
-		Title:        t.Title,
-		Description:  t.Description,
-		Phase:        string(t.Priority), // Map Priority to Phase field
-		Dependencies: t.DependsOn,
+		Id:                 t.ID,
+		Title:              t.Title,
+		Description:        t.Description,
+		Priority:           string(t.Priority),
+		Estimate:           t.Estimate,
+		Dependencies:       t.DependsOn,
+		FeatureId:          t.FeatureID,
+		Origin:             string(t.Origin),
+		Source:             source,
+		Files:              t.Files,
+		AcceptanceCriteria: t.AcceptanceCriteria,
+		Verify:             verificationToProto(t.Verify),
+	}
+}
+
+func verificationToProto(v *planning.Verification) *pb.Verification {
+	if v == nil {
+		return nil
+	}
+	return &pb.Verification{
+		Command: v.Command,
+		Test:    v.Test,
+		Package: v.Package,
+		Timeout: v.Timeout,
 	}
 }
 
@@ -159,6 +298,9 @@ func stateToProto(state *planning.ExecutionState) *pb.ExecutionState {
 
 	return &pb.ExecutionState{
 		TaskStates: taskStates,
+		ProjectId:  state.ProjectID,
+		Version:    int64(state.Version),
+		UpdatedAt:  timestamppb.New(state.UpdatedAt),
 	}
 }
 
@@ -168,13 +310,54 @@ func taskResultToProto(r planning.TaskResult) *pb.TaskResult {
 		refs[k] = externalRefToProto(v)
 	}
 
+	criteria := make([]*pb.CriterionEvidence, len(r.CriteriaEvidence))
+	for i, e := range r.CriteriaEvidence {
+		criteria[i] = &pb.CriterionEvidence{
+			Criterion:  e.Criterion,
+			Kind:       string(e.Kind),
+			Value:      e.Value,
+			RecordedBy: e.RecordedBy,
+			RecordedAt: timestamppb.New(e.RecordedAt),
+		}
+	}
+
+	var run *pb.VerificationRun
+	if r.VerificationRun != nil {
+		run = &pb.VerificationRun{
+			Command:    r.VerificationRun.Command,
+			ExitCode:   int64(r.VerificationRun.ExitCode),
+			TimedOut:   r.VerificationRun.TimedOut,
+			DurationMs: r.VerificationRun.DurationMS,
+			Digest:     r.VerificationRun.Digest,
+			Output:     r.VerificationRun.Output,
+			RanBy:      r.VerificationRun.RanBy,
+			RanAt:      timestamppb.New(r.VerificationRun.RanAt),
+		}
+	}
+
 	return &pb.TaskResult{
-		Status:       string(r.Status),
-		Owner:        r.Owner,
-		ExternalRefs: refs,
+		Status:           string(r.Status),
+		Owner:            r.Owner,
+		StartedAt:        optionalTimeToProto(r.StartedAt),
+		CompletedAt:      optionalTimeToProto(r.CompletedAt),
+		ExternalRefs:     refs,
+		Path:             r.Path,
+		Evidence:         r.Evidence,
+		CriteriaEvidence: criteria,
+		VerificationRun:  run,
+		ElapsedMinutes:   int64(r.ElapsedMinutes),
+		RateId:           r.RateID,
+		Comments:         commentsToProto(r.Comments),
 	}
 }
 
+func optionalTimeToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
 func externalRefToProto(ref planning.ExternalRef) *pb.ExternalRef {
 	return &pb.ExternalRef{
 		Id:           ref.ID,
@@ -194,6 +377,14 @@ func planFromProto(p *pb.Plan) *planning.Plan {
 		tasks[i] = taskFromProto(t)
 	}
 
+	var featureVerify map[string]planning.Verification
+	if len(p.FeatureVerify) > 0 {
+		featureVerify = make(map[string]planning.Verification, len(p.FeatureVerify))
+		for k, v := range p.FeatureVerify {
+			featureVerify[k] = *verificationFromProto(v)
+		}
+	}
+
 	return &planning.Plan{
 		ID:             p.Id,
 		SpecID:         p.SpecId,
@@ -201,16 +392,41 @@ func planFromProto(p *pb.Plan) *planning.Plan {
 		ApprovalStatus: planning.ApprovalStatus(p.ApprovalStatus),
 		CreatedAt:      p.CreatedAt.AsTime(),
 		UpdatedAt:      p.UpdatedAt.AsTime(),
+		FeatureVerify:  featureVerify,
 	}
 }
 
 func taskFromProto(t *pb.Task) planning.Task {
+	var source planning.TaskSource
+	if t.Source != nil {
+		source = planning.TaskSource{Doc: t.Source.Doc, Line: int(t.Source.Line)}
+	}
+
 	return planning.Task{
-		ID:          t.Id,
-		Title:       t.Title,
-		Description: t.Description,
-		Priority:    planning.TaskPriority(t.Phase),
-		DependsOn:   t.Dependencies,
+		ID:                 t.Id,
+		Title:              t.Title,
+		Description:        t.Description,
+		Priority:           planning.TaskPriority(t.Priority),
+		Estimate:           t.Estimate,
+		DependsOn:          t.Dependencies,
+		FeatureID:          t.FeatureId,
+		Origin:             planning.TaskOrigin(t.Origin),
+		Source:             source,
+		Files:              t.Files,
+		AcceptanceCriteria: t.AcceptanceCriteria,
+		Verify:             verificationFromProto(t.Verify),
+	}
+}
+
+func verificationFromProto(v *pb.Verification) *planning.Verification {
+	if v == nil {
+		return nil
+	}
+	return &planning.Verification{
+		Command: v.Command,
+		Test:    v.Test,
+		Package: v.Package,
+		Timeout: v.Timeout,
 	}
 }
 
@@ -225,7 +441,10 @@ func stateFromProto(s *pb.ExecutionState) *planning.ExecutionState {
 	}
 
 	return &planning.ExecutionState{
+		ProjectID:  s.ProjectId,
+		Version:    int(s.Version),
 		TaskStates: taskStates,
+		UpdatedAt:  s.UpdatedAt.AsTime(),
 	}
 }
 
@@ -235,13 +454,55 @@ func taskResultFromProto(r *pb.TaskResult) planning.TaskResult {
 		refs[k] = externalRefFromProto(v)
 	}
 
+	var criteria []planning.CriterionEvidence
+	for _, e := range r.CriteriaEvidence {
+		criteria = append(criteria, planning.CriterionEvidence{
+			Criterion:  e.Criterion,
+			Kind:       planning.EvidenceKind(e.Kind),
+			Value:      e.Value,
+			RecordedBy: e.RecordedBy,
+			RecordedAt: e.RecordedAt.AsTime(),
+		})
+	}
+
+	var run *planning.VerificationRun
+	if r.VerificationRun != nil {
+		run = &planning.VerificationRun{
+			Command:    r.VerificationRun.Command,
+			ExitCode:   int(r.VerificationRun.ExitCode),
+			TimedOut:   r.VerificationRun.TimedOut,
+			DurationMS: r.VerificationRun.DurationMs,
+			Digest:     r.VerificationRun.Digest,
+			Output:     r.VerificationRun.Output,
+			RanBy:      r.VerificationRun.RanBy,
+			RanAt:      r.VerificationRun.RanAt.AsTime(),
+		}
+	}
+
 	return planning.TaskResult{
-		Status:       planning.TaskStatus(r.Status),
-		Owner:        r.Owner,
-		ExternalRefs: refs,
+		Status:           planning.TaskStatus(r.Status),
+		Path:             r.Path,
+		Owner:            r.Owner,
+		Evidence:         r.Evidence,
+		CriteriaEvidence: criteria,
+		VerificationRun:  run,
+		ExternalRefs:     refs,
+		StartedAt:        optionalTimeFromProto(r.StartedAt),
+		CompletedAt:      optionalTimeFromProto(r.CompletedAt),
+		ElapsedMinutes:   int(r.ElapsedMinutes),
+		RateID:           r.RateId,
+		Comments:         commentsFromProto(r.Comments),
 	}
 }
 
+func optionalTimeFromProto(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
 func externalRefFromProto(ref *pb.ExternalRef) planning.ExternalRef {
 	return planning.ExternalRef{
 		ID:           ref.Id,
@@ -266,6 +527,7 @@ func syncResultToProto(result *domainPlugin.SyncResult) *pb.SyncResponse {
 		StatusUpdates: statusUpdates,
 		LinkUpdates:   linkUpdates,
 		Errors:        result.Errors,
+		Changes:       changesToProto(result.Changes),
 	}
 }
 
@@ -284,5 +546,135 @@ func syncResultFromProto(resp *pb.SyncResponse) *domainPlugin.SyncResult {
 		StatusUpdates: statusUpdates,
 		LinkUpdates:   linkUpdates,
 		Errors:        resp.Errors,
+		Changes:       changesFromProto(resp.Changes),
+	}
+}
+
+func eventToProto(e domainPlugin.ExternalEvent) *pb.Event {
+	var ref *pb.ExternalRef
+	if e.ExternalRef != nil {
+		ref = externalRefToProto(*e.ExternalRef)
+	}
+
+	return &pb.Event{
+		Id:          e.ID,
+		Type:        e.Type,
+		TaskId:      e.TaskID,
+		Status:      string(e.Status),
+		Provider:    e.Provider,
+		Timestamp:   timestamppb.New(e.Timestamp),
+		Metadata:    e.Metadata,
+		ExternalRef: ref,
+	}
+}
+
+func eventFromProto(e *pb.Event) domainPlugin.ExternalEvent {
+	var ref *planning.ExternalRef
+	if e.ExternalRef != nil {
+		r := externalRefFromProto(e.ExternalRef)
+		ref = &r
+	}
+
+	return domainPlugin.ExternalEvent{
+		ID:          e.Id,
+		Type:        e.Type,
+		TaskID:      e.TaskId,
+		Status:      planning.TaskStatus(e.Status),
+		Provider:    e.Provider,
+		Timestamp:   e.Timestamp.AsTime(),
+		Metadata:    e.Metadata,
+		ExternalRef: ref,
 	}
 }
+
+func commentsToProto(comments []planning.TaskComment) []*pb.Comment {
+	var out []*pb.Comment
+	for _, c := range comments {
+		out = append(out, &pb.Comment{
+			Id:         c.ID,
+			Author:     c.Author,
+			Body:       c.Body,
+			CreatedAt:  timestamppb.New(c.CreatedAt),
+			Provider:   c.Provider,
+			ExternalId: c.ExternalID,
+		})
+	}
+	return out
+}
+
+func commentsFromProto(comments []*pb.Comment) []planning.TaskComment {
+	var out []planning.TaskComment
+	for _, c := range comments {
+		out = append(out, planning.TaskComment{
+			ID:         c.Id,
+			Author:     c.Author,
+			Body:       c.Body,
+			CreatedAt:  c.CreatedAt.AsTime(),
+			Provider:   c.Provider,
+			ExternalID: c.ExternalId,
+		})
+	}
+	return out
+}
+
+// changesToProto encodes which optional fields are set in the fields mask.
+func changesToProto(changes []domainPlugin.TaskChange) []*pb.TaskChange {
+	var out []*pb.TaskChange
+	for _, c := range changes {
+		pc := &pb.TaskChange{
+			Kind:      string(c.Kind),
+			TaskId:    c.TaskID,
+			Fields:    c.Fields(),
+			Comments:  commentsToProto(c.Comments),
+			UpdatedAt: timestamppb.New(c.UpdatedAt),
+		}
+		if c.ExternalRef != nil {
+			pc.ExternalRef = externalRefToProto(*c.ExternalRef)
+		}
+		if c.Title != nil {
+			pc.Title = *c.Title
+		}
+		if c.Description != nil {
+			pc.Description = *c.Description
+		}
+		if c.Priority != nil {
+			pc.Priority = string(*c.Priority)
+		}
+		if c.Owner != nil {
+			pc.Owner = *c.Owner
+		}
+		out = append(out, pc)
+	}
+	return out
+}
+
+func changesFromProto(changes []*pb.TaskChange) []domainPlugin.TaskChange {
+	var out []domainPlugin.TaskChange
+	for _, pc := range changes {
+		c := domainPlugin.TaskChange{
+			Kind:      domainPlugin.ChangeKind(pc.Kind),
+			TaskID:    pc.TaskId,
+			Comments:  commentsFromProto(pc.Comments),
+			UpdatedAt: pc.UpdatedAt.AsTime(),
+		}
+		if pc.ExternalRef != nil {
+			ref := externalRefFromProto(pc.ExternalRef)
+			c.ExternalRef = &ref
+		}
+		for _, field := range pc.Fields {
+			switch field {
+			case domainPlugin.FieldTitle:
+				c.Title = &pc.Title
+			case domainPlugin.FieldDescription:
+				c.Description = &pc.Description
+			case domainPlugin.FieldPriority:
+				p := planning.TaskPriority(pc.Priority)
+				c.Priority = &p
+			case domainPlugin.FieldOwner:
+				c.Owner = &pc.Owner
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
@@ -47,6 +47,24 @@ func (f *fakeSyncer) Push(taskID string, status planning.TaskStatus) error {
 	return nil
 }
 
+// subscribingSyncer streams its events, then waits for cancellation.
+type subscribingSyncer struct {
+	fakeSyncer
+	events []domainPlugin.ExternalEvent
+	plan   *planning.Plan
+}
+
+func (s *subscribingSyncer) Subscribe(ctx context.Context, plan *planning.Plan, state *planning.ExecutionState, eventTypes []string, handle func(domainPlugin.ExternalEvent) error) error {
+	s.plan = plan
+	for _, e := range s.events {
+		if err := handle(e); err != nil {
+			return err
+		}
+	}
+	<-ctx.Done()
+	return nil
+}
+
 // startGRPCServer starts an in-process gRPC server with bufconn.
 func startGRPCServer(t *testing.T, impl domainPlugin.Syncer) (*grpc.ClientConn, func()) {
 	t.Helper()
@@ -219,3 +237,105 @@ func TestGRPCClientServer_Sync_NilPlanAndState(t *testing.T) {
 		t.Fatal("expected non-nil result")
 	}
 }
+
+func TestGRPCClientServer_Subscribe(t *testing.T) {
+	impl := &subscribingSyncer{events: []domainPlugin.ExternalEvent{
+		{ID: "e1", Type: domainPlugin.EventStatusChanged, TaskID: "task-1", Status: planning.StatusDone},
+		{ID: "e2", Type: domainPlugin.EventLinked, TaskID: "task-2", ExternalRef: &planning.ExternalRef{Identifier: "GH-7"}},
+	}}
+	conn, cleanup := startGRPCServer(t, impl)
+	defer cleanup()
+
+	client := infraPlugin.NewGRPCClient(conn)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	plan := &planning.Plan{ID: "plan-1", Tasks: []planning.Task{{ID: "task-1", Priority: planning.PriorityHigh}}}
+
+	var got []domainPlugin.ExternalEvent
+	err := client.Subscribe(ctx, plan, planning.NewExecutionState("p"), nil, func(e domainPlugin.ExternalEvent) error {
+		got = append(got, e)
+		if len(got) == len(impl.events) {
+			cancel()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	if len(got) != 2 || got[0].Status != planning.StatusDone || got[1].ExternalRef == nil || got[1].ExternalRef.Identifier != "GH-7" {
+		t.Errorf("unexpected events: %+v", got)
+	}
+	if impl.plan == nil || impl.plan.Tasks[0].Priority != planning.PriorityHigh {
+		t.Errorf("expected the plugin to receive the plan, got %+v", impl.plan)
+	}
+}
+
+func TestGRPCClientServer_Subscribe_Unsupported(t *testing.T) {
+	conn, cleanup := startGRPCServer(t, &fakeSyncer{})
+	defer cleanup()
+
+	client := infraPlugin.NewGRPCClient(conn)
+	err := client.Subscribe(context.Background(), nil, nil, nil, func(domainPlugin.ExternalEvent) error { return nil })
+	if !errors.Is(err, domainPlugin.ErrSubscribeUnsupported) {
+		t.Errorf("expected ErrSubscribeUnsupported, got %v", err)
+	}
+}
+
+func TestGRPCClientServer_Subscribe_HandlerError(t *testing.T) {
+	impl := &subscribingSyncer{events: []domainPlugin.ExternalEvent{{ID: "e1", Type: domainPlugin.EventStatusChanged, TaskID: "task-1"}}}
+	conn, cleanup := startGRPCServer(t, impl)
+	defer cleanup()
+
+	client := infraPlugin.NewGRPCClient(conn)
+	stop := errors.New("stop")
+	err := client.Subscribe(context.Background(), nil, nil, nil, func(domainPlugin.ExternalEvent) error { return stop })
+	if !errors.Is(err, stop) {
+		t.Errorf("expected the handler error, got %v", err)
+	}
+}
+
+// fieldSyncer records the changes pushed to it and links created tasks.
+type fieldSyncer struct {
+	fakeSyncer
+	pushed []domainPlugin.TaskChange
+}
+
+func (f *fieldSyncer) PushChanges(changes []domainPlugin.TaskChange) (*domainPlugin.SyncResult, error) {
+	f.pushed = changes
+	links := map[string]planning.ExternalRef{}
+	for _, c := range changes {
+		if c.Kind == domainPlugin.ChangeCreate {
+			links[c.TaskID] = planning.ExternalRef{ID: "ext-" + c.TaskID}
+		}
+	}
+	return &domainPlugin.SyncResult{LinkUpdates: links}, nil
+}
+
+func TestGRPCClientServer_PushChanges(t *testing.T) {
+	impl := &fieldSyncer{}
+	conn, cleanup := startGRPCServer(t, impl)
+	defer cleanup()
+
+	client := infraPlugin.NewGRPCClient(conn)
+	title := "New task"
+	result, err := client.PushChanges([]domainPlugin.TaskChange{{Kind: domainPlugin.ChangeCreate, TaskID: "task-1", Title: &title}})
+	if err != nil {
+		t.Fatalf("PushChanges: %v", err)
+	}
+	if len(impl.pushed) != 1 || impl.pushed[0].Title == nil || *impl.pushed[0].Title != title {
+		t.Errorf("plugin received %+v", impl.pushed)
+	}
+	if result.LinkUpdates["task-1"].ID != "ext-task-1" {
+		t.Errorf("expected the created task to be linked, got %+v", result.LinkUpdates)
+	}
+}
+
+func TestGRPCClientServer_PushChanges_Unsupported(t *testing.T) {
+	conn, cleanup := startGRPCServer(t, &fakeSyncer{})
+	defer cleanup()
+
+	client := infraPlugin.NewGRPCClient(conn)
+	if _, err := client.PushChanges(nil); !errors.Is(err, domainPlugin.ErrFieldSyncUnsupported) {
+		t.Errorf("expected ErrFieldSyncUnsupported, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package plugin
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
@@ -106,6 +107,105 @@ func TestTaskFromProto(t *testing.T) {
 	}
 }
 
+func TestTaskRoundTrip_FullModel(t *testing.T) {
+	task := planning.Task{
+		ID:                 "task-full",
+		Title:              "Full Task",
+		Description:        "Every field set",
+		Priority:           planning.PriorityHigh,
+		Estimate:           "4h",
+		DependsOn:          []string{"task-0"},
+		FeatureID:          "feature-1",
+		Origin:             planning.OriginAI,
+		Source:             planning.TaskSource{Doc: "docs/spec.md", Line: 42},
+		Files:              []string{"pkg/plugin"},
+		AcceptanceCriteria: []string{"it round-trips"},
+		Verify:             &planning.Verification{Test: "TestRoundTrip", Package: "./pkg/plugin", Timeout: "90s"},
+	}
+
+	if got := taskFromProto(taskToProto(task)); !reflect.DeepEqual(got, task) {
+		t.Errorf("task round trip mismatch:\n got %+v\nwant %+v", got, task)
+	}
+}
+
+func TestTaskResultRoundTrip_FullModel(t *testing.T) {
+	started := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	completed := started.Add(90 * time.Minute)
+	result := planning.TaskResult{
+		Status:   planning.StatusVerified,
+		Path:     "pkg/plugin",
+		Owner:    "alice",
+		Evidence: []string{"abc123", "https://example.com/pr/1"},
+		CriteriaEvidence: []planning.CriterionEvidence{
+			{Criterion: "it round-trips", Kind: planning.EvidenceCommit, Value: "abc123", RecordedBy: "alice", RecordedAt: completed},
+		},
+		VerificationRun: &planning.VerificationRun{Command: "go test ./pkg/plugin", ExitCode: 1, TimedOut: true, DurationMS: 1500, Digest: "sha256:00", Output: "FAIL", RanBy: "ci", RanAt: completed},
+		ExternalRefs: map[string]planning.ExternalRef{
+			"jira": {ID: "10001", Identifier: "PROJ-1", URL: "https://example.atlassian.net/browse/PROJ-1", LastSyncedAt: completed},
+		},
+		StartedAt:      &started,
+		CompletedAt:    &completed,
+		ElapsedMinutes: 90,
+		RateID:         "senior",
+		Comments: []planning.TaskComment{
+			{ID: "c1", Author: "bob", Body: "Ship it", CreatedAt: completed, Provider: "jira", ExternalID: "20001"},
+		},
+	}
+
+	if got := taskResultFromProto(taskResultToProto(result)); !reflect.DeepEqual(got, result) {
+		t.Errorf("task result round trip mismatch:\n got %+v\nwant %+v", got, result)
+	}
+
+	// Unset times stay unset.
+	if got := taskResultFromProto(taskResultToProto(planning.TaskResult{Status: planning.StatusPending})); got.StartedAt != nil || got.CompletedAt != nil {
+		t.Errorf("expected no timestamps, got %v and %v", got.StartedAt, got.CompletedAt)
+	}
+}
+
+func TestEventRoundTrip(t *testing.T) {
+	event := domainPlugin.ExternalEvent{
+		ID:          "evt-1",
+		Type:        domainPlugin.EventLinked,
+		TaskID:      "task-1",
+		Provider:    "linear",
+		Timestamp:   time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC),
+		Metadata:    map[string]string{"team": "core"},
+		ExternalRef: &planning.ExternalRef{ID: "lin-1", Identifier: "LIN-1", URL: "https://linear.app/issue/LIN-1", LastSyncedAt: time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)},
+	}
+
+	if got := eventFromProto(eventToProto(event)); !reflect.DeepEqual(got, event) {
+		t.Errorf("event round trip mismatch:\n got %+v\nwant %+v", got, event)
+	}
+}
+
+func TestTaskChangeRoundTrip(t *testing.T) {
+	title := "Login page"
+	owner := ""
+	high := planning.PriorityHigh
+	changes := []domainPlugin.TaskChange{
+		{
+			Kind:        domainPlugin.ChangeUpdate,
+			TaskID:      "task-1",
+			ExternalRef: &planning.ExternalRef{ID: "10001", Identifier: "PROJ-1", LastSyncedAt: time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)},
+			Title:       &title,
+			Priority:    &high,
+			Owner:       &owner,
+			Comments:    []planning.TaskComment{{ID: "c1", Author: "alice", Body: "Done", CreatedAt: time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)}},
+			UpdatedAt:   time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC),
+		},
+		{Kind: domainPlugin.ChangeClose, TaskID: "task-2", UpdatedAt: time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)},
+	}
+
+	got := changesFromProto(changesToProto(changes))
+	if !reflect.DeepEqual(got, changes) {
+		t.Errorf("change round trip mismatch:\n got %+v\nwant %+v", got, changes)
+	}
+	// An owner cleared to "" is still a change; an unset description is not.
+	if got[0].Owner == nil || got[0].Description != nil {
+		t.Errorf("expected the field mask to survive, got owner %v and description %v", got[0].Owner, got[0].Description)
+	}
+}
+
 func TestStateToProto(t *testing.T) {
 	state := &planning.ExecutionState{
 		TaskStates: map[string]planning.TaskResult{
@@ -11,8 +11,19 @@ import (
 	goplugin "github.com/hashicorp/go-plugin"
 )
 
+// Plugin protocol versions. Version 1 carries the Syncer over net/rpc;
+// version 2 carries it over gRPC with the full task model and Subscribe.
+const (
+	ProtocolVersionNetRPC = 1
+	ProtocolVersionGRPC   = 2
+)
+
+// HandshakeConfig is shared by Roady and its plugins. Its ProtocolVersion is
+// the version served by plugins that only set ServeConfig.Plugins; Roady
+// offers every version in VersionedPlugins and the plugin picks the newest
+// one it serves.
 var HandshakeConfig = goplugin.HandshakeConfig{
-	ProtocolVersion:  1,
+	ProtocolVersion:  ProtocolVersionNetRPC,
 	MagicCookieKey:   "ROADY_PLUGIN",
 	MagicCookieValue: "roady",
 }
@@ -21,6 +32,27 @@ var PluginMap = map[string]goplugin.Plugin{
 	"syncer": &domainPlugin.SyncerPlugin{},
 }
 
+// VersionedPlugins maps each protocol version to the plugins served with it.
+var VersionedPlugins = map[int]goplugin.PluginSet{
+	ProtocolVersionNetRPC: PluginMap,
+	ProtocolVersionGRPC: {
+		"syncer": &SyncerGRPCPlugin{},
+	},
+}
+
+// Serve runs impl as a plugin that speaks both protocol versions. It is
+// called from a plugin's main function and does not return.
+func Serve(impl domainPlugin.Syncer) {
+	goplugin.Serve(&goplugin.ServeConfig{
+		HandshakeConfig: HandshakeConfig,
+		VersionedPlugins: map[int]goplugin.PluginSet{
+			ProtocolVersionNetRPC: {"syncer": &domainPlugin.SyncerPlugin{Impl: impl}},
+			ProtocolVersionGRPC:   {"syncer": &SyncerGRPCPlugin{Impl: impl}},
+		},
+		GRPCServer: goplugin.DefaultGRPCServer,
+	})
+}
+
 type Loader struct {
 	plugins map[string]*goplugin.Client
 }
@@ -58,11 +90,12 @@ func (l *Loader) Load(path string) (domainPlugin.Syncer, error) {
 	}
 
 	client := goplugin.NewClient(&goplugin.ClientConfig{
-		HandshakeConfig: HandshakeConfig,
-		Plugins:         PluginMap,
-		Cmd:             exec.Command(path),
+		HandshakeConfig:  HandshakeConfig,
+		VersionedPlugins: VersionedPlugins,
+		Cmd:              exec.Command(path),
 		AllowedProtocols: []goplugin.Protocol{
 			goplugin.ProtocolNetRPC,
+			goplugin.ProtocolGRPC,
 		},
 	})
 
@@ -82,6 +115,16 @@ func (l *Loader) Load(path string) (domainPlugin.Syncer, error) {
 	return raw.(domainPlugin.Syncer), nil
 }
 
+// ProtocolVersion returns the protocol version negotiated with the plugin
+// loaded from path, or 0 when it is not loaded.
+func (l *Loader) ProtocolVersion(path string) int {
+	client, ok := l.plugins[path]
+	if !ok {
+		return 0
+	}
+	return client.NegotiatedVersion()
+}
+
 func (l *Loader) Cleanup() {
 	for _, client := range l.plugins {
 		client.Kill()
@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "plan.json")
+
+	if err := WriteFileAtomic(path, []byte("one"), 0600); err != nil {
+		t.Fatalf("WriteFileAtomic: %v", err)
+	}
+	if err := WriteFileAtomic(path, []byte("two"), 0600); err != nil {
+		t.Fatalf("WriteFileAtomic: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "two" {
+		t.Fatalf("got %q, %v", data, err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("temporary files left behind: %v", entries)
+	}
+
+	if err := WriteFileAtomic(filepath.Join(dir, "missing", "x.json"), []byte("x"), 0600); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
+
+func TestFilesystemRepository_WithLock_Serialises(t *testing.T) {
+	repo := NewFilesystemRepository(t.TempDir())
+	if err := repo.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	counter := filepath.Join(repo.ProjectBase(), "counter")
+	if err := os.WriteFile(counter, []byte{0}, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Go(func() {
+			err := repo.WithLock(func() error {
+				data, err := os.ReadFile(counter)
+				if err != nil {
+					return err
+				}
+				return WriteFileAtomic(counter, []byte{data[0] + 1}, 0600)
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	wg.Wait()
+
+	data, _ := os.ReadFile(counter)
+	if data[0] != 20 {
+		t.Errorf("expected 20 serialised increments, got %d", data[0])
+	}
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+)
+
+// AuditCheckpointsFile holds the signed audit checkpoints of a project. It
+// is kept on disk with either storage backend.
+const AuditCheckpointsFile = "audit-checkpoints.jsonl"
+
+// FileAuditCheckpointLog implements events.AuditCheckpointLog as a JSON Lines
+// file under the project base.
+type FileAuditCheckpointLog struct {
+	basePath string
+}
+
+// NewFileAuditCheckpointLog creates a checkpoint log under basePath.
+func NewFileAuditCheckpointLog(basePath string) *FileAuditCheckpointLog {
+	return &FileAuditCheckpointLog{basePath: basePath}
+}
+
+// AppendCheckpoint adds a checkpoint to the log.
+func (l *FileAuditCheckpointLog) AppendCheckpoint(c events.AuditCheckpoint) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("marshal checkpoint: %w", err)
+	}
+	if err := os.MkdirAll(l.basePath, 0750); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+	return withDirLock(l.basePath, func() (err error) {
+		// #nosec G304 -- path is the project base and a constant file name
+		f, err := os.OpenFile(filepath.Join(l.basePath, AuditCheckpointsFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return fmt.Errorf("open checkpoints file: %w", err)
+		}
+		defer func() {
+			if cerr := f.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("close checkpoints file: %w", cerr)
+			}
+		}()
+		if _, err := f.Write(append(data, '\n')); err != nil {
+			return fmt.Errorf("write checkpoint: %w", err)
+		}
+		return nil
+	})
+}
+
+// LoadCheckpoints returns the recorded checkpoints, oldest first.
+func (l *FileAuditCheckpointLog) LoadCheckpoints() ([]events.AuditCheckpoint, error) {
+	// #nosec G304 -- path is the project base and a constant file name
+	data, err := os.ReadFile(filepath.Join(l.basePath, AuditCheckpointsFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read checkpoints file: %w", err)
+	}
+
+	var checkpoints []events.AuditCheckpoint
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var c events.AuditCheckpoint
+		if err := json.Unmarshal(scanner.Bytes(), &c); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", AuditCheckpointsFile, line, err)
+		}
+		checkpoints = append(checkpoints, c)
+	}
+	return checkpoints, scanner.Err()
+}
+
+var _ events.AuditCheckpointLog = (*FileAuditCheckpointLog)(nil)
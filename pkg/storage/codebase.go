@@ -3,21 +3,41 @@ package storage
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/drift"
 )
 
 // CodebaseInspector implements CodeInspector using os and exec calls.
-type CodebaseInspector struct{}
+type CodebaseInspector struct {
+	root string // Directory relative paths and git commands resolve against; "" means the working directory
+}
 
 func NewCodebaseInspector() *CodebaseInspector {
 	return &CodebaseInspector{}
 }
 
+// NewCodebaseInspectorAt returns an inspector rooted at the given repository
+// directory instead of the process working directory.
+func NewCodebaseInspectorAt(root string) *CodebaseInspector {
+	return &CodebaseInspector{root: root}
+}
+
+func (i *CodebaseInspector) resolve(path string) string {
+	if i.root == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(i.root, path)
+}
+
 func (i *CodebaseInspector) FileExists(path string) (bool, error) {
-	_, err := os.Stat(path)
+	_, err := os.Stat(i.resolve(path))
 	if os.IsNotExist(err) {
 		return false, nil
 	}
@@ -25,7 +45,7 @@ func (i *CodebaseInspector) FileExists(path string) (bool, error) {
 }
 
 func (i *CodebaseInspector) FileNotEmpty(path string) (bool, error) {
-	info, err := os.Stat(path)
+	info, err := os.Stat(i.resolve(path))
 	if os.IsNotExist(err) {
 		return false, nil
 	}
@@ -44,7 +64,7 @@ func (i *CodebaseInspector) GitStatus(path string) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "git", "status", "--porcelain", path)
+	cmd := exec.CommandContext(ctx, "git", i.gitArgs("status", "--porcelain", path)...)
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	// ignore stderr, if git fails (e.g. not a repo), we return unknown/error
@@ -88,3 +108,127 @@ func (i *CodebaseInspector) GitStatus(path string) (string, error) {
 
 	return "unknown", nil
 }
+
+// gitArgs prefixes args with -C root when the inspector is rooted.
+func (i *CodebaseInspector) gitArgs(args ...string) []string {
+	if i.root == "" {
+		return args
+	}
+	return append([]string{"-C", i.root}, args...)
+}
+
+// git runs a git command and returns its trimmed stdout.
+func (i *CodebaseInspector) git(args ...string) (string, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// #nosec G204 -- arguments are fixed subcommands plus pathspecs and commit hashes
+	cmd := exec.CommandContext(ctx, "git", i.gitArgs(args...)...)
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// pathspecs turns file globs into git glob pathspecs.
+func pathspecs(patterns []string) []string {
+	specs := make([]string, 0, len(patterns)+1)
+	specs = append(specs, "--")
+	for _, p := range patterns {
+		specs = append(specs, ":(glob)"+strings.TrimSuffix(p, "/"))
+	}
+	return specs
+}
+
+func lines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// resolveCommit expands an abbreviated hash, returning drift.ErrCommitNotFound
+// for unknown or malformed references.
+func (i *CodebaseInspector) resolveCommit(commit string) (string, error) {
+	if strings.HasPrefix(commit, "-") {
+		return "", drift.ErrCommitNotFound
+	}
+	full, err := i.git("rev-parse", "--verify", "--quiet", commit+"^{commit}")
+	if err != nil || full == "" {
+		return "", drift.ErrCommitNotFound
+	}
+	return full, nil
+}
+
+// TrackedFiles returns the tracked files matching the glob patterns.
+func (i *CodebaseInspector) TrackedFiles(patterns []string) ([]string, error) {
+	out, err := i.git(append([]string{"ls-files"}, pathspecs(patterns)...)...)
+	if err != nil {
+		return nil, err
+	}
+	return lines(out), nil
+}
+
+// CommitFiles returns the files changed by commit that match the patterns.
+func (i *CodebaseInspector) CommitFiles(commit string, patterns []string) ([]string, error) {
+	full, err := i.resolveCommit(commit)
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"diff-tree", "--no-commit-id", "--name-only", "--relative", "-r", "--root", full}
+	out, err := i.git(append(args, pathspecs(patterns)...)...)
+	if err != nil {
+		return nil, err
+	}
+	return lines(out), nil
+}
+
+// RevertedBy returns the first commit whose message records that it reverts
+// commit, as written by git revert.
+func (i *CodebaseInspector) RevertedBy(commit string) (string, error) {
+	full, err := i.resolveCommit(commit)
+	if err != nil {
+		return "", err
+	}
+	out, err := i.git("log", "--all", "--format=%H", "--fixed-strings", "--grep=This reverts commit "+full)
+	if err != nil {
+		return "", err
+	}
+	revs := lines(out)
+	if len(revs) == 0 {
+		return "", nil
+	}
+	return revs[len(revs)-1], nil
+}
+
+// CommitsSince returns commits after since that touch the patterns, oldest first.
+func (i *CodebaseInspector) CommitsSince(since time.Time, patterns []string) ([]drift.Commit, error) {
+	args := []string{"log", "--reverse", "--format=%H%x09%ct%x09%s", "--since=" + since.UTC().Format(time.RFC3339)}
+	out, err := i.git(append(args, pathspecs(patterns)...)...)
+	if err != nil {
+		return nil, err
+	}
+	var commits []drift.Commit
+	for _, line := range lines(out) {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		ts, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		when := time.Unix(ts, 0)
+		if !when.After(since) {
+			continue
+		}
+		commits = append(commits, drift.Commit{Hash: parts[0], Time: when, Subject: parts[2]})
+	}
+	return commits, nil
+}
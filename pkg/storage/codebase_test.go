@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/drift"
+)
+
+// gitRepo initialises a throwaway repository and returns a helper that runs
+// git inside it.
+func gitRepo(t *testing.T) (string, func(args ...string) string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	run("init", "-q")
+	return dir, run
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCodebaseInspector_GitHistory(t *testing.T) {
+	dir, git := gitRepo(t)
+	inspector := NewCodebaseInspectorAt(dir)
+	var _ drift.GitInspector = inspector
+
+	writeFile(t, dir, "pkg/auth/login.go", "package auth\n")
+	writeFile(t, dir, "README.md", "readme\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "add login")
+	impl := git("rev-parse", "HEAD")
+
+	files, err := inspector.TrackedFiles([]string{"pkg/auth/**"})
+	if err != nil || len(files) != 1 || files[0] != "pkg/auth/login.go" {
+		t.Fatalf("TrackedFiles = %v, %v", files, err)
+	}
+	if files, _ := inspector.TrackedFiles([]string{"pkg/auth"}); len(files) != 1 {
+		t.Errorf("expected directory pattern to match, got %v", files)
+	}
+
+	changed, err := inspector.CommitFiles(impl[:8], []string{"pkg/auth/**"})
+	if err != nil || len(changed) != 1 {
+		t.Fatalf("CommitFiles = %v, %v", changed, err)
+	}
+	if _, err := inspector.CommitFiles("0123456789abcdef", nil); !errors.Is(err, drift.ErrCommitNotFound) {
+		t.Errorf("expected ErrCommitNotFound, got %v", err)
+	}
+
+	completed := time.Now().Add(-time.Minute)
+	writeFile(t, dir, "pkg/auth/session.go", "package auth\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "add session")
+	git("revert", "--no-edit", impl)
+	revert := git("rev-parse", "HEAD")
+
+	by, err := inspector.RevertedBy(impl)
+	if err != nil || by != revert {
+		t.Errorf("RevertedBy = %q, %v; want %q", by, err, revert)
+	}
+
+	later, err := inspector.CommitsSince(completed, []string{"pkg/auth/**"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(later) != 3 || later[1].Subject != "add session" {
+		t.Errorf("CommitsSince = %+v", later)
+	}
+}
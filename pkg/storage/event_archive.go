@@ -0,0 +1,363 @@
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
+	"gopkg.in/yaml.v3"
+)
+
+// Event archive layout for the files backend.
+const (
+	// EventArchiveDir holds compacted segments of events.jsonl.
+	EventArchiveDir = "archive"
+	// EventArchiveManifest lists the segments in order, with their anchors.
+	EventArchiveManifest = "manifest.json"
+)
+
+// EventSegment describes one archived, gzip'd run of events. Its Anchor is
+// a SHA-256 over the segment's checksum, its first and last chain hashes,
+// its event count and the previous segment's anchor, signed by the identity
+// that compacted the log with its team.yaml key. Segments cannot be altered,
+// dropped or reordered without detection, and anyone holding team.yaml can
+// check the signatures.
+type EventSegment struct {
+	File      string    `json:"file"`
+	Events    int       `json:"events"`
+	FirstID   string    `json:"first_id"`
+	LastID    string    `json:"last_id"`
+	PrevHash  string    `json:"prev_hash"`
+	LastHash  string    `json:"last_hash"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	SHA256    string    `json:"sha256"`
+	Anchor    string    `json:"anchor"`
+	Signer    string    `json:"signer"`
+	Signature string    `json:"signature"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// eventArchive is the serialized representation of the archive manifest.
+type eventArchive struct {
+	Segments []EventSegment `json:"segments"`
+}
+
+// CompactOptions selects the events CompactEvents archives: those older
+// than Before if it is set, otherwise all but the newest Keep. The newest
+// event always stays in events.jsonl so that appends keep chaining to it.
+// Signer signs the new segment's anchor and is required when there is
+// anything to archive.
+type CompactOptions struct {
+	Keep   int
+	Before time.Time
+	Signer *events.Signer
+}
+
+// CompactionResult summarises an event log compaction.
+type CompactionResult struct {
+	Segment   string `json:"segment,omitempty"`
+	Archived  int    `json:"archived"`
+	Remaining int    `json:"remaining"`
+	Segments  int    `json:"segments"`
+}
+
+// CompactEvents moves old events from events.jsonl into a new gzip'd segment
+// under archive/ and signs it into the manifest. Readers see the archived
+// and active events as one log, and VerifyIntegrity checks the chain across
+// segments.
+func (r *FilesystemRepository) CompactEvents(opts CompactOptions) (*CompactionResult, error) {
+	base := r.ProjectBase()
+	var result *CompactionResult
+	err := r.WithLock(func() error {
+		archive, err := loadEventArchive(base)
+		if err != nil {
+			return err
+		}
+		active, err := readActiveEventLines(base, archive)
+		if err != nil {
+			return err
+		}
+		parsed, err := parseEventLines(active)
+		if err != nil {
+			return err
+		}
+
+		n := len(parsed) - opts.Keep
+		if !opts.Before.IsZero() {
+			n = 0
+			for n < len(parsed) && parsed[n].Timestamp.Before(opts.Before) {
+				n++
+			}
+		}
+		n = min(n, len(parsed)-1)
+		result = &CompactionResult{Remaining: len(parsed), Segments: len(archive.Segments)}
+		if n <= 0 {
+			return nil
+		}
+
+		if opts.Signer == nil {
+			return fmt.Errorf("compacting the event log requires a signer")
+		}
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		for _, line := range active[:n] {
+			_, _ = zw.Write(line)
+			_, _ = zw.Write([]byte("\n"))
+		}
+		if err := zw.Close(); err != nil {
+			return fmt.Errorf("compress events: %w", err)
+		}
+
+		first, last := parsed[0], parsed[n-1]
+		sum := sha256.Sum256(buf.Bytes())
+		seg := EventSegment{
+			File:      fmt.Sprintf("events-%06d.jsonl.gz", len(archive.Segments)+1),
+			Events:    n,
+			FirstID:   first.ID,
+			LastID:    last.ID,
+			PrevHash:  first.PrevHash,
+			LastHash:  last.Hash,
+			From:      first.Timestamp,
+			To:        last.Timestamp,
+			SHA256:    hex.EncodeToString(sum[:]),
+			CreatedAt: time.Now().UTC(),
+		}
+		seg.Anchor = seg.anchor(archive.lastAnchor())
+		seg.Signer, seg.Signature = opts.Signer.ID, opts.Signer.SignAnchor(seg.Anchor)
+
+		// Segment, then manifest, then the shortened log: a crash in between
+		// leaves events both archived and active, which readers skip.
+		dir := filepath.Join(base, EventArchiveDir)
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return fmt.Errorf("create archive directory: %w", err)
+		}
+		if err := WriteFileAtomic(filepath.Join(dir, seg.File), buf.Bytes(), 0600); err != nil {
+			return err
+		}
+		archive.Segments = append(archive.Segments, seg)
+		if err := saveEventArchive(base, archive); err != nil {
+			return err
+		}
+		if err := WriteFileAtomic(filepath.Join(base, EventsFile), joinLines(active[n:]), 0600); err != nil {
+			return err
+		}
+
+		result = &CompactionResult{Segment: seg.File, Archived: n, Remaining: len(parsed) - n, Segments: len(archive.Segments)}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// VerifyEventArchive checks every archived segment against its checksum and
+// anchor, each anchor's signature against the public keys in team.yaml, and
+// that the segments continue each other's hash chain.
+// The chain through the events themselves is checked by VerifyIntegrity.
+func (r *FilesystemRepository) VerifyEventArchive() ([]string, error) {
+	return verifyEventArchive(r.ProjectBase())
+}
+
+func verifyEventArchive(base string) ([]string, error) {
+	archive, err := loadEventArchive(base)
+	if err != nil || len(archive.Segments) == 0 {
+		return nil, err
+	}
+	keys, err := loadTeamKeys(base)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []string
+	prevAnchor, prevHash := "", ""
+	for _, seg := range archive.Segments {
+		switch {
+		case seg.Anchor != seg.anchor(prevAnchor):
+			violations = append(violations, fmt.Sprintf("Segment %s: anchor mismatch", seg.File))
+		case seg.Signature == "":
+			violations = append(violations, fmt.Sprintf("Segment %s: anchor is not signed", seg.File))
+		default:
+			if err := keys.VerifyAnchor(seg.Signer, seg.Anchor, seg.Signature); err != nil {
+				violations = append(violations, fmt.Sprintf("Segment %s: %v", seg.File, err))
+			}
+		}
+		if seg.PrevHash != prevHash {
+			violations = append(violations, fmt.Sprintf("Segment %s: does not continue the previous segment", seg.File))
+		}
+		prevAnchor, prevHash = seg.Anchor, seg.LastHash
+
+		// #nosec G304 -- segment names come from the manifest and are reduced to a base name
+		data, err := os.ReadFile(filepath.Join(base, EventArchiveDir, filepath.Base(seg.File)))
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("Segment %s: %v", seg.File, err))
+			continue
+		}
+		if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != seg.SHA256 {
+			violations = append(violations, fmt.Sprintf("Segment %s: checksum mismatch - possible tampering", seg.File))
+			continue
+		}
+		lines, err := gunzipLines(data)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("Segment %s: %v", seg.File, err))
+			continue
+		}
+		parsed, err := parseEventLines(lines)
+		if err != nil || len(parsed) != seg.Events || len(parsed) == 0 {
+			violations = append(violations, fmt.Sprintf("Segment %s: expected %d events", seg.File, seg.Events))
+			continue
+		}
+		if parsed[0].ID != seg.FirstID || parsed[0].PrevHash != seg.PrevHash ||
+			parsed[len(parsed)-1].ID != seg.LastID || parsed[len(parsed)-1].Hash != seg.LastHash {
+			violations = append(violations, fmt.Sprintf("Segment %s: events do not match the manifest", seg.File))
+		}
+	}
+	return violations, nil
+}
+
+// anchor computes the segment's anchor, chained to the previous one.
+func (s EventSegment) anchor(prevAnchor string) string {
+	h := sha256.New()
+	for _, part := range []string{prevAnchor, s.SHA256, s.PrevHash, s.LastHash, strconv.Itoa(s.Events)} {
+		h.Write([]byte(part))
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (a *eventArchive) lastAnchor() string {
+	if len(a.Segments) == 0 {
+		return ""
+	}
+	return a.Segments[len(a.Segments)-1].Anchor
+}
+
+func loadEventArchive(base string) (*eventArchive, error) {
+	// #nosec G304 -- path is built from the project base and constant names
+	data, err := os.ReadFile(filepath.Join(base, EventArchiveDir, EventArchiveManifest))
+	if os.IsNotExist(err) {
+		return &eventArchive{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read archive manifest: %w", err)
+	}
+	var archive eventArchive
+	if err := json.Unmarshal(data, &archive); err != nil {
+		return nil, fmt.Errorf("unmarshal archive manifest: %w", err)
+	}
+	return &archive, nil
+}
+
+func saveEventArchive(base string, archive *eventArchive) error {
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal archive manifest: %w", err)
+	}
+	return WriteFileAtomic(filepath.Join(base, EventArchiveDir, EventArchiveManifest), data, 0600)
+}
+
+// loadTeamKeys returns the signing keys registered in the project's
+// team.yaml.
+func loadTeamKeys(base string) (events.KeyRing, error) {
+	// #nosec G304 -- path is built from the project base and a constant name
+	data, err := os.ReadFile(filepath.Join(base, TeamFile))
+	if os.IsNotExist(err) {
+		return events.KeyRing{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read team file: %w", err)
+	}
+	var cfg team.TeamConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal team config: %w", err)
+	}
+	keys, err := cfg.PublicKeys()
+	if err != nil {
+		return nil, fmt.Errorf("team.yaml: %w", err)
+	}
+	return keys, nil
+}
+
+// readEventLog returns every event line, archived segments first, as one
+// JSON Lines log.
+func readEventLog(base string) ([][]byte, error) {
+	archive, err := loadEventArchive(base)
+	if err != nil {
+		return nil, err
+	}
+	var lines [][]byte
+	for _, seg := range archive.Segments {
+		// #nosec G304 -- segment names come from the manifest and are reduced to a base name
+		data, err := os.ReadFile(filepath.Join(base, EventArchiveDir, filepath.Base(seg.File)))
+		if err != nil {
+			return nil, fmt.Errorf("read archived events: %w", err)
+		}
+		segLines, err := gunzipLines(data)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", seg.File, err)
+		}
+		lines = append(lines, segLines...)
+	}
+	active, err := readActiveEventLines(base, archive)
+	if err != nil {
+		return nil, err
+	}
+	return append(lines, active...), nil
+}
+
+// readActiveEventLines returns the lines of events.jsonl that are not yet
+// archived. Lines up to the last archived event are left over from an
+// interrupted compaction and are skipped.
+func readActiveEventLines(base string, archive *eventArchive) ([][]byte, error) {
+	lines, err := readLines(filepath.Join(base, EventsFile))
+	if err != nil || len(archive.Segments) == 0 || len(lines) == 0 {
+		return lines, err
+	}
+	last := archive.Segments[len(archive.Segments)-1]
+	var first events.BaseEvent
+	if json.Unmarshal(lines[0], &first) == nil && first.PrevHash == last.LastHash {
+		return lines, nil
+	}
+	for i, line := range lines {
+		var e events.BaseEvent
+		if json.Unmarshal(line, &e) == nil && e.ID == last.LastID && e.Hash == last.LastHash {
+			return lines[i+1:], nil
+		}
+	}
+	return lines, nil
+}
+
+func parseEventLines(lines [][]byte) ([]*events.BaseEvent, error) {
+	parsed := make([]*events.BaseEvent, 0, len(lines))
+	for _, line := range lines {
+		var e events.BaseEvent
+		if err := json.Unmarshal(line, &e); err != nil {
+			return nil, fmt.Errorf("unmarshal event: %w", err)
+		}
+		parsed = append(parsed, &e)
+	}
+	return parsed, nil
+}
+
+func gunzipLines(data []byte) ([][]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decompress: %w", err)
+	}
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("decompress: %w", err)
+	}
+	return splitLines(raw), nil
+}
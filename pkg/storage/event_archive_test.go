@@ -0,0 +1,351 @@
+package storage
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+	"github.com/felixgeelhaar/roady/pkg/domain/team"
+)
+
+// archiveKey signs the segments of test archives as alice.
+var archiveKey = ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+
+func archiveSigner(t *testing.T) *events.Signer {
+	t.Helper()
+	signer, err := events.NewSigner("alice", archiveKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+// newArchiveTestRepo returns a files repository with n chained events,
+// one hour apart, starting at base.
+func newArchiveTestRepo(t *testing.T, n int, base time.Time) (*FilesystemRepository, *FileEventStore) {
+	t.Helper()
+	repo := NewFilesystemRepository(t.TempDir())
+	if err := repo.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &team.TeamConfig{Members: []team.Member{{
+		Name: "alice", Role: team.RoleAdmin, PublicKey: team.EncodePublicKey(archiveKey.Public().(ed25519.PublicKey)),
+	}}}
+	if err := repo.SaveTeam(cfg); err != nil {
+		t.Fatal(err)
+	}
+	store, _ := NewFileEventStore(repo.ProjectBase())
+	for i := range n {
+		err := store.Append(&events.BaseEvent{
+			Type:      events.EventTypeTaskStarted,
+			Timestamp: base.Add(time.Duration(i) * time.Hour),
+			Actor:     "alice",
+			Metadata:  map[string]interface{}{"task_id": "t1"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return repo, store
+}
+
+func TestCompactEvents(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo, store := newArchiveTestRepo(t, 10, base)
+	before, _ := store.LoadAll()
+
+	result, err := repo.CompactEvents(CompactOptions{Signer: archiveSigner(t), Keep: 4})
+	if err != nil {
+		t.Fatalf("CompactEvents: %v", err)
+	}
+	if result.Archived != 6 || result.Remaining != 4 || result.Segments != 1 || result.Segment != "events-000001.jsonl.gz" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	active, _ := readLines(filepath.Join(repo.ProjectBase(), EventsFile))
+	if len(active) != 4 {
+		t.Errorf("expected 4 events left in events.jsonl, got %d", len(active))
+	}
+
+	// The second segment takes the events before a date.
+	result, err = repo.CompactEvents(CompactOptions{Signer: archiveSigner(t), Before: base.Add(8 * time.Hour)})
+	if err != nil || result.Archived != 2 || result.Segments != 2 {
+		t.Fatalf("CompactEvents(Before) = %+v, %v", result, err)
+	}
+	// The newest event always stays.
+	result, err = repo.CompactEvents(CompactOptions{Signer: archiveSigner(t)})
+	if err != nil || result.Archived != 1 || result.Remaining != 1 {
+		t.Fatalf("CompactEvents(Keep 0) = %+v, %v", result, err)
+	}
+	if result, _ := repo.CompactEvents(CompactOptions{Signer: archiveSigner(t)}); result.Archived != 0 {
+		t.Errorf("expected nothing left to compact, got %+v", result)
+	}
+
+	after, err := store.LoadAll()
+	if err != nil || len(after) != len(before) {
+		t.Fatalf("LoadAll after compaction returned %d events, %v", len(after), err)
+	}
+	for i := range before {
+		if after[i].Hash != before[i].Hash {
+			t.Errorf("event %d changed", i)
+		}
+	}
+	if domainEvents, _ := repo.LoadEvents(); len(domainEvents) != len(before) {
+		t.Errorf("LoadEvents returned %d events", len(domainEvents))
+	}
+
+	// Appends keep chaining to the log.
+	if err := store.Append(&events.BaseEvent{Type: events.EventTypeTaskCompleted, Metadata: map[string]interface{}{"task_id": "t1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if violations, err := store.VerifyIntegrity(); err != nil || len(violations) != 0 {
+		t.Errorf("VerifyIntegrity = %v, %v", violations, err)
+	}
+	if after, _ := store.LoadAfter(before[2].ID); len(after) != 8 {
+		t.Errorf("LoadAfter an archived event returned %d events", len(after))
+	}
+	if _, err := store.LoadAfter("unknown"); !errors.Is(err, events.ErrEventNotFound) {
+		t.Errorf("expected ErrEventNotFound, got %v", err)
+	}
+}
+
+func TestVerifyEventArchive_DetectsTampering(t *testing.T) {
+	tamper := map[string]func(t *testing.T, dir string){
+		"segment replaced": func(t *testing.T, dir string) {
+			if err := os.WriteFile(filepath.Join(dir, "events-000001.jsonl.gz"), []byte("junk"), 0600); err != nil {
+				t.Fatal(err)
+			}
+		},
+		"manifest edited": func(t *testing.T, dir string) {
+			archive, _ := loadEventArchive(filepath.Dir(dir))
+			archive.Segments[0].Events = 99
+			if err := saveEventArchive(filepath.Dir(dir), archive); err != nil {
+				t.Fatal(err)
+			}
+		},
+		"segment dropped": func(t *testing.T, dir string) {
+			archive, _ := loadEventArchive(filepath.Dir(dir))
+			archive.Segments = archive.Segments[1:]
+			if err := saveEventArchive(filepath.Dir(dir), archive); err != nil {
+				t.Fatal(err)
+			}
+		},
+		"manifest re-anchored": func(t *testing.T, dir string) {
+			archive, _ := loadEventArchive(filepath.Dir(dir))
+			archive.Segments[1].Events = 1
+			archive.Segments[1].Anchor = archive.Segments[1].anchor(archive.Segments[0].Anchor)
+			if err := saveEventArchive(filepath.Dir(dir), archive); err != nil {
+				t.Fatal(err)
+			}
+		},
+		"signed by an unknown key": func(t *testing.T, dir string) {
+			_, other, _ := ed25519.GenerateKey(nil)
+			forger, _ := events.NewSigner("alice", other)
+			archive, _ := loadEventArchive(filepath.Dir(dir))
+			archive.Segments[0].Signature = forger.SignAnchor(archive.Segments[0].Anchor)
+			if err := saveEventArchive(filepath.Dir(dir), archive); err != nil {
+				t.Fatal(err)
+			}
+		},
+		"signature removed": func(t *testing.T, dir string) {
+			archive, _ := loadEventArchive(filepath.Dir(dir))
+			archive.Segments[0].Signer, archive.Segments[0].Signature = "", ""
+			if err := saveEventArchive(filepath.Dir(dir), archive); err != nil {
+				t.Fatal(err)
+			}
+		},
+	}
+
+	for name, fn := range tamper {
+		t.Run(name, func(t *testing.T) {
+			repo, store := newArchiveTestRepo(t, 6, time.Now().Add(-time.Hour))
+			for _, keep := range []int{4, 2} {
+				if _, err := repo.CompactEvents(CompactOptions{Signer: archiveSigner(t), Keep: keep}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if violations, _ := repo.VerifyEventArchive(); len(violations) != 0 {
+				t.Fatalf("expected a clean archive, got %v", violations)
+			}
+
+			fn(t, filepath.Join(repo.ProjectBase(), EventArchiveDir))
+			violations, err := store.VerifyIntegrity()
+			if err != nil && name != "segment replaced" {
+				t.Fatal(err)
+			}
+			if err == nil && len(violations) == 0 {
+				t.Error("expected tampering to be reported")
+			}
+		})
+	}
+}
+
+func TestCompactEvents_RequiresSigner(t *testing.T) {
+	repo, _ := newArchiveTestRepo(t, 4, time.Now().Add(-time.Hour))
+	if _, err := repo.CompactEvents(CompactOptions{Keep: 1}); err == nil {
+		t.Fatal("expected compaction without a signer to fail")
+	}
+	if _, err := os.Stat(filepath.Join(repo.ProjectBase(), EventArchiveDir)); !os.IsNotExist(err) {
+		t.Error("expected no archive to be written")
+	}
+	if result, err := repo.CompactEvents(CompactOptions{Keep: 10}); err != nil || result.Archived != 0 {
+		t.Errorf("nothing to compact should not need a signer: %+v, %v", result, err)
+	}
+}
+
+func TestReadEventLog_InterruptedCompaction(t *testing.T) {
+	repo, store := newArchiveTestRepo(t, 5, time.Now().Add(-time.Hour))
+	full, _ := os.ReadFile(filepath.Join(repo.ProjectBase(), EventsFile))
+	if _, err := repo.CompactEvents(CompactOptions{Signer: archiveSigner(t), Keep: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The segment and manifest were written but events.jsonl was not.
+	if err := os.WriteFile(filepath.Join(repo.ProjectBase(), EventsFile), full, 0600); err != nil {
+		t.Fatal(err)
+	}
+	evts, err := store.LoadAll()
+	if err != nil || len(evts) != 5 {
+		t.Fatalf("LoadAll = %d events, %v", len(evts), err)
+	}
+	if violations, _ := store.VerifyIntegrity(); len(violations) != 0 {
+		t.Errorf("VerifyIntegrity = %v", violations)
+	}
+
+	// Compacting again finishes the job.
+	if result, err := repo.CompactEvents(CompactOptions{Signer: archiveSigner(t), Keep: 2}); err != nil || result.Archived != 0 {
+		t.Fatalf("CompactEvents = %+v, %v", result, err)
+	}
+}
+
+func TestMigrate_IncludesArchivedEvents(t *testing.T) {
+	repo, _ := newArchiveTestRepo(t, 5, time.Now().Add(-time.Hour))
+	if _, err := repo.CompactEvents(CompactOptions{Signer: archiveSigner(t), Keep: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Migrate(repo.Root(), "", BackendSQLite)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	t.Cleanup(func() { _ = NewSQLiteRepository(repo).Close() })
+	if result.Events != 5 {
+		t.Errorf("expected archived events to migrate, got %+v", result)
+	}
+	if _, err := os.Stat(filepath.Join(repo.ProjectBase(), EventArchiveDir)); !os.IsNotExist(err) {
+		t.Error("expected the archive to be removed")
+	}
+
+	sqlite := NewSQLiteRepository(repo)
+	if _, err := sqlite.CompactEvents(CompactOptions{Signer: archiveSigner(t)}); err == nil || !strings.Contains(err.Error(), BackendFiles) {
+		t.Errorf("expected compaction to be refused, got %v", err)
+	}
+	store, _ := sqlite.EventStore()
+	if violations, err := store.(*SQLiteEventStore).VerifyIntegrity(); err != nil || len(violations) != 0 {
+		t.Errorf("VerifyIntegrity = %v, %v", violations, err)
+	}
+}
+
+func TestProjectionStores(t *testing.T) {
+	repo := newTestSQLiteRepository(t)
+	stores := map[string]events.ProjectionStore{
+		"files":  repo.FilesystemRepository.ProjectionStore(),
+		"sqlite": repo.ProjectionStore(),
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			if id, state, err := store.LoadSnapshot("velocity"); id != "" || state != nil || err != nil {
+				t.Fatalf("expected no snapshot, got %q, %s, %v", id, state, err)
+			}
+			if err := store.SaveSnapshot("velocity", "e1", []byte(`{"completions":[]}`)); err != nil {
+				t.Fatal(err)
+			}
+			id, state, err := store.LoadSnapshot("velocity")
+			if err != nil || id != "e1" || !json.Valid(state) {
+				t.Fatalf("LoadSnapshot = %q, %s, %v", id, state, err)
+			}
+			if err := store.SaveCheckpoint("velocity", "e2"); err != nil {
+				t.Fatal(err)
+			}
+			if id, err := store.LoadCheckpoint("velocity"); err != nil || id != "e2" {
+				t.Errorf("LoadCheckpoint = %q, %v", id, err)
+			}
+		})
+	}
+
+	if err := stores["files"].SaveCheckpoint("../escape", "e1"); err == nil {
+		t.Error("expected an invalid projection name to be rejected")
+	}
+}
+
+func TestSQLiteEventStore_LoadAfter(t *testing.T) {
+	repo := newTestSQLiteRepository(t)
+	store, _ := NewSQLiteEventStore(repo.ProjectBase())
+	for range 3 {
+		if err := store.Append(&events.BaseEvent{Type: events.EventTypeTaskStarted}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	all, _ := store.LoadAll()
+	if after, err := store.LoadAfter(all[0].ID); err != nil || len(after) != 2 || after[0].ID != all[1].ID {
+		t.Errorf("LoadAfter = %+v, %v", after, err)
+	}
+	if after, _ := store.LoadAfter(""); len(after) != 3 {
+		t.Errorf("LoadAfter(\"\") returned %d events", len(after))
+	}
+	if _, err := store.LoadAfter("unknown"); !errors.Is(err, events.ErrEventNotFound) {
+		t.Errorf("expected ErrEventNotFound, got %v", err)
+	}
+}
+
+func TestEventStores_Query(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newStore := func() (*FilesystemRepository, *FileEventStore) {
+		repo, store := newArchiveTestRepo(t, 6, base)
+		if err := store.Append(&events.BaseEvent{
+			Type: events.EventTypeTaskCompleted, Actor: "ai:claude", Timestamp: base.Add(10 * time.Hour),
+			AggregateType_: events.AggregateTypeTask, AggregateID_: "t2", Metadata: map[string]interface{}{"task_id": "t2"},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return repo, store
+	}
+
+	// The files store reads the older events from the archive.
+	archived, fileStore := newStore()
+	if _, err := archived.CompactEvents(CompactOptions{Signer: archiveSigner(t), Keep: 2}); err != nil {
+		t.Fatal(err)
+	}
+	migrated, _ := newStore()
+	if _, err := Migrate(migrated.Root(), "", BackendSQLite); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = NewSQLiteRepository(migrated).Close() })
+	sqliteStore, _ := NewSQLiteEventStore(migrated.ProjectBase())
+
+	since, until := base.Add(2*time.Hour), base.Add(10*time.Hour)
+	stores := map[string]events.Querier{"files": fileStore, "sqlite": sqliteStore}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			got, err := store.Query(events.EventQuery{EventTypes: []string{"task.*"}, Since: &since, Until: &until, Offset: 1, Limit: 2})
+			if err != nil || len(got) != 2 || !got[0].Timestamp.Equal(base.Add(3*time.Hour)) {
+				t.Errorf("Query(window) = %+v, %v", got, err)
+			}
+			got, err = store.Query(events.EventQuery{AggregateType: events.AggregateTypeTask, AggregateID: "t2", Actor: "ai*"})
+			if err != nil || len(got) != 1 || got[0].Type != events.EventTypeTaskCompleted {
+				t.Errorf("Query(aggregate) = %+v, %v", got, err)
+			}
+			if got, _ := store.Query(events.EventQuery{Metadata: map[string]string{"task_id": "t1"}}); len(got) != 6 {
+				t.Errorf("Query(metadata) returned %d events", len(got))
+			}
+			if _, err := store.Query(events.EventQuery{EventTypes: []string{"["}}); err == nil {
+				t.Error("expected a malformed pattern to be rejected")
+			}
+		})
+	}
+}
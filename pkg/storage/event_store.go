@@ -1,9 +1,10 @@
 package storage
 
 import (
-	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sync"
@@ -19,6 +20,7 @@ type FileEventStore struct {
 	path     string
 	basePath string
 	lastHash string
+	signer   *events.Signer
 }
 
 // NewFileEventStore creates a new file-based event store.
@@ -38,7 +40,7 @@ func NewFileEventStore(basePath string) (*FileEventStore, error) {
 }
 
 // Append adds a new event to the store.
-func (s *FileEventStore) Append(event *events.BaseEvent) (err error) {
+func (s *FileEventStore) Append(event *events.BaseEvent) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -60,33 +62,52 @@ func (s *FileEventStore) Append(event *events.BaseEvent) (err error) {
 	// Ensure Action mirrors Type for backward compatibility
 	event.EnsureAction()
 
-	// Chain to previous event
-	event.PrevHash = s.lastHash
-	event.Hash = event.CalculateHash()
+	// Other processes may have appended since this store was opened, so the
+	// chain is continued from the file's last event under the project lock.
+	return withDirLock(s.basePath, func() (err error) {
+		if last, lerr := s.lastEvent(); lerr == nil && last != nil {
+			s.lastHash = last.Hash
+		}
 
-	// Open file in append mode with restricted permissions
-	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
-	if err != nil {
-		return fmt.Errorf("open events file: %w", err)
-	}
-	defer func() {
-		if cerr := f.Close(); cerr != nil && err == nil {
-			err = fmt.Errorf("close events file: %w", cerr)
+		// Chain to previous event
+		event.PrevHash = s.lastHash
+		event.Hash = event.CalculateHash()
+		if s.signer != nil {
+			s.signer.SignEvent(event)
 		}
-	}()
 
-	// Write JSON line
-	data, err := json.Marshal(event)
-	if err != nil {
-		return fmt.Errorf("marshal event: %w", err)
-	}
+		// Open file in append mode with restricted permissions
+		f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return fmt.Errorf("open events file: %w", err)
+		}
+		defer func() {
+			if cerr := f.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("close events file: %w", cerr)
+			}
+		}()
+
+		// Write JSON line
+		data, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("marshal event: %w", err)
+		}
 
-	if _, err := f.Write(append(data, '\n')); err != nil {
-		return fmt.Errorf("write event: %w", err)
-	}
+		if _, err := f.Write(append(data, '\n')); err != nil {
+			return fmt.Errorf("write event: %w", err)
+		}
 
-	s.lastHash = event.Hash
-	return nil
+		s.lastHash = event.Hash
+		return nil
+	})
+}
+
+// SetSigner makes Append sign every event it writes. Pass nil to stop
+// signing.
+func (s *FileEventStore) SetSigner(signer *events.Signer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.signer = signer
 }
 
 // LoadAll returns all events in chronological order.
@@ -162,6 +183,64 @@ func (s *FileEventStore) LoadRange(from, to time.Time) ([]*events.BaseEvent, err
 	return result, nil
 }
 
+// Query returns the events that match q, archived segments included.
+func (s *FileEventStore) Query(q events.EventQuery) ([]*events.BaseEvent, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	all, err := s.LoadAll()
+	if err != nil {
+		return nil, err
+	}
+	return q.Filter(all), nil
+}
+
+// LoadAfter returns the events appended after the event with the given ID.
+// Checkpoints in the active log are resolved without reading the archive.
+func (s *FileEventStore) LoadAfter(eventID string) ([]*events.BaseEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if eventID == "" {
+		return s.loadEvents()
+	}
+	archive, err := loadEventArchive(s.basePath)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := readActiveEventLines(s.basePath, archive)
+	if err != nil {
+		return nil, err
+	}
+	active, err := parseEventLines(lines)
+	if err != nil {
+		return nil, err
+	}
+	if after, ok := eventsAfter(active, eventID); ok {
+		return after, nil
+	}
+	if len(archive.Segments) > 0 {
+		all, err := s.loadEvents()
+		if err != nil {
+			return nil, err
+		}
+		if after, ok := eventsAfter(all, eventID); ok {
+			return after, nil
+		}
+	}
+	return nil, events.ErrEventNotFound
+}
+
+// eventsAfter returns the events following the one with the given ID.
+func eventsAfter(evts []*events.BaseEvent, eventID string) ([]*events.BaseEvent, bool) {
+	for i := len(evts) - 1; i >= 0; i-- {
+		if evts[i].ID == eventID {
+			return evts[i+1:], true
+		}
+	}
+	return nil, false
+}
+
 // GetLastEvent returns the most recent event.
 func (s *FileEventStore) GetLastEvent() (*events.BaseEvent, error) {
 	s.mu.RLock()
@@ -188,13 +267,28 @@ func (s *FileEventStore) Count() (int, error) {
 	return len(evts), nil
 }
 
-// VerifyIntegrity checks the hash chain for tampering.
+// VerifyIntegrity checks the hash chain for tampering, across archived
+// segments and the active log, and the anchors of the archived segments.
 func (s *FileEventStore) VerifyIntegrity() ([]string, error) {
 	evts, err := s.LoadAll()
 	if err != nil {
 		return nil, err
 	}
+	violations, err := s.VerifyEventArchive()
+	if err != nil {
+		return nil, err
+	}
+	return append(violations, verifyChain(evts)...), nil
+}
 
+// VerifyEventArchive checks the archived segments against their anchors.
+func (s *FileEventStore) VerifyEventArchive() ([]string, error) {
+	return verifyEventArchive(s.basePath)
+}
+
+// verifyChain checks that each event links to its predecessor and that its
+// hash matches its contents.
+func verifyChain(evts []*events.BaseEvent) []string {
 	var violations []string
 	lastHash := ""
 
@@ -213,11 +307,24 @@ func (s *FileEventStore) VerifyIntegrity() ([]string, error) {
 		lastHash = e.Hash
 	}
 
-	return violations, nil
+	return violations
 }
 
-// loadEvents reads all events from the file.
+// loadEvents reads all events, archived segments first.
 func (s *FileEventStore) loadEvents() ([]*events.BaseEvent, error) {
+	lines, err := readEventLog(s.basePath)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+	return parseEventLines(lines)
+}
+
+// lastEvent reads the final event from the end of the file without loading
+// the whole log. The caller holds s.mu.
+func (s *FileEventStore) lastEvent() (*events.BaseEvent, error) {
 	f, err := os.Open(s.path)
 	if os.IsNotExist(err) {
 		return nil, nil
@@ -227,33 +334,37 @@ func (s *FileEventStore) loadEvents() ([]*events.BaseEvent, error) {
 	}
 	defer f.Close() //nolint:errcheck // read-only file
 
-	var result []*events.BaseEvent
-	scanner := bufio.NewScanner(f)
-
-	// Increase buffer size for large events
-	buf := make([]byte, 0, 64*1024)
-	scanner.Buffer(buf, 1024*1024)
-
-	for scanner.Scan() {
-		line := scanner.Bytes()
-		if len(line) == 0 {
-			continue
-		}
-
-		var event events.BaseEvent
-		if err := json.Unmarshal(line, &event); err != nil {
-			return nil, fmt.Errorf("unmarshal event: %w", err)
-		}
-		result = append(result, &event)
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat events file: %w", err)
 	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("scan events: %w", err)
+	// Events are bounded by the scanner buffer in loadEvents.
+	const maxLine = 1024 * 1024
+	offset := max(info.Size()-maxLine, 0)
+	tail := make([]byte, info.Size()-offset)
+	if _, err := f.ReadAt(tail, offset); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("read events file: %w", err)
 	}
 
-	return result, nil
+	tail = bytes.TrimRight(tail, "\n")
+	if len(tail) == 0 {
+		return nil, nil
+	}
+	if i := bytes.LastIndexByte(tail, '\n'); i >= 0 {
+		tail = tail[i+1:]
+	}
+	var event events.BaseEvent
+	if err := json.Unmarshal(tail, &event); err != nil {
+		return nil, fmt.Errorf("unmarshal event: %w", err)
+	}
+	return &event, nil
 }
 
+var (
+	_ events.EventStore = (*FileEventStore)(nil)
+	_ events.Querier    = (*FileEventStore)(nil)
+)
+
 // InMemoryEventPublisher is a simple in-process event publisher.
 type InMemoryEventPublisher struct {
 	mu       sync.RWMutex
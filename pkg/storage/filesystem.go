@@ -23,6 +23,8 @@ const ProjectsDir = "projects"
 const SpecFile = "spec.yaml"
 const SpecLockFile = "spec.lock.json"
 const PlanFile = "plan.json"
+const PlanHistoryDir = "plans"
+const PlanHistoryFile = "history.jsonl"
 const PolicyFile = "policy.yaml"
 const StateFile = "state.json"
 const EventsFile = "events.jsonl"
@@ -164,7 +166,7 @@ func (r *FilesystemRepository) SaveSpec(s *spec.ProductSpec) error {
 	}
 
 	// G306: Use 0600 for files
-	return os.WriteFile(path, data, 0600)
+	return WriteFileAtomic(path, data, 0600)
 }
 
 func (r *FilesystemRepository) LoadSpec() (*spec.ProductSpec, error) {
@@ -202,7 +204,7 @@ func (r *FilesystemRepository) SaveSpecLock(s *spec.ProductSpec) error {
 		return fmt.Errorf("failed to marshal spec lock: %w", err)
 	}
 
-	return os.WriteFile(path, data, 0600)
+	return WriteFileAtomic(path, data, 0600)
 }
 
 func (r *FilesystemRepository) LoadSpecLock() (*spec.ProductSpec, error) {
@@ -236,7 +238,7 @@ func (r *FilesystemRepository) UpdateUsage(stats domain.UsageStats) error {
 		return fmt.Errorf("failed to marshal usage stats: %w", err)
 	}
 
-	return os.WriteFile(path, data, 0600)
+	return WriteFileAtomic(path, data, 0600)
 }
 
 func (r *FilesystemRepository) LoadUsage() (*domain.UsageStats, error) {
@@ -271,7 +273,7 @@ func (r *FilesystemRepository) SaveWebhookConfig(config *events.WebhookConfig) e
 		return fmt.Errorf("failed to marshal webhook config: %w", err)
 	}
 
-	return os.WriteFile(path, data, 0600)
+	return WriteFileAtomic(path, data, 0600)
 }
 
 // LoadWebhookConfig loads the webhook configuration from .roady/webhooks.yaml.
@@ -307,7 +309,7 @@ func (r *FilesystemRepository) SaveRates(config *billing.RateConfig) error {
 		return fmt.Errorf("failed to marshal rates: %w", err)
 	}
 
-	return os.WriteFile(path, data, 0600)
+	return WriteFileAtomic(path, data, 0600)
 }
 
 // LoadRates loads the rate configuration from .roady/rates.yaml.
@@ -346,7 +348,7 @@ func (r *FilesystemRepository) SaveTimeEntries(entries []billing.TimeEntry) erro
 		return fmt.Errorf("failed to marshal time entries: %w", err)
 	}
 
-	return os.WriteFile(path, data, 0600)
+	return WriteFileAtomic(path, data, 0600)
 }
 
 // LoadTimeEntries loads time entries from .roady/time_entries.yaml.
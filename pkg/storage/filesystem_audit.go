@@ -1,7 +1,6 @@
 package storage
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -9,7 +8,7 @@ import (
 	"github.com/felixgeelhaar/roady/pkg/domain"
 )
 
-func (r *FilesystemRepository) RecordEvent(event domain.Event) (err error) {
+func (r *FilesystemRepository) RecordEvent(event domain.Event) error {
 	path, err := r.ResolvePath(EventsFile)
 	if err != nil {
 		return err
@@ -22,45 +21,39 @@ func (r *FilesystemRepository) RecordEvent(event domain.Event) (err error) {
 
 	data = append(data, '\n')
 
-	// #nosec G304 -- Path is resolved and validated via resolvePath
-	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
-	if err != nil {
-		return fmt.Errorf("failed to open events file: %w", err)
-	}
-	defer func() {
-		if cerr := f.Close(); cerr != nil && err == nil {
-			err = fmt.Errorf("failed to close events file: %w", cerr)
+	return r.WithLock(func() (err error) {
+		// #nosec G304 -- Path is resolved and validated via resolvePath
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return fmt.Errorf("failed to open events file: %w", err)
 		}
-	}()
+		defer func() {
+			if cerr := f.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("failed to close events file: %w", cerr)
+			}
+		}()
 
-	if _, err := f.Write(data); err != nil {
-		return fmt.Errorf("failed to write event: %w", err)
-	}
-
-	return nil
+		if _, err := f.Write(data); err != nil {
+			return fmt.Errorf("failed to write event: %w", err)
+		}
+		return nil
+	})
 }
 
+// LoadEvents returns the audit log, including events archived by
+// CompactEvents.
 func (r *FilesystemRepository) LoadEvents() ([]domain.Event, error) {
-	path, err := r.ResolvePath(EventsFile)
-	if err != nil {
+	if _, err := r.ResolvePath(EventsFile); err != nil {
 		return nil, err
 	}
 
-	// #nosec G304 -- Path is resolved and validated via resolvePath
-	data, err := os.ReadFile(path)
+	lines, err := readEventLog(r.ProjectBase())
 	if err != nil {
-		if os.IsNotExist(err) {
-			return []domain.Event{}, nil
-		}
 		return nil, fmt.Errorf("failed to read events file: %w", err)
 	}
 
-	var events []domain.Event
-	lines := bytes.Split(data, []byte("\n"))
+	events := []domain.Event{}
 	for _, line := range lines {
-		if len(bytes.TrimSpace(line)) == 0 {
-			continue
-		}
 		var e domain.Event
 		if err := json.Unmarshal(line, &e); err != nil {
 			continue // Skip malformed lines
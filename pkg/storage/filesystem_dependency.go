@@ -23,7 +23,7 @@ func (r *FilesystemRepository) SaveDependencyGraph(graph *dependency.DependencyG
 		return fmt.Errorf("failed to marshal dependency graph: %w", err)
 	}
 
-	return os.WriteFile(path, data, 0600)
+	return WriteFileAtomic(path, data, 0600)
 }
 
 // LoadDependencyGraph loads the dependency graph from storage.
@@ -22,7 +22,7 @@ func (r *FilesystemRepository) SaveMessagingConfig(config *messaging.MessagingCo
 		return fmt.Errorf("failed to marshal messaging config: %w", err)
 	}
 
-	return os.WriteFile(path, data, 0600)
+	return WriteFileAtomic(path, data, 0600)
 }
 
 // LoadMessagingConfig loads the messaging configuration.
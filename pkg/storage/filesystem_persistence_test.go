@@ -414,6 +414,33 @@ func TestSetAndRemovePluginConfig(t *testing.T) {
 	}
 }
 
+func TestSaveAndLoadSyncBaseline(t *testing.T) {
+	d := t.TempDir()
+	r := NewFilesystemRepository(d)
+	_ = r.Initialize()
+
+	if b, err := r.LoadSyncBaseline("jira"); err != nil || b != nil {
+		t.Fatalf("expected no baseline, got %+v, %v", b, err)
+	}
+
+	b := plugin.NewSyncBaseline()
+	b.Tasks["t1"] = plugin.FieldSnapshot{Title: "Login", Priority: planning.PriorityHigh, Comments: []string{"c1"}}
+	if err := r.SaveSyncBaseline("jira", b); err != nil {
+		t.Fatalf("SaveSyncBaseline: %v", err)
+	}
+	if err := r.SaveSyncBaseline("linear", plugin.NewSyncBaseline()); err != nil {
+		t.Fatalf("SaveSyncBaseline: %v", err)
+	}
+
+	loaded, err := r.LoadSyncBaseline("jira")
+	if err != nil {
+		t.Fatalf("LoadSyncBaseline: %v", err)
+	}
+	if got := loaded.Tasks["t1"]; got.Title != "Login" || got.Priority != planning.PriorityHigh || len(got.Comments) != 1 {
+		t.Errorf("baseline = %+v", got)
+	}
+}
+
 func TestLoadPluginConfigs_InvalidYAML(t *testing.T) {
 	d := t.TempDir()
 	r := NewFilesystemRepository(d)
@@ -1,13 +1,19 @@
 package storage
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
 
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 )
 
+// revisionHashPattern matches a full Plan.Hash, which names revision files.
+var revisionHashPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
 func (r *FilesystemRepository) SavePlan(p *planning.Plan) error {
 	path, err := r.ResolvePath(PlanFile)
 	if err != nil {
@@ -19,7 +25,26 @@ func (r *FilesystemRepository) SavePlan(p *planning.Plan) error {
 		return fmt.Errorf("failed to marshal plan: %w", err)
 	}
 
-	return os.WriteFile(path, data, 0600)
+	return WriteFileAtomic(path, data, 0600)
+}
+
+// UpdatePlan loads the plan, passes it to fn and saves it, all under the
+// project lock, so edits from other processes in between are not lost.
+// Nothing is saved when fn fails.
+func (r *FilesystemRepository) UpdatePlan(fn func(p *planning.Plan) error) error {
+	return r.WithLock(func() error {
+		p, err := r.LoadPlan()
+		if err != nil {
+			return err
+		}
+		if p == nil {
+			return fmt.Errorf("no plan found")
+		}
+		if err := fn(p); err != nil {
+			return err
+		}
+		return r.SavePlan(p)
+	})
 }
 
 func (r *FilesystemRepository) LoadPlan() (*planning.Plan, error) {
@@ -48,3 +73,121 @@ func (r *FilesystemRepository) LoadPlan() (*planning.Plan, error) {
 
 	return &p, nil
 }
+
+// planHistoryPath returns a path inside the plan history directory
+// (<project>/plans/), creating the directory when create is set.
+func (r *FilesystemRepository) planHistoryPath(name string, create bool) (string, error) {
+	dir := filepath.Join(r.ProjectBase(), PlanHistoryDir)
+	if create {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return "", fmt.Errorf("failed to create plan history directory: %w", err)
+		}
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// SavePlanRevision stores the plan under its hash, unless already stored,
+// and appends rev to the plan history.
+func (r *FilesystemRepository) SavePlanRevision(p *planning.Plan, rev planning.PlanRevision) error {
+	if !revisionHashPattern.MatchString(rev.Hash) {
+		return fmt.Errorf("invalid plan revision hash: %q", rev.Hash)
+	}
+	planPath, err := r.planHistoryPath(rev.Hash+".json", true)
+	if err != nil {
+		return err
+	}
+	if _, statErr := os.Stat(planPath); os.IsNotExist(statErr) {
+		data, err := json.MarshalIndent(p, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal plan revision: %w", err)
+		}
+		if err := WriteFileAtomic(planPath, data, 0600); err != nil {
+			return fmt.Errorf("failed to write plan revision: %w", err)
+		}
+	}
+
+	historyPath, err := r.planHistoryPath(PlanHistoryFile, false)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(rev)
+	if err != nil {
+		return fmt.Errorf("failed to marshal plan revision: %w", err)
+	}
+	data = append(data, '\n')
+
+	return r.WithLock(func() (err error) {
+		// #nosec G304 -- Path is built from the project base and a constant name
+		f, err := os.OpenFile(historyPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return fmt.Errorf("failed to open plan history: %w", err)
+		}
+		defer func() {
+			if cerr := f.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("failed to close plan history: %w", cerr)
+			}
+		}()
+		if _, err := f.Write(data); err != nil {
+			return fmt.Errorf("failed to write plan history: %w", err)
+		}
+		return nil
+	})
+}
+
+// LoadPlanRevision loads the plan stored under the given full hash.
+func (r *FilesystemRepository) LoadPlanRevision(hash string) (*planning.Plan, error) {
+	if !revisionHashPattern.MatchString(hash) {
+		return nil, fmt.Errorf("invalid plan revision hash: %q", hash)
+	}
+	path, err := r.planHistoryPath(hash+".json", false)
+	if err != nil {
+		return nil, err
+	}
+
+	// #nosec G304 -- hash is validated above
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("plan revision %s not found", planning.ShortRevision(hash))
+		}
+		return nil, fmt.Errorf("failed to read plan revision: %w", err)
+	}
+
+	var p planning.Plan
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal plan revision: %w", err)
+	}
+	return &p, nil
+}
+
+// ListPlanRevisions returns the plan history, oldest approval first.
+func (r *FilesystemRepository) ListPlanRevisions() ([]planning.PlanRevision, error) {
+	path, err := r.planHistoryPath(PlanHistoryFile, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// #nosec G304 -- Path is built from the project base and a constant name
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []planning.PlanRevision{}, nil
+		}
+		return nil, fmt.Errorf("failed to read plan history: %w", err)
+	}
+
+	revisions := make([]planning.PlanRevision, 0)
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var rev planning.PlanRevision
+		if err := json.Unmarshal(line, &rev); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal plan history: %w", err)
+		}
+		revisions = append(revisions, rev)
+	}
+	return revisions, nil
+}
+
+var _ planning.RevisionRepository = (*FilesystemRepository)(nil)
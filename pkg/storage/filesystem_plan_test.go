@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+)
+
+func TestPlanRevisions_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	repo := NewFilesystemRepository(dir)
+	if err := repo.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+
+	revisions, err := repo.ListPlanRevisions()
+	if err != nil || len(revisions) != 0 {
+		t.Fatalf("expected empty history, got %v, %v", revisions, err)
+	}
+
+	plan := &planning.Plan{ID: "p1", Tasks: []planning.Task{{ID: "t1", Title: "Task"}}}
+	rev := planning.NewPlanRevision(plan, "alice")
+	if err := repo.SavePlanRevision(plan, rev); err != nil {
+		t.Fatalf("SavePlanRevision: %v", err)
+	}
+	again := rev
+	again.ApprovedBy = "bob"
+	if err := repo.SavePlanRevision(plan, again); err != nil {
+		t.Fatalf("SavePlanRevision: %v", err)
+	}
+
+	revisions, err = repo.ListPlanRevisions()
+	if err != nil || len(revisions) != 2 || revisions[0].ApprovedBy != "alice" || revisions[1].ApprovedBy != "bob" {
+		t.Fatalf("unexpected history: %+v, %v", revisions, err)
+	}
+	entries, _ := os.ReadDir(filepath.Join(repo.ProjectBase(), PlanHistoryDir))
+	if len(entries) != 2 {
+		t.Errorf("expected one stored plan and the history file, got %d entries", len(entries))
+	}
+
+	loaded, err := repo.LoadPlanRevision(rev.Hash)
+	if err != nil || loaded.RevisionHash() != rev.Hash {
+		t.Fatalf("LoadPlanRevision: %+v, %v", loaded, err)
+	}
+	if _, err := repo.LoadPlanRevision("../plan"); err == nil {
+		t.Error("expected an invalid hash to be rejected")
+	}
+	if _, err := repo.LoadPlanRevision(planning.NewPlanRevision(&planning.Plan{ID: "p2"}, "").Hash); err == nil {
+		t.Error("expected an unknown revision to fail")
+	}
+	if err := repo.SavePlanRevision(plan, planning.PlanRevision{Hash: "short"}); err == nil {
+		t.Error("expected an invalid hash to be rejected on save")
+	}
+}
+
+func TestUpdatePlan(t *testing.T) {
+	files := NewFilesystemRepository(t.TempDir())
+	if err := files.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	repos := map[string]Repository{"files": files, "sqlite": newTestSQLiteRepository(t)}
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			addTask := func(p *planning.Plan) error {
+				p.Tasks = append(p.Tasks, planning.Task{ID: "t2", Title: "Two"})
+				return nil
+			}
+			if err := repo.UpdatePlan(addTask); err == nil {
+				t.Error("expected an error without a plan")
+			}
+			if err := repo.SavePlan(&planning.Plan{ID: "p1", Tasks: []planning.Task{{ID: "t1", Title: "One"}}}); err != nil {
+				t.Fatal(err)
+			}
+			if err := repo.UpdatePlan(addTask); err != nil {
+				t.Fatalf("UpdatePlan: %v", err)
+			}
+			failing := func(p *planning.Plan) error {
+				p.Tasks = nil
+				return os.ErrInvalid
+			}
+			if err := repo.UpdatePlan(failing); err != os.ErrInvalid {
+				t.Errorf("UpdatePlan = %v, want fn's error", err)
+			}
+			plan, err := repo.LoadPlan()
+			if err != nil || len(plan.Tasks) != 2 {
+				t.Errorf("plan = %+v, %v; want the added task and not the failed change", plan, err)
+			}
+		})
+	}
+}
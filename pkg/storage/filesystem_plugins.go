@@ -22,7 +22,7 @@ func (r *FilesystemRepository) SavePluginConfigs(configs *plugin.PluginConfigs)
 		return fmt.Errorf("failed to marshal plugin configs: %w", err)
 	}
 
-	return os.WriteFile(path, data, 0600)
+	return WriteFileAtomic(path, data, 0600)
 }
 
 // LoadPluginConfigs loads plugin configurations from plugins.yaml
@@ -24,6 +24,11 @@ func (r *FilesystemRepository) LoadPolicy() (*domain.PolicyConfig, error) {
 		return nil, fmt.Errorf("failed to read policy file: %w", err)
 	}
 
+	return decodePolicy(data)
+}
+
+// decodePolicy parses policy.yaml, accepting the legacy provider/model fields.
+func decodePolicy(data []byte) (*domain.PolicyConfig, error) {
 	var cfg domain.PolicyConfig
 	dec := yaml.NewDecoder(bytes.NewReader(data))
 	dec.KnownFields(true)
@@ -64,5 +69,5 @@ func (r *FilesystemRepository) SavePolicy(cfg *domain.PolicyConfig) error {
 	if err != nil {
 		return fmt.Errorf("failed to marshal policy: %w", err)
 	}
-	return os.WriteFile(path, data, 0600)
+	return WriteFileAtomic(path, data, 0600)
 }
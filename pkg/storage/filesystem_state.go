@@ -8,33 +8,44 @@ import (
 	"github.com/felixgeelhaar/roady/pkg/domain/planning"
 )
 
+// SaveState writes the state if its Version still matches the one on disk,
+// and returns a *planning.ConflictError otherwise. The compare and the write
+// happen under the project lock, so concurrent writers from any process
+// cannot both succeed from the same version.
 func (r *FilesystemRepository) SaveState(s *planning.ExecutionState) error {
 	path, err := r.ResolvePath(StateFile)
 	if err != nil {
 		return err
 	}
 
-	// Optimistic locking: read current version from disk and compare.
-	// #nosec G304 -- Path is resolved and validated via ResolvePath
-	existing, err := os.ReadFile(path)
-	if err == nil {
-		var disk planning.ExecutionState
-		if jsonErr := json.Unmarshal(existing, &disk); jsonErr == nil {
-			if disk.Version != s.Version {
-				return &planning.ConflictError{Expected: s.Version, Actual: disk.Version}
+	return r.WithLock(func() error {
+		// Optimistic locking: read current version from disk and compare.
+		// #nosec G304 -- Path is resolved and validated via ResolvePath
+		existing, err := os.ReadFile(path)
+		if err == nil {
+			var disk planning.ExecutionState
+			if jsonErr := json.Unmarshal(existing, &disk); jsonErr == nil {
+				if disk.Version != s.Version {
+					return &planning.ConflictError{Expected: s.Version, Actual: disk.Version}
+				}
 			}
 		}
-	}
-	// If file doesn't exist, no conflict possible.
+		// If file doesn't exist, no conflict possible.
 
-	s.Version++
+		s.Version++
 
-	data, err := json.MarshalIndent(s, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal state: %w", err)
-	}
+		data, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			s.Version--
+			return fmt.Errorf("failed to marshal state: %w", err)
+		}
 
-	return os.WriteFile(path, data, 0600)
+		if err := WriteFileAtomic(path, data, 0600); err != nil {
+			s.Version--
+			return err
+		}
+		return nil
+	})
 }
 
 func (r *FilesystemRepository) LoadState() (*planning.ExecutionState, error) {
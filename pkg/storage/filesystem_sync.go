@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/plugin"
+)
+
+// SyncBaselinesFile holds the field sync baseline of each plugin.
+const SyncBaselinesFile = "sync-baselines.json"
+
+// LoadSyncBaseline returns the field sync baseline recorded for a plugin, or
+// nil when the plugin was never field-synced.
+func (r *FilesystemRepository) LoadSyncBaseline(name string) (*plugin.SyncBaseline, error) {
+	baselines, err := r.loadSyncBaselines()
+	if err != nil {
+		return nil, err
+	}
+	return baselines[name], nil
+}
+
+// SaveSyncBaseline records a plugin's field sync baseline.
+func (r *FilesystemRepository) SaveSyncBaseline(name string, baseline *plugin.SyncBaseline) error {
+	baselines, err := r.loadSyncBaselines()
+	if err != nil {
+		return err
+	}
+	baselines[name] = baseline
+
+	path, err := r.ResolvePath(SyncBaselinesFile)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(baselines, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal sync baselines: %w", err)
+	}
+	return WriteFileAtomic(path, data, 0600)
+}
+
+func (r *FilesystemRepository) loadSyncBaselines() (map[string]*plugin.SyncBaseline, error) {
+	path, err := r.ResolvePath(SyncBaselinesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// #nosec G304 -- Path is resolved and validated via ResolvePath
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return make(map[string]*plugin.SyncBaseline), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync baselines: %w", err)
+	}
+
+	baselines := make(map[string]*plugin.SyncBaseline)
+	if err := json.Unmarshal(data, &baselines); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sync baselines: %w", err)
+	}
+	return baselines, nil
+}
@@ -44,5 +44,45 @@ func (r *FilesystemRepository) SaveTeam(cfg *team.TeamConfig) error {
 		return fmt.Errorf("failed to marshal team config: %w", err)
 	}
 
-	return os.WriteFile(path, data, 0600)
+	return WriteFileAtomic(path, data, 0600)
+}
+
+// CredentialsFile holds the hashed member API tokens.
+const CredentialsFile = "credentials.yaml"
+
+func (r *FilesystemRepository) LoadCredentials() (*team.Credentials, error) {
+	path, err := r.ResolvePath(CredentialsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// #nosec G304 -- Path is resolved and validated via ResolvePath
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &team.Credentials{}, nil
+		}
+		return nil, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+
+	var creds team.Credentials
+	if err := yaml.Unmarshal(data, &creds); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal credentials: %w", err)
+	}
+
+	return &creds, nil
+}
+
+func (r *FilesystemRepository) SaveCredentials(creds *team.Credentials) error {
+	path, err := r.ResolvePath(CredentialsFile)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(creds)
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+
+	return WriteFileAtomic(path, data, 0600)
 }
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// LockFile is the advisory lock that serialises read-modify-write cycles on
+// a project directory across processes (CLI, MCP server, dashboard, watch).
+const LockFile = "roady.lock"
+
+// lockDir takes the exclusive advisory lock for dir, blocking until it is
+// free. The lock is released by the returned function, or by the operating
+// system if the process dies. Locks are per open file, so goroutines of one
+// process exclude each other too; callers must not nest them.
+func lockDir(dir string) (unlock func() error, err error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("create lock directory: %w", err)
+	}
+	// #nosec G304 -- dir is a project directory and LockFile a constant name
+	f, err := os.OpenFile(filepath.Join(dir, LockFile), os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("open lock file: %w", err)
+	}
+	if err := lockFile(f); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("acquire lock: %w", err)
+	}
+	return func() error {
+		uerr := unlockFile(f)
+		if cerr := f.Close(); uerr == nil {
+			uerr = cerr
+		}
+		return uerr
+	}, nil
+}
+
+// withDirLock runs fn while holding the advisory lock for dir.
+func withDirLock(dir string, fn func() error) (err error) {
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if uerr := unlock(); uerr != nil && err == nil {
+			err = fmt.Errorf("release lock: %w", uerr)
+		}
+	}()
+	return fn()
+}
+
+// WithLock runs fn while holding the project's cross-process advisory lock.
+// Use it around read-modify-write cycles on files in ProjectBase. fn must not
+// call repository methods that take the lock themselves (SaveState,
+// RecordEvent, SavePlanRevision).
+func (r *FilesystemRepository) WithLock(fn func() error) error {
+	return withDirLock(r.ProjectBase(), fn)
+}
@@ -0,0 +1,335 @@
+package storage
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// MigrationResult summarises a storage backend migration.
+type MigrationResult struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Artifacts int    `json:"artifacts"`
+	Events    int    `json:"events"`
+	Revisions int    `json:"revisions"`
+}
+
+// snapshot is a project's stored data as raw bytes: artifacts keyed by their
+// file name relative to the project base, and the event log and plan history
+// as one JSON document per entry. Migrations copy it verbatim, so the files
+// come back byte for byte and the event hash chain stays verifiable.
+type snapshot struct {
+	artifacts map[string][]byte
+	events    [][]byte
+	history   [][]byte
+}
+
+func (s *snapshot) isEmpty() bool {
+	return len(s.artifacts) == 0 && len(s.events) == 0 && len(s.history) == 0
+}
+
+func (s *snapshot) equal(o *snapshot) bool {
+	if len(s.artifacts) != len(o.artifacts) {
+		return false
+	}
+	for name, data := range s.artifacts {
+		if other, ok := o.artifacts[name]; !ok || !bytes.Equal(data, other) {
+			return false
+		}
+	}
+	return slices.EqualFunc(s.events, o.events, bytes.Equal) &&
+		slices.EqualFunc(s.history, o.history, bytes.Equal)
+}
+
+// snapshotter is implemented by backends that can be migrated. The methods
+// are called with the project lock held.
+type snapshotter interface {
+	exportSnapshot() (*snapshot, error)
+	importSnapshot(s *snapshot) error
+	clearSnapshot() error
+}
+
+// Migrate moves a project's data to the backend named to (BackendFiles or
+// BackendSQLite), verifies that the copy reads back identically, switches
+// storage.yaml to the new backend and removes the data from the old one.
+// Configuration files that both backends keep on disk are left untouched.
+// Other roady processes on the project should be stopped first: long-running
+// ones keep using the backend they opened.
+func Migrate(root, project, to string) (*MigrationResult, error) {
+	files, err := NewFilesystemRepositoryForProject(root, project)
+	if err != nil {
+		return nil, err
+	}
+	if !files.IsInitialized() {
+		return nil, fmt.Errorf("project is not initialized: %s", files.ProjectBase())
+	}
+	cfg, err := files.LoadStorageConfig()
+	if err != nil {
+		return nil, err
+	}
+	if to != BackendFiles && to != BackendSQLite {
+		return nil, fmt.Errorf("unknown storage backend %q (want %s or %s)", to, BackendFiles, BackendSQLite)
+	}
+	if cfg.Backend == to {
+		return nil, fmt.Errorf("project already uses the %s backend", to)
+	}
+
+	sqlite := NewSQLiteRepository(files)
+	var src, dst snapshotter = files, sqlite
+	if to == BackendFiles {
+		src, dst = sqlite, files
+	}
+
+	result := &MigrationResult{From: cfg.Backend, To: to}
+	err = files.WithLock(func() error {
+		snap, err := src.exportSnapshot()
+		if err != nil {
+			return fmt.Errorf("read %s backend: %w", cfg.Backend, err)
+		}
+		// Never merge into, or clean up, data already in the target.
+		if existing, err := dst.exportSnapshot(); err != nil {
+			return fmt.Errorf("read %s backend: %w", to, err)
+		} else if !existing.isEmpty() {
+			return fmt.Errorf("the %s backend already holds data for this project; move it away before migrating", to)
+		}
+		if err := dst.importSnapshot(snap); err != nil {
+			return fmt.Errorf("write %s backend: %w", to, err)
+		}
+		copied, err := dst.exportSnapshot()
+		if err != nil {
+			return fmt.Errorf("read back %s backend: %w", to, err)
+		}
+		if !copied.equal(snap) {
+			_ = dst.clearSnapshot()
+			return fmt.Errorf("migrated data does not match the %s backend; nothing was changed", cfg.Backend)
+		}
+		if err := files.SaveStorageConfig(&StorageConfig{Backend: to}); err != nil {
+			return err
+		}
+		result.Artifacts = len(snap.artifacts)
+		result.Events = len(snap.events)
+		result.Revisions = len(snap.history)
+		return src.clearSnapshot()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *FilesystemRepository) exportSnapshot() (*snapshot, error) {
+	snap := &snapshot{artifacts: make(map[string][]byte)}
+	base := r.ProjectBase()
+
+	for _, name := range r.snapshotArtifactNames() {
+		// #nosec G304 -- names are constants or validated revision hashes
+		data, err := os.ReadFile(filepath.Join(base, filepath.FromSlash(name)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", name, err)
+		}
+		snap.artifacts[name] = data
+	}
+
+	var err error
+	if snap.events, err = readEventLog(base); err != nil {
+		return nil, err
+	}
+	if snap.history, err = readLines(filepath.Join(base, PlanHistoryDir, PlanHistoryFile)); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// snapshotArtifactNames lists the artifacts the sqlite backend takes over,
+// including every stored plan revision.
+func (r *FilesystemRepository) snapshotArtifactNames() []string {
+	names := slices.Clone(sqliteArtifacts)
+	entries, _ := os.ReadDir(filepath.Join(r.ProjectBase(), PlanHistoryDir))
+	for _, e := range entries {
+		if hash, ok := strings.CutSuffix(e.Name(), ".json"); ok && revisionHashPattern.MatchString(hash) {
+			names = append(names, revisionArtifact(hash))
+		}
+	}
+	return names
+}
+
+func (r *FilesystemRepository) importSnapshot(s *snapshot) error {
+	base := r.ProjectBase()
+	for name, data := range s.artifacts {
+		path := filepath.Join(base, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return fmt.Errorf("create directory for %s: %w", name, err)
+		}
+		if err := WriteFileAtomic(path, data, 0600); err != nil {
+			return err
+		}
+	}
+	if err := writeLines(filepath.Join(base, EventsFile), s.events); err != nil {
+		return err
+	}
+	if len(s.history) > 0 {
+		if err := os.MkdirAll(filepath.Join(base, PlanHistoryDir), 0700); err != nil {
+			return fmt.Errorf("create plan history directory: %w", err)
+		}
+	}
+	return writeLines(filepath.Join(base, PlanHistoryDir, PlanHistoryFile), s.history)
+}
+
+func (r *FilesystemRepository) clearSnapshot() error {
+	base := r.ProjectBase()
+	names := append(r.snapshotArtifactNames(), EventsFile, PlanHistoryDir+"/"+PlanHistoryFile)
+	for _, name := range names {
+		if err := os.Remove(filepath.Join(base, filepath.FromSlash(name))); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove %s: %w", name, err)
+		}
+	}
+	// Only removed if nothing else was left in it.
+	_ = os.Remove(filepath.Join(base, PlanHistoryDir))
+	// Archived events were exported with the rest of the log.
+	if err := os.RemoveAll(filepath.Join(base, EventArchiveDir)); err != nil {
+		return fmt.Errorf("remove event archive: %w", err)
+	}
+	return nil
+}
+
+func (r *SQLiteRepository) exportSnapshot() (*snapshot, error) {
+	db, err := r.db()
+	if err != nil {
+		return nil, err
+	}
+	snap := &snapshot{artifacts: make(map[string][]byte)}
+
+	names, err := queryColumn(db, `SELECT name FROM artifacts ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		data, err := queryArtifact(db, string(name))
+		if err != nil {
+			return nil, err
+		}
+		snap.artifacts[string(name)] = data
+	}
+
+	if snap.events, err = queryColumn(db, `SELECT data FROM events ORDER BY seq`); err != nil {
+		return nil, err
+	}
+	if snap.history, err = queryColumn(db, `SELECT data FROM plan_history ORDER BY seq`); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+func (r *SQLiteRepository) importSnapshot(s *snapshot) error {
+	db, err := r.db()
+	if err != nil {
+		return err
+	}
+	return withTx(db, func(tx *sql.Tx) error {
+		for _, table := range []string{"artifacts", "events", "plan_history"} {
+			if _, err := tx.Exec(`DELETE FROM ` + table); err != nil {
+				return fmt.Errorf("clear %s: %w", table, err)
+			}
+		}
+		for name, data := range s.artifacts {
+			if err := execPutArtifact(tx, name, data); err != nil {
+				return err
+			}
+		}
+		for i, data := range s.events {
+			if err := insertEvent(tx, data); err != nil {
+				return fmt.Errorf("event %d: %w", i+1, err)
+			}
+		}
+		for i, data := range s.history {
+			if err := insertPlanHistory(tx, data); err != nil {
+				return fmt.Errorf("plan history entry %d: %w", i+1, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *SQLiteRepository) clearSnapshot() error {
+	path := r.DatabasePath()
+	if err := closeSQLite(path); err != nil {
+		return fmt.Errorf("close database: %w", err)
+	}
+	for _, p := range []string{path, path + "-wal", path + "-shm"} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove %s: %w", filepath.Base(p), err)
+		}
+	}
+	return nil
+}
+
+func queryColumn(db *sql.DB, query string) ([][]byte, error) {
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // read-only query
+
+	var out [][]byte
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		out = append(out, data)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read rows: %w", err)
+	}
+	return out, nil
+}
+
+// readLines returns the non-empty lines of a JSON Lines file.
+func readLines(path string) ([][]byte, error) {
+	// #nosec G304 -- path is built from the project base and constant names
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", filepath.Base(path), err)
+	}
+	return splitLines(data), nil
+}
+
+// splitLines returns the non-empty lines of data.
+func splitLines(data []byte) [][]byte {
+	var lines [][]byte
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// writeLines writes lines as a JSON Lines file; nothing is written for none.
+func writeLines(path string, lines [][]byte) error {
+	if len(lines) == 0 {
+		return nil
+	}
+	return WriteFileAtomic(path, joinLines(lines), 0600)
+}
+
+// joinLines joins lines into a JSON Lines document.
+func joinLines(lines [][]byte) []byte {
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
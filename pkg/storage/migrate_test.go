@@ -0,0 +1,176 @@
+package storage
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/felixgeelhaar/roady/pkg/domain"
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/spec"
+)
+
+func TestMigrate_RoundTrip(t *testing.T) {
+	root := t.TempDir()
+	files := NewFilesystemRepository(root)
+	if err := files.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+
+	plan := &planning.Plan{ID: "p1", Tasks: []planning.Task{{ID: "t1", Title: "Task"}}}
+	state := planning.NewExecutionState("p1")
+	for _, err := range []error{
+		files.SaveSpec(&spec.ProductSpec{ID: "s1", Title: "Shop"}),
+		files.SavePlan(plan),
+		files.SaveState(state),
+		files.SavePolicy(&domain.PolicyConfig{MaxWIP: 2}),
+		files.SavePlanRevision(plan, planning.NewPlanRevision(plan, "alice")),
+		files.SaveTeam(nil),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	store, _ := NewFileEventStore(files.ProjectBase())
+	for i := range 3 {
+		if err := store.Append(&events.BaseEvent{Type: events.EventTypeTaskStarted, AggregateID_: "t1", Actor: "alice", Metadata: map[string]interface{}{"i": i}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	before := readProjectFiles(t, files.ProjectBase())
+
+	result, err := Migrate(root, "", BackendSQLite)
+	if err != nil {
+		t.Fatalf("Migrate to sqlite: %v", err)
+	}
+	if result.From != BackendFiles || result.Events != 3 || result.Revisions != 1 || result.Artifacts != 5 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	for _, name := range []string{SpecFile, PlanFile, StateFile, EventsFile, PlanHistoryDir} {
+		if _, err := os.Stat(filepath.Join(files.ProjectBase(), name)); !os.IsNotExist(err) {
+			t.Errorf("%s should have moved into the database", name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(files.ProjectBase(), TeamFile)); err != nil {
+		t.Errorf("configuration files should stay on disk: %v", err)
+	}
+
+	repo, err := OpenRepository(root, "")
+	if err != nil || repo.Backend() != BackendSQLite {
+		t.Fatalf("OpenRepository = %v, %v", repo, err)
+	}
+	if loaded, err := repo.LoadState(); err != nil || loaded.Version != state.Version {
+		t.Errorf("LoadState = %+v, %v", loaded, err)
+	}
+	if revisions, err := repo.ListPlanRevisions(); err != nil || len(revisions) != 1 {
+		t.Errorf("ListPlanRevisions = %+v, %v", revisions, err)
+	}
+	sqliteStore, err := repo.EventStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sqliteStore.Append(&events.BaseEvent{Type: events.EventTypeTaskCompleted, AggregateID_: "t1", Actor: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if violations, err := sqliteStore.(*SQLiteEventStore).VerifyIntegrity(); err != nil || len(violations) != 0 {
+		t.Errorf("VerifyIntegrity after migration = %v, %v", violations, err)
+	}
+
+	if _, err := Migrate(root, "", BackendSQLite); err == nil {
+		t.Error("expected migrating to the current backend to fail")
+	}
+
+	result, err = Migrate(root, "", BackendFiles)
+	if err != nil {
+		t.Fatalf("Migrate to files: %v", err)
+	}
+	if result.Events != 4 {
+		t.Errorf("expected the appended event to migrate back, got %+v", result)
+	}
+	if _, err := os.Stat(filepath.Join(files.ProjectBase(), SQLiteFile)); !os.IsNotExist(err) {
+		t.Error("the database should be removed after migrating back")
+	}
+
+	after := readProjectFiles(t, files.ProjectBase())
+	for name, data := range before {
+		if name == EventsFile {
+			if !bytes.HasPrefix(after[name], data) {
+				t.Errorf("%s was not preserved", name)
+			}
+			continue
+		}
+		if !bytes.Equal(after[name], data) {
+			t.Errorf("%s changed in the round trip", name)
+		}
+	}
+	if violations, err := store.VerifyIntegrity(); err != nil || len(violations) != 0 {
+		t.Errorf("VerifyIntegrity after round trip = %v, %v", violations, err)
+	}
+}
+
+func TestMigrate_RefusesBadInput(t *testing.T) {
+	root := t.TempDir()
+	if _, err := Migrate(root, "", BackendSQLite); err == nil {
+		t.Error("expected an uninitialized project to be rejected")
+	}
+
+	files := NewFilesystemRepository(root)
+	if err := files.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Migrate(root, "", "postgres"); err == nil {
+		t.Error("expected an unknown backend to be rejected")
+	}
+	if _, err := Migrate(root, "", BackendFiles); err == nil {
+		t.Error("expected migrating to the current backend to fail")
+	}
+
+	// Data already in the target backend is never overwritten.
+	if err := files.SaveSpec(&spec.ProductSpec{ID: "s1"}); err != nil {
+		t.Fatal(err)
+	}
+	sqlite := NewSQLiteRepository(files)
+	t.Cleanup(func() { _ = sqlite.Close() })
+	if err := sqlite.SavePlan(&planning.Plan{ID: "stale"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Migrate(root, "", BackendSQLite); err == nil {
+		t.Error("expected a non-empty target to be rejected")
+	}
+	if cfg, _ := files.LoadStorageConfig(); cfg.Backend != BackendFiles {
+		t.Errorf("backend switched after a failed migration: %s", cfg.Backend)
+	}
+
+	if err := files.SaveStorageConfig(&StorageConfig{Backend: "postgres"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenRepository(root, ""); err == nil {
+		t.Error("expected an unknown backend in storage.yaml to be rejected")
+	}
+}
+
+// readProjectFiles returns the contents of every regular file under dir,
+// keyed by slash-separated relative path.
+func readProjectFiles(t *testing.T, dir string) map[string][]byte {
+	t.Helper()
+	out := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || d.Name() == LockFile {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		out[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+)
+
+// ProjectionsDir holds projection snapshots for the files backend.
+const ProjectionsDir = "projections"
+
+// projectionNamePattern matches the projection names used as file names.
+var projectionNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+
+// projectionRecord is the serialized form of a projection checkpoint.
+type projectionRecord struct {
+	LastEventID string          `json:"last_event_id"`
+	State       json.RawMessage `json:"state,omitempty"`
+	SavedAt     time.Time       `json:"saved_at"`
+}
+
+// FileProjectionStore implements events.ProjectionStore with one JSON file
+// per projection under <basePath>/projections. Snapshots are a cache: they
+// can be deleted at any time and are rebuilt from the event log.
+type FileProjectionStore struct {
+	dir string
+}
+
+// NewFileProjectionStore creates a projection store under basePath.
+func NewFileProjectionStore(basePath string) *FileProjectionStore {
+	return &FileProjectionStore{dir: filepath.Join(basePath, ProjectionsDir)}
+}
+
+// SaveCheckpoint records the last processed event ID without any state.
+func (s *FileProjectionStore) SaveCheckpoint(projectionName string, lastEventID string) error {
+	return s.SaveSnapshot(projectionName, lastEventID, nil)
+}
+
+// LoadCheckpoint returns the last processed event ID, or "" if none.
+func (s *FileProjectionStore) LoadCheckpoint(projectionName string) (string, error) {
+	id, _, err := s.LoadSnapshot(projectionName)
+	return id, err
+}
+
+// SaveSnapshot writes the projection's state and checkpoint.
+func (s *FileProjectionStore) SaveSnapshot(projectionName string, lastEventID string, state []byte) error {
+	path, err := s.path(projectionName)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(projectionRecord{LastEventID: lastEventID, State: state, SavedAt: time.Now().UTC()})
+	if err != nil {
+		return fmt.Errorf("marshal %s snapshot: %w", projectionName, err)
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return fmt.Errorf("create projections directory: %w", err)
+	}
+	return WriteFileAtomic(path, data, 0600)
+}
+
+// LoadSnapshot returns the saved state and checkpoint. A missing or
+// unreadable snapshot is reported as none, so the projection is rebuilt.
+func (s *FileProjectionStore) LoadSnapshot(projectionName string) (string, []byte, error) {
+	path, err := s.path(projectionName)
+	if err != nil {
+		return "", nil, err
+	}
+	// #nosec G304 -- path is the projections directory and a validated name
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("read %s snapshot: %w", projectionName, err)
+	}
+	var rec projectionRecord
+	if json.Unmarshal(data, &rec) != nil {
+		return "", nil, nil
+	}
+	return rec.LastEventID, rec.State, nil
+}
+
+func (s *FileProjectionStore) path(projectionName string) (string, error) {
+	if !projectionNamePattern.MatchString(projectionName) {
+		return "", fmt.Errorf("invalid projection name %q", projectionName)
+	}
+	return filepath.Join(s.dir, projectionName+".json"), nil
+}
+
+var _ events.ProjectionStore = (*FileProjectionStore)(nil)
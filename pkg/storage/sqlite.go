@@ -0,0 +1,621 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/felixgeelhaar/roady/pkg/domain"
+	"github.com/felixgeelhaar/roady/pkg/domain/billing"
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/spec"
+	"gopkg.in/yaml.v3"
+
+	_ "modernc.org/sqlite" // pure-Go driver registered as "sqlite"
+)
+
+// SQLiteFile is the database used by the sqlite backend.
+const SQLiteFile = "roady.db"
+
+// sqliteArtifacts are the files whose contents the sqlite backend keeps in
+// its artifacts table, byte for byte. Plan revisions are stored there too,
+// as plans/<hash>.json.
+var sqliteArtifacts = []string{
+	SpecFile, SpecLockFile, PlanFile, StateFile, PolicyFile, UsageFile, RatesFile, TimeEntriesFile,
+}
+
+var sqliteSchema = []string{
+	`CREATE TABLE IF NOT EXISTS artifacts (
+		name TEXT PRIMARY KEY,
+		data BLOB NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS events (
+		seq            INTEGER PRIMARY KEY AUTOINCREMENT,
+		id             TEXT NOT NULL,
+		type           TEXT NOT NULL,
+		aggregate_type TEXT NOT NULL,
+		aggregate_id   TEXT NOT NULL,
+		ts             INTEGER NOT NULL,
+		hash           TEXT NOT NULL,
+		data           TEXT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS events_type ON events (type, seq)`,
+	`CREATE INDEX IF NOT EXISTS events_aggregate ON events (aggregate_type, aggregate_id, seq)`,
+	`CREATE INDEX IF NOT EXISTS events_ts ON events (ts, seq)`,
+	`CREATE TABLE IF NOT EXISTS plan_history (
+		seq  INTEGER PRIMARY KEY AUTOINCREMENT,
+		hash TEXT NOT NULL,
+		data TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS projections (
+		name          TEXT PRIMARY KEY,
+		last_event_id TEXT NOT NULL,
+		state         BLOB
+	)`,
+}
+
+// Database handles are shared per file so that every repository and event
+// store for a project uses one connection pool.
+var (
+	sqliteMu  sync.Mutex
+	sqliteDBs = make(map[string]*sql.DB)
+)
+
+// openSQLite returns the shared handle for the database at path, creating
+// the file and schema on first use. Transactions begin IMMEDIATE so that
+// read-modify-write cycles take the write lock up front.
+func openSQLite(path string) (*sql.DB, error) {
+	sqliteMu.Lock()
+	defer sqliteMu.Unlock()
+
+	if db, ok := sqliteDBs[path]; ok {
+		return db, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("create database directory: %w", err)
+	}
+	// Create the file ourselves so it gets the same permissions as the
+	// files it replaces.
+	// #nosec G304 -- path is <project base>/roady.db
+	if f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600); err == nil {
+		_ = f.Close()
+	} else {
+		return nil, fmt.Errorf("create database: %w", err)
+	}
+
+	dsn := path + "?_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)&_pragma=synchronous(NORMAL)&_txlock=immediate"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	for _, stmt := range sqliteSchema {
+		if _, err := db.Exec(stmt); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("create database schema: %w", err)
+		}
+	}
+	sqliteDBs[path] = db
+	return db, nil
+}
+
+// closeSQLite closes the shared handle for path, if open.
+func closeSQLite(path string) error {
+	sqliteMu.Lock()
+	defer sqliteMu.Unlock()
+
+	db, ok := sqliteDBs[path]
+	if !ok {
+		return nil
+	}
+	delete(sqliteDBs, path)
+	return db.Close()
+}
+
+// withTx runs fn in a write transaction, committing if it returns nil.
+func withTx(db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// errArtifactNotFound reports a missing artifact the way a missing file is
+// reported, so errors.Is(err, fs.ErrNotExist) holds for both backends.
+func errArtifactNotFound(name string) error {
+	return &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+}
+
+// SQLiteRepository stores a project's spec, plan, state, policy, usage,
+// billing data, plan history and event log in <project>/roady.db. Webhook,
+// plugin, team, messaging and dependency configuration stay in their files,
+// served by the embedded FilesystemRepository.
+type SQLiteRepository struct {
+	*FilesystemRepository
+}
+
+// NewSQLiteRepository returns a sqlite-backed repository for the project
+// that files addresses. The database is opened on first use.
+func NewSQLiteRepository(files *FilesystemRepository) *SQLiteRepository {
+	return &SQLiteRepository{FilesystemRepository: files}
+}
+
+// Backend reports BackendSQLite.
+func (r *SQLiteRepository) Backend() string {
+	return BackendSQLite
+}
+
+// DatabasePath returns the location of the project's database.
+func (r *SQLiteRepository) DatabasePath() string {
+	return filepath.Join(r.ProjectBase(), SQLiteFile)
+}
+
+// EventStore returns the event store kept in the project's database.
+func (r *SQLiteRepository) EventStore() (events.EventStore, error) {
+	return NewSQLiteEventStore(r.ProjectBase())
+}
+
+// ProjectionStore returns the projection snapshots kept in the project's
+// database.
+func (r *SQLiteRepository) ProjectionStore() events.ProjectionStore {
+	return NewSQLiteProjectionStore(r.ProjectBase())
+}
+
+// CompactEvents is not supported: the database indexes the event log, so
+// there are no segments to archive.
+func (r *SQLiteRepository) CompactEvents(CompactOptions) (*CompactionResult, error) {
+	return nil, fmt.Errorf("event log compaction is only available with the %s backend", BackendFiles)
+}
+
+// Close releases the project's database handle. Later calls reopen it.
+func (r *SQLiteRepository) Close() error {
+	return closeSQLite(r.DatabasePath())
+}
+
+func (r *SQLiteRepository) db() (*sql.DB, error) {
+	return openSQLite(r.DatabasePath())
+}
+
+// getArtifact returns the stored bytes of the named artifact.
+func (r *SQLiteRepository) getArtifact(name string) ([]byte, error) {
+	db, err := r.db()
+	if err != nil {
+		return nil, err
+	}
+	return queryArtifact(db, name)
+}
+
+type queryer interface {
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+func queryArtifact(q queryer, name string) ([]byte, error) {
+	var data []byte
+	err := q.QueryRow(`SELECT data FROM artifacts WHERE name = ?`, name).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errArtifactNotFound(name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query %s: %w", name, err)
+	}
+	return data, nil
+}
+
+// putArtifact replaces the stored bytes of the named artifact.
+func (r *SQLiteRepository) putArtifact(name string, data []byte) error {
+	db, err := r.db()
+	if err != nil {
+		return err
+	}
+	return r.WithLock(func() error {
+		return execPutArtifact(db, name, data)
+	})
+}
+
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+func execPutArtifact(e execer, name string, data []byte) error {
+	_, err := e.Exec(`INSERT INTO artifacts (name, data) VALUES (?, ?)
+		ON CONFLICT (name) DO UPDATE SET data = excluded.data`, name, data)
+	if err != nil {
+		return fmt.Errorf("store %s: %w", name, err)
+	}
+	return nil
+}
+
+func (r *SQLiteRepository) SaveSpec(s *spec.ProductSpec) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal spec: %w", err)
+	}
+	return r.putArtifact(SpecFile, data)
+}
+
+func (r *SQLiteRepository) LoadSpec() (*spec.ProductSpec, error) {
+	data, err := r.getArtifact(SpecFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec: %w", err)
+	}
+
+	var s spec.ProductSpec
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal spec: %w", err)
+	}
+	return &s, nil
+}
+
+func (r *SQLiteRepository) SaveSpecLock(s *spec.ProductSpec) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal spec lock: %w", err)
+	}
+	return r.putArtifact(SpecLockFile, data)
+}
+
+func (r *SQLiteRepository) LoadSpecLock() (*spec.ProductSpec, error) {
+	data, err := r.getArtifact(SpecLockFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec lock: %w", err)
+	}
+
+	var s spec.ProductSpec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal spec lock: %w", err)
+	}
+	return &s, nil
+}
+
+func (r *SQLiteRepository) SavePlan(p *planning.Plan) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal plan: %w", err)
+	}
+	return r.putArtifact(PlanFile, data)
+}
+
+// UpdatePlan loads the plan, passes it to fn and saves it, all under the
+// project lock. Nothing is saved when fn fails.
+func (r *SQLiteRepository) UpdatePlan(fn func(p *planning.Plan) error) error {
+	db, err := r.db()
+	if err != nil {
+		return err
+	}
+	return r.WithLock(func() error {
+		p, err := r.LoadPlan()
+		if err != nil {
+			return err
+		}
+		if p == nil {
+			return fmt.Errorf("no plan found")
+		}
+		if err := fn(p); err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(p, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal plan: %w", err)
+		}
+		return execPutArtifact(db, PlanFile, data)
+	})
+}
+
+func (r *SQLiteRepository) LoadPlan() (*planning.Plan, error) {
+	data, err := r.getArtifact(PlanFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil // No plan exists yet
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan: %w", err)
+	}
+
+	var p planning.Plan
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal plan: %w", err)
+	}
+	return &p, nil
+}
+
+// SaveState writes the state if its Version still matches the stored one,
+// and returns a *planning.ConflictError otherwise. The compare and the write
+// happen in one transaction.
+func (r *SQLiteRepository) SaveState(s *planning.ExecutionState) error {
+	db, err := r.db()
+	if err != nil {
+		return err
+	}
+
+	return r.WithLock(func() error {
+		return withTx(db, func(tx *sql.Tx) error {
+			existing, err := queryArtifact(tx, StateFile)
+			if err == nil {
+				var stored planning.ExecutionState
+				if jsonErr := json.Unmarshal(existing, &stored); jsonErr == nil && stored.Version != s.Version {
+					return &planning.ConflictError{Expected: s.Version, Actual: stored.Version}
+				}
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+
+			s.Version++
+			data, err := json.MarshalIndent(s, "", "  ")
+			if err != nil {
+				s.Version--
+				return fmt.Errorf("failed to marshal state: %w", err)
+			}
+			if err := execPutArtifact(tx, StateFile, data); err != nil {
+				s.Version--
+				return err
+			}
+			return nil
+		})
+	})
+}
+
+func (r *SQLiteRepository) LoadState() (*planning.ExecutionState, error) {
+	data, err := r.getArtifact(StateFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return planning.NewExecutionState("unknown"), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state: %w", err)
+	}
+
+	var s planning.ExecutionState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal state: %w", err)
+	}
+	return &s, nil
+}
+
+func (r *SQLiteRepository) SavePolicy(cfg *domain.PolicyConfig) error {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal policy: %w", err)
+	}
+	return r.putArtifact(PolicyFile, data)
+}
+
+func (r *SQLiteRepository) LoadPolicy() (*domain.PolicyConfig, error) {
+	data, err := r.getArtifact(PolicyFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &domain.PolicyConfig{MaxWIP: 3, AllowAI: true}, nil // Default
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+	return decodePolicy(data)
+}
+
+func (r *SQLiteRepository) UpdateUsage(stats domain.UsageStats) error {
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal usage stats: %w", err)
+	}
+	return r.putArtifact(UsageFile, data)
+}
+
+func (r *SQLiteRepository) LoadUsage() (*domain.UsageStats, error) {
+	data, err := r.getArtifact(UsageFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read usage: %w", err)
+	}
+
+	var stats domain.UsageStats
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal usage stats: %w", err)
+	}
+	return &stats, nil
+}
+
+func (r *SQLiteRepository) SaveRates(config *billing.RateConfig) error {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rates: %w", err)
+	}
+	return r.putArtifact(RatesFile, data)
+}
+
+func (r *SQLiteRepository) LoadRates() (*billing.RateConfig, error) {
+	data, err := r.getArtifact(RatesFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &billing.RateConfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rates: %w", err)
+	}
+
+	var config billing.RateConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rates: %w", err)
+	}
+	return &config, nil
+}
+
+func (r *SQLiteRepository) SaveTimeEntries(entries []billing.TimeEntry) error {
+	data, err := yaml.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to marshal time entries: %w", err)
+	}
+	return r.putArtifact(TimeEntriesFile, data)
+}
+
+func (r *SQLiteRepository) LoadTimeEntries() ([]billing.TimeEntry, error) {
+	data, err := r.getArtifact(TimeEntriesFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return []billing.TimeEntry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read time entries: %w", err)
+	}
+
+	var entries []billing.TimeEntry
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal time entries: %w", err)
+	}
+	return entries, nil
+}
+
+// RecordEvent appends an audit event to the event log.
+func (r *SQLiteRepository) RecordEvent(event domain.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	db, err := r.db()
+	if err != nil {
+		return err
+	}
+	return r.WithLock(func() error {
+		return withTx(db, func(tx *sql.Tx) error {
+			return insertEvent(tx, data)
+		})
+	})
+}
+
+// LoadEvents returns the audit events in the log, oldest first.
+func (r *SQLiteRepository) LoadEvents() ([]domain.Event, error) {
+	db, err := r.db()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(`SELECT data FROM events ORDER BY seq`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // read-only query
+
+	evts := make([]domain.Event, 0)
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		var e domain.Event
+		if err := json.Unmarshal(data, &e); err != nil {
+			continue // Skip malformed rows, as the files backend does
+		}
+		evts = append(evts, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read events: %w", err)
+	}
+	return evts, nil
+}
+
+// SavePlanRevision stores the plan under its hash, unless already stored,
+// and appends rev to the plan history.
+func (r *SQLiteRepository) SavePlanRevision(p *planning.Plan, rev planning.PlanRevision) error {
+	if !revisionHashPattern.MatchString(rev.Hash) {
+		return fmt.Errorf("invalid plan revision hash: %q", rev.Hash)
+	}
+	planData, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal plan revision: %w", err)
+	}
+	revData, err := json.Marshal(rev)
+	if err != nil {
+		return fmt.Errorf("failed to marshal plan revision: %w", err)
+	}
+	db, err := r.db()
+	if err != nil {
+		return err
+	}
+
+	return r.WithLock(func() error {
+		return withTx(db, func(tx *sql.Tx) error {
+			if _, err := tx.Exec(`INSERT INTO artifacts (name, data) VALUES (?, ?) ON CONFLICT (name) DO NOTHING`,
+				revisionArtifact(rev.Hash), planData); err != nil {
+				return fmt.Errorf("failed to write plan revision: %w", err)
+			}
+			return insertPlanHistory(tx, revData)
+		})
+	})
+}
+
+// LoadPlanRevision loads the plan stored under the given full hash.
+func (r *SQLiteRepository) LoadPlanRevision(hash string) (*planning.Plan, error) {
+	if !revisionHashPattern.MatchString(hash) {
+		return nil, fmt.Errorf("invalid plan revision hash: %q", hash)
+	}
+	data, err := r.getArtifact(revisionArtifact(hash))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("plan revision %s not found", planning.ShortRevision(hash))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan revision: %w", err)
+	}
+
+	var p planning.Plan
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal plan revision: %w", err)
+	}
+	return &p, nil
+}
+
+// ListPlanRevisions returns the plan history, oldest approval first.
+func (r *SQLiteRepository) ListPlanRevisions() ([]planning.PlanRevision, error) {
+	db, err := r.db()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(`SELECT data FROM plan_history ORDER BY seq`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query plan history: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // read-only query
+
+	revisions := make([]planning.PlanRevision, 0)
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to scan plan history: %w", err)
+		}
+		var rev planning.PlanRevision
+		if err := json.Unmarshal(data, &rev); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal plan history: %w", err)
+		}
+		revisions = append(revisions, rev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read plan history: %w", err)
+	}
+	return revisions, nil
+}
+
+// revisionArtifact names a stored plan revision after its file in the
+// files backend.
+func revisionArtifact(hash string) string {
+	return PlanHistoryDir + "/" + hash + ".json"
+}
+
+// insertPlanHistory appends one JSON-encoded PlanRevision to the history.
+func insertPlanHistory(e execer, data []byte) error {
+	var rev planning.PlanRevision
+	if err := json.Unmarshal(data, &rev); err != nil {
+		return fmt.Errorf("failed to unmarshal plan history: %w", err)
+	}
+	if _, err := e.Exec(`INSERT INTO plan_history (hash, data) VALUES (?, ?)`, rev.Hash, string(data)); err != nil {
+		return fmt.Errorf("failed to write plan history: %w", err)
+	}
+	return nil
+}
+
+var (
+	_ Repository                  = (*SQLiteRepository)(nil)
+	_ planning.RevisionRepository = (*SQLiteRepository)(nil)
+)
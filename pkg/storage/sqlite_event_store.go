@@ -0,0 +1,302 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+	"github.com/google/uuid"
+)
+
+// SQLiteEventStore implements EventStore on the events table of a project
+// database. Each row keeps the event exactly as it would appear as a line of
+// events.jsonl, next to indexed type, aggregate and timestamp columns.
+type SQLiteEventStore struct {
+	basePath string
+	path     string
+	signer   *events.Signer
+}
+
+// NewSQLiteEventStore creates an event store on <basePath>/roady.db. The
+// database is opened on first use.
+func NewSQLiteEventStore(basePath string) (*SQLiteEventStore, error) {
+	return &SQLiteEventStore{basePath: basePath, path: filepath.Join(basePath, SQLiteFile)}, nil
+}
+
+// Append adds a new event to the store, chaining it to the last stored event.
+func (s *SQLiteEventStore) Append(event *events.BaseEvent) error {
+	db, err := openSQLite(s.path)
+	if err != nil {
+		return err
+	}
+
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	event.EnsureAction()
+
+	return withDirLock(s.basePath, func() error {
+		return withTx(db, func(tx *sql.Tx) error {
+			var prev string
+			err := tx.QueryRow(`SELECT hash FROM events ORDER BY seq DESC LIMIT 1`).Scan(&prev)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("query last event: %w", err)
+			}
+			event.PrevHash = prev
+			event.Hash = event.CalculateHash()
+			if s.signer != nil {
+				s.signer.SignEvent(event)
+			}
+
+			data, err := json.Marshal(event)
+			if err != nil {
+				return fmt.Errorf("marshal event: %w", err)
+			}
+			return insertEvent(tx, data)
+		})
+	})
+}
+
+// SetSigner makes Append sign every event it writes. Pass nil to stop
+// signing.
+func (s *SQLiteEventStore) SetSigner(signer *events.Signer) {
+	s.signer = signer
+}
+
+// LoadAll returns all events in chronological order.
+func (s *SQLiteEventStore) LoadAll() ([]*events.BaseEvent, error) {
+	return s.query(`SELECT data FROM events ORDER BY seq`)
+}
+
+// LoadByAggregate returns events for a specific aggregate.
+func (s *SQLiteEventStore) LoadByAggregate(aggregateType, aggregateID string) ([]*events.BaseEvent, error) {
+	return s.query(`SELECT data FROM events WHERE aggregate_type = ? AND aggregate_id = ? ORDER BY seq`, aggregateType, aggregateID)
+}
+
+// LoadByType returns events of a specific type.
+func (s *SQLiteEventStore) LoadByType(eventType string) ([]*events.BaseEvent, error) {
+	return s.query(`SELECT data FROM events WHERE type = ? ORDER BY seq`, eventType)
+}
+
+// LoadSince returns events that occurred after the given timestamp.
+func (s *SQLiteEventStore) LoadSince(since time.Time) ([]*events.BaseEvent, error) {
+	return s.query(`SELECT data FROM events WHERE ts > ? ORDER BY seq`, unixNanos(since))
+}
+
+// LoadRange returns events within a time range.
+func (s *SQLiteEventStore) LoadRange(from, to time.Time) ([]*events.BaseEvent, error) {
+	return s.query(`SELECT data FROM events WHERE ts >= ? AND ts <= ? ORDER BY seq`, unixNanos(from), unixNanos(to))
+}
+
+// Query returns the events that match q. Aggregate and time filters run in
+// SQL; type globs, actor and metadata filters and paging run on the result.
+func (s *SQLiteEventStore) Query(q events.EventQuery) ([]*events.BaseEvent, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	var where []string
+	var args []any
+	if q.AggregateType != "" {
+		where, args = append(where, "aggregate_type = ?"), append(args, q.AggregateType)
+	}
+	if q.AggregateID != "" {
+		where, args = append(where, "aggregate_id = ?"), append(args, q.AggregateID)
+	}
+	if q.Since != nil {
+		where, args = append(where, "ts >= ?"), append(args, unixNanos(*q.Since))
+	}
+	if q.Until != nil {
+		where, args = append(where, "ts <= ?"), append(args, unixNanos(*q.Until))
+	}
+	stmt := `SELECT data FROM events`
+	if len(where) > 0 {
+		stmt += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	evts, err := s.query(stmt+` ORDER BY seq`, args...)
+	if err != nil {
+		return nil, err
+	}
+	return q.Filter(evts), nil
+}
+
+// LoadAfter returns the events appended after the event with the given ID.
+func (s *SQLiteEventStore) LoadAfter(eventID string) ([]*events.BaseEvent, error) {
+	if eventID == "" {
+		return s.LoadAll()
+	}
+	db, err := openSQLite(s.path)
+	if err != nil {
+		return nil, err
+	}
+	var seq int64
+	err = db.QueryRow(`SELECT seq FROM events WHERE id = ? ORDER BY seq DESC LIMIT 1`, eventID).Scan(&seq)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, events.ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query event %s: %w", eventID, err)
+	}
+	return s.query(`SELECT data FROM events WHERE seq > ? ORDER BY seq`, seq)
+}
+
+// GetLastEvent returns the most recent event.
+func (s *SQLiteEventStore) GetLastEvent() (*events.BaseEvent, error) {
+	evts, err := s.query(`SELECT data FROM events ORDER BY seq DESC LIMIT 1`)
+	if err != nil || len(evts) == 0 {
+		return nil, err
+	}
+	return evts[0], nil
+}
+
+// Count returns the total number of events.
+func (s *SQLiteEventStore) Count() (int, error) {
+	db, err := openSQLite(s.path)
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM events`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count events: %w", err)
+	}
+	return n, nil
+}
+
+// VerifyIntegrity checks the hash chain for tampering.
+func (s *SQLiteEventStore) VerifyIntegrity() ([]string, error) {
+	evts, err := s.LoadAll()
+	if err != nil {
+		return nil, err
+	}
+	return verifyChain(evts), nil
+}
+
+func (s *SQLiteEventStore) query(query string, args ...any) ([]*events.BaseEvent, error) {
+	db, err := openSQLite(s.path)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query events: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // read-only query
+
+	var result []*events.BaseEvent
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("scan event: %w", err)
+		}
+		var event events.BaseEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			return nil, fmt.Errorf("unmarshal event: %w", err)
+		}
+		result = append(result, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read events: %w", err)
+	}
+	return result, nil
+}
+
+// insertEvent stores one JSON-encoded event, exactly as given, and indexes
+// it by the fields a BaseEvent reads from it. Audit events written through
+// the repository have no type or aggregate and are indexed with empty ones.
+func insertEvent(e execer, data []byte) error {
+	var event events.BaseEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return fmt.Errorf("unmarshal event: %w", err)
+	}
+	_, err := e.Exec(`INSERT INTO events (id, type, aggregate_type, aggregate_id, ts, hash, data) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		event.ID, event.Type, event.AggregateType_, event.AggregateID_, unixNanos(event.Timestamp), event.Hash, string(data))
+	if err != nil {
+		return fmt.Errorf("insert event: %w", err)
+	}
+	return nil
+}
+
+// unixNanos converts t for the ts column, clamping times that UnixNano
+// cannot represent (such as the zero time) to the ends of the range.
+func unixNanos(t time.Time) int64 {
+	switch {
+	case t.Before(time.Unix(0, math.MinInt64)):
+		return math.MinInt64
+	case t.After(time.Unix(0, math.MaxInt64)):
+		return math.MaxInt64
+	default:
+		return t.UnixNano()
+	}
+}
+
+var (
+	_ events.EventStore = (*SQLiteEventStore)(nil)
+	_ events.Querier    = (*SQLiteEventStore)(nil)
+)
+
+// SQLiteProjectionStore implements events.ProjectionStore on the projections
+// table of a project database.
+type SQLiteProjectionStore struct {
+	path string
+}
+
+// NewSQLiteProjectionStore creates a projection store on <basePath>/roady.db.
+func NewSQLiteProjectionStore(basePath string) *SQLiteProjectionStore {
+	return &SQLiteProjectionStore{path: filepath.Join(basePath, SQLiteFile)}
+}
+
+// SaveCheckpoint records the last processed event ID without any state.
+func (s *SQLiteProjectionStore) SaveCheckpoint(projectionName string, lastEventID string) error {
+	return s.SaveSnapshot(projectionName, lastEventID, nil)
+}
+
+// LoadCheckpoint returns the last processed event ID, or "" if none.
+func (s *SQLiteProjectionStore) LoadCheckpoint(projectionName string) (string, error) {
+	id, _, err := s.LoadSnapshot(projectionName)
+	return id, err
+}
+
+// SaveSnapshot writes the projection's state and checkpoint.
+func (s *SQLiteProjectionStore) SaveSnapshot(projectionName string, lastEventID string, state []byte) error {
+	db, err := openSQLite(s.path)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`INSERT INTO projections (name, last_event_id, state) VALUES (?, ?, ?)
+		ON CONFLICT (name) DO UPDATE SET last_event_id = excluded.last_event_id, state = excluded.state`,
+		projectionName, lastEventID, state)
+	if err != nil {
+		return fmt.Errorf("store %s snapshot: %w", projectionName, err)
+	}
+	return nil
+}
+
+// LoadSnapshot returns the saved state and checkpoint.
+func (s *SQLiteProjectionStore) LoadSnapshot(projectionName string) (string, []byte, error) {
+	db, err := openSQLite(s.path)
+	if err != nil {
+		return "", nil, err
+	}
+	var (
+		id    string
+		state []byte
+	)
+	err = db.QueryRow(`SELECT last_event_id, state FROM projections WHERE name = ?`, projectionName).Scan(&id, &state)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("query %s snapshot: %w", projectionName, err)
+	}
+	return id, state, nil
+}
+
+var _ events.ProjectionStore = (*SQLiteProjectionStore)(nil)
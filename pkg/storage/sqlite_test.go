@@ -0,0 +1,221 @@
+package storage
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain"
+	"github.com/felixgeelhaar/roady/pkg/domain/billing"
+	"github.com/felixgeelhaar/roady/pkg/domain/events"
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+	"github.com/felixgeelhaar/roady/pkg/domain/spec"
+)
+
+func newTestSQLiteRepository(t *testing.T) *SQLiteRepository {
+	t.Helper()
+	repo := NewSQLiteRepository(NewFilesystemRepository(t.TempDir()))
+	if err := repo.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+	return repo
+}
+
+func TestSQLiteRepository_Artifacts(t *testing.T) {
+	repo := newTestSQLiteRepository(t)
+
+	if _, err := repo.LoadSpec(); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected a missing spec to be not-exist, got %v", err)
+	}
+	if plan, err := repo.LoadPlan(); plan != nil || err != nil {
+		t.Fatalf("expected no plan, got %v, %v", plan, err)
+	}
+	if pol, err := repo.LoadPolicy(); err != nil || pol.MaxWIP != 3 {
+		t.Fatalf("expected the default policy, got %+v, %v", pol, err)
+	}
+	if entries, err := repo.LoadTimeEntries(); err != nil || len(entries) != 0 {
+		t.Fatalf("expected no time entries, got %v, %v", entries, err)
+	}
+
+	if err := repo.SaveSpec(&spec.ProductSpec{ID: "s1", Title: "Shop"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SaveSpecLock(&spec.ProductSpec{ID: "s1", Title: "Locked"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SavePlan(&planning.Plan{ID: "p1", Tasks: []planning.Task{{ID: "t1"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SavePolicy(&domain.PolicyConfig{MaxWIP: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.UpdateUsage(domain.UsageStats{TotalCommands: 7}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SaveRates(&billing.RateConfig{Currency: "EUR"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SaveTimeEntries([]billing.TimeEntry{{ID: "e1", TaskID: "t1"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if s, err := repo.LoadSpec(); err != nil || s.Title != "Shop" {
+		t.Errorf("LoadSpec = %+v, %v", s, err)
+	}
+	if s, err := repo.LoadSpecLock(); err != nil || s.Title != "Locked" {
+		t.Errorf("LoadSpecLock = %+v, %v", s, err)
+	}
+	if p, err := repo.LoadPlan(); err != nil || p.ID != "p1" || len(p.Tasks) != 1 {
+		t.Errorf("LoadPlan = %+v, %v", p, err)
+	}
+	if pol, err := repo.LoadPolicy(); err != nil || pol.MaxWIP != 5 {
+		t.Errorf("LoadPolicy = %+v, %v", pol, err)
+	}
+	if u, err := repo.LoadUsage(); err != nil || u.TotalCommands != 7 {
+		t.Errorf("LoadUsage = %+v, %v", u, err)
+	}
+	if r, err := repo.LoadRates(); err != nil || r.Currency != "EUR" {
+		t.Errorf("LoadRates = %+v, %v", r, err)
+	}
+	if e, err := repo.LoadTimeEntries(); err != nil || len(e) != 1 || e[0].TaskID != "t1" {
+		t.Errorf("LoadTimeEntries = %+v, %v", e, err)
+	}
+	if _, err := repo.ResolvePath(SpecFile); err != nil {
+		t.Errorf("expected path helpers from the filesystem repository: %v", err)
+	}
+}
+
+func TestSQLiteRepository_SaveStateConflict(t *testing.T) {
+	repo := newTestSQLiteRepository(t)
+
+	state := planning.NewExecutionState("p1")
+	if err := repo.SaveState(state); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+	stale := *state
+	stale.Version = 0
+	state.SetTaskStatus("t1", planning.StatusInProgress)
+	if err := repo.SaveState(state); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+
+	var conflict *planning.ConflictError
+	if err := repo.SaveState(&stale); !errors.As(err, &conflict) {
+		t.Fatalf("expected a conflict, got %v", err)
+	}
+	if stale.Version != 0 {
+		t.Errorf("a failed save must not bump the version, got %d", stale.Version)
+	}
+
+	loaded, err := repo.LoadState()
+	if err != nil || loaded.Version != 2 || loaded.TaskStates["t1"].Status != planning.StatusInProgress {
+		t.Fatalf("LoadState = %+v, %v", loaded, err)
+	}
+}
+
+func TestSQLiteRepository_AuditEventsAndRevisions(t *testing.T) {
+	repo := newTestSQLiteRepository(t)
+
+	if err := repo.RecordEvent(domain.Event{ID: "e1", Action: "spec.imported", Timestamp: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.RecordEvent(domain.Event{ID: "e2", Action: "plan.approved", Timestamp: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	evts, err := repo.LoadEvents()
+	if err != nil || len(evts) != 2 || evts[1].Action != "plan.approved" {
+		t.Fatalf("LoadEvents = %+v, %v", evts, err)
+	}
+
+	plan := &planning.Plan{ID: "p1", Tasks: []planning.Task{{ID: "t1", Title: "Task"}}}
+	rev := planning.NewPlanRevision(plan, "alice")
+	if err := repo.SavePlanRevision(plan, rev); err != nil {
+		t.Fatalf("SavePlanRevision: %v", err)
+	}
+	if err := repo.SavePlanRevision(plan, rev); err != nil {
+		t.Fatalf("SavePlanRevision again: %v", err)
+	}
+	revisions, err := repo.ListPlanRevisions()
+	if err != nil || len(revisions) != 2 || revisions[0].Hash != rev.Hash {
+		t.Fatalf("ListPlanRevisions = %+v, %v", revisions, err)
+	}
+	loaded, err := repo.LoadPlanRevision(rev.Hash)
+	if err != nil || loaded.RevisionHash() != rev.Hash {
+		t.Fatalf("LoadPlanRevision = %+v, %v", loaded, err)
+	}
+	if _, err := repo.LoadPlanRevision(planning.NewPlanRevision(&planning.Plan{ID: "p2"}, "").Hash); err == nil {
+		t.Error("expected an unknown revision to fail")
+	}
+}
+
+func TestSQLiteEventStore_Queries(t *testing.T) {
+	repo := newTestSQLiteRepository(t)
+	store, err := NewSQLiteEventStore(repo.ProjectBase())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if last, err := store.GetLastEvent(); last != nil || err != nil {
+		t.Fatalf("expected no last event, got %v, %v", last, err)
+	}
+
+	base := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	appendEvent := func(typ, taskID string, at time.Time) {
+		t.Helper()
+		err := store.Append(&events.BaseEvent{
+			Type:           typ,
+			AggregateID_:   taskID,
+			AggregateType_: events.AggregateTypeTask,
+			Timestamp:      at,
+			Actor:          "alice",
+			Metadata:       map[string]interface{}{"task_id": taskID},
+		})
+		if err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	appendEvent(events.EventTypeTaskStarted, "t1", base)
+	appendEvent(events.EventTypeTaskStarted, "t2", base.Add(time.Hour))
+	appendEvent(events.EventTypeTaskCompleted, "t1", base.Add(2*time.Hour))
+
+	if n, err := store.Count(); err != nil || n != 3 {
+		t.Errorf("Count = %d, %v", n, err)
+	}
+	if got, _ := store.LoadByType(events.EventTypeTaskStarted); len(got) != 2 {
+		t.Errorf("LoadByType returned %d events", len(got))
+	}
+	if got, _ := store.LoadByAggregate(events.AggregateTypeTask, "t1"); len(got) != 2 || got[1].Type != events.EventTypeTaskCompleted {
+		t.Errorf("LoadByAggregate = %+v", got)
+	}
+	if got, _ := store.LoadSince(base); len(got) != 2 {
+		t.Errorf("LoadSince returned %d events", len(got))
+	}
+	if got, _ := store.LoadSince(time.Time{}); len(got) != 3 {
+		t.Errorf("LoadSince(zero) returned %d events", len(got))
+	}
+	if got, _ := store.LoadRange(base, base.Add(time.Hour)); len(got) != 2 {
+		t.Errorf("LoadRange returned %d events", len(got))
+	}
+	last, err := store.GetLastEvent()
+	if err != nil || last.Type != events.EventTypeTaskCompleted {
+		t.Fatalf("GetLastEvent = %+v, %v", last, err)
+	}
+
+	all, _ := store.LoadAll()
+	if all[0].PrevHash != "" || all[1].PrevHash != all[0].Hash || all[2].PrevHash != all[1].Hash {
+		t.Error("events are not hash-chained")
+	}
+	if violations, err := store.VerifyIntegrity(); err != nil || len(violations) != 0 {
+		t.Errorf("VerifyIntegrity = %v, %v", violations, err)
+	}
+
+	// Audit events recorded through the repository continue the same chain.
+	if err := repo.RecordEvent(domain.Event{ID: "legacy", Action: "usage.updated", PrevHash: last.Hash}); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := store.Count(); n != 4 {
+		t.Errorf("expected repository events in the same log, got %d", n)
+	}
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+)
+
+// verificationOutputTail is how much of a run's combined output is kept
+// with the run. The digest always covers the full output.
+const verificationOutputTail = 4096
+
+// verificationWaitDelay bounds how long a timed-out run waits for processes
+// it spawned to release its output pipes.
+const verificationWaitDelay = 2 * time.Second
+
+// CommandRunner implements planning.VerificationRunner by executing
+// verification commands from a project directory.
+type CommandRunner struct {
+	root string
+}
+
+// NewCommandRunnerAt returns a runner that executes commands in root.
+func NewCommandRunnerAt(root string) *CommandRunner {
+	return &CommandRunner{root: root}
+}
+
+// Run executes the verification with its timeout and captures the exit
+// status, a digest of the output and the output's tail.
+func (r *CommandRunner) Run(ctx context.Context, v planning.Verification) (planning.VerificationRun, error) {
+	if err := v.Validate(); err != nil {
+		return planning.VerificationRun{}, err
+	}
+	timeout, _ := v.TimeoutDuration()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	args := v.Args()
+	// #nosec G204 -- the command is declared in the project's own plan and run on request
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Dir = r.root
+	cmd.WaitDelay = verificationWaitDelay
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	start := time.Now()
+	err := cmd.Run()
+	run := planning.VerificationRun{
+		Command:    v.String(),
+		DurationMS: time.Since(start).Milliseconds(),
+		RanAt:      start,
+	}
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		run.TimedOut = true
+		run.ExitCode = -1
+	case errors.As(err, &exitErr):
+		run.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		return planning.VerificationRun{}, fmt.Errorf("run %s: %w", args[0], err)
+	}
+
+	sum := sha256.Sum256(out.Bytes())
+	run.Digest = hex.EncodeToString(sum[:])
+	run.Output = outputTail(out.String(), verificationOutputTail)
+	return run, nil
+}
+
+// outputTail returns at most limit bytes from the end of output, starting
+// at a line boundary when one is available.
+func outputTail(output string, limit int) string {
+	output = strings.TrimRight(output, "\n")
+	if len(output) <= limit {
+		return output
+	}
+	tail := output[len(output)-limit:]
+	if i := strings.IndexByte(tail, '\n'); i >= 0 && i < len(tail)-1 {
+		tail = tail[i+1:]
+	}
+	return "…\n" + tail
+}
+
+var _ planning.VerificationRunner = (*CommandRunner)(nil)
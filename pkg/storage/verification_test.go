@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/felixgeelhaar/roady/pkg/domain/planning"
+)
+
+func TestCommandRunner_Run(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	dir := t.TempDir()
+	writeFile(t, dir, "marker.txt", "present")
+	runner := NewCommandRunnerAt(dir)
+	ctx := context.Background()
+
+	run, err := runner.Run(ctx, planning.Verification{Command: "cat marker.txt"})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !run.Passed() || run.Output != "present" || len(run.Digest) != 64 || run.Command != "cat marker.txt" {
+		t.Errorf("unexpected passing run: %+v", run)
+	}
+
+	run, err = runner.Run(ctx, planning.Verification{Command: "echo boom >&2; exit 3"})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if run.Passed() || run.ExitCode != 3 || run.Output != "boom" {
+		t.Errorf("unexpected failing run: %+v", run)
+	}
+
+	run, err = runner.Run(ctx, planning.Verification{Command: "exec sleep 5", Timeout: "100ms"})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if run.Passed() || !run.TimedOut || run.Duration().Seconds() >= 5 {
+		t.Errorf("expected a timed-out run: %+v", run)
+	}
+
+	if _, err := runner.Run(ctx, planning.Verification{}); err == nil {
+		t.Error("expected an error for an empty verification")
+	}
+}
+
+func TestOutputTail(t *testing.T) {
+	if got := outputTail("short\n", 100); got != "short" {
+		t.Errorf("outputTail = %q", got)
+	}
+	long := strings.Repeat("line of output\n", 20)
+	got := outputTail(long, 40)
+	if !strings.HasPrefix(got, "…\nline of output") || len(got) > 45 {
+		t.Errorf("outputTail = %q", got)
+	}
+}